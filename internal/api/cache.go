@@ -0,0 +1,117 @@
+package api
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"hawkeye-cli/internal/config"
+)
+
+// ─── Response cache ─────────────────────────────────────────────────────────
+//
+// Lists that rarely change (projects, connections, resources, the prompt
+// library) are fetched with If-None-Match once a response carrying an ETag
+// has been seen. A 304 Not Modified is answered from the cache, so the body
+// is only transferred when it changed. Entries are kept in memory for the
+// process and on disk under ~/.hawkeye/cache/<profile> across runs. Every
+// request still reaches the server, so the cache never serves stale data.
+
+// cacheablePrefixes are the GET paths whose responses are cached.
+var cacheablePrefixes = []string{
+	"/v1/project",
+	"/v1/connection",
+	"/v1/resource",
+	"/v1/inference/prompt-library",
+}
+
+// cacheDisabled turns the cache off for the process, set by --no-cache.
+var cacheDisabled bool
+
+// SetCacheDisabled bypasses the response cache for the rest of the process:
+// nothing is read from or written to it.
+func SetCacheDisabled(on bool) { cacheDisabled = on }
+
+// cacheEntry is a cached response body and the ETag it was served with.
+type cacheEntry struct {
+	ETag string          `json:"etag"`
+	Body json.RawMessage `json:"body"`
+}
+
+// responseCache stores entries for one profile. dir may be empty for a
+// memory-only cache.
+type responseCache struct {
+	dir string
+}
+
+var (
+	memCacheMu sync.Mutex
+	memCache   = map[string]cacheEntry{}
+)
+
+// cacheable reports whether a GET of path may be cached.
+func cacheable(path string) bool {
+	p, _, _ := strings.Cut(path, "?")
+	for _, prefix := range cacheablePrefixes {
+		if p == prefix || strings.HasPrefix(p, prefix+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// key identifies a response by profile directory, organization and URL.
+func (rc *responseCache) key(org, url string) string {
+	sum := sha256.Sum256([]byte(rc.dir + "\x00" + org + "\x00" + url))
+	return hex.EncodeToString(sum[:16])
+}
+
+func (rc *responseCache) get(key string) (cacheEntry, bool) {
+	memCacheMu.Lock()
+	e, ok := memCache[key]
+	memCacheMu.Unlock()
+	if ok || rc.dir == "" {
+		return e, ok
+	}
+	data, err := os.ReadFile(filepath.Join(rc.dir, key+".json"))
+	if err != nil || json.Unmarshal(data, &e) != nil || e.ETag == "" {
+		return cacheEntry{}, false
+	}
+	memCacheMu.Lock()
+	memCache[key] = e
+	memCacheMu.Unlock()
+	return e, true
+}
+
+// put stores an entry. Failing to write the disk copy is not an error: the
+// next run simply fetches the full body again.
+func (rc *responseCache) put(key string, e cacheEntry) {
+	memCacheMu.Lock()
+	memCache[key] = e
+	memCacheMu.Unlock()
+	if rc.dir == "" {
+		return
+	}
+	data, err := json.Marshal(e)
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(rc.dir, 0700); err != nil {
+		return
+	}
+	_ = os.WriteFile(filepath.Join(rc.dir, key+".json"), data, 0600)
+}
+
+// newResponseCache returns the cache for a profile, memory-only when the
+// cache directory cannot be determined.
+func newResponseCache(profile string) *responseCache {
+	dir, err := config.CacheDir(profile)
+	if err != nil {
+		dir = ""
+	}
+	return &responseCache{dir: dir}
+}
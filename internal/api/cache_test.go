@@ -0,0 +1,77 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCacheable(t *testing.T) {
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{"/v1/project", true},
+		{"/v1/connection?project_uuid=p", true},
+		{"/v1/connection/c-1", true},
+		{"/v1/resource?connection_uuid=c", true},
+		{"/v1/inference/prompt-library?project_uuid=p", true},
+		{"/v1/projects", false},
+		{"/v1/inference/session/summary/s-1", false},
+		{"/v1/user", false},
+	}
+	for _, tt := range tests {
+		if got := cacheable(tt.path); got != tt.want {
+			t.Errorf("cacheable(%q) = %v, want %v", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestDoJSONETagCache(t *testing.T) {
+	var requests, notModified int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			notModified++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		_, _ = fmt.Fprint(w, `{"name":"cached"}`)
+	}))
+	defer srv.Close()
+
+	dir := t.TempDir()
+	fetch := func() string {
+		c := &Client{baseURL: srv.URL, httpClient: srv.Client(), cache: &responseCache{dir: dir}}
+		var result struct{ Name string }
+		if err := c.doJSON("GET", "/v1/project", nil, &result); err != nil {
+			t.Fatalf("doJSON() error = %v", err)
+		}
+		return result.Name
+	}
+
+	for i := 0; i < 2; i++ {
+		if got := fetch(); got != "cached" {
+			t.Fatalf("fetch %d: Name = %q, want cached", i, got)
+		}
+	}
+	if requests != 2 || notModified != 1 {
+		t.Errorf("requests = %d, not modified = %d; want 2 and 1", requests, notModified)
+	}
+
+	// A fresh process only has the disk copy.
+	memCacheMu.Lock()
+	memCache = map[string]cacheEntry{}
+	memCacheMu.Unlock()
+	if got := fetch(); got != "cached" || notModified != 2 {
+		t.Errorf("after clearing memory: Name = %q, not modified = %d", got, notModified)
+	}
+
+	SetCacheDisabled(true)
+	defer SetCacheDisabled(false)
+	if got := fetch(); got != "cached" || notModified != 2 {
+		t.Errorf("with cache disabled: Name = %q, not modified = %d; want a full fetch", got, notModified)
+	}
+}
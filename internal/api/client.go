@@ -3,8 +3,10 @@ package api
 import (
 	"bufio"
 	"bytes"
+	"context"
 	"crypto/rand"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -21,7 +23,28 @@ type Client struct {
 	httpClient *http.Client
 	token      string
 	orgUUID    string
+	language   string // preferred response language, e.g. "ja"
 	debug      bool
+	cache      *responseCache // nil for clients without a profile
+
+	timeout     time.Duration // per API call; 0 for none
+	idleTimeout time.Duration // prompt stream without data; 0 for none
+}
+
+// orgOverride replaces the profile's organization in every client, set by
+// the global --org flag.
+var orgOverride string
+
+// SetOrg makes every client created from now on act in org instead of the
+// profile's organization, for the rest of the process.
+func SetOrg(org string) { orgOverride = org }
+
+// EffectiveOrg returns the organization clients created from cfg act in.
+func EffectiveOrg(cfg *config.Config) string {
+	if orgOverride != "" {
+		return orgOverride
+	}
+	return cfg.OrgUUID
 }
 
 func NewClient(cfg *config.Config) *Client {
@@ -29,17 +52,28 @@ func NewClient(cfg *config.Config) *Client {
 		baseURL: strings.TrimRight(cfg.Server, "/"),
 		httpClient: &http.Client{
 			// No timeout on the client — investigations can take 30+ minutes.
-			// We rely on the server closing the SSE stream (end_turn) to finish.
-			Timeout: 0,
+			// API calls and streams are limited per request instead; see
+			// timeouts.go.
+			Timeout:   0,
+			Transport: NewTransport(cfg),
 		},
-		token:   cfg.Token,
-		orgUUID: cfg.OrgUUID,
+		token:       cfg.Token,
+		orgUUID:     EffectiveOrg(cfg),
+		language:    cfg.Language,
+		cache:       newResponseCache(cfg.Profile),
+		timeout:     RequestTimeout(cfg),
+		idleTimeout: StreamIdleTimeout(cfg),
 	}
 }
 
-// SetDebug enables debug output for SSE parsing.
+// SetDebug enables debug output for SSE parsing, ending each prompt stream
+// with its StreamStats.
 func (c *Client) SetDebug(on bool) { c.debug = on }
 
+// SetLanguage sets the language final answers and summaries are requested
+// in. An empty code leaves it to the server.
+func (c *Client) SetLanguage(code string) { c.language = code }
+
 func (c *Client) setHeaders(req *http.Request, hasBody bool) {
 	if hasBody {
 		req.Header.Set("Content-Type", "application/json")
@@ -84,10 +118,14 @@ type UserInfoResponse struct {
 func NewClientWithServer(server string) *Client {
 	return &Client{
 		baseURL:    strings.TrimRight(server, "/"),
-		httpClient: &http.Client{Timeout: 30 * time.Second},
+		httpClient: &http.Client{Timeout: 30 * time.Second, Transport: NewTransport(nil)},
 	}
 }
 
+// SetTransport applies cfg's proxy and TLS settings, for clients created
+// with NewClientWithServer.
+func (c *Client) SetTransport(cfg *config.Config) { c.httpClient.Transport = NewTransport(cfg) }
+
 // NormalizeBackendURL normalizes a URL to be used as the backend API endpoint.
 // It strips trailing slashes and removes any /api suffix to get the base URL,
 // then appends /api to ensure a consistent backend endpoint.
@@ -157,6 +195,53 @@ func (c *Client) FetchUserInfo() (*UserSpec, error) {
 	return &resp.Specs[0], nil
 }
 
+// OrgSpec is an organization the current user belongs to.
+type OrgSpec struct {
+	UUID string `json:"uuid"`
+	Name string `json:"name,omitempty"`
+	Role string `json:"user_role,omitempty"`
+}
+
+type ListOrganizationsResponse struct {
+	Response *GenDBResponse `json:"response,omitempty"`
+	Specs    []OrgSpec      `json:"specs,omitempty"`
+}
+
+// ListOrganizations returns the organizations the user is a member of.
+// Servers without the organizations endpoint fall back to the memberships
+// listed on the user record, which carry UUIDs and roles but no names.
+func (c *Client) ListOrganizations() ([]OrgSpec, error) {
+	var resp ListOrganizationsResponse
+	err := c.doJSON("GET", "/v1/user/organizations", nil, &resp)
+	if err == nil && resp.Response != nil && resp.Response.ErrorCode != 0 {
+		err = fmt.Errorf("server error: %s", resp.Response.ErrorMessage)
+	}
+	if err == nil && len(resp.Specs) > 0 {
+		return resp.Specs, nil
+	}
+
+	var user UserInfoResponse
+	if uerr := c.doJSON("GET", "/v1/user", nil, &user); uerr != nil {
+		if err != nil {
+			return nil, err
+		}
+		return nil, uerr
+	}
+	var orgs []OrgSpec
+	seen := map[string]bool{}
+	for _, u := range user.Specs {
+		if u.OrgUUID == "" || seen[u.OrgUUID] {
+			continue
+		}
+		seen[u.OrgUUID] = true
+		orgs = append(orgs, OrgSpec{UUID: u.OrgUUID, Role: u.UserRole})
+	}
+	if len(orgs) == 0 {
+		return nil, fmt.Errorf("no organizations returned")
+	}
+	return orgs, nil
+}
+
 // --- Session Management ---
 
 type GenDBRequest struct {
@@ -258,7 +343,8 @@ type ProcessPromptRequest struct {
 }
 
 type PromptOptions struct {
-	DisableReplay bool `json:"disable_replay,omitempty"`
+	DisableReplay bool   `json:"disable_replay,omitempty"`
+	Language      string `json:"language,omitempty"` // BCP 47 code for answers and summaries
 }
 
 type ProcessPromptResponse struct {
@@ -277,6 +363,22 @@ type ProcessPromptResponse struct {
 type StreamCallback func(resp *ProcessPromptResponse)
 
 func (c *Client) ProcessPromptStream(projectUUID, sessionUUID, prompt string, cb StreamCallback) error {
+	return c.ProcessPromptStreamWithContext(projectUUID, sessionUUID, prompt, nil, cb)
+}
+
+// ProcessPromptStreamWithContext is ProcessPromptStream with additional
+// context parts (e.g. locally gathered kubectl output) sent after the prompt
+// in the same chat message.
+func (c *Client) ProcessPromptStreamWithContext(projectUUID, sessionUUID, prompt string, contextParts []string, cb StreamCallback) (err error) {
+	ctx, span := StartSpan(context.Background(), "hawkeye.prompt_stream")
+	span.SetAttr("hawkeye.project_uuid", projectUUID)
+	span.SetAttr("hawkeye.session_uuid", sessionUUID)
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
+
+	parts := append([]string{prompt}, contextParts...)
 	reqBody := ProcessPromptRequest{
 		Request:     &GenDBRequest{ClientIdentifier: "hawkeye-cli", UUID: c.orgUUID},
 		Action:      "ACTION_NEXT",
@@ -286,25 +388,45 @@ func (c *Client) ProcessPromptStream(projectUUID, sessionUUID, prompt string, cb
 			{
 				Content: &Content{
 					ContentType: "CONTENT_TYPE_CHAT_PROMPT",
-					Parts:       []string{prompt},
+					Parts:       parts,
 				},
 			},
 		},
 	}
+	if c.language != "" {
+		reqBody.PromptOptions = &PromptOptions{Language: c.language}
+	}
 
 	body, err := json.Marshal(reqBody)
 	if err != nil {
 		return fmt.Errorf("marshaling request: %w", err)
 	}
 
-	req, err := http.NewRequest("POST", c.baseURL+"/v1/inference/session", bytes.NewReader(body))
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	idle := newIdleWatch(c.idleTimeout, cancel)
+	defer idle.stop()
+
+	req, err := http.NewRequestWithContext(ctx, "POST", c.baseURL+"/v1/inference/session", bytes.NewReader(body))
 	if err != nil {
 		return fmt.Errorf("creating request: %w", err)
 	}
 	c.setHeaders(req, true)
 
+	stats := newStreamStats(time.Now())
+	endTurn := false
+	if c.debug {
+		defer func() {
+			stats.finish(err, endTurn, time.Now())
+			stats.Write(os.Stderr)
+		}()
+	}
+
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
+		if idleErr := idle.err(); idleErr != nil {
+			return idleErr
+		}
 		return fmt.Errorf("sending request: %w", err)
 	}
 	defer resp.Body.Close()
@@ -318,7 +440,7 @@ func (c *Client) ProcessPromptStream(projectUUID, sessionUUID, prompt string, cb
 		fmt.Fprintf(os.Stderr, "[DEBUG] Content-Type: %s\n", resp.Header.Get("Content-Type"))
 	}
 
-	scanner := bufio.NewScanner(resp.Body)
+	scanner := bufio.NewScanner(idle.reader(countingReader{resp.Body, &stats.Bytes}))
 	// 1 MB buffer for large streamed chunks (chain-of-thought can be huge)
 	scanner.Buffer(make([]byte, 0, 1024*1024), 1024*1024)
 
@@ -378,16 +500,20 @@ func (c *Client) ProcessPromptStream(projectUUID, sessionUUID, prompt string, cb
 			}
 			if err2 := json.Unmarshal([]byte(jsonStr), &envelope); err2 == nil && envelope.Result != nil {
 				envelope.Result.EventType = currentEventType
+				traceStreamEvent(span, envelope.Result)
+				stats.event(envelope.Result, time.Now())
 				cb(envelope.Result)
 				if c.debug && envelope.Result.Message != nil && envelope.Result.Message.Content != nil {
 					c.debugLog(currentEventType, envelope.Result)
 				}
 				if envelope.Result.Message != nil && envelope.Result.Message.EndTurn {
+					endTurn = true
 					return nil
 				}
 				continue
 			}
 			// Skip unparseable lines
+			stats.ParseFailures++
 			if c.debug {
 				snippet := jsonStr
 				if len(snippet) > 80 {
@@ -399,18 +525,39 @@ func (c *Client) ProcessPromptStream(projectUUID, sessionUUID, prompt string, cb
 		}
 
 		streamResp.EventType = currentEventType
+		traceStreamEvent(span, &streamResp)
+		stats.event(&streamResp, time.Now())
 		cb(&streamResp)
 		if c.debug && streamResp.Message != nil && streamResp.Message.Content != nil {
 			c.debugLog(currentEventType, &streamResp)
 		}
 		if streamResp.Message != nil && streamResp.Message.EndTurn {
+			endTurn = true
 			return nil
 		}
 	}
 
+	if idleErr := idle.err(); idleErr != nil {
+		return idleErr
+	}
 	return scanner.Err()
 }
 
+// traceStreamEvent annotates the stream's span with one SSE event.
+func traceStreamEvent(span *Span, resp *ProcessPromptResponse) {
+	if span == nil {
+		return
+	}
+	kv := []any{"sse.event", resp.EventType}
+	if resp.Message != nil && resp.Message.Content != nil {
+		kv = append(kv, "hawkeye.content_type", resp.Message.Content.ContentType)
+	}
+	if resp.Message != nil && resp.Message.EndTurn {
+		kv = append(kv, "hawkeye.end_turn", true)
+	}
+	span.AddEvent("sse."+resp.EventType, kv...)
+}
+
 // debugLog prints a compact debug line for an SSE event.
 func (c *Client) debugLog(eventType string, resp *ProcessPromptResponse) {
 	ct := resp.Message.Content.ContentType
@@ -493,6 +640,48 @@ func (c *Client) SessionList(projectUUID string, start, limit int, filters []Pag
 	return &resp, nil
 }
 
+// RenameSessionRequest holds the body for PATCH /v1/inference/session/{uuid}.
+type RenameSessionRequest struct {
+	Request     *GenDBRequest `json:"request,omitempty"`
+	ProjectUUID string        `json:"project_uuid,omitempty"`
+	Name        string        `json:"name"`
+}
+
+// RenameSession sets the display name of a session.
+func (c *Client) RenameSession(projectUUID, sessionUUID, name string) error {
+	reqBody := RenameSessionRequest{
+		Request:     &GenDBRequest{ClientIdentifier: "hawkeye-cli", UUID: c.orgUUID},
+		ProjectUUID: projectUUID,
+		Name:        name,
+	}
+	var resp struct {
+		Response *GenDBResponse `json:"response,omitempty"`
+	}
+	if err := c.doJSON("PATCH", "/v1/inference/session/"+sessionUUID, reqBody, &resp); err != nil {
+		return err
+	}
+	if resp.Response != nil && resp.Response.ErrorCode != 0 {
+		return fmt.Errorf("server error: %s", resp.Response.ErrorMessage)
+	}
+	return nil
+}
+
+// DeleteSession deletes a session. Servers without session deletion
+// answer 404 or 405.
+func (c *Client) DeleteSession(projectUUID, sessionUUID string) error {
+	var resp struct {
+		Response *GenDBResponse `json:"response,omitempty"`
+	}
+	path := "/v1/inference/session/" + sessionUUID + "?project_uuid=" + url.QueryEscape(projectUUID)
+	if err := c.doJSON("DELETE", path, nil, &resp); err != nil {
+		return err
+	}
+	if resp.Response != nil && resp.Response.ErrorCode != 0 {
+		return fmt.Errorf("server error: %s", resp.Response.ErrorMessage)
+	}
+	return nil
+}
+
 // --- Session Inspect ---
 
 type PromptCycle struct {
@@ -500,6 +689,7 @@ type PromptCycle struct {
 	CreateTime          string                `json:"create_time"`
 	FinalAnswer         string                `json:"final_answer"`
 	Rating              string                `json:"rating"`
+	RatingReason        string                `json:"rating_reason,omitempty"`
 	FollowUpSuggestions []string              `json:"follow_up_suggestions"`
 	Sources             []Source              `json:"sources"`
 	ChainOfThoughts     []ChainOfThought      `json:"chain_of_thoughts"`
@@ -752,6 +942,59 @@ func (c *Client) PromptLibrary(projectUUID string) (*PromptLibraryResponse, erro
 	return &resp, nil
 }
 
+// SavePromptRequest holds the body for adding a prompt to the library
+// (POST /v1/inference/prompt-library) or editing one
+// (PUT /v1/inference/prompt-library/{uuid}).
+type SavePromptRequest struct {
+	Request     *GenDBRequest `json:"request,omitempty"`
+	ProjectUUID string        `json:"project_uuid"`
+	Item        InitialPrompt `json:"item"`
+}
+
+// SavePromptResponse holds the saved library prompt.
+type SavePromptResponse struct {
+	Response *GenDBResponse `json:"response,omitempty"`
+	Item     *InitialPrompt `json:"item,omitempty"`
+}
+
+// SavePrompt adds p to the project's prompt library, or replaces the
+// prompt with p.UUID when it is set.
+func (c *Client) SavePrompt(projectUUID string, p InitialPrompt) (*SavePromptResponse, error) {
+	reqBody := SavePromptRequest{
+		Request:     &GenDBRequest{ClientIdentifier: "hawkeye-cli", RequestID: newUUID()},
+		ProjectUUID: projectUUID,
+		Item:        p,
+	}
+	method, path := "POST", "/v1/inference/prompt-library"
+	if p.UUID != "" {
+		method, path = "PUT", path+"/"+url.PathEscape(p.UUID)
+	}
+	var resp SavePromptResponse
+	if err := c.doJSON(method, path, reqBody, &resp); err != nil {
+		return nil, err
+	}
+	if resp.Response != nil && resp.Response.ErrorCode != 0 {
+		return nil, fmt.Errorf("server error: %s", resp.Response.ErrorMessage)
+	}
+	return &resp, nil
+}
+
+// DeletePrompt removes a prompt from the project's library.
+func (c *Client) DeletePrompt(projectUUID, promptUUID string) error {
+	params := url.Values{}
+	params.Set("project_uuid", projectUUID)
+	var resp struct {
+		Response *GenDBResponse `json:"response,omitempty"`
+	}
+	if err := c.doJSON("DELETE", "/v1/inference/prompt-library/"+url.PathEscape(promptUUID)+"?"+params.Encode(), nil, &resp); err != nil {
+		return err
+	}
+	if resp.Response != nil && resp.Response.ErrorCode != 0 {
+		return fmt.Errorf("server error: %s", resp.Response.ErrorMessage)
+	}
+	return nil
+}
+
 // --- Rating / Feedback ---
 
 type RatingItemID struct {
@@ -831,6 +1074,88 @@ func (c *Client) GetIncidentReport() (*IncidentReportResponse, error) {
 	return &resp, nil
 }
 
+// GetIncidentReportRange returns the analytics for incidents between start
+// and end. Servers without time-bucketed reports ignore the range and
+// answer with their default period, which the response's StartTime and
+// EndTime show.
+func (c *Client) GetIncidentReportRange(start, end time.Time) (*IncidentReportResponse, error) {
+	params := url.Values{}
+	params.Set("start_time", start.UTC().Format(time.RFC3339))
+	params.Set("end_time", end.UTC().Format(time.RFC3339))
+	var resp IncidentReportResponse
+	if err := c.doJSON("GET", "/v1/inference/incident_report?"+params.Encode(), nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// --- Incident groups ---
+
+// IncidentGroup is a set of alerts the platform grouped as one incident,
+// investigated once through its representative session.
+type IncidentGroup struct {
+	GroupID     string       `json:"group_id"`
+	Title       string       `json:"title"`
+	Priority    string       `json:"priority,omitempty"`
+	Status      string       `json:"status,omitempty"`
+	CreateTime  string       `json:"create_time,omitempty"`
+	LastUpdate  string       `json:"last_update,omitempty"`
+	AlertCount  int          `json:"alert_count"`
+	SessionUUID string       `json:"session_uuid,omitempty"` // representative investigation
+	Alerts      []GroupAlert `json:"alerts,omitempty"`       // filled in by GetIncidentGroup
+}
+
+// GroupAlert is one alert in an incident group.
+type GroupAlert struct {
+	AlertID    string `json:"alert_id"`
+	Title      string `json:"title"`
+	Source     string `json:"source,omitempty"`
+	Priority   string `json:"priority,omitempty"`
+	CreateTime string `json:"create_time,omitempty"`
+}
+
+type ListIncidentGroupsResponse struct {
+	Response *GenDBResponse  `json:"response,omitempty"`
+	Groups   []IncidentGroup `json:"groups,omitempty"`
+}
+
+type GetIncidentGroupResponse struct {
+	Response *GenDBResponse `json:"response,omitempty"`
+	Group    *IncidentGroup `json:"group,omitempty"`
+}
+
+// ListIncidentGroups returns the project's incident groups, without their
+// member alerts.
+func (c *Client) ListIncidentGroups(projectUUID string) (*ListIncidentGroupsResponse, error) {
+	params := url.Values{}
+	params.Set("project_uuid", projectUUID)
+	var resp ListIncidentGroupsResponse
+	if err := c.doJSON("GET", "/v1/inference/incident_group?"+params.Encode(), nil, &resp); err != nil {
+		return nil, err
+	}
+	if resp.Response != nil && resp.Response.ErrorCode != 0 {
+		return nil, fmt.Errorf("server error: %s", resp.Response.ErrorMessage)
+	}
+	return &resp, nil
+}
+
+// GetIncidentGroup returns one incident group with its member alerts.
+func (c *Client) GetIncidentGroup(projectUUID, groupID string) (*IncidentGroup, error) {
+	params := url.Values{}
+	params.Set("project_uuid", projectUUID)
+	var resp GetIncidentGroupResponse
+	if err := c.doJSON("GET", "/v1/inference/incident_group/"+url.PathEscape(groupID)+"?"+params.Encode(), nil, &resp); err != nil {
+		return nil, err
+	}
+	if resp.Response != nil && resp.Response.ErrorCode != 0 {
+		return nil, fmt.Errorf("server error: %s", resp.Response.ErrorMessage)
+	}
+	if resp.Group == nil {
+		return nil, fmt.Errorf("incident group %s not found", groupID)
+	}
+	return resp.Group, nil
+}
+
 // --- Connections ---
 
 // ConnectionSpec describes a data source connection.
@@ -914,6 +1239,34 @@ func (c *Client) CreateConnection(name, connType string, connConfig map[string]s
 	return &resp, nil
 }
 
+// UpdateConnectionConfigRequest holds the body for PATCH /v1/datasource/connection/{uuid}.
+type UpdateConnectionConfigRequest struct {
+	Request *GenDBRequest     `json:"request,omitempty"`
+	Config  map[string]string `json:"config"`
+}
+
+// UpdateConnectionConfig changes the given config keys of a connection in
+// place and leaves the others as they are. The connection keeps its UUID,
+// resources and training; the server re-validates it, which shows as a
+// new sync.
+func (c *Client) UpdateConnectionConfig(connUUID string, connConfig map[string]string) (*GetConnectionResponse, error) {
+	reqBody := UpdateConnectionConfigRequest{
+		Request: &GenDBRequest{ClientIdentifier: "hawkeye-cli", UUID: c.orgUUID},
+		Config:  connConfig,
+	}
+	var resp GetConnectionResponse
+	if err := c.doJSON("PATCH", "/v1/datasource/connection/"+connUUID, reqBody, &resp); err != nil {
+		return nil, err
+	}
+	if resp.Response != nil && resp.Response.ErrorCode != 0 {
+		return nil, fmt.Errorf("server error: %s", resp.Response.ErrorMessage)
+	}
+	return &resp, nil
+}
+
+// connectionPollInterval is how often the connection waiters poll.
+var connectionPollInterval = 5 * time.Second
+
 func (c *Client) WaitForConnectionSync(connUUID string, timeoutSeconds int) (*GetConnectionResponse, error) {
 	deadline := time.Now().Add(time.Duration(timeoutSeconds) * time.Second)
 	for time.Now().Before(deadline) {
@@ -930,11 +1283,56 @@ func (c *Client) WaitForConnectionSync(connUUID string, timeoutSeconds int) (*Ge
 				return resp, fmt.Errorf("sync failed for connection %s", connUUID)
 			}
 		}
-		time.Sleep(5 * time.Second)
+		time.Sleep(connectionPollInterval)
 	}
 	return nil, fmt.Errorf("sync timed out after %d seconds", timeoutSeconds)
 }
 
+// TrainConnectionRequest holds the body for POST /v1/connection/{uuid}/train.
+type TrainConnectionRequest struct {
+	Request *GenDBRequest `json:"request,omitempty"`
+}
+
+// TrainConnection (re)triggers training for a connection.
+func (c *Client) TrainConnection(connUUID string) error {
+	reqBody := TrainConnectionRequest{
+		Request: &GenDBRequest{ClientIdentifier: "hawkeye-cli", UUID: c.orgUUID},
+	}
+	var resp struct {
+		Response *GenDBResponse `json:"response,omitempty"`
+	}
+	if err := c.doJSON("POST", "/v1/connection/"+connUUID+"/train", reqBody, &resp); err != nil {
+		return err
+	}
+	if resp.Response != nil && resp.Response.ErrorCode != 0 {
+		return fmt.Errorf("server error: %s", resp.Response.ErrorMessage)
+	}
+	return nil
+}
+
+// WaitForConnectionTraining polls a connection until training finishes,
+// like WaitForConnectionSync does for sync.
+func (c *Client) WaitForConnectionTraining(connUUID string, timeoutSeconds int) (*GetConnectionResponse, error) {
+	deadline := time.Now().Add(time.Duration(timeoutSeconds) * time.Second)
+	for time.Now().Before(deadline) {
+		resp, err := c.GetConnectionInfo(connUUID)
+		if err != nil {
+			return nil, err
+		}
+		if resp.Spec != nil {
+			state := resp.Spec.TrainingState
+			if state == "TRAINED" || state == "TRAINING_STATE_TRAINED" {
+				return resp, nil
+			}
+			if state == "TRAINING_STATE_FAILED" || state == "FAILED" {
+				return resp, fmt.Errorf("training failed for connection %s", connUUID)
+			}
+		}
+		time.Sleep(connectionPollInterval)
+	}
+	return nil, fmt.Errorf("training timed out after %d seconds", timeoutSeconds)
+}
+
 // AddConnectionToProjectRequest holds the body for adding a connection to a project.
 type AddConnectionToProjectRequest struct {
 	Request        *GenDBRequest `json:"request,omitempty"`
@@ -1066,13 +1464,35 @@ type ResourceSpec struct {
 
 // ListResourcesResponse holds the list of resources.
 type ListResourcesResponse struct {
-	Specs []ResourceSpec `json:"specs"`
+	Specs      []ResourceSpec      `json:"specs"`
+	Pagination *ResourcePagination `json:"pagination,omitempty"`
+}
+
+// ResourcePagination is the paging state servers with cursor paging return
+// with a page of resources. Servers without it are paged by offset.
+type ResourcePagination struct {
+	NextPageToken string `json:"next_page_token,omitempty"`
 }
 
+// ListConnectionResources returns the first page of up to limit resources
+// of a connection. Use ListAllConnectionResources to read past it.
 func (c *Client) ListConnectionResources(connectionUUID string, limit int) (*ListResourcesResponse, error) {
+	return c.ListConnectionResourcesPage(connectionUUID, 0, limit, "")
+}
+
+// ListConnectionResourcesPage returns one page of a connection's
+// resources: from pageToken when the server returned one for the previous
+// page, else from offset start.
+func (c *Client) ListConnectionResourcesPage(connectionUUID string, start, limit int, pageToken string) (*ListResourcesResponse, error) {
 	params := url.Values{}
 	params.Set("connection_uuid", connectionUUID)
 	params.Set("pagination.limit", fmt.Sprintf("%d", limit))
+	switch {
+	case pageToken != "":
+		params.Set("pagination.page_token", pageToken)
+	case start > 0:
+		params.Set("pagination.start", fmt.Sprintf("%d", start))
+	}
 	var resp ListResourcesResponse
 	if err := c.doJSON("GET", "/v1/resource?"+params.Encode(), nil, &resp); err != nil {
 		return nil, err
@@ -1080,6 +1500,94 @@ func (c *Client) ListConnectionResources(connectionUUID string, limit int) (*Lis
 	return &resp, nil
 }
 
+// resourcePageSize is how many resources ListAllConnectionResources asks
+// for per request.
+var resourcePageSize = 500
+
+// ListAllConnectionResources pages through a connection's resources until
+// the server has no more, or max of them have been read when max > 0. It
+// reports whether resources were left out because of max. progress, if
+// set, is called with the running count after every page.
+func (c *Client) ListAllConnectionResources(connectionUUID string, max int, progress func(read int)) ([]ResourceSpec, bool, error) {
+	var all []ResourceSpec
+	var token, firstOfLast string
+	cursor := false // the server pages by token rather than by offset
+	for {
+		limit := resourcePageSize
+		if max > 0 {
+			limit = min(limit, max-len(all)+1) // one more tells whether any were left out
+		}
+		resp, err := c.ListConnectionResourcesPage(connectionUUID, len(all), limit, token)
+		if err != nil {
+			return all, false, err
+		}
+		page := resp.Specs
+		// A server that ignores the offset serves the first page again.
+		if len(page) == 0 || (token == "" && page[0].ID.UUID != "" && page[0].ID.UUID == firstOfLast) {
+			break
+		}
+		firstOfLast = page[0].ID.UUID
+		all = append(all, page...)
+		if progress != nil {
+			progress(len(all))
+		}
+		if max > 0 && len(all) > max {
+			return all[:max], true, nil
+		}
+
+		// A short page does not mean the end, as servers cap the page
+		// size; with cursor paging the last page is the one without a
+		// token, otherwise it is the first empty one.
+		next := ""
+		if resp.Pagination != nil {
+			next = resp.Pagination.NextPageToken
+		}
+		if next == "" && cursor {
+			break
+		}
+		cursor = cursor || next != ""
+		token = next
+	}
+	return all, false, nil
+}
+
+// ResourceTypeSpec is one resource type the server supports for a
+// connection type and telemetry type.
+type ResourceTypeSpec struct {
+	ConnectionType string   `json:"connection_type"`
+	TelemetryType  string   `json:"telemetry_type"`
+	Type           string   `json:"type"`
+	Description    string   `json:"description,omitempty"`
+	Capabilities   []string `json:"capabilities,omitempty"` // e.g. "discover", "query"
+}
+
+// ListResourceTypesResponse holds the server's resource type matrix.
+type ListResourceTypesResponse struct {
+	ResourceTypes []ResourceTypeSpec `json:"resource_types"`
+}
+
+// ListResourceTypes fetches the resource types the server supports,
+// optionally narrowed to a connection type and telemetry type. Servers
+// that predate the endpoint return an error.
+func (c *Client) ListResourceTypes(connectionType, telemetryType string) (*ListResourceTypesResponse, error) {
+	params := url.Values{}
+	if connectionType != "" {
+		params.Set("connection_type", connectionType)
+	}
+	if telemetryType != "" {
+		params.Set("telemetry_type", telemetryType)
+	}
+	path := "/v1/resource/types"
+	if len(params) > 0 {
+		path += "?" + params.Encode()
+	}
+	var resp ListResourceTypesResponse
+	if err := c.doJSON("GET", path, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
 // --- Discovery ---
 
 // DiscoverResourcesResponse holds the response for resource discovery.
@@ -1108,11 +1616,11 @@ func (c *Client) DiscoverProjectResources(projectUUID, telemetryType, connection
 		if connectionType != "" && conn.Type != connectionType {
 			continue
 		}
-		resResp, err := c.ListConnectionResources(conn.UUID, 100)
+		specs, _, err := c.ListAllConnectionResources(conn.UUID, 0, nil)
 		if err != nil {
 			continue // skip connections with errors
 		}
-		for _, r := range resResp.Specs {
+		for _, r := range specs {
 			if telemetryType != "" && r.TelemetryType != telemetryType {
 				continue
 			}
@@ -1298,6 +1806,35 @@ func (c *Client) UpdateInstructionStatus(instrUUID string, enabled bool) error {
 	return nil
 }
 
+// UpdateInstructionRequest holds the body for editing an instruction via
+// PUT /v1/instruction/{uuid}. Empty fields are left unchanged by the server.
+type UpdateInstructionRequest struct {
+	Instruction struct {
+		Name    string `json:"name,omitempty"`
+		Content string `json:"content,omitempty"`
+	} `json:"instruction"`
+}
+
+// UpdateInstructionResponse holds the response from editing an instruction.
+type UpdateInstructionResponse struct {
+	Response    *GenDBResponse   `json:"response,omitempty"`
+	Instruction *InstructionSpec `json:"instruction,omitempty"`
+}
+
+func (c *Client) UpdateInstruction(instrUUID, name, content string) (*UpdateInstructionResponse, error) {
+	var reqBody UpdateInstructionRequest
+	reqBody.Instruction.Name = name
+	reqBody.Instruction.Content = content
+	var resp UpdateInstructionResponse
+	if err := c.doJSON("PUT", "/v1/instruction/"+instrUUID, reqBody, &resp); err != nil {
+		return nil, err
+	}
+	if resp.Response != nil && resp.Response.ErrorCode != 0 {
+		return nil, fmt.Errorf("server error: %s", resp.Response.ErrorMessage)
+	}
+	return &resp, nil
+}
+
 func (c *Client) DeleteInstruction(instrUUID string) error {
 	var resp struct {
 		Response *GenDBResponse `json:"response,omitempty"`
@@ -1380,6 +1917,65 @@ func (c *Client) RerunSession(sessionUUID string) (*RerunSessionResponse, error)
 	return &resp, nil
 }
 
+// --- CLI usage telemetry ---
+
+// TelemetryBatch is one upload of anonymous CLI usage counts.
+type TelemetryBatch struct {
+	InstallID string         `json:"install_id"`
+	Version   string         `json:"version"`
+	OS        string         `json:"os"`
+	Arch      string         `json:"arch"`
+	From      time.Time      `json:"from"`
+	To        time.Time      `json:"to"`
+	Commands  map[string]int `json:"commands"`
+	Errors    map[string]int `json:"errors,omitempty"`
+}
+
+// TelemetryPath is the endpoint usage batches are posted to.
+const TelemetryPath = "/v1/cli/telemetry"
+
+// TelemetryHeaders are the only headers a telemetry upload carries. It has
+// no token, organization or trace context, so a batch cannot be tied to
+// the user or to their other requests.
+var TelemetryHeaders = map[string]string{"Content-Type": "application/json"}
+
+// UploadTelemetry sends a usage batch to the server. Unlike every other
+// call it bypasses setHeaders and tracing: the request is the batch and
+// TelemetryHeaders, nothing else.
+func (c *Client) UploadTelemetry(batch *TelemetryBatch) error {
+	data, err := json.Marshal(batch)
+	if err != nil {
+		return fmt.Errorf("marshaling request: %w", err)
+	}
+	ctx := context.Background()
+	if c.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", c.baseURL+TelemetryPath, bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("creating request: %w", err)
+	}
+	for k, v := range TelemetryHeaders {
+		req.Header.Set(k, v)
+	}
+	transport := c.httpClient.Transport
+	if tt, ok := transport.(tracingTransport); ok {
+		transport = tt.base
+	}
+	resp, err := (&http.Client{Transport: transport}).Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("server returned %d: %s", resp.StatusCode, string(body))
+	}
+	return nil
+}
+
 // --- Generic JSON helper ---
 
 func (c *Client) doJSON(method, path string, reqBody interface{}, result interface{}) error {
@@ -1394,25 +1990,55 @@ func (c *Client) doJSON(method, path string, reqBody interface{}, result interfa
 
 	fullURL := c.baseURL + path
 
-	req, err := http.NewRequest(method, fullURL, bodyReader)
+	ctx := context.Background()
+	if c.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
+	req, err := http.NewRequestWithContext(ctx, method, fullURL, bodyReader)
 	if err != nil {
 		return fmt.Errorf("creating request: %w", err)
 	}
 	c.setHeaders(req, bodyReader != nil)
 
+	var cacheKey string
+	var cached cacheEntry
+	useCache := method == "GET" && c.cache != nil && !cacheDisabled && cacheable(path)
+	if useCache {
+		cacheKey = c.cache.key(c.orgUUID, fullURL)
+		if e, ok := c.cache.get(cacheKey); ok {
+			cached = e
+			req.Header.Set("If-None-Match", e.ETag)
+		}
+	}
+
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			return requestTimeoutError(c.timeout)
+		}
 		return fmt.Errorf("request failed: %w", err)
 	}
 	defer resp.Body.Close()
 
 	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			return requestTimeoutError(c.timeout)
+		}
 		return fmt.Errorf("reading response: %w", err)
 	}
 
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+	switch {
+	case resp.StatusCode == http.StatusNotModified && cached.ETag != "":
+		respBody = cached.Body
+	case resp.StatusCode < 200 || resp.StatusCode >= 300:
 		return fmt.Errorf("server returned %d: %s", resp.StatusCode, string(respBody))
+	case useCache:
+		if etag := resp.Header.Get("ETag"); etag != "" {
+			c.cache.put(cacheKey, cacheEntry{ETag: etag, Body: respBody})
+		}
 	}
 
 	if result != nil {
@@ -1422,3 +2048,30 @@ func (c *Client) doJSON(method, path string, reqBody interface{}, result interfa
 	}
 	return nil
 }
+
+// --- Session sharing ---
+
+// ShareSessionResponse is a read-only share link to a session.
+type ShareSessionResponse struct {
+	URL        string `json:"url"`
+	ExpireTime string `json:"expire_time,omitempty"`
+}
+
+// ShareSession asks the server for a link that shows a session read-only
+// to anyone holding it, without a platform account, until it expires.
+func (c *Client) ShareSession(projectUUID, sessionUUID string, expiresIn time.Duration) (*ShareSessionResponse, error) {
+	reqBody := struct {
+		Request          *GenDBRequest `json:"request,omitempty"`
+		ProjectUUID      string        `json:"project_uuid"`
+		ExpiresInSeconds int64         `json:"expires_in_seconds"`
+	}{
+		Request:          &GenDBRequest{ClientIdentifier: "hawkeye-cli", UUID: c.orgUUID},
+		ProjectUUID:      projectUUID,
+		ExpiresInSeconds: int64(expiresIn.Seconds()),
+	}
+	var resp ShareSessionResponse
+	if err := c.doJSON("POST", "/v1/inference/session/"+sessionUUID+":share", reqBody, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
@@ -6,9 +6,12 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"regexp"
+	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"hawkeye-cli/internal/config"
 )
@@ -219,6 +222,58 @@ func TestLoginAllFail(t *testing.T) {
 	}
 }
 
+func TestListOrganizations(t *testing.T) {
+	tests := []struct {
+		name     string
+		orgsBody string // "" = endpoint missing (404)
+		userBody string
+		want     []OrgSpec
+		wantErr  bool
+	}{
+		{
+			name:     "organizations endpoint",
+			orgsBody: `{"specs":[{"uuid":"o1","name":"Acme"},{"uuid":"o2","name":"Globex"}]}`,
+			want:     []OrgSpec{{UUID: "o1", Name: "Acme"}, {UUID: "o2", Name: "Globex"}},
+		},
+		{
+			name:     "fallback to user memberships",
+			userBody: `{"specs":[{"org_uuid":"o1","user_role":"admin"},{"org_uuid":"o2"},{"org_uuid":"o1"}]}`,
+			want:     []OrgSpec{{UUID: "o1", Role: "admin"}, {UUID: "o2"}},
+		},
+		{
+			name:     "no organizations",
+			userBody: `{"specs":[]}`,
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body := tt.userBody
+				if r.URL.Path == "/v1/user/organizations" {
+					if tt.orgsBody == "" {
+						w.WriteHeader(http.StatusNotFound)
+						return
+					}
+					body = tt.orgsBody
+				}
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = fmt.Fprint(w, body)
+			}))
+			defer srv.Close()
+
+			c := &Client{baseURL: srv.URL, httpClient: srv.Client(), token: "tok"}
+			got, err := c.ListOrganizations()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ListOrganizations() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("ListOrganizations() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestLoginErrorResponse(t *testing.T) {
 	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
@@ -256,6 +311,27 @@ func TestNewClient(t *testing.T) {
 	}
 }
 
+func TestSetOrg(t *testing.T) {
+	defer SetOrg("")
+	cfg := &config.Config{Server: "http://localhost:3001", Token: "tok", OrgUUID: "org-123"}
+
+	SetOrg("org-456")
+	if c := NewClient(cfg); c.orgUUID != "org-456" {
+		t.Errorf("orgUUID = %q, want the override", c.orgUUID)
+	}
+	if got := EffectiveOrg(cfg); got != "org-456" {
+		t.Errorf("EffectiveOrg() = %q, want org-456", got)
+	}
+	if cfg.OrgUUID != "org-123" {
+		t.Errorf("profile org changed to %q", cfg.OrgUUID)
+	}
+
+	SetOrg("")
+	if c := NewClient(cfg); c.orgUUID != "org-123" {
+		t.Errorf("orgUUID = %q after clearing, want the profile's", c.orgUUID)
+	}
+}
+
 func TestNormalizeBackendURL(t *testing.T) {
 	tests := []struct {
 		name  string
@@ -435,6 +511,57 @@ data: {"message":{"content":{"content_type":"CONTENT_TYPE_CHAT_RESPONSE","parts"
 	})
 }
 
+func TestProcessPromptStreamWithContext(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var req ProcessPromptRequest
+		if err := json.Unmarshal(body, &req); err != nil {
+			t.Fatalf("unmarshal: %v", err)
+		}
+		parts := req.Messages[0].Content.Parts
+		if len(parts) != 3 || parts[0] != "why?" || parts[2] != "events" {
+			t.Errorf("parts = %v, want [why? pods events]", parts)
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		_, _ = fmt.Fprint(w, "data: {\"message\":{\"end_turn\":true}}\n\n")
+	}))
+	defer srv.Close()
+
+	c := &Client{baseURL: srv.URL, httpClient: srv.Client(), token: "tok"}
+	err := c.ProcessPromptStreamWithContext("proj", "sess", "why?", []string{"pods", "events"}, func(*ProcessPromptResponse) {})
+	if err != nil {
+		t.Fatalf("ProcessPromptStreamWithContext() error = %v", err)
+	}
+}
+
+func TestProcessPromptStreamLanguage(t *testing.T) {
+	for _, lang := range []string{"", "ja"} {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			var req ProcessPromptRequest
+			if err := json.Unmarshal(body, &req); err != nil {
+				t.Fatalf("unmarshal: %v", err)
+			}
+			got := ""
+			if req.PromptOptions != nil {
+				got = req.PromptOptions.Language
+			}
+			if got != lang {
+				t.Errorf("prompt_options.language = %q, want %q", got, lang)
+			}
+			w.Header().Set("Content-Type", "text/event-stream")
+			_, _ = fmt.Fprint(w, "data: {\"message\":{\"end_turn\":true}}\n\n")
+		}))
+
+		c := &Client{baseURL: srv.URL, httpClient: srv.Client(), token: "tok"}
+		c.SetLanguage(lang)
+		if err := c.ProcessPromptStream("proj", "sess", "why?", func(*ProcessPromptResponse) {}); err != nil {
+			t.Errorf("ProcessPromptStream(%q) error = %v", lang, err)
+		}
+		srv.Close()
+	}
+}
+
 func TestSessionListWithFilters(t *testing.T) {
 	t.Run("without filters", func(t *testing.T) {
 		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -541,6 +668,27 @@ func TestGetIncidentReport(t *testing.T) {
 	}
 }
 
+func TestGetIncidentReportRange(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("start_time") != "2026-03-01T00:00:00Z" || q.Get("end_time") != "2026-03-08T00:00:00Z" {
+			t.Errorf("query = %s", r.URL.RawQuery)
+		}
+		_, _ = fmt.Fprint(w, `{"avg_mttr": 12.5, "total_incidents": 4}`)
+	}))
+	defer srv.Close()
+
+	c := &Client{baseURL: srv.URL, httpClient: srv.Client(), token: "tok"}
+	start := time.Date(2026, 3, 1, 1, 0, 0, 0, time.FixedZone("CET", 3600))
+	resp, err := c.GetIncidentReportRange(start, start.AddDate(0, 0, 7))
+	if err != nil {
+		t.Fatalf("GetIncidentReportRange() error = %v", err)
+	}
+	if resp.AvgMTTR != 12.5 || resp.TotalIncidents != 4 {
+		t.Errorf("resp = %+v", resp)
+	}
+}
+
 func TestListConnections(t *testing.T) {
 	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != "GET" {
@@ -614,6 +762,72 @@ func TestListConnectionResources(t *testing.T) {
 	}
 }
 
+func TestListAllConnectionResources(t *testing.T) {
+	old := resourcePageSize
+	resourcePageSize = 4
+	defer func() { resourcePageSize = old }()
+
+	const total = 7
+	spec := func(i int) string {
+		return fmt.Sprintf(`{"id":{"name":"metric-%d","uuid":"r%d"},"connection_uuid":"c1"}`, i, i)
+	}
+	// The server caps pages at 3 whatever the limit, as real ones do.
+	serve := func(w http.ResponseWriter, from int, token string) {
+		var specs []string
+		for i := from; i < total && i < from+3; i++ {
+			specs = append(specs, spec(i))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"specs":[%s],"pagination":{"next_page_token":%q}}`, strings.Join(specs, ","), token)
+	}
+
+	tests := []struct {
+		name      string
+		max       int
+		handler   http.HandlerFunc
+		want      int
+		truncated bool
+	}{
+		{"offset", 0, func(w http.ResponseWriter, r *http.Request) {
+			start, _ := strconv.Atoi(r.URL.Query().Get("pagination.start"))
+			serve(w, start, "")
+		}, total, false},
+		{"cursor", 0, func(w http.ResponseWriter, r *http.Request) {
+			from, _ := strconv.Atoi(strings.TrimPrefix(r.URL.Query().Get("pagination.page_token"), "at-"))
+			next := ""
+			if from+3 < total {
+				next = fmt.Sprintf("at-%d", from+3)
+			}
+			serve(w, from, next)
+		}, total, false},
+		{"offset ignored", 0, func(w http.ResponseWriter, r *http.Request) {
+			serve(w, 0, "")
+		}, 3, false},
+		{"max", 5, func(w http.ResponseWriter, r *http.Request) {
+			start, _ := strconv.Atoi(r.URL.Query().Get("pagination.start"))
+			serve(w, start, "")
+		}, 5, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(tt.handler)
+			defer srv.Close()
+
+			c := &Client{baseURL: srv.URL, httpClient: srv.Client(), token: "tok"}
+			specs, truncated, err := c.ListAllConnectionResources("c1", tt.max, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(specs) != tt.want || truncated != tt.truncated {
+				t.Errorf("got %d resources, truncated %v; want %d, %v", len(specs), truncated, tt.want, tt.truncated)
+			}
+			if len(specs) > 0 && specs[len(specs)-1].ID.UUID != fmt.Sprintf("r%d", tt.want-1) {
+				t.Errorf("last = %+v", specs[len(specs)-1])
+			}
+		})
+	}
+}
+
 // ─── Phase 1: Project CRUD ──────────────────────────────────────────────────
 
 func TestGetProject(t *testing.T) {
@@ -751,6 +965,52 @@ func TestDeleteProject(t *testing.T) {
 	})
 }
 
+func TestSavePrompt(t *testing.T) {
+	var gotMethod, gotPath string
+	var gotBody SavePromptRequest
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotMethod, gotPath = r.Method, r.URL.Path
+		_ = json.NewDecoder(r.Body).Decode(&gotBody)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprint(w, `{"item":{"uuid":"p-9","oneliner":"Latency","prompt":"Why slow?"}}`)
+	}))
+	defer srv.Close()
+	c := &Client{baseURL: srv.URL, httpClient: srv.Client(), token: "tok"}
+
+	resp, err := c.SavePrompt("proj-1", InitialPrompt{Oneliner: "Latency", Prompt: "Why slow?"})
+	if err != nil {
+		t.Fatalf("SavePrompt() error = %v", err)
+	}
+	if gotMethod != "POST" || gotPath != "/v1/inference/prompt-library" {
+		t.Errorf("create = %s %s", gotMethod, gotPath)
+	}
+	if gotBody.ProjectUUID != "proj-1" || gotBody.Item.Oneliner != "Latency" || resp.Item == nil || resp.Item.UUID != "p-9" {
+		t.Errorf("body = %+v, resp = %+v", gotBody, resp)
+	}
+
+	if _, err := c.SavePrompt("proj-1", InitialPrompt{UUID: "p-9", Oneliner: "Latency", Prompt: "Why?"}); err != nil {
+		t.Fatalf("SavePrompt() update error = %v", err)
+	}
+	if gotMethod != "PUT" || gotPath != "/v1/inference/prompt-library/p-9" {
+		t.Errorf("update = %s %s", gotMethod, gotPath)
+	}
+}
+
+func TestDeletePrompt(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "DELETE" || r.URL.Path != "/v1/inference/prompt-library/p-1" || r.URL.Query().Get("project_uuid") != "proj-1" {
+			t.Errorf("request = %s %s", r.Method, r.URL)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprint(w, `{"response":{"error_code":403,"error_message":"admins only"}}`)
+	}))
+	defer srv.Close()
+	c := &Client{baseURL: srv.URL, httpClient: srv.Client(), token: "tok"}
+	if err := c.DeletePrompt("proj-1", "p-1"); err == nil || !strings.Contains(err.Error(), "admins only") {
+		t.Errorf("DeletePrompt() error = %v, want server error", err)
+	}
+}
+
 // ─── Phase 2: Connections ───────────────────────────────────────────────────
 
 func TestGetConnectionInfo(t *testing.T) {
@@ -817,6 +1077,37 @@ func TestCreateConnection(t *testing.T) {
 	}
 }
 
+func TestUpdateConnectionConfig(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PATCH" {
+			t.Errorf("method = %s, want PATCH", r.Method)
+		}
+		if r.URL.Path != "/v1/datasource/connection/conn-1" {
+			t.Errorf("path = %s, want /v1/datasource/connection/conn-1", r.URL.Path)
+		}
+		body, _ := io.ReadAll(r.Body)
+		var req UpdateConnectionConfigRequest
+		if err := json.Unmarshal(body, &req); err != nil {
+			t.Fatalf("unmarshal: %v", err)
+		}
+		if len(req.Config) != 1 || req.Config["api_key"] != "new-key" {
+			t.Errorf("Config = %v, want only the new api_key", req.Config)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprint(w, `{"spec":{"uuid":"conn-1","sync_state":"SYNC_STATE_SYNCING"}}`)
+	}))
+	defer srv.Close()
+
+	c := &Client{baseURL: srv.URL, httpClient: srv.Client(), token: "tok", orgUUID: "org"}
+	resp, err := c.UpdateConnectionConfig("conn-1", map[string]string{"api_key": "new-key"})
+	if err != nil {
+		t.Fatalf("UpdateConnectionConfig() error = %v", err)
+	}
+	if resp.Spec.UUID != "conn-1" {
+		t.Errorf("UUID = %q, want conn-1", resp.Spec.UUID)
+	}
+}
+
 func TestWaitForConnectionSync(t *testing.T) {
 	t.Run("already synced", func(t *testing.T) {
 		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -853,6 +1144,67 @@ func TestWaitForConnectionSync(t *testing.T) {
 	})
 }
 
+func TestTrainConnection(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" {
+			t.Errorf("method = %s, want POST", r.Method)
+		}
+		if r.URL.Path != "/v1/connection/conn-1/train" {
+			t.Errorf("path = %s, want /v1/connection/conn-1/train", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprint(w, `{}`)
+	}))
+	defer srv.Close()
+
+	c := &Client{baseURL: srv.URL, httpClient: srv.Client(), token: "tok", orgUUID: "org"}
+	if err := c.TrainConnection("conn-1"); err != nil {
+		t.Fatalf("TrainConnection() error = %v", err)
+	}
+}
+
+func TestWaitForConnectionTraining(t *testing.T) {
+	old := connectionPollInterval
+	connectionPollInterval = time.Millisecond
+	defer func() { connectionPollInterval = old }()
+
+	tests := []struct {
+		name    string
+		states  []string
+		wantErr string
+	}{
+		{"trains after polling", []string{"TRAINING_STATE_IN_PROGRESS", "TRAINING_STATE_TRAINED"}, ""},
+		{"training failed", []string{"TRAINING_STATE_FAILED"}, "training failed"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				state := tt.states[min(calls, len(tt.states)-1)]
+				calls++
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = fmt.Fprintf(w, `{"spec":{"uuid":"conn-1","training_state":%q}}`, state)
+			}))
+			defer srv.Close()
+
+			c := &Client{baseURL: srv.URL, httpClient: srv.Client(), token: "tok"}
+			resp, err := c.WaitForConnectionTraining("conn-1", 10)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("WaitForConnectionTraining() error = %v", err)
+			}
+			if resp.Spec.TrainingState != "TRAINING_STATE_TRAINED" || calls != 2 {
+				t.Errorf("state = %q after %d calls", resp.Spec.TrainingState, calls)
+			}
+		})
+	}
+}
+
 func TestAddConnectionToProject(t *testing.T) {
 	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != "POST" {
@@ -1053,6 +1405,40 @@ func TestUpdateInstructionStatus(t *testing.T) {
 	})
 }
 
+func TestUpdateInstruction(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PUT" {
+			t.Errorf("method = %s, want PUT", r.Method)
+		}
+		if !strings.HasSuffix(r.URL.Path, "/v1/instruction/instr-1") {
+			t.Errorf("path = %s", r.URL.Path)
+		}
+		body, _ := io.ReadAll(r.Body)
+		if strings.Contains(string(body), `"name"`) {
+			t.Errorf("body = %s, empty name should be omitted", body)
+		}
+		var req UpdateInstructionRequest
+		if err := json.Unmarshal(body, &req); err != nil {
+			t.Fatalf("unmarshal: %v", err)
+		}
+		if req.Instruction.Content != "line one\nline two" {
+			t.Errorf("Instruction.Content = %q", req.Instruction.Content)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprint(w, `{"instruction":{"uuid":"instr-1","name":"My rule","content":"line one\nline two"}}`)
+	}))
+	defer srv.Close()
+
+	c := &Client{baseURL: srv.URL, httpClient: srv.Client(), token: "tok"}
+	resp, err := c.UpdateInstruction("instr-1", "", "line one\nline two")
+	if err != nil {
+		t.Fatalf("UpdateInstruction() error = %v", err)
+	}
+	if resp.Instruction.Name != "My rule" {
+		t.Errorf("Name = %q, want %q", resp.Instruction.Name, "My rule")
+	}
+}
+
 func TestDeleteInstruction(t *testing.T) {
 	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != "DELETE" {
@@ -1343,3 +1729,165 @@ func TestGetSessionReport(t *testing.T) {
 
 // Verify *Client implements HawkeyeAPI at compile time.
 var _ HawkeyeAPI = (*Client)(nil)
+
+func TestRenameSession(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PATCH" {
+			t.Errorf("method = %s, want PATCH", r.Method)
+		}
+		if r.URL.Path != "/v1/inference/session/sess-1" {
+			t.Errorf("path = %s, want /v1/inference/session/sess-1", r.URL.Path)
+		}
+		body, _ := io.ReadAll(r.Body)
+		var req RenameSessionRequest
+		if err := json.Unmarshal(body, &req); err != nil {
+			t.Fatalf("unmarshal: %v", err)
+		}
+		if req.ProjectUUID != "proj-1" || req.Name != "Why are pods crashlooping?" {
+			t.Errorf("request = %+v", req)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprint(w, `{}`)
+	}))
+	defer srv.Close()
+
+	c := &Client{baseURL: srv.URL, httpClient: srv.Client(), token: "tok", orgUUID: "org"}
+	if err := c.RenameSession("proj-1", "sess-1", "Why are pods crashlooping?"); err != nil {
+		t.Fatalf("RenameSession() error = %v", err)
+	}
+}
+
+func TestUploadTelemetry(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/v1/cli/telemetry" {
+			t.Errorf("request = %s %s, want POST /v1/cli/telemetry", r.Method, r.URL.Path)
+		}
+		for _, h := range []string{"Authorization", "Traceparent", "Cookie"} {
+			if v := r.Header.Get(h); v != "" {
+				t.Errorf("telemetry upload sent %s: %q", h, v)
+			}
+		}
+		var batch TelemetryBatch
+		if err := json.NewDecoder(r.Body).Decode(&batch); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		if batch.InstallID != "abc" || batch.Commands["investigate"] != 2 {
+			t.Errorf("batch = %+v", batch)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprint(w, `{}`)
+	}))
+	defer srv.Close()
+
+	c := &Client{baseURL: srv.URL, httpClient: srv.Client(), token: "tok", orgUUID: "org-1"}
+	batch := &TelemetryBatch{InstallID: "abc", Commands: map[string]int{"investigate": 2}}
+	if err := c.UploadTelemetry(batch); err != nil {
+		t.Fatalf("UploadTelemetry() error = %v", err)
+	}
+}
+
+func TestIncidentGroups(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("project_uuid") != "proj-1" {
+			t.Errorf("project_uuid = %q", r.URL.Query().Get("project_uuid"))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v1/inference/incident_group":
+			_, _ = fmt.Fprint(w, `{"groups":[{"group_id":"g1","title":"checkout","alert_count":2,"session_uuid":"s1"}]}`)
+		case "/v1/inference/incident_group/g1":
+			_, _ = fmt.Fprint(w, `{"group":{"group_id":"g1","alerts":[{"alert_id":"a1"},{"alert_id":"a2"}]}}`)
+		default:
+			_, _ = fmt.Fprint(w, `{}`)
+		}
+	}))
+	defer srv.Close()
+
+	c := &Client{baseURL: srv.URL, httpClient: srv.Client(), token: "tok"}
+	list, err := c.ListIncidentGroups("proj-1")
+	if err != nil || len(list.Groups) != 1 || list.Groups[0].SessionUUID != "s1" || list.Groups[0].AlertCount != 2 {
+		t.Fatalf("ListIncidentGroups() = %+v, %v", list, err)
+	}
+	g, err := c.GetIncidentGroup("proj-1", "g1")
+	if err != nil || len(g.Alerts) != 2 {
+		t.Fatalf("GetIncidentGroup() = %+v, %v", g, err)
+	}
+	if _, err := c.GetIncidentGroup("proj-1", "missing"); err == nil {
+		t.Error("GetIncidentGroup(missing): expected an error for an empty response")
+	}
+}
+
+func TestListResourceTypes(t *testing.T) {
+	var gotQuery url.Values
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/resource/types" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		gotQuery = r.URL.Query()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprint(w, `{"resource_types":[{"connection_type":"aws","telemetry_type":"metric","type":"cloudwatch_metric","capabilities":["discover","query"]}]}`)
+	}))
+	defer srv.Close()
+
+	c := &Client{baseURL: srv.URL, httpClient: srv.Client(), token: "tok"}
+	resp, err := c.ListResourceTypes("aws", "metric")
+	if err != nil {
+		t.Fatalf("ListResourceTypes() error = %v", err)
+	}
+	if gotQuery.Get("connection_type") != "aws" || gotQuery.Get("telemetry_type") != "metric" {
+		t.Errorf("query = %v", gotQuery)
+	}
+	if len(resp.ResourceTypes) != 1 || resp.ResourceTypes[0].Type != "cloudwatch_metric" || len(resp.ResourceTypes[0].Capabilities) != 2 {
+		t.Errorf("got %+v", resp.ResourceTypes)
+	}
+}
+
+func TestShareSession(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/v1/inference/session/sess-1:share" {
+			t.Errorf("request = %s %s, want POST /v1/inference/session/sess-1:share", r.Method, r.URL.Path)
+		}
+		var body struct {
+			ProjectUUID      string `json:"project_uuid"`
+			ExpiresInSeconds int64  `json:"expires_in_seconds"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatal(err)
+		}
+		if body.ProjectUUID != "proj-1" || body.ExpiresInSeconds != 7200 {
+			t.Errorf("body = %+v", body)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprint(w, `{"url":"https://x.neubird.ai/s/abc","expire_time":"2026-10-18T21:00:00Z"}`)
+	}))
+	defer srv.Close()
+
+	c := &Client{baseURL: srv.URL, httpClient: srv.Client(), token: "tok", orgUUID: "org"}
+	resp, err := c.ShareSession("proj-1", "sess-1", 2*time.Hour)
+	if err != nil {
+		t.Fatalf("ShareSession() error = %v", err)
+	}
+	if resp.URL != "https://x.neubird.ai/s/abc" || resp.ExpireTime != "2026-10-18T21:00:00Z" {
+		t.Errorf("resp = %+v", resp)
+	}
+}
+
+func TestDeleteSession(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "DELETE" || r.URL.Path != "/v1/inference/session/sess-1" {
+			t.Errorf("request = %s %s, want DELETE /v1/inference/session/sess-1", r.Method, r.URL.Path)
+		}
+		if got := r.URL.Query().Get("project_uuid"); got != "proj-1" {
+			t.Errorf("project_uuid = %q, want proj-1", got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprint(w, `{}`)
+	}))
+	defer srv.Close()
+
+	c := &Client{baseURL: srv.URL, httpClient: srv.Client(), token: "tok", orgUUID: "org"}
+	if err := c.DeleteSession("proj-1", "sess-1"); err != nil {
+		t.Fatalf("DeleteSession() error = %v", err)
+	}
+}
@@ -0,0 +1,131 @@
+package api
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
+
+// StreamEvent is the flat, serializable form of one streamed event. It is
+// written one-per-line (NDJSON) by --json-stream and can be turned back
+// into a ProcessPromptResponse for offline rendering.
+type StreamEvent struct {
+	Time        time.Time `json:"time"`
+	EventType   string    `json:"event_type"`
+	ContentType string    `json:"content_type,omitempty"`
+	Parts       []string  `json:"parts,omitempty"`
+	IsDelta     bool      `json:"is_delta,omitempty"`
+	EndTurn     bool      `json:"end_turn,omitempty"`
+	Status      string    `json:"status,omitempty"`
+	SessionUUID string    `json:"session_uuid,omitempty"`
+	Error       string    `json:"error,omitempty"`
+}
+
+// NewStreamEvent flattens a stream response into a StreamEvent stamped with t.
+func NewStreamEvent(resp *ProcessPromptResponse, t time.Time) StreamEvent {
+	ev := StreamEvent{
+		Time:        t,
+		EventType:   resp.EventType,
+		SessionUUID: resp.SessionUUID,
+		Error:       resp.Error,
+	}
+	if ev.EventType == "" {
+		ev.EventType = "message"
+	}
+	if msg := resp.Message; msg != nil {
+		ev.EndTurn = msg.EndTurn
+		ev.Status = msg.Status
+		ev.IsDelta = msg.Metadata.IsDeltaTrue()
+		if msg.Content != nil {
+			ev.ContentType = msg.Content.ContentType
+			ev.Parts = msg.Content.Parts
+		}
+	}
+	return ev
+}
+
+// Response rebuilds the ProcessPromptResponse an event was created from.
+func (e StreamEvent) Response() *ProcessPromptResponse {
+	resp := &ProcessPromptResponse{
+		EventType:   e.EventType,
+		SessionUUID: e.SessionUUID,
+		Error:       e.Error,
+		Message: &Message{
+			EndTurn: e.EndTurn,
+			Status:  e.Status,
+		},
+	}
+	if e.ContentType != "" || len(e.Parts) > 0 {
+		resp.Message.Content = &Content{ContentType: e.ContentType, Parts: e.Parts}
+	}
+	if e.IsDelta {
+		resp.Message.Metadata = &Metadata{IsDelta: true}
+	}
+	return resp
+}
+
+// EventWriter writes stream events as newline-delimited JSON.
+// HandleEvent can be passed directly as a StreamCallback.
+type EventWriter struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+	now func() time.Time
+	err error
+}
+
+// NewEventWriter returns an EventWriter that writes to w.
+func NewEventWriter(w io.Writer) *EventWriter {
+	return &EventWriter{enc: json.NewEncoder(w), now: time.Now}
+}
+
+// HandleEvent is the StreamCallback that serializes each event.
+func (w *EventWriter) HandleEvent(resp *ProcessPromptResponse) {
+	w.Write(NewStreamEvent(resp, w.now()))
+}
+
+// Write serializes a single event. The first write error is kept and
+// returned by Err; later writes are skipped.
+func (w *EventWriter) Write(ev StreamEvent) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.err != nil {
+		return
+	}
+	w.err = w.enc.Encode(ev)
+}
+
+// Err returns the first error encountered while writing.
+func (w *EventWriter) Err() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.err
+}
+
+// ReadStreamEvents parses an NDJSON event log written by EventWriter.
+// Blank lines are ignored; malformed lines are reported with their line number.
+func ReadStreamEvents(r io.Reader) ([]StreamEvent, error) {
+	var events []StreamEvent
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 1024*1024), 1024*1024)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		var ev StreamEvent
+		if err := json.Unmarshal([]byte(text), &ev); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		events = append(events, ev)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
@@ -0,0 +1,109 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStreamEventRoundTrip(t *testing.T) {
+	ts := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	tests := []struct {
+		name string
+		resp *ProcessPromptResponse
+	}{
+		{
+			name: "delta chat",
+			resp: &ProcessPromptResponse{EventType: "message", Message: &Message{
+				Content:  &Content{ContentType: "CONTENT_TYPE_CHAT_RESPONSE", Parts: []string{"Hi"}},
+				Metadata: &Metadata{IsDelta: "true"},
+				EndTurn:  true,
+			}},
+		},
+		{
+			name: "cot start",
+			resp: &ProcessPromptResponse{EventType: "cot_start", SessionUUID: "s1", Message: &Message{
+				Content: &Content{ContentType: "CONTENT_TYPE_CHAIN_OF_THOUGHT", Parts: []string{`{"id":"1"}`}},
+			}},
+		},
+		{
+			name: "failed cycle end",
+			resp: &ProcessPromptResponse{EventType: "prompt_cycle_end", Error: "quota exceeded", Message: &Message{
+				Content: &Content{ContentType: "CONTENT_TYPE_ERROR_MESSAGE", Parts: []string{"quota exceeded"}},
+				Status:  "PROMPT_CYCLE_STATUS_FAILED",
+				EndTurn: true,
+			}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ev := NewStreamEvent(tt.resp, ts)
+			got := ev.Response()
+			if got.EventType != tt.resp.EventType || got.SessionUUID != tt.resp.SessionUUID {
+				t.Errorf("envelope = %+v, want %+v", got, tt.resp)
+			}
+			if got.Message.Content.ContentType != tt.resp.Message.Content.ContentType {
+				t.Errorf("ContentType = %q", got.Message.Content.ContentType)
+			}
+			if got.Message.Metadata.IsDeltaTrue() != tt.resp.Message.Metadata.IsDeltaTrue() {
+				t.Errorf("IsDelta mismatch")
+			}
+			if got.Message.EndTurn != tt.resp.Message.EndTurn {
+				t.Errorf("EndTurn = %v", got.Message.EndTurn)
+			}
+			if got.Message.Status != tt.resp.Message.Status || got.Error != tt.resp.Error {
+				t.Errorf("Status = %q, Error = %q", got.Message.Status, got.Error)
+			}
+		})
+	}
+
+	if ev := NewStreamEvent(&ProcessPromptResponse{}, ts); ev.EventType != "message" {
+		t.Errorf("default EventType = %q, want message", ev.EventType)
+	}
+}
+
+func TestEventWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewEventWriter(&buf)
+	w.now = func() time.Time { return time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC) }
+
+	w.HandleEvent(&ProcessPromptResponse{Message: &Message{
+		Content: &Content{ContentType: "CONTENT_TYPE_PROGRESS_STATUS", Parts: []string{"Working"}},
+	}})
+	w.HandleEvent(&ProcessPromptResponse{Message: &Message{EndTurn: true}})
+	if err := w.Err(); err != nil {
+		t.Fatalf("Err() = %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("got %d lines, want 2: %q", len(lines), buf.String())
+	}
+	var ev StreamEvent
+	if err := json.Unmarshal([]byte(lines[0]), &ev); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if ev.ContentType != "CONTENT_TYPE_PROGRESS_STATUS" || ev.Parts[0] != "Working" || ev.Time.Year() != 2025 {
+		t.Errorf("event = %+v", ev)
+	}
+}
+
+func TestReadStreamEvents(t *testing.T) {
+	input := `{"time":"2025-01-01T00:00:00Z","event_type":"session","session_uuid":"s1"}
+
+{"time":"2025-01-01T00:00:01Z","event_type":"message","content_type":"CONTENT_TYPE_CHAT_RESPONSE","parts":["Hi"],"end_turn":true}
+`
+	events, err := ReadStreamEvents(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ReadStreamEvents() error = %v", err)
+	}
+	if len(events) != 2 || events[0].SessionUUID != "s1" || !events[1].EndTurn {
+		t.Errorf("events = %+v", events)
+	}
+
+	if _, err := ReadStreamEvents(strings.NewReader("{}\nnot json\n")); err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("malformed error = %v, want line 2", err)
+	}
+}
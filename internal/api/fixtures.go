@@ -0,0 +1,163 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// ─── Fixtures: canned responses instead of a server ─────────────────────────
+//
+// With HAWKEYE_FIXTURES=<dir> every request is answered from files in dir,
+// for offline demos, onboarding and deterministic tests. A request for
+// /v1/inference/session/list is served from the first file that exists of
+//
+//	<dir>/v1/inference/session/list/<session_uuid>.<ext>  (session_uuid from the request body)
+//	<dir>/v1/inference/session/list.<ext>
+//	<dir>/v1/inference/session/_.<ext>                    (any ID as the last path segment)
+//
+// where ext is json, sse (served as is) or ndjson (a stream recorded with
+// investigate --record, served as server-sent events). A missing fixture
+// is a 404 naming the file to add, except for PUT, PATCH and DELETE, which
+// succeed with an empty object so ratings and renames work in demos.
+
+// FixturesEnv names the environment variable holding the fixture directory.
+const FixturesEnv = "HAWKEYE_FIXTURES"
+
+var fixtureExts = []string{".json", ".sse", ".ndjson"}
+
+// NewFixtureClient returns a client that replays the fixtures in dir
+// instead of talking to a server.
+func NewFixtureClient(dir string) *Client {
+	return &Client{
+		baseURL:    "http://fixtures",
+		httpClient: &http.Client{Transport: fixtureTransport{dir: dir}},
+		token:      "fixture",
+	}
+}
+
+type fixtureTransport struct{ dir string }
+
+func (f fixtureTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var sessionUUID string
+	if req.Body != nil {
+		body, err := io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		var ids struct {
+			SessionUUID string `json:"session_uuid"`
+		}
+		if json.Unmarshal(body, &ids) == nil {
+			sessionUUID = ids.SessionUUID
+		}
+	}
+
+	file, err := f.find(req.URL.Path, sessionUUID)
+	if err != nil {
+		return nil, err
+	}
+	if file == "" {
+		switch req.Method {
+		case http.MethodPut, http.MethodPatch, http.MethodDelete:
+			return fixtureResponse(req, http.StatusOK, "application/json", []byte("{}")), nil
+		}
+		msg := fmt.Sprintf("no fixture for %s %s: add %s.json", req.Method, req.URL.Path,
+			filepath.Join(f.dir, filepath.FromSlash(fixturePath(req.URL.Path))))
+		return fixtureResponse(req, http.StatusNotFound, "text/plain", []byte(msg)), nil
+	}
+
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("reading fixture: %w", err)
+	}
+	switch filepath.Ext(file) {
+	case ".sse":
+		return fixtureResponse(req, http.StatusOK, "text/event-stream", data), nil
+	case ".ndjson":
+		sse, err := recordingToSSE(data)
+		if err != nil {
+			return nil, fmt.Errorf("reading fixture %s: %w", file, err)
+		}
+		return fixtureResponse(req, http.StatusOK, "text/event-stream", sse), nil
+	}
+	return fixtureResponse(req, http.StatusOK, "application/json", data), nil
+}
+
+// find returns the fixture file for a request path, or "" if none exists.
+func (f fixtureTransport) find(urlPath, sessionUUID string) (string, error) {
+	p := fixturePath(urlPath)
+	if p == "" {
+		return "", fmt.Errorf("no fixture for path %q", urlPath)
+	}
+	var candidates []string
+	if sessionUUID != "" && !strings.ContainsAny(sessionUUID, `/\`) {
+		candidates = append(candidates, path.Join(p, sessionUUID))
+	}
+	candidates = append(candidates, p)
+	if dir := path.Dir(p); dir != "." {
+		candidates = append(candidates, path.Join(dir, "_"))
+	}
+	for _, c := range candidates {
+		for _, ext := range fixtureExts {
+			file := filepath.Join(f.dir, filepath.FromSlash(c)+ext)
+			if info, err := os.Stat(file); err == nil && !info.IsDir() {
+				return file, nil
+			}
+		}
+	}
+	return "", nil
+}
+
+// fixturePath returns the fixture name for a request path: the path from
+// its v1 segment on, so the server URL's own path (such as /api) does not
+// matter.
+func fixturePath(urlPath string) string {
+	p := strings.Trim(path.Clean("/"+urlPath), "/")
+	if i := strings.Index("/"+p+"/", "/v1/"); i > 0 {
+		p = p[i:]
+	}
+	return p
+}
+
+// recordingToSSE turns a stream recorded with investigate --record (or
+// --json-stream) back into the server-sent events it came from.
+func recordingToSSE(data []byte) ([]byte, error) {
+	events, err := ReadStreamEvents(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	var out bytes.Buffer
+	for _, ev := range events {
+		if ev.EventType == "session" && ev.ContentType == "" {
+			continue // the recording's own header line
+		}
+		frame, err := json.Marshal(ev.Response())
+		if err != nil {
+			return nil, err
+		}
+		fmt.Fprintf(&out, "event: %s\ndata: %s\n\n", ev.EventType, frame)
+	}
+	return out.Bytes(), nil
+}
+
+func fixtureResponse(req *http.Request, status int, contentType string, body []byte) *http.Response {
+	return &http.Response{
+		StatusCode:    status,
+		Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": {contentType}},
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
@@ -0,0 +1,110 @@
+package api
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeFixture(t *testing.T, dir, name, content string) {
+	t.Helper()
+	path := filepath.Join(dir, filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFixturePath(t *testing.T) {
+	tests := []struct{ in, want string }{
+		{"/v1/project", "v1/project"},
+		{"/api/v1/user/login", "v1/user/login"},
+		{"/login", "login"},
+		{"/v1/../../etc/passwd", "etc/passwd"},
+		{"/", ""},
+	}
+	for _, tt := range tests {
+		if got := fixturePath(tt.in); got != tt.want {
+			t.Errorf("fixturePath(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestFixtureClient(t *testing.T) {
+	dir := t.TempDir()
+	writeFixture(t, dir, "v1/project.json", `{"specs":[{"uuid":"p1","name":"checkout"}]}`)
+	writeFixture(t, dir, "v1/inference/session/inspect.json", `{"session_info":{"session_uuid":"any"}}`)
+	writeFixture(t, dir, "v1/inference/session/inspect/s2.json", `{"session_info":{"session_uuid":"s2"}}`)
+	writeFixture(t, dir, "v1/inference/session/summary/_.json", `{"session_summary":{"analysis":"pool exhausted"}}`)
+	c := NewFixtureClient(dir)
+
+	projects, err := c.ListProjects()
+	if err != nil || len(projects.Specs) != 1 || projects.Specs[0].Name != "checkout" {
+		t.Fatalf("ListProjects() = %+v, %v", projects, err)
+	}
+
+	for session, want := range map[string]string{"s1": "any", "s2": "s2"} {
+		resp, err := c.SessionInspect("p1", session)
+		if err != nil || resp.SessionInfo.SessionUUID != want {
+			t.Errorf("SessionInspect(%s) = %+v, %v; want the %s fixture", session, resp, err, want)
+		}
+	}
+
+	summary, err := c.GetSessionSummary("p1", "s9")
+	if err != nil || summary.SessionSummary.Analysis != "pool exhausted" {
+		t.Errorf("GetSessionSummary() = %+v, %v", summary, err)
+	}
+
+	// Writes succeed without a fixture; reads name the file to add.
+	if err := c.RenameSession("p1", "s1", "new name"); err != nil {
+		t.Errorf("RenameSession() = %v, want success", err)
+	}
+	_, err = c.ListOrganizations()
+	if err == nil || !strings.Contains(err.Error(), filepath.Join(dir, "v1", "user", "organizations")+".json") {
+		t.Errorf("ListOrganizations() err = %v, want the fixture to add", err)
+	}
+}
+
+func TestFixtureStream(t *testing.T) {
+	tests := []struct {
+		name, file, content string
+	}{
+		{"sse", "v1/inference/session.sse",
+			"event: message\ndata: {\"message\":{\"content\":{\"content_type\":\"CONTENT_TYPE_CHAT_RESPONSE\",\"parts\":[\"pool exhausted\"]}}}\n\n" +
+				"data: {\"message\":{\"end_turn\":true}}\n\n"},
+		{"recording", "v1/inference/session.ndjson",
+			`{"time":"2026-10-15T09:12:00Z","event_type":"session","session_uuid":"s1"}` + "\n" +
+				`{"time":"2026-10-15T09:12:01Z","event_type":"message","content_type":"CONTENT_TYPE_CHAT_RESPONSE","parts":["pool exhausted"]}` + "\n\n" +
+				`{"time":"2026-10-15T09:12:02Z","event_type":"message","end_turn":true}` + "\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeFixture(t, dir, tt.file, tt.content)
+			var events []*ProcessPromptResponse
+			err := NewFixtureClient(dir).ProcessPromptStream("p1", "s1", "why?", func(r *ProcessPromptResponse) {
+				events = append(events, r)
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(events) != 2 || events[0].EventType != "message" || events[0].Message.Content.Parts[0] != "pool exhausted" || !events[1].Message.EndTurn {
+				t.Errorf("got %d events: %+v", len(events), events)
+			}
+		})
+	}
+}
+
+func TestFixturesEnv(t *testing.T) {
+	dir := t.TempDir()
+	writeFixture(t, dir, "v1/project.json", `{"specs":[{"uuid":"p1"}]}`)
+	t.Setenv(FixturesEnv, dir)
+	c := NewClientWithServer("https://hawkeye.invalid/api")
+	projects, err := c.ListProjects()
+	if err != nil || len(projects.Specs) != 1 {
+		t.Errorf("ListProjects() = %+v, %v; want the fixture", projects, err)
+	}
+}
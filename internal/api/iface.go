@@ -5,9 +5,11 @@ package api
 type HawkeyeAPI interface {
 	Login(email, password string) (*LoginResponse, error)
 	FetchUserInfo() (*UserSpec, error)
+	ListOrganizations() ([]OrgSpec, error)
 	NewSession(projectUUID string) (*NewSessionResponse, error)
 	SessionList(projectUUID string, start, limit int, filters []PaginationFilter) (*SessionListResponse, error)
 	SessionInspect(projectUUID, sessionUUID string) (*SessionInspectResponse, error)
+	RenameSession(projectUUID, sessionUUID, name string) error
 	GetSessionSummary(projectUUID, sessionUUID string) (*GetSessionSummaryResponse, error)
 	ProcessPromptStream(projectUUID, sessionUUID, prompt string, cb StreamCallback) error
 	PutRating(projectUUID, sessionUUID string, itemIDs []RatingItemID, rating, reason string) error
@@ -23,6 +25,8 @@ type HawkeyeAPI interface {
 	GetConnectionInfo(connUUID string) (*GetConnectionResponse, error)
 	CreateConnection(name, connType string, connConfig map[string]string) (*CreateConnectionResponse, error)
 	WaitForConnectionSync(connUUID string, timeoutSeconds int) (*GetConnectionResponse, error)
+	TrainConnection(connUUID string) error
+	WaitForConnectionTraining(connUUID string, timeoutSeconds int) (*GetConnectionResponse, error)
 	AddConnectionToProject(projectUUID, connUUID string) error
 	RemoveConnectionFromProject(projectUUID, connUUID string) error
 	ListProjectConnections(projectUUID string) (*ListProjectConnectionsResponse, error)
@@ -30,6 +34,7 @@ type HawkeyeAPI interface {
 	ListInstructions(projectUUID string) (*ListInstructionsResponse, error)
 	CreateInstruction(projectUUID, name, instrType, content string) (*CreateInstructionResponse, error)
 	UpdateInstructionStatus(instrUUID string, enabled bool) error
+	UpdateInstruction(instrUUID, name, content string) (*UpdateInstructionResponse, error)
 	DeleteInstruction(instrUUID string) error
 	ValidateInstruction(instrType, content string) (*ValidateInstructionResponse, error)
 	ApplySessionInstruction(sessionUUID, instrType, content string) error
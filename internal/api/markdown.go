@@ -5,6 +5,8 @@ import (
 	"regexp"
 	"strings"
 
+	"hawkeye-cli/internal/display"
+
 	"github.com/charmbracelet/glamour"
 )
 
@@ -14,9 +16,6 @@ const (
 	ansiDim       = "\033[2m"
 	ansiItalic    = "\033[3m"
 	ansiUnderline = "\033[4m"
-	ansiCyan      = "\033[36m"
-	ansiBlue      = "\033[34m"
-	ansiBoldCyan  = "\033[1;36m"
 )
 
 var htmlTagRe2 = regexp.MustCompile(`<[^>]+>`)
@@ -28,8 +27,12 @@ func cleanHTML(s string) string {
 	return htmlTagRe2.ReplaceAllString(s, "")
 }
 
+// mdPrinter renders streamed markdown line by line. Fenced code blocks and
+// tables need every line before they can be laid out, so their lines are
+// held in block and rendered through RenderMarkdown once the block ends.
 type mdPrinter struct {
 	buf    string
+	block  []string
 	inCode bool
 }
 
@@ -42,11 +45,49 @@ func (m *mdPrinter) printMarkdown(text string) {
 		}
 		line := m.buf[:idx]
 		m.buf = m.buf[idx+1:]
-		fmt.Println(m.renderLine(line))
+		m.printLine(line)
+	}
+}
+
+func (m *mdPrinter) printLine(line string) {
+	trimmed := strings.TrimSpace(line)
+	switch {
+	case m.inCode:
+		m.block = append(m.block, line)
+		if strings.HasPrefix(trimmed, "```") {
+			m.inCode = false
+			m.flushBlock()
+		}
+		return
+	case strings.HasPrefix(trimmed, "```"):
+		m.flushBlock()
+		m.inCode = true
+		m.block = []string{line}
+		return
+	case strings.HasPrefix(trimmed, "|"):
+		m.block = append(m.block, line)
+		return
+	}
+	m.flushBlock()
+	fmt.Println(m.renderLine(line))
+}
+
+// flushBlock prints a held code block or table.
+func (m *mdPrinter) flushBlock() {
+	if len(m.block) == 0 {
+		return
 	}
+	fmt.Println(strings.TrimLeft(RenderMarkdown(strings.Join(m.block, "\n")), "\n"))
+	m.block = nil
+	m.inCode = false
 }
 
 func (m *mdPrinter) flush() {
+	if m.buf != "" && (m.inCode || strings.HasPrefix(strings.TrimSpace(m.buf), "|")) {
+		m.block = append(m.block, m.buf)
+		m.buf = ""
+	}
+	m.flushBlock()
 	if m.buf == "" {
 		return
 	}
@@ -57,23 +98,6 @@ func (m *mdPrinter) flush() {
 func (m *mdPrinter) renderLine(line string) string {
 	trimmed := strings.TrimSpace(line)
 
-	if strings.HasPrefix(trimmed, "```") {
-		if !m.inCode {
-			m.inCode = true
-			lang := strings.TrimSpace(trimmed[3:])
-			if lang != "" {
-				return fmt.Sprintf("  %s┌─ %s ─%s", ansiDim, lang, ansiReset)
-			}
-			return fmt.Sprintf("  %s┌──%s", ansiDim, ansiReset)
-		}
-		m.inCode = false
-		return fmt.Sprintf("  %s└──%s", ansiDim, ansiReset)
-	}
-
-	if m.inCode {
-		return fmt.Sprintf("  %s│%s %s", ansiDim, ansiReset, line)
-	}
-
 	if strings.HasPrefix(trimmed, "#### ") {
 		return fmt.Sprintf("  %s%s%s", ansiBold, trimmed[5:], ansiReset)
 	}
@@ -81,10 +105,10 @@ func (m *mdPrinter) renderLine(line string) string {
 		return fmt.Sprintf("  %s%s%s", ansiBold, trimmed[4:], ansiReset)
 	}
 	if strings.HasPrefix(trimmed, "## ") {
-		return fmt.Sprintf("\n  %s%s%s", ansiBoldCyan, trimmed[3:], ansiReset)
+		return fmt.Sprintf("\n  %s%s%s", ansiBold+display.Cyan, trimmed[3:], ansiReset)
 	}
 	if strings.HasPrefix(trimmed, "# ") {
-		return fmt.Sprintf("\n  %s%s%s", ansiBoldCyan, trimmed[2:], ansiReset)
+		return fmt.Sprintf("\n  %s%s%s", ansiBold+display.Cyan, trimmed[2:], ansiReset)
 	}
 
 	if trimmed == "---" || trimmed == "***" || trimmed == "___" {
@@ -183,10 +207,24 @@ func renderInline(text string) string {
 	return out.String()
 }
 
+const (
+	// defaultMarkdownWidth is the wrap column when --width is not set.
+	defaultMarkdownWidth = 80
+	// markdownMargin is the horizontal margin glamour's styles add.
+	markdownMargin = 4
+)
+
+// RenderMarkdown renders a complete markdown document for the terminal:
+// tables are laid out to fit the output width and fenced code blocks are
+// syntax highlighted.
 func RenderMarkdown(text string) string {
+	width := defaultMarkdownWidth
+	if w := display.Width(); w > 0 {
+		width = max(w-markdownMargin, 20)
+	}
 	renderer, err := glamour.NewTermRenderer(
-		glamour.WithAutoStyle(),
-		glamour.WithWordWrap(80),
+		glamour.WithStandardStyle(display.CurrentPalette().GlamourStyle()),
+		glamour.WithWordWrap(width),
 	)
 	if err != nil {
 		return cleanHTML(text)
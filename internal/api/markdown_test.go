@@ -0,0 +1,71 @@
+package api
+
+import (
+	"io"
+	"os"
+	"strings"
+	"testing"
+)
+
+func captureStdout(t *testing.T, fn func()) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	orig := os.Stdout
+	os.Stdout = w
+	defer func() { os.Stdout = orig }()
+	fn()
+	w.Close()
+	out, _ := io.ReadAll(r)
+	return string(out)
+}
+
+func TestMDPrinterBlocks(t *testing.T) {
+	tests := []struct {
+		name   string
+		chunks []string
+		want   []string // substrings in order
+	}{
+		{
+			name:   "table held until it ends",
+			chunks: []string{"Pods:\n| name | restarts |\n|---|", "---|\n| api | 7 |\n", "Done.\n"},
+			want:   []string{"Pods:", "name", "restarts", "api", "7", "Done."},
+		},
+		{
+			name:   "code fence rendered as a block",
+			chunks: []string{"Run:\n```sh\nkubectl get", " pods\n```\nthen check.\n"},
+			want:   []string{"Run:", "kubectl", "pods", "then check."},
+		},
+		{
+			name:   "unterminated table flushed at end",
+			chunks: []string{"| a | b |\n|---|---|\n| 1 | 2 |"},
+			want:   []string{"a", "b", "1", "2"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := captureStdout(t, func() {
+				var m mdPrinter
+				for _, c := range tt.chunks {
+					m.printMarkdown(c)
+				}
+				m.flush()
+			})
+			// Piped output gets glamour's plain style, whose tables keep
+			// ASCII rules, so look for the raw delimiter row itself.
+			if strings.Contains(out, "|---|") || strings.Contains(out, "```") {
+				t.Errorf("raw markdown leaked into output:\n%s", out)
+			}
+			rest := out
+			for _, w := range tt.want {
+				i := strings.Index(rest, w)
+				if i < 0 {
+					t.Fatalf("output missing %q (in order):\n%s", w, out)
+				}
+				rest = rest[i+len(w):]
+			}
+		})
+	}
+}
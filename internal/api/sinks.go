@@ -0,0 +1,63 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"hawkeye-cli/internal/config"
+)
+
+// ─── Sink delivery ──────────────────────────────────────────────────────────
+//
+// --sink destinations outside the Hawkeye backend. The bodies are built by
+// the service layer; these calls only send them.
+
+// sinkHTTPClient sends sink deliveries. They go to third parties, so they
+// carry neither the profile's credentials nor trace headers.
+var sinkHTTPClient = &http.Client{Timeout: 15 * time.Second}
+
+// PostWebhook POSTs a JSON body to url.
+func PostWebhook(url string, body []byte) error {
+	resp, err := sinkHTTPClient.Post(url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("webhook returned %d: %s", resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+	return nil
+}
+
+// CreateJiraIssue opens an issue on site from a create-issue body and
+// returns the new issue's key.
+func CreateJiraIssue(site config.JiraSite, body []byte) (string, error) {
+	req, err := http.NewRequest("POST", site.BaseURL+"/rest/api/2/issue", bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req.SetBasicAuth(site.User, site.Token)
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := sinkHTTPClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return "", fmt.Errorf("jira returned %d: %s", resp.StatusCode, strings.TrimSpace(string(data)))
+	}
+	var created struct {
+		Key string `json:"key"`
+	}
+	if err := json.Unmarshal(data, &created); err != nil || created.Key == "" {
+		return "", fmt.Errorf("jira did not return an issue key: %s", strings.TrimSpace(string(data)))
+	}
+	return created.Key, nil
+}
@@ -0,0 +1,67 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"hawkeye-cli/internal/config"
+)
+
+func TestPostWebhook(t *testing.T) {
+	var received string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("request = %s %s", r.Method, r.Header.Get("Content-Type"))
+		}
+		if r.Header.Get("Authorization") != "" {
+			t.Error("webhook delivery sent an Authorization header")
+		}
+		body, _ := io.ReadAll(r.Body)
+		received = string(body)
+		if r.URL.Path == "/fail" {
+			http.Error(w, "nope", http.StatusBadGateway)
+		}
+	}))
+	defer srv.Close()
+
+	if err := PostWebhook(srv.URL+"/ok", []byte(`{"session_uuid":"sess-1"}`)); err != nil {
+		t.Fatalf("PostWebhook() error = %v", err)
+	}
+	if received != `{"session_uuid":"sess-1"}` {
+		t.Errorf("received = %s", received)
+	}
+	if err := PostWebhook(srv.URL+"/fail", []byte(`{}`)); err == nil || !strings.Contains(err.Error(), "502") {
+		t.Errorf("PostWebhook() error = %v, want 502", err)
+	}
+}
+
+func TestCreateJiraIssue(t *testing.T) {
+	var got map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, pass, _ := r.BasicAuth(); r.URL.Path != "/rest/api/2/issue" || user != "sre@example.com" || pass != "tok" {
+			t.Errorf("request %s as %s", r.URL.Path, user)
+		}
+		_ = json.NewDecoder(r.Body).Decode(&got)
+		if got["fail"] != nil {
+			http.Error(w, `{"errors":{"project":"invalid"}}`, http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"id":"10001","key":"OPS-42"}`)
+	}))
+	defer srv.Close()
+
+	site := config.JiraSite{BaseURL: srv.URL, User: "sre@example.com", Token: "tok", IssueType: "Task"}
+	key, err := CreateJiraIssue(site, []byte(`{"fields":{}}`))
+	if err != nil || key != "OPS-42" {
+		t.Fatalf("CreateJiraIssue() = %q, %v", key, err)
+	}
+	if _, err := CreateJiraIssue(site, []byte(`{"fail":true}`)); err == nil || !strings.Contains(err.Error(), "400") {
+		t.Errorf("CreateJiraIssue() error = %v, want 400", err)
+	}
+}
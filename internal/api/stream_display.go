@@ -7,14 +7,46 @@ import (
 	"strings"
 	"sync"
 	"time"
+
+	"hawkeye-cli/internal/display"
 )
 
 // Spinner frames for activity indication
 var spinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
 
+// Verbosity controls how much of a stream StreamDisplay prints.
+type Verbosity int
+
+const (
+	// VerbosityNormal prints progress, sources grouped by category and
+	// chain-of-thought steps with placeholder text left out.
+	VerbosityNormal Verbosity = iota
+	// VerbosityQuiet prints a dot per new progress message and then only
+	// the final answer.
+	VerbosityQuiet
+	// VerbosityVerbose prints every chain-of-thought delta, the raw source
+	// JSON and the server's internal error messages as they stream.
+	VerbosityVerbose
+)
+
+// ParseVerbosity parses a --verbosity value. Empty means normal.
+func ParseVerbosity(s string) (Verbosity, error) {
+	switch strings.ToLower(s) {
+	case "", "normal":
+		return VerbosityNormal, nil
+	case "quiet":
+		return VerbosityQuiet, nil
+	case "verbose":
+		return VerbosityVerbose, nil
+	}
+	return VerbosityNormal, fmt.Errorf("invalid verbosity %q: use quiet, normal or verbose", s)
+}
+
 // StreamDisplay handles clean terminal output for SSE streams.
 type StreamDisplay struct {
-	debug bool
+	debug     bool
+	verbosity Verbosity
+	quietDots int // progress dots printed in quiet mode
 
 	// Progress tracking
 	lastProgress string
@@ -80,11 +112,21 @@ func NewStreamDisplay(debug bool) *StreamDisplay {
 	}
 }
 
+// SetVerbosity sets how much of the stream is printed. The default is
+// VerbosityNormal.
+func (d *StreamDisplay) SetVerbosity(v Verbosity) {
+	d.verbosity = v
+}
+
 // HandleEvent is the StreamCallback for ProcessPromptStream.
 func (d *StreamDisplay) HandleEvent(resp *ProcessPromptResponse) {
 	if resp.SessionUUID != "" {
 		d.SessionUUID = resp.SessionUUID
 	}
+	if d.verbosity == VerbosityQuiet {
+		d.handleQuiet(resp)
+		return
+	}
 
 	msg := resp.Message
 	if msg == nil || msg.Content == nil {
@@ -164,8 +206,13 @@ func (d *StreamDisplay) HandleEvent(resp *ProcessPromptResponse) {
 
 	case "CONTENT_TYPE_ERROR_MESSAGE":
 		// The web UI does nothing with ERROR_MESSAGE events (no-op).
-		// These are internal query retry/fix messages not meant for display.
-		// Do nothing.
+		// These are internal query retry/fix messages not meant for display,
+		// so only verbose mode shows them.
+		if d.verbosity == VerbosityVerbose {
+			for _, p := range parts {
+				fmt.Printf("  %s⚠ %s%s\n", ansiDim, p, ansiReset)
+			}
+		}
 
 	case "CONTENT_TYPE_ALTERNATE_QUESTIONS":
 		if len(parts) > 0 {
@@ -214,9 +261,10 @@ func (d *StreamDisplay) Stop() {
 }
 
 // Reset prepares the display for a new prompt in the same session.
-// Keeps SessionUUID and debug, resets everything else.
+// Keeps SessionUUID, debug and verbosity, resets everything else.
 func (d *StreamDisplay) Reset() {
 	d.Stop()
+	d.quietDots = 0
 	d.lastProgress = ""
 	d.seenProgress = make(map[string]bool)
 	d.spinnerIdx = 0
@@ -244,6 +292,56 @@ func (d *StreamDisplay) Reset() {
 	d.md = mdPrinter{}
 }
 
+// --- Quiet mode ---
+
+// handleQuiet prints a dot for each new progress message and the final
+// answer once the turn ends. Everything else is only collected.
+func (d *StreamDisplay) handleQuiet(resp *ProcessPromptResponse) {
+	msg := resp.Message
+	if msg == nil {
+		return
+	}
+	if msg.Content != nil {
+		parts := msg.Content.Parts
+		switch msg.Content.ContentType {
+		case "CONTENT_TYPE_PROGRESS_STATUS":
+			if len(parts) > 0 && !d.seenProgress[normalizeProgress(parts[0])] {
+				d.seenProgress[normalizeProgress(parts[0])] = true
+				if d.quietDots == 0 {
+					fmt.Print("  ")
+				}
+				fmt.Printf("%s.%s", ansiDim, ansiReset)
+				d.quietDots++
+			}
+		case "CONTENT_TYPE_CHAT_RESPONSE":
+			if len(parts) == 0 {
+				break
+			}
+			if msg.Metadata != nil && msg.Metadata.IsDeltaTrue() {
+				d.chatAccumulated += stripHTML(parts[0])
+			} else {
+				d.chatAccumulated = stripHTML(strings.Join(parts, "\n"))
+			}
+			d.FinalAnswer = strings.TrimSpace(d.chatAccumulated)
+		case "CONTENT_TYPE_FOLLOW_UP_SUGGESTIONS":
+			d.FollowUpSuggestions = append([]string(nil), parts...)
+		}
+	}
+	if !msg.EndTurn {
+		return
+	}
+	if d.quietDots > 0 {
+		fmt.Println()
+		d.quietDots = 0
+	}
+	if d.FinalAnswer != "" {
+		fmt.Println()
+		d.md.printMarkdown(d.FinalAnswer + "\n")
+		d.md.flush()
+	}
+	d.chatAccumulated = ""
+}
+
 // --- Progress ---
 
 func (d *StreamDisplay) handleProgress(parts []string) {
@@ -282,6 +380,17 @@ func (d *StreamDisplay) showActivity(text string) {
 	d.spinnerMu.Lock()
 	defer d.spinnerMu.Unlock()
 
+	// Screen readers lose track of a line redrawn in place, so accessible
+	// mode announces each new activity once instead of spinning.
+	if display.Accessible() {
+		desc := extractProgressDescription(text)
+		if desc != d.activityText && desc != extractProgressDescription(d.lastProgress) {
+			fmt.Printf("  %s\n", desc)
+		}
+		d.activityText = desc
+		return
+	}
+
 	d.activityText = extractProgressDescription(text)
 	d.renderSpinnerFrame()
 	d.activityUp = true
@@ -390,6 +499,7 @@ type sourceJSON struct {
 
 func (d *StreamDisplay) handleSources(parts []string) {
 	var newSources []sourceJSON
+	var newRaw []string
 
 	for _, raw := range parts {
 		var s sourceJSON
@@ -397,6 +507,7 @@ func (d *StreamDisplay) handleSources(parts []string) {
 			if !d.seenSourceIDs[raw] {
 				d.seenSourceIDs[raw] = true
 				newSources = append(newSources, sourceJSON{Title: raw})
+				newRaw = append(newRaw, raw)
 			}
 			continue
 		}
@@ -409,12 +520,25 @@ func (d *StreamDisplay) handleSources(parts []string) {
 		}
 		d.seenSourceIDs[key] = true
 		newSources = append(newSources, s)
+		newRaw = append(newRaw, raw)
 	}
 
 	if len(newSources) == 0 {
 		return
 	}
 
+	if d.verbosity == VerbosityVerbose {
+		if !d.sourcesPrinted {
+			fmt.Println()
+			fmt.Printf("  📎 %sSources:%s\n", ansiDim, ansiReset)
+			d.sourcesPrinted = true
+		}
+		for _, raw := range newRaw {
+			fmt.Printf("     %s%s%s\n", ansiDim, raw, ansiReset)
+		}
+		return
+	}
+
 	// Group sources by category
 	groups := make(map[string][]string)
 	var order []string
@@ -642,10 +766,10 @@ func (d *StreamDisplay) ensureHeader() {
 	cat := formatCOTCategory(d.cotCategory)
 	if cat != "" {
 		fmt.Printf(" %s%s── Step %d · %s ────────────────────────────────────────────────────────────%s\n",
-			ansiBold, ansiBlue, d.cotRound, cat, ansiReset)
+			ansiBold, display.Blue, d.cotRound, cat, ansiReset)
 	} else {
 		fmt.Printf(" %s%s── Step %d ──────────────────────────────────────────────────────────────────%s\n",
-			ansiBold, ansiBlue, d.cotRound, ansiReset)
+			ansiBold, display.Blue, d.cotRound, ansiReset)
 	}
 
 	// Explanation (primary — the short summary shown in UI sidebar)
@@ -727,7 +851,8 @@ func (d *StreamDisplay) onCOTFullText(cot cotJSON) {
 
 	// Don't print trivial/placeholder content ("In progress...", etc).
 	// Just update metadata silently — real content will print when it arrives.
-	if isTrivialContent(fullText) {
+	// Verbose mode prints everything the server sends.
+	if isTrivialContent(fullText) && d.verbosity != VerbosityVerbose {
 		return
 	}
 
@@ -767,6 +892,11 @@ func (d *StreamDisplay) endCOTRound() {
 		if srcCount > 0 {
 			fmt.Println()
 			fmt.Printf("     %s✓ %d sources consulted%s\n", ansiDim, srcCount, ansiReset)
+			if d.verbosity == VerbosityVerbose {
+				for _, src := range d.cotSources {
+					fmt.Printf("       %s%s%s\n", ansiDim, src, ansiReset)
+				}
+			}
 		}
 		fmt.Println()
 	}
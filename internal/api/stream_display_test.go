@@ -1,6 +1,7 @@
 package api
 
 import (
+	"strings"
 	"testing"
 )
 
@@ -227,3 +228,93 @@ func TestFormatSourceLabel(t *testing.T) {
 		})
 	}
 }
+
+func TestParseVerbosity(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Verbosity
+		wantErr bool
+	}{
+		{"", VerbosityNormal, false},
+		{"normal", VerbosityNormal, false},
+		{"quiet", VerbosityQuiet, false},
+		{"VERBOSE", VerbosityVerbose, false},
+		{"loud", VerbosityNormal, true},
+	}
+	for _, tt := range tests {
+		got, err := ParseVerbosity(tt.in)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseVerbosity(%q) = %v, %v; want %v, error %v", tt.in, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestStreamDisplayVerbosity(t *testing.T) {
+	event := func(ct string, delta bool, endTurn bool, parts ...string) *ProcessPromptResponse {
+		msg := &Message{Content: &Content{ContentType: ct, Parts: parts}, EndTurn: endTurn}
+		if delta {
+			msg.Metadata = &Metadata{IsDelta: true}
+		}
+		return &ProcessPromptResponse{Message: msg}
+	}
+	stream := []*ProcessPromptResponse{
+		event("CONTENT_TYPE_PROGRESS_STATUS", false, false, "Planner (Planning the investigation)"),
+		event("CONTENT_TYPE_PROGRESS_STATUS", false, false, "Planner (Planning the investigation)"),
+		event("CONTENT_TYPE_SOURCES", false, false, `{"id":"s1","category":"logs","title":"api.errors"}`),
+		event("CONTENT_TYPE_CHAIN_OF_THOUGHT", false, false, `{"id":"c1","explanation":"Check errors","investigation":"In progress...","status":"IN_PROGRESS"}`),
+		event("CONTENT_TYPE_ERROR_MESSAGE", false, false, "query retried"),
+		event("CONTENT_TYPE_PROGRESS_STATUS", false, false, "Answer (Writing the answer)"),
+		event("CONTENT_TYPE_CHAT_RESPONSE", true, false, "The API "),
+		event("CONTENT_TYPE_CHAT_RESPONSE", true, true, "is down."),
+	}
+
+	tests := []struct {
+		name     string
+		v        Verbosity
+		want     []string
+		dontWant []string
+	}{
+		{
+			name:     "quiet",
+			v:        VerbosityQuiet,
+			want:     []string{"  " + ansiDim + "." + ansiReset + ansiDim + "." + ansiReset + "\n", "The API is down."},
+			dontWant: []string{"Planning", "Sources", "api.errors", "Check errors", "query retried"},
+		},
+		{
+			name:     "normal",
+			v:        VerbosityNormal,
+			want:     []string{"Planning the investigation", "api.errors", "Check errors", "The API is down."},
+			dontWant: []string{`"id":"s1"`, "In progress...", "query retried"},
+		},
+		{
+			name: "verbose",
+			v:    VerbosityVerbose,
+			want: []string{`{"id":"s1","category":"logs","title":"api.errors"}`, "In progress...", "query retried", "The API is down."},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := NewStreamDisplay(false)
+			d.SetVerbosity(tt.v)
+			out := captureStdout(t, func() {
+				for _, ev := range stream {
+					d.HandleEvent(ev)
+				}
+				d.Stop()
+			})
+			for _, s := range tt.want {
+				if !strings.Contains(out, s) {
+					t.Errorf("output missing %q:\n%s", s, out)
+				}
+			}
+			for _, s := range tt.dontWant {
+				if strings.Contains(out, s) {
+					t.Errorf("output contains %q:\n%s", s, out)
+				}
+			}
+			if d.FinalAnswer != "The API is down." {
+				t.Errorf("FinalAnswer = %q", d.FinalAnswer)
+			}
+		})
+	}
+}
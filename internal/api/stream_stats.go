@@ -0,0 +1,121 @@
+package api
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ─── Stream statistics ──────────────────────────────────────────────────────
+//
+// With SetDebug on, every prompt stream ends with a block of numbers on
+// stderr: events by content type, bytes received, delta events merged into
+// the text before them, lines that did not parse, the longest silence and
+// how the stream ended. Bug reports about truncated or stalled
+// investigations need exactly these, and they are gone once the terminal
+// scrolls.
+
+// StreamStats describes one prompt stream.
+type StreamStats struct {
+	Events        map[string]int // by content type, without the CONTENT_TYPE_ prefix
+	Bytes         int64
+	Deltas        int // delta events, which extend the text of the event before them
+	ParseFailures int
+	LongestGap    time.Duration // longest wait for an event, the first one included
+	GapAfter      int           // events received before the longest gap
+	Duration      time.Duration
+	End           string // "end_turn", "eof" or the error that ended the stream
+
+	start, last time.Time
+	total       int
+}
+
+func newStreamStats(now time.Time) *StreamStats {
+	return &StreamStats{Events: map[string]int{}, start: now, last: now}
+}
+
+// event records an event received at now.
+func (s *StreamStats) event(resp *ProcessPromptResponse, now time.Time) {
+	if gap := now.Sub(s.last); gap > s.LongestGap {
+		s.LongestGap, s.GapAfter = gap, s.total
+	}
+	s.last = now
+	s.total++
+
+	key := "no_content"
+	if msg := resp.Message; msg != nil && msg.Content != nil {
+		key = strings.ToLower(strings.TrimPrefix(msg.Content.ContentType, "CONTENT_TYPE_"))
+		if msg.Metadata != nil && msg.Metadata.IsDeltaTrue() {
+			s.Deltas++
+		}
+	}
+	s.Events[key]++
+}
+
+// finish records how the stream ended at now.
+func (s *StreamStats) finish(err error, endTurn bool, now time.Time) {
+	s.Duration = now.Sub(s.start)
+	switch {
+	case err != nil:
+		s.End = err.Error()
+	case endTurn:
+		s.End = "end_turn"
+	default:
+		s.End = "eof"
+	}
+}
+
+// Write prints the stats as [DEBUG] lines.
+func (s *StreamStats) Write(w io.Writer) {
+	types := make([]string, 0, len(s.Events))
+	for t := range s.Events {
+		types = append(types, t)
+	}
+	sort.Slice(types, func(i, j int) bool {
+		if s.Events[types[i]] != s.Events[types[j]] {
+			return s.Events[types[i]] > s.Events[types[j]]
+		}
+		return types[i] < types[j]
+	})
+	var byType []string
+	for _, t := range types {
+		byType = append(byType, fmt.Sprintf("%s %d", t, s.Events[t]))
+	}
+
+	fmt.Fprintf(w, "[DEBUG] stream stats\n")
+	fmt.Fprintf(w, "[DEBUG]   duration      %s\n", s.Duration.Round(time.Millisecond))
+	fmt.Fprintf(w, "[DEBUG]   received      %s\n", formatStreamBytes(s.Bytes))
+	if len(byType) > 0 {
+		fmt.Fprintf(w, "[DEBUG]   events        %d (%s)\n", s.total, strings.Join(byType, ", "))
+	} else {
+		fmt.Fprintf(w, "[DEBUG]   events        0\n")
+	}
+	fmt.Fprintf(w, "[DEBUG]   deltas        %d coalesced\n", s.Deltas)
+	fmt.Fprintf(w, "[DEBUG]   parse errors  %d\n", s.ParseFailures)
+	fmt.Fprintf(w, "[DEBUG]   longest gap   %s (after event %d)\n", s.LongestGap.Round(time.Millisecond), s.GapAfter)
+	fmt.Fprintf(w, "[DEBUG]   ended         %s\n", s.End)
+}
+
+func formatStreamBytes(n int64) string {
+	switch {
+	case n >= 1<<20:
+		return fmt.Sprintf("%.1f MB", float64(n)/(1<<20))
+	case n >= 1<<10:
+		return fmt.Sprintf("%.1f KB", float64(n)/(1<<10))
+	}
+	return fmt.Sprintf("%d B", n)
+}
+
+// countingReader counts the bytes read through it.
+type countingReader struct {
+	r io.Reader
+	n *int64
+}
+
+func (c countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	*c.n += int64(n)
+	return n, err
+}
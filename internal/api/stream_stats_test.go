@@ -0,0 +1,56 @@
+package api
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStreamStats(t *testing.T) {
+	start := time.Unix(0, 0)
+	s := newStreamStats(start)
+	cot := func(delta bool) *ProcessPromptResponse {
+		return &ProcessPromptResponse{Message: &Message{
+			Content:  &Content{ContentType: "CONTENT_TYPE_CHAIN_OF_THOUGHT"},
+			Metadata: &Metadata{IsDelta: delta},
+		}}
+	}
+	s.event(cot(false), start.Add(2*time.Second))
+	s.event(cot(true), start.Add(3*time.Second))
+	s.event(cot(true), start.Add(11*time.Second)) // the 8s stall
+	s.event(&ProcessPromptResponse{Message: &Message{Content: &Content{ContentType: "CONTENT_TYPE_CHAT_RESPONSE"}}}, start.Add(12*time.Second))
+	s.event(&ProcessPromptResponse{}, start.Add(12*time.Second))
+	s.ParseFailures = 1
+	s.Bytes = 3 << 10
+	s.finish(nil, false, start.Add(15*time.Second))
+
+	if s.Deltas != 2 || s.Events["chain_of_thought"] != 3 || s.Events["no_content"] != 1 {
+		t.Errorf("stats = %+v", s)
+	}
+	if s.LongestGap != 8*time.Second || s.GapAfter != 2 {
+		t.Errorf("longest gap = %v after %d, want 8s after 2", s.LongestGap, s.GapAfter)
+	}
+
+	var buf bytes.Buffer
+	s.Write(&buf)
+	for _, want := range []string{
+		"duration      15s",
+		"received      3.0 KB",
+		"events        5 (chain_of_thought 3, chat_response 1, no_content 1)",
+		"deltas        2 coalesced",
+		"parse errors  1",
+		"longest gap   8s (after event 2)",
+		"ended         eof",
+	} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("stats block missing %q:\n%s", want, buf.String())
+		}
+	}
+
+	s.finish(errors.New("stream idle for 5m0s"), false, start.Add(time.Minute))
+	if s.End != "stream idle for 5m0s" {
+		t.Errorf("End = %q", s.End)
+	}
+}
@@ -0,0 +1,156 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"hawkeye-cli/internal/config"
+)
+
+// ─── Timeouts ───────────────────────────────────────────────────────────────
+//
+// API calls give up after the profile's timeout (`set timeout`). A prompt
+// stream has no overall limit, since investigations can take half an hour,
+// but is aborted once no data has arrived for the stream idle timeout
+// (`set stream-idle-timeout`): proxies that silently drop long-lived
+// connections would otherwise leave the CLI waiting forever.
+
+const (
+	DefaultTimeout           = 2 * time.Minute
+	DefaultStreamIdleTimeout = 10 * time.Minute
+)
+
+// ParseTimeout parses a timeout setting: a duration such as 60s or 10m, a
+// number of seconds, or 0/none/off for no limit.
+func ParseTimeout(s string) (time.Duration, error) {
+	s = strings.TrimSpace(strings.ToLower(s))
+	switch s {
+	case "0", "none", "off", "never":
+		return 0, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		n, nerr := strconv.Atoi(s)
+		if nerr != nil {
+			return 0, fmt.Errorf("invalid timeout %q (use a duration such as 60s or 10m, or none)", s)
+		}
+		d = time.Duration(n) * time.Second
+	}
+	if d < 0 {
+		return 0, fmt.Errorf("invalid timeout %q: must not be negative", s)
+	}
+	return d, nil
+}
+
+// FormatTimeout renders a timeout the way it is typed: 10m rather than
+// 10m0s, "none" for no limit.
+func FormatTimeout(d time.Duration) string {
+	if d <= 0 {
+		return "none"
+	}
+	s := d.String()
+	if strings.HasSuffix(s, "m0s") {
+		s = strings.TrimSuffix(s, "0s")
+	}
+	if strings.HasSuffix(s, "h0m") {
+		s = strings.TrimSuffix(s, "0m")
+	}
+	return s
+}
+
+// profileTimeout resolves a timeout setting, falling back to def when it
+// is unset or invalid (config validate reports the invalid ones).
+func profileTimeout(value string, def time.Duration) time.Duration {
+	if value == "" {
+		return def
+	}
+	d, err := ParseTimeout(value)
+	if err != nil {
+		return def
+	}
+	return d
+}
+
+// RequestTimeout is the timeout API calls from cfg use.
+func RequestTimeout(cfg *config.Config) time.Duration {
+	return profileTimeout(cfg.Timeout, DefaultTimeout)
+}
+
+// StreamIdleTimeout is how long prompt streams from cfg wait for data.
+func StreamIdleTimeout(cfg *config.Config) time.Duration {
+	return profileTimeout(cfg.StreamIdleTimeout, DefaultStreamIdleTimeout)
+}
+
+// ErrStreamIdle is returned when a prompt stream receives nothing for the
+// stream idle timeout.
+type ErrStreamIdle struct {
+	Idle time.Duration
+}
+
+func (e *ErrStreamIdle) Error() string {
+	return fmt.Sprintf("no events for %s; a proxy may have dropped the connection (raise the limit with: hawkeye set stream-idle-timeout <duration>)", FormatTimeout(e.Idle))
+}
+
+func requestTimeoutError(d time.Duration) error {
+	return fmt.Errorf("request timed out after %s (raise the limit with: hawkeye set timeout <duration>)", FormatTimeout(d))
+}
+
+// idleWatch cancels a stream's context once its body has been silent for
+// the idle timeout. A nil watch never fires.
+type idleWatch struct {
+	idle  time.Duration
+	timer *time.Timer
+	hit   atomic.Bool
+}
+
+func newIdleWatch(idle time.Duration, cancel context.CancelFunc) *idleWatch {
+	if idle <= 0 {
+		return nil
+	}
+	w := &idleWatch{idle: idle}
+	w.timer = time.AfterFunc(idle, func() {
+		w.hit.Store(true)
+		cancel()
+	})
+	return w
+}
+
+// reader returns r, restarting the timer on every read that returns data.
+func (w *idleWatch) reader(r io.Reader) io.Reader {
+	if w == nil {
+		return r
+	}
+	return idleReader{r: r, w: w}
+}
+
+func (w *idleWatch) stop() {
+	if w != nil {
+		w.timer.Stop()
+	}
+}
+
+// err returns the idle error when the watch fired, or nil.
+func (w *idleWatch) err() error {
+	if w == nil || !w.hit.Load() {
+		return nil
+	}
+	return &ErrStreamIdle{Idle: w.idle}
+}
+
+type idleReader struct {
+	r io.Reader
+	w *idleWatch
+}
+
+func (r idleReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	if n > 0 {
+		r.w.timer.Reset(r.w.idle)
+	}
+	return n, err
+}
@@ -0,0 +1,112 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"hawkeye-cli/internal/config"
+)
+
+func TestParseTimeout(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    time.Duration
+		wantErr bool
+	}{
+		{"60s", time.Minute, false},
+		{"10m", 10 * time.Minute, false},
+		{"90", 90 * time.Second, false},
+		{"none", 0, false},
+		{"0", 0, false},
+		{"-5s", 0, true},
+		{"soon", 0, true},
+	}
+	for _, tt := range tests {
+		got, err := ParseTimeout(tt.in)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseTimeout(%q) = %v, %v; want %v (error %v)", tt.in, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestFormatTimeout(t *testing.T) {
+	for d, want := range map[time.Duration]string{
+		0:                       "none",
+		10 * time.Second:        "10s",
+		10 * time.Minute:        "10m",
+		90 * time.Second:        "1m30s",
+		2 * time.Hour:           "2h",
+		90 * time.Minute:        "1h30m",
+		1500 * time.Millisecond: "1.5s",
+	} {
+		if got := FormatTimeout(d); got != want {
+			t.Errorf("FormatTimeout(%v) = %q, want %q", d, got, want)
+		}
+	}
+}
+
+func TestProfileTimeouts(t *testing.T) {
+	cfg := &config.Config{}
+	if RequestTimeout(cfg) != DefaultTimeout || StreamIdleTimeout(cfg) != DefaultStreamIdleTimeout {
+		t.Errorf("unset timeouts should use the defaults")
+	}
+	cfg.Timeout, cfg.StreamIdleTimeout = "60s", "none"
+	c := NewClient(cfg)
+	if c.timeout != time.Minute || c.idleTimeout != 0 {
+		t.Errorf("timeout = %v, idle = %v", c.timeout, c.idleTimeout)
+	}
+}
+
+func TestDoJSONTimeout(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(2 * time.Second):
+		}
+	}))
+	defer srv.Close()
+
+	c := &Client{baseURL: srv.URL, httpClient: srv.Client(), timeout: 50 * time.Millisecond}
+	err := c.doJSON("GET", "/slow", nil, &struct{}{})
+	if err == nil || !strings.Contains(err.Error(), "timed out after 50ms") {
+		t.Errorf("err = %v, want a timeout", err)
+	}
+}
+
+func TestProcessPromptStreamIdleTimeout(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, "event: message\ndata: {\"message\":{\"content\":{\"content_type\":\"CONTENT_TYPE_PROGRESS_STATUS\",\"parts\":[\"Working...\"]}}}\n\n")
+		w.(http.Flusher).Flush()
+		// Then go silent, like a proxy that dropped the connection.
+		select {
+		case <-r.Context().Done():
+		case <-time.After(2 * time.Second):
+		}
+	}))
+	defer srv.Close()
+
+	c := &Client{baseURL: srv.URL, httpClient: srv.Client(), idleTimeout: 100 * time.Millisecond}
+	events := 0
+	start := time.Now()
+	err := c.ProcessPromptStream("proj", "sess", "prompt", func(*ProcessPromptResponse) { events++ })
+
+	var idle *ErrStreamIdle
+	if !errors.As(err, &idle) || idle.Idle != 100*time.Millisecond {
+		t.Fatalf("err = %v, want ErrStreamIdle", err)
+	}
+	if !strings.Contains(err.Error(), "no events for 100ms") {
+		t.Errorf("message = %q", err)
+	}
+	if events != 1 {
+		t.Errorf("got %d events before the timeout, want 1", events)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("gave up after %v", elapsed)
+	}
+}
@@ -0,0 +1,513 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ─── Tracing ────────────────────────────────────────────────────────────────
+//
+// With HAWKEYE_OTEL_EXPORTER set, every run records one root span for the
+// command, a client span per API request and a span per prompt stream
+// annotated with its SSE events. Spans are sent to an OpenTelemetry
+// collector as OTLP/HTTP JSON when the run ends, and each request carries
+// a W3C traceparent header so backend traces join the same trace.
+//
+// Tracing is off unless StartTracing finds the variable, and every span
+// method is a no-op on a nil span.
+
+// Tracing environment variables. The last three are the standard
+// OpenTelemetry ones.
+const (
+	TracingEnv         = "HAWKEYE_OTEL_EXPORTER"
+	tracingHeadersEnv  = "OTEL_EXPORTER_OTLP_HEADERS"
+	tracingServiceEnv  = "OTEL_SERVICE_NAME"
+	tracingParentEnv   = "TRACEPARENT"
+	tracingScope       = "hawkeye-cli/internal/api"
+	tracingBatchSize   = 256
+	tracingExportLimit = 5 * time.Second
+	// maxSpanEvents bounds the events kept on one span; a long stream
+	// sends thousands of deltas.
+	maxSpanEvents = 1000
+)
+
+// OTLP span kinds and status codes.
+const (
+	spanKindInternal = 1
+	spanKindClient   = 3
+	statusError      = 2
+)
+
+var activeTracer *tracer
+
+type tracer struct {
+	endpoint string
+	headers  map[string]string
+	resource []otlpKeyValue
+	client   *http.Client
+
+	mu       sync.Mutex
+	root     *Span
+	finished []*Span
+	exports  sync.WaitGroup
+	errs     []error
+}
+
+// Span is one timed operation in a trace.
+type Span struct {
+	t       *tracer
+	traceID [16]byte
+	spanID  [8]byte
+	parent  [8]byte
+	name    string
+	kind    int
+	start   time.Time
+
+	mu      sync.Mutex
+	end     time.Time
+	attrs   []otlpKeyValue
+	events  []otlpEvent
+	dropped int
+	errMsg  string
+	ended   bool
+}
+
+// StartTracing enables tracing when HAWKEYE_OTEL_EXPORTER names a
+// collector, opening the root span for the run. It returns an error for
+// an unusable exporter URL; tracing stays off in that case.
+func StartTracing(name, version string) error {
+	endpoint, err := ParseOTLPEndpoint(os.Getenv(TracingEnv))
+	if err != nil || endpoint == "" {
+		return err
+	}
+	service := os.Getenv(tracingServiceEnv)
+	if service == "" {
+		service = "hawkeye-cli"
+	}
+	t := &tracer{
+		endpoint: endpoint,
+		headers:  parseOTLPHeaders(os.Getenv(tracingHeadersEnv)),
+		resource: []otlpKeyValue{
+			stringAttr("service.name", service),
+			stringAttr("service.version", version),
+		},
+		client: &http.Client{Timeout: tracingExportLimit},
+	}
+	root := t.newSpan(name, spanKindInternal, nil)
+	if traceID, parent, ok := parseTraceparent(os.Getenv(tracingParentEnv)); ok {
+		root.traceID, root.parent = traceID, parent
+	}
+	t.root = root
+	activeTracer = t
+	return nil
+}
+
+// EndTracing ends the root span, recording err on it, and sends every
+// finished span to the collector. Export failures are returned so the
+// caller can mention them; they never affect the command's result.
+func EndTracing(err error) error {
+	t := activeTracer
+	if t == nil {
+		return nil
+	}
+	activeTracer = nil
+	t.root.RecordError(err)
+	t.root.End()
+
+	t.mu.Lock()
+	batch := t.finished
+	t.finished = nil
+	t.mu.Unlock()
+	if len(batch) > 0 {
+		t.export(batch)
+	}
+	t.exports.Wait()
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if len(t.errs) > 0 {
+		return fmt.Errorf("exporting traces to %s: %w", t.endpoint, t.errs[0])
+	}
+	return nil
+}
+
+// ParseOTLPEndpoint turns an exporter setting into the collector's trace
+// URL. otlp://host:port and otlps://host:port mean OTLP/HTTP over http and
+// https on the default /v1/traces path; http and https URLs are used as
+// given, adding the path if they have none. An empty setting returns "".
+func ParseOTLPEndpoint(s string) (string, error) {
+	if s == "" {
+		return "", nil
+	}
+	u, err := url.Parse(s)
+	if err != nil || u.Host == "" {
+		return "", fmt.Errorf("invalid %s %q", TracingEnv, s)
+	}
+	switch u.Scheme {
+	case "otlp":
+		u.Scheme = "http"
+	case "otlps":
+		u.Scheme = "https"
+	case "http", "https":
+	default:
+		return "", fmt.Errorf("%s %q must use otlp, otlps, http or https", TracingEnv, s)
+	}
+	if u.Path == "" || u.Path == "/" {
+		u.Path = "/v1/traces"
+	}
+	return u.String(), nil
+}
+
+// StartSpan starts a span as a child of the span in ctx, or of the root
+// span. It returns nil when tracing is off.
+func StartSpan(ctx context.Context, name string) (context.Context, *Span) {
+	t := activeTracer
+	if t == nil {
+		return ctx, nil
+	}
+	s := t.newSpan(name, spanKindInternal, spanFromContext(ctx))
+	return context.WithValue(ctx, spanKey{}, s), s
+}
+
+type spanKey struct{}
+
+func spanFromContext(ctx context.Context) *Span {
+	s, _ := ctx.Value(spanKey{}).(*Span)
+	return s
+}
+
+func (t *tracer) newSpan(name string, kind int, parent *Span) *Span {
+	s := &Span{t: t, name: name, kind: kind, start: time.Now()}
+	rand.Read(s.spanID[:])
+	if parent == nil {
+		parent = t.root
+	}
+	if parent != nil {
+		s.traceID, s.parent = parent.traceID, parent.spanID
+	} else {
+		rand.Read(s.traceID[:])
+	}
+	return s
+}
+
+// SetAttr records a string, bool or integer attribute on the span.
+func (s *Span) SetAttr(key string, value any) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.attrs = append(s.attrs, anyAttr(key, value))
+}
+
+// AddEvent records a timestamped event with attributes given as
+// alternating keys and values.
+func (s *Span) AddEvent(name string, kv ...any) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.events) >= maxSpanEvents {
+		s.dropped++
+		return
+	}
+	ev := otlpEvent{TimeUnixNano: unixNano(time.Now()), Name: name}
+	for i := 0; i+1 < len(kv); i += 2 {
+		ev.Attributes = append(ev.Attributes, anyAttr(fmt.Sprint(kv[i]), kv[i+1]))
+	}
+	s.events = append(s.events, ev)
+}
+
+// RecordError marks the span as failed. A nil err is ignored.
+func (s *Span) RecordError(err error) {
+	if s == nil || err == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.errMsg = err.Error()
+}
+
+// End finishes the span. Only the first call counts.
+func (s *Span) End() {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	if s.ended {
+		s.mu.Unlock()
+		return
+	}
+	s.ended = true
+	s.end = time.Now()
+	s.mu.Unlock()
+
+	t := s.t
+	t.mu.Lock()
+	t.finished = append(t.finished, s)
+	var batch []*Span
+	if len(t.finished) >= tracingBatchSize {
+		batch, t.finished = t.finished, nil
+	}
+	t.mu.Unlock()
+	if batch != nil {
+		t.exports.Add(1)
+		go func() {
+			defer t.exports.Done()
+			t.export(batch)
+		}()
+	}
+}
+
+// traceparent returns the W3C trace context header value for the span.
+func (s *Span) traceparent() string {
+	return "00-" + hex.EncodeToString(s.traceID[:]) + "-" + hex.EncodeToString(s.spanID[:]) + "-01"
+}
+
+func parseTraceparent(v string) (traceID [16]byte, spanID [8]byte, ok bool) {
+	parts := strings.Split(strings.TrimSpace(v), "-")
+	if len(parts) != 4 || len(parts[1]) != 32 || len(parts[2]) != 16 {
+		return traceID, spanID, false
+	}
+	if _, err := hex.Decode(traceID[:], []byte(parts[1])); err != nil {
+		return traceID, spanID, false
+	}
+	if _, err := hex.Decode(spanID[:], []byte(parts[2])); err != nil {
+		return traceID, spanID, false
+	}
+	return traceID, spanID, traceID != [16]byte{} && spanID != [8]byte{}
+}
+
+func parseOTLPHeaders(v string) map[string]string {
+	headers := map[string]string{}
+	for _, pair := range strings.Split(v, ",") {
+		k, val, ok := strings.Cut(pair, "=")
+		if !ok || strings.TrimSpace(k) == "" {
+			continue
+		}
+		if unescaped, err := url.QueryUnescape(strings.TrimSpace(val)); err == nil {
+			val = unescaped
+		}
+		headers[strings.TrimSpace(k)] = val
+	}
+	return headers
+}
+
+// ─── HTTP instrumentation ───────────────────────────────────────────────────
+
+// tracingTransport opens a client span per request, ending it when the
+// response body is closed so streamed responses are timed in full.
+type tracingTransport struct {
+	base http.RoundTripper
+}
+
+func (tt tracingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t := activeTracer
+	if t == nil {
+		return tt.base.RoundTrip(req)
+	}
+	s := t.newSpan(req.Method+" "+spanRoute(req.URL.Path), spanKindClient, spanFromContext(req.Context()))
+	s.SetAttr("http.request.method", req.Method)
+	s.SetAttr("url.full", req.URL.Scheme+"://"+req.URL.Host+req.URL.Path)
+	s.SetAttr("server.address", req.URL.Hostname())
+
+	req = req.Clone(req.Context())
+	req.Header.Set("traceparent", s.traceparent())
+	resp, err := tt.base.RoundTrip(req)
+	if err != nil {
+		s.RecordError(err)
+		s.End()
+		return nil, err
+	}
+	s.SetAttr("http.response.status_code", resp.StatusCode)
+	if resp.StatusCode >= 400 {
+		s.RecordError(fmt.Errorf("%s", resp.Status))
+	}
+	resp.Body = &spanBody{ReadCloser: resp.Body, span: s}
+	return resp, nil
+}
+
+type spanBody struct {
+	io.ReadCloser
+	span *Span
+}
+
+func (b *spanBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.span.End()
+	return err
+}
+
+// spanRoute shortens a request path to a low-cardinality span name by
+// replacing UUIDs with {id}.
+func spanRoute(path string) string {
+	segs := strings.Split(path, "/")
+	for i, seg := range segs {
+		id, suffix, _ := strings.Cut(seg, ":")
+		if len(id) == 36 && strings.Count(id, "-") == 4 {
+			segs[i] = "{id}"
+			if suffix != "" {
+				segs[i] += ":" + suffix
+			}
+		}
+	}
+	return strings.Join(segs, "/")
+}
+
+// ─── OTLP/HTTP JSON export ──────────────────────────────────────────────────
+
+type otlpKeyValue struct {
+	Key   string    `json:"key"`
+	Value otlpValue `json:"value"`
+}
+
+type otlpValue struct {
+	StringValue *string `json:"stringValue,omitempty"`
+	IntValue    *string `json:"intValue,omitempty"`
+	BoolValue   *bool   `json:"boolValue,omitempty"`
+}
+
+type otlpEvent struct {
+	TimeUnixNano string         `json:"timeUnixNano"`
+	Name         string         `json:"name"`
+	Attributes   []otlpKeyValue `json:"attributes,omitempty"`
+}
+
+type otlpStatus struct {
+	Code    int    `json:"code,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+type otlpSpan struct {
+	TraceID            string         `json:"traceId"`
+	SpanID             string         `json:"spanId"`
+	ParentSpanID       string         `json:"parentSpanId,omitempty"`
+	Name               string         `json:"name"`
+	Kind               int            `json:"kind"`
+	StartTimeUnixNano  string         `json:"startTimeUnixNano"`
+	EndTimeUnixNano    string         `json:"endTimeUnixNano"`
+	Attributes         []otlpKeyValue `json:"attributes,omitempty"`
+	Events             []otlpEvent    `json:"events,omitempty"`
+	DroppedEventsCount int            `json:"droppedEventsCount,omitempty"`
+	Status             otlpStatus     `json:"status"`
+}
+
+type otlpExport struct {
+	ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+}
+
+type otlpResourceSpans struct {
+	Resource   otlpResource     `json:"resource"`
+	ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+}
+
+type otlpResource struct {
+	Attributes []otlpKeyValue `json:"attributes"`
+}
+
+type otlpScopeSpans struct {
+	Scope otlpScope  `json:"scope"`
+	Spans []otlpSpan `json:"spans"`
+}
+
+type otlpScope struct {
+	Name string `json:"name"`
+}
+
+func (s *Span) otlp() otlpSpan {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := otlpSpan{
+		TraceID:            hex.EncodeToString(s.traceID[:]),
+		SpanID:             hex.EncodeToString(s.spanID[:]),
+		Name:               s.name,
+		Kind:               s.kind,
+		StartTimeUnixNano:  unixNano(s.start),
+		EndTimeUnixNano:    unixNano(s.end),
+		Attributes:         s.attrs,
+		Events:             s.events,
+		DroppedEventsCount: s.dropped,
+	}
+	if s.parent != [8]byte{} {
+		out.ParentSpanID = hex.EncodeToString(s.parent[:])
+	}
+	if s.errMsg != "" {
+		out.Status = otlpStatus{Code: statusError, Message: s.errMsg}
+	}
+	return out
+}
+
+func (t *tracer) export(batch []*Span) {
+	spans := make([]otlpSpan, len(batch))
+	for i, s := range batch {
+		spans[i] = s.otlp()
+	}
+	body, err := json.Marshal(otlpExport{ResourceSpans: []otlpResourceSpans{{
+		Resource:   otlpResource{Attributes: t.resource},
+		ScopeSpans: []otlpScopeSpans{{Scope: otlpScope{Name: tracingScope}, Spans: spans}},
+	}}})
+	if err == nil {
+		err = t.post(body)
+	}
+	if err != nil {
+		t.mu.Lock()
+		t.errs = append(t.errs, err)
+		t.mu.Unlock()
+	}
+}
+
+func (t *tracer) post(body []byte) error {
+	req, err := http.NewRequest("POST", t.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range t.headers {
+		req.Header.Set(k, v)
+	}
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("collector returned %d: %s", resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+	return nil
+}
+
+func stringAttr(key, value string) otlpKeyValue {
+	return otlpKeyValue{Key: key, Value: otlpValue{StringValue: &value}}
+}
+
+func anyAttr(key string, value any) otlpKeyValue {
+	switch v := value.(type) {
+	case bool:
+		return otlpKeyValue{Key: key, Value: otlpValue{BoolValue: &v}}
+	case int:
+		s := strconv.Itoa(v)
+		return otlpKeyValue{Key: key, Value: otlpValue{IntValue: &s}}
+	case int64:
+		s := strconv.FormatInt(v, 10)
+		return otlpKeyValue{Key: key, Value: otlpValue{IntValue: &s}}
+	}
+	return stringAttr(key, fmt.Sprint(value))
+}
+
+func unixNano(t time.Time) string {
+	return strconv.FormatInt(t.UnixNano(), 10)
+}
@@ -0,0 +1,143 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestParseOTLPEndpoint(t *testing.T) {
+	tests := []struct {
+		in, want, wantErr string
+	}{
+		{"", "", ""},
+		{"otlp://collector:4318", "http://collector:4318/v1/traces", ""},
+		{"otlps://collector.corp", "https://collector.corp/v1/traces", ""},
+		{"https://collector.corp/otlp/v1/traces", "https://collector.corp/otlp/v1/traces", ""},
+		{"grpc://collector:4317", "", "must use otlp"},
+		{"collector:4318", "", "invalid"},
+		{"otlp://", "", "invalid"},
+	}
+	for _, tt := range tests {
+		got, err := ParseOTLPEndpoint(tt.in)
+		if tt.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ParseOTLPEndpoint(%q) error = %v, want %q", tt.in, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("ParseOTLPEndpoint(%q) = %q, %v, want %q", tt.in, got, err, tt.want)
+		}
+	}
+}
+
+func TestSpanRoute(t *testing.T) {
+	tests := []struct{ in, want string }{
+		{"/api/v1/inference/session", "/api/v1/inference/session"},
+		{"/api/v1/gendb/project/66520f61-6a43-48ac-8286-a7e7cf9755c5", "/api/v1/gendb/project/{id}"},
+		{"/v1/inference/session/66520f61-6a43-48ac-8286-a7e7cf9755c5:rerun", "/v1/inference/session/{id}:rerun"},
+	}
+	for _, tt := range tests {
+		if got := spanRoute(tt.in); got != tt.want {
+			t.Errorf("spanRoute(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestTracingExport(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		exported otlpExport
+		auth     string
+	)
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		if r.URL.Path != "/v1/traces" {
+			t.Errorf("export path = %s", r.URL.Path)
+		}
+		auth = r.Header.Get("Authorization")
+		body, _ := io.ReadAll(r.Body)
+		if err := json.Unmarshal(body, &exported); err != nil {
+			t.Errorf("export body: %v", err)
+		}
+	}))
+	defer collector.Close()
+
+	var traceparent string
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get("traceparent")
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, "event: progress\ndata: {\"message\":{\"content\":{\"content_type\":\"CONTENT_TYPE_PROGRESS_STATUS\"}}}\n\n")
+		fmt.Fprint(w, "data: {\"message\":{\"end_turn\":true}}\n\n")
+	}))
+	defer backend.Close()
+
+	t.Setenv(TracingEnv, strings.Replace(collector.URL, "http://", "otlp://", 1))
+	t.Setenv(tracingHeadersEnv, "Authorization=Bearer%20abc")
+	t.Setenv(tracingParentEnv, "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01")
+	if err := StartTracing("hawkeye investigate", "1.2.3"); err != nil {
+		t.Fatalf("StartTracing() error = %v", err)
+	}
+
+	c := &Client{baseURL: backend.URL, httpClient: &http.Client{Transport: NewTransport(nil)}, token: "tok"}
+	if err := c.ProcessPromptStream("proj", "sess", "why?", func(*ProcessPromptResponse) {}); err != nil {
+		t.Fatalf("ProcessPromptStream() error = %v", err)
+	}
+	if err := EndTracing(nil); err != nil {
+		t.Fatalf("EndTracing() error = %v", err)
+	}
+	if activeTracer != nil {
+		t.Error("tracer still active after EndTracing")
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if auth != "Bearer abc" {
+		t.Errorf("collector Authorization = %q", auth)
+	}
+	if len(exported.ResourceSpans) != 1 || len(exported.ResourceSpans[0].ScopeSpans) != 1 {
+		t.Fatalf("export = %+v", exported)
+	}
+	spans := map[string]otlpSpan{}
+	for _, s := range exported.ResourceSpans[0].ScopeSpans[0].Spans {
+		spans[s.Name] = s
+	}
+	root, stream, call := spans["hawkeye investigate"], spans["hawkeye.prompt_stream"], spans["POST /v1/inference/session"]
+	if root.SpanID == "" || stream.SpanID == "" || call.SpanID == "" {
+		t.Fatalf("spans = %v", spans)
+	}
+	if root.TraceID != "0af7651916cd43dd8448eb211c80319c" || root.ParentSpanID != "b7ad6b7169203331" {
+		t.Errorf("root did not join TRACEPARENT: trace %s parent %s", root.TraceID, root.ParentSpanID)
+	}
+	if stream.ParentSpanID != root.SpanID || call.ParentSpanID != stream.SpanID {
+		t.Errorf("span tree: stream parent %s (root %s), call parent %s (stream %s)",
+			stream.ParentSpanID, root.SpanID, call.ParentSpanID, stream.SpanID)
+	}
+	if call.Kind != spanKindClient || traceparent != "00-"+call.TraceID+"-"+call.SpanID+"-01" {
+		t.Errorf("call kind %d, backend saw traceparent %q", call.Kind, traceparent)
+	}
+	if len(stream.Events) != 2 || stream.Events[0].Name != "sse.progress" || stream.Events[1].Name != "sse.message" {
+		t.Errorf("stream events = %+v", stream.Events)
+	}
+}
+
+func TestTracingOff(t *testing.T) {
+	t.Setenv(TracingEnv, "")
+	if err := StartTracing("hawkeye", "dev"); err != nil || activeTracer != nil {
+		t.Fatalf("StartTracing() = %v, tracer %v; want off", err, activeTracer)
+	}
+	_, span := StartSpan(t.Context(), "noop")
+	span.SetAttr("k", 1)
+	span.AddEvent("e")
+	span.End()
+	if err := EndTracing(nil); err != nil {
+		t.Errorf("EndTracing() error = %v", err)
+	}
+}
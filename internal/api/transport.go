@@ -0,0 +1,130 @@
+package api
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+
+	"hawkeye-cli/internal/config"
+)
+
+// ─── Transport: proxy, custom CA, client certificates ───────────────────────
+//
+// Corporate networks put a proxy, often with TLS interception, between the
+// CLI and the backend. The proxy, extra CA bundle and client certificate
+// come from the profile; --proxy and --insecure-skip-verify override them
+// for one run. Without a configured proxy, HTTPS_PROXY and friends apply.
+
+var (
+	proxyOverride      string
+	insecureSkipVerify bool
+)
+
+// SetProxy sends every request through proxyURL for the rest of the
+// process, overriding the profile's proxy and the environment.
+func SetProxy(proxyURL string) { proxyOverride = proxyURL }
+
+// SetInsecureSkipVerify turns off TLS certificate verification for the
+// rest of the process.
+func SetInsecureSkipVerify(on bool) { insecureSkipVerify = on }
+
+// NewTransport returns the HTTP transport for cfg's proxy and TLS settings.
+// cfg may be nil for the process-wide overrides only. A setting that fails
+// to load, such as a missing CA file, makes every request fail with the
+// reason rather than quietly falling back to the defaults. Requests are
+// traced when tracing is on. With HAWKEYE_FIXTURES set, requests are
+// answered from fixture files instead (see fixtures.go).
+func NewTransport(cfg *config.Config) http.RoundTripper {
+	if dir := os.Getenv(FixturesEnv); dir != "" {
+		return fixtureTransport{dir: dir}
+	}
+	t, err := buildTransport(cfg)
+	if err != nil {
+		return tracingTransport{errTransport{err}}
+	}
+	return tracingTransport{t}
+}
+
+// CheckTransport reports whether cfg's proxy and TLS settings load.
+func CheckTransport(cfg *config.Config) error {
+	_, err := buildTransport(cfg)
+	return err
+}
+
+type errTransport struct{ err error }
+
+func (e errTransport) RoundTrip(*http.Request) (*http.Response, error) {
+	return nil, fmt.Errorf("configuring HTTP transport: %w", e.err)
+}
+
+func buildTransport(cfg *config.Config) (*http.Transport, error) {
+	var proxy, caCert, clientCert, clientKey string
+	if cfg != nil {
+		proxy, caCert, clientCert, clientKey = cfg.Proxy, cfg.CACert, cfg.ClientCert, cfg.ClientKey
+	}
+	if proxyOverride != "" {
+		proxy = proxyOverride
+	}
+
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	if proxy != "" {
+		u, err := ParseProxyURL(proxy)
+		if err != nil {
+			return nil, err
+		}
+		t.Proxy = http.ProxyURL(u)
+	}
+
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12, InsecureSkipVerify: insecureSkipVerify}
+	if caCert != "" {
+		pool, err := LoadCAPool(caCert)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.RootCAs = pool
+	}
+	if clientCert != "" || clientKey != "" {
+		if clientCert == "" || clientKey == "" {
+			return nil, fmt.Errorf("client-cert and client-key must be set together")
+		}
+		pair, err := tls.LoadX509KeyPair(clientCert, clientKey)
+		if err != nil {
+			return nil, fmt.Errorf("loading client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{pair}
+	}
+	t.TLSClientConfig = tlsConfig
+	return t, nil
+}
+
+// ParseProxyURL validates an http, https or socks5 proxy URL.
+func ParseProxyURL(s string) (*url.URL, error) {
+	u, err := url.Parse(s)
+	if err != nil || u.Host == "" {
+		return nil, fmt.Errorf("invalid proxy URL %q", s)
+	}
+	switch u.Scheme {
+	case "http", "https", "socks5":
+		return u, nil
+	}
+	return nil, fmt.Errorf("proxy URL %q must use http, https or socks5", s)
+}
+
+// LoadCAPool returns the system roots plus the PEM certificates in path.
+func LoadCAPool(path string) (*x509.CertPool, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading CA certificate: %w", err)
+	}
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(data) {
+		return nil, fmt.Errorf("no PEM certificates found in %s", path)
+	}
+	return pool, nil
+}
@@ -0,0 +1,197 @@
+package api
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"hawkeye-cli/internal/config"
+)
+
+// writeTestCert writes a self-signed certificate valid for 127.0.0.1 and
+// its key as PEM files, returning their paths and the parsed pair.
+func writeTestCert(t *testing.T, name string) (certPath, keyPath string, pair tls.Certificate) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1")},
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+
+	dir := t.TempDir()
+	certPath, keyPath = filepath.Join(dir, name+".crt"), filepath.Join(dir, name+".key")
+	if err := os.WriteFile(certPath, certPEM, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(keyPath, keyPEM, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	pair, err = tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return certPath, keyPath, pair
+}
+
+func resetTransportOverrides(t *testing.T) {
+	t.Cleanup(func() {
+		SetProxy("")
+		SetInsecureSkipVerify(false)
+	})
+}
+
+func TestTransportCustomCA(t *testing.T) {
+	resetTransportOverrides(t)
+	caPath, _, serverPair := writeTestCert(t, "server")
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	srv.TLS = &tls.Config{Certificates: []tls.Certificate{serverPair}}
+	srv.StartTLS()
+	defer srv.Close()
+
+	get := func(cfg *config.Config) error {
+		resp, err := (&http.Client{Transport: NewTransport(cfg)}).Get(srv.URL)
+		if err == nil {
+			resp.Body.Close()
+		}
+		return err
+	}
+
+	if err := get(&config.Config{}); err == nil {
+		t.Error("request to an untrusted server succeeded without ca-cert")
+	}
+	if err := get(&config.Config{CACert: caPath}); err != nil {
+		t.Errorf("request with ca-cert failed: %v", err)
+	}
+	SetInsecureSkipVerify(true)
+	if err := get(&config.Config{}); err != nil {
+		t.Errorf("request with --insecure-skip-verify failed: %v", err)
+	}
+}
+
+func TestTransportClientCert(t *testing.T) {
+	resetTransportOverrides(t)
+	caPath, _, serverPair := writeTestCert(t, "server")
+	certPath, keyPath, _ := writeTestCert(t, "client")
+
+	var gotClient string
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if len(r.TLS.PeerCertificates) > 0 {
+			gotClient = r.TLS.PeerCertificates[0].Subject.CommonName
+		}
+	}))
+	srv.TLS = &tls.Config{Certificates: []tls.Certificate{serverPair}, ClientAuth: tls.RequireAnyClientCert}
+	srv.StartTLS()
+	defer srv.Close()
+
+	cfg := &config.Config{CACert: caPath, ClientCert: certPath, ClientKey: keyPath}
+	resp, err := (&http.Client{Transport: NewTransport(cfg)}).Get(srv.URL)
+	if err != nil {
+		t.Fatalf("mTLS request failed: %v", err)
+	}
+	resp.Body.Close()
+	if gotClient != "client" {
+		t.Errorf("server saw client certificate %q, want %q", gotClient, "client")
+	}
+}
+
+func TestTransportProxy(t *testing.T) {
+	resetTransportOverrides(t)
+	var proxied string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = r.URL.String()
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer proxy.Close()
+
+	get := func(cfg *config.Config) {
+		t.Helper()
+		proxied = ""
+		resp, err := (&http.Client{Transport: NewTransport(cfg)}).Get("http://backend.invalid/api/v1/ping")
+		if err != nil {
+			t.Fatalf("proxied request failed: %v", err)
+		}
+		resp.Body.Close()
+		if proxied != "http://backend.invalid/api/v1/ping" {
+			t.Errorf("proxy saw %q", proxied)
+		}
+	}
+
+	get(&config.Config{Proxy: proxy.URL})
+	SetProxy(proxy.URL)
+	get(&config.Config{Proxy: "http://unreachable.invalid:1"})
+}
+
+func TestTransportErrors(t *testing.T) {
+	resetTransportOverrides(t)
+	certPath, _, _ := writeTestCert(t, "client")
+	notPEM := filepath.Join(t.TempDir(), "ca.txt")
+	os.WriteFile(notPEM, []byte("not a certificate"), 0o600)
+
+	tests := []struct {
+		name    string
+		cfg     config.Config
+		wantErr string
+	}{
+		{"defaults", config.Config{}, ""},
+		{"bad proxy scheme", config.Config{Proxy: "ftp://proxy:21"}, "must use http, https or socks5"},
+		{"proxy without host", config.Config{Proxy: "proxy.corp:3128"}, "invalid proxy URL"},
+		{"missing CA file", config.Config{CACert: "/nonexistent/ca.pem"}, "reading CA certificate"},
+		{"CA file without PEM", config.Config{CACert: notPEM}, "no PEM certificates"},
+		{"cert without key", config.Config{ClientCert: certPath}, "must be set together"},
+		{"key is not a key", config.Config{ClientCert: certPath, ClientKey: certPath}, "loading client certificate"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckTransport(&tt.cfg)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("CheckTransport() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("CheckTransport() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+
+	_, err := NewTransport(&config.Config{CACert: "/nonexistent/ca.pem"}).RoundTrip(httptest.NewRequest("GET", "http://x/", nil))
+	if err == nil || !strings.Contains(err.Error(), "configuring HTTP transport") {
+		t.Errorf("RoundTrip() error = %v, want the configuration error", err)
+	}
+}
@@ -0,0 +1,110 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// ─── Action items ───────────────────────────────────────────────────────────
+//
+// `hawkeye actions` tracks the action items of investigation summaries. The
+// backend has no completion state for them, so it is kept per profile in
+// actions.json (actions-<profile>.json) next to the config, together with
+// the last listing so `actions done <n>` refers to the numbers last shown.
+
+// ActionRef identifies one action item of a session's summary.
+type ActionRef struct {
+	Key         string `json:"key"` // see service.ActionKey
+	SessionUUID string `json:"session_uuid"`
+	SessionName string `json:"session_name,omitempty"`
+	Text        string `json:"text"`
+}
+
+// ActionStore is the local action-item state of a profile.
+type ActionStore struct {
+	Listing []ActionRef          `json:"listing,omitempty"` // as last listed, numbered from 1
+	Done    map[string]time.Time `json:"done,omitempty"`    // key → when it was marked done
+
+	profile string
+}
+
+func actionsPath(profile string) (string, error) {
+	base, err := configBase()
+	if err != nil {
+		return "", err
+	}
+	filename := "actions.json"
+	if profile != "" {
+		filename = fmt.Sprintf("actions-%s.json", profile)
+	}
+	return filepath.Join(base, filename), nil
+}
+
+// LoadActions reads a profile's action store. A missing file is an empty
+// store.
+func LoadActions(profile string) (*ActionStore, error) {
+	s := &ActionStore{profile: profile}
+	path, err := actionsPath(profile)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, s); err != nil {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+	return s, nil
+}
+
+// Save writes the action store.
+func (s *ActionStore) Save() error {
+	path, err := actionsPath(s.profile)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0600)
+}
+
+// DoneAt returns when an item was marked done, or the zero time.
+func (s *ActionStore) DoneAt(key string) time.Time {
+	return s.Done[key]
+}
+
+// SetDone marks an item done at the given time, or open again when done is
+// false.
+func (s *ActionStore) SetDone(key string, done bool, at time.Time) {
+	if !done {
+		delete(s.Done, key)
+		return
+	}
+	if s.Done == nil {
+		s.Done = map[string]time.Time{}
+	}
+	s.Done[key] = at
+}
+
+// Item returns the nth item (from 1) of the last listing.
+func (s *ActionStore) Item(n int) (ActionRef, error) {
+	if len(s.Listing) == 0 {
+		return ActionRef{}, fmt.Errorf("no action items listed yet — run: hawkeye actions list")
+	}
+	if n < 1 || n > len(s.Listing) {
+		return ActionRef{}, fmt.Errorf("no action item %d (the last listing has 1-%d)", n, len(s.Listing))
+	}
+	return s.Listing[n-1], nil
+}
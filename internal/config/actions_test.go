@@ -0,0 +1,49 @@
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func TestActionStoreRoundTrip(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("SNAP_USER_COMMON", "")
+
+	s, err := LoadActions("work")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Item(1); err == nil {
+		t.Fatal("Item(1) on an empty listing should fail")
+	}
+	s.Listing = []ActionRef{{Key: "s1:abc", SessionUUID: "s1", Text: "Add an alert"}}
+	at := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	s.SetDone("s1:abc", true, at)
+	if err := s.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := LoadActions("work")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ref, err := loaded.Item(1)
+	if err != nil || ref.Text != "Add an alert" {
+		t.Fatalf("Item(1) = %+v, %v", ref, err)
+	}
+	if _, err := loaded.Item(2); err == nil {
+		t.Error("Item(2) should be out of range")
+	}
+	if !loaded.DoneAt("s1:abc").Equal(at) {
+		t.Errorf("DoneAt = %v, want %v", loaded.DoneAt("s1:abc"), at)
+	}
+	loaded.SetDone("s1:abc", false, time.Time{})
+	if !loaded.DoneAt("s1:abc").IsZero() {
+		t.Error("SetDone(false) should reopen the item")
+	}
+
+	other, err := LoadActions("")
+	if err != nil || len(other.Listing) != 0 {
+		t.Errorf("default profile should have its own store, got %+v, %v", other, err)
+	}
+}
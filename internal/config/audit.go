@@ -0,0 +1,155 @@
+package config
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+)
+
+// ─── Audit log ──────────────────────────────────────────────────────────────
+//
+// Every command run is appended to audit.log in the config directory, one
+// JSON object per line, shared by all profiles. Entries are never rewritten,
+// so incident reviews can reconstruct what was asked from this machine and
+// when. Secret values in the arguments are redacted before they are written.
+
+const auditFile = "audit.log"
+
+// Audit statuses.
+const (
+	AuditOK    = "ok"
+	AuditError = "error"
+)
+
+// AuditEntry is one command invocation.
+type AuditEntry struct {
+	Time       time.Time `json:"time"`
+	Profile    string    `json:"profile"`
+	Command    string    `json:"command"`
+	Args       []string  `json:"args,omitempty"`
+	Status     string    `json:"status"`
+	Error      string    `json:"error,omitempty"`
+	DurationMS int64     `json:"duration_ms"`
+	Sessions   []string  `json:"sessions,omitempty"`
+}
+
+// AuditPath returns the location of the audit log.
+func AuditPath() (string, error) {
+	base, err := configBase()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(base, auditFile), nil
+}
+
+// AppendAudit adds an entry to the end of the audit log.
+func AppendAudit(e AuditEntry) error {
+	path, err := AuditPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0600)
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(append(data, '\n'))
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+// LoadAudit returns the entries recorded at or after since, oldest first.
+// A zero since returns everything. Lines that do not parse, such as one
+// cut short by a crash, are skipped.
+func LoadAudit(since time.Time) ([]AuditEntry, error) {
+	path, err := AuditPath()
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var entries []AuditEntry
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var e AuditEntry
+		if json.Unmarshal(scanner.Bytes(), &e) != nil {
+			continue
+		}
+		if !since.IsZero() && e.Time.Before(since) {
+			continue
+		}
+		entries = append(entries, e)
+	}
+	return entries, scanner.Err()
+}
+
+// redacted replaces secret values in audit entries.
+const redacted = "[REDACTED]"
+
+// secretFlag matches flags whose value is a secret.
+var secretFlag = regexp.MustCompile(`^--?(p|password|passphrase|token|secret|api-key|routing-key)$`)
+
+// RedactArgs returns a copy of a command's arguments with secret values
+// replaced: the value after a secret flag (--password x, --token=x), the
+// token in "set token <value>", and passwords embedded in URLs. extra
+// names further secret flags, such as the credential flags of each
+// connection type.
+func RedactArgs(command string, args []string, extra ...string) []string {
+	isSecret := func(flag string) bool {
+		return secretFlag.MatchString(flag) || slices.Contains(extra, flag)
+	}
+	out := make([]string, len(args))
+	for i, a := range args {
+		out[i] = a
+		if name, _, ok := strings.Cut(a, "="); ok && isSecret(name) {
+			out[i] = name + "=" + redacted
+			continue
+		}
+		if i > 0 && isSecret(args[i-1]) {
+			out[i] = redacted
+			continue
+		}
+		out[i] = redactURL(a)
+	}
+	if command == "set" && len(out) > 1 && out[0] == "token" {
+		out[1] = redacted
+	}
+	return out
+}
+
+func redactURL(s string) string {
+	scheme, rest, ok := strings.Cut(s, "://")
+	if !ok {
+		return s
+	}
+	userinfo, host, ok := strings.Cut(rest, "@")
+	if !ok || strings.Contains(userinfo, "/") {
+		return s
+	}
+	user, _, hasPassword := strings.Cut(userinfo, ":")
+	if !hasPassword {
+		return s
+	}
+	return scheme + "://" + user + ":" + redacted + "@" + host
+}
@@ -5,22 +5,61 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
+	"runtime"
+	"slices"
+	"sort"
 	"strings"
+	"time"
 )
 
 const configDir = ".hawkeye"
 const configFile = "config.json"
 
 type Config struct {
-	Server      string `json:"server"`
-	FrontendURL string `json:"frontend_url,omitempty"`
-	Username    string `json:"username,omitempty"`
-	Token       string `json:"token,omitempty"`
-	OrgUUID     string `json:"org_uuid,omitempty"`
-	ProjectID   string `json:"project_uuid,omitempty"`
-	ProjectName string `json:"project_name,omitempty"`
-	LastSession string `json:"last_session,omitempty"`
-	Profile     string `json:"-"`
+	Version           int                  `json:"config_version,omitempty"` // schema version, see CurrentVersion
+	Server            string               `json:"server"`
+	FrontendURL       string               `json:"frontend_url,omitempty"`
+	Username          string               `json:"username,omitempty"`
+	Token             string               `json:"token,omitempty"`
+	OrgUUID           string               `json:"org_uuid,omitempty"`
+	UserRole          string               `json:"user_role,omitempty"` // role in OrgUUID, saved at login; "" when unknown
+	ProjectID         string               `json:"project_uuid,omitempty"`
+	ProjectName       string               `json:"project_name,omitempty"`
+	LastSession       string               `json:"last_session,omitempty"`
+	Timezone          string               `json:"timezone,omitempty"`
+	Theme             string               `json:"theme,omitempty"`
+	Banner            string               `json:"banner,omitempty"` // interactive startup banner; "" for the hawk
+	NoAutoName        bool                 `json:"no_auto_name,omitempty"`
+	Notifications     bool                 `json:"notifications,omitempty"`       // desktop notifications when investigations finish
+	CoTView           string               `json:"cot_view,omitempty"`            // interactive chain of thought: "" (collapsed) or "expanded"
+	Language          string               `json:"language,omitempty"`            // preferred response language, e.g. "ja"
+	Proxy             string               `json:"proxy,omitempty"`               // http(s) or socks5 proxy URL
+	CACert            string               `json:"ca_cert,omitempty"`             // extra trusted CA bundle (PEM)
+	ClientCert        string               `json:"client_cert,omitempty"`         // mTLS certificate (PEM)
+	ClientKey         string               `json:"client_key,omitempty"`          // mTLS private key (PEM)
+	TranscriptDir     string               `json:"transcript_dir,omitempty"`      // write a markdown transcript of every investigation here
+	Timeout           string               `json:"timeout,omitempty"`             // API call timeout, e.g. "60s"; "" for the default
+	StreamIdleTimeout string               `json:"stream_idle_timeout,omitempty"` // abort a prompt stream after this long without data
+	Aliases           map[string]string    `json:"aliases,omitempty"`             // name → session UUID
+	Tags              map[string][]string  `json:"tags,omitempty"`                // session UUID → tags
+	Defaults          map[string]string    `json:"defaults,omitempty"`            // "command.flag" → default value
+	Snoozed           map[string]time.Time `json:"snoozed,omitempty"`             // session UUID → hidden from triage until
+	Profile           string               `json:"-"`
+
+	seal     *sealKey                   // set when the profile is stored encrypted
+	warnings []string                   // problems noticed by Load
+	extra    map[string]json.RawMessage // unknown keys, written back by Save
+	saved    *[2]string                 // saved project UUID and name while UseProject is in effect
+}
+
+// UseProject switches the project for this run only; Save keeps writing
+// the project that was configured.
+func (c *Config) UseProject(uuid, name string) {
+	if c.saved == nil {
+		c.saved = &[2]string{c.ProjectID, c.ProjectName}
+	}
+	c.ProjectID, c.ProjectName = uuid, name
 }
 
 // ConsoleSessionURL returns the web console URL for a given session,
@@ -47,6 +86,177 @@ func (c *Config) ConsoleSessionURL(sessionID string) string {
 	return base + "/console/project/" + c.ProjectID + "/session/" + sessionID
 }
 
+var aliasNamePattern = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9._-]*$`)
+
+// ResolveSession returns the session UUID for an alias, or ref unchanged
+// when it is not an alias. Alias names are case-insensitive.
+func (c *Config) ResolveSession(ref string) string {
+	if c == nil {
+		return ref
+	}
+	if uuid, ok := c.Aliases[strings.ToLower(ref)]; ok {
+		return uuid
+	}
+	return ref
+}
+
+// SetAlias points name at a session UUID, replacing any existing alias of
+// that name. Names start with a letter and contain no spaces.
+func (c *Config) SetAlias(name, sessionUUID string) error {
+	if !aliasNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid alias %q: use letters, digits, '.', '_' or '-', starting with a letter", name)
+	}
+	if sessionUUID == "" {
+		return fmt.Errorf("alias %q needs a session UUID", name)
+	}
+	if c.Aliases == nil {
+		c.Aliases = map[string]string{}
+	}
+	c.Aliases[strings.ToLower(name)] = sessionUUID
+	return nil
+}
+
+// RemoveAlias deletes an alias and reports whether it existed.
+func (c *Config) RemoveAlias(name string) bool {
+	name = strings.ToLower(name)
+	if _, ok := c.Aliases[name]; !ok {
+		return false
+	}
+	delete(c.Aliases, name)
+	return true
+}
+
+// AliasNames returns the alias names in sorted order.
+func (c *Config) AliasNames() []string {
+	names := make([]string, 0, len(c.Aliases))
+	for name := range c.Aliases {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+var tagPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9._:/-]*$`)
+
+// AddTags tags a session. Tags are lowercased and kept sorted without
+// duplicates; they start with a letter or digit and contain no spaces.
+func (c *Config) AddTags(sessionUUID string, tags ...string) error {
+	if sessionUUID == "" {
+		return fmt.Errorf("tags need a session UUID")
+	}
+	merged := append([]string{}, c.Tags[sessionUUID]...)
+	for _, tag := range tags {
+		tag = strings.ToLower(tag)
+		if !tagPattern.MatchString(tag) {
+			return fmt.Errorf("invalid tag %q: use letters, digits, '.', '_', ':', '/' or '-'", tag)
+		}
+		if !slices.Contains(merged, tag) {
+			merged = append(merged, tag)
+		}
+	}
+	sort.Strings(merged)
+	if c.Tags == nil {
+		c.Tags = map[string][]string{}
+	}
+	c.Tags[sessionUUID] = merged
+	return nil
+}
+
+// RemoveTags removes tags from a session, or all of its tags when none are
+// given, and returns how many were removed.
+func (c *Config) RemoveTags(sessionUUID string, tags ...string) int {
+	current := c.Tags[sessionUUID]
+	if len(tags) == 0 {
+		delete(c.Tags, sessionUUID)
+		return len(current)
+	}
+	var kept []string
+	for _, tag := range current {
+		if !slices.ContainsFunc(tags, func(t string) bool { return strings.EqualFold(t, tag) }) {
+			kept = append(kept, tag)
+		}
+	}
+	if len(kept) == 0 {
+		delete(c.Tags, sessionUUID)
+	} else {
+		c.Tags[sessionUUID] = kept
+	}
+	return len(current) - len(kept)
+}
+
+// SessionTags returns a session's tags in sorted order.
+func (c *Config) SessionTags(sessionUUID string) []string {
+	if c == nil {
+		return nil
+	}
+	return c.Tags[sessionUUID]
+}
+
+// HasTags reports whether a session carries every one of tags.
+func (c *Config) HasTags(sessionUUID string, tags []string) bool {
+	have := c.SessionTags(sessionUUID)
+	for _, tag := range tags {
+		if !slices.Contains(have, strings.ToLower(tag)) {
+			return false
+		}
+	}
+	return true
+}
+
+// TagCounts returns how many sessions carry each tag.
+func (c *Config) TagCounts() map[string]int {
+	counts := map[string]int{}
+	for _, tags := range c.Tags {
+		for _, tag := range tags {
+			counts[tag]++
+		}
+	}
+	return counts
+}
+
+// Snooze hides a session from the triage board until the given time.
+// Expired snoozes are dropped whenever a new one is set.
+func (c *Config) Snooze(sessionUUID string, until time.Time) {
+	now := time.Now()
+	for id, t := range c.Snoozed {
+		if !t.After(now) {
+			delete(c.Snoozed, id)
+		}
+	}
+	if c.Snoozed == nil {
+		c.Snoozed = map[string]time.Time{}
+	}
+	c.Snoozed[sessionUUID] = until
+}
+
+// Unsnooze clears a session's snooze and reports whether it had one.
+func (c *Config) Unsnooze(sessionUUID string) bool {
+	if _, ok := c.Snoozed[sessionUUID]; !ok {
+		return false
+	}
+	delete(c.Snoozed, sessionUUID)
+	return true
+}
+
+// SnoozedUntil returns when a session's snooze ends, or the zero time if
+// it is not snoozed at now.
+func (c *Config) SnoozedUntil(sessionUUID string, now time.Time) time.Time {
+	if c == nil {
+		return time.Time{}
+	}
+	if t := c.Snoozed[sessionUUID]; t.After(now) {
+		return t
+	}
+	return time.Time{}
+}
+
+// windowsConfigDir is the directory under os.UserConfigDir (%AppData%)
+// holding profiles on Windows.
+const windowsConfigDir = "hawkeye"
+
+// configBase is the directory holding every profile: ~/.hawkeye, or
+// %AppData%\hawkeye on Windows. A %USERPROFILE%\.hawkeye left by an older
+// release keeps being used so upgrading does not lose profiles.
 func configBase() (string, error) {
 	if d := os.Getenv("SNAP_USER_COMMON"); d != "" {
 		return filepath.Join(d, configDir), nil
@@ -55,7 +265,24 @@ func configBase() (string, error) {
 	if err != nil {
 		return "", fmt.Errorf("cannot find home directory: %w", err)
 	}
-	return filepath.Join(home, configDir), nil
+	legacy := filepath.Join(home, configDir)
+	if runtime.GOOS != "windows" {
+		return legacy, nil
+	}
+	if _, err := os.Stat(legacy); err == nil {
+		return legacy, nil
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return legacy, nil
+	}
+	return filepath.Join(dir, windowsConfigDir), nil
+}
+
+// Dir returns the directory holding profiles and other local state, such
+// as the incident simulator's test_config.
+func Dir() (string, error) {
+	return configBase()
 }
 
 func configPath(profile string) (string, error) {
@@ -79,16 +306,55 @@ func Load(profile string) (*Config, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
 		if os.IsNotExist(err) {
-			return &Config{Profile: profile}, nil
+			return &Config{Profile: profile, Version: CurrentVersion}, nil
 		}
 		return nil, fmt.Errorf("reading config: %w", err)
 	}
 
+	var k *sealKey
+	if isEncrypted(data) {
+		passphrase, err := PassphraseFunc(profile)
+		if err != nil {
+			return nil, err
+		}
+		if data, k, err = openConfig(data, passphrase); err != nil {
+			return nil, fmt.Errorf("decrypting config: %w", err)
+		}
+	}
+
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("parsing config: %w", err)
+	}
+	from, err := migrate(raw)
+	if err != nil {
+		return nil, err
+	}
+	if data, err = json.Marshal(raw); err != nil {
+		return nil, fmt.Errorf("parsing config: %w", err)
+	}
+
 	var cfg Config
 	if err := json.Unmarshal(data, &cfg); err != nil {
 		return nil, fmt.Errorf("parsing config: %w", err)
 	}
 	cfg.Profile = profile
+	cfg.seal = k
+	for _, key := range unknownKeys(raw) {
+		if cfg.extra == nil {
+			cfg.extra = map[string]json.RawMessage{}
+		}
+		cfg.extra[key] = raw[key]
+		cfg.warnings = append(cfg.warnings, fmt.Sprintf("unknown config key %q in %s (kept but ignored)", key, path))
+	}
+	switch {
+	case from > CurrentVersion:
+		cfg.warnings = append(cfg.warnings, fmt.Sprintf("config version %d is newer than this hawkeye supports (%d); upgrade the CLI", from, CurrentVersion))
+	case from < CurrentVersion:
+		if err := cfg.Save(); err != nil {
+			cfg.warnings = append(cfg.warnings, fmt.Sprintf("could not save migrated config: %v", err))
+		}
+	}
 	return &cfg, nil
 }
 
@@ -102,10 +368,18 @@ func (c *Config) Save() error {
 		return fmt.Errorf("creating config directory: %w", err)
 	}
 
-	data, err := json.MarshalIndent(c, "", "  ")
+	if c.Version < CurrentVersion {
+		c.Version = CurrentVersion
+	}
+	data, err := c.marshal()
 	if err != nil {
 		return fmt.Errorf("marshaling config: %w", err)
 	}
+	if c.seal != nil {
+		if data, err = sealConfig(data, c.seal); err != nil {
+			return fmt.Errorf("encrypting config: %w", err)
+		}
+	}
 
 	if err := os.WriteFile(path, data, 0600); err != nil {
 		return fmt.Errorf("writing config: %w", err)
@@ -113,6 +387,32 @@ func (c *Config) Save() error {
 	return nil
 }
 
+// marshal encodes the config with any unknown keys it was loaded with, so
+// settings written by a newer version survive a save by an older one.
+func (c *Config) marshal() ([]byte, error) {
+	if c.saved != nil {
+		saved := *c
+		saved.ProjectID, saved.ProjectName = c.saved[0], c.saved[1]
+		saved.saved = nil
+		return saved.marshal()
+	}
+	if len(c.extra) == 0 {
+		return json.MarshalIndent(c, "", "  ")
+	}
+	data, err := json.Marshal(c)
+	if err != nil {
+		return nil, err
+	}
+	var merged map[string]json.RawMessage
+	if err := json.Unmarshal(data, &merged); err != nil {
+		return nil, err
+	}
+	for k, v := range c.extra {
+		merged[k] = v
+	}
+	return json.MarshalIndent(merged, "", "  ")
+}
+
 func (c *Config) profileFlag() string {
 	if c.Profile == "" {
 		return ""
@@ -188,7 +488,31 @@ func historyPath(profile string) (string, error) {
 	return filepath.Join(base, filename), nil
 }
 
+// HistoryEntry is one submitted prompt or command. SessionUUID is set for
+// prompts that ran an investigation, once the session is known.
+type HistoryEntry struct {
+	Prompt      string    `json:"prompt"`
+	SessionUUID string    `json:"session_uuid,omitempty"`
+	Time        time.Time `json:"time"`
+}
+
+// LoadHistory returns the saved prompts, oldest first.
 func LoadHistory(profile string) []string {
+	entries := LoadHistoryEntries(profile)
+	if entries == nil {
+		return nil
+	}
+	prompts := make([]string, len(entries))
+	for i, e := range entries {
+		prompts[i] = e.Prompt
+	}
+	return prompts
+}
+
+// LoadHistoryEntries returns the saved history, oldest first. Files written
+// before entries carried session UUIDs (a plain list of strings) are read
+// as prompts without metadata.
+func LoadHistoryEntries(profile string) []HistoryEntry {
 	path, err := historyPath(profile)
 	if err != nil {
 		return nil
@@ -197,9 +521,16 @@ func LoadHistory(profile string) []string {
 	if err != nil {
 		return nil
 	}
-	var entries []string
+	var entries []HistoryEntry
 	if json.Unmarshal(data, &entries) != nil {
-		return nil
+		var prompts []string
+		if json.Unmarshal(data, &prompts) != nil {
+			return nil
+		}
+		entries = make([]HistoryEntry, len(prompts))
+		for i, p := range prompts {
+			entries[i] = HistoryEntry{Prompt: p}
+		}
 	}
 	if len(entries) > maxHistory {
 		entries = entries[len(entries)-maxHistory:]
@@ -207,7 +538,18 @@ func LoadHistory(profile string) []string {
 	return entries
 }
 
+// SaveHistory replaces the saved history with entries.
 func SaveHistory(profile string, entries []string) error {
+	records := make([]HistoryEntry, len(entries))
+	for i, p := range entries {
+		records[i] = HistoryEntry{Prompt: p}
+	}
+	return SaveHistoryEntries(profile, records)
+}
+
+// SaveHistoryEntries replaces the saved history, keeping the newest
+// maxHistory entries.
+func SaveHistoryEntries(profile string, entries []HistoryEntry) error {
 	path, err := historyPath(profile)
 	if err != nil {
 		return err
@@ -224,3 +566,73 @@ func SaveHistory(profile string, entries []string) error {
 	}
 	return os.WriteFile(path, data, 0600)
 }
+
+// AppendHistory adds an entry. Repeating the most recent prompt refreshes
+// that entry instead of adding a duplicate.
+func AppendHistory(profile string, e HistoryEntry) error {
+	entries := LoadHistoryEntries(profile)
+	if n := len(entries); n > 0 && entries[n-1].Prompt == e.Prompt {
+		if e.SessionUUID == "" {
+			e.SessionUUID = entries[n-1].SessionUUID
+		}
+		entries[n-1] = e
+	} else {
+		entries = append(entries, e)
+	}
+	return SaveHistoryEntries(profile, entries)
+}
+
+// SetHistorySession records the session a prompt ran in, on the most recent
+// entry for that prompt.
+func SetHistorySession(profile, prompt, sessionUUID string) error {
+	entries := LoadHistoryEntries(profile)
+	for i := len(entries) - 1; i >= 0; i-- {
+		if entries[i].Prompt == prompt {
+			if entries[i].SessionUUID == sessionUUID {
+				return nil
+			}
+			entries[i].SessionUUID = sessionUUID
+			return SaveHistoryEntries(profile, entries)
+		}
+	}
+	return nil
+}
+
+// RecentHistory returns up to limit entries, newest first, keeping only
+// prompts that contain search (case-insensitive) when it is set.
+func RecentHistory(entries []HistoryEntry, search string, limit int) []HistoryEntry {
+	search = strings.ToLower(strings.TrimSpace(search))
+	var out []HistoryEntry
+	for i := len(entries) - 1; i >= 0 && len(out) < limit; i-- {
+		if search != "" && !strings.Contains(strings.ToLower(entries[i].Prompt), search) {
+			continue
+		}
+		out = append(out, entries[i])
+	}
+	return out
+}
+
+// ClearHistory deletes the saved history.
+func ClearHistory(profile string) error {
+	path, err := historyPath(profile)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// CacheDir returns the directory holding cached API responses for a
+// profile, e.g. ~/.hawkeye/cache/default.
+func CacheDir(profile string) (string, error) {
+	base, err := configBase()
+	if err != nil {
+		return "", err
+	}
+	if profile == "" {
+		profile = "default"
+	}
+	return filepath.Join(base, "cache", profile), nil
+}
@@ -4,7 +4,9 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestValidate(t *testing.T) {
@@ -337,6 +339,182 @@ func TestHistoryCap(t *testing.T) {
 	}
 }
 
+func TestHistoryLegacyFormat(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("SNAP_USER_COMMON", "")
+
+	dir := filepath.Join(home, configDir)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "history.json"), []byte(`["old one","old two"]`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	got := LoadHistoryEntries("")
+	if len(got) != 2 || got[1].Prompt != "old two" || got[1].SessionUUID != "" {
+		t.Errorf("LoadHistoryEntries() = %+v", got)
+	}
+}
+
+func TestAppendHistory(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("SNAP_USER_COMMON", "")
+
+	now := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	steps := []HistoryEntry{
+		{Prompt: "why is checkout slow", Time: now},
+		{Prompt: "/sessions", Time: now},
+		{Prompt: "/sessions", Time: now.Add(time.Minute)}, // repeat refreshes
+		{Prompt: "why is checkout slow", Time: now.Add(2 * time.Minute)},
+	}
+	for _, e := range steps {
+		if err := AppendHistory("", e); err != nil {
+			t.Fatalf("AppendHistory() error = %v", err)
+		}
+	}
+	if err := SetHistorySession("", "why is checkout slow", "sess-2"); err != nil {
+		t.Fatalf("SetHistorySession() error = %v", err)
+	}
+
+	got := LoadHistoryEntries("")
+	if len(got) != 3 {
+		t.Fatalf("entries = %+v, want 3", got)
+	}
+	if !got[1].Time.Equal(now.Add(time.Minute)) {
+		t.Errorf("repeated entry time = %v", got[1].Time)
+	}
+	if got[0].SessionUUID != "" || got[2].SessionUUID != "sess-2" {
+		t.Errorf("session only belongs on the newest match: %+v", got)
+	}
+
+	recent := RecentHistory(got, "", 2)
+	if len(recent) != 2 || recent[0].Prompt != "why is checkout slow" || recent[1].Prompt != "/sessions" {
+		t.Errorf("RecentHistory() = %+v", recent)
+	}
+	if found := RecentHistory(got, "CHECKOUT", 10); len(found) != 2 {
+		t.Errorf("RecentHistory(search) = %+v, want 2 entries", found)
+	}
+
+	if err := ClearHistory(""); err != nil {
+		t.Fatalf("ClearHistory() error = %v", err)
+	}
+	if got := LoadHistoryEntries(""); got != nil {
+		t.Errorf("after clear = %+v", got)
+	}
+}
+
+func TestSessionAliases(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("SNAP_USER_COMMON", "")
+
+	cfg := &Config{Profile: "dev"}
+	if err := cfg.SetAlias("Payments-Outage", "sess-1"); err != nil {
+		t.Fatalf("SetAlias() error = %v", err)
+	}
+	for _, bad := range []string{"", "9lives", "has space", "-flag"} {
+		if err := cfg.SetAlias(bad, "sess-2"); err == nil {
+			t.Errorf("SetAlias(%q) accepted an invalid name", bad)
+		}
+	}
+	if err := cfg.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := Load("dev")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		ref  string
+		want string
+	}{
+		{"payments-outage", "sess-1"},
+		{"PAYMENTS-OUTAGE", "sess-1"},
+		{"0b7e-uuid", "0b7e-uuid"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := loaded.ResolveSession(tt.ref); got != tt.want {
+			t.Errorf("ResolveSession(%q) = %q, want %q", tt.ref, got, tt.want)
+		}
+	}
+
+	if !loaded.RemoveAlias("payments-outage") || loaded.RemoveAlias("payments-outage") {
+		t.Error("RemoveAlias() should succeed once")
+	}
+	if other, _ := Load(""); other.ResolveSession("payments-outage") != "payments-outage" {
+		t.Error("aliases leaked into another profile")
+	}
+	var nilCfg *Config
+	if got := nilCfg.ResolveSession("x"); got != "x" {
+		t.Errorf("nil config ResolveSession = %q", got)
+	}
+}
+
+func TestSessionTags(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("SNAP_USER_COMMON", "")
+
+	cfg := &Config{Profile: "dev"}
+	if err := cfg.AddTags("sess-1", "SEV1", "payments"); err != nil {
+		t.Fatalf("AddTags() error = %v", err)
+	}
+	if err := cfg.AddTags("sess-1", "sev1", "team:core"); err != nil {
+		t.Fatalf("AddTags() error = %v", err)
+	}
+	if err := cfg.AddTags("sess-2", "payments"); err != nil {
+		t.Fatalf("AddTags() error = %v", err)
+	}
+	for _, bad := range []string{"", "has space", "-flag"} {
+		if err := cfg.AddTags("sess-3", bad); err == nil {
+			t.Errorf("AddTags(%q) accepted an invalid tag", bad)
+		}
+	}
+	if err := cfg.AddTags("", "sev1"); err == nil {
+		t.Error("AddTags() accepted an empty session UUID")
+	}
+	if err := cfg.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := Load("dev")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(loaded.SessionTags("sess-1"), ","); got != "payments,sev1,team:core" {
+		t.Errorf("SessionTags(sess-1) = %s", got)
+	}
+	tests := []struct {
+		session string
+		tags    []string
+		want    bool
+	}{
+		{"sess-1", []string{"sev1", "payments"}, true},
+		{"sess-1", []string{"Sev1"}, true},
+		{"sess-2", []string{"sev1", "payments"}, false},
+		{"sess-3", nil, true},
+	}
+	for _, tt := range tests {
+		if got := loaded.HasTags(tt.session, tt.tags); got != tt.want {
+			t.Errorf("HasTags(%s, %v) = %v, want %v", tt.session, tt.tags, got, tt.want)
+		}
+	}
+	if counts := loaded.TagCounts(); counts["payments"] != 2 || counts["sev1"] != 1 {
+		t.Errorf("TagCounts() = %v", counts)
+	}
+
+	if n := loaded.RemoveTags("sess-1", "SEV1", "missing"); n != 1 {
+		t.Errorf("RemoveTags() = %d, want 1", n)
+	}
+	if n := loaded.RemoveTags("sess-2"); n != 1 || loaded.SessionTags("sess-2") != nil {
+		t.Errorf("RemoveTags(all) = %d, tags left %v", n, loaded.SessionTags("sess-2"))
+	}
+	if other, _ := Load(""); other.SessionTags("sess-1") != nil {
+		t.Error("tags leaked into another profile")
+	}
+}
+
 func contains(s, substr string) bool {
 	return len(s) >= len(substr) && searchString(s, substr)
 }
@@ -349,3 +527,27 @@ func searchString(s, substr string) bool {
 	}
 	return false
 }
+
+func TestUseProjectNotSaved(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	cfg := &Config{Server: "http://example.com", ProjectID: "proj-a", ProjectName: "A"}
+	cfg.UseProject("proj-b", "B")
+	cfg.UseProject("proj-c", "C")
+	cfg.LastSession = "sess-1"
+	if err := cfg.Save(); err != nil {
+		t.Fatal(err)
+	}
+	if cfg.ProjectID != "proj-c" {
+		t.Errorf("ProjectID = %q after Save, want the override", cfg.ProjectID)
+	}
+
+	loaded, err := Load("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded.ProjectID != "proj-a" || loaded.ProjectName != "A" || loaded.LastSession != "sess-1" {
+		t.Errorf("loaded %q %q %q, want the configured project and the new last session",
+			loaded.ProjectID, loaded.ProjectName, loaded.LastSession)
+	}
+}
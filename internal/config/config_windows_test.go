@@ -0,0 +1,37 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestConfigBaseWindows(t *testing.T) {
+	home, appData := t.TempDir(), t.TempDir()
+	t.Setenv("SNAP_USER_COMMON", "")
+	t.Setenv("USERPROFILE", home)
+	t.Setenv("APPDATA", appData)
+
+	base, err := configBase()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := filepath.Join(appData, windowsConfigDir); base != want {
+		t.Errorf("configBase() = %q, want %q", base, want)
+	}
+
+	if err := (&Config{Server: "http://example.com"}).Save(); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(appData, windowsConfigDir, configFile)); err != nil {
+		t.Errorf("config not written under %%AppData%%: %v", err)
+	}
+
+	legacy := filepath.Join(home, configDir)
+	if err := os.MkdirAll(legacy, 0o700); err != nil {
+		t.Fatal(err)
+	}
+	if base, _ := configBase(); base != legacy {
+		t.Errorf("configBase() = %q, want existing %q", base, legacy)
+	}
+}
@@ -0,0 +1,139 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// ─── Per-command default flags ──────────────────────────────────────────────
+//
+// Defaults are keyed "command.flag" or "command.subcommand.flag", e.g.
+// "sessions.limit" or "connections.list.limit", and are added to a
+// command's arguments unless the flag is given explicitly. A value of
+// "true" adds a bare switch and "false" adds nothing.
+
+var defaultKeyPattern = regexp.MustCompile(`^[a-z][a-z-]*(\.[a-z][a-z-]*)?\.[a-z][a-z0-9-]*$`)
+
+// commandAliases maps alternative command names to the name defaults are
+// stored under.
+var commandAliases = map[string]string{"ask": "investigate", "td": "feedback", "aliases": "alias", "templates": "template"}
+
+// CanonicalCommand returns the name a command is recorded under, resolving
+// aliases such as ask for investigate.
+func CanonicalCommand(name string) string {
+	if canonical, ok := commandAliases[name]; ok {
+		return canonical
+	}
+	return name
+}
+
+// shortFlags are the one-letter spellings of long flags, so an explicit
+// -n suppresses a default --limit.
+var shortFlags = map[string]string{"limit": "-n", "session": "-s", "file": "-f", "reason": "-r"}
+
+// normalizeDefaultKey lowercases key, strips leading dashes from the flag
+// and resolves command aliases.
+func normalizeDefaultKey(key string) (string, error) {
+	key = strings.ToLower(strings.TrimSpace(key))
+	if i := strings.LastIndex(key, "."); i >= 0 {
+		key = key[:i+1] + strings.TrimLeft(key[i+1:], "-")
+	}
+	if !defaultKeyPattern.MatchString(key) {
+		return "", fmt.Errorf("invalid default %q: use command.flag, e.g. sessions.limit", key)
+	}
+	cmd, rest, _ := strings.Cut(key, ".")
+	return CanonicalCommand(cmd) + "." + rest, nil
+}
+
+// SetDefault stores a default value for a command flag.
+func (c *Config) SetDefault(key, value string) error {
+	key, err := normalizeDefaultKey(key)
+	if err != nil {
+		return err
+	}
+	if c.Defaults == nil {
+		c.Defaults = map[string]string{}
+	}
+	c.Defaults[key] = value
+	return nil
+}
+
+// UnsetDefault removes a default and reports whether it existed.
+func (c *Config) UnsetDefault(key string) bool {
+	key, err := normalizeDefaultKey(key)
+	if err != nil {
+		return false
+	}
+	if _, ok := c.Defaults[key]; !ok {
+		return false
+	}
+	delete(c.Defaults, key)
+	return true
+}
+
+// DefaultKeys returns the stored default keys in sorted order.
+func (c *Config) DefaultKeys() []string {
+	keys := make([]string, 0, len(c.Defaults))
+	for k := range c.Defaults {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// ApplyDefaults returns args, a command line starting with the command
+// name, with the stored defaults for that command appended. Flags already
+// present in args are left alone, so explicit flags always win. A
+// "command.flag" default is skipped when args[1] is one of subcommands,
+// which take only their own "command.subcommand.flag" defaults.
+func (c *Config) ApplyDefaults(args []string, subcommands []string) []string {
+	if len(args) == 0 || len(c.Defaults) == 0 {
+		return args
+	}
+	cmd := args[0]
+	if canonical, ok := commandAliases[cmd]; ok {
+		cmd = canonical
+	}
+	sub := ""
+	if len(args) > 1 {
+		sub = args[1]
+	}
+
+	out := append([]string(nil), args...)
+	for _, key := range c.DefaultKeys() {
+		parts := strings.Split(key, ".")
+		if parts[0] != cmd || (len(parts) == 3 && parts[1] != sub) || (len(parts) == 2 && slices.Contains(subcommands, sub)) {
+			continue
+		}
+		flag := parts[len(parts)-1]
+		if hasFlag(args[1:], flag) {
+			continue
+		}
+		switch value := c.Defaults[key]; strings.ToLower(value) {
+		case "false":
+		case "true":
+			out = append(out, "--"+flag)
+		default:
+			out = append(out, "--"+flag, value)
+		}
+	}
+	return out
+}
+
+// hasFlag reports whether args contain --flag, --flag=value or the flag's
+// short form.
+func hasFlag(args []string, flag string) bool {
+	short := shortFlags[flag]
+	for _, a := range args {
+		if a == "--" {
+			return false
+		}
+		if a == "--"+flag || strings.HasPrefix(a, "--"+flag+"=") || (short != "" && a == short) {
+			return true
+		}
+	}
+	return false
+}
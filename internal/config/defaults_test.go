@@ -0,0 +1,77 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSetDefault(t *testing.T) {
+	var c Config
+	for _, key := range []string{"sessions.limit", "Ask.--lang", "connections.list.limit"} {
+		if err := c.SetDefault(key, "x"); err != nil {
+			t.Errorf("SetDefault(%q) error = %v", key, err)
+		}
+	}
+	if got := strings.Join(c.DefaultKeys(), " "); got != "connections.list.limit investigate.lang sessions.limit" {
+		t.Errorf("DefaultKeys() = %s", got)
+	}
+	for _, key := range []string{"limit", "sessions.", ".limit", "sessions.list.all.limit", "sessions limit"} {
+		if err := c.SetDefault(key, "x"); err == nil {
+			t.Errorf("SetDefault(%q) accepted an invalid key", key)
+		}
+	}
+	if !c.UnsetDefault("investigate.lang") || c.UnsetDefault("investigate.lang") {
+		t.Error("UnsetDefault() should report removal once")
+	}
+}
+
+func TestApplyDefaults(t *testing.T) {
+	c := Config{Defaults: map[string]string{
+		"sessions.limit":          "50",
+		"sessions.uninvestigated": "true",
+		"sessions.pinned":         "false",
+		"investigate.debug":       "true",
+		"connections.list.limit":  "5",
+		"report.format":           "csv",
+	}}
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{[]string{"sessions"}, "sessions --limit 50 --uninvestigated"},
+		{[]string{"sessions", "-n", "3"}, "sessions -n 3 --uninvestigated"},
+		{[]string{"sessions", "--limit=3", "--uninvestigated"}, "sessions --limit=3 --uninvestigated"},
+		{[]string{"ask", "why?"}, "ask why? --debug"},
+		{[]string{"connections", "list"}, "connections list --limit 5"},
+		{[]string{"connections", "info", "abc"}, "connections info abc"},
+		{[]string{"report"}, "report --format csv"},
+		{[]string{"inspect", "abc"}, "inspect abc"},
+	}
+	for _, tt := range tests {
+		if got := strings.Join(c.ApplyDefaults(tt.args, nil), " "); got != tt.want {
+			t.Errorf("ApplyDefaults(%v) = %q, want %q", tt.args, got, tt.want)
+		}
+	}
+}
+
+func TestApplyDefaultsSubcommands(t *testing.T) {
+	c := Config{Defaults: map[string]string{
+		"sessions.limit":         "50",
+		"sessions.export.format": "jsonl",
+	}}
+	subcommands := []string{"tag", "export"}
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{[]string{"sessions"}, "sessions --limit 50"},
+		{[]string{"sessions", "--status", "done"}, "sessions --status done --limit 50"},
+		{[]string{"sessions", "tag", "abc", "prod"}, "sessions tag abc prod"},
+		{[]string{"sessions", "export"}, "sessions export --format jsonl"},
+	}
+	for _, tt := range tests {
+		if got := strings.Join(c.ApplyDefaults(tt.args, subcommands), " "); got != tt.want {
+			t.Errorf("ApplyDefaults(%v) = %q, want %q", tt.args, got, tt.want)
+		}
+	}
+}
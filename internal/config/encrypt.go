@@ -0,0 +1,159 @@
+package config
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// ─── Encryption at rest ─────────────────────────────────────────────────────
+//
+// An encrypted profile is a JSON envelope holding the AES-256-GCM sealed
+// config. The key is derived from a passphrase with PBKDF2-SHA256, so the
+// token and other secrets never reach disk in plaintext. The derived key is
+// kept on the loaded Config so Save re-encrypts without asking again.
+
+const encryptedFormat = "hawkeye-aes-gcm-v1"
+
+// PassphraseEnv names the environment variable that supplies the
+// passphrase non-interactively.
+const PassphraseEnv = "HAWKEYE_CONFIG_PASSPHRASE"
+
+// kdfIterations is the PBKDF2 work factor for newly encrypted files.
+var kdfIterations = 600_000
+
+// PassphraseFunc returns the passphrase for an encrypted profile. The
+// default reads PassphraseEnv; the CLI replaces it with a terminal prompt.
+var PassphraseFunc = func(profile string) (string, error) {
+	if p := os.Getenv(PassphraseEnv); p != "" {
+		return p, nil
+	}
+	return "", fmt.Errorf("config for profile %s is encrypted; set %s", ProfileName(profile), PassphraseEnv)
+}
+
+// ErrWrongPassphrase is returned when an encrypted config cannot be opened.
+var ErrWrongPassphrase = errors.New("wrong passphrase or corrupted config")
+
+type encryptedFile struct {
+	Format     string `json:"format"`
+	KDF        string `json:"kdf"`
+	Iterations int    `json:"iterations"`
+	Salt       []byte `json:"salt"`
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+// sealKey is the derived key and the parameters it was derived with.
+type sealKey struct {
+	key        []byte
+	salt       []byte
+	iterations int
+}
+
+func deriveKey(passphrase string, salt []byte, iterations int) (*sealKey, error) {
+	key, err := pbkdf2.Key(sha256.New, passphrase, salt, iterations, 32)
+	if err != nil {
+		return nil, err
+	}
+	return &sealKey{key: key, salt: salt, iterations: iterations}, nil
+}
+
+func newSealKey(passphrase string) (*sealKey, error) {
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	return deriveKey(passphrase, salt, kdfIterations)
+}
+
+// isEncrypted reports whether data is an encrypted config envelope.
+func isEncrypted(data []byte) bool {
+	if !bytes.Contains(data, []byte(encryptedFormat)) {
+		return false
+	}
+	var f encryptedFile
+	return json.Unmarshal(data, &f) == nil && f.Format == encryptedFormat
+}
+
+func sealConfig(plain []byte, k *sealKey) ([]byte, error) {
+	gcm, err := newGCM(k.key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(encryptedFile{
+		Format:     encryptedFormat,
+		KDF:        "pbkdf2-sha256",
+		Iterations: k.iterations,
+		Salt:       k.salt,
+		Nonce:      nonce,
+		Ciphertext: gcm.Seal(nil, nonce, plain, []byte(encryptedFormat)),
+	}, "", "  ")
+}
+
+func openConfig(data []byte, passphrase string) ([]byte, *sealKey, error) {
+	var f encryptedFile
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, nil, fmt.Errorf("parsing encrypted config: %w", err)
+	}
+	if f.KDF != "pbkdf2-sha256" || f.Iterations <= 0 {
+		return nil, nil, fmt.Errorf("unsupported config encryption (kdf %q)", f.KDF)
+	}
+	k, err := deriveKey(passphrase, f.Salt, f.Iterations)
+	if err != nil {
+		return nil, nil, err
+	}
+	gcm, err := newGCM(k.key)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(f.Nonce) != gcm.NonceSize() {
+		return nil, nil, ErrWrongPassphrase
+	}
+	plain, err := gcm.Open(nil, f.Nonce, f.Ciphertext, []byte(encryptedFormat))
+	if err != nil {
+		return nil, nil, ErrWrongPassphrase
+	}
+	return plain, k, nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// Encrypted reports whether the profile is stored encrypted.
+func (c *Config) Encrypted() bool { return c.seal != nil }
+
+// Encrypt stores the profile encrypted with a key derived from passphrase,
+// replacing any previous passphrase.
+func (c *Config) Encrypt(passphrase string) error {
+	if passphrase == "" {
+		return fmt.Errorf("passphrase must not be empty")
+	}
+	k, err := newSealKey(passphrase)
+	if err != nil {
+		return err
+	}
+	c.seal = k
+	return c.Save()
+}
+
+// Decrypt stores the profile as plaintext again.
+func (c *Config) Decrypt() error {
+	c.seal = nil
+	return c.Save()
+}
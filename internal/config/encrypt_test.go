@@ -0,0 +1,76 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestEncryptedConfig(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("HOME", tmpDir)
+	t.Setenv("SNAP_USER_COMMON", "")
+	t.Setenv(PassphraseEnv, "")
+	defer func(n int) { kdfIterations = n }(kdfIterations)
+	kdfIterations = 1000
+
+	cfg := &Config{Server: "http://example.com", Token: "secret-jwt", Profile: "prod"}
+	if err := cfg.Encrypt("correct horse"); err != nil {
+		t.Fatalf("Encrypt() error = %v", err)
+	}
+	path := filepath.Join(tmpDir, configDir, "config-prod.json")
+	readFile := func() string {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(data)
+	}
+	if data := readFile(); strings.Contains(data, "secret-jwt") || !strings.Contains(data, encryptedFormat) {
+		t.Fatalf("encrypted file = %s", data)
+	}
+
+	if _, err := Load("prod"); err == nil || !strings.Contains(err.Error(), PassphraseEnv) {
+		t.Errorf("Load() without passphrase error = %v, want hint to set %s", err, PassphraseEnv)
+	}
+
+	t.Setenv(PassphraseEnv, "wrong")
+	if _, err := Load("prod"); !errors.Is(err, ErrWrongPassphrase) {
+		t.Errorf("Load() with wrong passphrase error = %v, want ErrWrongPassphrase", err)
+	}
+
+	t.Setenv(PassphraseEnv, "correct horse")
+	loaded, err := Load("prod")
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if loaded.Token != "secret-jwt" || !loaded.Encrypted() {
+		t.Errorf("loaded = %+v, encrypted = %v", loaded, loaded.Encrypted())
+	}
+
+	// Saving a loaded encrypted profile keeps it encrypted.
+	loaded.Token = "rotated-jwt"
+	if err := loaded.Save(); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	if data := readFile(); strings.Contains(data, "rotated-jwt") {
+		t.Errorf("Save() wrote plaintext: %s", data)
+	}
+
+	if err := loaded.Decrypt(); err != nil {
+		t.Fatalf("Decrypt() error = %v", err)
+	}
+	if data := readFile(); !strings.Contains(data, "rotated-jwt") {
+		t.Errorf("Decrypt() left file encrypted: %s", data)
+	}
+	t.Setenv(PassphraseEnv, "")
+	if plain, err := Load("prod"); err != nil || plain.Encrypted() {
+		t.Errorf("Load() after Decrypt = %v, %v", plain, err)
+	}
+
+	if err := cfg.Encrypt(""); err == nil {
+		t.Error("Encrypt(\"\") error = nil")
+	}
+}
@@ -0,0 +1,58 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// ─── Export checkpoints ─────────────────────────────────────────────────────
+//
+// `sessions export` keeps its resume state in <output>.checkpoint, next to
+// the archive rather than in the config directory, so the two move
+// together. The state itself is the service layer's ExportCheckpoint; this
+// file only reads and writes it.
+
+// ExportCheckpointPath is where the checkpoint of an export to output lives.
+func ExportCheckpointPath(output string) string {
+	return output + ".checkpoint"
+}
+
+// LoadExportCheckpoint reads the checkpoint at path into cp. A missing
+// file is an error: there is nothing to resume.
+func LoadExportCheckpoint(path string, cp any) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("no export to resume (%s not found)", path)
+		}
+		return err
+	}
+	if err := json.Unmarshal(data, cp); err != nil {
+		return fmt.Errorf("reading %s: %w", path, err)
+	}
+	return nil
+}
+
+// SaveExportCheckpoint writes cp to path atomically, so an interrupt never
+// leaves a half-written checkpoint behind.
+func SaveExportCheckpoint(path string, cp any) error {
+	data, err := json.MarshalIndent(cp, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// RemoveExportCheckpoint deletes the checkpoint at path once an export
+// has finished. A checkpoint that is already gone is not an error.
+func RemoveExportCheckpoint(path string) error {
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
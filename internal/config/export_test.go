@@ -0,0 +1,35 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestExportCheckpointRoundTrip(t *testing.T) {
+	path := ExportCheckpointPath(filepath.Join(t.TempDir(), "out.ndjson"))
+	var cp struct {
+		NextStart int   `json:"next_start"`
+		Offset    int64 `json:"offset"`
+	}
+	if err := LoadExportCheckpoint(path, &cp); err == nil {
+		t.Error("a missing checkpoint should fail")
+	}
+	cp.NextStart, cp.Offset = 200, 4096
+	if err := SaveExportCheckpoint(path, cp); err != nil {
+		t.Fatal(err)
+	}
+	cp.NextStart, cp.Offset = 0, 0
+	if err := LoadExportCheckpoint(path, &cp); err != nil || cp.NextStart != 200 || cp.Offset != 4096 {
+		t.Fatalf("got %+v, %v", cp, err)
+	}
+	if err := RemoveExportCheckpoint(path); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("checkpoint still exists: %v", err)
+	}
+	if err := RemoveExportCheckpoint(path); err != nil {
+		t.Errorf("removing a missing checkpoint: %v", err)
+	}
+}
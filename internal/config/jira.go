@@ -0,0 +1,39 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// JiraSite is where jira:// sinks open issues. It comes from the
+// environment rather than the profile so CI can supply it per job.
+type JiraSite struct {
+	BaseURL   string // JIRA_URL
+	User      string // JIRA_USER
+	Token     string // JIRA_API_TOKEN
+	IssueType string // JIRA_ISSUE_TYPE, default "Task"
+}
+
+// LoadJiraSite reads the Jira site from the environment, failing when it
+// does not say where Jira is.
+func LoadJiraSite() (JiraSite, error) {
+	site := JiraSite{
+		BaseURL:   strings.TrimRight(os.Getenv("JIRA_URL"), "/"),
+		User:      os.Getenv("JIRA_USER"),
+		Token:     os.Getenv("JIRA_API_TOKEN"),
+		IssueType: os.Getenv("JIRA_ISSUE_TYPE"),
+	}
+	if site.IssueType == "" {
+		site.IssueType = "Task"
+	}
+	if site.BaseURL == "" || site.User == "" || site.Token == "" {
+		return JiraSite{}, fmt.Errorf("jira sinks need JIRA_URL, JIRA_USER and JIRA_API_TOKEN in the environment")
+	}
+	return site, nil
+}
+
+// IssueURL links to an issue on the site.
+func (s JiraSite) IssueURL(key string) string {
+	return s.BaseURL + "/browse/" + key
+}
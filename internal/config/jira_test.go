@@ -0,0 +1,33 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLoadJiraSite(t *testing.T) {
+	t.Setenv("JIRA_URL", "")
+	t.Setenv("JIRA_USER", "sre@example.com")
+	t.Setenv("JIRA_API_TOKEN", "tok")
+	t.Setenv("JIRA_ISSUE_TYPE", "")
+	if _, err := LoadJiraSite(); err == nil || !strings.Contains(err.Error(), "JIRA_URL") {
+		t.Fatalf("LoadJiraSite() error = %v, want one naming JIRA_URL", err)
+	}
+
+	t.Setenv("JIRA_URL", "https://example.atlassian.net/")
+	site, err := LoadJiraSite()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if site.BaseURL != "https://example.atlassian.net" || site.IssueType != "Task" {
+		t.Errorf("LoadJiraSite() = %+v", site)
+	}
+	if got := site.IssueURL("OPS-42"); got != "https://example.atlassian.net/browse/OPS-42" {
+		t.Errorf("IssueURL() = %q", got)
+	}
+
+	t.Setenv("JIRA_ISSUE_TYPE", "Incident")
+	if site, _ := LoadJiraSite(); site.IssueType != "Incident" {
+		t.Errorf("IssueType = %q, want Incident", site.IssueType)
+	}
+}
@@ -0,0 +1,132 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// ─── Schema versions and migrations ─────────────────────────────────────────
+//
+// Every saved config carries config_version. Load upgrades older files one
+// version at a time with the functions in migrations and writes the result
+// back, so a field can be renamed or reshaped without breaking existing
+// installs. Keys this build does not know are reported, not silently lost.
+
+// CurrentVersion is the config schema version this build writes.
+const CurrentVersion = 1
+
+// migrations[i] upgrades a raw config from version i to i+1.
+var migrations = []func(raw map[string]json.RawMessage) error{
+	// 0 → 1: configs written before versioning. The layout is unchanged;
+	// the version is stamped on save.
+	func(map[string]json.RawMessage) error { return nil },
+}
+
+// migrate upgrades raw to CurrentVersion and returns the version it was at.
+func migrate(raw map[string]json.RawMessage) (int, error) {
+	from := 0
+	if v, ok := raw["config_version"]; ok {
+		if err := json.Unmarshal(v, &from); err != nil {
+			return 0, fmt.Errorf("invalid config_version %s", v)
+		}
+	}
+	for v := from; v < CurrentVersion && v < len(migrations); v++ {
+		if err := migrations[v](raw); err != nil {
+			return from, fmt.Errorf("migrating config from version %d to %d: %w", v, v+1, err)
+		}
+	}
+	return from, nil
+}
+
+// knownKeys lists the JSON keys of Config.
+var knownKeys = func() map[string]bool {
+	keys := map[string]bool{}
+	t := reflect.TypeOf(Config{})
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if name != "" && name != "-" {
+			keys[name] = true
+		}
+	}
+	return keys
+}()
+
+func unknownKeys(raw map[string]json.RawMessage) []string {
+	var unknown []string
+	for k := range raw {
+		if !knownKeys[k] {
+			unknown = append(unknown, k)
+		}
+	}
+	sort.Strings(unknown)
+	return unknown
+}
+
+// Warnings returns problems noticed while loading the config, such as
+// unknown keys or a schema newer than this build.
+func (c *Config) Warnings() []string { return c.warnings }
+
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// IsUUID reports whether s is a canonical UUID.
+func IsUUID(s string) bool { return uuidPattern.MatchString(s) }
+
+// Check validates the formats of the stored settings and returns one
+// message per problem.
+func (c *Config) Check() []string {
+	var problems []string
+	if c.Server == "" {
+		problems = append(problems, "server is not set (run hawkeye login)")
+	} else if err := checkURL(c.Server); err != nil {
+		problems = append(problems, fmt.Sprintf("server: %v", err))
+	}
+	if c.FrontendURL != "" {
+		if err := checkURL(c.FrontendURL); err != nil {
+			problems = append(problems, fmt.Sprintf("frontend_url: %v", err))
+		}
+	}
+	for _, f := range []struct{ key, value string }{
+		{"org_uuid", c.OrgUUID},
+		{"project_uuid", c.ProjectID},
+		{"last_session", c.LastSession},
+	} {
+		if f.value != "" && !IsUUID(f.value) {
+			problems = append(problems, fmt.Sprintf("%s: %q is not a UUID", f.key, f.value))
+		}
+	}
+	for _, name := range c.AliasNames() {
+		if target := c.Aliases[name]; !IsUUID(target) {
+			problems = append(problems, fmt.Sprintf("aliases.%s: %q is not a UUID", name, target))
+		}
+	}
+	var tagged []string
+	for id := range c.Tags {
+		tagged = append(tagged, id)
+	}
+	sort.Strings(tagged)
+	for _, id := range tagged {
+		if !IsUUID(id) {
+			problems = append(problems, fmt.Sprintf("tags: %q is not a session UUID", id))
+		}
+	}
+	return problems
+}
+
+func checkURL(s string) error {
+	u, err := url.Parse(s)
+	if err != nil {
+		return fmt.Errorf("%q is not a valid URL", s)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("%q must start with http:// or https://", s)
+	}
+	if u.Host == "" {
+		return fmt.Errorf("%q has no host", s)
+	}
+	return nil
+}
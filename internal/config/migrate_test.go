@@ -0,0 +1,91 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadMigratesLegacyConfig(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("HOME", tmpDir)
+	t.Setenv("SNAP_USER_COMMON", "")
+
+	path := filepath.Join(tmpDir, configDir, configFile)
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		t.Fatal(err)
+	}
+	legacy := `{"server": "http://example.com", "token": "tok", "colour": "blue"}`
+	if err := os.WriteFile(path, []byte(legacy), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := Load("")
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.Server != "http://example.com" || cfg.Version != CurrentVersion {
+		t.Errorf("cfg = %+v, want server kept and version %d", cfg, CurrentVersion)
+	}
+	if w := cfg.Warnings(); len(w) != 1 || !strings.Contains(w[0], `"colour"`) {
+		t.Errorf("Warnings() = %v, want one for the unknown key", w)
+	}
+	data, _ := os.ReadFile(path)
+	if !strings.Contains(string(data), `"config_version": 1`) || !strings.Contains(string(data), `"colour": "blue"`) {
+		t.Errorf("migrated file not rewritten with a version and the unknown key kept:\n%s", data)
+	}
+
+	newer := `{"config_version": 99, "server": "http://example.com"}`
+	if err := os.WriteFile(path, []byte(newer), 0600); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err = Load("")
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if w := cfg.Warnings(); len(w) != 1 || !strings.Contains(w[0], "newer") {
+		t.Errorf("Warnings() = %v, want a newer-version warning", w)
+	}
+	if data, _ := os.ReadFile(path); string(data) != newer {
+		t.Errorf("newer config was rewritten:\n%s", data)
+	}
+
+	if err := os.WriteFile(path, []byte(`{"config_version": "one"}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(""); err == nil {
+		t.Error("Load() with a bad config_version error = nil")
+	}
+}
+
+func TestConfigCheck(t *testing.T) {
+	const id = "0b7c2a8e-3f4d-4e5a-9b6c-7d8e9f0a1b2c"
+	tests := []struct {
+		name string
+		cfg  Config
+		want []string
+	}{
+		{"valid", Config{Server: "https://hawkeye.example.com", OrgUUID: id, ProjectID: id, LastSession: id,
+			Aliases: map[string]string{"db": id}, Tags: map[string][]string{id: {"p1"}}}, nil},
+		{"no server", Config{}, []string{"server is not set"}},
+		{"bad scheme", Config{Server: "hawkeye.example.com"}, []string{"must start with http"}},
+		{"no host", Config{Server: "https://"}, []string{"has no host"}},
+		{"bad frontend", Config{Server: "http://a", FrontendURL: "ftp://a"}, []string{"frontend_url"}},
+		{"bad ids", Config{Server: "http://a", ProjectID: "my-project", Aliases: map[string]string{"x": "nope"}, Tags: map[string][]string{"s1": {"t"}}},
+			[]string{"project_uuid", "aliases.x", `tags: "s1"`}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.cfg.Check()
+			if len(got) != len(tt.want) {
+				t.Fatalf("Check() = %v, want %d problems", got, len(tt.want))
+			}
+			for i, w := range tt.want {
+				if !strings.Contains(got[i], w) {
+					t.Errorf("problem %d = %q, want it to mention %q", i, got[i], w)
+				}
+			}
+		})
+	}
+}
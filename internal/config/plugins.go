@@ -0,0 +1,104 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// ─── Plugins ────────────────────────────────────────────────────────────────
+//
+// Like git, an unknown command <cmd> runs an executable named hawkeye-<cmd>
+// found on PATH, with the remaining arguments. Built-in commands always
+// win. Plugins learn the active context from HAWKEYE_* variables (see
+// PluginEnv) and can call back into the CLI through $HAWKEYE_BIN.
+
+// PluginPrefix starts the file name of every plugin executable.
+const PluginPrefix = "hawkeye-"
+
+var pluginNameRE = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.-]*$`)
+
+// Plugin is an executable found on PATH.
+type Plugin struct {
+	Name string // the command it adds
+	Path string
+}
+
+// FindPlugin returns the executable for command name, if there is one.
+// lookPath is exec.LookPath outside tests.
+func FindPlugin(name string, lookPath func(string) (string, error)) (string, bool) {
+	if !pluginNameRE.MatchString(name) {
+		return "", false
+	}
+	path, err := lookPath(PluginPrefix + name)
+	if err != nil {
+		return "", false
+	}
+	return path, true
+}
+
+// ListPlugins lists the plugins in the directories of pathList, a PATH
+// value, sorted by name. The first of several with the same name wins, as
+// it does when the plugin runs. On Windows pathExt, a PATHEXT value, lists
+// the executable extensions; elsewhere it is ignored and files need an
+// execute bit.
+func ListPlugins(pathList, goos, pathExt string) []Plugin {
+	exts := map[string]bool{}
+	for _, ext := range strings.Split(strings.ToLower(pathExt), ";") {
+		exts[ext] = ext != ""
+	}
+	found := map[string]bool{}
+	var plugins []Plugin
+	for _, dir := range filepath.SplitList(pathList) {
+		if dir == "" {
+			continue
+		}
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, e := range entries {
+			name := e.Name()
+			if !strings.HasPrefix(name, PluginPrefix) || e.IsDir() {
+				continue
+			}
+			if goos == "windows" {
+				ext := filepath.Ext(name)
+				if !exts[strings.ToLower(ext)] {
+					continue
+				}
+				name = strings.TrimSuffix(name, ext)
+			} else if info, err := e.Info(); err != nil || info.Mode()&0o111 == 0 {
+				continue
+			}
+			name = strings.TrimPrefix(name, PluginPrefix)
+			if !pluginNameRE.MatchString(name) || found[name] {
+				continue
+			}
+			found[name] = true
+			plugins = append(plugins, Plugin{Name: name, Path: filepath.Join(dir, e.Name())})
+		}
+	}
+	sort.Slice(plugins, func(i, j int) bool { return plugins[i].Name < plugins[j].Name })
+	return plugins
+}
+
+// PluginEnv returns environ with the variables a plugin reads the active
+// context from: the hawkeye binary, the profile, and the profile's server
+// and project. Unset values are left out. The token is not passed on; a
+// plugin that needs the API runs "$HAWKEYE_BIN" -j <command> instead.
+func PluginEnv(environ []string, self, profile string, cfg *Config) []string {
+	vars := [][2]string{{"HAWKEYE_BIN", self}, {"HAWKEYE_PROFILE", profile}}
+	if cfg != nil {
+		vars = append(vars, [2]string{"HAWKEYE_SERVER", cfg.Server}, [2]string{"HAWKEYE_PROJECT_ID", cfg.ProjectID})
+	}
+	env := append([]string(nil), environ...)
+	for _, v := range vars {
+		if v[1] != "" {
+			env = append(env, v[0]+"="+v[1])
+		}
+	}
+	return env
+}
@@ -0,0 +1,57 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestPlugins(t *testing.T) {
+	lookPath := func(name string) (string, error) {
+		if name == "hawkeye-hello" {
+			return "/usr/local/bin/hawkeye-hello", nil
+		}
+		return "", errors.New("not found")
+	}
+	if path, ok := FindPlugin("hello", lookPath); !ok || path != "/usr/local/bin/hawkeye-hello" {
+		t.Errorf("FindPlugin(hello) = %q, %v", path, ok)
+	}
+	for _, name := range []string{"missing", "../hello", "-x", ""} {
+		if _, ok := FindPlugin(name, lookPath); ok {
+			t.Errorf("FindPlugin(%q) found a plugin", name)
+		}
+	}
+
+	first, second := t.TempDir(), t.TempDir()
+	write := func(dir, name string, mode os.FileMode) {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("#!/bin/sh\n"), mode); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write(first, "hawkeye-hello", 0o755)
+	write(first, "hawkeye-notes.txt", 0o644)
+	write(second, "hawkeye-hello", 0o755)
+	write(second, "hawkeye-deploy", 0o755)
+	write(second, "kubectl-hello", 0o755)
+	pathList := first + string(os.PathListSeparator) + second
+
+	got := ListPlugins(pathList, "linux", "")
+	want := []Plugin{
+		{Name: "deploy", Path: filepath.Join(second, "hawkeye-deploy")},
+		{Name: "hello", Path: filepath.Join(first, "hawkeye-hello")},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ListPlugins(linux) = %+v, want %+v", got, want)
+	}
+	if got := ListPlugins(pathList, "windows", ".EXE;.TXT"); len(got) != 1 || got[0].Name != "notes" {
+		t.Errorf("ListPlugins(windows) = %+v, want only notes", got)
+	}
+
+	env := PluginEnv([]string{"PATH=/bin"}, "/bin/hawkeye", "staging", &Config{Server: "https://x.neubird.ai", Token: "secret"})
+	wantEnv := []string{"PATH=/bin", "HAWKEYE_BIN=/bin/hawkeye", "HAWKEYE_PROFILE=staging", "HAWKEYE_SERVER=https://x.neubird.ai"}
+	if !reflect.DeepEqual(env, wantEnv) {
+		t.Errorf("PluginEnv() = %q, want %q", env, wantEnv)
+	}
+}
@@ -0,0 +1,105 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// ─── Local session search index ─────────────────────────────────────────────
+//
+// `hawkeye search` reads a per-profile index of sessions kept in the
+// profile's cache directory. Each entry holds the prompts, final answers
+// and summary of a session; how entries are built and ranked is left to
+// the service layer.
+
+// SearchIndexFile is the index's file name inside the cache directory.
+const SearchIndexFile = "sessions-index.json"
+
+// SearchDoc is the searchable text of one session.
+type SearchDoc struct {
+	SessionUUID string    `json:"session_uuid"`
+	ProjectUUID string    `json:"project_uuid,omitempty"`
+	Name        string    `json:"name,omitempty"`
+	CreateTime  string    `json:"create_time,omitempty"`
+	LastUpdate  string    `json:"last_update,omitempty"`
+	Prompts     []string  `json:"prompts,omitempty"`
+	Answer      string    `json:"answer,omitempty"`
+	Summary     string    `json:"summary,omitempty"`
+	IndexedAt   time.Time `json:"indexed_at"`
+}
+
+// SearchIndex is the set of indexed sessions, by session UUID.
+type SearchIndex struct {
+	Sessions map[string]*SearchDoc `json:"sessions"`
+
+	profile string
+}
+
+// SearchIndexPath returns the location of a profile's search index.
+func SearchIndexPath(profile string) (string, error) {
+	dir, err := CacheDir(profile)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, SearchIndexFile), nil
+}
+
+// LoadSearchIndex reads a profile's search index. A missing file is an
+// empty index.
+func LoadSearchIndex(profile string) (*SearchIndex, error) {
+	idx := &SearchIndex{Sessions: map[string]*SearchDoc{}, profile: profile}
+	path, err := SearchIndexPath(profile)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return idx, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, idx); err != nil {
+		return nil, err
+	}
+	if idx.Sessions == nil {
+		idx.Sessions = map[string]*SearchDoc{}
+	}
+	return idx, nil
+}
+
+// Save writes the index atomically.
+func (idx *SearchIndex) Save() error {
+	path, err := SearchIndexPath(idx.profile)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(idx)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// Doc returns the entry for a session, adding an empty one if there is
+// none.
+func (idx *SearchIndex) Doc(sessionUUID string) *SearchDoc {
+	if idx.Sessions == nil {
+		idx.Sessions = map[string]*SearchDoc{}
+	}
+	doc, ok := idx.Sessions[sessionUUID]
+	if !ok {
+		doc = &SearchDoc{SessionUUID: sessionUUID}
+		idx.Sessions[sessionUUID] = doc
+	}
+	return doc
+}
@@ -0,0 +1,27 @@
+package config
+
+import "testing"
+
+func TestSearchIndexRoundTrip(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("SNAP_USER_COMMON", "")
+
+	empty, err := LoadSearchIndex("work")
+	if err != nil || len(empty.Sessions) != 0 {
+		t.Fatalf("missing index = %+v, %v", empty, err)
+	}
+	empty.Doc("s1").Summary = "pool exhausted"
+	if empty.Doc("s1") != empty.Sessions["s1"] {
+		t.Error("Doc should return the existing entry")
+	}
+	if err := empty.Save(); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := LoadSearchIndex("work")
+	if err != nil || loaded.Sessions["s1"] == nil || loaded.Sessions["s1"].Summary != "pool exhausted" {
+		t.Fatalf("loaded = %+v, %v", loaded, err)
+	}
+	if other, err := LoadSearchIndex("other"); err != nil || len(other.Sessions) != 0 {
+		t.Errorf("another profile's index = %+v, %v", other, err)
+	}
+}
@@ -0,0 +1,171 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ─── Team settings ──────────────────────────────────────────────────────────
+//
+// `hawkeye config export` writes the part of a profile a team shares —
+// server, org, project, theme, network settings, defaults and aliases — as
+// YAML, and `config import` applies such a file to a profile, after which a
+// new member only needs to log in. Personal state (user, last session, history, tags,
+// certificate paths) is never exported. The token is exported unless
+// secrets are redacted.
+
+// TeamSettingsVersion is the format version written by export.
+const TeamSettingsVersion = 1
+
+// TeamSettings is the shareable part of a profile.
+type TeamSettings struct {
+	Version     int               `yaml:"version"`
+	Server      string            `yaml:"server,omitempty"`
+	FrontendURL string            `yaml:"frontend_url,omitempty"`
+	OrgUUID     string            `yaml:"org_uuid,omitempty"`
+	ProjectID   string            `yaml:"project_uuid,omitempty"`
+	ProjectName string            `yaml:"project_name,omitempty"`
+	Timezone    string            `yaml:"timezone,omitempty"`
+	Theme       string            `yaml:"theme,omitempty"`
+	Language    string            `yaml:"language,omitempty"`
+	NoAutoName  bool              `yaml:"no_auto_name,omitempty"`
+	Proxy       string            `yaml:"proxy,omitempty"`
+	Timeout     string            `yaml:"timeout,omitempty"`
+	StreamIdle  string            `yaml:"stream_idle_timeout,omitempty"`
+	Defaults    map[string]string `yaml:"defaults,omitempty"`
+	Aliases     map[string]string `yaml:"aliases,omitempty"`
+	Token       string            `yaml:"token,omitempty"`
+}
+
+// ExportTeamSettings returns the profile's shareable settings. The token
+// is left out and a password in the proxy URL is masked unless
+// includeSecrets is set.
+func (c *Config) ExportTeamSettings(includeSecrets bool) TeamSettings {
+	t := TeamSettings{
+		Version:     TeamSettingsVersion,
+		Server:      c.Server,
+		FrontendURL: c.FrontendURL,
+		OrgUUID:     c.OrgUUID,
+		ProjectID:   c.ProjectID,
+		ProjectName: c.ProjectName,
+		Timezone:    c.Timezone,
+		Theme:       c.Theme,
+		Language:    c.Language,
+		NoAutoName:  c.NoAutoName,
+		Proxy:       c.Proxy,
+		Timeout:     c.Timeout,
+		StreamIdle:  c.StreamIdleTimeout,
+		Defaults:    c.Defaults,
+		Aliases:     c.Aliases,
+		Token:       c.Token,
+	}
+	if !includeSecrets {
+		t.Token = ""
+		t.Proxy = redactURL(t.Proxy)
+	}
+	return t
+}
+
+// MarshalTeamSettings encodes team settings as YAML.
+func MarshalTeamSettings(t TeamSettings) ([]byte, error) {
+	return yaml.Marshal(t)
+}
+
+// ParseTeamSettings decodes and validates a team settings file.
+func ParseTeamSettings(data []byte) (TeamSettings, error) {
+	var t TeamSettings
+	dec := yaml.NewDecoder(strings.NewReader(string(data)))
+	dec.KnownFields(true)
+	if err := dec.Decode(&t); err != nil {
+		return t, fmt.Errorf("parsing team settings: %w", err)
+	}
+	if t.Version > TeamSettingsVersion {
+		return t, fmt.Errorf("team settings version %d is newer than this hawkeye supports (%d); upgrade the CLI", t.Version, TeamSettingsVersion)
+	}
+	for _, f := range []struct{ key, value string }{{"server", t.Server}, {"frontend_url", t.FrontendURL}} {
+		if f.value != "" {
+			if err := checkURL(f.value); err != nil {
+				return t, fmt.Errorf("%s: %w", f.key, err)
+			}
+		}
+	}
+	if strings.Contains(t.Proxy, ":"+redacted+"@") {
+		return t, fmt.Errorf("proxy: the password was redacted on export; set it with hawkeye set proxy")
+	}
+	for key := range t.Defaults {
+		if _, err := normalizeDefaultKey(key); err != nil {
+			return t, err
+		}
+	}
+	return t, nil
+}
+
+// ImportTeamSettings applies team settings to the profile and returns the
+// keys that changed, sorted. Settings the file leaves empty are kept;
+// defaults and aliases are merged. Moving to another server drops the
+// session tied to the old one, so the user has to log in again.
+func (c *Config) ImportTeamSettings(t TeamSettings) []string {
+	changed := map[string]bool{}
+	set := func(key string, dst *string, value string) {
+		if value != "" && *dst != value {
+			*dst = value
+			changed[key] = true
+		}
+	}
+
+	if t.Server != "" && c.Server != "" && t.Server != c.Server {
+		c.Token, c.Username, c.LastSession, c.UserRole = "", "", "", ""
+	}
+	if t.OrgUUID != "" && t.OrgUUID != c.OrgUUID {
+		c.UserRole = "" // the saved role was for the old organization
+	}
+	set("server", &c.Server, t.Server)
+	set("frontend_url", &c.FrontendURL, t.FrontendURL)
+	set("org_uuid", &c.OrgUUID, t.OrgUUID)
+	if t.ProjectID != "" && t.ProjectID != c.ProjectID {
+		c.ProjectName = ""
+	}
+	set("project_uuid", &c.ProjectID, t.ProjectID)
+	set("project_name", &c.ProjectName, t.ProjectName)
+	set("timezone", &c.Timezone, t.Timezone)
+	set("theme", &c.Theme, t.Theme)
+	set("language", &c.Language, t.Language)
+	set("proxy", &c.Proxy, t.Proxy)
+	set("timeout", &c.Timeout, t.Timeout)
+	set("stream_idle_timeout", &c.StreamIdleTimeout, t.StreamIdle)
+	set("token", &c.Token, t.Token)
+	if t.NoAutoName && !c.NoAutoName {
+		c.NoAutoName = true
+		changed["no_auto_name"] = true
+	}
+	for key, value := range t.Defaults {
+		key, _ = normalizeDefaultKey(key)
+		if c.Defaults[key] != value {
+			if c.Defaults == nil {
+				c.Defaults = map[string]string{}
+			}
+			c.Defaults[key] = value
+			changed["defaults."+key] = true
+		}
+	}
+	for name, target := range t.Aliases {
+		name = strings.ToLower(name)
+		if c.Aliases[name] != target {
+			if c.Aliases == nil {
+				c.Aliases = map[string]string{}
+			}
+			c.Aliases[name] = target
+			changed["aliases."+name] = true
+		}
+	}
+
+	keys := make([]string, 0, len(changed))
+	for k := range changed {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,91 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const (
+	testOrg     = "11111111-2222-3333-4444-555555555555"
+	testProject = "66520f61-6a43-48ac-8286-a7e7cf9755c5"
+)
+
+func TestTeamSettingsRoundTrip(t *testing.T) {
+	src := &Config{
+		Server:      "https://team.app.neubird.ai/api",
+		Username:    "lead@example.com",
+		Token:       "tok",
+		OrgUUID:     testOrg,
+		ProjectID:   testProject,
+		ProjectName: "Payments",
+		LastSession: testProject,
+		Theme:       "dark",
+		Proxy:       "http://user:pw@proxy:3128",
+		Timeout:     "60s",
+		Defaults:    map[string]string{"sessions.limit": "50"},
+	}
+
+	exported := src.ExportTeamSettings(false)
+	if exported.Token != "" || strings.Contains(exported.Proxy, "pw") {
+		t.Errorf("default export leaks secrets: %+v", exported)
+	}
+	if full := src.ExportTeamSettings(true); full.Token != "tok" || full.Proxy != src.Proxy {
+		t.Errorf("export with secrets = %+v", full)
+	}
+
+	exported.Proxy = ""
+	data, err := MarshalTeamSettings(exported)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "lead@example.com") {
+		t.Errorf("export contains the user:\n%s", data)
+	}
+	parsed, err := ParseTeamSettings(data)
+	if err != nil {
+		t.Fatalf("ParseTeamSettings() error = %v\n%s", err, data)
+	}
+
+	dst := &Config{Theme: "light", Defaults: map[string]string{"investigate.debug": "true"}}
+	changed := dst.ImportTeamSettings(parsed)
+	want := []string{"defaults.sessions.limit", "org_uuid", "project_name", "project_uuid", "server", "theme", "timeout"}
+	if !reflect.DeepEqual(changed, want) {
+		t.Errorf("changed = %v, want %v", changed, want)
+	}
+	if dst.Server != src.Server || dst.ProjectID != testProject || dst.Theme != "dark" || dst.Token != "" {
+		t.Errorf("imported config = %+v", dst)
+	}
+	if dst.Defaults["investigate.debug"] != "true" || dst.Defaults["sessions.limit"] != "50" {
+		t.Errorf("defaults not merged: %v", dst.Defaults)
+	}
+	if again := dst.ImportTeamSettings(parsed); len(again) != 0 {
+		t.Errorf("second import changed %v", again)
+	}
+}
+
+func TestImportTeamSettingsNewServerLogsOut(t *testing.T) {
+	c := &Config{Server: "https://old.example.com", Token: "tok", Username: "me", LastSession: testProject, UserRole: "ADMIN"}
+	c.ImportTeamSettings(TeamSettings{Server: "https://new.example.com"})
+	if c.Token != "" || c.Username != "" || c.LastSession != "" || c.UserRole != "" {
+		t.Errorf("switching servers kept the old login: %+v", c)
+	}
+}
+
+func TestParseTeamSettingsErrors(t *testing.T) {
+	tests := []struct {
+		yaml string
+		want string
+	}{
+		{"version: 9\n", "newer"},
+		{"version: 1\nserver: ftp://x\n", "server"},
+		{"version: 1\nproxy: http://u:[REDACTED]@p:1\n", "redacted"},
+		{"version: 1\ndefaults:\n  bad: x\n", "invalid default"},
+		{"version: 1\nusername: me\n", "parsing"},
+	}
+	for _, tt := range tests {
+		if _, err := ParseTeamSettings([]byte(tt.yaml)); err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("ParseTeamSettings(%q) error = %v, want %q", tt.yaml, err, tt.want)
+		}
+	}
+}
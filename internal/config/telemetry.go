@@ -0,0 +1,133 @@
+package config
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// ─── Usage telemetry ────────────────────────────────────────────────────────
+//
+// Telemetry is off until `hawkeye telemetry on`. While on, each run adds
+// one to its command's count (and to an error category when it fails) in
+// telemetry.json in the config directory, shared by all profiles. The
+// counts are uploaded in one batch every TelemetryInterval and then reset.
+// Nothing else is recorded: no arguments, profiles, servers or sessions.
+
+const telemetryFile = "telemetry.json"
+
+// TelemetryInterval is how often a batch is uploaded.
+const TelemetryInterval = 24 * time.Hour
+
+// Telemetry is the local telemetry state and the batch not yet uploaded.
+type Telemetry struct {
+	Enabled    bool           `json:"enabled"`
+	InstallID  string         `json:"install_id,omitempty"` // random, identifies this machine only
+	Since      time.Time      `json:"since,omitempty"`      // start of the pending batch
+	LastUpload time.Time      `json:"last_upload,omitempty"`
+	Commands   map[string]int `json:"commands,omitempty"`
+	Errors     map[string]int `json:"errors,omitempty"` // error category → count
+}
+
+// TelemetryPath returns the location of the telemetry state.
+func TelemetryPath() (string, error) {
+	base, err := configBase()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(base, telemetryFile), nil
+}
+
+// LoadTelemetry reads the telemetry state. A missing file means off.
+func LoadTelemetry() (*Telemetry, error) {
+	path, err := TelemetryPath()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return &Telemetry{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var t Telemetry
+	if err := json.Unmarshal(data, &t); err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// Save writes the telemetry state.
+func (t *Telemetry) Save() error {
+	path, err := TelemetryPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(t, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0600)
+}
+
+// Enable turns telemetry on, creating the install ID on first use, and
+// starts a new batch.
+func (t *Telemetry) Enable(now time.Time) {
+	if t.InstallID == "" {
+		b := make([]byte, 16)
+		_, _ = rand.Read(b)
+		t.InstallID = hex.EncodeToString(b)
+	}
+	if !t.Enabled {
+		t.Enabled = true
+		t.Reset(now)
+	}
+}
+
+// Disable turns telemetry off and drops the pending batch.
+func (t *Telemetry) Disable() {
+	t.Enabled = false
+	t.Since = time.Time{}
+	t.Commands = nil
+	t.Errors = nil
+}
+
+// Record counts one run of command. errCategory is empty when it
+// succeeded. Nothing is recorded while telemetry is off.
+func (t *Telemetry) Record(command, errCategory string, now time.Time) {
+	if !t.Enabled || command == "" {
+		return
+	}
+	if t.Since.IsZero() {
+		t.Since = now
+	}
+	if t.Commands == nil {
+		t.Commands = map[string]int{}
+	}
+	t.Commands[command]++
+	if errCategory != "" {
+		if t.Errors == nil {
+			t.Errors = map[string]int{}
+		}
+		t.Errors[errCategory]++
+	}
+}
+
+// Due reports whether the pending batch should be uploaded.
+func (t *Telemetry) Due(now time.Time) bool {
+	return t.Enabled && len(t.Commands) > 0 && !now.Before(t.Since.Add(TelemetryInterval))
+}
+
+// Reset starts a new batch at now, after an upload or when enabling.
+func (t *Telemetry) Reset(now time.Time) {
+	t.Since = now
+	t.Commands = nil
+	t.Errors = nil
+}
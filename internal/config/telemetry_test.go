@@ -0,0 +1,53 @@
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTelemetry(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("SNAP_USER_COMMON", "")
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	tel, err := LoadTelemetry()
+	if err != nil || tel.Enabled {
+		t.Fatalf("LoadTelemetry() on a missing file = %+v, %v; want off", tel, err)
+	}
+	tel.Record("investigate", "", now)
+	if len(tel.Commands) != 0 {
+		t.Errorf("recorded while off: %v", tel.Commands)
+	}
+
+	tel.Enable(now)
+	id := tel.InstallID
+	if len(id) != 32 {
+		t.Errorf("InstallID = %q", id)
+	}
+	tel.Record("investigate", "", now)
+	tel.Record("investigate", "network", now.Add(time.Hour))
+	tel.Record("sessions", "", now.Add(time.Hour))
+	if err := tel.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	tel, err = LoadTelemetry()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tel.Commands["investigate"] != 2 || tel.Commands["sessions"] != 1 || tel.Errors["network"] != 1 {
+		t.Errorf("counts = %v, errors = %v", tel.Commands, tel.Errors)
+	}
+	if tel.Due(now.Add(TelemetryInterval - time.Minute)) {
+		t.Error("batch due before the interval")
+	}
+	if !tel.Due(now.Add(TelemetryInterval)) {
+		t.Error("batch not due after the interval")
+	}
+
+	tel.Disable()
+	tel.Enable(now)
+	if tel.InstallID != id || len(tel.Commands) != 0 || tel.Due(now.Add(2*TelemetryInterval)) {
+		t.Errorf("after off/on: %+v; want the same ID and an empty batch", tel)
+	}
+}
@@ -0,0 +1,119 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ─── Investigation templates ────────────────────────────────────────────────
+//
+// `hawkeye template create` saves a parameterized prompt together with the
+// session instructions and telemetry scope to apply with it, so an
+// investigation style can be re-run with `investigate --template`.
+// Templates are not tied to a profile; they live in templates.json next to
+// the config.
+
+// TemplateInstruction is a session instruction applied before the prompt.
+type TemplateInstruction struct {
+	Type    string `json:"type"`
+	Content string `json:"content"`
+}
+
+// Template is a saved investigation template.
+type Template struct {
+	Name         string                `json:"name"`
+	Prompt       string                `json:"prompt"`
+	Instructions []TemplateInstruction `json:"instructions,omitempty"`
+	Telemetry    []string              `json:"telemetry,omitempty"` // metric, log, trace
+	CreatedAt    time.Time             `json:"created_at"`
+}
+
+// TemplateStore holds the saved templates by name.
+type TemplateStore struct {
+	Templates map[string]Template `json:"templates,omitempty"`
+}
+
+func templatesPath() (string, error) {
+	base, err := configBase()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(base, "templates.json"), nil
+}
+
+// LoadTemplates reads the template store. A missing file is an empty store.
+func LoadTemplates() (*TemplateStore, error) {
+	s := &TemplateStore{}
+	path, err := templatesPath()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, s); err != nil {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+	return s, nil
+}
+
+// Save writes the template store.
+func (s *TemplateStore) Save() error {
+	path, err := templatesPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0600)
+}
+
+// Get returns the template with the given name, ignoring case.
+func (s *TemplateStore) Get(name string) (Template, error) {
+	if t, ok := s.Templates[strings.ToLower(name)]; ok {
+		return t, nil
+	}
+	return Template{}, fmt.Errorf("no template named %q — run: hawkeye template list", name)
+}
+
+// Put adds or replaces a template.
+func (s *TemplateStore) Put(t Template) {
+	if s.Templates == nil {
+		s.Templates = map[string]Template{}
+	}
+	s.Templates[strings.ToLower(t.Name)] = t
+}
+
+// Delete removes a template and reports whether it existed.
+func (s *TemplateStore) Delete(name string) bool {
+	key := strings.ToLower(name)
+	if _, ok := s.Templates[key]; !ok {
+		return false
+	}
+	delete(s.Templates, key)
+	return true
+}
+
+// List returns the templates sorted by name.
+func (s *TemplateStore) List() []Template {
+	out := make([]Template, 0, len(s.Templates))
+	for _, t := range s.Templates {
+		out = append(out, t)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
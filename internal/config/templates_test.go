@@ -0,0 +1,42 @@
+package config
+
+import "testing"
+
+func TestTemplateStoreRoundTrip(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("SNAP_USER_COMMON", "")
+
+	s, err := LoadTemplates()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Get("db-latency"); err == nil {
+		t.Fatal("Get on an empty store should fail")
+	}
+	s.Put(Template{
+		Name:         "db-latency",
+		Prompt:       "Why is {{db}} slow?",
+		Instructions: []TemplateInstruction{{Type: "rca", Content: "focus on database layer"}},
+		Telemetry:    []string{"metric", "log"},
+	})
+	s.Put(Template{Name: "API-errors", Prompt: "Why is the API failing?"})
+	if err := s.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := LoadTemplates()
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := loaded.Get("DB-Latency")
+	if err != nil || got.Prompt != "Why is {{db}} slow?" || len(got.Instructions) != 1 || len(got.Telemetry) != 2 {
+		t.Fatalf("Get = %+v, %v", got, err)
+	}
+	list := loaded.List()
+	if len(list) != 2 || list[0].Name != "API-errors" || list[1].Name != "db-latency" {
+		t.Errorf("List = %+v", list)
+	}
+	if !loaded.Delete("api-errors") || loaded.Delete("api-errors") {
+		t.Error("Delete should succeed once")
+	}
+}
@@ -0,0 +1,239 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ─── watch queue ────────────────────────────────────────────────────────────
+//
+// `watch --auto-investigate` keeps its queue per profile in watch.json
+// (watch-<profile>.json) next to the config, so a restarted watch picks up
+// where it stopped. Every incident it has seen stays listed with its state;
+// an incident that was already started is never started again, including
+// one left running when the previous watch was killed.
+//
+// A watch that investigates owns the queue through a lock file holding its
+// pid (watch.lock, watch-<profile>.lock). Only the owner writes the queue;
+// anyone else may read it.
+
+// Watch queue states.
+const (
+	WatchQueued      = "queued"
+	WatchRunning     = "running"
+	WatchCompleted   = "completed"
+	WatchFailed      = "failed"
+	WatchInterrupted = "interrupted" // running when a previous watch stopped
+	WatchSkipped     = "skipped"     // investigated by someone else first
+)
+
+// watchRetention is how long finished entries are kept.
+const watchRetention = 7 * 24 * time.Hour
+
+// WatchItem is one incident in the watch queue.
+type WatchItem struct {
+	SessionUUID string    `json:"session_uuid"`
+	ProjectUUID string    `json:"project_uuid"`
+	Name        string    `json:"name,omitempty"`
+	Priority    string    `json:"priority,omitempty"`
+	CreateTime  string    `json:"create_time,omitempty"` // the incident's, for ordering
+	Status      string    `json:"status"`
+	QueuedAt    time.Time `json:"queued_at"`
+	StartedAt   time.Time `json:"started_at,omitempty"`
+	FinishedAt  time.Time `json:"finished_at,omitempty"`
+	Error       string    `json:"error,omitempty"`
+}
+
+// WatchQueue is the persisted watch queue of a profile.
+type WatchQueue struct {
+	Items []*WatchItem `json:"items,omitempty"`
+
+	profile string
+}
+
+func watchPath(profile string) (string, error) {
+	return watchFile(profile, "json")
+}
+
+func watchFile(profile, ext string) (string, error) {
+	base, err := configBase()
+	if err != nil {
+		return "", err
+	}
+	filename := "watch." + ext
+	if profile != "" {
+		filename = fmt.Sprintf("watch-%s.%s", profile, ext)
+	}
+	return filepath.Join(base, filename), nil
+}
+
+// WatchLock is a profile's claim on its watch queue.
+type WatchLock struct {
+	path string
+}
+
+// LockWatchQueue claims a profile's watch queue for this process. It fails
+// while another live process holds it; a lock left behind by one that is
+// gone is taken over.
+func LockWatchQueue(profile string) (*WatchLock, error) {
+	path, err := watchFile(profile, "lock")
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, err
+	}
+	for attempt := 0; attempt < 2; attempt++ {
+		f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+		if err == nil {
+			_, err = fmt.Fprintf(f, "%d\n", os.Getpid())
+			if cerr := f.Close(); err == nil {
+				err = cerr
+			}
+			if err != nil {
+				os.Remove(path)
+				return nil, err
+			}
+			return &WatchLock{path: path}, nil
+		}
+		if !errors.Is(err, os.ErrExist) {
+			return nil, err
+		}
+		if pid := lockOwner(path); pid != 0 {
+			return nil, fmt.Errorf("the watch queue is in use by a running watch (pid %d); stop it first", pid)
+		}
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+	}
+	return nil, fmt.Errorf("could not lock the watch queue; try again")
+}
+
+// Unlock releases the queue.
+func (l *WatchLock) Unlock() error {
+	return os.Remove(l.path)
+}
+
+// WatchOwner returns the pid of the live process holding a profile's watch
+// queue, or 0 when none does.
+func WatchOwner(profile string) int {
+	path, err := watchFile(profile, "lock")
+	if err != nil {
+		return 0
+	}
+	return lockOwner(path)
+}
+
+// lockOwner reads the pid in a lock file, returning 0 when the file is
+// missing or its process has exited.
+func lockOwner(path string) int {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil || pid <= 0 || !processAlive(pid) {
+		return 0
+	}
+	return pid
+}
+
+// LoadWatchQueue reads a profile's watch queue. A missing file is an empty
+// queue. Entries are returned as saved; see Interrupt.
+func LoadWatchQueue(profile string) (*WatchQueue, error) {
+	q := &WatchQueue{profile: profile}
+	path, err := watchPath(profile)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return q, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, q); err != nil {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+	return q, nil
+}
+
+// Interrupt marks entries still running as interrupted. Call it when no
+// live watch owns the queue: those entries belonged to one that is gone.
+func (q *WatchQueue) Interrupt() {
+	for _, it := range q.Items {
+		if it.Status == WatchRunning {
+			it.Status = WatchInterrupted
+		}
+	}
+}
+
+// Save writes the watch queue, dropping entries finished more than a week
+// ago.
+func (q *WatchQueue) Save() error {
+	path, err := watchPath(q.profile)
+	if err != nil {
+		return err
+	}
+	cutoff := time.Now().Add(-watchRetention)
+	kept := q.Items[:0]
+	for _, it := range q.Items {
+		if it.FinishedAt.IsZero() || it.FinishedAt.After(cutoff) {
+			kept = append(kept, it)
+		}
+	}
+	q.Items = kept
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(q, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0600)
+}
+
+// Find returns the entry for a session, or nil.
+func (q *WatchQueue) Find(sessionUUID string) *WatchItem {
+	for _, it := range q.Items {
+		if it.SessionUUID == sessionUUID {
+			return it
+		}
+	}
+	return nil
+}
+
+// Add queues a session unless it is already listed, and reports whether it
+// was added.
+func (q *WatchQueue) Add(item WatchItem) bool {
+	if q.Find(item.SessionUUID) != nil {
+		return false
+	}
+	item.Status = WatchQueued
+	q.Items = append(q.Items, &item)
+	return true
+}
+
+// Queued returns the entries waiting to start in a project.
+func (q *WatchQueue) Queued(projectUUID string) []*WatchItem {
+	var out []*WatchItem
+	for _, it := range q.Items {
+		if it.Status == WatchQueued && it.ProjectUUID == projectUUID {
+			out = append(out, it)
+		}
+	}
+	return out
+}
+
+// Clear removes every entry. Hold the queue's lock: with no watch running,
+// nothing listed is in flight.
+func (q *WatchQueue) Clear() {
+	q.Items = nil
+}
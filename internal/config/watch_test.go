@@ -0,0 +1,100 @@
+package config
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWatchQueueRoundTrip(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("SNAP_USER_COMMON", "")
+
+	q, err := LoadWatchQueue("work")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !q.Add(WatchItem{SessionUUID: "s1", ProjectUUID: "p"}) || q.Add(WatchItem{SessionUUID: "s1", ProjectUUID: "p"}) {
+		t.Fatal("Add should queue a session once")
+	}
+	q.Add(WatchItem{SessionUUID: "s2", ProjectUUID: "p"})
+	q.Add(WatchItem{SessionUUID: "s3", ProjectUUID: "other"})
+	q.Add(WatchItem{SessionUUID: "old", ProjectUUID: "p"})
+	q.Find("s2").Status = WatchRunning
+	old := q.Find("old")
+	old.Status, old.FinishedAt = WatchCompleted, time.Now().Add(-8*24*time.Hour)
+	if err := q.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := LoadWatchQueue("work")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded.Find("old") != nil {
+		t.Error("entries finished over a week ago should be dropped")
+	}
+	if s := loaded.Find("s2").Status; s != WatchRunning {
+		t.Errorf("running entry loaded as %q; loading must not change it", s)
+	}
+	loaded.Interrupt()
+	if s := loaded.Find("s2").Status; s != WatchInterrupted {
+		t.Errorf("after Interrupt, running entry is %q, want interrupted", s)
+	}
+	if queued := loaded.Queued("p"); len(queued) != 1 || queued[0].SessionUUID != "s1" {
+		t.Errorf("Queued(p) = %+v", queued)
+	}
+	if other, _ := LoadWatchQueue(""); len(other.Items) != 0 {
+		t.Error("the default profile should have its own queue")
+	}
+
+	loaded.Clear()
+	if len(loaded.Items) != 0 {
+		t.Errorf("Clear left %+v", loaded.Items)
+	}
+}
+
+func TestLockWatchQueue(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("SNAP_USER_COMMON", "")
+
+	if pid := WatchOwner("work"); pid != 0 {
+		t.Fatalf("WatchOwner() = %d before locking", pid)
+	}
+	lock, err := LockWatchQueue("work")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pid := WatchOwner("work"); pid != os.Getpid() {
+		t.Errorf("WatchOwner() = %d, want %d", pid, os.Getpid())
+	}
+	if _, err := LockWatchQueue("work"); err == nil || !strings.Contains(err.Error(), "in use") {
+		t.Errorf("second LockWatchQueue() error = %v, want in use", err)
+	}
+	if other, err := LockWatchQueue("other"); err != nil {
+		t.Errorf("another profile's queue should lock separately: %v", err)
+	} else {
+		other.Unlock()
+	}
+	if err := lock.Unlock(); err != nil {
+		t.Fatal(err)
+	}
+	if pid := WatchOwner("work"); pid != 0 {
+		t.Errorf("WatchOwner() = %d after Unlock", pid)
+	}
+
+	// A lock left by a process that has exited is taken over.
+	path, _ := watchFile("work", "lock")
+	if err := os.WriteFile(path, []byte("1073741824\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if pid := WatchOwner("work"); pid != 0 {
+		t.Errorf("WatchOwner() = %d for a stale lock", pid)
+	}
+	lock, err = LockWatchQueue("work")
+	if err != nil {
+		t.Fatalf("LockWatchQueue() over a stale lock: %v", err)
+	}
+	lock.Unlock()
+}
@@ -0,0 +1,68 @@
+package display
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ─── Accessible mode ────────────────────────────────────────────────────────
+//
+// --accessible makes output something a screen reader can follow: no color
+// or cursor movement, no spinners redrawing a line in place, icons spoken
+// as words instead of "check mark" or "black circle", and every list item
+// starting with the same "- ". Progress that would animate is announced
+// once as a plain line instead. Writer applies the icon rewriting; the
+// places that animate check Accessible themselves.
+
+var accessibleMode bool
+
+// SetAccessible enables or disables accessible output.
+func SetAccessible(on bool) { accessibleMode = on }
+
+// Accessible reports whether accessible output is enabled.
+func Accessible() bool { return accessibleMode }
+
+// accessibleWords maps icons to the words read out in their place. An
+// empty word drops the icon: rules, box corners and spinner frames only
+// add noise when read aloud.
+var accessibleWords = map[rune]string{
+	'✓': "OK", '✅': "OK", '✗': "Failed", '❌': "Failed", '⊘': "Skipped",
+	'⟳': "In progress", '🔄': "In progress", '⏱': "Time", '⏸': "Paused",
+	'⚠': "Warning", '🚨': "Alert", '💡': "Tip", '❓': "Question",
+	'•': "-", '●': "-", '◆': "-", '⏺': "-", '▸': "-", '▶': "-", '↳': "-", '·': "",
+	'❯': ">", '→': "to", '↑': "up", '↓': "down", '—': "-", '≤': "at most",
+	'💬': "Chat:", '📌': "Note:", '📛': "Name:", '📎': "Attachment:",
+	'📊': "Chart:", '📈': "Chart:", '📋': "List:", '🔍': "Step:", '🔗': "Link:", '📨': "Message:",
+	'🦅': "", '🦜': "", '🧠': "", '🎯': "",
+}
+
+// accessibleRune returns what r is read as in accessible mode. Letters and
+// digits from any script and punctuation are kept; other symbols without a
+// word are dropped.
+func accessibleRune(r rune) string {
+	if r < utf8.RuneSelf {
+		return string(r)
+	}
+	if s, ok := accessibleWords[r]; ok {
+		return s
+	}
+	switch {
+	case unicode.IsLetter(r) || unicode.IsDigit(r):
+		return string(r)
+	case unicode.IsSpace(r):
+		return " "
+	case unicode.IsPunct(r):
+		return string(r)
+	}
+	return ""
+}
+
+// ToAccessible replaces icons in s with words and drops decorative symbols.
+func ToAccessible(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		b.WriteString(accessibleRune(r))
+	}
+	return b.String()
+}
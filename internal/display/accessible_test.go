@@ -0,0 +1,55 @@
+package display
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestToAccessible(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"✓ Done", "OK Done"},
+		{"✗ failed — retry", "Failed failed - retry"},
+		{"  ● Checking logs", "  - Checking logs"},
+		{"⚠️ careful", "Warning careful"},
+		{"🔍 Step 2", "Step: Step 2"},
+		{"───", ""},
+		{"café “quoted”", "café “quoted”"},
+	}
+	for _, tt := range tests {
+		if got := ToAccessible(tt.in); got != tt.want {
+			t.Errorf("ToAccessible(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+
+	var b strings.Builder
+	w := NewWriter(&b, true, 0)
+	w.strip, w.words = true, true
+	w.Write([]byte("\x1b[32m✓\x1b[0m Saved\n\x1b[2A\x1b[J• item\n"))
+	w.Flush()
+	if want := "OK Saved\n- item\n"; b.String() != want {
+		t.Errorf("Writer output = %q, want %q", b.String(), want)
+	}
+}
+
+func TestProgressAnnounced(t *testing.T) {
+	var buf bytes.Buffer
+	p := newProgress(&buf, false)
+	p.announce = true
+	task := p.Add("Uploading")
+	for i := 1; i <= 8; i++ {
+		task.SetProgress(i, 8)
+	}
+	task.SetText("Uploading")
+	task.SetText("Verifying")
+	task.Done()
+	p.Stop()
+
+	want := "Uploading\nUploading 1 of 8\nUploading 2 of 8\nUploading 4 of 8\nUploading 6 of 8\nUploading 8 of 8\nVerifying 8 of 8\n"
+	if got := buf.String(); got != want {
+		t.Errorf("announced = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,7 @@
+//go:build !windows
+
+package display
+
+// enableConsole prepares the console for UTF-8 and escape sequences. Only
+// Windows needs it; other terminals interpret them already.
+func enableConsole() bool { return true }
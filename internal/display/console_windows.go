@@ -0,0 +1,32 @@
+package display
+
+import (
+	"os"
+
+	"golang.org/x/sys/windows"
+)
+
+// cpUTF8 is the Windows code page for UTF-8.
+const cpUTF8 = 65001
+
+// enableConsole switches the Windows console to UTF-8 output and turns on
+// virtual terminal processing, so the console host interprets escape
+// sequences instead of printing them. It reports whether stdout now
+// understands escape sequences; it is false when stdout is not a console
+// or the console predates Windows 10.
+func enableConsole() bool {
+	_ = windows.SetConsoleOutputCP(cpUTF8)
+	vt := false
+	for _, f := range []*os.File{os.Stdout, os.Stderr} {
+		h := windows.Handle(f.Fd())
+		var mode uint32
+		if err := windows.GetConsoleMode(h, &mode); err != nil {
+			continue
+		}
+		err := windows.SetConsoleMode(h, mode|windows.ENABLE_VIRTUAL_TERMINAL_PROCESSING)
+		if f == os.Stdout {
+			vt = err == nil
+		}
+	}
+	return vt
+}
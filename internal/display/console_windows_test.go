@@ -0,0 +1,24 @@
+package display
+
+import (
+	"os"
+	"runtime"
+	"testing"
+)
+
+func TestEnableConsoleWindows(t *testing.T) {
+	// Under go test stdout is a pipe, so there is no console mode to set.
+	if enableConsole() && !isTerminal(os.Stdout) {
+		t.Errorf("enableConsole() = true with stdout not a console")
+	}
+}
+
+func TestDetectTermWindows(t *testing.T) {
+	caps := DetectTerm()
+	if caps.OS != runtime.GOOS {
+		t.Errorf("OS = %q, want %q", caps.OS, runtime.GOOS)
+	}
+	if caps.UnicodeReason == "" || caps.ColorsReason == "" {
+		t.Errorf("missing reasons: %+v", caps)
+	}
+}
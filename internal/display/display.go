@@ -8,22 +8,26 @@ import (
 )
 
 const (
-	Reset   = "\033[0m"
-	Bold    = "\033[1m"
-	Dim     = "\033[2m"
-	Red     = "\033[31m"
-	Green   = "\033[32m"
-	Yellow  = "\033[33m"
-	Blue    = "\033[34m"
-	Magenta = "\033[35m"
-	Cyan    = "\033[36m"
-	White   = "\033[37m"
-	Gray    = "\033[90m"
+	Reset = "\033[0m"
+	Bold  = "\033[1m"
+	Dim   = "\033[2m"
+)
+
+// Colors are set from the active theme; see SetTheme.
+var (
+	Red     string
+	Green   string
+	Yellow  string
+	Blue    string
+	Magenta string
+	Cyan    string
+	White   string
+	Gray    string
 )
 
 func Header(text string) {
 	fmt.Printf("\n%s%s%s\n", Bold+Cyan, text, Reset)
-	fmt.Println(strings.Repeat("─", min(len(text)+4, 80)))
+	fmt.Println(strings.Repeat("─", FitWidth(min(len(text)+4, 80))))
 }
 
 func SubHeader(text string) {
@@ -46,12 +50,12 @@ func Info(label, value string) {
 	fmt.Printf("  %s%-20s%s %s\n", Dim, label, Reset, value)
 }
 
-func Spinner(text string) {
-	fmt.Printf("\r%s⟳%s %s", Yellow, Reset, text)
-}
-
-func ClearLine() {
-	fmt.Print("\r\033[K")
+// ClearLines moves the cursor up n lines and clears to the end of the
+// screen, so a block printed earlier can be redrawn in place.
+func ClearLines(n int) {
+	if n > 0 {
+		fmt.Printf("\033[%dA\033[J", n)
+	}
 }
 
 // Content type display for streaming
@@ -106,15 +110,103 @@ func InvestigationStatusLabel(status string) string {
 	return status
 }
 
-func FormatTime(ts string) string {
+// Time display settings. The location comes from `hawkeye set timezone`
+// and relative mode from the --relative flag; JSON output is unaffected
+// since it prints the raw API values.
+var (
+	timeLocation = time.Local
+	relativeTime bool
+	timeNow      = time.Now
+)
+
+// SetTimeZone sets the zone used by FormatTime. "" and "local" use the
+// system zone.
+func SetTimeZone(name string) error {
+	loc, err := LoadTimeZone(name)
+	if err != nil {
+		return err
+	}
+	timeLocation = loc
+	return nil
+}
+
+// LoadTimeZone resolves an IANA zone name such as "Europe/Berlin".
+// "" and "local" return the system zone.
+func LoadTimeZone(name string) (*time.Location, error) {
+	switch strings.ToLower(name) {
+	case "", "local":
+		return time.Local, nil
+	case "utc":
+		return time.UTC, nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("unknown time zone %q (use an IANA name like America/New_York)", name)
+	}
+	return loc, nil
+}
+
+// SetRelativeTime switches FormatTime to relative output ("2h ago").
+func SetRelativeTime(on bool) {
+	relativeTime = on
+}
+
+// InZone converts t to the configured display zone.
+func InZone(t time.Time) time.Time {
+	return t.In(timeLocation)
+}
+
+// ParseTime parses an API timestamp (RFC3339, with or without fractions).
+func ParseTime(ts string) (time.Time, bool) {
 	t, err := time.Parse(time.RFC3339Nano, ts)
 	if err != nil {
 		t, err = time.Parse(time.RFC3339, ts)
 		if err != nil {
-			return ts
+			return time.Time{}, false
 		}
 	}
-	return t.Local().Format("2006-01-02 15:04:05")
+	return t, true
+}
+
+// FormatTime renders an API timestamp in the configured zone, or relative
+// to now when --relative is set. Unparseable values are returned as-is.
+func FormatTime(ts string) string {
+	t, ok := ParseTime(ts)
+	if !ok {
+		return ts
+	}
+	if relativeTime {
+		return RelativeTime(t, timeNow())
+	}
+	return InZone(t).Format("2006-01-02 15:04:05")
+}
+
+// RelativeTime describes t relative to now, e.g. "5m ago" or "in 2h".
+// Anything more than 30 days away falls back to a date.
+func RelativeTime(t, now time.Time) string {
+	d := now.Sub(t)
+	future := d < 0
+	if future {
+		d = -d
+	}
+
+	var span string
+	switch {
+	case d < time.Minute:
+		return "just now"
+	case d < time.Hour:
+		span = fmt.Sprintf("%dm", int(d/time.Minute))
+	case d < 24*time.Hour:
+		span = fmt.Sprintf("%dh", int(d/time.Hour))
+	case d <= 30*24*time.Hour:
+		span = fmt.Sprintf("%dd", int(d/(24*time.Hour)))
+	default:
+		return InZone(t).Format("2006-01-02")
+	}
+	if future {
+		return "in " + span
+	}
+	return span + " ago"
 }
 
 func min(a, b int) int {
@@ -143,3 +143,61 @@ func TestFormatTime(t *testing.T) {
 		})
 	}
 }
+
+func TestFormatTimeZoneAndRelative(t *testing.T) {
+	defer func() {
+		timeLocation = time.Local
+		relativeTime = false
+		timeNow = time.Now
+	}()
+
+	if err := SetTimeZone("Asia/Tokyo"); err != nil {
+		t.Skipf("tzdata unavailable: %v", err)
+	}
+	if got := FormatTime("2024-01-15T10:30:00Z"); got != "2024-01-15 19:30:00" {
+		t.Errorf("FormatTime in Asia/Tokyo = %q, want 2024-01-15 19:30:00", got)
+	}
+	if err := SetTimeZone("Mars/Olympus"); err == nil {
+		t.Error("SetTimeZone(invalid) expected error")
+	}
+
+	timeNow = func() time.Time { return time.Date(2024, 1, 15, 12, 30, 0, 0, time.UTC) }
+	SetRelativeTime(true)
+	if got := FormatTime("2024-01-15T10:30:00Z"); got != "2h ago" {
+		t.Errorf("relative FormatTime = %q, want 2h ago", got)
+	}
+	if got := FormatTime("garbage"); got != "garbage" {
+		t.Errorf("relative FormatTime(garbage) = %q", got)
+	}
+}
+
+func TestRelativeTime(t *testing.T) {
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		t    time.Time
+		want string
+	}{
+		{now.Add(-20 * time.Second), "just now"},
+		{now.Add(-5 * time.Minute), "5m ago"},
+		{now.Add(-2*time.Hour - 10*time.Minute), "2h ago"},
+		{now.Add(-3 * 24 * time.Hour), "3d ago"},
+		{now.Add(45 * time.Minute), "in 45m"},
+		{now.Add(-40 * 24 * time.Hour), now.Add(-40 * 24 * time.Hour).In(timeLocation).Format("2006-01-02")},
+	}
+	for _, tt := range tests {
+		if got := RelativeTime(tt.t, now); got != tt.want {
+			t.Errorf("RelativeTime(%v) = %q, want %q", tt.t, got, tt.want)
+		}
+	}
+}
+
+func TestLoadTimeZone(t *testing.T) {
+	for _, name := range []string{"", "local", "LOCAL", "UTC"} {
+		if _, err := LoadTimeZone(name); err != nil {
+			t.Errorf("LoadTimeZone(%q) error = %v", name, err)
+		}
+	}
+	if _, err := LoadTimeZone("Not/AZone"); err == nil {
+		t.Error("LoadTimeZone(Not/AZone) expected error")
+	}
+}
@@ -0,0 +1,64 @@
+package display
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// ─── GitHub Actions output ──────────────────────────────────────────────────
+//
+// With --output gha, commands report through workflow commands
+// (::notice::, ::warning::, ::error::) so results show up as annotations
+// in the Actions UI, and append markdown to the job summary file named by
+// GITHUB_STEP_SUMMARY.
+
+// GHAAnnotation formats a workflow command of the given level ("notice",
+// "warning" or "error") with an optional title.
+func GHAAnnotation(level, title, msg string) string {
+	props := ""
+	if title != "" {
+		props = " title=" + ghaEscapeProperty(title)
+	}
+	return fmt.Sprintf("::%s%s::%s", level, props, ghaEscapeData(msg))
+}
+
+// GHANotice prints a notice annotation.
+func GHANotice(title, msg string) { fmt.Println(GHAAnnotation("notice", title, msg)) }
+
+// GHAWarning prints a warning annotation.
+func GHAWarning(title, msg string) { fmt.Println(GHAAnnotation("warning", title, msg)) }
+
+// GHAError prints an error annotation.
+func GHAError(title, msg string) { fmt.Println(GHAAnnotation("error", title, msg)) }
+
+// GHAGroup prints body inside a collapsible log group.
+func GHAGroup(title, body string) {
+	fmt.Printf("::group::%s\n%s\n::endgroup::\n", ghaEscapeData(title), strings.TrimRight(body, "\n"))
+}
+
+// GHASummary appends markdown to the job summary. Outside Actions, where
+// GITHUB_STEP_SUMMARY is unset, it does nothing.
+func GHASummary(markdown string) error {
+	path := os.Getenv("GITHUB_STEP_SUMMARY")
+	if path == "" {
+		return nil
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("writing job summary: %w", err)
+	}
+	defer f.Close()
+	if _, err := f.WriteString(strings.TrimRight(markdown, "\n") + "\n\n"); err != nil {
+		return fmt.Errorf("writing job summary: %w", err)
+	}
+	return nil
+}
+
+func ghaEscapeData(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A").Replace(s)
+}
+
+func ghaEscapeProperty(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C").Replace(s)
+}
@@ -0,0 +1,44 @@
+package display
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestGHAAnnotation(t *testing.T) {
+	tests := []struct {
+		level, title, msg string
+		want              string
+	}{
+		{"notice", "", "done", "::notice::done"},
+		{"error", "Hawkeye: RCA, score", "line one\nline two 100%", "::error title=Hawkeye%3A RCA%2C score::line one%0Aline two 100%25"},
+	}
+	for _, tt := range tests {
+		if got := GHAAnnotation(tt.level, tt.title, tt.msg); got != tt.want {
+			t.Errorf("GHAAnnotation(%q, %q, %q) = %q, want %q", tt.level, tt.title, tt.msg, got, tt.want)
+		}
+	}
+}
+
+func TestGHASummary(t *testing.T) {
+	t.Setenv("GITHUB_STEP_SUMMARY", "")
+	if err := GHASummary("ignored"); err != nil {
+		t.Fatalf("GHASummary() without env error = %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "summary.md")
+	t.Setenv("GITHUB_STEP_SUMMARY", path)
+	for _, md := range []string{"# One\n", "# Two"} {
+		if err := GHASummary(md); err != nil {
+			t.Fatalf("GHASummary() error = %v", err)
+		}
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "# One\n\n# Two\n\n"; string(data) != want {
+		t.Errorf("summary = %q, want %q", data, want)
+	}
+}
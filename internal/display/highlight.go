@@ -0,0 +1,53 @@
+package display
+
+import (
+	"strings"
+
+	"github.com/alecthomas/chroma/v2"
+	"github.com/alecthomas/chroma/v2/formatters"
+	"github.com/alecthomas/chroma/v2/lexers"
+	"github.com/alecthomas/chroma/v2/styles"
+)
+
+// ─── Syntax highlighting ────────────────────────────────────────────────────
+
+// HighlightCode colors source code for the terminal using the lexer for
+// lang (a fence info string such as "go", "yaml" or "sql"). Code is
+// returned unchanged when the language is unknown or the palette is mono.
+func HighlightCode(code, lang string) string {
+	style := palette.codeStyle()
+	if style == "" || lang == "" {
+		return code
+	}
+	lexer := lexers.Get(strings.ToLower(strings.Fields(lang)[0]))
+	if lexer == nil {
+		return code
+	}
+	it, err := chroma.Coalesce(lexer).Tokenise(nil, code)
+	if err != nil {
+		return code
+	}
+	var b strings.Builder
+	if err := formatters.TTY256.Format(&b, styles.Get(style), it); err != nil {
+		return code
+	}
+	// Tokenise ensures the code ends in a newline; drop it if we added it.
+	out := b.String()
+	if !strings.HasSuffix(code, "\n") {
+		if i := strings.LastIndexByte(out, '\n'); i >= 0 {
+			out = out[:i] + out[i+1:]
+		}
+	}
+	return out
+}
+
+// codeStyle names the chroma style that suits the palette, or "" for none.
+func (p Palette) codeStyle() string {
+	switch p.Base {
+	case "light":
+		return "github"
+	case "mono":
+		return ""
+	}
+	return "monokai"
+}
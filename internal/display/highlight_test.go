@@ -0,0 +1,30 @@
+package display
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestHighlightCode(t *testing.T) {
+	defer applyPalette(palette)
+
+	applyPalette(Palette{Base: "dark"})
+	got := HighlightCode("x := 1", "go")
+	if !strings.Contains(got, "\033[") || strings.Contains(got, "\n") {
+		t.Errorf("HighlightCode(go) = %q, want colored single line", got)
+	}
+	if got := HighlightCode("a: b\nc: d\n", "yaml"); strings.Count(got, "\n") != 2 {
+		t.Errorf("HighlightCode(yaml) = %q, want both newlines kept", got)
+	}
+
+	for _, tt := range []struct{ base, lang string }{
+		{"dark", ""},
+		{"dark", "no-such-language"},
+		{"mono", "go"},
+	} {
+		applyPalette(Palette{Base: tt.base})
+		if got := HighlightCode("x := 1", tt.lang); got != "x := 1" {
+			t.Errorf("HighlightCode(%s, %q) = %q, want unchanged", tt.base, tt.lang, got)
+		}
+	}
+}
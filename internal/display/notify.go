@@ -0,0 +1,18 @@
+package display
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// RunNotifier runs a desktop notification command, as built by
+// service.NotifyCommand. When it fails, what it printed is added to the
+// error.
+func RunNotifier(name string, args []string) error {
+	out, err := exec.Command(name, args...).CombinedOutput()
+	if err != nil && len(strings.TrimSpace(string(out))) > 0 {
+		return fmt.Errorf("%s: %v: %s", name, err, strings.TrimSpace(string(out)))
+	}
+	return err
+}
@@ -0,0 +1,296 @@
+package display
+
+import (
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ─── ASCII mode and output width ────────────────────────────────────────────
+//
+// Runbook automation captures CLI output into systems that mangle emoji and
+// assume a fixed column count. ASCII mode swaps icons for plain markers and
+// a non-zero width wraps output at that column. Both are applied by Writer,
+// which sits between the process and the real stdout/stderr.
+
+var (
+	asciiMode   bool
+	outputWidth int
+)
+
+// SetASCII enables or disables ASCII-only output.
+func SetASCII(on bool) { asciiMode = on }
+
+// ASCII reports whether ASCII-only output is enabled.
+func ASCII() bool { return asciiMode }
+
+// SetWidth sets the column output is wrapped at. Zero disables wrapping.
+func SetWidth(n int) {
+	if n < 0 {
+		n = 0
+	}
+	outputWidth = n
+}
+
+// Width returns the configured output width, or 0 when unset.
+func Width() int { return outputWidth }
+
+// FitWidth caps n, typically a rule or column width, at the output width.
+func FitWidth(n int) int {
+	if outputWidth > 0 && n > outputWidth {
+		return outputWidth
+	}
+	return n
+}
+
+// asciiIcons maps the icons used across the CLI and TUI to ASCII markers.
+var asciiIcons = map[rune]string{
+	'✓': "[ok]", '✅': "[ok]", '✗': "[x]", '❌': "[x]", '⊘': "[-]",
+	'⟳': "~", '🔄': "~", '⏱': "~", '⏸': "||",
+	'•': "*", '●': "*", '⏺': "*", '·': "-",
+	'❯': ">", '▸': ">", '▶': ">", '→': "->", '↳': "->", '↑': "^", '↓': "v",
+	'—': "--", '≤': "<=",
+	'─': "-", '━': "=", '│': "|",
+	'┌': "+", '┐': "+", '└': "+", '┘': "+", '├': "+", '┤': "+", '┬': "+", '┴': "+", '┼': "+",
+	'💬': ">", '💡': "?", '❓': "?", '🚨': "!", '📌': "#", '📛': "#",
+	'📎': "+", '📊': "#", '📈': "#", '📋': "#", '🔍': ">", '🔗': "@", '📨': ">",
+	'🦅': "", '🦜': "", '🧠': "", '🎯': "",
+}
+
+// asciiRune returns the ASCII replacement for r. Letters and digits from
+// other scripts are kept; unmapped symbols become "*" and joiners and
+// variation selectors are dropped.
+func asciiRune(r rune) string {
+	if r < utf8.RuneSelf {
+		return string(r)
+	}
+	if s, ok := asciiIcons[r]; ok {
+		return s
+	}
+	switch {
+	case r == '\u200d' || (r >= '\ufe00' && r <= '\ufe0f') || unicode.Is(unicode.Mn, r):
+		return ""
+	case r >= 0x2800 && r <= 0x28ff: // braille spinner frames
+		return "*"
+	case unicode.IsLetter(r) || unicode.IsDigit(r):
+		return string(r)
+	case unicode.IsSpace(r):
+		return " "
+	}
+	return "*"
+}
+
+// ToASCII replaces icons and other non-ASCII symbols in s with ASCII markers.
+func ToASCII(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		b.WriteString(asciiRune(r))
+	}
+	return b.String()
+}
+
+// Apply formats s with the current ASCII, width and color settings.
+func Apply(s string) string {
+	if !filtering() {
+		return s
+	}
+	var b strings.Builder
+	w := NewWriter(&b, asciiMode, outputWidth)
+	w.strip = colorLevel == ColorNone
+	w.words = accessibleMode
+	w.Write([]byte(s))
+	w.Flush()
+	return b.String()
+}
+
+// Writer rewrites a text stream for ASCII mode and wraps it at a fixed width.
+// It is safe across arbitrary write boundaries: partial UTF-8 sequences,
+// escape sequences and words are carried over to the next Write. Escape
+// sequences take no columns; words longer than the width are broken.
+// Output may lag by one word until the next blank, newline or Flush.
+type Writer struct {
+	mu    sync.Mutex
+	w     io.Writer
+	ascii bool
+	width int
+	strip bool // drop escape sequences, for terminals without color
+	words bool // speak icons as words, for accessible mode
+
+	pending []byte // incomplete UTF-8 tail from the previous write
+	spaces  []byte // blanks before the current word
+	word    []byte // current word, not yet placed on a line
+	wordLen int    // visible width of word
+	col     int    // visible column of the output cursor
+	esc     int    // 0 text, 1 after ESC, 2 inside CSI
+	out     []byte
+}
+
+// NewWriter returns a Writer that writes to w.
+func NewWriter(w io.Writer, ascii bool, width int) *Writer {
+	return &Writer{w: w, ascii: ascii, width: width}
+}
+
+// Write implements io.Writer.
+func (fw *Writer) Write(p []byte) (int, error) {
+	fw.mu.Lock()
+	defer fw.mu.Unlock()
+
+	data := append(fw.pending, p...)
+	fw.pending = nil
+	for len(data) > 0 {
+		if !utf8.FullRune(data) {
+			fw.pending = append([]byte(nil), data...)
+			break
+		}
+		r, size := utf8.DecodeRune(data)
+		fw.writeRune(r, data[:size])
+		data = data[size:]
+	}
+	if fw.width == 0 {
+		fw.flushWord()
+	}
+	return len(p), fw.emit()
+}
+
+// Flush writes out any buffered word and partial input.
+func (fw *Writer) Flush() error {
+	fw.mu.Lock()
+	defer fw.mu.Unlock()
+	fw.flushWord()
+	fw.out = append(fw.out, fw.pending...)
+	fw.pending = nil
+	return fw.emit()
+}
+
+func (fw *Writer) writeRune(r rune, raw []byte) {
+	switch fw.esc {
+	case 1:
+		fw.appendEscape(raw)
+		fw.esc = 0
+		if r == '[' {
+			fw.esc = 2
+		}
+		return
+	case 2:
+		fw.appendEscape(raw)
+		if r >= 0x40 && r <= 0x7e {
+			fw.esc = 0
+		}
+		return
+	}
+
+	switch r {
+	case 0x1b:
+		fw.appendEscape(raw)
+		fw.esc = 1
+	case '\n', '\r':
+		fw.flushWord()
+		fw.out = append(fw.out, raw...)
+		fw.col = 0
+	case ' ', '\t':
+		if len(fw.word) > 0 {
+			fw.flushWord()
+		}
+		fw.spaces = append(fw.spaces, raw...)
+	default:
+		text := string(raw)
+		switch {
+		case fw.words:
+			text = accessibleRune(r)
+		case fw.ascii:
+			text = asciiRune(r)
+		}
+		for _, c := range text {
+			if fw.width > 0 && fw.wordLen >= fw.width {
+				fw.flushWord()
+			}
+			fw.word = utf8.AppendRune(fw.word, c)
+			fw.wordLen++
+		}
+	}
+}
+
+func (fw *Writer) appendEscape(raw []byte) {
+	if !fw.strip {
+		fw.word = append(fw.word, raw...)
+	}
+}
+
+// flushWord places the buffered spaces and word. A word that would overflow
+// the width starts a new line instead, dropping the spaces before it.
+func (fw *Writer) flushWord() {
+	wrap := fw.width > 0 && fw.col > 0 && fw.col+len(fw.spaces)+fw.wordLen > fw.width
+	switch {
+	case wrap && fw.wordLen > 0:
+		fw.out = append(fw.out, '\n')
+		fw.col = 0
+	case wrap:
+		// trailing spaces past the width
+	default:
+		fw.out = append(fw.out, fw.spaces...)
+		fw.col += len(fw.spaces)
+	}
+	fw.spaces = fw.spaces[:0]
+	fw.out = append(fw.out, fw.word...)
+	fw.col += fw.wordLen
+	fw.word = fw.word[:0]
+	fw.wordLen = 0
+}
+
+func (fw *Writer) emit() error {
+	if len(fw.out) == 0 {
+		return nil
+	}
+	_, err := fw.w.Write(fw.out)
+	fw.out = fw.out[:0]
+	return err
+}
+
+// FilterStdio routes os.Stdout and os.Stderr through Writers with the
+// current settings. The returned function flushes pending output and
+// restores the original files; it must run before the process exits.
+// Escape sequences are stripped when the color level is ColorNone. When
+// no option is set FilterStdio does nothing.
+func FilterStdio() (restore func()) {
+	if !filtering() {
+		return func() {}
+	}
+	restoreOut := filterFile(&os.Stdout)
+	restoreErr := filterFile(&os.Stderr)
+	return func() {
+		restoreOut()
+		restoreErr()
+	}
+}
+
+// filtering reports whether output needs rewriting at all.
+func filtering() bool {
+	return asciiMode || accessibleMode || outputWidth > 0 || colorLevel == ColorNone
+}
+
+func filterFile(f **os.File) func() {
+	orig := *f
+	r, w, err := os.Pipe()
+	if err != nil {
+		return func() {}
+	}
+	*f = w
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		fw := NewWriter(orig, asciiMode, outputWidth)
+		fw.strip = colorLevel == ColorNone
+		fw.words = accessibleMode
+		io.Copy(fw, r)
+		fw.Flush()
+		r.Close()
+	}()
+	return func() {
+		w.Close()
+		<-done
+		*f = orig
+	}
+}
@@ -0,0 +1,73 @@
+package display
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestToASCII(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"plain text", "plain text"},
+		{"✓ Done", "[ok] Done"},
+		{"✗ failed — retry", "[x] failed -- retry"},
+		{"💬 Answer", "> Answer"},
+		{"⚠️ careful", "* careful"},
+		{"café", "café"},
+		{"───", "---"},
+	}
+	for _, tt := range tests {
+		if got := ToASCII(tt.in); got != tt.want {
+			t.Errorf("ToASCII(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestWriterWrap(t *testing.T) {
+	tests := []struct {
+		name   string
+		chunks []string
+		ascii  bool
+		width  int
+		want   string
+	}{
+		{"no options", []string{"✓ hello world\n"}, false, 0, "✓ hello world\n"},
+		{"ascii only", []string{"✓ hello\n"}, true, 0, "[ok] hello\n"},
+		{"word wrap", []string{"the quick brown fox\n"}, false, 10, "the quick\nbrown fox\n"},
+		{"ansi is zero width", []string{"\x1b[32mgreen\x1b[0m words here\n"}, false, 11, "\x1b[32mgreen\x1b[0m words\nhere\n"},
+		{"long word broken", []string{"abcdefghij\n"}, false, 4, "abcd\nefgh\nij\n"},
+		{"split writes", []string{"hello wo", "rld again\n"}, false, 11, "hello world\nagain\n"},
+		{"indent kept", []string{"  ab cd\n"}, false, 5, "  ab\ncd\n"},
+		{"split rune", []string{"\xe2\x9c", "\x93 ok\n"}, true, 0, "[ok] ok\n"},
+		{"carriage return resets column", []string{"\rabcd\rabcd efg\n"}, false, 8, "\rabcd\rabcd efg\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var b strings.Builder
+			w := NewWriter(&b, tt.ascii, tt.width)
+			for _, c := range tt.chunks {
+				w.Write([]byte(c))
+			}
+			w.Flush()
+			if b.String() != tt.want {
+				t.Errorf("output = %q, want %q", b.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestFitWidth(t *testing.T) {
+	defer SetWidth(0)
+	if got := FitWidth(80); got != 80 {
+		t.Errorf("FitWidth(80) unset = %d", got)
+	}
+	SetWidth(60)
+	if got := FitWidth(80); got != 60 {
+		t.Errorf("FitWidth(80) at width 60 = %d", got)
+	}
+	if got := FitWidth(20); got != 20 {
+		t.Errorf("FitWidth(20) at width 60 = %d", got)
+	}
+}
@@ -0,0 +1,248 @@
+package display
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ─── Progress ───────────────────────────────────────────────────────────────
+//
+// A Progress owns a block of live status lines at the bottom of the output,
+// one per running Task, redrawn in place as tasks change. Tasks may be
+// updated from several goroutines. Permanent output printed while tasks
+// run goes through Progress.Printf so it lands above the block.
+//
+// When stdout is not a terminal (piped, redirected, CI logs) the block is
+// never drawn and only Printf output appears. In accessible mode it is not
+// drawn either; instead each new task, change of text and quarter of
+// progress is announced once as a line of its own.
+
+var (
+	spinnerFrames      = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+	spinnerFramesASCII = []string{"|", "/", "-", `\`}
+)
+
+// progressTick is how often running spinners advance a frame.
+const progressTick = 100 * time.Millisecond
+
+// progressLive is decided once at startup, before FilterStdio can swap
+// os.Stdout for a pipe.
+var progressLive = isTerminal(os.Stdout) && os.Getenv("TERM") != "dumb"
+
+// SetProgressEnabled turns live progress lines on or off for the process.
+func SetProgressEnabled(on bool) { progressLive = on }
+
+func isTerminal(f *os.File) bool {
+	fi, err := f.Stat()
+	return err == nil && fi.Mode()&os.ModeCharDevice != 0
+}
+
+// Progress is a set of concurrently updated status lines.
+type Progress struct {
+	mu       sync.Mutex
+	out      io.Writer // nil means os.Stdout at write time
+	live     bool
+	announce bool // print task changes as lines instead of drawing them
+	tasks    []*Task
+	drawn    int
+	frame    int
+	stop     chan struct{}
+	stopped  bool
+}
+
+// Task is one line of a Progress.
+type Task struct {
+	p       *Progress
+	text    string
+	current int
+	total   int
+}
+
+// NewProgress returns an empty Progress writing to stdout.
+func NewProgress() *Progress {
+	p := newProgress(nil, progressLive && !accessibleMode)
+	p.announce = accessibleMode
+	if p.live {
+		go p.animate()
+	}
+	return p
+}
+
+// newProgress returns a Progress that does not animate on its own.
+func newProgress(w io.Writer, live bool) *Progress {
+	return &Progress{out: w, live: live, stop: make(chan struct{})}
+}
+
+// Spin starts a Progress with a single spinner line, for the common case
+// of waiting on one call. Stop clears it.
+func Spin(text string) *Progress {
+	p := NewProgress()
+	p.Add(text)
+	return p
+}
+
+// Add starts a new task line.
+func (p *Progress) Add(text string) *Task {
+	t := &Task{p: p, text: text}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if !p.stopped {
+		p.tasks = append(p.tasks, t)
+		p.announceTask(t)
+		p.redraw()
+	}
+	return t
+}
+
+// SetText replaces the task's status text.
+func (t *Task) SetText(text string) {
+	t.p.mu.Lock()
+	defer t.p.mu.Unlock()
+	changed := text != t.text
+	t.text = text
+	if changed {
+		t.p.announceTask(t)
+	}
+	t.p.redraw()
+}
+
+// SetProgress shows the task as current of total with a progress bar.
+func (t *Task) SetProgress(current, total int) {
+	t.p.mu.Lock()
+	defer t.p.mu.Unlock()
+	before := t.quarter()
+	t.current, t.total = current, total
+	if t.quarter() != before {
+		t.p.announceTask(t)
+	}
+	t.p.redraw()
+}
+
+// Done removes the task's line.
+func (t *Task) Done() {
+	p := t.p
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for i, other := range p.tasks {
+		if other == t {
+			p.tasks = append(p.tasks[:i], p.tasks[i+1:]...)
+			break
+		}
+	}
+	p.redraw()
+}
+
+// Printf prints a permanent line above the live block.
+func (p *Progress) Printf(format string, args ...any) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	w := p.writer()
+	p.clear(w)
+	fmt.Fprintf(w, format, args...)
+	p.draw(w)
+}
+
+// Stop clears the live block. It is safe to call more than once.
+func (p *Progress) Stop() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.stopped {
+		return
+	}
+	p.stopped = true
+	close(p.stop)
+	p.tasks = nil
+	p.clear(p.writer())
+}
+
+func (p *Progress) animate() {
+	ticker := time.NewTicker(progressTick)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-p.stop:
+			return
+		case <-ticker.C:
+			p.mu.Lock()
+			p.frame++
+			p.redraw()
+			p.mu.Unlock()
+		}
+	}
+}
+
+func (p *Progress) writer() io.Writer {
+	if p.out != nil {
+		return p.out
+	}
+	return os.Stdout
+}
+
+// redraw, clear and draw expect p.mu to be held.
+func (p *Progress) redraw() {
+	w := p.writer()
+	p.clear(w)
+	p.draw(w)
+}
+
+// announceTask prints the task's state as a permanent line in accessible
+// mode. Caller must hold p.mu.
+func (p *Progress) announceTask(t *Task) {
+	if !p.announce || p.stopped {
+		return
+	}
+	line := t.text
+	if t.total > 0 {
+		line = fmt.Sprintf("%s %d of %d", t.text, min(t.current, t.total), t.total)
+	}
+	fmt.Fprintln(p.writer(), line)
+}
+
+func (p *Progress) clear(w io.Writer) {
+	if !p.live || p.drawn == 0 {
+		return
+	}
+	fmt.Fprintf(w, "\r\033[%dA\033[J", p.drawn)
+	p.drawn = 0
+}
+
+func (p *Progress) draw(w io.Writer) {
+	if !p.live || p.stopped {
+		return
+	}
+	frames := spinnerFrames
+	if asciiMode {
+		frames = spinnerFramesASCII
+	}
+	var b strings.Builder
+	for _, t := range p.tasks {
+		fmt.Fprintf(&b, "%s%s%s %s\n", Yellow, frames[p.frame%len(frames)], Reset, t.line())
+	}
+	io.WriteString(w, b.String())
+	p.drawn = len(p.tasks)
+}
+
+// progressBarWidth is the number of cells in a task's progress bar.
+const progressBarWidth = 20
+
+// quarter is how many quarters of its total the task has done, or -1
+// without a total.
+func (t *Task) quarter() int {
+	if t.total <= 0 {
+		return -1
+	}
+	return 4 * min(t.current, t.total) / t.total
+}
+
+func (t *Task) line() string {
+	if t.total <= 0 {
+		return t.text
+	}
+	filled := progressBarWidth * min(t.current, t.total) / t.total
+	bar := strings.Repeat("█", filled) + strings.Repeat("░", progressBarWidth-filled)
+	return fmt.Sprintf("%s %s%s%s %d/%d", t.text, Cyan, bar, Reset, t.current, t.total)
+}
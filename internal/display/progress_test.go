@@ -0,0 +1,101 @@
+package display
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestProgressPiped(t *testing.T) {
+	var buf bytes.Buffer
+	p := newProgress(&buf, false)
+	task := p.Add("Loading...")
+	task.SetProgress(1, 3)
+	p.Printf("result %d\n", 1)
+	task.Done()
+	p.Stop()
+
+	if got := buf.String(); got != "result 1\n" {
+		t.Errorf("piped output = %q, want only the Printf line", got)
+	}
+}
+
+func TestProgressLive(t *testing.T) {
+	var buf bytes.Buffer
+	p := newProgress(&buf, true)
+	defer p.Stop()
+
+	a := p.Add("alpha")
+	b := p.Add("beta")
+	if out := buf.String(); !strings.Contains(out, "alpha\n") || !strings.Contains(out, "beta\n") {
+		t.Fatalf("live block = %q", out)
+	}
+
+	buf.Reset()
+	p.Printf("done alpha\n")
+	out := buf.String()
+	if !strings.HasPrefix(out, "\r\033[2A\033[J") || !strings.Contains(out, "done alpha\n") {
+		t.Errorf("Printf did not clear the block before printing: %q", out)
+	}
+	if i, j := strings.Index(out, "done alpha"), strings.LastIndex(out, "beta"); j < i {
+		t.Errorf("block not redrawn below the printed line: %q", out)
+	}
+
+	buf.Reset()
+	a.Done()
+	b.SetProgress(5, 10)
+	out = buf.String()
+	if strings.Contains(out, "alpha") || !strings.Contains(out, "5/10") {
+		t.Errorf("after Done/SetProgress = %q", out)
+	}
+
+	buf.Reset()
+	p.Stop()
+	if out := buf.String(); out != "\r\033[1A\033[J" {
+		t.Errorf("Stop() wrote %q, want a clear of one line", out)
+	}
+	p.Stop() // idempotent
+}
+
+func TestProgressConcurrent(t *testing.T) {
+	var buf bytes.Buffer
+	p := newProgress(&buf, true)
+	var wg sync.WaitGroup
+	for i := range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			task := p.Add(fmt.Sprintf("job %d", i))
+			task.SetText(fmt.Sprintf("job %d running", i))
+			p.Printf("job %d finished\n", i)
+			task.Done()
+		}()
+	}
+	wg.Wait()
+	if len(p.tasks) != 0 || p.drawn != 0 {
+		t.Errorf("tasks = %d drawn = %d after all jobs finished", len(p.tasks), p.drawn)
+	}
+	p.Stop()
+	if n := strings.Count(buf.String(), "finished\n"); n != 20 {
+		t.Errorf("printed %d result lines, want 20", n)
+	}
+}
+
+func TestTaskLine(t *testing.T) {
+	tests := []struct {
+		current, total int
+		want           string
+	}{
+		{0, 0, "Analyzing"},
+		{5, 10, "Analyzing " + Cyan + strings.Repeat("█", 10) + strings.Repeat("░", 10) + Reset + " 5/10"},
+		{12, 10, "Analyzing " + Cyan + strings.Repeat("█", 20) + Reset + " 12/10"},
+	}
+	for _, tt := range tests {
+		task := &Task{text: "Analyzing", current: tt.current, total: tt.total}
+		if got := task.line(); got != tt.want {
+			t.Errorf("line(%d/%d) = %q, want %q", tt.current, tt.total, got, tt.want)
+		}
+	}
+}
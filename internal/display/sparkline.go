@@ -0,0 +1,40 @@
+package display
+
+import (
+	"math"
+	"strings"
+)
+
+var (
+	sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+	sparkASCII  = []rune("_.-~=+*#")
+)
+
+// Sparkline draws values as a row of block characters scaled between their
+// minimum and maximum, or ASCII marks in ASCII mode. NaN values, such as
+// periods without data, are drawn as blanks.
+func Sparkline(values []float64) string {
+	ramp := sparkBlocks
+	if asciiMode {
+		ramp = sparkASCII
+	}
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for _, v := range values {
+		if !math.IsNaN(v) {
+			lo, hi = math.Min(lo, v), math.Max(hi, v)
+		}
+	}
+	var b strings.Builder
+	for _, v := range values {
+		switch {
+		case math.IsNaN(v):
+			b.WriteRune(' ')
+		case hi == lo:
+			b.WriteRune(ramp[len(ramp)/2])
+		default:
+			i := int(math.Round((v - lo) / (hi - lo) * float64(len(ramp)-1)))
+			b.WriteRune(ramp[i])
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,28 @@
+package display
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSparkline(t *testing.T) {
+	defer SetASCII(false)
+
+	tests := []struct {
+		values []float64
+		ascii  bool
+		want   string
+	}{
+		{[]float64{0, 1, 2, 3, 4, 5, 6, 7}, false, "▁▂▃▄▅▆▇█"},
+		{[]float64{10, math.NaN(), 20}, false, "▁ █"},
+		{[]float64{5, 5}, false, "▅▅"},
+		{[]float64{0, 7}, true, "_#"},
+		{nil, false, ""},
+	}
+	for _, tt := range tests {
+		SetASCII(tt.ascii)
+		if got := Sparkline(tt.values); got != tt.want {
+			t.Errorf("Sparkline(%v) = %q, want %q", tt.values, got, tt.want)
+		}
+	}
+}
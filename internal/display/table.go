@@ -0,0 +1,251 @@
+package display
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"golang.org/x/term"
+)
+
+// ─── Tables ─────────────────────────────────────────────────────────────────
+//
+// Table lays out listings as aligned columns sized to their content and to
+// the output: the --width setting, else the terminal, else unlimited when
+// piped. When the row is too wide the widest columns shrink first and cells
+// are cut with an ellipsis. Cells may carry ANSI colors; they take no
+// columns. Columns marked Wide only appear with --wide.
+
+// Align is a column's alignment.
+type Align int
+
+const (
+	AlignLeft Align = iota
+	AlignRight
+)
+
+// Column describes one table column.
+type Column struct {
+	Title string
+	Align Align
+	Max   int  // widest the column grows to; 0 means no limit
+	Wide  bool // only shown by wide tables
+	Keep  bool // never shrunk to fit, e.g. IDs that must stay copyable
+}
+
+// Table is a set of rows to render as aligned columns.
+type Table struct {
+	Columns []Column
+	Rows    [][]string
+	Border  bool // draw box borders around and between cells
+	Wide    bool // include Wide columns
+}
+
+// minColumnWidth is as narrow as shrinking makes a column.
+const minColumnWidth = 4
+
+// stdoutFd is captured before FilterStdio can swap os.Stdout for a pipe.
+var stdoutFd = int(os.Stdout.Fd())
+
+// NewTable returns an empty table with the given columns.
+func NewTable(cols ...Column) *Table {
+	return &Table{Columns: cols}
+}
+
+// Row appends a row. Missing cells are blank; extra ones are ignored.
+func (t *Table) Row(cells ...string) {
+	t.Rows = append(t.Rows, cells)
+}
+
+// TerminalWidth is the width tables are fitted to: the --width setting,
+// else the terminal's width, else 0 (unlimited) when stdout is not a
+// terminal.
+func TerminalWidth() int {
+	if outputWidth > 0 {
+		return outputWidth
+	}
+	if w, _, err := term.GetSize(stdoutFd); err == nil && w > 0 {
+		return w
+	}
+	return 0
+}
+
+// Print writes the table to stdout, fitted to TerminalWidth.
+func (t *Table) Print() {
+	fmt.Print(t.Render(TerminalWidth()))
+}
+
+// Render lays the table out in at most width columns, or unlimited when
+// width is 0. Rows are indented two spaces, like the rest of the output.
+func (t *Table) Render(width int) string {
+	var cols []int
+	for i, c := range t.Columns {
+		if !c.Wide || t.Wide {
+			cols = append(cols, i)
+		}
+	}
+	if len(cols) == 0 {
+		return ""
+	}
+
+	cell := func(row []string, i int) string {
+		if i >= len(row) {
+			return ""
+		}
+		if asciiMode {
+			return ToASCII(row[i])
+		}
+		return row[i]
+	}
+
+	widths := make([]int, len(cols))
+	for j, i := range cols {
+		widths[j] = lipgloss.Width(t.Columns[i].Title)
+		for _, row := range t.Rows {
+			widths[j] = max(widths[j], lipgloss.Width(cell(row, i)))
+		}
+		if m := t.Columns[i].Max; m > 0 && widths[j] > m {
+			widths[j] = max(m, minColumnWidth)
+		}
+	}
+	if width > 0 {
+		keep := make([]bool, len(cols))
+		for j, i := range cols {
+			keep[j] = t.Columns[i].Keep
+		}
+		fitColumns(widths, keep, width-t.overhead(len(cols)))
+	}
+
+	var b strings.Builder
+	rule := func(left, mid, right string) {
+		if !t.Border {
+			return
+		}
+		parts := make([]string, len(widths))
+		for j, w := range widths {
+			parts[j] = strings.Repeat("─", w+2)
+		}
+		b.WriteString("  " + Dim + left + strings.Join(parts, mid) + right + Reset + "\n")
+	}
+	line := func(cells []string, header bool) {
+		parts := make([]string, len(cols))
+		for j, i := range cols {
+			last := j == len(cols)-1 && !t.Border
+			parts[j] = padCell(truncateCell(cells[i], widths[j]), widths[j], t.Columns[i].Align, last)
+			if header {
+				parts[j] = Bold + parts[j] + Reset
+			}
+		}
+		if t.Border {
+			sep := Dim + "│" + Reset
+			b.WriteString("  " + sep + " " + strings.Join(parts, " "+sep+" ") + " " + sep + "\n")
+			return
+		}
+		b.WriteString(strings.TrimRight("  "+strings.Join(parts, "  "), " ") + "\n")
+	}
+
+	titles := make([]string, len(t.Columns))
+	for i, c := range t.Columns {
+		titles[i] = c.Title
+	}
+	rule("┌", "┬", "┐")
+	line(titles, true)
+	rule("├", "┼", "┤")
+	for _, row := range t.Rows {
+		cells := make([]string, len(t.Columns))
+		for i := range t.Columns {
+			cells[i] = cell(row, i)
+		}
+		line(cells, false)
+	}
+	rule("└", "┴", "┘")
+	return b.String()
+}
+
+// overhead is the width taken by the indent and the space between n
+// columns.
+func (t *Table) overhead(n int) int {
+	if t.Border {
+		return 2 + 3*n + 1
+	}
+	return 2 + 2*(n-1)
+}
+
+// fitColumns shrinks the widest columns not marked keep, one cell at a
+// time, until they fit in avail or none can shrink further.
+func fitColumns(widths []int, keep []bool, avail int) {
+	total := 0
+	for _, w := range widths {
+		total += w
+	}
+	for total > avail {
+		widest := -1
+		for j, w := range widths {
+			if !keep[j] && w > minColumnWidth && (widest < 0 || w > widths[widest]) {
+				widest = j
+			}
+		}
+		if widest < 0 {
+			return
+		}
+		widths[widest]--
+		total--
+	}
+}
+
+// truncateCell cuts s to at most width visible columns, marking the cut
+// with an ellipsis. Escape sequences are kept and colors are reset after
+// a cut.
+func truncateCell(s string, width int) string {
+	if lipgloss.Width(s) <= width {
+		return s
+	}
+	ellipsis := "…"
+	if asciiMode {
+		ellipsis = "~"
+	}
+	var b strings.Builder
+	col, esc, styled := 0, false, false
+	for _, r := range s {
+		switch {
+		case esc:
+			b.WriteRune(r)
+			if r >= 0x40 && r <= 0x7e && r != '[' {
+				esc = false
+			}
+			continue
+		case r == 0x1b:
+			b.WriteRune(r)
+			esc, styled = true, true
+			continue
+		}
+		w := lipgloss.Width(string(r))
+		if col+w > width-1 {
+			break
+		}
+		b.WriteRune(r)
+		col += w
+	}
+	b.WriteString(ellipsis)
+	if styled {
+		b.WriteString(Reset)
+	}
+	return b.String()
+}
+
+// padCell pads s to width visible columns. The last column of a borderless
+// table is not padded on the right, so lines carry no trailing blanks.
+func padCell(s string, width int, align Align, last bool) string {
+	gap := width - lipgloss.Width(s)
+	if gap <= 0 {
+		return s
+	}
+	if align == AlignRight {
+		return strings.Repeat(" ", gap) + s
+	}
+	if last {
+		return s
+	}
+	return s + strings.Repeat(" ", gap)
+}
@@ -0,0 +1,93 @@
+package display
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTableRender(t *testing.T) {
+	plain := func(s string) string {
+		for _, code := range []string{Bold, Dim, Reset, Green} {
+			s = strings.ReplaceAll(s, code, "")
+		}
+		return s
+	}
+	table := func() *Table {
+		tb := NewTable(
+			Column{Title: "NAME"},
+			Column{Title: "COUNT", Align: AlignRight},
+			Column{Title: "NOTE", Wide: true},
+			Column{Title: "ID", Keep: true},
+		)
+		tb.Row("alpha", "3", "first", "uuid-0001")
+		tb.Row(Green+"a much longer name"+Reset, "12", "", "uuid-0002")
+		return tb
+	}
+
+	tests := []struct {
+		name   string
+		width  int
+		wide   bool
+		border bool
+		want   string
+	}{
+		{
+			name: "natural width",
+			want: "  NAME                COUNT  ID\n" +
+				"  alpha                   3  uuid-0001\n" +
+				"  a much longer name     12  uuid-0002\n",
+		},
+		{
+			name: "wide columns",
+			wide: true,
+			want: "  NAME                COUNT  NOTE   ID\n" +
+				"  alpha                   3  first  uuid-0001\n" +
+				"  a much longer name     12         uuid-0002\n",
+		},
+		{
+			name:  "shrinks the widest column, keeping IDs",
+			width: 29,
+			want: "  NAME       COUNT  ID\n" +
+				"  alpha          3  uuid-0001\n" +
+				"  a much l…     12  uuid-0002\n",
+		},
+		{
+			name:   "border",
+			border: true,
+			want: "  ┌────────────────────┬───────┬───────────┐\n" +
+				"  │ NAME               │ COUNT │ ID        │\n" +
+				"  ├────────────────────┼───────┼───────────┤\n" +
+				"  │ alpha              │     3 │ uuid-0001 │\n" +
+				"  │ a much longer name │    12 │ uuid-0002 │\n" +
+				"  └────────────────────┴───────┴───────────┘\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tb := table()
+			tb.Wide, tb.Border = tt.wide, tt.border
+			if got := plain(tb.Render(tt.width)); got != tt.want {
+				t.Errorf("Render() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTruncateCell(t *testing.T) {
+	tests := []struct {
+		in    string
+		width int
+		want  string
+	}{
+		{"short", 10, "short"},
+		{"exactly10!", 10, "exactly10!"},
+		{"too long by far", 8, "too lon…"},
+		{"\x1b[32mgreen text\x1b[0m", 6, "\x1b[32mgreen…" + Reset},
+		{"日本語テキスト", 5, "日本…"},
+	}
+	for _, tt := range tests {
+		if got := truncateCell(tt.in, tt.width); got != tt.want {
+			t.Errorf("truncateCell(%q, %d) = %q, want %q", tt.in, tt.width, got, tt.want)
+		}
+	}
+}
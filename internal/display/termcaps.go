@@ -0,0 +1,310 @@
+package display
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// ─── Terminal capabilities ──────────────────────────────────────────────────
+//
+// Not every terminal shows emoji or 256 colors: legacy Windows consoles on
+// jump boxes print escape sequences as mojibake, the Linux console has no
+// emoji glyphs and a C locale garbles UTF-8. DetectTerm looks at TERM,
+// COLORTERM, the locale and the Windows console at startup so main can
+// fall back to ASCII icons and fewer colors. HAWKEYE_ASCII and
+// HAWKEYE_COLOR override what is detected.
+
+// ColorLevel is how many colors the terminal can show.
+type ColorLevel int
+
+const (
+	ColorNone ColorLevel = iota // no escape sequences at all
+	Color16
+	Color256
+	ColorTrue
+)
+
+func (l ColorLevel) String() string {
+	switch l {
+	case ColorNone:
+		return "none"
+	case Color16:
+		return "16"
+	case Color256:
+		return "256"
+	}
+	return "truecolor"
+}
+
+// ParseColorLevel parses a HAWKEYE_COLOR value.
+func ParseColorLevel(s string) (ColorLevel, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "none", "0", "off", "false":
+		return ColorNone, nil
+	case "16", "ansi", "basic":
+		return Color16, nil
+	case "256", "ansi256":
+		return Color256, nil
+	case "truecolor", "24bit", "true", "full":
+		return ColorTrue, nil
+	}
+	return ColorTrue, fmt.Errorf("invalid HAWKEYE_COLOR %q (use none, 16, 256 or truecolor)", s)
+}
+
+// TermCaps is what was detected about the terminal, with the reason for
+// each decision.
+type TermCaps struct {
+	Term          string     `json:"term"`
+	ColorTerm     string     `json:"colorterm,omitempty"`
+	Locale        string     `json:"locale,omitempty"`
+	OS            string     `json:"os"`
+	Terminal      bool       `json:"terminal"`
+	VT            bool       `json:"vt"` // the console interprets escape sequences
+	Unicode       bool       `json:"unicode"`
+	UnicodeReason string     `json:"unicode_reason"`
+	Colors        ColorLevel `json:"-"`
+	ColorName     string     `json:"colors"`
+	ColorsReason  string     `json:"colors_reason"`
+}
+
+// DetectTerm inspects the current process's terminal, first switching a
+// Windows console to UTF-8 and escape sequences where it can. It must run
+// before FilterStdio replaces os.Stdout.
+func DetectTerm() TermCaps {
+	vt := enableConsole()
+	return DetectTermCaps(os.Getenv, runtime.GOOS, isTerminal(os.Stdout), vt)
+}
+
+// DetectTermCaps decides unicode and color support from the environment.
+// vt reports whether the console interprets escape sequences; it only
+// matters for the Windows console host.
+func DetectTermCaps(getenv func(string) string, goos string, terminal, vt bool) TermCaps {
+	c := TermCaps{
+		Term:      getenv("TERM"),
+		ColorTerm: getenv("COLORTERM"),
+		OS:        goos,
+		Terminal:  terminal,
+		VT:        vt,
+	}
+	for _, k := range []string{"LC_ALL", "LC_CTYPE", "LANG"} {
+		if v := getenv(k); v != "" {
+			c.Locale = v
+			break
+		}
+	}
+	c.Unicode, c.UnicodeReason = detectUnicode(c, getenv)
+	c.Colors, c.ColorsReason = detectColors(c, getenv)
+	c.ColorName = c.Colors.String()
+	return c
+}
+
+// modernWindowsTerminal names the Windows terminal that handles escape
+// sequences and UTF-8, or "" for the legacy console host.
+func modernWindowsTerminal(getenv func(string) string) string {
+	switch {
+	case getenv("WT_SESSION") != "":
+		return "Windows Terminal"
+	case getenv("TERM_PROGRAM") != "":
+		return getenv("TERM_PROGRAM")
+	case strings.EqualFold(getenv("ConEmuANSI"), "ON"):
+		return "ConEmu"
+	case getenv("TERM") != "":
+		return "TERM=" + getenv("TERM") // mintty, MSYS2, Cygwin
+	}
+	return ""
+}
+
+func detectUnicode(c TermCaps, getenv func(string) string) (bool, string) {
+	if v := getenv("HAWKEYE_ASCII"); v != "" {
+		switch strings.ToLower(strings.TrimSpace(v)) {
+		case "0", "false", "no", "off":
+			return true, "HAWKEYE_ASCII=" + v
+		}
+		return false, "HAWKEYE_ASCII=" + v
+	}
+	if c.OS == "windows" {
+		if t := modernWindowsTerminal(getenv); t != "" {
+			return true, t
+		}
+		return false, "Windows console host has no emoji glyphs"
+	}
+	switch c.Term {
+	case "dumb":
+		return false, "TERM=dumb"
+	case "linux":
+		return false, "Linux console has no emoji glyphs"
+	}
+	if c.Locale != "" {
+		l := strings.ToLower(c.Locale)
+		if !strings.Contains(l, "utf-8") && !strings.Contains(l, "utf8") {
+			return false, fmt.Sprintf("locale %s is not UTF-8", c.Locale)
+		}
+		return true, "UTF-8 locale"
+	}
+	return true, "no locale set; assuming UTF-8"
+}
+
+func detectColors(c TermCaps, getenv func(string) string) (ColorLevel, string) {
+	if v := getenv("HAWKEYE_COLOR"); v != "" {
+		if l, err := ParseColorLevel(v); err == nil {
+			return l, "HAWKEYE_COLOR=" + v
+		}
+	}
+	if c.Term == "dumb" {
+		return ColorNone, "TERM=dumb"
+	}
+	if c.OS == "windows" && c.Term == "" {
+		if t := modernWindowsTerminal(getenv); t != "" {
+			return ColorTrue, t
+		}
+		if c.VT {
+			return Color256, "Windows console with virtual terminal processing"
+		}
+		if getenv("ANSICON") != "" {
+			return Color16, "ANSICON"
+		}
+		return ColorNone, "legacy Windows console shows escape sequences as text"
+	}
+	switch strings.ToLower(c.ColorTerm) {
+	case "truecolor", "24bit":
+		return ColorTrue, "COLORTERM=" + c.ColorTerm
+	}
+	switch getenv("TERM_PROGRAM") {
+	case "iTerm.app", "WezTerm", "vscode", "ghostty":
+		return ColorTrue, "TERM_PROGRAM=" + getenv("TERM_PROGRAM")
+	}
+	t := c.Term
+	switch {
+	case strings.Contains(t, "256color"), strings.Contains(t, "kitty"), strings.Contains(t, "alacritty"):
+		return Color256, "TERM=" + t
+	case strings.HasPrefix(t, "xterm"), strings.HasPrefix(t, "tmux"), strings.HasPrefix(t, "screen"):
+		return Color256, "TERM=" + t
+	case t == "" && !c.Terminal:
+		return Color256, "not a terminal"
+	case t == "":
+		return Color16, "TERM not set"
+	}
+	return Color16, "TERM=" + t
+}
+
+// colorLevel limits the colors ANSIColor emits.
+var colorLevel = ColorTrue
+
+// SetColorLevel limits colors to what the terminal supports and re-applies
+// the palette. ColorNone also makes FilterStdio strip every escape sequence.
+func SetColorLevel(l ColorLevel) {
+	colorLevel = l
+	applyPalette(palette)
+}
+
+// CurrentColorLevel returns the color level in effect.
+func CurrentColorLevel() ColorLevel { return colorLevel }
+
+// degradeColor rewrites an ANSI-256 index or hex color as the escape
+// sequence for the current color level.
+func degradeColor(c string) string {
+	if colorLevel == Color256 && !strings.HasPrefix(c, "#") {
+		return "\033[38;5;" + c + "m"
+	}
+	r, g, b, ok := colorRGB(c)
+	if !ok {
+		return ""
+	}
+	switch colorLevel {
+	case ColorNone:
+		return ""
+	case Color16:
+		return "\033[" + strconv.Itoa(nearest16(r, g, b)) + "m"
+	}
+	return "\033[38;5;" + strconv.Itoa(nearest256(r, g, b)) + "m"
+}
+
+// colorRGB resolves a palette color to RGB.
+func colorRGB(c string) (r, g, b int, ok bool) {
+	if strings.HasPrefix(c, "#") {
+		hex := c[1:]
+		if len(hex) == 3 {
+			hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+		}
+		v, err := strconv.ParseUint(hex, 16, 32)
+		if err != nil {
+			return 0, 0, 0, false
+		}
+		return int(v >> 16), int(v >> 8 & 0xff), int(v & 0xff), true
+	}
+	n, err := strconv.Atoi(c)
+	if err != nil || n < 0 || n > 255 {
+		return 0, 0, 0, false
+	}
+	switch {
+	case n < 16:
+		p := ansi16[n]
+		return p[0], p[1], p[2], true
+	case n < 232:
+		n -= 16
+		return cubeLevel(n / 36), cubeLevel(n / 6 % 6), cubeLevel(n % 6), true
+	}
+	v := 8 + (n-232)*10
+	return v, v, v, true
+}
+
+// ansi16 are the xterm defaults for the basic colors.
+var ansi16 = [16][3]int{
+	{0, 0, 0}, {205, 0, 0}, {0, 205, 0}, {205, 205, 0},
+	{0, 0, 238}, {205, 0, 205}, {0, 205, 205}, {229, 229, 229},
+	{127, 127, 127}, {255, 0, 0}, {0, 255, 0}, {255, 255, 0},
+	{92, 92, 255}, {255, 0, 255}, {0, 255, 255}, {255, 255, 255},
+}
+
+func cubeLevel(i int) int {
+	if i == 0 {
+		return 0
+	}
+	return 55 + i*40
+}
+
+func distance(r1, g1, b1, r2, g2, b2 int) int {
+	dr, dg, db := r1-r2, g1-g2, b1-b2
+	return dr*dr + dg*dg + db*db
+}
+
+// nearest16 returns the SGR foreground code (30-37, 90-97) closest to rgb.
+func nearest16(r, g, b int) int {
+	best, bestDist := 0, -1
+	for i, p := range ansi16 {
+		if d := distance(r, g, b, p[0], p[1], p[2]); bestDist < 0 || d < bestDist {
+			best, bestDist = i, d
+		}
+	}
+	if best < 8 {
+		return 30 + best
+	}
+	return 90 + best - 8
+}
+
+// nearest256 returns the color cube or gray ramp index closest to rgb.
+func nearest256(r, g, b int) int {
+	idx := func(v int) int {
+		if v < 48 {
+			return 0
+		}
+		if v < 115 {
+			return 1
+		}
+		return (v - 35) / 40
+	}
+	ri, gi, bi := idx(r), idx(g), idx(b)
+	cube := 16 + 36*ri + 6*gi + bi
+	cubeDist := distance(r, g, b, cubeLevel(ri), cubeLevel(gi), cubeLevel(bi))
+
+	avg := (r + g + b) / 3
+	grayIdx := min(max((avg-8+5)/10, 0), 23)
+	gv := 8 + grayIdx*10
+	if distance(r, g, b, gv, gv, gv) < cubeDist {
+		return 232 + grayIdx
+	}
+	return cube
+}
@@ -0,0 +1,83 @@
+package display
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestDetectTermCaps(t *testing.T) {
+	tests := []struct {
+		name        string
+		env         map[string]string
+		goos        string
+		terminal    bool
+		vt          bool
+		wantUnicode bool
+		wantColors  ColorLevel
+	}{
+		{name: "modern xterm", env: map[string]string{"TERM": "xterm-256color", "COLORTERM": "truecolor", "LANG": "en_US.UTF-8"}, goos: "linux", terminal: true, wantUnicode: true, wantColors: ColorTrue},
+		{name: "256 colors", env: map[string]string{"TERM": "screen-256color", "LANG": "en_US.UTF-8"}, goos: "linux", terminal: true, wantUnicode: true, wantColors: Color256},
+		{name: "C locale", env: map[string]string{"TERM": "xterm", "LANG": "en_US.UTF-8", "LC_ALL": "C"}, goos: "linux", terminal: true, wantUnicode: false, wantColors: Color256},
+		{name: "linux console", env: map[string]string{"TERM": "linux", "LANG": "en_US.UTF-8"}, goos: "linux", terminal: true, wantUnicode: false, wantColors: Color16},
+		{name: "dumb", env: map[string]string{"TERM": "dumb"}, goos: "linux", terminal: true, wantUnicode: false, wantColors: ColorNone},
+		{name: "piped without TERM", env: map[string]string{}, goos: "linux", wantUnicode: true, wantColors: Color256},
+		{name: "legacy windows console", env: map[string]string{}, goos: "windows", terminal: true, wantUnicode: false, wantColors: ColorNone},
+		{name: "windows console host with VT", env: map[string]string{}, goos: "windows", terminal: true, vt: true, wantUnicode: false, wantColors: Color256},
+		{name: "windows terminal", env: map[string]string{"WT_SESSION": "abc"}, goos: "windows", terminal: true, wantUnicode: true, wantColors: ColorTrue},
+		{name: "windows ansicon", env: map[string]string{"ANSICON": "120x50"}, goos: "windows", terminal: true, wantUnicode: false, wantColors: Color16},
+		{name: "mintty", env: map[string]string{"TERM": "xterm-256color"}, goos: "windows", terminal: true, wantUnicode: true, wantColors: Color256},
+		{name: "overrides", env: map[string]string{"HAWKEYE_ASCII": "0", "HAWKEYE_COLOR": "16"}, goos: "windows", terminal: true, wantUnicode: true, wantColors: Color16},
+		{name: "forced ascii", env: map[string]string{"TERM": "xterm-256color", "HAWKEYE_ASCII": "1"}, goos: "darwin", terminal: true, wantUnicode: false, wantColors: Color256},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := DetectTermCaps(func(k string) string { return tt.env[k] }, tt.goos, tt.terminal, tt.vt)
+			if got.Unicode != tt.wantUnicode || got.Colors != tt.wantColors {
+				t.Errorf("unicode=%v colors=%s (%s; %s), want unicode=%v colors=%s",
+					got.Unicode, got.Colors, got.UnicodeReason, got.ColorsReason, tt.wantUnicode, tt.wantColors)
+			}
+			if got.UnicodeReason == "" || got.ColorsReason == "" {
+				t.Errorf("missing reasons: %+v", got)
+			}
+		})
+	}
+}
+
+func TestANSIColorLevels(t *testing.T) {
+	defer SetColorLevel(ColorTrue)
+
+	tests := []struct {
+		level ColorLevel
+		color string
+		want  string
+	}{
+		{ColorTrue, "#ff0000", "\033[38;2;255;0;0m"},
+		{ColorTrue, "160", "\033[38;5;160m"},
+		{Color256, "#ff0000", "\033[38;5;196m"},
+		{Color256, "#808080", "\033[38;5;244m"},
+		{Color256, "33", "\033[38;5;33m"},
+		{Color16, "#ff0000", "\033[91m"},
+		{Color16, "160", "\033[31m"},
+		{Color16, "#2080ff", "\033[94m"},
+		{ColorNone, "#ff0000", ""},
+		{ColorNone, "", ""},
+	}
+	for _, tt := range tests {
+		SetColorLevel(tt.level)
+		if got := ANSIColor(tt.color); got != tt.want {
+			t.Errorf("ANSIColor(%q) at %s = %q, want %q", tt.color, tt.level, got, tt.want)
+		}
+	}
+}
+
+func TestWriterStripsEscapes(t *testing.T) {
+	var b bytes.Buffer
+	w := NewWriter(&b, false, 0)
+	w.strip = true
+	w.Write([]byte("\033[1m✓ done\033[0m \033[38;5;"))
+	w.Write([]byte("160mred\033[0m\n"))
+	w.Flush()
+	if got, want := b.String(), "✓ done red\n"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,255 @@
+package display
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ─── Themes ─────────────────────────────────────────────────────────────────
+//
+// A Palette is the single source of colors for the CLI (the ANSI variables
+// in this package) and the TUI (its lipgloss styles and markdown renderer).
+// Colors are ANSI-256 indexes ("39") or hex ("#F28C28"); an empty color
+// means the terminal default.
+
+// Palette assigns a color to each role used in output.
+type Palette struct {
+	Base    string `json:"base,omitempty"` // built-in theme the palette derives from
+	Accent  string `json:"accent,omitempty"`
+	Success string `json:"success,omitempty"`
+	Warning string `json:"warning,omitempty"`
+	Error   string `json:"error,omitempty"`
+	Info    string `json:"info,omitempty"`
+	Link    string `json:"link,omitempty"`
+	Teal    string `json:"teal,omitempty"`
+	Magenta string `json:"magenta,omitempty"`
+	Text    string `json:"text,omitempty"`
+	Body    string `json:"body,omitempty"`
+	Muted   string `json:"muted,omitempty"`
+	Subtle  string `json:"subtle,omitempty"`
+}
+
+var darkPalette = Palette{
+	Base:    "dark",
+	Accent:  "#F28C28",
+	Success: "78",
+	Warning: "220",
+	Error:   "196",
+	Info:    "39",
+	Link:    "111",
+	Teal:    "73",
+	Magenta: "170",
+	Text:    "255",
+	Body:    "252",
+	Muted:   "250",
+	Subtle:  "240",
+}
+
+var lightPalette = Palette{
+	Base:    "light",
+	Accent:  "#D2691E",
+	Success: "28",
+	Warning: "136",
+	Error:   "160",
+	Info:    "25",
+	Link:    "26",
+	Teal:    "30",
+	Magenta: "127",
+	Text:    "232",
+	Body:    "239",
+	Muted:   "240",
+	Subtle:  "245",
+}
+
+// monoPalette has no colors; bold, dim and reverse still apply.
+var monoPalette = Palette{Base: "mono"}
+
+// ThemeNames lists the built-in themes accepted by SetTheme.
+var ThemeNames = []string{"auto", "dark", "light", "mono"}
+
+var (
+	palette = darkPalette
+
+	// hasDarkBackground is swapped out in tests.
+	hasDarkBackground = lipgloss.HasDarkBackground
+)
+
+func init() {
+	applyPalette(darkPalette)
+}
+
+// CurrentPalette returns the palette in effect.
+func CurrentPalette() Palette { return palette }
+
+// NoColor reports whether the NO_COLOR convention (https://no-color.org)
+// asks for uncolored output.
+func NoColor() bool {
+	return os.Getenv("NO_COLOR") != ""
+}
+
+// SetTheme loads and applies a theme: "auto" (or "") picks dark or light
+// from the terminal background, a built-in name selects that palette and a
+// path to a .json file loads a custom one. NO_COLOR overrides any theme.
+func SetTheme(name string) error {
+	if NoColor() {
+		applyPalette(monoPalette)
+		return nil
+	}
+	p, err := LoadTheme(name)
+	if err != nil {
+		return err
+	}
+	applyPalette(p)
+	return nil
+}
+
+// LoadTheme resolves a theme name or custom palette file without applying it.
+func LoadTheme(name string) (Palette, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "", "auto":
+		if hasDarkBackground() {
+			return darkPalette, nil
+		}
+		return lightPalette, nil
+	case "dark":
+		return darkPalette, nil
+	case "light":
+		return lightPalette, nil
+	case "mono", "none":
+		return monoPalette, nil
+	}
+	if !strings.HasSuffix(strings.ToLower(name), ".json") {
+		return Palette{}, fmt.Errorf("unknown theme %q (valid: %s, or a .json palette file)", name, strings.Join(ThemeNames, ", "))
+	}
+	return loadPaletteFile(name)
+}
+
+// loadPaletteFile reads a custom palette. Roles it leaves out are taken from
+// its "base" theme (default dark).
+func loadPaletteFile(path string) (Palette, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Palette{}, fmt.Errorf("reading theme: %w", err)
+	}
+	var custom Palette
+	if err := json.Unmarshal(data, &custom); err != nil {
+		return Palette{}, fmt.Errorf("parsing theme %s: %w", path, err)
+	}
+
+	var p Palette
+	switch strings.ToLower(custom.Base) {
+	case "", "dark":
+		p = darkPalette
+	case "light":
+		p = lightPalette
+	case "mono":
+		p = monoPalette
+	default:
+		return Palette{}, fmt.Errorf("theme %s: unknown base %q", path, custom.Base)
+	}
+
+	fields := []struct {
+		name      string
+		src, into *string
+	}{
+		{"accent", &custom.Accent, &p.Accent},
+		{"success", &custom.Success, &p.Success},
+		{"warning", &custom.Warning, &p.Warning},
+		{"error", &custom.Error, &p.Error},
+		{"info", &custom.Info, &p.Info},
+		{"link", &custom.Link, &p.Link},
+		{"teal", &custom.Teal, &p.Teal},
+		{"magenta", &custom.Magenta, &p.Magenta},
+		{"text", &custom.Text, &p.Text},
+		{"body", &custom.Body, &p.Body},
+		{"muted", &custom.Muted, &p.Muted},
+		{"subtle", &custom.Subtle, &p.Subtle},
+	}
+	for _, f := range fields {
+		if *f.src == "" {
+			continue
+		}
+		if !validColor(*f.src) {
+			return Palette{}, fmt.Errorf("theme %s: invalid %s color %q (use 0-255 or #RRGGBB)", path, f.name, *f.src)
+		}
+		*f.into = *f.src
+	}
+	return p, nil
+}
+
+func validColor(c string) bool {
+	if strings.HasPrefix(c, "#") {
+		if len(c) != 4 && len(c) != 7 {
+			return false
+		}
+		_, err := strconv.ParseUint(c[1:], 16, 32)
+		return err == nil
+	}
+	n, err := strconv.Atoi(c)
+	return err == nil && n >= 0 && n <= 255
+}
+
+// ANSIColor returns the foreground escape sequence for a palette color, or
+// "" for the terminal default. Colors are degraded to the current color
+// level.
+func ANSIColor(c string) string {
+	if c == "" {
+		return ""
+	}
+	if colorLevel < ColorTrue {
+		return degradeColor(c)
+	}
+	if strings.HasPrefix(c, "#") {
+		hex := c[1:]
+		if len(hex) == 3 {
+			hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+		}
+		v, err := strconv.ParseUint(hex, 16, 32)
+		if err != nil {
+			return ""
+		}
+		return fmt.Sprintf("\033[38;2;%d;%d;%dm", v>>16, v>>8&0xff, v&0xff)
+	}
+	return "\033[38;5;" + c + "m"
+}
+
+// stdoutTerminal is decided once at startup, before FilterStdio can swap
+// os.Stdout for a pipe.
+var stdoutTerminal = isTerminal(os.Stdout)
+
+// GlamourStyle names the glamour markdown style that suits the palette.
+// Output that is not a terminal, or has colors turned off, gets glamour's
+// plain "notty" style so piped markdown stays free of escape sequences.
+func (p Palette) GlamourStyle() string {
+	return p.glamourStyle(stdoutTerminal && colorLevel != ColorNone)
+}
+
+func (p Palette) glamourStyle(styled bool) string {
+	if !styled {
+		return "notty"
+	}
+	switch p.Base {
+	case "light":
+		return "light"
+	case "mono":
+		return "notty"
+	}
+	return "dark"
+}
+
+func applyPalette(p Palette) {
+	palette = p
+	Red = ANSIColor(p.Error)
+	Green = ANSIColor(p.Success)
+	Yellow = ANSIColor(p.Warning)
+	Blue = ANSIColor(p.Link)
+	Magenta = ANSIColor(p.Magenta)
+	Cyan = ANSIColor(p.Info)
+	White = ANSIColor(p.Text)
+	Gray = ANSIColor(p.Muted)
+}
@@ -0,0 +1,121 @@
+package display
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadTheme(t *testing.T) {
+	orig := hasDarkBackground
+	defer func() { hasDarkBackground = orig }()
+	hasDarkBackground = func() bool { return false }
+
+	dir := t.TempDir()
+	write := func(name, body string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(body), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	custom := write("custom.json", `{"base":"light","accent":"#112233","info":"33"}`)
+	badColor := write("bad.json", `{"error":"crimson"}`)
+	badBase := write("base.json", `{"base":"sepia"}`)
+
+	tests := []struct {
+		name     string
+		wantBase string
+		check    func(Palette) bool
+		wantErr  string
+	}{
+		{name: "", wantBase: "light"},
+		{name: "auto", wantBase: "light"},
+		{name: "Dark", wantBase: "dark"},
+		{name: "mono", wantBase: "mono", check: func(p Palette) bool { return p.Error == "" && p.Text == "" }},
+		{name: custom, wantBase: "light", check: func(p Palette) bool {
+			return p.Accent == "#112233" && p.Info == "33" && p.Error == lightPalette.Error
+		}},
+		{name: badColor, wantErr: "invalid error color"},
+		{name: badBase, wantErr: "unknown base"},
+		{name: "solarized", wantErr: "unknown theme"},
+		{name: filepath.Join(dir, "missing.json"), wantErr: "reading theme"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := LoadTheme(tt.name)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("err = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("LoadTheme() error = %v", err)
+			}
+			if p.Base != tt.wantBase {
+				t.Errorf("Base = %q, want %q", p.Base, tt.wantBase)
+			}
+			if tt.check != nil && !tt.check(p) {
+				t.Errorf("palette = %+v", p)
+			}
+		})
+	}
+}
+
+func TestSetTheme(t *testing.T) {
+	defer applyPalette(darkPalette)
+
+	t.Setenv("NO_COLOR", "")
+	if err := SetTheme("light"); err != nil {
+		t.Fatal(err)
+	}
+	if Red != "\033[38;5;160m" || Gray != "\033[38;5;240m" {
+		t.Errorf("light: Red=%q Gray=%q", Red, Gray)
+	}
+
+	t.Setenv("NO_COLOR", "1")
+	if err := SetTheme("dark"); err != nil {
+		t.Fatal(err)
+	}
+	if Red != "" || Cyan != "" || CurrentPalette().Base != "mono" {
+		t.Errorf("NO_COLOR: Red=%q Cyan=%q base=%q", Red, Cyan, CurrentPalette().Base)
+	}
+}
+
+func TestANSIColor(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"", ""},
+		{"39", "\033[38;5;39m"},
+		{"#F28C28", "\033[38;2;242;140;40m"},
+		{"#fff", "\033[38;2;255;255;255m"},
+	}
+	for _, tt := range tests {
+		if got := ANSIColor(tt.in); got != tt.want {
+			t.Errorf("ANSIColor(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestGlamourStyle(t *testing.T) {
+	tests := []struct {
+		base   string
+		styled bool
+		want   string
+	}{
+		{"dark", true, "dark"},
+		{"light", true, "light"},
+		{"mono", true, "notty"},
+		{"dark", false, "notty"},
+		{"light", false, "notty"},
+	}
+	for _, tt := range tests {
+		if got := (Palette{Base: tt.base}).glamourStyle(tt.styled); got != tt.want {
+			t.Errorf("glamourStyle(%s, %v) = %q, want %q", tt.base, tt.styled, got, tt.want)
+		}
+	}
+}
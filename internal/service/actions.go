@@ -0,0 +1,91 @@
+package service
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ActionItem is one action item from a session summary, with its local
+// completion state.
+type ActionItem struct {
+	Key         string    `json:"key"`
+	SessionUUID string    `json:"session_uuid"`
+	SessionName string    `json:"session_name,omitempty"`
+	Text        string    `json:"text"`
+	Done        bool      `json:"done"`
+	DoneAt      time.Time `json:"done_at,omitempty"`
+}
+
+// ActionKey identifies an action item by its session and text, so its
+// completion state survives re-listing in a different order.
+func ActionKey(sessionUUID, text string) string {
+	sum := sha256.Sum256([]byte(strings.TrimSpace(text)))
+	return sessionUUID + ":" + hex.EncodeToString(sum[:6])
+}
+
+// SessionActionItems turns a session's summary action items into
+// ActionItems, dropping blank and repeated ones.
+func SessionActionItems(sessionUUID, sessionName string, items []string) []ActionItem {
+	var out []ActionItem
+	seen := map[string]bool{}
+	for _, text := range items {
+		text = strings.TrimSpace(text)
+		if text == "" {
+			continue
+		}
+		key := ActionKey(sessionUUID, text)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		out = append(out, ActionItem{Key: key, SessionUUID: sessionUUID, SessionName: sessionName, Text: text})
+	}
+	return out
+}
+
+// OpenActionItems returns the items not yet done.
+func OpenActionItems(items []ActionItem) []ActionItem {
+	var open []ActionItem
+	for _, it := range items {
+		if !it.Done {
+			open = append(open, it)
+		}
+	}
+	return open
+}
+
+// FormatActionsMarkdown renders action items as a markdown checklist
+// grouped by session, in the order the sessions first appear.
+func FormatActionsMarkdown(items []ActionItem, consoleURL func(sessionUUID string) string) string {
+	var b strings.Builder
+	b.WriteString("# Action Items\n")
+	if len(items) == 0 {
+		b.WriteString("\nNo action items.\n")
+		return b.String()
+	}
+	current := ""
+	for i, it := range items {
+		if i == 0 || it.SessionUUID != current {
+			current = it.SessionUUID
+			name := it.SessionName
+			if name == "" {
+				name = it.SessionUUID
+			}
+			fmt.Fprintf(&b, "\n## %s\n\n", name)
+			if url := consoleURL(it.SessionUUID); url != "" {
+				fmt.Fprintf(&b, "Session: [%s](%s)\n\n", it.SessionUUID, url)
+			} else {
+				fmt.Fprintf(&b, "Session: `%s`\n\n", it.SessionUUID)
+			}
+		}
+		box := " "
+		if it.Done {
+			box = "x"
+		}
+		fmt.Fprintf(&b, "- [%s] %s\n", box, it.Text)
+	}
+	return b.String()
+}
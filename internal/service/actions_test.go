@@ -0,0 +1,44 @@
+package service
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSessionActionItems(t *testing.T) {
+	items := SessionActionItems("s1", "Checkout latency", []string{"Add an alert", "  ", "Add an alert ", "Roll back"})
+	if len(items) != 2 {
+		t.Fatalf("got %d items, want 2: %+v", len(items), items)
+	}
+	if items[0].Key != ActionKey("s1", "Add an alert") || items[1].Text != "Roll back" {
+		t.Errorf("got %+v", items)
+	}
+	if ActionKey("s1", "Roll back") == ActionKey("s2", "Roll back") {
+		t.Error("keys of different sessions should differ")
+	}
+}
+
+func TestFormatActionsMarkdown(t *testing.T) {
+	items := append(
+		SessionActionItems("s1", "Checkout latency", []string{"Add an alert", "Roll back"}),
+		SessionActionItems("s2", "", []string{"Resize pool"})...,
+	)
+	items[1].Done = true
+	url := func(id string) string {
+		if id == "s1" {
+			return "https://console/s1"
+		}
+		return ""
+	}
+	got := FormatActionsMarkdown(items, url)
+	want := "# Action Items\n\n## Checkout latency\n\nSession: [s1](https://console/s1)\n\n- [ ] Add an alert\n- [x] Roll back\n\n## s2\n\nSession: `s2`\n\n- [ ] Resize pool\n"
+	if got != want {
+		t.Errorf("FormatActionsMarkdown() =\n%s\nwant\n%s", got, want)
+	}
+	if open := OpenActionItems(items); len(open) != 2 {
+		t.Errorf("OpenActionItems() = %d items, want 2", len(open))
+	}
+	if !strings.Contains(FormatActionsMarkdown(nil, url), "No action items") {
+		t.Error("empty export should say so")
+	}
+}
@@ -0,0 +1,114 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// AlertmanagerPayload is a Prometheus Alertmanager webhook notification.
+type AlertmanagerPayload struct {
+	Version           string              `json:"version"`
+	Status            string              `json:"status"`
+	Receiver          string              `json:"receiver"`
+	GroupLabels       map[string]string   `json:"groupLabels"`
+	CommonLabels      map[string]string   `json:"commonLabels"`
+	CommonAnnotations map[string]string   `json:"commonAnnotations"`
+	ExternalURL       string              `json:"externalURL"`
+	Alerts            []AlertmanagerAlert `json:"alerts"`
+}
+
+// AlertmanagerAlert is one alert in an Alertmanager notification.
+type AlertmanagerAlert struct {
+	Status       string            `json:"status"`
+	Labels       map[string]string `json:"labels"`
+	Annotations  map[string]string `json:"annotations"`
+	StartsAt     time.Time         `json:"startsAt"`
+	GeneratorURL string            `json:"generatorURL"`
+	Fingerprint  string            `json:"fingerprint"`
+}
+
+// ParseAlertmanagerPayload decodes an Alertmanager webhook body.
+func ParseAlertmanagerPayload(data []byte) (*AlertmanagerPayload, error) {
+	var p AlertmanagerPayload
+	if err := json.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("invalid Alertmanager payload: %w", err)
+	}
+	if p.Alerts == nil {
+		return nil, fmt.Errorf("invalid Alertmanager payload: no alerts array")
+	}
+	return &p, nil
+}
+
+// Firing returns the alerts that are still firing. Alerts without a status
+// count as firing.
+func (p *AlertmanagerPayload) Firing() []AlertmanagerAlert {
+	var firing []AlertmanagerAlert
+	for _, a := range p.Alerts {
+		if a.Status == "" || a.Status == "firing" {
+			firing = append(firing, a)
+		}
+	}
+	return firing
+}
+
+// Name is the alert's alertname label.
+func (a AlertmanagerAlert) Name() string {
+	if name := a.Labels["alertname"]; name != "" {
+		return name
+	}
+	return "unnamed alert"
+}
+
+// AlertmanagerPrompt builds an investigation prompt from firing alerts:
+// name, severity, instance, summary, description and runbook of each, with
+// the remaining labels for context.
+func AlertmanagerPrompt(alerts []AlertmanagerAlert) string {
+	var b strings.Builder
+	if len(alerts) == 1 {
+		fmt.Fprintf(&b, "Investigate the firing Prometheus alert %s.\n", alerts[0].Name())
+		writeAlertDetails(&b, alerts[0], "")
+		return strings.TrimRight(b.String(), "\n")
+	}
+	fmt.Fprintf(&b, "Investigate these %d firing Prometheus alerts, which were notified together:\n", len(alerts))
+	for i, a := range alerts {
+		fmt.Fprintf(&b, "\n%d. %s\n", i+1, a.Name())
+		writeAlertDetails(&b, a, "   ")
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
+
+// alertDetailLabels are shown on their own line rather than in Labels.
+var alertDetailLabels = map[string]bool{"alertname": true, "severity": true, "instance": true}
+
+func writeAlertDetails(b *strings.Builder, a AlertmanagerAlert, indent string) {
+	line := func(label, value string) {
+		if value != "" {
+			fmt.Fprintf(b, "%s%s: %s\n", indent, label, value)
+		}
+	}
+	line("Severity", a.Labels["severity"])
+	line("Instance", a.Labels["instance"])
+	line("Summary", a.Annotations["summary"])
+	line("Description", a.Annotations["description"])
+	runbook := a.Annotations["runbook_url"]
+	if runbook == "" {
+		runbook = a.Annotations["runbook"]
+	}
+	line("Runbook", runbook)
+	if !a.StartsAt.IsZero() {
+		line("Started", a.StartsAt.UTC().Format(time.RFC3339))
+	}
+
+	var pairs []string
+	for k, v := range a.Labels {
+		if !alertDetailLabels[k] {
+			pairs = append(pairs, k+"="+v)
+		}
+	}
+	sort.Strings(pairs)
+	line("Labels", strings.Join(pairs, ", "))
+	line("Source", a.GeneratorURL)
+}
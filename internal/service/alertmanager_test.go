@@ -0,0 +1,70 @@
+package service
+
+import (
+	"strings"
+	"testing"
+)
+
+const alertmanagerSample = `{
+  "version": "4",
+  "status": "firing",
+  "receiver": "hawkeye",
+  "alerts": [
+    {
+      "status": "firing",
+      "labels": {"alertname": "HighErrorRate", "severity": "critical", "instance": "api-1:9090", "job": "api", "namespace": "prod"},
+      "annotations": {"summary": "5xx above 5%", "description": "Error rate has been high for 10m", "runbook_url": "https://runbooks.example.com/high-error-rate"},
+      "startsAt": "2025-03-04T10:00:00Z",
+      "generatorURL": "http://prometheus:9090/graph?g0.expr=rate"
+    },
+    {
+      "status": "resolved",
+      "labels": {"alertname": "DiskFull"}
+    }
+  ]
+}`
+
+func TestParseAlertmanagerPayload(t *testing.T) {
+	p, err := ParseAlertmanagerPayload([]byte(alertmanagerSample))
+	if err != nil {
+		t.Fatalf("ParseAlertmanagerPayload() error = %v", err)
+	}
+	if len(p.Alerts) != 2 || p.Receiver != "hawkeye" {
+		t.Fatalf("got %+v", p)
+	}
+	firing := p.Firing()
+	if len(firing) != 1 || firing[0].Name() != "HighErrorRate" {
+		t.Fatalf("Firing() = %+v", firing)
+	}
+
+	for _, bad := range []string{`nope`, `{"version":"4"}`} {
+		if _, err := ParseAlertmanagerPayload([]byte(bad)); err == nil {
+			t.Errorf("ParseAlertmanagerPayload(%q) succeeded, want error", bad)
+		}
+	}
+}
+
+func TestAlertmanagerPrompt(t *testing.T) {
+	p, _ := ParseAlertmanagerPayload([]byte(alertmanagerSample))
+
+	got := AlertmanagerPrompt(p.Firing())
+	want := `Investigate the firing Prometheus alert HighErrorRate.
+Severity: critical
+Instance: api-1:9090
+Summary: 5xx above 5%
+Description: Error rate has been high for 10m
+Runbook: https://runbooks.example.com/high-error-rate
+Started: 2025-03-04T10:00:00Z
+Labels: job=api, namespace=prod
+Source: http://prometheus:9090/graph?g0.expr=rate`
+	if got != want {
+		t.Errorf("AlertmanagerPrompt() =\n%s\nwant\n%s", got, want)
+	}
+
+	group := AlertmanagerPrompt(p.Alerts)
+	for _, s := range []string{"these 2 firing Prometheus alerts", "1. HighErrorRate\n   Severity: critical", "2. DiskFull"} {
+		if !strings.Contains(group, s) {
+			t.Errorf("group prompt missing %q:\n%s", s, group)
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package service
+
+import (
+	"bufio"
+	"strings"
+	"time"
+
+	"hawkeye-cli/internal/api"
+)
+
+// BulkResult holds the outcome of one investigation in a bulk run.
+type BulkResult struct {
+	AlertID     string `json:"alert_id"`
+	SessionUUID string `json:"session_uuid,omitempty"`
+	Status      string `json:"status"`
+	Summary     string `json:"summary,omitempty"`
+	Error       string `json:"error,omitempty"`
+}
+
+// Bulk result statuses.
+const (
+	BulkStatusCompleted = "completed"
+	BulkStatusFailed    = "failed"
+)
+
+// ParseAlertList reads one alert ID per line. Blank lines and lines starting
+// with '#' are skipped, surrounding whitespace is trimmed, and duplicates are
+// dropped while preserving the original order.
+func ParseAlertList(content string) []string {
+	var ids []string
+	seen := make(map[string]bool)
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if seen[line] {
+			continue
+		}
+		seen[line] = true
+		ids = append(ids, line)
+	}
+	return ids
+}
+
+// ShortSummary reduces an investigation answer to a single line suitable for
+// a roll-up table: the first non-empty line with markdown markers removed,
+// truncated to max runes.
+func ShortSummary(answer string, max int) string {
+	for _, line := range strings.Split(StripHTML(answer), "\n") {
+		line = strings.TrimSpace(line)
+		line = strings.TrimLeft(line, "#>*-• ")
+		line = strings.ReplaceAll(line, "**", "")
+		line = strings.ReplaceAll(line, "`", "")
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		runes := []rune(line)
+		if max > 3 && len(runes) > max {
+			return string(runes[:max-3]) + "..."
+		}
+		return line
+	}
+	return ""
+}
+
+// CountBulkResults returns how many results completed and failed.
+func CountBulkResults(results []BulkResult) (completed, failed int) {
+	for _, r := range results {
+		if r.Status == BulkStatusCompleted {
+			completed++
+		} else {
+			failed++
+		}
+	}
+	return completed, failed
+}
+
+// RerunResult is the outcome of rerunning one session in a bulk rerun.
+type RerunResult struct {
+	SessionUUID    string `json:"session_uuid"`
+	Name           string `json:"name,omitempty"`
+	NewSessionUUID string `json:"new_session_uuid,omitempty"` // set when the rerun created a new session
+	Status         string `json:"status"`                     // BulkStatusCompleted or BulkStatusFailed
+	Error          string `json:"error,omitempty"`
+}
+
+// SessionsSince keeps the sessions created at or after since, in case the
+// server ignored the create_time filter. Sessions with an unparseable
+// create time are kept; a zero since keeps everything.
+func SessionsSince(sessions []api.SessionInfo, since time.Time) []api.SessionInfo {
+	if since.IsZero() {
+		return sessions
+	}
+	var out []api.SessionInfo
+	for _, s := range sessions {
+		t, err := parseAPITime(s.CreateTime)
+		if err == nil && t.Before(since) {
+			continue
+		}
+		out = append(out, s)
+	}
+	return out
+}
+
+// CountRerunResults returns how many reruns started and failed.
+func CountRerunResults(results []RerunResult) (started, failed int) {
+	for _, r := range results {
+		if r.Status == BulkStatusCompleted {
+			started++
+		} else {
+			failed++
+		}
+	}
+	return started, failed
+}
@@ -0,0 +1,91 @@
+package service
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"hawkeye-cli/internal/api"
+)
+
+func TestParseAlertList(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []string
+	}{
+		{"empty", "", nil},
+		{"single", "alert-1\n", []string{"alert-1"}},
+		{"comments and blanks", "# overnight\n\nalert-1\n  alert-2  \n", []string{"alert-1", "alert-2"}},
+		{"duplicates", "a\nb\na\n", []string{"a", "b"}},
+		{"crlf", "a\r\nb\r\n", []string{"a", "b"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ParseAlertList(tt.content)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseAlertList() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestShortSummary(t *testing.T) {
+	tests := []struct {
+		name   string
+		answer string
+		max    int
+		want   string
+	}{
+		{"empty", "", 40, ""},
+		{"first line", "Root cause found\nMore detail", 40, "Root cause found"},
+		{"markdown heading", "\n## **Root cause**: OOM\n", 40, "Root cause: OOM"},
+		{"html break", "<b>Disk full</b><br/>next", 40, "Disk full"},
+		{"truncated", "abcdefghijklmnop", 10, "abcdefg..."},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ShortSummary(tt.answer, tt.max); got != tt.want {
+				t.Errorf("ShortSummary() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCountBulkResults(t *testing.T) {
+	results := []BulkResult{
+		{Status: BulkStatusCompleted},
+		{Status: BulkStatusFailed},
+		{Status: BulkStatusCompleted},
+	}
+	completed, failed := CountBulkResults(results)
+	if completed != 2 || failed != 1 {
+		t.Errorf("CountBulkResults() = %d, %d, want 2, 1", completed, failed)
+	}
+}
+
+func TestSessionsSince(t *testing.T) {
+	sessions := []api.SessionInfo{
+		{SessionUUID: "old", CreateTime: "2026-03-01T08:00:00Z"},
+		{SessionUUID: "new", CreateTime: "2026-03-02T08:00:00Z"},
+		{SessionUUID: "unknown", CreateTime: ""},
+	}
+	since := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	var got []string
+	for _, s := range SessionsSince(sessions, since) {
+		got = append(got, s.SessionUUID)
+	}
+	if want := []string{"new", "unknown"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("SessionsSince() = %v, want %v", got, want)
+	}
+	if n := len(SessionsSince(sessions, time.Time{})); n != 3 {
+		t.Errorf("SessionsSince(zero) kept %d, want 3", n)
+	}
+}
+
+func TestCountRerunResults(t *testing.T) {
+	started, failed := CountRerunResults([]RerunResult{{Status: BulkStatusCompleted}, {Status: BulkStatusFailed}})
+	if started != 1 || failed != 1 {
+		t.Errorf("CountRerunResults() = %d, %d, want 1, 1", started, failed)
+	}
+}
@@ -0,0 +1,80 @@
+package service
+
+import (
+	"strconv"
+	"strings"
+
+	"hawkeye-cli/internal/api"
+)
+
+// ─── Chat view ──────────────────────────────────────────────────────────────
+//
+// `hawkeye chat` shows a session as the conversation people see: each
+// prompt and the answer it got, without chain of thought or sources.
+// --follow polls the session and prints turns as they land, to read along
+// while a colleague drives it.
+
+// Chat roles.
+const (
+	ChatUser      = "user"
+	ChatAssistant = "assistant"
+)
+
+// ChatMessage is one turn of a session's conversation.
+type ChatMessage struct {
+	Key   string `json:"-"` // identifies the turn across polls
+	Cycle int    `json:"cycle"`
+	Role  string `json:"role"`
+	Text  string `json:"text"`
+	Time  string `json:"time,omitempty"` // when the prompt was sent; answers carry none
+}
+
+// ChatMessages returns the conversation of a session's prompt cycles. A
+// cycle still being answered contributes only its prompt.
+func ChatMessages(cycles []api.PromptCycle) []ChatMessage {
+	var out []ChatMessage
+	for i, pc := range cycles {
+		id := pc.ID
+		if id == "" {
+			id = "cycle-" + strconv.Itoa(i+1)
+		}
+		if prompt := CyclePrompt(pc); prompt != "" {
+			out = append(out, ChatMessage{Key: id + ":" + ChatUser, Cycle: i + 1, Role: ChatUser, Text: prompt, Time: pc.CreateTime})
+		}
+		if cycleAnswered(pc) {
+			out = append(out, ChatMessage{Key: id + ":" + ChatAssistant, Cycle: i + 1, Role: ChatAssistant, Text: strings.TrimSpace(pc.FinalAnswer)})
+		}
+	}
+	return out
+}
+
+// ChatPending reports whether the last prompt is still being answered.
+func ChatPending(cycles []api.PromptCycle) bool {
+	if len(cycles) == 0 {
+		return false
+	}
+	last := cycles[len(cycles)-1]
+	return CyclePrompt(last) != "" && !cycleAnswered(last)
+}
+
+// cycleAnswered reports whether a cycle's answer is final. Answers of
+// cycles still running may be partial and are held back.
+func cycleAnswered(pc api.PromptCycle) bool {
+	if strings.TrimSpace(pc.FinalAnswer) == "" {
+		return false
+	}
+	return !strings.HasSuffix(pc.Status, "IN_PROGRESS") && !strings.HasSuffix(pc.Status, "RUNNING")
+}
+
+// NewChatMessages returns the messages of cur that seen does not hold, and
+// adds them to seen.
+func NewChatMessages(cur []ChatMessage, seen map[string]bool) []ChatMessage {
+	var out []ChatMessage
+	for _, m := range cur {
+		if !seen[m.Key] {
+			seen[m.Key] = true
+			out = append(out, m)
+		}
+	}
+	return out
+}
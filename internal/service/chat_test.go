@@ -0,0 +1,53 @@
+package service
+
+import (
+	"testing"
+
+	"hawkeye-cli/internal/api"
+)
+
+func chatCycle(id, prompt, answer, status string) api.PromptCycle {
+	return api.PromptCycle{
+		ID:          id,
+		CreateTime:  "2026-10-15T09:12:00Z",
+		FinalAnswer: answer,
+		Status:      status,
+		Request:     &api.ProcessPromptRequest{Messages: []api.Message{{Content: &api.Content{Parts: []string{prompt}}}}},
+	}
+}
+
+func TestChatMessages(t *testing.T) {
+	cycles := []api.PromptCycle{
+		chatCycle("pc-1", "Why is checkout slow?", "Pool exhausted.\n", "PROMPT_CYCLE_STATUS_COMPLETED"),
+		chatCycle("", "Which deploy?", "partial answ", "PROMPT_CYCLE_STATUS_IN_PROGRESS"),
+	}
+	msgs := ChatMessages(cycles)
+	if len(msgs) != 3 {
+		t.Fatalf("messages = %+v", msgs)
+	}
+	if m := msgs[0]; m.Role != ChatUser || m.Text != "Why is checkout slow?" || m.Time != "2026-10-15T09:12:00Z" || m.Cycle != 1 {
+		t.Errorf("prompt = %+v", m)
+	}
+	if m := msgs[1]; m.Role != ChatAssistant || m.Text != "Pool exhausted." || m.Time != "" {
+		t.Errorf("answer = %+v", m)
+	}
+	if m := msgs[2]; m.Key != "cycle-2:user" || m.Cycle != 2 {
+		t.Errorf("running cycle's prompt = %+v", m)
+	}
+	if !ChatPending(cycles) {
+		t.Error("the running cycle should be pending")
+	}
+
+	seen := map[string]bool{}
+	if got := NewChatMessages(msgs, seen); len(got) != 3 {
+		t.Errorf("first poll = %d new, want 3", len(got))
+	}
+	cycles[1].Status, cycles[1].FinalAnswer = "PROMPT_CYCLE_STATUS_COMPLETED", "The 09:00 one."
+	got := NewChatMessages(ChatMessages(cycles), seen)
+	if len(got) != 1 || got[0].Role != ChatAssistant || got[0].Text != "The 09:00 one." {
+		t.Errorf("second poll = %+v", got)
+	}
+	if ChatPending(cycles) || ChatPending(nil) {
+		t.Error("nothing should be pending")
+	}
+}
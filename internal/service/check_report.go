@@ -0,0 +1,257 @@
+package service
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// ─── Check reports (JUnit XML, SARIF) ───────────────────────────────────────
+//
+// Commands that run a list of pass/fail checks convert their results to a
+// CheckReport and hand it to the encoder CheckReportWriter picks from the
+// file name: JUnit XML or SARIF. CI dashboards read the former; code-scanning
+// UIs the latter.
+
+// Check statuses.
+const (
+	CheckPassed = "passed"
+	CheckFailed = "failed"
+	CheckError  = "error"
+)
+
+// CheckReport is a named list of check outcomes.
+type CheckReport struct {
+	Name     string
+	Source   string // file the checks came from, e.g. an eval suite
+	Duration time.Duration
+	Checks   []CheckResult
+}
+
+// CheckResult is the outcome of one check.
+type CheckResult struct {
+	ID       string
+	Name     string
+	Status   string // CheckPassed, CheckFailed or CheckError
+	Message  string
+	Details  string
+	Duration time.Duration
+}
+
+// Counts returns how many checks failed and errored.
+func (r CheckReport) Counts() (failed, errored int) {
+	for _, c := range r.Checks {
+		switch c.Status {
+		case CheckFailed:
+			failed++
+		case CheckError:
+			errored++
+		}
+	}
+	return failed, errored
+}
+
+// CheckReportFormat returns the encoder name for a report path: "junit"
+// for .xml and "sarif" for .sarif or .json.
+func CheckReportFormat(path string) (string, error) {
+	lower := strings.ToLower(path)
+	switch {
+	case strings.HasSuffix(lower, ".xml"):
+		return "junit", nil
+	case strings.HasSuffix(lower, ".sarif"), strings.HasSuffix(lower, ".sarif.json"), strings.HasSuffix(lower, ".json"):
+		return "sarif", nil
+	}
+	return "", fmt.Errorf("unknown report format for %s (use .xml for JUnit or .sarif/.json for SARIF)", filepath.Base(path))
+}
+
+// CheckReportWriter returns the encoder for the format a report path's
+// extension names. The caller opens the file and passes it in.
+func CheckReportWriter(path string) (func(io.Writer, CheckReport) error, error) {
+	format, err := CheckReportFormat(path)
+	if err != nil {
+		return nil, err
+	}
+	if format == "junit" {
+		return WriteJUnit, nil
+	}
+	return WriteSARIF, nil
+}
+
+// ─── JUnit XML ──────────────────────────────────────────────────────────────
+
+type junitSuites struct {
+	XMLName xml.Name     `xml:"testsuites"`
+	Suites  []junitSuite `xml:"testsuite"`
+}
+
+type junitSuite struct {
+	Name     string      `xml:"name,attr"`
+	Tests    int         `xml:"tests,attr"`
+	Failures int         `xml:"failures,attr"`
+	Errors   int         `xml:"errors,attr"`
+	Time     string      `xml:"time,attr"`
+	Cases    []junitCase `xml:"testcase"`
+}
+
+type junitCase struct {
+	Name      string        `xml:"name,attr"`
+	Classname string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Error     *junitMessage `xml:"error,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+	Body    string `xml:",chardata"`
+}
+
+// WriteJUnit encodes a report as JUnit XML, one testcase per check.
+func WriteJUnit(w io.Writer, r CheckReport) error {
+	failed, errored := r.Counts()
+	suite := junitSuite{
+		Name:     r.Name,
+		Tests:    len(r.Checks),
+		Failures: failed,
+		Errors:   errored,
+		Time:     junitSeconds(r.Duration),
+	}
+	for _, c := range r.Checks {
+		tc := junitCase{
+			Name:      c.Name,
+			Classname: r.Name,
+			Time:      junitSeconds(c.Duration),
+			SystemOut: c.Details,
+		}
+		switch c.Status {
+		case CheckError:
+			tc.Error = &junitMessage{Message: c.Message, Body: c.Message}
+		case CheckFailed:
+			tc.Failure = &junitMessage{Message: c.Message, Body: strings.ReplaceAll(c.Message, "; ", "\n")}
+		}
+		suite.Cases = append(suite.Cases, tc)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(junitSuites{Suites: []junitSuite{suite}}); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+func junitSeconds(d time.Duration) string {
+	return fmt.Sprintf("%.3f", d.Seconds())
+}
+
+// ─── SARIF 2.1.0 ────────────────────────────────────────────────────────────
+
+const sarifSchema = "https://json.schemastore.org/sarif-2.1.0.json"
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri,omitempty"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	Name             string       `json:"name,omitempty"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Kind      string          `json:"kind"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations,omitempty"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifact `json:"artifactLocation"`
+}
+
+type sarifArtifact struct {
+	URI string `json:"uri"`
+}
+
+// WriteSARIF encodes a report as a SARIF 2.1.0 log with one rule and one
+// result per check. Passing checks are kept as kind "pass" so viewers can
+// show the full run.
+func WriteSARIF(w io.Writer, r CheckReport) error {
+	driver := sarifDriver{
+		Name:           "hawkeye",
+		InformationURI: "https://neubird.ai",
+		Rules:          []sarifRule{},
+	}
+	results := []sarifResult{}
+	for _, c := range r.Checks {
+		driver.Rules = append(driver.Rules, sarifRule{
+			ID:               c.ID,
+			Name:             c.Name,
+			ShortDescription: sarifMessage{Text: r.Name + ": " + c.Name},
+		})
+		res := sarifResult{
+			RuleID:  c.ID,
+			Kind:    "pass",
+			Level:   "none",
+			Message: sarifMessage{Text: c.Message},
+		}
+		switch c.Status {
+		case CheckFailed:
+			res.Kind, res.Level = "fail", "error"
+		case CheckError:
+			res.Kind, res.Level = "fail", "warning"
+		}
+		if res.Message.Text == "" {
+			res.Message.Text = c.Status
+		}
+		if r.Source != "" {
+			res.Locations = []sarifLocation{{PhysicalLocation: sarifPhysicalLocation{
+				ArtifactLocation: sarifArtifact{URI: filepath.ToSlash(r.Source)},
+			}}}
+		}
+		results = append(results, res)
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(sarifLog{
+		Schema:  sarifSchema,
+		Version: "2.1.0",
+		Runs:    []sarifRun{{Tool: sarifTool{Driver: driver}, Results: results}},
+	})
+}
@@ -0,0 +1,105 @@
+package service
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+var testCheckReport = CheckReport{
+	Name:     "golden",
+	Source:   "evals/suite.yaml",
+	Duration: 1500 * time.Millisecond,
+	Checks: []CheckResult{
+		{ID: "ok", Name: "ok", Status: CheckPassed, Message: "all expectations met"},
+		{ID: "bad", Name: "bad", Status: CheckFailed, Message: `missing "pool"`},
+		{ID: "broken", Name: "broken", Status: CheckError, Message: "stream error"},
+	},
+}
+
+func TestCheckReportFormat(t *testing.T) {
+	tests := []struct {
+		path    string
+		want    string
+		wantErr bool
+	}{
+		{"junit.xml", "junit", false},
+		{"out/Results.XML", "junit", false},
+		{"scan.sarif", "sarif", false},
+		{"sarif.json", "sarif", false},
+		{"report.txt", "", true},
+	}
+	for _, tt := range tests {
+		got, err := CheckReportFormat(tt.path)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("CheckReportFormat(%q) = %q, %v; want %q", tt.path, got, err, tt.want)
+		}
+	}
+}
+
+func TestWriteJUnit(t *testing.T) {
+	var b strings.Builder
+	if err := WriteJUnit(&b, testCheckReport); err != nil {
+		t.Fatalf("WriteJUnit() error = %v", err)
+	}
+	out := b.String()
+	for _, want := range []string{
+		`<?xml version="1.0" encoding="UTF-8"?>`,
+		`<testsuite name="golden" tests="3" failures="1" errors="1" time="1.500">`,
+		`<testcase name="ok" classname="golden"`,
+		`<failure message="missing &#34;pool&#34;">`,
+		`<error message="stream error">`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("JUnit output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestWriteSARIF(t *testing.T) {
+	var b strings.Builder
+	if err := WriteSARIF(&b, testCheckReport); err != nil {
+		t.Fatalf("WriteSARIF() error = %v", err)
+	}
+	var log sarifLog
+	if err := json.Unmarshal([]byte(b.String()), &log); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if log.Version != "2.1.0" || len(log.Runs) != 1 {
+		t.Fatalf("log = %+v", log)
+	}
+	run := log.Runs[0]
+	if len(run.Tool.Driver.Rules) != 3 || len(run.Results) != 3 {
+		t.Fatalf("rules = %d, results = %d, want 3 and 3", len(run.Tool.Driver.Rules), len(run.Results))
+	}
+	want := []struct{ kind, level string }{{"pass", "none"}, {"fail", "error"}, {"fail", "warning"}}
+	for i, w := range want {
+		r := run.Results[i]
+		if r.Kind != w.kind || r.Level != w.level {
+			t.Errorf("result %d = %s/%s, want %s/%s", i, r.Kind, r.Level, w.kind, w.level)
+		}
+		if len(r.Locations) != 1 || r.Locations[0].PhysicalLocation.ArtifactLocation.URI != "evals/suite.yaml" {
+			t.Errorf("result %d locations = %+v", i, r.Locations)
+		}
+	}
+}
+
+func TestCheckReportWriter(t *testing.T) {
+	for name, want := range map[string]string{"junit.xml": "<testsuites>", "scan.sarif": `"2.1.0"`} {
+		write, err := CheckReportWriter(name)
+		if err != nil {
+			t.Fatalf("CheckReportWriter(%s) error = %v", name, err)
+		}
+		var b strings.Builder
+		if err := write(&b, testCheckReport); err != nil {
+			t.Fatalf("%s: write error = %v", name, err)
+		}
+		if !strings.Contains(b.String(), want) {
+			t.Errorf("%s output missing %q:\n%s", name, want, b.String())
+		}
+	}
+	if _, err := CheckReportWriter("x.txt"); err == nil {
+		t.Error("CheckReportWriter(.txt) error = nil, want unknown format")
+	}
+}
@@ -0,0 +1,91 @@
+package service
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ─── Command registry ───────────────────────────────────────────────────────
+//
+// The CLI and the interactive mode each keep their commands in a
+// CommandRegistry: name, aliases, argument synopsis and a one-line summary
+// next to the handler, so dispatch, help and completion read from one
+// table. H is the handler type, which differs between the two.
+
+// Command is one registered command. Subcommands lists the first
+// arguments that select something other than the command's default
+// action, so command-wide defaults from config set-default skip them.
+// Terminal marks a command that hands the terminal to interactive mode.
+type Command[H any] struct {
+	Name        string
+	Aliases     []string
+	Args        string // argument synopsis for help, e.g. "[session-uuid]"
+	Summary     string
+	Subcommands []string
+	Terminal    bool
+	Run         H
+}
+
+// CommandRegistry looks commands up by name or alias.
+type CommandRegistry[H any] struct {
+	commands []*Command[H]
+	byName   map[string]*Command[H]
+}
+
+// NewCommandRegistry returns a registry of commands, kept in the given
+// order. A name or alias registered twice is a programming error and
+// panics.
+func NewCommandRegistry[H any](commands ...Command[H]) *CommandRegistry[H] {
+	r := &CommandRegistry[H]{byName: make(map[string]*Command[H])}
+	for i := range commands {
+		c := &commands[i]
+		for _, name := range append([]string{c.Name}, c.Aliases...) {
+			if _, dup := r.byName[name]; dup {
+				panic(fmt.Sprintf("command %q registered twice", name))
+			}
+			r.byName[name] = c
+		}
+		r.commands = append(r.commands, c)
+	}
+	return r
+}
+
+// Lookup finds a command by name or alias.
+func (r *CommandRegistry[H]) Lookup(name string) (*Command[H], bool) {
+	c, ok := r.byName[name]
+	return c, ok
+}
+
+// Commands returns the registered commands in registration order.
+func (r *CommandRegistry[H]) Commands() []*Command[H] {
+	return r.commands
+}
+
+// UsageBlock returns the lines of usage text that document a command:
+// every line at command indent that starts with one of names (alone or in
+// an "a|b" alternation), followed by its more deeply indented flag lines.
+// Lines repeated across sections are kept once.
+func UsageBlock(usage string, names ...string) string {
+	var b strings.Builder
+	seen := map[string]bool{}
+	in := false
+	for _, line := range strings.Split(usage, "\n") {
+		indent := len(line) - len(strings.TrimLeft(line, " "))
+		switch {
+		case indent == 2:
+			first, _, _ := strings.Cut(strings.TrimSpace(line), " ")
+			in = false
+			for _, alt := range strings.Split(first, "|") {
+				in = in || slices.Contains(names, alt)
+			}
+		case indent < 2:
+			in = false
+		}
+		if in && !seen[line] {
+			seen[line] = true
+			b.WriteString(line + "\n")
+		}
+	}
+	return b.String()
+}
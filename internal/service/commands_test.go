@@ -0,0 +1,58 @@
+package service
+
+import "testing"
+
+func TestCommandRegistry(t *testing.T) {
+	r := NewCommandRegistry(
+		Command[int]{Name: "investigate", Aliases: []string{"ask"}, Run: 1},
+		Command[int]{Name: "inspect", Run: 2},
+	)
+	if c, ok := r.Lookup("ask"); !ok || c.Run != 1 {
+		t.Errorf("Lookup(ask) = %+v, %v", c, ok)
+	}
+	if _, ok := r.Lookup("Inspect"); ok {
+		t.Error("lookup should be case-sensitive")
+	}
+	if got := r.Commands(); len(got) != 2 || got[1].Name != "inspect" {
+		t.Errorf("Commands() = %+v", got)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("duplicate alias did not panic")
+		}
+	}()
+	NewCommandRegistry(Command[int]{Name: "a"}, Command[int]{Name: "b", Aliases: []string{"a"}})
+}
+
+func TestUsageBlock(t *testing.T) {
+	usage := `Getting Started:
+  set project <uuid>        Set the active project
+  config                    Show configuration
+
+Investigation:
+  investigate|ask "<q>"     Investigate
+    -s, --session <uuid>    Continue a session
+                            (wrapped note)
+  inspect [uuid]            View details
+    --cot <mode>            Chain of thought
+
+Settings:
+  set project <uuid>        Set the active project
+  set theme <name>          Colors
+`
+	want := `  investigate|ask "<q>"     Investigate
+    -s, --session <uuid>    Continue a session
+                            (wrapped note)
+`
+	if got := UsageBlock(usage, "ask"); got != want {
+		t.Errorf("UsageBlock(ask) =\n%s\nwant\n%s", got, want)
+	}
+	want = "  set project <uuid>        Set the active project\n  set theme <name>          Colors\n"
+	if got := UsageBlock(usage, "set"); got != want {
+		t.Errorf("UsageBlock(set) =\n%s\nwant\n%s", got, want)
+	}
+	if got := UsageBlock(usage, "session"); got != "" {
+		t.Errorf("UsageBlock(session) = %q, want nothing", got)
+	}
+}
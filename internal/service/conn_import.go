@@ -0,0 +1,304 @@
+package service
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ─── Connection import ──────────────────────────────────────────────────────
+//
+// `hawkeye connections import` proposes connections from what already
+// describes the infrastructure: a Terraform state file (AWS accounts and
+// regions with their CloudWatch log groups, Amazon Managed Prometheus
+// workspaces) or the local AWS CLI profiles.
+
+// ConnectionCandidate is a connection import proposes to create.
+type ConnectionCandidate struct {
+	Type    string            `json:"type"`
+	Name    string            `json:"name"`
+	Source  string            `json:"source"`            // where it was found, e.g. "terraform" or "aws profile prod"
+	Details []string          `json:"details,omitempty"` // what was detected, for the user to judge
+	Config  map[string]string `json:"config"`
+	Missing []string          `json:"missing,omitempty"` // required fields still to be filled in
+}
+
+// FindConnectionType returns the supported connection type named t.
+func FindConnectionType(t string) (ConnectionType, bool) {
+	for _, ct := range GetConnectionTypes() {
+		if ct.Type == t {
+			return ct, true
+		}
+	}
+	return ConnectionType{}, false
+}
+
+// fillMissing records which required fields of the candidate's type have
+// no value.
+func (c *ConnectionCandidate) fillMissing() {
+	c.Missing = nil
+	ct, ok := FindConnectionType(c.Type)
+	if !ok {
+		return
+	}
+	for _, f := range ct.Fields {
+		if !f.Optional && strings.TrimSpace(c.Config[f.Key]) == "" {
+			c.Missing = append(c.Missing, f.Key)
+		}
+	}
+}
+
+// Redacted returns a copy with secret config values masked, for display.
+func (c ConnectionCandidate) Redacted() ConnectionCandidate {
+	ct, _ := FindConnectionType(c.Type)
+	secret := map[string]bool{}
+	for _, f := range ct.Fields {
+		secret[f.Key] = f.Secret
+	}
+	out := c
+	out.Config = make(map[string]string, len(c.Config))
+	for k, v := range c.Config {
+		if secret[k] && v != "" {
+			v = strings.Repeat("•", 8)
+		}
+		out.Config[k] = v
+	}
+	return out
+}
+
+type terraformState struct {
+	Version   int `json:"version"`
+	Resources []struct {
+		Mode      string `json:"mode"`
+		Type      string `json:"type"`
+		Name      string `json:"name"`
+		Instances []struct {
+			Attributes map[string]any `json:"attributes"`
+		} `json:"instances"`
+	} `json:"resources"`
+}
+
+// awsTarget is an AWS account and region seen in the state.
+type awsTarget struct {
+	account, region string
+	logGroups       []string
+	resources       int
+}
+
+// CandidatesFromTerraformState proposes connections for a Terraform state
+// file (format version 4): one AWS connection per account and region its
+// managed resources live in, noting the CloudWatch log groups found there,
+// and one Prometheus connection per Amazon Managed Prometheus workspace.
+// AWS credentials are never in the state, so they are left missing.
+func CandidatesFromTerraformState(data []byte) ([]ConnectionCandidate, error) {
+	var st terraformState
+	if err := json.Unmarshal(data, &st); err != nil {
+		return nil, fmt.Errorf("invalid Terraform state: %w", err)
+	}
+	if st.Version != 4 {
+		return nil, fmt.Errorf("unsupported Terraform state version %d (expected 4)", st.Version)
+	}
+
+	targets := map[string]*awsTarget{}
+	var order []string
+	var out []ConnectionCandidate
+	for _, r := range st.Resources {
+		if r.Mode != "managed" || !strings.HasPrefix(r.Type, "aws_") {
+			continue
+		}
+		for _, inst := range r.Instances {
+			attrs := inst.Attributes
+			account, region := arnAccountRegion(attrString(attrs, "arn"))
+			if region == "" {
+				region = attrString(attrs, "region")
+			}
+			if region != "" {
+				key := account + "/" + region
+				t := targets[key]
+				if t == nil {
+					t = &awsTarget{account: account, region: region}
+					targets[key] = t
+					order = append(order, key)
+				}
+				t.resources++
+				if r.Type == "aws_cloudwatch_log_group" {
+					if name := attrString(attrs, "name"); name != "" {
+						t.logGroups = append(t.logGroups, name)
+					}
+				}
+			}
+
+			if r.Type == "aws_prometheus_workspace" {
+				endpoint := attrString(attrs, "prometheus_endpoint")
+				if endpoint == "" {
+					continue
+				}
+				name := firstNonEmpty(attrString(attrs, "alias"), attrString(attrs, "id"), r.Name)
+				c := ConnectionCandidate{
+					Type:    "prometheus",
+					Name:    "amp-" + candidateSlug(name),
+					Source:  "terraform " + r.Type + "." + r.Name,
+					Details: []string{"Amazon Managed Prometheus workspace " + name},
+					Config:  map[string]string{"url": strings.TrimSuffix(endpoint, "/")},
+				}
+				c.fillMissing()
+				out = append(out, c)
+			}
+		}
+	}
+
+	var aws []ConnectionCandidate
+	for _, key := range order {
+		t := targets[key]
+		name := "aws-" + t.region
+		details := []string{fmt.Sprintf("%d managed resource(s) in %s", t.resources, t.region)}
+		if t.account != "" {
+			name = fmt.Sprintf("aws-%s-%s", t.account, t.region)
+			details[0] = fmt.Sprintf("%d managed resource(s) in account %s, %s", t.resources, t.account, t.region)
+		}
+		if n := len(t.logGroups); n > 0 {
+			sort.Strings(t.logGroups)
+			shown := t.logGroups
+			if len(shown) > 3 {
+				shown = shown[:3]
+			}
+			line := fmt.Sprintf("%d CloudWatch log group(s): %s", n, strings.Join(shown, ", "))
+			if n > len(shown) {
+				line += ", ..."
+			}
+			details = append(details, line)
+		}
+		c := ConnectionCandidate{
+			Type:    "aws",
+			Name:    name,
+			Source:  "terraform",
+			Details: details,
+			Config:  map[string]string{"region": t.region},
+		}
+		c.fillMissing()
+		aws = append(aws, c)
+	}
+	return append(aws, out...), nil
+}
+
+// arnAccountRegion extracts the account ID and region from an ARN such as
+// arn:aws:logs:us-east-1:123456789012:log-group:app.
+func arnAccountRegion(arn string) (account, region string) {
+	parts := strings.SplitN(arn, ":", 6)
+	if len(parts) < 6 || parts[0] != "arn" {
+		return "", ""
+	}
+	return parts[4], parts[3]
+}
+
+func attrString(attrs map[string]any, key string) string {
+	s, _ := attrs[key].(string)
+	return s
+}
+
+// candidateSlug turns a label into a connection name fragment.
+func candidateSlug(s string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(s) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			b.WriteRune(r)
+		default:
+			if b.Len() > 0 && !strings.HasSuffix(b.String(), "-") {
+				b.WriteByte('-')
+			}
+		}
+	}
+	return strings.TrimSuffix(b.String(), "-")
+}
+
+// CandidatesFromAWSProfiles proposes one AWS connection per profile in the
+// AWS CLI config and credentials files (their contents, either may be
+// empty), with the region, role and static keys the profile sets.
+func CandidatesFromAWSProfiles(configFile, credentialsFile string) []ConnectionCandidate {
+	profiles := map[string]map[string]string{}
+	var order []string
+	merge := func(name string, values map[string]string) {
+		p := profiles[name]
+		if p == nil {
+			p = map[string]string{}
+			profiles[name] = p
+			order = append(order, name)
+		}
+		for k, v := range values {
+			p[k] = v
+		}
+	}
+	for _, s := range parseINI(configFile) {
+		// The config file names sections "profile x", except "default".
+		merge(strings.TrimSpace(strings.TrimPrefix(s.name, "profile ")), s.values)
+	}
+	for _, s := range parseINI(credentialsFile) {
+		merge(s.name, s.values)
+	}
+
+	var out []ConnectionCandidate
+	for _, name := range order {
+		p := profiles[name]
+		if strings.HasPrefix(name, "sso-session ") || name == "" {
+			continue
+		}
+		c := ConnectionCandidate{
+			Type:   "aws",
+			Name:   "aws-" + candidateSlug(name),
+			Source: "aws profile " + name,
+			Config: map[string]string{},
+		}
+		set := func(key, value string) {
+			if value != "" {
+				c.Config[key] = value
+			}
+		}
+		set("region", p["region"])
+		set("access_key_id", p["aws_access_key_id"])
+		set("secret_access_key", p["aws_secret_access_key"])
+		set("role_arn", p["role_arn"])
+		if p["sso_start_url"] != "" || p["sso_session"] != "" {
+			c.Details = append(c.Details, "SSO profile: create an access key or role for Hawkeye")
+		}
+		if p["role_arn"] != "" {
+			c.Details = append(c.Details, "Assumes "+p["role_arn"])
+		}
+		c.fillMissing()
+		out = append(out, c)
+	}
+	return out
+}
+
+type iniSection struct {
+	name   string
+	values map[string]string
+}
+
+// parseINI reads the sections of an AWS-style INI file. Keys outside any
+// section and comment lines are ignored.
+func parseINI(content string) []iniSection {
+	var sections []iniSection
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			sections = append(sections, iniSection{
+				name:   strings.TrimSpace(line[1 : len(line)-1]),
+				values: map[string]string{},
+			})
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if !ok || len(sections) == 0 {
+			continue
+		}
+		sections[len(sections)-1].values[strings.TrimSpace(key)] = strings.TrimSpace(value)
+	}
+	return sections
+}
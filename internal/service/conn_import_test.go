@@ -0,0 +1,89 @@
+package service
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const testTFState = `{
+  "version": 4,
+  "resources": [
+    {"mode": "managed", "type": "aws_cloudwatch_log_group", "name": "app", "instances": [
+      {"attributes": {"arn": "arn:aws:logs:us-east-1:111122223333:log-group:/app/api", "name": "/app/api"}},
+      {"attributes": {"arn": "arn:aws:logs:us-east-1:111122223333:log-group:/app/worker", "name": "/app/worker"}}
+    ]},
+    {"mode": "managed", "type": "aws_prometheus_workspace", "name": "main", "instances": [
+      {"attributes": {"arn": "arn:aws:aps:eu-west-1:111122223333:workspace/ws-1", "id": "ws-1", "alias": "Prod Metrics",
+        "prometheus_endpoint": "https://aps-workspaces.eu-west-1.amazonaws.com/workspaces/ws-1/"}}
+    ]},
+    {"mode": "data", "type": "aws_caller_identity", "name": "me", "instances": [{"attributes": {"account_id": "111122223333"}}]},
+    {"mode": "managed", "type": "google_storage_bucket", "name": "b", "instances": [{"attributes": {"name": "b"}}]}
+  ]
+}`
+
+func TestCandidatesFromTerraformState(t *testing.T) {
+	got, err := CandidatesFromTerraformState([]byte(testTFState))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, c := range got {
+		names = append(names, c.Type+":"+c.Name)
+	}
+	want := []string{"aws:aws-111122223333-us-east-1", "aws:aws-111122223333-eu-west-1", "prometheus:amp-prod-metrics"}
+	if !reflect.DeepEqual(names, want) {
+		t.Fatalf("candidates = %v, want %v", names, want)
+	}
+
+	east := got[0]
+	if east.Config["region"] != "us-east-1" {
+		t.Errorf("region = %q", east.Config["region"])
+	}
+	if !reflect.DeepEqual(east.Missing, []string{"access_key_id", "secret_access_key"}) {
+		t.Errorf("missing = %v", east.Missing)
+	}
+	if len(east.Details) != 2 || !strings.Contains(east.Details[1], "2 CloudWatch log group(s): /app/api, /app/worker") {
+		t.Errorf("details = %v", east.Details)
+	}
+
+	amp := got[2]
+	if amp.Config["url"] != "https://aps-workspaces.eu-west-1.amazonaws.com/workspaces/ws-1" || len(amp.Missing) != 0 {
+		t.Errorf("prometheus candidate = %+v", amp)
+	}
+
+	for _, bad := range []string{`nope`, `{"version": 3}`} {
+		if _, err := CandidatesFromTerraformState([]byte(bad)); err == nil {
+			t.Errorf("CandidatesFromTerraformState(%s) should fail", bad)
+		}
+	}
+}
+
+func TestCandidatesFromAWSProfiles(t *testing.T) {
+	config := "[default]\nregion = us-east-1\n\n[profile prod]\nregion=eu-west-1\nrole_arn = arn:aws:iam::1:role/ro\n\n[profile sso]\nsso_start_url = https://x.awsapps.com/start\n\n[sso-session corp]\nsso_region = us-east-1\n"
+	credentials := "# keys\n[default]\naws_access_key_id = AKIA1\naws_secret_access_key = s3cret\n"
+
+	got := CandidatesFromAWSProfiles(config, credentials)
+	if len(got) != 3 {
+		t.Fatalf("got %d candidates, want 3: %+v", len(got), got)
+	}
+
+	def := got[0]
+	if def.Name != "aws-default" || def.Config["access_key_id"] != "AKIA1" || len(def.Missing) != 0 {
+		t.Errorf("default = %+v", def)
+	}
+	if red := def.Redacted(); red.Config["secret_access_key"] == "s3cret" || red.Config["access_key_id"] != "AKIA1" {
+		t.Errorf("Redacted() = %+v", red.Config)
+	}
+	if def.Config["secret_access_key"] != "s3cret" {
+		t.Error("Redacted() should not modify the original")
+	}
+
+	prod := got[1]
+	if prod.Config["role_arn"] != "arn:aws:iam::1:role/ro" || !reflect.DeepEqual(prod.Missing, []string{"access_key_id", "secret_access_key"}) {
+		t.Errorf("prod = %+v", prod)
+	}
+	if sso := got[2]; len(sso.Details) == 0 || !reflect.DeepEqual(sso.Missing, []string{"region", "access_key_id", "secret_access_key"}) {
+		t.Errorf("sso = %+v", sso)
+	}
+}
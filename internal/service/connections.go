@@ -1,7 +1,9 @@
 package service
 
 import (
+	"fmt"
 	"hawkeye-cli/internal/api"
+	"slices"
 	"strings"
 )
 
@@ -77,24 +79,218 @@ func FormatConnectionDetail(c *api.ConnectionDetail) ConnectionDetailDisplay {
 type ConnectionType struct {
 	Type        string
 	Description string
+	Fields      []ConnectionField
+}
+
+// ConnectionField is one config key a connection type takes. Secret fields
+// are masked when prompted for interactively. Credential marks the
+// non-secret half of a key pair (an access key ID, a username), which
+// `connections rotate-secret` changes together with the secret.
+type ConnectionField struct {
+	Key        string
+	Label      string
+	Secret     bool
+	Optional   bool
+	Credential bool
 }
 
 // GetConnectionTypes returns the list of supported connection types.
 func GetConnectionTypes() []ConnectionType {
 	return []ConnectionType{
-		{"aws", "Amazon Web Services (CloudWatch, X-Ray)"},
-		{"datadog", "Datadog monitoring platform"},
-		{"prometheus", "Prometheus metrics"},
-		{"grafana", "Grafana dashboards and datasources"},
-		{"pagerduty", "PagerDuty incident management"},
-		{"jira", "Jira issue tracking"},
-		{"slack", "Slack notifications"},
-		{"elasticsearch", "Elasticsearch / OpenSearch logs"},
-		{"gcp", "Google Cloud Platform (Cloud Monitoring)"},
-		{"azure", "Microsoft Azure Monitor"},
-		{"splunk", "Splunk observability"},
-		{"newrelic", "New Relic monitoring"},
+		{"aws", "Amazon Web Services (CloudWatch, X-Ray)", []ConnectionField{
+			{Key: "region", Label: "Region"},
+			{Key: "access_key_id", Label: "Access key ID", Credential: true},
+			{Key: "secret_access_key", Label: "Secret access key", Secret: true},
+			{Key: "role_arn", Label: "Role ARN", Optional: true},
+		}},
+		{"datadog", "Datadog monitoring platform", []ConnectionField{
+			{Key: "api_key", Label: "API key", Secret: true},
+			{Key: "app_key", Label: "Application key", Secret: true},
+			{Key: "site", Label: "Site (e.g. datadoghq.com)", Optional: true},
+		}},
+		{"prometheus", "Prometheus metrics", []ConnectionField{
+			{Key: "url", Label: "URL"},
+			{Key: "username", Label: "Username", Credential: true, Optional: true},
+			{Key: "password", Label: "Password", Secret: true, Optional: true},
+		}},
+		{"grafana", "Grafana dashboards and datasources", []ConnectionField{
+			{Key: "url", Label: "URL"},
+			{Key: "api_key", Label: "API key", Secret: true},
+		}},
+		{"pagerduty", "PagerDuty incident management", []ConnectionField{
+			{Key: "api_key", Label: "API key", Secret: true},
+		}},
+		{"jira", "Jira issue tracking", []ConnectionField{
+			{Key: "url", Label: "URL"},
+			{Key: "email", Label: "Email", Credential: true},
+			{Key: "api_token", Label: "API token", Secret: true},
+		}},
+		{"slack", "Slack notifications", []ConnectionField{
+			{Key: "bot_token", Label: "Bot token", Secret: true},
+		}},
+		{"elasticsearch", "Elasticsearch / OpenSearch logs", []ConnectionField{
+			{Key: "url", Label: "URL"},
+			{Key: "username", Label: "Username", Credential: true, Optional: true},
+			{Key: "password", Label: "Password", Secret: true, Optional: true},
+		}},
+		{"gcp", "Google Cloud Platform (Cloud Monitoring)", []ConnectionField{
+			{Key: "project_id", Label: "Project ID"},
+			{Key: "service_account_json", Label: "Service account JSON", Secret: true},
+		}},
+		{"azure", "Microsoft Azure Monitor", []ConnectionField{
+			{Key: "tenant_id", Label: "Tenant ID"},
+			{Key: "client_id", Label: "Client ID", Credential: true},
+			{Key: "client_secret", Label: "Client secret", Secret: true},
+			{Key: "subscription_id", Label: "Subscription ID"},
+		}},
+		{"splunk", "Splunk observability", []ConnectionField{
+			{Key: "url", Label: "URL"},
+			{Key: "token", Label: "Token", Secret: true},
+		}},
+		{"newrelic", "New Relic monitoring", []ConnectionField{
+			{Key: "account_id", Label: "Account ID"},
+			{Key: "api_key", Label: "API key", Secret: true},
+		}},
+	}
+}
+
+// ValidateConnectionConfig checks that every required field of ct has a
+// non-blank value and drops blank optional ones.
+func ValidateConnectionConfig(ct ConnectionType, config map[string]string) (map[string]string, error) {
+	out := make(map[string]string, len(config))
+	for k, v := range config {
+		if strings.TrimSpace(v) != "" {
+			out[k] = strings.TrimSpace(v)
+		}
+	}
+	var missing []string
+	for _, f := range ct.Fields {
+		if !f.Optional && out[f.Key] == "" {
+			missing = append(missing, f.Key)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("%s connection is missing %s", ct.Type, strings.Join(missing, ", "))
+	}
+	return out, nil
+}
+
+// CredentialFields returns the fields of ct that rotate-secret may change:
+// its secrets and the credential fields paired with them.
+func (ct ConnectionType) CredentialFields() []ConnectionField {
+	var out []ConnectionField
+	for _, f := range ct.Fields {
+		if f.Secret || f.Credential {
+			out = append(out, f)
+		}
+	}
+	return out
+}
+
+// CredentialFlag is the command-line flag for a field: api_key → --api-key.
+func CredentialFlag(f ConnectionField) string {
+	return "--" + strings.ReplaceAll(f.Key, "_", "-")
+}
+
+// SecretFlags returns the command-line flags of every secret field across
+// all connection types, for redacting them from the audit log.
+func SecretFlags() []string {
+	var out []string
+	for _, ct := range GetConnectionTypes() {
+		for _, f := range ct.Fields {
+			if flag := CredentialFlag(f); f.Secret && !slices.Contains(out, flag) {
+				out = append(out, flag)
+			}
+		}
+	}
+	return out
+}
+
+// ParseCredentialFlags reads `--api-key <value>` or `--api-key=<value>`
+// pairs for the credential fields of ct into a config map. Any other flag
+// is refused, so a rotation can never touch a connection's URL or region.
+func ParseCredentialFlags(ct ConnectionType, args []string) (map[string]string, error) {
+	fields := ct.CredentialFields()
+	byFlag := make(map[string]string, len(fields))
+	var flags []string
+	for _, f := range fields {
+		byFlag[CredentialFlag(f)] = f.Key
+		flags = append(flags, CredentialFlag(f))
+	}
+
+	out := make(map[string]string)
+	for i := 0; i < len(args); i++ {
+		flag, value, inline := strings.Cut(args[i], "=")
+		key, ok := byFlag[flag]
+		if !ok {
+			if len(flags) == 0 {
+				return nil, fmt.Errorf("%s connections have no credentials to rotate", ct.Type)
+			}
+			return nil, fmt.Errorf("unknown flag for a %s connection: %s (credentials: %s)", ct.Type, args[i], strings.Join(flags, ", "))
+		}
+		if !inline {
+			if i+1 >= len(args) {
+				return nil, fmt.Errorf("%s requires a value", flag)
+			}
+			i++
+			value = args[i]
+		}
+		out[key] = value
+	}
+	return out, nil
+}
+
+// Connection progress, as classified by SyncProgress and TrainingProgress.
+const (
+	ProgressDone    = "done"
+	ProgressFailed  = "failed"
+	ProgressPending = "pending"
+	ProgressNone    = "none" // state not reported, e.g. types that do not train
+)
+
+// SyncProgress classifies a connection sync state.
+func SyncProgress(state string) string {
+	return classifyProgress(state, "SYNC_STATE_", "SYNCED")
+}
+
+// TrainingProgress classifies a connection training state.
+func TrainingProgress(state string) string {
+	return classifyProgress(state, "TRAINING_STATE_", "TRAINED")
+}
+
+func classifyProgress(state, prefix, done string) string {
+	switch strings.TrimPrefix(state, prefix) {
+	case "", "UNSPECIFIED":
+		return ProgressNone
+	case done:
+		return ProgressDone
+	case "FAILED":
+		return ProgressFailed
+	}
+	return ProgressPending
+}
+
+// ShortState strips the enum prefix from a sync or training state and
+// lowercases it for display: "TRAINING_STATE_IN_PROGRESS" → "in progress".
+func ShortState(state string) string {
+	for _, p := range []string{"SYNC_STATE_", "TRAINING_STATE_"} {
+		state = strings.TrimPrefix(state, p)
+	}
+	if state == "" {
+		return "-"
+	}
+	return strings.ToLower(strings.ReplaceAll(state, "_", " "))
+}
+
+// ConnectionsSettled reports whether no connection is still syncing or
+// training.
+func ConnectionsSettled(conns []ConnectionDisplay) bool {
+	for _, c := range conns {
+		if SyncProgress(c.SyncState) == ProgressPending || TrainingProgress(c.TrainingState) == ProgressPending {
+			return false
+		}
 	}
+	return true
 }
 
 // FormatResources maps raw ResourceSpecs to display-ready structs.
@@ -1,6 +1,8 @@
 package service
 
 import (
+	"slices"
+	"strings"
 	"testing"
 
 	"hawkeye-cli/internal/api"
@@ -64,6 +66,108 @@ func TestGetConnectionTypes(t *testing.T) {
 	}
 }
 
+func TestValidateConnectionConfig(t *testing.T) {
+	ct := ConnectionType{Type: "prometheus", Fields: []ConnectionField{
+		{Key: "url"},
+		{Key: "password", Secret: true, Optional: true},
+	}}
+	tests := []struct {
+		name    string
+		config  map[string]string
+		want    map[string]string
+		wantErr string
+	}{
+		{"required set", map[string]string{"url": " http://prom:9090 "}, map[string]string{"url": "http://prom:9090"}, ""},
+		{"blank optional dropped", map[string]string{"url": "u", "password": "  "}, map[string]string{"url": "u"}, ""},
+		{"missing required", map[string]string{"password": "p"}, nil, "missing url"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ValidateConnectionConfig(ct, tt.config)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("error = %v", err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+			for k, v := range tt.want {
+				if got[k] != v {
+					t.Errorf("%s = %q, want %q", k, got[k], v)
+				}
+			}
+		})
+	}
+}
+
+func TestParseCredentialFlags(t *testing.T) {
+	aws, _ := FindConnectionType("aws")
+	got, err := ParseCredentialFlags(aws, []string{"--access-key-id", "AKIANEW", "--secret-access-key=s3cr=t"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 || got["access_key_id"] != "AKIANEW" || got["secret_access_key"] != "s3cr=t" {
+		t.Errorf("got %v", got)
+	}
+
+	tests := []struct {
+		args    []string
+		wantErr string
+	}{
+		{[]string{"--region", "eu-west-1"}, "credentials: --access-key-id, --secret-access-key"},
+		{[]string{"--secret-access-key"}, "requires a value"},
+	}
+	for _, tt := range tests {
+		if _, err := ParseCredentialFlags(aws, tt.args); err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("ParseCredentialFlags(%q) error = %v, want %q", tt.args, err, tt.wantErr)
+		}
+	}
+}
+
+func TestConnectionProgress(t *testing.T) {
+	tests := []struct {
+		state    string
+		sync     string
+		training string
+		short    string
+	}{
+		{"SYNCED", ProgressDone, ProgressPending, "synced"},
+		{"SYNC_STATE_SYNCED", ProgressDone, ProgressPending, "synced"},
+		{"TRAINING_STATE_TRAINED", ProgressPending, ProgressDone, "trained"},
+		{"TRAINING_STATE_IN_PROGRESS", ProgressPending, ProgressPending, "in progress"},
+		{"SYNC_STATE_FAILED", ProgressFailed, ProgressPending, "failed"},
+		{"FAILED", ProgressFailed, ProgressFailed, "failed"},
+		{"", ProgressNone, ProgressNone, "-"},
+	}
+	for _, tt := range tests {
+		if got := SyncProgress(tt.state); got != tt.sync {
+			t.Errorf("SyncProgress(%q) = %q, want %q", tt.state, got, tt.sync)
+		}
+		if got := TrainingProgress(tt.state); got != tt.training {
+			t.Errorf("TrainingProgress(%q) = %q, want %q", tt.state, got, tt.training)
+		}
+		if got := ShortState(tt.state); got != tt.short {
+			t.Errorf("ShortState(%q) = %q, want %q", tt.state, got, tt.short)
+		}
+	}
+
+	settled := []ConnectionDisplay{
+		{SyncState: "SYNCED", TrainingState: "TRAINED"},
+		{SyncState: "SYNC_STATE_FAILED"},
+	}
+	if !ConnectionsSettled(settled) {
+		t.Error("ConnectionsSettled() = false for finished connections")
+	}
+	if ConnectionsSettled(append(settled, ConnectionDisplay{SyncState: "SYNCED", TrainingState: "TRAINING_STATE_IN_PROGRESS"})) {
+		t.Error("ConnectionsSettled() = true while one is training")
+	}
+}
+
 func TestFormatConnection(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -138,3 +242,15 @@ func TestFormatResources(t *testing.T) {
 		}
 	})
 }
+
+func TestSecretFlags(t *testing.T) {
+	flags := SecretFlags()
+	for _, want := range []string{"--secret-access-key", "--app-key", "--client-secret", "--service-account-json", "--bot-token"} {
+		if !slices.Contains(flags, want) {
+			t.Errorf("SecretFlags() is missing %s", want)
+		}
+	}
+	if slices.Contains(flags, "--access-key-id") {
+		t.Error("SecretFlags() includes the non-secret --access-key-id")
+	}
+}
@@ -0,0 +1,84 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"hawkeye-cli/internal/api"
+)
+
+// Chain-of-thought detail levels for inspect.
+const (
+	CoTNone    = "none"
+	CoTSummary = "summary"
+	CoTFull    = "full"
+)
+
+// CoTFilter selects which prompt cycles and chain-of-thought steps inspect
+// shows, and in how much detail.
+type CoTFilter struct {
+	Level      string   // CoTNone, CoTSummary or CoTFull ("" means full)
+	Categories []string // keep steps in any of these categories; empty keeps all
+	Cycle      int      // 1-based prompt cycle; 0 keeps all
+	FailedOnly bool     // keep only steps that ended in failure
+}
+
+// ParseCoTLevel validates a --cot value.
+func ParseCoTLevel(s string) (string, error) {
+	switch level := strings.ToLower(s); level {
+	case CoTNone, CoTSummary, CoTFull:
+		return level, nil
+	}
+	return "", fmt.Errorf("invalid --cot value %q (use none, summary or full)", s)
+}
+
+// CoTFailed reports whether a chain-of-thought step ended in failure.
+func CoTFailed(cot api.ChainOfThought) bool {
+	status := strings.ToUpper(firstNonEmpty(cot.CotStatus, cot.Status))
+	return strings.Contains(status, "ERROR") || strings.Contains(status, "FAIL")
+}
+
+// Apply returns the selected prompt cycles with their steps filtered. The
+// input is not modified.
+func (f CoTFilter) Apply(cycles []api.PromptCycle) ([]api.PromptCycle, error) {
+	if f.Cycle < 0 || f.Cycle > len(cycles) {
+		return nil, fmt.Errorf("cycle %d out of range (session has %d)", f.Cycle, len(cycles))
+	}
+	if f.Cycle > 0 {
+		cycles = cycles[f.Cycle-1 : f.Cycle]
+	}
+	out := make([]api.PromptCycle, len(cycles))
+	for i, pc := range cycles {
+		var steps []api.ChainOfThought
+		if f.Level != CoTNone {
+			for _, cot := range pc.ChainOfThoughts {
+				if f.keep(cot) {
+					steps = append(steps, cot)
+				}
+			}
+		}
+		pc.ChainOfThoughts = steps
+		out[i] = pc
+	}
+	return out, nil
+}
+
+func (f CoTFilter) keep(cot api.ChainOfThought) bool {
+	if f.FailedOnly && !CoTFailed(cot) {
+		return false
+	}
+	if len(f.Categories) == 0 {
+		return true
+	}
+	for _, c := range f.Categories {
+		if strings.EqualFold(c, cot.Category) {
+			return true
+		}
+	}
+	return false
+}
+
+// Filtered reports whether the filter drops any steps.
+func (f CoTFilter) Filtered() bool {
+	return f.FailedOnly || len(f.Categories) > 0
+}
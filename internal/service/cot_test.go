@@ -0,0 +1,73 @@
+package service
+
+import (
+	"testing"
+
+	"hawkeye-cli/internal/api"
+)
+
+func TestCoTFilterApply(t *testing.T) {
+	cycles := []api.PromptCycle{
+		{ID: "p1", ChainOfThoughts: []api.ChainOfThought{
+			{ID: "a", Category: "logs", CotStatus: "CHAIN_OF_THOUGHT_STATUS_DONE"},
+			{ID: "b", Category: "metrics", CotStatus: "CHAIN_OF_THOUGHT_STATUS_ERROR"},
+			{ID: "c", Category: "Logs", Status: "FAILED"},
+		}},
+		{ID: "p2", ChainOfThoughts: []api.ChainOfThought{
+			{ID: "d", Category: "metrics"},
+		}},
+	}
+	ids := func(pcs []api.PromptCycle) string {
+		s := ""
+		for _, pc := range pcs {
+			s += pc.ID + ":"
+			for _, cot := range pc.ChainOfThoughts {
+				s += cot.ID
+			}
+			s += " "
+		}
+		return s
+	}
+
+	tests := []struct {
+		name   string
+		filter CoTFilter
+		want   string
+	}{
+		{"all", CoTFilter{}, "p1:abc p2:d "},
+		{"none", CoTFilter{Level: CoTNone}, "p1: p2: "},
+		{"category", CoTFilter{Categories: []string{"LOGS"}}, "p1:ac p2: "},
+		{"failed only", CoTFilter{FailedOnly: true}, "p1:bc p2: "},
+		{"failed metrics", CoTFilter{FailedOnly: true, Categories: []string{"metrics"}}, "p1:b p2: "},
+		{"cycle", CoTFilter{Cycle: 2}, "p2:d "},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.filter.Apply(cycles)
+			if err != nil {
+				t.Fatalf("Apply() error = %v", err)
+			}
+			if ids(got) != tt.want {
+				t.Errorf("Apply() = %q, want %q", ids(got), tt.want)
+			}
+		})
+	}
+
+	if len(cycles[0].ChainOfThoughts) != 3 {
+		t.Error("Apply() modified its input")
+	}
+	if _, err := (CoTFilter{Cycle: 3}).Apply(cycles); err == nil {
+		t.Error("Apply(cycle 3) error = nil, want out of range")
+	}
+}
+
+func TestParseCoTLevel(t *testing.T) {
+	for _, in := range []string{"none", "Summary", "full"} {
+		if _, err := ParseCoTLevel(in); err != nil {
+			t.Errorf("ParseCoTLevel(%q) error = %v", in, err)
+		}
+	}
+	if _, err := ParseCoTLevel("brief"); err == nil {
+		t.Error("ParseCoTLevel(brief) error = nil")
+	}
+}
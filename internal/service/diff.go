@@ -0,0 +1,157 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ─── Line diffs ─────────────────────────────────────────────────────────────
+//
+// Library and instruction edits replace text the AI reads on every
+// investigation, so previews show exactly which lines change.
+
+// Diff line operations.
+const (
+	DiffSame   = ' '
+	DiffAdd    = '+'
+	DiffRemove = '-'
+)
+
+// DiffLine is one line of a line diff.
+type DiffLine struct {
+	Op   byte   `json:"op"`
+	Text string `json:"text"`
+}
+
+// DiffLines returns the lines that turn before into after, from a longest
+// common subsequence: unchanged lines are DiffSame, and removals come
+// before the additions that replace them.
+func DiffLines(before, after string) []DiffLine {
+	a, b := splitLines(before), splitLines(after)
+
+	// lcs[i][j] is the common subsequence length of a[i:] and b[j:].
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var out []DiffLine
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			out = append(out, DiffLine{DiffSame, a[i]})
+			i++
+			j++
+		case j == len(b) || (i < len(a) && lcs[i+1][j] >= lcs[i][j+1]):
+			out = append(out, DiffLine{DiffRemove, a[i]})
+			i++
+		default:
+			out = append(out, DiffLine{DiffAdd, b[j]})
+			j++
+		}
+	}
+	return out
+}
+
+// DiffChanged reports whether a diff has any added or removed line.
+func DiffChanged(lines []DiffLine) bool {
+	for _, l := range lines {
+		if l.Op != DiffSame {
+			return true
+		}
+	}
+	return false
+}
+
+// DiffContext is how many unchanged lines a hunk shows around a change.
+const DiffContext = 3
+
+// DiffHunk is a run of changes with the unchanged lines around them, as in
+// a unified diff. Starts are 1-based line numbers.
+type DiffHunk struct {
+	OldStart, OldLines int
+	NewStart, NewLines int
+	Lines              []DiffLine
+}
+
+// Header is the hunk's unified diff header, e.g. "@@ -3,7 +3,8 @@".
+func (h DiffHunk) Header() string {
+	return fmt.Sprintf("@@ -%s +%s @@", hunkRange(h.OldStart, h.OldLines), hunkRange(h.NewStart, h.NewLines))
+}
+
+// hunkRange formats one side of a hunk header. A side with no lines names
+// the line before it, as diff -u does.
+func hunkRange(start, n int) string {
+	if n == 0 {
+		start--
+	}
+	if n == 1 {
+		return fmt.Sprint(start)
+	}
+	return fmt.Sprintf("%d,%d", start, n)
+}
+
+// DiffHunks groups a diff into hunks with context unchanged lines on each
+// side. Changes closer than twice the context share a hunk; unchanged
+// stretches between hunks are left out.
+func DiffHunks(lines []DiffLine, context int) []DiffHunk {
+	var hunks []DiffHunk
+	oldLine, newLine := 1, 1 // line numbers at lines[i]
+	oldAt := make([]int, len(lines))
+	newAt := make([]int, len(lines))
+	for i, l := range lines {
+		oldAt[i], newAt[i] = oldLine, newLine
+		if l.Op != DiffAdd {
+			oldLine++
+		}
+		if l.Op != DiffRemove {
+			newLine++
+		}
+	}
+
+	for i := 0; i < len(lines); {
+		if lines[i].Op == DiffSame {
+			i++
+			continue
+		}
+		start := max(0, i-context)
+		end := i // one past the last change in the hunk
+		for j := i; j < len(lines) && j < end+2*context+1; j++ {
+			if lines[j].Op != DiffSame {
+				end = j + 1
+			}
+		}
+		stop := min(len(lines), end+context)
+		h := DiffHunk{OldStart: oldAt[start], NewStart: newAt[start], Lines: lines[start:stop]}
+		for _, l := range h.Lines {
+			if l.Op != DiffAdd {
+				h.OldLines++
+			}
+			if l.Op != DiffRemove {
+				h.NewLines++
+			}
+		}
+		hunks = append(hunks, h)
+		i = stop
+	}
+	return hunks
+}
+
+// splitLines splits s into lines, ignoring one trailing newline. Empty
+// text has no lines.
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
@@ -0,0 +1,84 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestDiffLines(t *testing.T) {
+	render := func(lines []DiffLine) string {
+		var b strings.Builder
+		for _, l := range lines {
+			b.WriteByte(l.Op)
+			b.WriteString(l.Text)
+			b.WriteByte('|')
+		}
+		return b.String()
+	}
+	tests := []struct {
+		name          string
+		before, after string
+		want          string
+	}{
+		{"both empty", "", "", ""},
+		{"added", "", "a\nb\n", "+a|+b|"},
+		{"removed", "a\nb", "", "-a|-b|"},
+		{"unchanged", "a\nb", "a\nb\n", " a| b|"},
+		{"replaced line", "a\nb\nc", "a\nB\nc", " a|-b|+B| c|"},
+		{"inserted and dropped", "a\nb\nc", "x\na\nc\nd", "+x| a|-b| c|+d|"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lines := DiffLines(tt.before, tt.after)
+			if got := render(lines); got != tt.want {
+				t.Errorf("DiffLines() = %q, want %q", got, tt.want)
+			}
+			if changed := DiffChanged(lines); changed != (tt.before != tt.after && strings.TrimSuffix(tt.before, "\n") != strings.TrimSuffix(tt.after, "\n")) {
+				t.Errorf("DiffChanged() = %v", changed)
+			}
+		})
+	}
+}
+
+func TestDiffHunks(t *testing.T) {
+	numbered := func(from, to int, edit map[int]string) string {
+		var b strings.Builder
+		for i := from; i <= to; i++ {
+			if s, ok := edit[i]; ok {
+				if s != "" {
+					b.WriteString(s + "\n")
+				}
+				continue
+			}
+			fmt.Fprintf(&b, "line %d\n", i)
+		}
+		return b.String()
+	}
+	before := numbered(1, 30, nil)
+	tests := []struct {
+		name  string
+		after string
+		want  []string // hunk headers
+	}{
+		{"unchanged", before, nil},
+		{"one change", numbered(1, 30, map[int]string{10: "changed"}), []string{"@@ -7,7 +7,7 @@"}},
+		{"near the top", numbered(1, 30, map[int]string{1: "changed"}), []string{"@@ -1,4 +1,4 @@"}},
+		{"close changes merge", numbered(1, 30, map[int]string{10: "a", 16: "b"}), []string{"@@ -7,13 +7,13 @@"}},
+		{"distant changes split", numbered(1, 30, map[int]string{5: "a", 25: "b"}), []string{"@@ -2,7 +2,7 @@", "@@ -22,7 +22,7 @@"}},
+		{"removed line", numbered(1, 30, map[int]string{15: ""}), []string{"@@ -12,7 +12,6 @@"}},
+		{"appended", before + "line 31\n", []string{"@@ -28,3 +28,4 @@"}},
+		{"into empty", "", []string{"@@ -1,30 +0,0 @@"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, h := range DiffHunks(DiffLines(before, tt.after), DiffContext) {
+				got = append(got, h.Header())
+			}
+			if strings.Join(got, " ") != strings.Join(tt.want, " ") {
+				t.Errorf("DiffHunks() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
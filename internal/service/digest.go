@@ -0,0 +1,291 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"hawkeye-cli/internal/api"
+)
+
+// ─── Digest ─────────────────────────────────────────────────────────────────
+//
+// `hawkeye digest` rolls the sessions of the last day (or --since) up into
+// something to paste into standup notes: incidents that came in,
+// investigations that finished, their root causes with similar ones
+// clustered, the time saved and the action items still open.
+
+// digestTopCauses is how many root cause clusters a digest lists.
+const digestTopCauses = 5
+
+// DigestEntry is a session listed in a digest.
+type DigestEntry struct {
+	SessionUUID      string  `json:"session_uuid"`
+	Name             string  `json:"name,omitempty"`
+	Status           string  `json:"status"`
+	RootCause        string  `json:"root_cause,omitempty"`
+	TimeSavedMinutes float64 `json:"time_saved_minutes,omitempty"`
+}
+
+// RootCauseCluster is a group of investigations with similar root causes.
+type RootCauseCluster struct {
+	RootCause string   `json:"root_cause"` // of the first session listed in the cluster
+	Sessions  []string `json:"sessions"`
+}
+
+// Digest is the rollup of a time window.
+type Digest struct {
+	Since            time.Time          `json:"since"`
+	Until            time.Time          `json:"until"`
+	NewIncidents     []DigestEntry      `json:"new_incidents"`
+	Completed        []DigestEntry      `json:"completed"`
+	RootCauses       []RootCauseCluster `json:"root_causes"`
+	TimeSavedMinutes float64            `json:"time_saved_minutes"`
+	OpenActions      []ActionItem       `json:"open_actions"`
+}
+
+// DigestSessions keeps the sessions created or updated at or after since.
+// Sessions with unparseable times are kept.
+func DigestSessions(sessions []api.SessionInfo, since time.Time) []api.SessionInfo {
+	var out []api.SessionInfo
+	for _, s := range sessions {
+		created, err1 := parseAPITime(s.CreateTime)
+		updated, err2 := parseAPITime(s.LastUpdate)
+		if err1 == nil && created.Before(since) && (err2 != nil || updated.Before(since)) {
+			continue
+		}
+		out = append(out, s)
+	}
+	return out
+}
+
+// InvestigationFinished reports whether a session's investigation has
+// completed.
+func InvestigationFinished(s api.SessionInfo) bool {
+	return s.InvestigationStatus == "INVESTIGATION_STATUS_COMPLETED" || s.InvestigationStatus == "INVESTIGATION_STATUS_INVESTIGATED"
+}
+
+// RootCause returns the one-line root cause of a summary: the short
+// analysis, or else the first sentence of the full one.
+func RootCause(s *api.SessionSummary) string {
+	if s == nil {
+		return ""
+	}
+	if s.ShortSummary != nil && strings.TrimSpace(s.ShortSummary.Analysis) != "" {
+		return strings.TrimSpace(s.ShortSummary.Analysis)
+	}
+	analysis := strings.TrimSpace(s.Analysis)
+	if i := strings.IndexAny(analysis, ".\n"); i > 0 {
+		analysis = analysis[:i]
+	}
+	return analysis
+}
+
+// BuildDigest rolls up the sessions of a window, as kept by DigestSessions.
+// summaries holds the summaries of finished investigations by session;
+// done reports action items already marked done, and may be nil.
+func BuildDigest(sessions []api.SessionInfo, summaries map[string]*api.SessionSummary, done func(key string) bool, since, until time.Time) Digest {
+	d := Digest{Since: since, Until: until}
+	for _, s := range sessions {
+		entry := DigestEntry{SessionUUID: s.SessionUUID, Name: s.Name, Status: s.InvestigationStatus}
+		summary := summaries[s.SessionUUID]
+		if InvestigationFinished(s) {
+			entry.RootCause = RootCause(summary)
+			if summary != nil && summary.TimeSaved != nil {
+				entry.TimeSavedMinutes = summary.TimeSaved.TimeSavedMinutes
+			}
+		}
+		if created, err := parseAPITime(s.CreateTime); s.SessionType == "SESSION_TYPE_INCIDENT" && (err != nil || !created.Before(since)) {
+			d.NewIncidents = append(d.NewIncidents, entry)
+		}
+		if !InvestigationFinished(s) {
+			continue
+		}
+		d.Completed = append(d.Completed, entry)
+		d.TimeSavedMinutes += entry.TimeSavedMinutes
+		if summary == nil {
+			continue
+		}
+		for _, it := range SessionActionItems(s.SessionUUID, s.Name, summary.ActionItems) {
+			if done == nil || !done(it.Key) {
+				d.OpenActions = append(d.OpenActions, it)
+			}
+		}
+	}
+	d.RootCauses = ClusterRootCauses(d.Completed)
+	if len(d.RootCauses) > digestTopCauses {
+		d.RootCauses = d.RootCauses[:digestTopCauses]
+	}
+	return d
+}
+
+// rootCauseSimilarity is how alike two root causes must be, as the Jaccard
+// similarity of their words, to share a cluster.
+const rootCauseSimilarity = 0.4
+
+// ClusterRootCauses groups entries whose root causes share most of their
+// words, largest cluster first. Entries without a root cause are left out.
+func ClusterRootCauses(entries []DigestEntry) []RootCauseCluster {
+	type cluster struct {
+		RootCauseCluster
+		words map[string]bool
+	}
+	var clusters []*cluster
+	for _, e := range entries {
+		words := rootCauseWords(e.RootCause)
+		if len(words) == 0 {
+			continue
+		}
+		var best *cluster
+		bestScore := rootCauseSimilarity
+		for _, c := range clusters {
+			if score := jaccard(words, c.words); score >= bestScore {
+				best, bestScore = c, score
+			}
+		}
+		if best == nil {
+			best = &cluster{RootCauseCluster: RootCauseCluster{RootCause: e.RootCause}, words: words}
+			clusters = append(clusters, best)
+		}
+		best.Sessions = append(best.Sessions, e.SessionUUID)
+	}
+	sort.SliceStable(clusters, func(i, j int) bool { return len(clusters[i].Sessions) > len(clusters[j].Sessions) })
+	out := make([]RootCauseCluster, len(clusters))
+	for i, c := range clusters {
+		out[i] = c.RootCauseCluster
+	}
+	return out
+}
+
+// rootCauseStopWords carry no meaning for telling root causes apart.
+var rootCauseStopWords = map[string]bool{
+	"the": true, "and": true, "for": true, "was": true, "were": true, "are": true, "with": true,
+	"from": true, "after": true, "due": true, "that": true, "this": true, "into": true, "caused": true,
+	"because": true, "which": true, "has": true, "have": true, "been": true, "its": true, "not": true,
+}
+
+// rootCauseWords returns the distinct significant words of s, lowercased
+// and with a plural s dropped.
+func rootCauseWords(s string) map[string]bool {
+	words := map[string]bool{}
+	for _, w := range strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		if len(w) < 3 || rootCauseStopWords[w] {
+			continue
+		}
+		if len(w) > 3 && strings.HasSuffix(w, "s") && !strings.HasSuffix(w, "ss") {
+			w = w[:len(w)-1]
+		}
+		words[w] = true
+	}
+	return words
+}
+
+func jaccard(a, b map[string]bool) float64 {
+	shared := 0
+	for w := range a {
+		if b[w] {
+			shared++
+		}
+	}
+	union := len(a) + len(b) - shared
+	if union == 0 {
+		return 0
+	}
+	return float64(shared) / float64(union)
+}
+
+// FormatTimeSaved renders minutes saved as "45 min" or "3.5 hrs".
+func FormatTimeSaved(minutes float64) string {
+	if minutes < 60 {
+		return fmt.Sprintf("%.0f min", minutes)
+	}
+	return fmt.Sprintf("%.1f hrs", minutes/60)
+}
+
+// StatusWord returns an investigation status as a lowercase word, such as
+// "not started".
+func StatusWord(status string) string {
+	if status == "INVESTIGATION_STATUS_INVESTIGATED" {
+		return "completed"
+	}
+	word := strings.TrimPrefix(status, "INVESTIGATION_STATUS_")
+	if word == "" {
+		return "unknown"
+	}
+	return strings.ReplaceAll(strings.ToLower(word), "_", " ")
+}
+
+// FormatDigestMarkdown renders a digest as markdown for standup notes.
+func FormatDigestMarkdown(d Digest, consoleURL func(sessionUUID string) string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Hawkeye digest: %s – %s\n\n", d.Since.Format("Jan 2 15:04"), d.Until.Format("Jan 2 15:04"))
+	fmt.Fprintf(&b, "**%s · %s · %s saved · %s**\n",
+		countOf(len(d.NewIncidents), "new incident", "new incidents"),
+		countOf(len(d.Completed), "investigation completed", "investigations completed"),
+		FormatTimeSaved(d.TimeSavedMinutes),
+		countOf(len(d.OpenActions), "open action item", "open action items"))
+
+	link := func(e DigestEntry) string {
+		name := e.Name
+		if name == "" {
+			name = e.SessionUUID
+		}
+		if url := consoleURL(e.SessionUUID); url != "" {
+			return fmt.Sprintf("[%s](%s)", name, url)
+		}
+		return name
+	}
+
+	if len(d.NewIncidents) > 0 {
+		b.WriteString("\n## New incidents\n\n")
+		for _, e := range d.NewIncidents {
+			fmt.Fprintf(&b, "- %s — %s\n", link(e), StatusWord(e.Status))
+		}
+	}
+	if len(d.Completed) > 0 {
+		b.WriteString("\n## Investigations completed\n\n")
+		for _, e := range d.Completed {
+			line := "- " + link(e)
+			if e.RootCause != "" {
+				line += ": " + e.RootCause
+			}
+			if e.TimeSavedMinutes > 0 {
+				line += fmt.Sprintf(" (saved %s)", FormatTimeSaved(e.TimeSavedMinutes))
+			}
+			b.WriteString(line + "\n")
+		}
+	}
+	if len(d.RootCauses) > 0 {
+		b.WriteString("\n## Top root causes\n\n")
+		for i, c := range d.RootCauses {
+			fmt.Fprintf(&b, "%d. %s", i+1, c.RootCause)
+			if len(c.Sessions) > 1 {
+				fmt.Fprintf(&b, " (%d investigations)", len(c.Sessions))
+			}
+			b.WriteString("\n")
+		}
+	}
+	if len(d.OpenActions) > 0 {
+		b.WriteString("\n## Open action items\n\n")
+		for _, it := range d.OpenActions {
+			name := it.SessionName
+			if name == "" {
+				name = it.SessionUUID
+			}
+			fmt.Fprintf(&b, "- [ ] %s (%s)\n", it.Text, name)
+		}
+	}
+	return b.String()
+}
+
+// countOf renders n with the singular or plural noun.
+func countOf(n int, one, many string) string {
+	if n == 1 {
+		return "1 " + one
+	}
+	return fmt.Sprintf("%d %s", n, many)
+}
@@ -0,0 +1,105 @@
+package service
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"hawkeye-cli/internal/api"
+)
+
+func TestDigestSessions(t *testing.T) {
+	since := time.Date(2026, 10, 15, 0, 0, 0, 0, time.UTC)
+	got := DigestSessions([]api.SessionInfo{
+		{SessionUUID: "new", CreateTime: "2026-10-15T09:00:00Z"},
+		{SessionUUID: "updated", CreateTime: "2026-10-10T09:00:00Z", LastUpdate: "2026-10-15T10:00:00Z"},
+		{SessionUUID: "old", CreateTime: "2026-10-10T09:00:00Z", LastUpdate: "2026-10-11T09:00:00Z"},
+		{SessionUUID: "unknown"},
+	}, since)
+	var uuids []string
+	for _, s := range got {
+		uuids = append(uuids, s.SessionUUID)
+	}
+	if strings.Join(uuids, ",") != "new,updated,unknown" {
+		t.Errorf("DigestSessions = %v", uuids)
+	}
+}
+
+func TestRootCause(t *testing.T) {
+	if got := RootCause(&api.SessionSummary{Analysis: "Pool exhausted. Details follow.", ShortSummary: &api.ShortSessionSummary{Analysis: " pool exhausted "}}); got != "pool exhausted" {
+		t.Errorf("short analysis = %q", got)
+	}
+	if got := RootCause(&api.SessionSummary{Analysis: "Pool exhausted. Details follow."}); got != "Pool exhausted" {
+		t.Errorf("first sentence = %q", got)
+	}
+	if RootCause(nil) != "" {
+		t.Error("nil summary should have no root cause")
+	}
+}
+
+func TestClusterRootCauses(t *testing.T) {
+	clusters := ClusterRootCauses([]DigestEntry{
+		{SessionUUID: "a", RootCause: "Disk full on the logging node"},
+		{SessionUUID: "b", RootCause: "orders-db connection pool exhausted after deploy"},
+		{SessionUUID: "c", RootCause: "Connection pools exhausted on orders-db"},
+		{SessionUUID: "d", RootCause: ""},
+		{SessionUUID: "e", RootCause: "orders-db connection pool exhausted"},
+	})
+	if len(clusters) != 2 {
+		t.Fatalf("clusters = %+v", clusters)
+	}
+	if c := clusters[0]; strings.Join(c.Sessions, ",") != "b,c,e" || !strings.HasPrefix(c.RootCause, "orders-db") {
+		t.Errorf("largest cluster = %+v", c)
+	}
+	if c := clusters[1]; strings.Join(c.Sessions, ",") != "a" {
+		t.Errorf("second cluster = %+v", c)
+	}
+}
+
+func TestBuildDigest(t *testing.T) {
+	since := time.Date(2026, 10, 15, 0, 0, 0, 0, time.UTC)
+	until := since.Add(24 * time.Hour)
+	sessions := []api.SessionInfo{
+		{SessionUUID: "i1", Name: "[P1] payments 5xx", SessionType: "SESSION_TYPE_INCIDENT", CreateTime: "2026-10-15T08:00:00Z", InvestigationStatus: "INVESTIGATION_STATUS_COMPLETED"},
+		{SessionUUID: "i2", Name: "disk alert", SessionType: "SESSION_TYPE_INCIDENT", CreateTime: "2026-10-15T09:00:00Z", InvestigationStatus: "INVESTIGATION_STATUS_NOT_STARTED"},
+		{SessionUUID: "c1", Name: "Why is checkout slow?", SessionType: "SESSION_TYPE_CHAT", CreateTime: "2026-10-12T08:00:00Z", LastUpdate: "2026-10-15T10:00:00Z", InvestigationStatus: "INVESTIGATION_STATUS_INVESTIGATED"},
+	}
+	summaries := map[string]*api.SessionSummary{
+		"i1": {Analysis: "Payments pool exhausted.", ActionItems: []string{"Raise the pool size", "Add an alert"}, TimeSaved: &api.TimeSavedSummary{TimeSavedMinutes: 40}},
+		"c1": {Analysis: "Payments pool exhausted.", TimeSaved: &api.TimeSavedSummary{TimeSavedMinutes: 50}},
+	}
+	done := func(key string) bool { return key == ActionKey("i1", "Add an alert") }
+
+	d := BuildDigest(sessions, summaries, done, since, until)
+	if len(d.NewIncidents) != 2 || len(d.Completed) != 2 {
+		t.Fatalf("new = %+v, completed = %+v", d.NewIncidents, d.Completed)
+	}
+	if d.TimeSavedMinutes != 90 {
+		t.Errorf("time saved = %v, want 90", d.TimeSavedMinutes)
+	}
+	if len(d.OpenActions) != 1 || d.OpenActions[0].Text != "Raise the pool size" {
+		t.Errorf("open actions = %+v", d.OpenActions)
+	}
+	if len(d.RootCauses) != 1 || len(d.RootCauses[0].Sessions) != 2 {
+		t.Errorf("root causes = %+v", d.RootCauses)
+	}
+
+	md := FormatDigestMarkdown(d, func(uuid string) string { return "https://x/session/" + uuid })
+	for _, want := range []string{
+		"# Hawkeye digest: Oct 15 00:00 – Oct 16 00:00",
+		"**2 new incidents · 2 investigations completed · 1.5 hrs saved · 1 open action item**",
+		"- [disk alert](https://x/session/i2) — not started",
+		"- [Why is checkout slow?](https://x/session/c1): Payments pool exhausted (saved 50 min)",
+		"1. Payments pool exhausted (2 investigations)",
+		"- [ ] Raise the pool size ([P1] payments 5xx)",
+	} {
+		if !strings.Contains(md, want) {
+			t.Errorf("markdown missing %q:\n%s", want, md)
+		}
+	}
+
+	empty := FormatDigestMarkdown(BuildDigest(nil, nil, nil, since, until), func(string) string { return "" })
+	if !strings.Contains(empty, "0 new incidents · 0 investigations completed · 0 min saved · 0 open action items") || strings.Contains(empty, "##") {
+		t.Errorf("empty digest:\n%s", empty)
+	}
+}
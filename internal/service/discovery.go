@@ -1,6 +1,8 @@
 package service
 
 import (
+	"sort"
+
 	"hawkeye-cli/internal/api"
 )
 
@@ -28,10 +30,49 @@ func FormatDiscoveredResources(resources []api.ResourceSpec) []DiscoveredResourc
 	return result
 }
 
-// ResourceType describes a telemetry resource type.
+// ResourceType describes a telemetry resource type. The connection type,
+// telemetry type and capabilities are only known for types the server
+// reported.
 type ResourceType struct {
-	Type        string
-	Description string
+	Type           string
+	Description    string
+	ConnectionType string   `json:",omitempty"`
+	TelemetryType  string   `json:",omitempty"`
+	Capabilities   []string `json:",omitempty"`
+}
+
+// ResourceTypesFromServer converts the server's resource type matrix,
+// keeping the entries for connectionType and telemetryType (when set) in
+// case the server did not filter, ordered by connection, telemetry type
+// and type.
+func ResourceTypesFromServer(specs []api.ResourceTypeSpec, connectionType, telemetryType string) []ResourceType {
+	var result []ResourceType
+	for _, s := range specs {
+		if connectionType != "" && s.ConnectionType != connectionType {
+			continue
+		}
+		if telemetryType != "" && s.TelemetryType != telemetryType {
+			continue
+		}
+		result = append(result, ResourceType{
+			Type:           s.Type,
+			Description:    s.Description,
+			ConnectionType: s.ConnectionType,
+			TelemetryType:  s.TelemetryType,
+			Capabilities:   s.Capabilities,
+		})
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		a, b := result[i], result[j]
+		if a.ConnectionType != b.ConnectionType {
+			return a.ConnectionType < b.ConnectionType
+		}
+		if a.TelemetryType != b.TelemetryType {
+			return a.TelemetryType < b.TelemetryType
+		}
+		return a.Type < b.Type
+	})
+	return result
 }
 
 // GetResourceTypes returns the resource types for a given connection type and telemetry type.
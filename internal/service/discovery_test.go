@@ -1,6 +1,7 @@
 package service
 
 import (
+	"strings"
 	"testing"
 
 	"hawkeye-cli/internal/api"
@@ -61,3 +62,28 @@ func TestGetResourceTypes(t *testing.T) {
 		}
 	})
 }
+
+func TestResourceTypesFromServer(t *testing.T) {
+	specs := []api.ResourceTypeSpec{
+		{ConnectionType: "datadog", TelemetryType: "metric", Type: "datadog_metric"},
+		{ConnectionType: "aws", TelemetryType: "metric", Type: "cloudwatch_metric", Capabilities: []string{"query"}},
+		{ConnectionType: "aws", TelemetryType: "log", Type: "cloudwatch_log_group"},
+	}
+
+	all := ResourceTypesFromServer(specs, "", "")
+	var order []string
+	for _, rt := range all {
+		order = append(order, rt.Type)
+	}
+	if want := "cloudwatch_log_group,cloudwatch_metric,datadog_metric"; strings.Join(order, ",") != want {
+		t.Errorf("order = %v, want %s", order, want)
+	}
+
+	got := ResourceTypesFromServer(specs, "aws", "metric")
+	if len(got) != 1 || got[0].Type != "cloudwatch_metric" || len(got[0].Capabilities) != 1 {
+		t.Errorf("ResourceTypesFromServer(aws, metric) = %+v", got)
+	}
+	if got := ResourceTypesFromServer(specs, "prometheus", ""); got != nil {
+		t.Errorf("ResourceTypesFromServer(prometheus) = %+v, want nil", got)
+	}
+}
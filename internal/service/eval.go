@@ -0,0 +1,221 @@
+package service
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// EvalSuite is a set of golden questions consumed by `hawkeye eval run`.
+//
+//	name: checkout
+//	cases:
+//	  - name: db-latency
+//	    prompt: Why is checkout slow?
+//	    expect:
+//	      contains: [connection pool]
+//	      matches: ["pool (size|exhaust)"]
+//	      min_accuracy: 80
+type EvalSuite struct {
+	Name    string     `yaml:"name" json:"name"`
+	Project string     `yaml:"project,omitempty" json:"project,omitempty"`
+	Cases   []EvalCase `yaml:"cases" json:"cases"`
+}
+
+// EvalCase is one prompt and the expectations its answer must meet.
+type EvalCase struct {
+	Name   string     `yaml:"name" json:"name"`
+	Prompt string     `yaml:"prompt" json:"prompt"`
+	Expect EvalExpect `yaml:"expect,omitempty" json:"expect,omitempty"`
+}
+
+// EvalExpect lists assertions on an answer. Text checks are
+// case-insensitive; score minima require the session to be scored.
+type EvalExpect struct {
+	Contains        []string `yaml:"contains,omitempty" json:"contains,omitempty"`
+	NotContains     []string `yaml:"not_contains,omitempty" json:"not_contains,omitempty"`
+	Matches         []string `yaml:"matches,omitempty" json:"matches,omitempty"`
+	MinAccuracy     float64  `yaml:"min_accuracy,omitempty" json:"min_accuracy,omitempty"`
+	MinCompleteness float64  `yaml:"min_completeness,omitempty" json:"min_completeness,omitempty"`
+}
+
+// NeedsScores reports whether the expectations include score minima.
+func (e EvalExpect) NeedsScores() bool {
+	return e.MinAccuracy > 0 || e.MinCompleteness > 0
+}
+
+// ParseEvalSuite decodes and validates a YAML eval suite.
+func ParseEvalSuite(data []byte) (EvalSuite, error) {
+	var s EvalSuite
+	if err := yaml.Unmarshal(data, &s); err != nil {
+		return s, fmt.Errorf("parsing suite: %w", err)
+	}
+	if len(s.Cases) == 0 {
+		return s, fmt.Errorf("suite has no cases")
+	}
+	seen := make(map[string]bool)
+	for i := range s.Cases {
+		c := &s.Cases[i]
+		if strings.TrimSpace(c.Prompt) == "" {
+			return s, fmt.Errorf("case %d: prompt is required", i+1)
+		}
+		if c.Name == "" {
+			c.Name = fmt.Sprintf("case-%d", i+1)
+		}
+		if seen[c.Name] {
+			return s, fmt.Errorf("duplicate case name %q", c.Name)
+		}
+		seen[c.Name] = true
+		for _, expr := range c.Expect.Matches {
+			if _, err := regexp.Compile("(?i)" + expr); err != nil {
+				return s, fmt.Errorf("case %q: invalid regex %q: %w", c.Name, expr, err)
+			}
+		}
+		for _, v := range []float64{c.Expect.MinAccuracy, c.Expect.MinCompleteness} {
+			if v < 0 || v > 100 {
+				return s, fmt.Errorf("case %q: score minimum %v is outside 0-100", c.Name, v)
+			}
+		}
+	}
+	return s, nil
+}
+
+// CheckAnswer returns a description of every text expectation the answer
+// fails. Regexes are assumed valid (see ParseEvalSuite).
+func (e EvalExpect) CheckAnswer(answer string) []string {
+	var failures []string
+	lower := strings.ToLower(answer)
+	for _, want := range e.Contains {
+		if !strings.Contains(lower, strings.ToLower(want)) {
+			failures = append(failures, fmt.Sprintf("missing %q", want))
+		}
+	}
+	for _, unwanted := range e.NotContains {
+		if strings.Contains(lower, strings.ToLower(unwanted)) {
+			failures = append(failures, fmt.Sprintf("unexpected %q", unwanted))
+		}
+	}
+	for _, expr := range e.Matches {
+		if !regexp.MustCompile("(?i)" + expr).MatchString(answer) {
+			failures = append(failures, fmt.Sprintf("no match for /%s/", expr))
+		}
+	}
+	return failures
+}
+
+// EvalRunner runs investigations for the harness; main wires it to the API.
+type EvalRunner interface {
+	// Investigate runs prompt in a new session and returns its final answer.
+	Investigate(prompt string) (sessionUUID, answer string, err error)
+	// Scores waits for a session's RCA scores.
+	Scores(sessionUUID string) (ScoreDisplay, error)
+}
+
+// EvalCaseResult is the outcome of one case.
+type EvalCaseResult struct {
+	Name         string        `json:"name"`
+	Prompt       string        `json:"prompt"`
+	SessionUUID  string        `json:"session_uuid,omitempty"`
+	Passed       bool          `json:"passed"`
+	Failures     []string      `json:"failures,omitempty"`
+	Error        string        `json:"error,omitempty"`
+	Answer       string        `json:"answer,omitempty"`
+	Accuracy     *float64      `json:"accuracy,omitempty"`
+	Completeness *float64      `json:"completeness,omitempty"`
+	Duration     time.Duration `json:"duration_ns"`
+}
+
+// EvalReport is the outcome of a suite run.
+type EvalReport struct {
+	Suite    string           `json:"suite"`
+	Results  []EvalCaseResult `json:"results"`
+	Passed   int              `json:"passed"`
+	Failed   int              `json:"failed"`
+	Duration time.Duration    `json:"duration_ns"`
+}
+
+// RunEval runs every case in order. progress, if set, is called after
+// each case so callers can report as the suite goes.
+func RunEval(suite EvalSuite, runner EvalRunner, progress func(EvalCaseResult)) EvalReport {
+	report := EvalReport{Suite: suite.Name}
+	start := time.Now()
+	for _, c := range suite.Cases {
+		r := runEvalCase(c, runner)
+		if r.Passed {
+			report.Passed++
+		} else {
+			report.Failed++
+		}
+		report.Results = append(report.Results, r)
+		if progress != nil {
+			progress(r)
+		}
+	}
+	report.Duration = time.Since(start)
+	return report
+}
+
+func runEvalCase(c EvalCase, runner EvalRunner) EvalCaseResult {
+	r := EvalCaseResult{Name: c.Name, Prompt: c.Prompt}
+	start := time.Now()
+	defer func() { r.Duration = time.Since(start) }()
+
+	sessionUUID, answer, err := runner.Investigate(c.Prompt)
+	r.SessionUUID = sessionUUID
+	r.Answer = answer
+	if err != nil {
+		r.Error = err.Error()
+		return r
+	}
+	r.Failures = c.Expect.CheckAnswer(answer)
+
+	if c.Expect.NeedsScores() {
+		scores, err := runner.Scores(sessionUUID)
+		if err != nil {
+			r.Error = err.Error()
+			return r
+		}
+		if scores.HasScores {
+			r.Accuracy = &scores.Accuracy.Score
+			r.Completeness = &scores.Completeness.Score
+		}
+		t := ScoreThresholds{MinAccuracy: c.Expect.MinAccuracy, MinCompleteness: c.Expect.MinCompleteness}
+		r.Failures = append(r.Failures, t.Check(scores)...)
+	}
+	r.Passed = len(r.Failures) == 0
+	return r
+}
+
+// CheckReport converts an eval run for the shared JUnit/SARIF encoders.
+// source is the suite file, used as the SARIF result location.
+func (r EvalReport) CheckReport(source string) CheckReport {
+	name := r.Suite
+	if name == "" {
+		name = "hawkeye-eval"
+	}
+	report := CheckReport{Name: name, Source: source, Duration: r.Duration}
+	for _, res := range r.Results {
+		c := CheckResult{
+			ID:       res.Name,
+			Name:     res.Name,
+			Status:   CheckPassed,
+			Details:  fmt.Sprintf("session: %s\nprompt: %s", res.SessionUUID, res.Prompt),
+			Duration: res.Duration,
+		}
+		switch {
+		case res.Error != "":
+			c.Status = CheckError
+			c.Message = res.Error
+		case !res.Passed:
+			c.Status = CheckFailed
+			c.Message = strings.Join(res.Failures, "; ")
+		default:
+			c.Message = "all expectations met"
+		}
+		report.Checks = append(report.Checks, c)
+	}
+	return report
+}
@@ -0,0 +1,128 @@
+package service
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParseEvalSuite(t *testing.T) {
+	tests := []struct {
+		name    string
+		yaml    string
+		wantErr string
+	}{
+		{"valid", "name: s\ncases:\n  - prompt: why?\n    expect:\n      contains: [db]\n", ""},
+		{"no cases", "name: s\n", "no cases"},
+		{"missing prompt", "cases:\n  - name: a\n", "prompt is required"},
+		{"duplicate names", "cases:\n  - {name: a, prompt: x}\n  - {name: a, prompt: y}\n", "duplicate case name"},
+		{"bad regex", "cases:\n  - prompt: x\n    expect: {matches: ['(']}\n", "invalid regex"},
+		{"score out of range", "cases:\n  - prompt: x\n    expect: {min_accuracy: 120}\n", "outside 0-100"},
+		{"bad yaml", "cases: [", "parsing suite"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseEvalSuite([]byte(tt.yaml))
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("ParseEvalSuite() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ParseEvalSuite() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+
+	s, _ := ParseEvalSuite([]byte("cases:\n  - prompt: x\n  - prompt: y\n"))
+	if s.Cases[0].Name != "case-1" || s.Cases[1].Name != "case-2" {
+		t.Errorf("default names = %q, %q", s.Cases[0].Name, s.Cases[1].Name)
+	}
+}
+
+func TestEvalExpectCheckAnswer(t *testing.T) {
+	e := EvalExpect{
+		Contains:    []string{"Connection Pool", "postgres"},
+		NotContains: []string{"unknown"},
+		Matches:     []string{`pool (size|exhaust)`},
+	}
+	if got := e.CheckAnswer("Connection pool exhausted on postgres."); len(got) != 0 {
+		t.Errorf("CheckAnswer() = %v, want no failures", got)
+	}
+	got := e.CheckAnswer("Root cause unknown.")
+	want := []string{`missing "Connection Pool"`, `missing "postgres"`, `unexpected "unknown"`, "no match for /pool (size|exhaust)/"}
+	if strings.Join(got, "; ") != strings.Join(want, "; ") {
+		t.Errorf("CheckAnswer() = %v, want %v", got, want)
+	}
+}
+
+type fakeEvalRunner struct {
+	answers map[string]string
+	scores  ScoreDisplay
+	err     error
+}
+
+func (f *fakeEvalRunner) Investigate(prompt string) (string, string, error) {
+	if f.err != nil {
+		return "", "", f.err
+	}
+	return "sess-" + prompt, f.answers[prompt], nil
+}
+
+func (f *fakeEvalRunner) Scores(string) (ScoreDisplay, error) { return f.scores, nil }
+
+func TestRunEval(t *testing.T) {
+	suite := EvalSuite{Name: "golden", Cases: []EvalCase{
+		{Name: "pass", Prompt: "a", Expect: EvalExpect{Contains: []string{"pool"}}},
+		{Name: "text-fail", Prompt: "b", Expect: EvalExpect{Contains: []string{"pool"}}},
+		{Name: "score-fail", Prompt: "a", Expect: EvalExpect{MinAccuracy: 90}},
+	}}
+	runner := &fakeEvalRunner{
+		answers: map[string]string{"a": "pool exhausted", "b": "no idea"},
+		scores:  ScoreDisplay{HasScores: true, Accuracy: ScoreSectionDisplay{Score: 75}},
+	}
+	var seen []string
+	report := RunEval(suite, runner, func(r EvalCaseResult) { seen = append(seen, r.Name) })
+
+	if report.Passed != 1 || report.Failed != 2 {
+		t.Errorf("passed/failed = %d/%d, want 1/2", report.Passed, report.Failed)
+	}
+	if len(seen) != 3 {
+		t.Errorf("progress called %d times, want 3", len(seen))
+	}
+	if r := report.Results[2]; r.Accuracy == nil || *r.Accuracy != 75 || r.SessionUUID != "sess-a" {
+		t.Errorf("score-fail result = %+v", r)
+	}
+
+	runner.err = errors.New("boom")
+	report = RunEval(suite, runner, nil)
+	if report.Failed != 3 || report.Results[0].Error != "boom" {
+		t.Errorf("error run = %+v", report)
+	}
+}
+
+func TestEvalReportCheckReport(t *testing.T) {
+	report := EvalReport{Suite: "golden", Results: []EvalCaseResult{
+		{Name: "ok", Passed: true, SessionUUID: "s1"},
+		{Name: "bad", Failures: []string{`missing "pool"`, "accuracy 70.0 < 80.0"}},
+		{Name: "broken", Error: "stream error"},
+	}}
+	got := report.CheckReport("suite.yaml")
+	if got.Name != "golden" || got.Source != "suite.yaml" || len(got.Checks) != 3 {
+		t.Fatalf("CheckReport() = %+v", got)
+	}
+	want := []struct{ status, message string }{
+		{CheckPassed, "all expectations met"},
+		{CheckFailed, `missing "pool"; accuracy 70.0 < 80.0`},
+		{CheckError, "stream error"},
+	}
+	for i, w := range want {
+		if c := got.Checks[i]; c.Status != w.status || c.Message != w.message {
+			t.Errorf("check %d = %s %q, want %s %q", i, c.Status, c.Message, w.status, w.message)
+		}
+	}
+	if !strings.Contains(got.Checks[0].Details, "session: s1") {
+		t.Errorf("details = %q", got.Checks[0].Details)
+	}
+}
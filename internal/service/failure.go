@@ -0,0 +1,98 @@
+package service
+
+import (
+	"strings"
+
+	"hawkeye-cli/internal/api"
+)
+
+// ─── Failed investigations ──────────────────────────────────────────────────
+//
+// The backend can give up on an investigation and still end the turn
+// normally, so a stream that returns without error is not proof that the
+// investigation worked. FailureCollector watches the stream for the signs
+// of a failure: an error on the event itself, a failed prompt cycle status,
+// or error messages that no answer followed.
+
+// InvestigationFailure describes why the backend gave up.
+type InvestigationFailure struct {
+	Status string `json:"status,omitempty"`
+	Reason string `json:"reason"`
+}
+
+// FailureCollector records failure signs from a prompt stream without
+// printing anything. Use Handle as the stream callback.
+type FailureCollector struct {
+	status    string
+	eventErr  string
+	lastError string
+	answered  bool
+}
+
+// Handle is a StreamCallback that records failure statuses and errors.
+func (f *FailureCollector) Handle(resp *api.ProcessPromptResponse) {
+	if resp == nil {
+		return
+	}
+	if resp.Error != "" {
+		f.eventErr = resp.Error
+	}
+	msg := resp.Message
+	if msg == nil {
+		return
+	}
+	if IsFailureStatus(msg.Status) {
+		f.status = msg.Status
+	}
+	if msg.Content == nil {
+		return
+	}
+	switch msg.Content.ContentType {
+	case "CONTENT_TYPE_ERROR_MESSAGE":
+		// Query retries report errors too; they only count as a failure
+		// when no answer arrives afterwards.
+		if text := strings.TrimSpace(StripHTML(strings.Join(msg.Content.Parts, "\n"))); text != "" {
+			f.lastError = text
+			f.answered = false
+		}
+	case "CONTENT_TYPE_CHAT_RESPONSE":
+		for _, p := range msg.Content.Parts {
+			if !IsTrivialContent(StripHTML(p)) {
+				f.answered = true
+				break
+			}
+		}
+	}
+}
+
+// Failure returns why the investigation failed, or nil when it did not.
+func (f *FailureCollector) Failure() *InvestigationFailure {
+	unanswered := f.lastError != "" && !f.answered
+	if f.eventErr == "" && f.status == "" && !unanswered {
+		return nil
+	}
+	reason := f.eventErr
+	if reason == "" {
+		reason = f.lastError
+	}
+	if reason == "" {
+		reason = "the backend reported " + FailureStatusLabel(f.status) + " without a reason"
+	}
+	return &InvestigationFailure{Status: f.status, Reason: reason}
+}
+
+// IsFailureStatus reports whether a prompt cycle or message status means
+// the investigation failed, e.g. PROMPT_CYCLE_STATUS_FAILED or "error".
+func IsFailureStatus(status string) bool {
+	s := strings.ToUpper(status)
+	return strings.Contains(s, "FAIL") || strings.HasSuffix(s, "ERROR") || strings.HasSuffix(s, "ABORTED")
+}
+
+// FailureStatusLabel turns a status enum into words:
+// PROMPT_CYCLE_STATUS_FAILED becomes "failed".
+func FailureStatusLabel(status string) string {
+	if i := strings.LastIndex(status, "STATUS_"); i >= 0 {
+		status = status[i+len("STATUS_"):]
+	}
+	return strings.ToLower(strings.ReplaceAll(status, "_", " "))
+}
@@ -0,0 +1,64 @@
+package service
+
+import (
+	"testing"
+
+	"hawkeye-cli/internal/api"
+)
+
+func TestFailureCollector(t *testing.T) {
+	event := func(ct, status string, parts ...string) *api.ProcessPromptResponse {
+		return &api.ProcessPromptResponse{Message: &api.Message{
+			Content: &api.Content{ContentType: ct, Parts: parts},
+			Status:  status,
+		}}
+	}
+
+	// Retried query errors followed by an answer are not a failure.
+	var ok FailureCollector
+	ok.Handle(nil)
+	ok.Handle(event("CONTENT_TYPE_ERROR_MESSAGE", "", "syntax error near SELECT"))
+	ok.Handle(event("CONTENT_TYPE_CHAT_RESPONSE", "", "The pool is saturated."))
+	ok.Handle(&api.ProcessPromptResponse{EventType: "prompt_cycle_end", Message: &api.Message{Status: "PROMPT_CYCLE_STATUS_COMPLETED", EndTurn: true}})
+	if f := ok.Failure(); f != nil {
+		t.Errorf("Failure() = %+v, want nil", f)
+	}
+
+	// An error with no answer after it is.
+	var unanswered FailureCollector
+	unanswered.Handle(event("CONTENT_TYPE_CHAT_RESPONSE", "", "In progress..."))
+	unanswered.Handle(event("CONTENT_TYPE_ERROR_MESSAGE", "", "<b>No telemetry</b> sources available"))
+	if f := unanswered.Failure(); f == nil || f.Reason != "No telemetry sources available" {
+		t.Errorf("Failure() = %+v", f)
+	}
+
+	// A failed status wins even after an answer, and the event error is
+	// preferred as the reason.
+	var failed FailureCollector
+	failed.Handle(event("CONTENT_TYPE_CHAT_RESPONSE", "", "Partial answer"))
+	failed.Handle(&api.ProcessPromptResponse{Error: "LLM quota exceeded", Message: &api.Message{Status: "PROMPT_CYCLE_STATUS_FAILED", EndTurn: true}})
+	if f := failed.Failure(); f == nil || f.Reason != "LLM quota exceeded" || f.Status != "PROMPT_CYCLE_STATUS_FAILED" {
+		t.Errorf("Failure() = %+v", f)
+	}
+
+	var bare FailureCollector
+	bare.Handle(&api.ProcessPromptResponse{Message: &api.Message{Status: "PROMPT_CYCLE_STATUS_FAILED"}})
+	if f := bare.Failure(); f == nil || f.Reason != "the backend reported failed without a reason" {
+		t.Errorf("Failure() = %+v", f)
+	}
+}
+
+func TestIsFailureStatus(t *testing.T) {
+	for status, want := range map[string]bool{
+		"PROMPT_CYCLE_STATUS_FAILED":    true,
+		"failed":                        true,
+		"CHAIN_OF_THOUGHT_STATUS_ERROR": true,
+		"PROMPT_CYCLE_STATUS_COMPLETED": false,
+		"IN_PROGRESS":                   false,
+		"":                              false,
+	} {
+		if got := IsFailureStatus(status); got != want {
+			t.Errorf("IsFailureStatus(%q) = %v, want %v", status, got, want)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"hawkeye-cli/internal/api"
+)
+
+// FanoutResult is the outcome of one project's run in a multi-project
+// investigation.
+type FanoutResult struct {
+	ProjectUUID string `json:"project_uuid"`
+	ProjectName string `json:"project_name,omitempty"`
+	SessionUUID string `json:"session_uuid,omitempty"`
+	Status      string `json:"status"` // BulkStatusCompleted or BulkStatusFailed
+	Summary     string `json:"summary,omitempty"`
+	Answer      string `json:"answer,omitempty"`
+	Error       string `json:"error,omitempty"`
+}
+
+// ResolveFanoutProjects picks the projects a prompt fans out to: every
+// non-system project when all is set, otherwise each comma-separated entry
+// of list matched by UUID or name. A UUID the listing does not include is
+// kept as is, since the caller may still have access to it; an unknown
+// name is an error. Duplicates are dropped.
+func ResolveFanoutProjects(projects []api.ProjectSpec, list string, all bool) ([]api.ProjectSpec, error) {
+	if all {
+		filtered := FilterSystemProjects(projects)
+		if len(filtered) == 0 {
+			return nil, fmt.Errorf("no projects found")
+		}
+		return filtered, nil
+	}
+
+	var out []api.ProjectSpec
+	seen := map[string]bool{}
+	for _, entry := range strings.Split(list, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		p := FindProject(projects, entry)
+		if p == nil {
+			if !looksLikeUUID(entry) {
+				return nil, fmt.Errorf("unknown project %q", entry)
+			}
+			p = &api.ProjectSpec{UUID: entry}
+		}
+		if !seen[p.UUID] {
+			seen[p.UUID] = true
+			out = append(out, *p)
+		}
+	}
+	if len(out) == 0 {
+		return nil, fmt.Errorf("--projects requires at least one project")
+	}
+	return out, nil
+}
+
+// looksLikeUUID reports whether s has the 8-4-4-4-12 shape of a UUID.
+func looksLikeUUID(s string) bool {
+	if len(s) != 36 {
+		return false
+	}
+	for i, r := range s {
+		switch i {
+		case 8, 13, 18, 23:
+			if r != '-' {
+				return false
+			}
+		default:
+			if !strings.ContainsRune("0123456789abcdefABCDEF", r) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// CountFanoutResults returns how many project runs completed and failed.
+func CountFanoutResults(results []FanoutResult) (completed, failed int) {
+	for _, r := range results {
+		if r.Status == BulkStatusCompleted {
+			completed++
+		} else {
+			failed++
+		}
+	}
+	return completed, failed
+}
@@ -0,0 +1,54 @@
+package service
+
+import (
+	"strings"
+	"testing"
+
+	"hawkeye-cli/internal/api"
+)
+
+func TestResolveFanoutProjects(t *testing.T) {
+	projects := []api.ProjectSpec{
+		{UUID: "11111111-1111-1111-1111-111111111111", Name: "Payments"},
+		{UUID: "22222222-2222-2222-2222-222222222222", Name: "Checkout"},
+		{UUID: "33333333-3333-3333-3333-333333333333", Name: "SystemGlobalProject"},
+	}
+	tests := []struct {
+		name    string
+		list    string
+		all     bool
+		want    string
+		wantErr string
+	}{
+		{"all skips system projects", "", true, "Payments,Checkout", ""},
+		{"by name and uuid, deduplicated", "checkout, 11111111-1111-1111-1111-111111111111,Checkout", false, "Checkout,Payments", ""},
+		{"unlisted uuid kept", "44444444-4444-4444-4444-444444444444", false, "", ""},
+		{"unknown name", "payments,billing", false, "", `unknown project "billing"`},
+		{"empty list", " , ", false, "", "at least one project"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ResolveFanoutProjects(projects, tt.list, tt.all)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("error = %v", err)
+			}
+			var names []string
+			for _, p := range got {
+				names = append(names, p.Name)
+			}
+			if strings.Join(names, ",") != tt.want {
+				t.Errorf("projects = %v, want %s", names, tt.want)
+			}
+		})
+	}
+
+	if _, err := ResolveFanoutProjects(projects[2:], "", true); err == nil {
+		t.Error("--all-projects with only system projects should fail")
+	}
+}
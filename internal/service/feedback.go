@@ -0,0 +1,167 @@
+package service
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+
+	"hawkeye-cli/internal/api"
+)
+
+// Ratings as the server stores them.
+const (
+	RatingUp   = "RATING_THUMBS_UP"
+	RatingDown = "RATING_THUMBS_DOWN"
+)
+
+// FeedbackEntry is the rating of one prompt cycle.
+type FeedbackEntry struct {
+	Cycle   int    `json:"cycle"` // 1-based
+	CycleID string `json:"cycle_id"`
+	Time    string `json:"time,omitempty"`
+	Prompt  string `json:"prompt,omitempty"`
+	Rating  string `json:"rating,omitempty"` // "up", "down" or "" when unrated
+	Reason  string `json:"reason,omitempty"`
+}
+
+// FeedbackHistory lists the rating of each prompt cycle, oldest first.
+func FeedbackHistory(cycles []api.PromptCycle) []FeedbackEntry {
+	out := make([]FeedbackEntry, len(cycles))
+	for i, pc := range cycles {
+		out[i] = FeedbackEntry{
+			Cycle:   i + 1,
+			CycleID: pc.ID,
+			Time:    pc.CreateTime,
+			Prompt:  CyclePrompt(pc),
+			Rating:  RatingLabel(pc.Rating),
+			Reason:  pc.RatingReason,
+		}
+	}
+	return out
+}
+
+// RatingLabel shortens a server rating to "up" or "down". Unrated cycles,
+// including the RATING_UNSPECIFIED zero value, give "".
+func RatingLabel(rating string) string {
+	switch rating {
+	case RatingUp:
+		return "up"
+	case RatingDown:
+		return "down"
+	}
+	return ""
+}
+
+// SelectCycle returns the 1-based prompt cycle n, or the last one when n
+// is 0.
+func SelectCycle(cycles []api.PromptCycle, n int) (api.PromptCycle, error) {
+	if len(cycles) == 0 {
+		return api.PromptCycle{}, fmt.Errorf("session has no prompt cycles")
+	}
+	if n == 0 {
+		return cycles[len(cycles)-1], nil
+	}
+	if n < 1 || n > len(cycles) {
+		return api.PromptCycle{}, fmt.Errorf("cycle %d out of range (session has %d)", n, len(cycles))
+	}
+	return cycles[n-1], nil
+}
+
+// ParseRating accepts the ways a rating is written in a ratings file:
+// up/down, thumbs_up/thumbs_down, +1/-1, 👍/👎 or the server values.
+func ParseRating(s string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "up", "thumbs_up", "thumbs-up", "+1", "1", "👍", strings.ToLower(RatingUp):
+		return RatingUp, nil
+	case "down", "thumbs_down", "thumbs-down", "-1", "👎", strings.ToLower(RatingDown):
+		return RatingDown, nil
+	}
+	return "", fmt.Errorf("invalid rating %q (use up or down)", s)
+}
+
+// RatingRow is one rating to submit from a ratings file.
+type RatingRow struct {
+	Line        int    `json:"line"`
+	SessionUUID string `json:"session_uuid"`
+	Cycle       int    `json:"cycle"` // 1-based; 0 is the last cycle
+	Rating      string `json:"rating"`
+	Reason      string `json:"reason,omitempty"`
+}
+
+// ratingColumns is the column order of a ratings file without a header.
+var ratingColumns = []string{"session_uuid", "cycle", "rating", "reason"}
+
+// ParseRatingsCSV reads a ratings file with the columns session_uuid,
+// cycle, rating and reason. A header row may name them in any order; an
+// empty cycle is the last one. Every bad row is reported, so a file is
+// either fully valid or nothing in it is submitted.
+func ParseRatingsCSV(r io.Reader) ([]RatingRow, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+	cr.Comment = '#'
+
+	col := map[string]int{}
+	for i, name := range ratingColumns {
+		col[name] = i
+	}
+	field := func(rec []string, name string) string {
+		if i, ok := col[name]; ok && i < len(rec) {
+			return strings.TrimSpace(rec[i])
+		}
+		return ""
+	}
+
+	var rows []RatingRow
+	var errs []error
+	for first := true; ; first = false {
+		rec, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		line, _ := cr.FieldPos(0)
+		if first && slices.ContainsFunc(rec, func(c string) bool { return strings.EqualFold(strings.TrimSpace(c), "session_uuid") }) {
+			col = map[string]int{}
+			for i, name := range rec {
+				col[strings.ToLower(strings.TrimSpace(name))] = i
+			}
+			if _, ok := col["rating"]; !ok {
+				return nil, fmt.Errorf("line %d: header has no rating column", line)
+			}
+			continue
+		}
+
+		row := RatingRow{Line: line, SessionUUID: field(rec, "session_uuid"), Reason: field(rec, "reason")}
+		if row.SessionUUID == "" {
+			errs = append(errs, fmt.Errorf("line %d: missing session_uuid", line))
+			continue
+		}
+		if c := field(rec, "cycle"); c != "" {
+			n, err := strconv.Atoi(c)
+			if err != nil || n < 1 {
+				errs = append(errs, fmt.Errorf("line %d: cycle must be a positive number, got %q", line, c))
+				continue
+			}
+			row.Cycle = n
+		}
+		if row.Rating, err = ParseRating(field(rec, "rating")); err != nil {
+			errs = append(errs, fmt.Errorf("line %d: %w", line, err))
+			continue
+		}
+		rows = append(rows, row)
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("no ratings found")
+	}
+	return rows, nil
+}
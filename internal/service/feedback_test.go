@@ -0,0 +1,104 @@
+package service
+
+import (
+	"strings"
+	"testing"
+
+	"hawkeye-cli/internal/api"
+)
+
+func feedbackCycles() []api.PromptCycle {
+	prompt := func(text string) *api.ProcessPromptRequest {
+		return &api.ProcessPromptRequest{Messages: []api.Message{{Content: &api.Content{Parts: []string{text}}}}}
+	}
+	return []api.PromptCycle{
+		{ID: "pc-1", Rating: RatingDown, RatingReason: "wrong service", Request: prompt("Why is checkout slow?")},
+		{ID: "pc-2", Rating: "RATING_UNSPECIFIED", Request: prompt("Check the DB")},
+		{ID: "pc-3", Rating: RatingUp},
+	}
+}
+
+func TestFeedbackHistory(t *testing.T) {
+	got := FeedbackHistory(feedbackCycles())
+	want := []FeedbackEntry{
+		{Cycle: 1, CycleID: "pc-1", Prompt: "Why is checkout slow?", Rating: "down", Reason: "wrong service"},
+		{Cycle: 2, CycleID: "pc-2", Prompt: "Check the DB"},
+		{Cycle: 3, CycleID: "pc-3", Rating: "up"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("FeedbackHistory() = %+v", got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("entry %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestSelectCycle(t *testing.T) {
+	cycles := feedbackCycles()
+	tests := []struct {
+		n       int
+		wantID  string
+		wantErr string
+	}{
+		{0, "pc-3", ""},
+		{1, "pc-1", ""},
+		{3, "pc-3", ""},
+		{4, "", "out of range"},
+		{-1, "", "out of range"},
+	}
+	for _, tt := range tests {
+		pc, err := SelectCycle(cycles, tt.n)
+		if tt.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("SelectCycle(%d) error = %v, want %q", tt.n, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil || pc.ID != tt.wantID {
+			t.Errorf("SelectCycle(%d) = %s, %v, want %s", tt.n, pc.ID, err, tt.wantID)
+		}
+	}
+	if _, err := SelectCycle(nil, 0); err == nil {
+		t.Error("SelectCycle(nil) succeeded")
+	}
+}
+
+func TestParseRatingsCSV(t *testing.T) {
+	in := `# ratings from the 2026-10-14 quality review
+rating,session_uuid,reason,cycle
+down,s-1,"Missed the deploy, blamed DB",2
+👍,s-2,,
+`
+	rows, err := ParseRatingsCSV(strings.NewReader(in))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []RatingRow{
+		{Line: 3, SessionUUID: "s-1", Cycle: 2, Rating: RatingDown, Reason: "Missed the deploy, blamed DB"},
+		{Line: 4, SessionUUID: "s-2", Rating: RatingUp},
+	}
+	if len(rows) != len(want) || rows[0] != want[0] || rows[1] != want[1] {
+		t.Errorf("rows = %+v", rows)
+	}
+
+	// Without a header the columns are session_uuid, cycle, rating, reason.
+	rows, err = ParseRatingsCSV(strings.NewReader("s-3,1,up,spot on\n"))
+	if err != nil || len(rows) != 1 || rows[0].Cycle != 1 || rows[0].Rating != RatingUp || rows[0].Reason != "spot on" {
+		t.Errorf("headerless rows = %+v, %v", rows, err)
+	}
+
+	_, err = ParseRatingsCSV(strings.NewReader("s-1,0,up\n,1,down\ns-2,1,meh\n"))
+	for _, msg := range []string{"line 1: cycle must be a positive number", "line 2: missing session_uuid", `line 3: invalid rating "meh"`} {
+		if err == nil || !strings.Contains(err.Error(), msg) {
+			t.Errorf("error %v should mention %q", err, msg)
+		}
+	}
+	if _, err := ParseRatingsCSV(strings.NewReader("session_uuid,cycle\n")); err == nil {
+		t.Error("a header without a rating column should fail")
+	}
+	if _, err := ParseRatingsCSV(strings.NewReader("")); err == nil {
+		t.Error("an empty file should fail")
+	}
+}
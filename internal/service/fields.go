@@ -0,0 +1,120 @@
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// ─── JSON field selection ───────────────────────────────────────────────────
+//
+// --fields projects JSON output onto the listed fields, so scripts get only
+// what they read and the shape stays the same from run to run. A dotted
+// path such as session_info.name reaches into nested objects, and through
+// arrays element by element. A listing is projected per element. Fields
+// missing from a value come out as null rather than being left out.
+
+// ParseFields parses a --fields list such as "session_uuid,name".
+func ParseFields(s string) ([]string, error) {
+	var fields []string
+	for _, f := range strings.Split(s, ",") {
+		f = strings.TrimSpace(f)
+		if f == "" {
+			continue
+		}
+		for _, seg := range strings.Split(f, ".") {
+			if seg == "" {
+				return nil, fmt.Errorf("invalid field %q (use names like session_uuid or session_info.name)", f)
+			}
+		}
+		fields = append(fields, f)
+	}
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("--fields needs at least one field, e.g. session_uuid,name")
+	}
+	return fields, nil
+}
+
+// SelectFields projects a decoded JSON value onto fields. It also returns
+// the fields found nowhere in v, which are usually typos.
+func SelectFields(v any, fields []string) (any, []string) {
+	found := map[string]bool{}
+	out := selectPaths(v, fields, "", found)
+	var missing []string
+	for _, f := range fields {
+		if !found[f] {
+			missing = append(missing, f)
+		}
+	}
+	return out, missing
+}
+
+// selectPaths projects v onto paths, which are relative to prefix, and
+// marks every full path that resolved in found.
+func selectPaths(v any, paths []string, prefix string, found map[string]bool) any {
+	switch v := v.(type) {
+	case []any:
+		out := make([]any, len(v))
+		for i, el := range v {
+			out[i] = selectPaths(el, paths, prefix, found)
+		}
+		return out
+	case map[string]any:
+		// Group the paths by their first name, so a.b and a.c share a.
+		type want struct {
+			whole bool // the full value, as for a plain name
+			rest  []string
+		}
+		wants := map[string]*want{}
+		for _, p := range paths {
+			head, rest, dotted := strings.Cut(p, ".")
+			w := wants[head]
+			if w == nil {
+				w = &want{}
+				wants[head] = w
+			}
+			if dotted {
+				w.rest = append(w.rest, rest)
+			} else {
+				w.whole = true
+			}
+		}
+		out := map[string]any{}
+		for head, w := range wants {
+			val, ok := v[head]
+			switch {
+			case !ok:
+				out[head] = nil
+			case w.whole:
+				out[head] = val
+				found[prefix+head] = true
+				for _, r := range w.rest {
+					found[prefix+head+"."+r] = true
+				}
+			default:
+				out[head] = selectPaths(val, w.rest, prefix+head+".", found)
+			}
+		}
+		return out
+	}
+	return nil
+}
+
+// SelectJSON applies SelectFields to encoded JSON. The result is indented
+// with indent, or compact when indent is "".
+func SelectJSON(data []byte, fields []string, indent string) ([]byte, []string, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, nil, err
+	}
+	out, missing := SelectFields(v, fields)
+	if indent == "" {
+		data, err := json.Marshal(out)
+		return data, missing, err
+	}
+	data, err := json.MarshalIndent(out, "", indent)
+	return data, missing, err
+}
@@ -0,0 +1,62 @@
+package service
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseFields(t *testing.T) {
+	fields, err := ParseFields(" session_uuid, name,,session_info.name ")
+	if err != nil || strings.Join(fields, "|") != "session_uuid|name|session_info.name" {
+		t.Errorf("ParseFields = %q, %v", fields, err)
+	}
+	for _, bad := range []string{"", " , ", "a..b", ".name", "name."} {
+		if _, err := ParseFields(bad); err == nil {
+			t.Errorf("ParseFields(%q) should fail", bad)
+		}
+	}
+}
+
+func TestSelectJSON(t *testing.T) {
+	listing := `[
+		{"session_uuid": "s1", "name": "Disk full", "investigation_status": "INVESTIGATION_STATUS_COMPLETED", "pinned": false},
+		{"session_uuid": "s2", "investigation_status": "INVESTIGATION_STATUS_NOT_STARTED", "pinned": true}
+	]`
+	got, missing, err := SelectJSON([]byte(listing), []string{"session_uuid", "name"}, "")
+	if err != nil || len(missing) != 0 {
+		t.Fatalf("SelectJSON: %v, missing %q", err, missing)
+	}
+	if want := `[{"name":"Disk full","session_uuid":"s1"},{"name":null,"session_uuid":"s2"}]`; string(got) != want {
+		t.Errorf("listing = %s, want %s", got, want)
+	}
+
+	inspect := `{
+		"session_info": {"session_uuid": "s1", "name": "Disk full", "create_time": "2026-10-15T09:12:00Z"},
+		"prompt_cycle": [
+			{"id": "pc-1", "final_answer": "Log rotation stopped.", "sources": [{"id": "src-1", "title": "node logs"}]},
+			{"id": "pc-2", "final_answer": "", "sources": []}
+		],
+		"usage": {"tokens": 12345678901234567890}
+	}`
+	got, missing, err = SelectJSON([]byte(inspect),
+		[]string{"session_info.name", "session_info.session_uuid", "prompt_cycle.sources.title", "usage", "usage.tokens", "session_info.nmae"}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"prompt_cycle":[{"sources":[{"title":"node logs"}]},{"sources":[]}],` +
+		`"session_info":{"name":"Disk full","nmae":null,"session_uuid":"s1"},"usage":{"tokens":12345678901234567890}}`
+	if string(got) != want {
+		t.Errorf("nested =\n%s\nwant\n%s", got, want)
+	}
+	if strings.Join(missing, ",") != "session_info.nmae" {
+		t.Errorf("missing = %q, want the typo only", missing)
+	}
+
+	got, _, _ = SelectJSON([]byte(`{"a": {"b": 1}}`), []string{"a.b"}, "  ")
+	if want := "{\n  \"a\": {\n    \"b\": 1\n  }\n}"; string(got) != want {
+		t.Errorf("indented = %q", got)
+	}
+	if _, _, err := SelectJSON([]byte(`{`), []string{"a"}, ""); err == nil {
+		t.Error("invalid JSON should fail")
+	}
+}
@@ -0,0 +1,67 @@
+package service
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// FuzzyScore matches pattern against s as a case-insensitive subsequence,
+// the way editors' file pickers do: "pgdb" matches "prod-postgres-db".
+// Characters that follow the previous match or start a word score extra,
+// and an earlier first match beats a later one. ok is false when some
+// character of pattern is missing from s. An empty pattern matches
+// everything with score 0.
+func FuzzyScore(pattern, s string) (score int, ok bool) {
+	p := []rune(strings.ToLower(pattern))
+	if len(p) == 0 {
+		return 0, true
+	}
+	text := []rune(s)
+	lower := []rune(strings.ToLower(s))
+	if len(lower) != len(text) {
+		text = lower
+	}
+
+	pi, prev, first := 0, -2, -1
+	for i := 0; i < len(lower) && pi < len(p); i++ {
+		if lower[i] != p[pi] {
+			continue
+		}
+		score++
+		if i == prev+1 {
+			score += 5
+		}
+		if i == 0 || !unicode.IsLetter(text[i-1]) && !unicode.IsDigit(text[i-1]) ||
+			unicode.IsUpper(text[i]) && unicode.IsLower(text[i-1]) {
+			score += 4
+		}
+		if first < 0 {
+			first = i
+		}
+		prev = i
+		pi++
+	}
+	if pi < len(p) {
+		return 0, false
+	}
+	return score - min(first, 3), true
+}
+
+// FuzzyFilter returns the indexes of the items whose key matches pattern,
+// best match first; ties keep the items' order.
+func FuzzyFilter(pattern string, n int, key func(i int) string) []int {
+	type match struct{ i, score int }
+	var matches []match
+	for i := range n {
+		if score, ok := FuzzyScore(pattern, key(i)); ok {
+			matches = append(matches, match{i, score})
+		}
+	}
+	sort.SliceStable(matches, func(a, b int) bool { return matches[a].score > matches[b].score })
+	out := make([]int, len(matches))
+	for i, m := range matches {
+		out[i] = m.i
+	}
+	return out
+}
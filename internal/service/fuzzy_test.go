@@ -0,0 +1,48 @@
+package service
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFuzzyScore(t *testing.T) {
+	tests := []struct {
+		pattern, s string
+		ok         bool
+	}{
+		{"pgdb", "prod-postgres-db", true},
+		{"PGDB", "prod-postgres-db", true},
+		{"", "anything", true},
+		{"dbpg", "prod-postgres-db", false},
+		{"cpux", "cpu", false},
+	}
+	for _, tt := range tests {
+		if _, ok := FuzzyScore(tt.pattern, tt.s); ok != tt.ok {
+			t.Errorf("FuzzyScore(%q, %q) ok = %v, want %v", tt.pattern, tt.s, ok, tt.ok)
+		}
+	}
+
+	// Consecutive and word-start matches beat scattered ones.
+	contiguous, _ := FuzzyScore("api", "checkout-api")
+	scattered, _ := FuzzyScore("api", "kafka-pipeline")
+	if contiguous <= scattered {
+		t.Errorf("contiguous %d <= scattered %d", contiguous, scattered)
+	}
+	camel, _ := FuzzyScore("rl", "RequestLatency")
+	inner, _ := FuzzyScore("rl", "errlog")
+	if camel <= inner {
+		t.Errorf("word starts %d <= inner %d", camel, inner)
+	}
+}
+
+func TestFuzzyFilter(t *testing.T) {
+	names := []string{"kafka-pipeline", "orders-db", "checkout-api", "api-gateway"}
+	got := FuzzyFilter("api", len(names), func(i int) string { return names[i] })
+	want := []int{3, 2, 0}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("FuzzyFilter() = %v, want %v", got, want)
+	}
+	if got := FuzzyFilter("", len(names), func(i int) string { return names[i] }); len(got) != len(names) {
+		t.Errorf("empty pattern kept %d of %d", len(got), len(names))
+	}
+}
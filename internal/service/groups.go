@@ -0,0 +1,55 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"hawkeye-cli/internal/api"
+)
+
+// maxGroupPromptAlerts caps how many member alerts are listed in the
+// prompt for `groups investigate`.
+const maxGroupPromptAlerts = 20
+
+// GroupLabel returns the group's title, or its ID when it has none.
+func GroupLabel(g api.IncidentGroup) string {
+	if g.Title != "" {
+		return g.Title
+	}
+	return g.GroupID
+}
+
+// GroupAlertCount returns how many alerts a group holds, preferring the
+// member list when it has been fetched.
+func GroupAlertCount(g api.IncidentGroup) int {
+	if len(g.Alerts) > g.AlertCount {
+		return len(g.Alerts)
+	}
+	return g.AlertCount
+}
+
+// GroupPrompt builds the prompt that investigates a group as one incident,
+// listing its member alerts.
+func GroupPrompt(g api.IncidentGroup) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Investigate incident group %q (%s)", GroupLabel(g), g.GroupID)
+	if n := GroupAlertCount(g); n > 0 {
+		fmt.Fprintf(&b, ", %d grouped alerts", n)
+	}
+	b.WriteString(". Find the common root cause.")
+	if len(g.Alerts) == 0 {
+		return b.String()
+	}
+	b.WriteString("\n\nAlerts:")
+	for i, a := range g.Alerts {
+		if i == maxGroupPromptAlerts {
+			fmt.Fprintf(&b, "\n- … and %d more", len(g.Alerts)-i)
+			break
+		}
+		fmt.Fprintf(&b, "\n- %s: %s", a.AlertID, firstNonEmpty(a.Title, "(untitled)"))
+		if a.Source != "" {
+			fmt.Fprintf(&b, " [%s]", a.Source)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,58 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"hawkeye-cli/internal/api"
+)
+
+func TestGroupLabel(t *testing.T) {
+	if got := GroupLabel(api.IncidentGroup{GroupID: "grp-1", Title: "Payments 5xx"}); got != "Payments 5xx" {
+		t.Errorf("GroupLabel() = %q", got)
+	}
+	if got := GroupLabel(api.IncidentGroup{GroupID: "grp-1"}); got != "grp-1" {
+		t.Errorf("GroupLabel() = %q, want the ID", got)
+	}
+}
+
+func TestGroupPrompt(t *testing.T) {
+	tests := []struct {
+		name  string
+		group api.IncidentGroup
+		want  []string
+		not   []string
+	}{
+		{"no members fetched", api.IncidentGroup{GroupID: "grp-1", Title: "Payments 5xx", AlertCount: 3},
+			[]string{`"Payments 5xx" (grp-1), 3 grouped alerts. Find the common root cause.`}, []string{"Alerts:"}},
+		{"members", api.IncidentGroup{GroupID: "grp-1", Alerts: []api.GroupAlert{
+			{AlertID: "a1", Title: "5xx on /pay", Source: "pagerduty"},
+			{AlertID: "a2"},
+		}}, []string{`"grp-1" (grp-1), 2 grouped alerts`, "- a1: 5xx on /pay [pagerduty]", "- a2: (untitled)"}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := GroupPrompt(tt.group)
+			for _, w := range tt.want {
+				if !strings.Contains(got, w) {
+					t.Errorf("prompt missing %q:\n%s", w, got)
+				}
+			}
+			for _, n := range tt.not {
+				if strings.Contains(got, n) {
+					t.Errorf("prompt should not contain %q:\n%s", n, got)
+				}
+			}
+		})
+	}
+
+	var many api.IncidentGroup
+	for i := 0; i < 25; i++ {
+		many.Alerts = append(many.Alerts, api.GroupAlert{AlertID: fmt.Sprintf("a%d", i)})
+	}
+	got := GroupPrompt(many)
+	if strings.Contains(got, "a20:") || !strings.Contains(got, "… and 5 more") {
+		t.Errorf("long member list not capped:\n%s", got)
+	}
+}
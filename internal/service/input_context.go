@@ -0,0 +1,99 @@
+package service
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// MaxInputContextBytes caps how much piped or file input is attached to a
+// prompt with investigate --context.
+const MaxInputContextBytes = 16 * 1024
+
+var ansiEscapeRe = regexp.MustCompile(`\x1b\[[0-9;?]*[A-Za-z]`)
+
+// NormalizeNewlines turns Windows CRLF line endings into LF, so files and
+// pipes from Windows parse like any other input.
+func NormalizeNewlines(s string) string {
+	return strings.ReplaceAll(s, "\r\n", "\n")
+}
+
+// FormatInputContext turns input given with --context into a context part
+// for the prompt. Colour codes are stripped and runs of identical lines
+// are collapsed. If the result is still over limit bytes, the first and
+// last lines are kept around a marker, with more room given to the end
+// since logs put the failure last. It returns "" for blank input.
+func FormatInputContext(source, input string, limit int) string {
+	input = ansiEscapeRe.ReplaceAllString(NormalizeNewlines(input), "")
+	input = strings.TrimRight(input, "\n")
+	if strings.TrimSpace(input) == "" {
+		return ""
+	}
+	lines := collapseRepeats(strings.Split(input, "\n"))
+	total := len(lines)
+
+	note := fmt.Sprintf("%d lines", total)
+	if size := textSize(lines); size > limit {
+		head, tail := fitLines(lines, limit)
+		if omitted := total - len(head) - len(tail); omitted > 0 {
+			head = append(head, fmt.Sprintf("… %d lines omitted …", omitted))
+		}
+		lines = append(head, tail...)
+		note += fmt.Sprintf(", truncated from %d bytes", size)
+	}
+	return fmt.Sprintf("Input from %s (%s), attached to the question:\n```\n%s\n```", source, note, strings.Join(lines, "\n"))
+}
+
+// collapseRepeats replaces each run of identical lines with one copy and
+// a repeat count.
+func collapseRepeats(lines []string) []string {
+	var out []string
+	for i := 0; i < len(lines); {
+		j := i + 1
+		for j < len(lines) && lines[j] == lines[i] {
+			j++
+		}
+		if n := j - i; n > 2 {
+			out = append(out, lines[i], fmt.Sprintf("… repeated %d more times", n-1))
+		} else {
+			out = append(out, lines[i:j]...)
+		}
+		i = j
+	}
+	return out
+}
+
+// fitLines picks leading and trailing lines totalling at most limit bytes,
+// a quarter of the budget for the head and the rest for the tail. Lines
+// longer than the budget left are cut.
+func fitLines(lines []string, limit int) (head, tail []string) {
+	headBudget := limit / 4
+	tailBudget := limit - headBudget
+	for _, l := range lines {
+		if len(l)+1 > headBudget {
+			break
+		}
+		head = append(head, l)
+		headBudget -= len(l) + 1
+	}
+	for i := len(lines) - 1; i >= len(head); i-- {
+		l := lines[i]
+		if len(l)+1 > tailBudget {
+			if len(tail) == 0 && tailBudget > 1 {
+				tail = append(tail, strings.ToValidUTF8(l[:tailBudget-1], ""))
+			}
+			break
+		}
+		tail = append([]string{l}, tail...)
+		tailBudget -= len(l) + 1
+	}
+	return head, tail
+}
+
+func textSize(lines []string) int {
+	n := 0
+	for _, l := range lines {
+		n += len(l) + 1
+	}
+	return n
+}
@@ -0,0 +1,68 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestFormatInputContext(t *testing.T) {
+	var long []string
+	for i := 1; i <= 500; i++ {
+		long = append(long, fmt.Sprintf("line %03d", i))
+	}
+
+	tests := []struct {
+		name     string
+		input    string
+		limit    int
+		want     []string
+		dontWant []string
+	}{
+		{"blank", " \n\n", 100, nil, nil},
+		{
+			name:  "small input kept whole",
+			input: "\x1b[31mERROR\x1b[0m db timeout\r\nretrying\r\n",
+			limit: 100,
+			want:  []string{"Input from stdin (2 lines)", "```\nERROR db timeout\nretrying\n```"},
+		},
+		{
+			name:     "repeats collapsed",
+			input:    "start\n" + strings.Repeat("conn refused\n", 40) + "end\n",
+			limit:    1000,
+			want:     []string{"conn refused\n… repeated 39 more times\nend"},
+			dontWant: []string{"conn refused\nconn refused"},
+		},
+		{
+			name:     "truncated keeps head and tail",
+			input:    strings.Join(long, "\n"),
+			limit:    400,
+			want:     []string{"truncated from 4500 bytes", "line 001\n", "lines omitted …", "line 500\n```"},
+			dontWant: []string{"line 250"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FormatInputContext("stdin", tt.input, tt.limit)
+			if tt.want == nil {
+				if got != "" {
+					t.Errorf("FormatInputContext() = %q, want empty", got)
+				}
+				return
+			}
+			for _, s := range tt.want {
+				if !strings.Contains(got, s) {
+					t.Errorf("missing %q in:\n%s", s, got)
+				}
+			}
+			for _, s := range tt.dontWant {
+				if strings.Contains(got, s) {
+					t.Errorf("unexpected %q in:\n%s", s, got)
+				}
+			}
+			if body := got[strings.Index(got, "```"):]; len(body) > tt.limit+64 {
+				t.Errorf("body is %d bytes, limit %d", len(body), tt.limit)
+			}
+		})
+	}
+}
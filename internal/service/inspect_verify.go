@@ -0,0 +1,172 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"hawkeye-cli/internal/api"
+)
+
+// InspectChange is one difference between a recorded `inspect --json` and
+// the session as the server returns it now.
+type InspectChange struct {
+	Cycle  int    `json:"cycle,omitempty"` // 1-based; 0 for the session itself
+	Field  string `json:"field"`
+	Before string `json:"before"`
+	After  string `json:"after"`
+}
+
+// Summary describes the change on one line. For multi-line values it
+// shows the first line that differs.
+func (c InspectChange) Summary() string {
+	where := c.Field
+	switch {
+	case c.Field == "cycle":
+		where = fmt.Sprintf("cycle %d", c.Cycle)
+	case c.Cycle > 0:
+		where = fmt.Sprintf("cycle %d %s", c.Cycle, c.Field)
+	}
+	switch {
+	case c.Before == "":
+		return fmt.Sprintf("%s: added %q", where, firstLine(c.After))
+	case c.After == "":
+		return fmt.Sprintf("%s: removed %q", where, firstLine(c.Before))
+	}
+	before, after := strings.Split(c.Before, "\n"), strings.Split(c.After, "\n")
+	if len(before) == 1 && len(after) == 1 {
+		return fmt.Sprintf("%s: %q → %q", where, clip(c.Before, 60), clip(c.After, 60))
+	}
+	for i := 0; i < len(before) || i < len(after); i++ {
+		var b, a string
+		if i < len(before) {
+			b = before[i]
+		}
+		if i < len(after) {
+			a = after[i]
+		}
+		if a != b {
+			return fmt.Sprintf("%s: line %d: %q → %q", where, i+1, clip(b, 50), clip(a, 50))
+		}
+	}
+	return where + ": changed"
+}
+
+func firstLine(s string) string {
+	line, _, _ := strings.Cut(s, "\n")
+	return clip(line, 60)
+}
+
+// clip shortens s to at most max runes.
+func clip(s string, max int) string {
+	if runes := []rune(s); len(runes) > max {
+		return string(runes[:max-1]) + "…"
+	}
+	return s
+}
+
+// ParseInspectRecord parses a session saved with `hawkeye inspect --json`.
+// path names the file in errors.
+func ParseInspectRecord(path string, data []byte) (*api.SessionInspectResponse, error) {
+	var rec api.SessionInspectResponse
+	if err := json.Unmarshal(data, &rec); err != nil {
+		return nil, fmt.Errorf("%s is not a saved inspect --json: %w", path, err)
+	}
+	if rec.SessionInfo == nil && len(rec.PromptCycle) == 0 {
+		return nil, fmt.Errorf("%s is not a saved inspect --json: no session or prompt cycles", path)
+	}
+	return &rec, nil
+}
+
+// VerifyInspect compares a recorded inspect with a fresh one and returns
+// what differs in the content a reader of the record relies on: prompts,
+// answers, statuses, ratings, follow-ups, sources and the steps of the
+// chain of thought. Cycles are matched by ID, or by position when the
+// record has no IDs. Cycles after the last recorded one are reported as
+// added; earlier ones the record leaves out (saved with --cycle, say) are
+// not.
+func VerifyInspect(recorded, current *api.SessionInspectResponse) ([]InspectChange, error) {
+	recUUID, curUUID := inspectSessionUUID(recorded), inspectSessionUUID(current)
+	if recUUID != "" && curUUID != "" && recUUID != curUUID {
+		return nil, fmt.Errorf("record is for session %s, not %s", recUUID, curUUID)
+	}
+
+	var changes []InspectChange
+	if recorded.SessionInfo != nil && current.SessionInfo != nil && recorded.SessionInfo.Name != current.SessionInfo.Name {
+		changes = append(changes, InspectChange{Field: "name", Before: recorded.SessionInfo.Name, After: current.SessionInfo.Name})
+	}
+
+	byID := map[string]int{}
+	for i, pc := range current.PromptCycle {
+		if pc.ID != "" {
+			byID[pc.ID] = i
+		}
+	}
+	last := -1 // the latest current cycle the record covers
+	for i, rec := range recorded.PromptCycle {
+		j, ok := byID[rec.ID]
+		if rec.ID == "" {
+			j, ok = i, i < len(current.PromptCycle)
+		}
+		if !ok {
+			changes = append(changes, InspectChange{Cycle: i + 1, Field: "cycle", Before: cycleLabel(rec)})
+			continue
+		}
+		last = max(last, j)
+		changes = append(changes, diffCycle(j+1, rec, current.PromptCycle[j])...)
+	}
+	for j := last + 1; j < len(current.PromptCycle); j++ {
+		changes = append(changes, InspectChange{Cycle: j + 1, Field: "cycle", After: cycleLabel(current.PromptCycle[j])})
+	}
+	return changes, nil
+}
+
+func diffCycle(n int, rec, cur api.PromptCycle) []InspectChange {
+	var changes []InspectChange
+	add := func(field, before, after string) {
+		if before != after {
+			changes = append(changes, InspectChange{Cycle: n, Field: field, Before: before, After: after})
+		}
+	}
+	add("prompt", CyclePrompt(rec), CyclePrompt(cur))
+	add("status", rec.Status, cur.Status)
+	add("answer", strings.TrimSpace(StripHTML(rec.FinalAnswer)), strings.TrimSpace(StripHTML(cur.FinalAnswer)))
+	add("rating", RatingLabel(rec.Rating), RatingLabel(cur.Rating))
+	add("rating reason", rec.RatingReason, cur.RatingReason)
+	add("follow-ups", strings.Join(rec.FollowUpSuggestions, "\n"), strings.Join(cur.FollowUpSuggestions, "\n"))
+	add("sources", sourceList(rec.Sources), sourceList(cur.Sources))
+	if len(rec.ChainOfThoughts) > 0 { // not saved with --cot none
+		add("chain of thought", stepList(rec.ChainOfThoughts), stepList(cur.ChainOfThoughts))
+	}
+	return changes
+}
+
+func inspectSessionUUID(r *api.SessionInspectResponse) string {
+	if r.SessionInfo == nil {
+		return ""
+	}
+	return r.SessionInfo.SessionUUID
+}
+
+func cycleLabel(pc api.PromptCycle) string {
+	if prompt := CyclePrompt(pc); prompt != "" {
+		return prompt
+	}
+	return "(no prompt)"
+}
+
+func sourceList(sources []api.Source) string {
+	lines := make([]string, len(sources))
+	for i, s := range sources {
+		lines[i] = firstNonEmpty(s.Title, s.ID)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func stepList(cots []api.ChainOfThought) string {
+	lines := make([]string, len(cots))
+	for i, c := range cots {
+		lines[i] = strings.TrimSpace(fmt.Sprintf("%s [%s] %s", firstNonEmpty(c.Description, c.Explanation), c.Status, oneLine(c.Investigation)))
+	}
+	return strings.Join(lines, "\n")
+}
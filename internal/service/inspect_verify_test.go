@@ -0,0 +1,119 @@
+package service
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"hawkeye-cli/internal/api"
+)
+
+func verifySession() *api.SessionInspectResponse {
+	return &api.SessionInspectResponse{
+		SessionInfo: &api.SessionInfo{SessionUUID: "sess-1", Name: "checkout latency"},
+		PromptCycle: []api.PromptCycle{
+			{
+				ID: "pc-1", Status: "COMPLETED", Request: &api.ProcessPromptRequest{Messages: []api.Message{{Content: &api.Content{Parts: []string{"Why is checkout slow?"}}}}},
+				FinalAnswer:     "Root cause\nDB pool exhausted\nFix: raise pool size",
+				Sources:         []api.Source{{ID: "s1", Title: "db metrics"}},
+				ChainOfThoughts: []api.ChainOfThought{{ID: "c1", Description: "Check DB", Status: "DONE"}},
+			},
+			{ID: "pc-2", Status: "COMPLETED", FinalAnswer: "Pool size is 10"},
+		},
+	}
+}
+
+// clone round-trips through JSON, as a saved record would.
+func clone(t *testing.T, r *api.SessionInspectResponse) *api.SessionInspectResponse {
+	t.Helper()
+	data, err := json.Marshal(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out api.SessionInspectResponse
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatal(err)
+	}
+	return &out
+}
+
+func TestVerifyInspect(t *testing.T) {
+	tests := []struct {
+		name string
+		edit func(rec, cur *api.SessionInspectResponse)
+		want []string // change summaries
+	}{
+		{"unchanged", func(rec, cur *api.SessionInspectResponse) {}, nil},
+		{"answer edited", func(rec, cur *api.SessionInspectResponse) {
+			cur.PromptCycle[0].FinalAnswer = "Root cause\nDNS outage\nFix: raise pool size"
+		}, []string{`cycle 1 answer: line 2: "DB pool exhausted" → "DNS outage"`}},
+		{"rated afterwards", func(rec, cur *api.SessionInspectResponse) {
+			cur.PromptCycle[1].Rating = RatingDown
+		}, []string{`cycle 2 rating: added "down"`}},
+		{"renamed", func(rec, cur *api.SessionInspectResponse) {
+			cur.SessionInfo.Name = "db pool"
+		}, []string{`name: "checkout latency" → "db pool"`}},
+		{"step changed", func(rec, cur *api.SessionInspectResponse) {
+			cur.PromptCycle[0].ChainOfThoughts[0].Status = "FAILED"
+		}, []string{`cycle 1 chain of thought: "Check DB [DONE]" → "Check DB [FAILED]"`}},
+		{"saved with --cot none", func(rec, cur *api.SessionInspectResponse) {
+			rec.PromptCycle[0].ChainOfThoughts = nil
+		}, nil},
+		{"cycle added since", func(rec, cur *api.SessionInspectResponse) {
+			cur.PromptCycle = append(cur.PromptCycle, api.PromptCycle{ID: "pc-3"})
+		}, []string{`cycle 3: added "(no prompt)"`}},
+		{"cycle deleted", func(rec, cur *api.SessionInspectResponse) {
+			cur.PromptCycle = cur.PromptCycle[1:]
+		}, []string{`cycle 1: removed "Why is checkout slow?"`}},
+		{"saved with --cycle 1", func(rec, cur *api.SessionInspectResponse) {
+			rec.PromptCycle = rec.PromptCycle[1:]
+		}, nil},
+		{"matched by position", func(rec, cur *api.SessionInspectResponse) {
+			for _, r := range []*api.SessionInspectResponse{rec, cur} {
+				for i := range r.PromptCycle {
+					r.PromptCycle[i].ID = ""
+				}
+			}
+			cur.PromptCycle[1].FinalAnswer = "Pool size is 20"
+		}, []string{`cycle 2 answer: "Pool size is 10" → "Pool size is 20"`}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec, cur := clone(t, verifySession()), clone(t, verifySession())
+			tt.edit(rec, cur)
+			changes, err := VerifyInspect(rec, cur)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, c := range changes {
+				got = append(got, c.Summary())
+			}
+			if strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+				t.Errorf("changes:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(tt.want, "\n"))
+			}
+		})
+	}
+}
+
+func TestVerifyInspectOtherSession(t *testing.T) {
+	rec, cur := verifySession(), verifySession()
+	cur.SessionInfo = &api.SessionInfo{SessionUUID: "sess-2"}
+	if _, err := VerifyInspect(rec, cur); err == nil || !strings.Contains(err.Error(), "sess-1") {
+		t.Errorf("err = %v, want a session mismatch", err)
+	}
+}
+
+func TestParseInspectRecord(t *testing.T) {
+	data, _ := json.Marshal(verifySession())
+	rec, err := ParseInspectRecord("good.json", data)
+	if err != nil || rec.SessionInfo.SessionUUID != "sess-1" || len(rec.PromptCycle) != 2 {
+		t.Fatalf("ParseInspectRecord() = %+v, %v", rec, err)
+	}
+
+	for name, content := range map[string]string{"notjson.json": "# transcript", "empty.json": "{}"} {
+		if _, err := ParseInspectRecord(name, []byte(content)); err == nil || !strings.Contains(err.Error(), name) {
+			t.Errorf("%s: err = %v, want one naming the file", name, err)
+		}
+	}
+}
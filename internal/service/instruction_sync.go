@@ -0,0 +1,192 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+
+	"hawkeye-cli/internal/api"
+
+	"gopkg.in/yaml.v3"
+)
+
+// InstructionManifest is the on-disk (YAML) representation of a project's
+// instructions, used by `instructions export` and `instructions import`.
+type InstructionManifest struct {
+	Instructions []InstructionEntry `yaml:"instructions" json:"instructions"`
+}
+
+// InstructionEntry describes one desired instruction. Entries are matched to
+// existing instructions by (type, name).
+type InstructionEntry struct {
+	Name    string `yaml:"name" json:"name"`
+	Type    string `yaml:"type" json:"type"`
+	Content string `yaml:"content" json:"content"`
+	Enabled *bool  `yaml:"enabled,omitempty" json:"enabled,omitempty"`
+}
+
+// IsEnabled reports whether the entry should be enabled. Entries without an
+// explicit value default to enabled.
+func (e InstructionEntry) IsEnabled() bool {
+	return e.Enabled == nil || *e.Enabled
+}
+
+// Instruction sync actions.
+const (
+	SyncCreate    = "create"
+	SyncUpdate    = "update"
+	SyncDelete    = "delete"
+	SyncUnchanged = "unchanged"
+)
+
+// InstructionChange is one step of an instruction sync plan.
+type InstructionChange struct {
+	Action         string `json:"action"`
+	UUID           string `json:"uuid,omitempty"`
+	Name           string `json:"name"`
+	Type           string `json:"type"`
+	Content        string `json:"content,omitempty"`
+	OldContent     string `json:"old_content,omitempty"`
+	Enabled        bool   `json:"enabled"`
+	ContentChanged bool   `json:"content_changed,omitempty"`
+	EnabledChanged bool   `json:"enabled_changed,omitempty"`
+}
+
+// ExportInstructions converts live instructions into a manifest, sorted by
+// type then name so exports are stable across runs.
+func ExportInstructions(specs []api.InstructionSpec) InstructionManifest {
+	var m InstructionManifest
+	for _, s := range specs {
+		enabled := s.Enabled
+		m.Instructions = append(m.Instructions, InstructionEntry{
+			Name:    s.Name,
+			Type:    s.Type,
+			Content: s.Content,
+			Enabled: &enabled,
+		})
+	}
+	sort.SliceStable(m.Instructions, func(i, j int) bool {
+		a, b := m.Instructions[i], m.Instructions[j]
+		if a.Type != b.Type {
+			return a.Type < b.Type
+		}
+		return a.Name < b.Name
+	})
+	return m
+}
+
+// MarshalInstructionManifest encodes a manifest as YAML.
+func MarshalInstructionManifest(m InstructionManifest) ([]byte, error) {
+	return yaml.Marshal(m)
+}
+
+// ParseInstructionManifest decodes and validates a YAML manifest.
+func ParseInstructionManifest(data []byte) (InstructionManifest, error) {
+	var m InstructionManifest
+	if err := yaml.Unmarshal(data, &m); err != nil {
+		return m, fmt.Errorf("parsing manifest: %w", err)
+	}
+	if err := ValidateInstructionEntries(m.Instructions); err != nil {
+		return m, err
+	}
+	return m, nil
+}
+
+// ValidateInstructionEntries checks that every entry has a name, a valid type
+// and content, and that no (type, name) pair appears twice.
+func ValidateInstructionEntries(entries []InstructionEntry) error {
+	seen := make(map[string]bool)
+	for i, e := range entries {
+		if e.Name == "" {
+			return fmt.Errorf("instruction %d: name is required", i+1)
+		}
+		if !ValidInstructionType(e.Type) {
+			return fmt.Errorf("instruction %q: invalid type %q (valid: filter, system, grouping, rca)", e.Name, e.Type)
+		}
+		if e.Content == "" {
+			return fmt.Errorf("instruction %q: content is required", e.Name)
+		}
+		key := e.Type + "/" + e.Name
+		if seen[key] {
+			return fmt.Errorf("instruction %q: duplicate %s instruction", e.Name, e.Type)
+		}
+		seen[key] = true
+	}
+	return nil
+}
+
+// PlanInstructionSync compares desired entries against the live instructions
+// and returns the changes needed to converge. Live instructions missing from
+// the manifest are deleted only when prune is set.
+func PlanInstructionSync(desired []InstructionEntry, current []api.InstructionSpec, prune bool) []InstructionChange {
+	byKey := make(map[string]api.InstructionSpec)
+	for _, s := range current {
+		byKey[s.Type+"/"+s.Name] = s
+	}
+
+	var changes []InstructionChange
+	matched := make(map[string]bool)
+	for _, e := range desired {
+		key := e.Type + "/" + e.Name
+		c := InstructionChange{
+			Name:    e.Name,
+			Type:    e.Type,
+			Content: e.Content,
+			Enabled: e.IsEnabled(),
+		}
+		live, ok := byKey[key]
+		if !ok {
+			c.Action = SyncCreate
+			changes = append(changes, c)
+			continue
+		}
+		matched[key] = true
+		c.UUID = live.UUID
+		c.OldContent = live.Content
+		c.ContentChanged = live.Content != e.Content
+		c.EnabledChanged = live.Enabled != e.IsEnabled()
+		if c.ContentChanged || c.EnabledChanged {
+			c.Action = SyncUpdate
+		} else {
+			c.Action = SyncUnchanged
+		}
+		changes = append(changes, c)
+	}
+
+	if prune {
+		for _, s := range current {
+			if matched[s.Type+"/"+s.Name] {
+				continue
+			}
+			changes = append(changes, InstructionChange{
+				Action:     SyncDelete,
+				UUID:       s.UUID,
+				Name:       s.Name,
+				Type:       s.Type,
+				OldContent: s.Content,
+				Enabled:    s.Enabled,
+			})
+		}
+	}
+
+	return changes
+}
+
+// HasInstructionChanges reports whether a plan creates, updates or deletes
+// any instruction.
+func HasInstructionChanges(changes []InstructionChange) bool {
+	for _, c := range changes {
+		if c.Action != SyncUnchanged {
+			return true
+		}
+	}
+	return false
+}
+
+// CountInstructionChanges tallies a plan by action.
+func CountInstructionChanges(changes []InstructionChange) map[string]int {
+	counts := make(map[string]int)
+	for _, c := range changes {
+		counts[c.Action]++
+	}
+	return counts
+}
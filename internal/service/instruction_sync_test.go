@@ -0,0 +1,111 @@
+package service
+
+import (
+	"strings"
+	"testing"
+
+	"hawkeye-cli/internal/api"
+)
+
+func TestInstructionManifestRoundTrip(t *testing.T) {
+	specs := []api.InstructionSpec{
+		{UUID: "2", Name: "zeta", Type: "system", Content: "be terse", Enabled: true},
+		{UUID: "1", Name: "alpha", Type: "filter", Content: "ignore 404s\nignore 401s", Enabled: false},
+	}
+	data, err := MarshalInstructionManifest(ExportInstructions(specs))
+	if err != nil {
+		t.Fatalf("MarshalInstructionManifest() error = %v", err)
+	}
+	m, err := ParseInstructionManifest(data)
+	if err != nil {
+		t.Fatalf("ParseInstructionManifest() error = %v", err)
+	}
+	if len(m.Instructions) != 2 {
+		t.Fatalf("got %d entries, want 2", len(m.Instructions))
+	}
+	first := m.Instructions[0]
+	if first.Name != "alpha" || first.Content != "ignore 404s\nignore 401s" || first.IsEnabled() {
+		t.Errorf("first entry = %+v, want disabled alpha with multi-line content", first)
+	}
+	if strings.Contains(string(data), "uuid") {
+		t.Errorf("export should not contain UUIDs:\n%s", data)
+	}
+}
+
+func TestParseInstructionManifestErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		yaml    string
+		wantErr string
+	}{
+		{"bad yaml", "instructions: [", "parsing manifest"},
+		{"missing name", "instructions:\n- type: filter\n  content: x\n", "name is required"},
+		{"bad type", "instructions:\n- name: a\n  type: nope\n  content: x\n", "invalid type"},
+		{"missing content", "instructions:\n- name: a\n  type: rca\n", "content is required"},
+		{"duplicate", "instructions:\n- {name: a, type: rca, content: x}\n- {name: a, type: rca, content: y}\n", "duplicate"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseInstructionManifest([]byte(tt.yaml))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPlanInstructionSync(t *testing.T) {
+	off := false
+	current := []api.InstructionSpec{
+		{UUID: "u1", Name: "same", Type: "filter", Content: "x", Enabled: true},
+		{UUID: "u2", Name: "edit", Type: "rca", Content: "old", Enabled: true},
+		{UUID: "u3", Name: "toggle", Type: "system", Content: "y", Enabled: true},
+		{UUID: "u4", Name: "extra", Type: "grouping", Content: "z", Enabled: true},
+	}
+	desired := []InstructionEntry{
+		{Name: "same", Type: "filter", Content: "x"},
+		{Name: "edit", Type: "rca", Content: "new"},
+		{Name: "toggle", Type: "system", Content: "y", Enabled: &off},
+		{Name: "fresh", Type: "filter", Content: "f"},
+	}
+
+	tests := []struct {
+		name  string
+		prune bool
+		want  []string
+	}{
+		{"no prune", false, []string{SyncUnchanged, SyncUpdate, SyncUpdate, SyncCreate}},
+		{"prune", true, []string{SyncUnchanged, SyncUpdate, SyncUpdate, SyncCreate, SyncDelete}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			changes := PlanInstructionSync(desired, current, tt.prune)
+			if len(changes) != len(tt.want) {
+				t.Fatalf("got %d changes, want %d", len(changes), len(tt.want))
+			}
+			for i, c := range changes {
+				if c.Action != tt.want[i] {
+					t.Errorf("changes[%d].Action = %q, want %q", i, c.Action, tt.want[i])
+				}
+			}
+			if !changes[1].ContentChanged || changes[1].EnabledChanged {
+				t.Errorf("edit change = %+v, want content-only", changes[1])
+			}
+			if changes[2].ContentChanged || !changes[2].EnabledChanged || changes[2].Enabled {
+				t.Errorf("toggle change = %+v, want disable-only", changes[2])
+			}
+		})
+	}
+
+	pruned := PlanInstructionSync(desired, current, true)
+	counts := CountInstructionChanges(pruned)
+	if counts[SyncUpdate] != 2 || counts[SyncDelete] != 1 {
+		t.Errorf("CountInstructionChanges() = %v", counts)
+	}
+	if del := pruned[4]; del.OldContent != "z" {
+		t.Errorf("delete change = %+v, want the old content for the diff", del)
+	}
+	if !HasInstructionChanges(pruned) || HasInstructionChanges(pruned[:1]) {
+		t.Error("HasInstructionChanges() should be true only for a plan with changes")
+	}
+}
@@ -0,0 +1,61 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+)
+
+// KubeQuery is one read-only kubectl invocation used to enrich a prompt.
+type KubeQuery struct {
+	Label string
+	Args  []string
+}
+
+// maxKubeContextLines caps how much of each kubectl output is attached.
+const maxKubeContextLines = 40
+
+// KubeQueries returns the kubectl invocations used to gather live cluster
+// context. An empty kubeContext uses kubectl's current context; an empty
+// namespace uses the context's default namespace.
+func KubeQueries(kubeContext, namespace string) []KubeQuery {
+	var base []string
+	if kubeContext != "" {
+		base = append(base, "--context", kubeContext)
+	}
+	if namespace != "" {
+		base = append(base, "--namespace", namespace)
+	}
+	with := func(args ...string) []string {
+		out := append([]string{}, base...)
+		return append(out, args...)
+	}
+	return []KubeQuery{
+		{Label: "pods", Args: with("get", "pods", "-o", "wide")},
+		{Label: "events", Args: with("get", "events", "--sort-by=.lastTimestamp")},
+		{Label: "deployments", Args: with("get", "deployments")},
+	}
+}
+
+// FormatKubeContext turns one kubectl output into a context part for the
+// prompt. Only the last maxKubeContextLines lines are kept, since events are
+// sorted oldest-first and the most recent ones matter most.
+func FormatKubeContext(kubeContext, namespace, label, output string) string {
+	output = strings.TrimRight(output, "\n")
+	if strings.TrimSpace(output) == "" {
+		return ""
+	}
+	lines := strings.Split(output, "\n")
+	if len(lines) > maxKubeContextLines+1 {
+		// keep the header row plus the most recent rows
+		lines = append([]string{lines[0]}, lines[len(lines)-maxKubeContextLines:]...)
+	}
+
+	scope := "current context"
+	if kubeContext != "" {
+		scope = "context " + kubeContext
+	}
+	if namespace != "" {
+		scope += ", namespace " + namespace
+	}
+	return fmt.Sprintf("Kubernetes %s (%s), captured locally via kubectl:\n```\n%s\n```", label, scope, strings.Join(lines, "\n"))
+}
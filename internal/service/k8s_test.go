@@ -0,0 +1,58 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestKubeQueries(t *testing.T) {
+	tests := []struct {
+		name      string
+		context   string
+		namespace string
+		wantFirst string
+	}{
+		{"current context", "", "", "get pods -o wide"},
+		{"context and namespace", "prod", "checkout", "--context prod --namespace checkout get pods -o wide"},
+		{"namespace only", "", "checkout", "--namespace checkout get pods -o wide"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			qs := KubeQueries(tt.context, tt.namespace)
+			if len(qs) != 3 {
+				t.Fatalf("got %d queries, want 3", len(qs))
+			}
+			if got := strings.Join(qs[0].Args, " "); got != tt.wantFirst {
+				t.Errorf("pods args = %q, want %q", got, tt.wantFirst)
+			}
+			if qs[1].Label != "events" {
+				t.Errorf("qs[1].Label = %q, want events", qs[1].Label)
+			}
+		})
+	}
+}
+
+func TestFormatKubeContext(t *testing.T) {
+	if got := FormatKubeContext("prod", "", "pods", "  \n"); got != "" {
+		t.Errorf("empty output = %q, want empty", got)
+	}
+
+	got := FormatKubeContext("prod", "checkout", "pods", "NAME STATUS\napi-1 CrashLoopBackOff\n")
+	if !strings.Contains(got, "context prod, namespace checkout") || !strings.Contains(got, "api-1 CrashLoopBackOff") {
+		t.Errorf("FormatKubeContext() = %q", got)
+	}
+
+	var b strings.Builder
+	b.WriteString("HEADER\n")
+	for i := 0; i < 100; i++ {
+		fmt.Fprintf(&b, "row-%d\n", i)
+	}
+	got = FormatKubeContext("", "", "events", b.String())
+	if !strings.Contains(got, "HEADER") || strings.Contains(got, "row-0\n") || !strings.Contains(got, "row-99") {
+		t.Errorf("long output not trimmed to header + recent rows: %q", got)
+	}
+	if !strings.Contains(got, "current context") {
+		t.Errorf("missing scope: %q", got)
+	}
+}
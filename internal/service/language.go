@@ -0,0 +1,37 @@
+package service
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var languageTagPattern = regexp.MustCompile(`^[a-zA-Z]{2,3}(-[a-zA-Z0-9]{2,8})*$`)
+
+// NormalizeLanguage validates a BCP 47 style language code for answers
+// and summaries and returns it in canonical case, e.g. "JA" → "ja" and
+// "pt-br" → "pt-BR". "auto", "default" and "" clear the preference.
+func NormalizeLanguage(code string) (string, error) {
+	code = strings.TrimSpace(code)
+	switch strings.ToLower(code) {
+	case "", "auto", "default":
+		return "", nil
+	}
+	code = strings.ReplaceAll(code, "_", "-")
+	if !languageTagPattern.MatchString(code) {
+		return "", fmt.Errorf("invalid language %q: use a code like ja, de or pt-BR", code)
+	}
+	parts := strings.Split(code, "-")
+	parts[0] = strings.ToLower(parts[0])
+	for i := 1; i < len(parts); i++ {
+		switch len(parts[i]) {
+		case 2: // region
+			parts[i] = strings.ToUpper(parts[i])
+		case 4: // script
+			parts[i] = strings.ToUpper(parts[i][:1]) + strings.ToLower(parts[i][1:])
+		default:
+			parts[i] = strings.ToLower(parts[i])
+		}
+	}
+	return strings.Join(parts, "-"), nil
+}
@@ -0,0 +1,28 @@
+package service
+
+import "testing"
+
+func TestNormalizeLanguage(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{"ja", "ja", false},
+		{"JA", "ja", false},
+		{"pt-br", "pt-BR", false},
+		{"pt_BR", "pt-BR", false},
+		{"zh-hant-tw", "zh-Hant-TW", false},
+		{"auto", "", false},
+		{"", "", false},
+		{"japanese!", "", true},
+		{"j", "", true},
+		{"en-", "", true},
+	}
+	for _, tt := range tests {
+		got, err := NormalizeLanguage(tt.in)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("NormalizeLanguage(%q) = %q, %v; want %q, err %v", tt.in, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
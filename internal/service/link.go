@@ -18,27 +18,92 @@ func BuildSessionURL(serverURL, projectUUID, sessionUUID string) string {
 	return base + "/console/project/" + projectUUID + "/session/" + sessionUUID + "?tab=results"
 }
 
+// ParseSessionURL extracts the project and session IDs from a console
+// link. Every form the console produces, or that survives being pasted
+// through chat, is accepted:
+//
+//	/console/project/{p}/session/{s}                 path based
+//	/console/org/{o}/project/{p}/session/{s}         with an org segment
+//	/console/session/{s}?project={p}                 query-param deep links
+//	/console?project_uuid={p}&session_uuid={s}
+//	/#/console/project/{p}/session/{s}               hash routing
+//	<https://...|label>                              Slack link markup
+//
+// Trailing fragments and punctuation are ignored. host is the scheme and
+// host of the link.
 func ParseSessionURL(rawURL string) (host, projectUUID, sessionUUID string, err error) {
-	u, err := url.Parse(rawURL)
+	u, err := url.Parse(cleanPastedURL(rawURL))
 	if err != nil {
 		return "", "", "", fmt.Errorf("invalid URL: %w", err)
 	}
 	if u.Scheme == "" || u.Host == "" {
 		return "", "", "", fmt.Errorf("invalid URL: missing scheme or host")
 	}
-
 	host = u.Scheme + "://" + u.Host
-	segments := strings.Split(strings.Trim(u.Path, "/"), "/")
-
-	for i := 0; i+4 < len(segments); i++ {
-		if segments[i] == "console" && segments[i+1] == "project" && segments[i+3] == "session" {
-			projectUUID = segments[i+2]
-			sessionUUID = segments[i+4]
-			if projectUUID == "" || sessionUUID == "" {
-				return "", "", "", fmt.Errorf("URL path has empty project or session ID")
+
+	// Hash-routed links carry the real path and query in the fragment.
+	path, query := u.Path, u.Query()
+	if frag := strings.TrimPrefix(u.Fragment, "!"); strings.HasPrefix(frag, "/") {
+		fp, fq, _ := strings.Cut(frag, "?")
+		path += "/" + fp
+		if q, err := url.ParseQuery(fq); err == nil {
+			for k, v := range q {
+				query[k] = append(query[k], v...)
+			}
+		}
+	}
+
+	projectUUID = pathID(path, "project", "projects")
+	sessionUUID = pathID(path, "session", "sessions")
+	if projectUUID == "" {
+		projectUUID = queryID(query, "project", "project_uuid", "projectId", "project_id", "projectUuid")
+	}
+	if sessionUUID == "" {
+		sessionUUID = queryID(query, "session", "session_uuid", "sessionId", "session_id", "sessionUuid")
+	}
+	switch {
+	case projectUUID == "" && sessionUUID == "":
+		return "", "", "", fmt.Errorf("URL does not point to a session (expected /console/project/{id}/session/{id})")
+	case projectUUID == "":
+		return "", "", "", fmt.Errorf("URL names session %s but no project", sessionUUID)
+	case sessionUUID == "":
+		return "", "", "", fmt.Errorf("URL names project %s but no session", projectUUID)
+	}
+	return host, projectUUID, sessionUUID, nil
+}
+
+// cleanPastedURL undoes what chat clients do to a pasted link: Slack's
+// <url|label> markup, surrounding quotes or brackets, and trailing
+// punctuation from the sentence around it.
+func cleanPastedURL(s string) string {
+	s = strings.TrimSpace(s)
+	s = strings.TrimPrefix(s, "<")
+	if i := strings.IndexAny(s, "|>"); i >= 0 {
+		s = s[:i]
+	}
+	s = strings.Trim(s, "\"'`()[]")
+	return strings.TrimRight(s, ".,;:!")
+}
+
+// pathID returns the segment after the first segment named one of keys.
+func pathID(path string, keys ...string) string {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for i := 0; i+1 < len(segments); i++ {
+		for _, k := range keys {
+			if strings.EqualFold(segments[i], k) && segments[i+1] != "" {
+				return segments[i+1]
 			}
-			return host, projectUUID, sessionUUID, nil
 		}
 	}
-	return "", "", "", fmt.Errorf("URL path does not match /console/project/{id}/session/{id}")
+	return ""
+}
+
+// queryID returns the first non-empty query parameter named one of keys.
+func queryID(q url.Values, keys ...string) string {
+	for _, k := range keys {
+		if v := strings.TrimSpace(q.Get(k)); v != "" {
+			return v
+		}
+	}
+	return ""
 }
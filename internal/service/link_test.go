@@ -96,6 +96,67 @@ func TestParseSessionURL(t *testing.T) {
 			wantProject: "p1",
 			wantSession: "s1",
 		},
+		{
+			name:        "org segment",
+			rawURL:      "https://app.neubird.ai/console/org/org-1/project/proj-123/session/sess-456?tab=rca",
+			wantHost:    "https://app.neubird.ai",
+			wantProject: "proj-123",
+			wantSession: "sess-456",
+		},
+		{
+			name:        "org before console",
+			rawURL:      "https://app.neubird.ai/org/org-1/console/project/proj-123/session/sess-456",
+			wantHost:    "https://app.neubird.ai",
+			wantProject: "proj-123",
+			wantSession: "sess-456",
+		},
+		{
+			name:        "query-param deep link",
+			rawURL:      "https://app.neubird.ai/console?project_uuid=proj-123&session_uuid=sess-456",
+			wantHost:    "https://app.neubird.ai",
+			wantProject: "proj-123",
+			wantSession: "sess-456",
+		},
+		{
+			name:        "session path, project param",
+			rawURL:      "https://app.neubird.ai/console/session/sess-456?projectId=proj-123",
+			wantHost:    "https://app.neubird.ai",
+			wantProject: "proj-123",
+			wantSession: "sess-456",
+		},
+		{
+			name:        "trailing fragment",
+			rawURL:      "https://app.neubird.ai/console/project/proj-123/session/sess-456?tab=rca#cot-3",
+			wantHost:    "https://app.neubird.ai",
+			wantProject: "proj-123",
+			wantSession: "sess-456",
+		},
+		{
+			name:        "hash routing",
+			rawURL:      "https://app.neubird.ai/#/console/project/proj-123/session/sess-456?tab=rca",
+			wantHost:    "https://app.neubird.ai",
+			wantProject: "proj-123",
+			wantSession: "sess-456",
+		},
+		{
+			name:        "slack markup",
+			rawURL:      "<https://app.neubird.ai/console/project/proj-123/session/sess-456?tab=rca|Checkout RCA>",
+			wantHost:    "https://app.neubird.ai",
+			wantProject: "proj-123",
+			wantSession: "sess-456",
+		},
+		{
+			name:        "trailing punctuation",
+			rawURL:      " https://app.neubird.ai/console/project/proj-123/session/sess-456. ",
+			wantHost:    "https://app.neubird.ai",
+			wantProject: "proj-123",
+			wantSession: "sess-456",
+		},
+		{
+			name:    "project without session",
+			rawURL:  "https://app.neubird.ai/console/project/proj-123/sessions",
+			wantErr: true,
+		},
 		{
 			name:    "missing session segment",
 			rawURL:  "https://app.neubird.ai/console/project/proj-123",
@@ -0,0 +1,106 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ─── Desktop notifications ──────────────────────────────────────────────────
+//
+// With `set notifications on`, investigations that finish while nobody is
+// looking — background jobs in interactive mode, and investigations that
+// ran longer than NotifyAfter — raise a native desktop notification with
+// the session name. Clicking it opens the session in the web console where
+// the platform allows: terminal-notifier on macOS (osascript otherwise,
+// with the link in the text), a protocol toast on Windows. notify-send on
+// Linux shows the link in the body. NotifyCommand only builds the command;
+// display.RunNotifier runs it.
+
+// NotifyAfter is how long a foreground investigation runs before its end
+// is worth a notification.
+const NotifyAfter = 30 * time.Second
+
+// Notification is a desktop notification.
+type Notification struct {
+	Title string
+	Body  string
+	URL   string // opened on click where supported
+}
+
+// windowsToastAppID is the AppUserModelID toasts are raised under. Toasts
+// need a registered one, and PowerShell's exists on every Windows.
+const windowsToastAppID = `{1AC14E77-02E7-4E5D-B744-2EB1AE5198B7}\WindowsPowerShell\v1.0\powershell.exe`
+
+// NotifyCommand returns the command that shows n on goos. lookPath finds
+// optional helpers such as terminal-notifier.
+func NotifyCommand(goos string, n Notification, lookPath func(string) (string, error)) (string, []string, error) {
+	switch goos {
+	case "darwin":
+		if _, err := lookPath("terminal-notifier"); err == nil {
+			args := []string{"-title", n.Title, "-message", n.Body, "-group", "hawkeye"}
+			if n.URL != "" {
+				args = append(args, "-open", n.URL)
+			}
+			return "terminal-notifier", args, nil
+		}
+		body := n.Body
+		if n.URL != "" {
+			body += "\n" + n.URL
+		}
+		script := fmt.Sprintf("display notification %s with title %s", appleScriptString(body), appleScriptString(n.Title))
+		return "osascript", []string{"-e", script}, nil
+
+	case "windows":
+		launch := ""
+		if n.URL != "" {
+			launch = fmt.Sprintf(` activationType="protocol" launch="%s"`, xmlEscape(n.URL))
+		}
+		toast := fmt.Sprintf(`<toast%s><visual><binding template="ToastGeneric"><text>%s</text><text>%s</text></binding></visual></toast>`,
+			launch, xmlEscape(n.Title), xmlEscape(n.Body))
+		script := strings.Join([]string{
+			"[Windows.UI.Notifications.ToastNotificationManager, Windows.UI.Notifications, ContentType = WindowsRuntime] > $null",
+			"[Windows.Data.Xml.Dom.XmlDocument, Windows.Data.Xml.Dom.XmlDocument, ContentType = WindowsRuntime] > $null",
+			"$x = New-Object Windows.Data.Xml.Dom.XmlDocument",
+			"$x.LoadXml(" + powerShellString(toast) + ")",
+			"[Windows.UI.Notifications.ToastNotificationManager]::CreateToastNotifier(" + powerShellString(windowsToastAppID) + ").Show([Windows.UI.Notifications.ToastNotification]::new($x))",
+		}, "; ")
+		return "powershell", []string{"-NoProfile", "-NonInteractive", "-Command", script}, nil
+
+	case "linux", "freebsd", "openbsd", "netbsd":
+		if _, err := lookPath("notify-send"); err != nil {
+			return "", nil, fmt.Errorf("notify-send not found (install libnotify)")
+		}
+		body := n.Body
+		if n.URL != "" {
+			body += "\n" + n.URL
+		}
+		// "--" keeps a title or body starting with "-" from being read as an
+		// option.
+		return "notify-send", []string{"--app-name=Hawkeye", "--", n.Title, body}, nil
+	}
+	return "", nil, fmt.Errorf("desktop notifications are not supported on %s", goos)
+}
+
+// InvestigationNotification describes a finished investigation. failure is
+// the reason it failed, or "" when it completed.
+func InvestigationNotification(name, failure, consoleURL string) Notification {
+	n := Notification{Title: "Hawkeye: investigation complete", Body: name, URL: consoleURL}
+	if failure != "" {
+		n.Title = "Hawkeye: investigation failed"
+		n.Body = name + " — " + failure
+	}
+	return n
+}
+
+func appleScriptString(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+func powerShellString(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+func xmlEscape(s string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", `"`, "&quot;", "'", "&apos;").Replace(s)
+}
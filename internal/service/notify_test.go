@@ -0,0 +1,49 @@
+package service
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestNotifyCommand(t *testing.T) {
+	n := InvestigationNotification(`Why is "checkout" slow?`, "", "https://x.neubird.ai/console/project/p/session/s?a=1&b=2")
+	found := func(string) (string, error) { return "/usr/bin/x", nil }
+	missing := func(string) (string, error) { return "", errors.New("not found") }
+
+	name, args, err := NotifyCommand("darwin", n, found)
+	if err != nil || name != "terminal-notifier" || args[len(args)-1] != n.URL {
+		t.Errorf("darwin with terminal-notifier = %s %q, %v", name, args, err)
+	}
+	name, args, _ = NotifyCommand("darwin", n, missing)
+	want := `display notification "Why is \"checkout\" slow?` + "\n" + n.URL + `" with title "Hawkeye: investigation complete"`
+	if name != "osascript" || args[1] != want {
+		t.Errorf("darwin = %s %q, want script %q", name, args, want)
+	}
+
+	name, args, _ = NotifyCommand("windows", n, missing)
+	script := args[len(args)-1]
+	if name != "powershell" || !strings.Contains(script, `launch="https://x.neubird.ai/console/project/p/session/s?a=1&amp;b=2"`) ||
+		!strings.Contains(script, "<text>Why is &quot;checkout&quot; slow?</text>") {
+		t.Errorf("windows = %s %q", name, script)
+	}
+
+	if name, args, _ := NotifyCommand("linux", n, found); name != "notify-send" || args[1] != "--" || args[2] != "Hawkeye: investigation complete" {
+		t.Errorf("linux = %s %q", name, args)
+	}
+	dashed := Notification{Title: "-u critical", Body: "--help"}
+	if _, args, _ := NotifyCommand("linux", dashed, found); !slices.Equal(args, []string{"--app-name=Hawkeye", "--", "-u critical", "--help"}) {
+		t.Errorf("linux with dashes = %q, want them after --", args)
+	}
+	if _, _, err := NotifyCommand("linux", n, missing); err == nil || !strings.Contains(err.Error(), "notify-send not found") {
+		t.Errorf("linux without notify-send: %v", err)
+	}
+	if _, _, err := NotifyCommand("plan9", n, found); err == nil {
+		t.Error("plan9 should be unsupported")
+	}
+
+	if f := InvestigationNotification("Disk full", "no data sources", ""); f.Title != "Hawkeye: investigation failed" || f.Body != "Disk full — no data sources" {
+		t.Errorf("failure notification = %+v", f)
+	}
+}
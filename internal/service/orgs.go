@@ -0,0 +1,44 @@
+package service
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"hawkeye-cli/internal/api"
+)
+
+// FindOrg searches for an organization by UUID or name (case-insensitive).
+// Returns nil if no match is found.
+func FindOrg(orgs []api.OrgSpec, value string) *api.OrgSpec {
+	for i := range orgs {
+		o := &orgs[i]
+		if o.UUID == value || (o.Name != "" && strings.EqualFold(o.Name, value)) {
+			return o
+		}
+	}
+	return nil
+}
+
+// OrgLabel returns the organization's name, or its UUID when the server
+// did not report a name.
+func OrgLabel(o api.OrgSpec) string {
+	if o.Name != "" {
+		return o.Name
+	}
+	return o.UUID
+}
+
+// ParseOrgChoice parses a 1-based menu selection from an interactive prompt
+// and returns the 0-based index.
+func ParseOrgChoice(input string, count int) (int, error) {
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return 0, fmt.Errorf("no selection made")
+	}
+	n, err := strconv.Atoi(input)
+	if err != nil || n < 1 || n > count {
+		return 0, fmt.Errorf("invalid selection %q (choose 1-%d)", input, count)
+	}
+	return n - 1, nil
+}
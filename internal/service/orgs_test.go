@@ -0,0 +1,70 @@
+package service
+
+import (
+	"testing"
+
+	"hawkeye-cli/internal/api"
+)
+
+func TestFindOrg(t *testing.T) {
+	orgs := []api.OrgSpec{
+		{UUID: "o1", Name: "Acme"},
+		{UUID: "o2"},
+	}
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{"o1", "o1"},
+		{"acme", "o1"},
+		{"o2", "o2"},
+		{"", ""},
+		{"globex", ""},
+	}
+	for _, tt := range tests {
+		got := FindOrg(orgs, tt.value)
+		if tt.want == "" {
+			if got != nil {
+				t.Errorf("FindOrg(%q) = %+v, want nil", tt.value, got)
+			}
+			continue
+		}
+		if got == nil || got.UUID != tt.want {
+			t.Errorf("FindOrg(%q) = %+v, want %s", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestOrgLabel(t *testing.T) {
+	if got := OrgLabel(api.OrgSpec{UUID: "o1", Name: "Acme"}); got != "Acme" {
+		t.Errorf("OrgLabel() = %q, want Acme", got)
+	}
+	if got := OrgLabel(api.OrgSpec{UUID: "o2"}); got != "o2" {
+		t.Errorf("OrgLabel() = %q, want o2", got)
+	}
+}
+
+func TestParseOrgChoice(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    int
+		wantErr bool
+	}{
+		{"1", 0, false},
+		{" 3 ", 2, false},
+		{"", 0, true},
+		{"0", 0, true},
+		{"4", 0, true},
+		{"abc", 0, true},
+	}
+	for _, tt := range tests {
+		got, err := ParseOrgChoice(tt.input, 3)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseOrgChoice(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseOrgChoice(%q) = %d, want %d", tt.input, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"hawkeye-cli/internal/api"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ProjectManifest is the declarative description of a project consumed by
+// `hawkeye apply -f project.yaml`.
+type ProjectManifest struct {
+	Project      ProjectManifestSpec `yaml:"project" json:"project"`
+	Connections  []string            `yaml:"connections,omitempty" json:"connections,omitempty"`
+	Instructions []InstructionEntry  `yaml:"instructions,omitempty" json:"instructions,omitempty"`
+}
+
+// ProjectManifestSpec holds the project's own fields.
+type ProjectManifestSpec struct {
+	Name        string `yaml:"name" json:"name"`
+	Description string `yaml:"description,omitempty" json:"description,omitempty"`
+}
+
+// ProjectPlan is the set of changes needed to make a project match a manifest.
+type ProjectPlan struct {
+	ProjectAction     string               `json:"project_action"`
+	ProjectUUID       string               `json:"project_uuid,omitempty"`
+	Name              string               `json:"name"`
+	Description       string               `json:"description,omitempty"`
+	AttachConnections []api.ConnectionSpec `json:"attach_connections,omitempty"`
+	Instructions      []InstructionChange  `json:"instructions,omitempty"`
+}
+
+// HasChanges reports whether applying the plan would modify anything.
+func (p ProjectPlan) HasChanges() bool {
+	return p.ProjectAction != SyncUnchanged || len(p.AttachConnections) > 0 || HasInstructionChanges(p.Instructions)
+}
+
+// ParseProjectManifest decodes and validates a YAML project manifest.
+func ParseProjectManifest(data []byte) (ProjectManifest, error) {
+	var m ProjectManifest
+	if err := yaml.Unmarshal(data, &m); err != nil {
+		return m, fmt.Errorf("parsing manifest: %w", err)
+	}
+	if strings.TrimSpace(m.Project.Name) == "" {
+		return m, fmt.Errorf("project.name is required")
+	}
+	if err := ValidateInstructionEntries(m.Instructions); err != nil {
+		return m, err
+	}
+	return m, nil
+}
+
+// ResolveConnections maps manifest connection references (UUID or
+// case-insensitive name) to known connections. Unknown references are an error.
+func ResolveConnections(refs []string, available []api.ConnectionSpec) ([]api.ConnectionSpec, error) {
+	var resolved []api.ConnectionSpec
+	seen := make(map[string]bool)
+	for _, ref := range refs {
+		var match *api.ConnectionSpec
+		for i := range available {
+			c := &available[i]
+			if c.UUID == ref || strings.EqualFold(c.Name, ref) {
+				match = c
+				break
+			}
+		}
+		if match == nil {
+			return nil, fmt.Errorf("connection %q not found", ref)
+		}
+		if seen[match.UUID] {
+			continue
+		}
+		seen[match.UUID] = true
+		resolved = append(resolved, *match)
+	}
+	return resolved, nil
+}
+
+// PlanProjectApply compares a manifest against the live project state.
+// existing is nil when no project with the manifest's name exists yet.
+func PlanProjectApply(m ProjectManifest, existing *api.ProjectDetail, desiredConns, attached []api.ConnectionSpec, instructions []api.InstructionSpec) ProjectPlan {
+	plan := ProjectPlan{
+		Name:        m.Project.Name,
+		Description: m.Project.Description,
+	}
+
+	switch {
+	case existing == nil:
+		plan.ProjectAction = SyncCreate
+	case m.Project.Description != "" && existing.Description != m.Project.Description:
+		plan.ProjectAction = SyncUpdate
+		plan.ProjectUUID = existing.UUID
+	default:
+		plan.ProjectAction = SyncUnchanged
+		plan.ProjectUUID = existing.UUID
+	}
+
+	isAttached := make(map[string]bool)
+	for _, c := range attached {
+		isAttached[c.UUID] = true
+	}
+	for _, c := range desiredConns {
+		if !isAttached[c.UUID] {
+			plan.AttachConnections = append(plan.AttachConnections, c)
+		}
+	}
+
+	plan.Instructions = PlanInstructionSync(m.Instructions, instructions, false)
+	return plan
+}
+
+// PlanProjectClone plans a new project named name that copies source's
+// description and, when given, its connections and instructions. Disabled
+// instructions are copied disabled.
+func PlanProjectClone(source *api.ProjectDetail, name string, conns []api.ConnectionSpec, instructions []api.InstructionSpec) ProjectPlan {
+	m := ProjectManifest{
+		Project:      ProjectManifestSpec{Name: name, Description: source.Description},
+		Instructions: ExportInstructions(instructions).Instructions,
+	}
+	return PlanProjectApply(m, nil, conns, nil, nil)
+}
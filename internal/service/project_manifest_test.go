@@ -0,0 +1,138 @@
+package service
+
+import (
+	"strings"
+	"testing"
+
+	"hawkeye-cli/internal/api"
+)
+
+const sampleProjectManifest = `
+project:
+  name: checkout-prod
+  description: Checkout service, production
+connections:
+  - Datadog Prod
+  - c2
+instructions:
+  - name: ignore-404
+    type: filter
+    content: Ignore 404 responses
+`
+
+func TestParseProjectManifest(t *testing.T) {
+	m, err := ParseProjectManifest([]byte(sampleProjectManifest))
+	if err != nil {
+		t.Fatalf("ParseProjectManifest() error = %v", err)
+	}
+	if m.Project.Name != "checkout-prod" || len(m.Connections) != 2 || len(m.Instructions) != 1 {
+		t.Errorf("manifest = %+v", m)
+	}
+
+	if _, err := ParseProjectManifest([]byte("project: {}\n")); err == nil || !strings.Contains(err.Error(), "project.name") {
+		t.Errorf("missing name error = %v", err)
+	}
+	if _, err := ParseProjectManifest([]byte("project: {name: x}\ninstructions:\n- {name: a, type: bad, content: c}\n")); err == nil {
+		t.Error("expected invalid instruction type error")
+	}
+}
+
+func TestResolveConnections(t *testing.T) {
+	available := []api.ConnectionSpec{
+		{UUID: "c1", Name: "Datadog Prod"},
+		{UUID: "c2", Name: "CloudWatch"},
+	}
+	tests := []struct {
+		name    string
+		refs    []string
+		want    []string
+		wantErr bool
+	}{
+		{"by name and uuid", []string{"datadog prod", "c2"}, []string{"c1", "c2"}, false},
+		{"deduplicated", []string{"c1", "Datadog Prod"}, []string{"c1"}, false},
+		{"unknown", []string{"nope"}, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ResolveConnections(tt.refs, available)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d connections, want %d", len(got), len(tt.want))
+			}
+			for i := range got {
+				if got[i].UUID != tt.want[i] {
+					t.Errorf("got[%d] = %q, want %q", i, got[i].UUID, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestPlanProjectApply(t *testing.T) {
+	m, _ := ParseProjectManifest([]byte(sampleProjectManifest))
+	conns := []api.ConnectionSpec{{UUID: "c1"}, {UUID: "c2"}}
+
+	t.Run("new project", func(t *testing.T) {
+		plan := PlanProjectApply(m, nil, conns, nil, nil)
+		if plan.ProjectAction != SyncCreate || len(plan.AttachConnections) != 2 || plan.Instructions[0].Action != SyncCreate {
+			t.Errorf("plan = %+v", plan)
+		}
+		if !plan.HasChanges() {
+			t.Error("HasChanges() = false, want true")
+		}
+	})
+
+	t.Run("converged project", func(t *testing.T) {
+		existing := &api.ProjectDetail{UUID: "p1", Name: "checkout-prod", Description: "Checkout service, production"}
+		instrs := []api.InstructionSpec{{UUID: "i1", Name: "ignore-404", Type: "filter", Content: "Ignore 404 responses", Enabled: true}}
+		plan := PlanProjectApply(m, existing, conns, conns, instrs)
+		if plan.ProjectAction != SyncUnchanged || plan.ProjectUUID != "p1" || len(plan.AttachConnections) != 0 {
+			t.Errorf("plan = %+v", plan)
+		}
+		if plan.HasChanges() {
+			t.Error("HasChanges() = true, want false")
+		}
+	})
+
+	t.Run("description drift", func(t *testing.T) {
+		existing := &api.ProjectDetail{UUID: "p1", Description: "old"}
+		plan := PlanProjectApply(m, existing, conns, conns[:1], nil)
+		if plan.ProjectAction != SyncUpdate || len(plan.AttachConnections) != 1 || plan.AttachConnections[0].UUID != "c2" {
+			t.Errorf("plan = %+v", plan)
+		}
+	})
+}
+
+func TestPlanProjectClone(t *testing.T) {
+	source := &api.ProjectDetail{UUID: "p1", Name: "checkout-prod", Description: "Checkout service"}
+	conns := []api.ConnectionSpec{{UUID: "c1", Name: "datadog"}}
+	instrs := []api.InstructionSpec{
+		{UUID: "i2", Name: "rca-db", Type: "rca", Content: "Check the database first", Enabled: true},
+		{UUID: "i1", Name: "ignore-404", Type: "filter", Content: "Ignore 404 responses", Enabled: false},
+	}
+
+	plan := PlanProjectClone(source, "checkout-staging", conns, instrs)
+	if plan.ProjectAction != SyncCreate || plan.ProjectUUID != "" || plan.Name != "checkout-staging" || plan.Description != "Checkout service" {
+		t.Errorf("plan = %+v", plan)
+	}
+	if len(plan.AttachConnections) != 1 || plan.AttachConnections[0].UUID != "c1" {
+		t.Errorf("AttachConnections = %+v", plan.AttachConnections)
+	}
+	if len(plan.Instructions) != 2 {
+		t.Fatalf("Instructions = %+v", plan.Instructions)
+	}
+	for _, c := range plan.Instructions {
+		if c.Action != SyncCreate || c.UUID != "" {
+			t.Errorf("instruction change = %+v, want a create", c)
+		}
+		if c.Name == "ignore-404" && c.Enabled {
+			t.Error("a disabled instruction should be cloned disabled")
+		}
+	}
+
+	if bare := PlanProjectClone(source, "empty", nil, nil); len(bare.AttachConnections) != 0 || len(bare.Instructions) != 0 {
+		t.Errorf("bare clone = %+v", bare)
+	}
+}
@@ -1,6 +1,7 @@
 package service
 
 import (
+	"fmt"
 	"strings"
 
 	"hawkeye-cli/internal/api"
@@ -30,6 +31,32 @@ func FindProject(projects []api.ProjectSpec, value string) *api.ProjectSpec {
 	return nil
 }
 
+// IsNotFound reports whether err is a 404 from the server.
+func IsNotFound(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "server returned 404")
+}
+
+// FindSessionProject looks for the project a session belongs to by calling
+// probe for every project except skip, the one already tried. A probe
+// that 404s moves on to the next project; any other error stops the
+// search. Returns nil when no project has the session.
+func FindSessionProject(projects []api.ProjectSpec, skip string, probe func(projectUUID string) error) (*api.ProjectSpec, error) {
+	for i := range projects {
+		p := &projects[i]
+		if p.UUID == skip {
+			continue
+		}
+		err := probe(p.UUID)
+		if err == nil {
+			return p, nil
+		}
+		if !IsNotFound(err) {
+			return nil, fmt.Errorf("looking for the session in project %s: %w", p.Name, err)
+		}
+	}
+	return nil, nil
+}
+
 // ProjectDetailDisplay holds display-ready project detail info.
 type ProjectDetailDisplay struct {
 	UUID        string
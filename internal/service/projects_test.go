@@ -1,6 +1,7 @@
 package service
 
 import (
+	"errors"
 	"testing"
 
 	"hawkeye-cli/internal/api"
@@ -174,3 +175,37 @@ func TestFindProject(t *testing.T) {
 		})
 	}
 }
+
+func TestFindSessionProject(t *testing.T) {
+	projects := []api.ProjectSpec{
+		{Name: "Production", UUID: "uuid-prod"},
+		{Name: "Staging", UUID: "uuid-staging"},
+		{Name: "Development", UUID: "uuid-dev"},
+	}
+	notFound := errors.New("server returned 404: session not found")
+
+	var probed []string
+	got, err := FindSessionProject(projects, "uuid-prod", func(projectUUID string) error {
+		probed = append(probed, projectUUID)
+		if projectUUID == "uuid-dev" {
+			return nil
+		}
+		return notFound
+	})
+	if err != nil || got == nil || got.UUID != "uuid-dev" {
+		t.Fatalf("got %+v, %v; want uuid-dev", got, err)
+	}
+	if len(probed) != 2 || probed[0] != "uuid-staging" {
+		t.Errorf("probed %v, want the current project skipped", probed)
+	}
+
+	got, err = FindSessionProject(projects, "", func(string) error { return notFound })
+	if got != nil || err != nil {
+		t.Errorf("no match = %+v, %v; want nil, nil", got, err)
+	}
+
+	_, err = FindSessionProject(projects, "", func(string) error { return errors.New("server returned 500: boom") })
+	if err == nil || IsNotFound(err) {
+		t.Errorf("a server error should stop the search, got %v", err)
+	}
+}
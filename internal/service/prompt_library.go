@@ -0,0 +1,56 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"hawkeye-cli/internal/api"
+)
+
+// PromptChange is what `prompts push` does to the shared prompt library.
+// Prompts are matched by their one-line name, ignoring case.
+type PromptChange struct {
+	Action    string `json:"action"` // SyncCreate, SyncUpdate or SyncUnchanged
+	UUID      string `json:"uuid,omitempty"`
+	Name      string `json:"name"`
+	Prompt    string `json:"prompt"`
+	OldPrompt string `json:"old_prompt,omitempty"`
+}
+
+// PlanPromptPush compares a local prompt with the library and returns the
+// change that publishes it.
+func PlanPromptPush(name, prompt string, library []api.InitialPrompt) (PromptChange, error) {
+	name = strings.TrimSpace(name)
+	prompt = strings.TrimSpace(prompt)
+	if name == "" {
+		return PromptChange{}, fmt.Errorf("prompt name is required")
+	}
+	if prompt == "" {
+		return PromptChange{}, fmt.Errorf("prompt %q has no text", name)
+	}
+	c := PromptChange{Action: SyncCreate, Name: name, Prompt: prompt}
+	for _, p := range library {
+		if !strings.EqualFold(strings.TrimSpace(p.Oneliner), name) {
+			continue
+		}
+		c.UUID = p.UUID
+		c.Name = p.Oneliner
+		c.OldPrompt = p.Prompt
+		c.Action = SyncUpdate
+		if strings.TrimSpace(p.Prompt) == prompt {
+			c.Action = SyncUnchanged
+		}
+		break
+	}
+	return c, nil
+}
+
+// FindLibraryPrompt returns the library prompt with the given UUID.
+func FindLibraryPrompt(library []api.InitialPrompt, uuid string) (api.InitialPrompt, bool) {
+	for _, p := range library {
+		if p.UUID == uuid {
+			return p, true
+		}
+	}
+	return api.InitialPrompt{}, false
+}
@@ -0,0 +1,42 @@
+package service
+
+import (
+	"testing"
+
+	"hawkeye-cli/internal/api"
+)
+
+func TestPlanPromptPush(t *testing.T) {
+	library := []api.InitialPrompt{
+		{UUID: "p-1", Oneliner: "Checkout latency", Prompt: "Why is checkout slow?"},
+		{UUID: "p-2", Oneliner: "Error spike", Prompt: "What caused the 5xx spike?"},
+	}
+	tests := []struct {
+		name, prompt string
+		wantAction   string
+		wantUUID     string
+		wantErr      bool
+	}{
+		{"New prompt", "Check the queue depth", SyncCreate, "", false},
+		{"checkout LATENCY ", "Why is checkout slow, and since when?", SyncUpdate, "p-1", false},
+		{"Error spike", "What caused the 5xx spike?\n", SyncUnchanged, "p-2", false},
+		{" ", "text", "", "", true},
+		{"Empty", "  ", "", "", true},
+	}
+	for _, tt := range tests {
+		c, err := PlanPromptPush(tt.name, tt.prompt, library)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("PlanPromptPush(%q) error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if c.Action != tt.wantAction || c.UUID != tt.wantUUID {
+			t.Errorf("PlanPromptPush(%q) = %+v, want %s %q", tt.name, c, tt.wantAction, tt.wantUUID)
+		}
+		if c.Action == SyncUpdate && (c.OldPrompt != "Why is checkout slow?" || c.Name != "Checkout latency") {
+			t.Errorf("update keeps library name and old text: %+v", c)
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package service
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxReplayGap caps the pause between two replayed events so long stretches
+// of server-side dead air don't stall a replay.
+const maxReplayGap = 2 * time.Second
+
+// ParseReplaySpeed parses a --speed value such as "2x", "0.5", or "max".
+// "max" (or 0) replays without any delay and is returned as 0.
+func ParseReplaySpeed(s string) (float64, error) {
+	s = strings.TrimSpace(strings.ToLower(s))
+	if s == "" {
+		return 1, nil
+	}
+	if s == "max" {
+		return 0, nil
+	}
+	v, err := strconv.ParseFloat(strings.TrimSuffix(s, "x"), 64)
+	if err != nil || v < 0 {
+		return 0, fmt.Errorf("invalid speed: %s (e.g. 1x, 2x, 0.5x, max)", s)
+	}
+	return v, nil
+}
+
+// ReplayDelay returns how long to wait between two recorded events at the
+// given speed. A zero speed, missing timestamps, or out-of-order events
+// yield no delay; long gaps are capped at maxReplayGap.
+func ReplayDelay(prev, cur time.Time, speed float64) time.Duration {
+	if speed <= 0 || prev.IsZero() || cur.IsZero() || !cur.After(prev) {
+		return 0
+	}
+	d := time.Duration(float64(cur.Sub(prev)) / speed)
+	if d > maxReplayGap {
+		d = maxReplayGap
+	}
+	return d
+}
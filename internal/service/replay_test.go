@@ -0,0 +1,57 @@
+package service
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseReplaySpeed(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    float64
+		wantErr bool
+	}{
+		{"", 1, false},
+		{"2x", 2, false},
+		{"0.5", 0.5, false},
+		{"MAX", 0, false},
+		{"fast", 0, true},
+		{"-1x", 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseReplaySpeed(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseReplaySpeed(%q) = %v, want %v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReplayDelay(t *testing.T) {
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name  string
+		prev  time.Time
+		cur   time.Time
+		speed float64
+		want  time.Duration
+	}{
+		{"real time", base, base.Add(time.Second), 1, time.Second},
+		{"double speed", base, base.Add(time.Second), 2, 500 * time.Millisecond},
+		{"max speed", base, base.Add(time.Second), 0, 0},
+		{"capped", base, base.Add(time.Minute), 1, maxReplayGap},
+		{"out of order", base.Add(time.Second), base, 1, 0},
+		{"missing time", time.Time{}, base, 1, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ReplayDelay(tt.prev, tt.cur, tt.speed); got != tt.want {
+				t.Errorf("ReplayDelay() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
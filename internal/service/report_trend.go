@@ -0,0 +1,255 @@
+package service
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"hawkeye-cli/internal/api"
+)
+
+// ─── Report trends ──────────────────────────────────────────────────────────
+//
+// `hawkeye report trend` asks the incident report endpoint for one period
+// at a time and lines the numbers up, so a team can see whether MTTR, time
+// saved or noise is moving rather than reading a single all-time figure.
+
+// maxTrendPeriods bounds the number of report calls one trend makes.
+const maxTrendPeriods = 120
+
+// TrendPeriod is one bucket of a trend, [Start, End).
+type TrendPeriod struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
+
+// TrendPeriods splits the window (e.g. 12w, 90d, 6mo) ending at now into
+// periods of every (day, week or month). An empty every follows the
+// window's unit. The oldest period comes first and may be shorter when the
+// window is not a whole number of periods.
+func TrendPeriods(window, every string, now time.Time) ([]TrendPeriod, error) {
+	window = strings.ToLower(strings.TrimSpace(window))
+	var unit string
+	var n int
+	for _, u := range []string{"mo", "d", "w"} {
+		if num, ok := strings.CutSuffix(window, u); ok {
+			v, err := strconv.Atoi(num)
+			if err == nil && v > 0 {
+				unit, n = u, v
+			}
+			break
+		}
+	}
+	if unit == "" {
+		return nil, fmt.Errorf("invalid --window %q (use e.g. 30d, 12w or 6mo)", window)
+	}
+	start := windowStart(unit, n, now)
+
+	if every == "" {
+		every = map[string]string{"d": "day", "w": "week", "mo": "month"}[unit]
+	}
+	var back func(k int) time.Time // the boundary k periods before now
+	switch strings.ToLower(every) {
+	case "day", "daily":
+		back = func(k int) time.Time { return now.AddDate(0, 0, -k) }
+	case "week", "weekly":
+		back = func(k int) time.Time { return now.AddDate(0, 0, -7*k) }
+	case "month", "monthly":
+		back = func(k int) time.Time { return monthsBefore(now, k) }
+	default:
+		return nil, fmt.Errorf("invalid --every %q (use day, week or month)", every)
+	}
+
+	var periods []TrendPeriod
+	for k := 0; back(k).After(start); k++ {
+		if k == maxTrendPeriods {
+			return nil, fmt.Errorf("--window %s is more than %d periods of a %s; use a larger --every", window, maxTrendPeriods, every)
+		}
+		p := TrendPeriod{Start: back(k + 1), End: back(k)}
+		if p.Start.Before(start) {
+			p.Start = start
+		}
+		periods = append(periods, p)
+	}
+	for i, j := 0, len(periods)-1; i < j; i, j = i+1, j-1 {
+		periods[i], periods[j] = periods[j], periods[i]
+	}
+	return periods, nil
+}
+
+func windowStart(unit string, n int, now time.Time) time.Time {
+	switch unit {
+	case "d":
+		return now.AddDate(0, 0, -n)
+	case "w":
+		return now.AddDate(0, 0, -7*n)
+	}
+	return monthsBefore(now, n)
+}
+
+// monthsBefore is t moved back k calendar months, clamped to the end of
+// a shorter month (March 31 → February 28) rather than overflowing.
+func monthsBefore(t time.Time, k int) time.Time {
+	y, m, d := t.Date()
+	first := time.Date(y, m-time.Month(k), 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+	if last := first.AddDate(0, 1, -1).Day(); d > last {
+		d = last
+	}
+	return first.AddDate(0, 0, d-1)
+}
+
+// TrendMetric is a report number that can be followed over time.
+type TrendMetric struct {
+	Name         string
+	Label        string
+	Unit         string
+	HigherBetter bool
+	value        func(*api.IncidentReportResponse) float64
+}
+
+var trendMetrics = []TrendMetric{
+	{Name: "mttr", Label: "Avg MTTR", Unit: "min", value: func(r *api.IncidentReportResponse) float64 { return r.AvgMTTR }},
+	{Name: "time-saved", Label: "Time saved", Unit: "hrs", HigherBetter: true, value: func(r *api.IncidentReportResponse) float64 { return r.TotalTimeSavedHours }},
+	{Name: "noise", Label: "Noise reduction", Unit: "%", HigherBetter: true, value: func(r *api.IncidentReportResponse) float64 { return r.NoiseReduction }},
+}
+
+// ParseTrendMetric looks up a --metric name.
+func ParseTrendMetric(name string) (TrendMetric, error) {
+	var names []string
+	for _, m := range trendMetrics {
+		if strings.EqualFold(m.Name, name) {
+			return m, nil
+		}
+		names = append(names, m.Name)
+	}
+	return TrendMetric{}, fmt.Errorf("invalid --metric %q (use %s)", name, strings.Join(names, ", "))
+}
+
+// TrendPoint is a metric's value for one period. Periods without
+// incidents or investigations have no value.
+type TrendPoint struct {
+	TrendPeriod
+	Value          float64 `json:"value"`
+	HasData        bool    `json:"has_data"`
+	Incidents      int     `json:"incidents"`
+	Investigations int     `json:"investigations"`
+}
+
+// BuildTrend pairs each period with its report. reports[i] belongs to
+// periods[i]; a nil report is a period without data.
+func BuildTrend(periods []TrendPeriod, reports []*api.IncidentReportResponse, m TrendMetric) []TrendPoint {
+	points := make([]TrendPoint, len(periods))
+	for i, p := range periods {
+		points[i].TrendPeriod = p
+		if i >= len(reports) || reports[i] == nil {
+			continue
+		}
+		r := reports[i]
+		points[i].Incidents = r.TotalIncidents
+		points[i].Investigations = r.TotalInvestigations
+		if r.TotalIncidents > 0 || r.TotalInvestigations > 0 {
+			points[i].Value = m.value(r)
+			points[i].HasData = true
+		}
+	}
+	return points
+}
+
+// TrendValues returns the points' values for a sparkline, NaN where a
+// period has no data.
+func TrendValues(points []TrendPoint) []float64 {
+	values := make([]float64, len(points))
+	for i, p := range points {
+		values[i] = math.NaN()
+		if p.HasData {
+			values[i] = p.Value
+		}
+	}
+	return values
+}
+
+// ReportRangeIgnored reports whether the server answered every period with
+// the same reporting range, i.e. it does not support time-bucketed reports
+// and each period shows the all-time numbers.
+func ReportRangeIgnored(reports []*api.IncidentReportResponse) bool {
+	if len(reports) < 2 || reports[0] == nil || reports[0].StartTime == "" {
+		return false
+	}
+	for _, r := range reports[1:] {
+		if r == nil || r.StartTime != reports[0].StartTime || r.EndTime != reports[0].EndTime {
+			return false
+		}
+	}
+	return true
+}
+
+// TrendSummary describes the change from the first to the last period
+// with data, e.g. "Avg MTTR fell 12.0 min (-25%): improving".
+func TrendSummary(points []TrendPoint, m TrendMetric) string {
+	var first, last *TrendPoint
+	for i := range points {
+		if !points[i].HasData {
+			continue
+		}
+		if first == nil {
+			first = &points[i]
+		}
+		last = &points[i]
+	}
+	if first == nil || first == last {
+		return "not enough data for a trend"
+	}
+	diff := last.Value - first.Value
+	if math.Abs(diff) < 0.05 {
+		return fmt.Sprintf("%s is flat at %s", m.Label, FormatTrendValue(last.Value, m))
+	}
+	verb := "rose"
+	if diff < 0 {
+		verb = "fell"
+	}
+	s := fmt.Sprintf("%s %s %s", m.Label, verb, FormatTrendValue(math.Abs(diff), m))
+	if first.Value != 0 {
+		s += fmt.Sprintf(" (%+.0f%%)", diff/first.Value*100)
+	}
+	if (diff > 0) == m.HigherBetter {
+		return s + ": improving"
+	}
+	return s + ": getting worse"
+}
+
+// FormatTrendValue renders a metric value with its unit.
+func FormatTrendValue(v float64, m TrendMetric) string {
+	if m.Unit == "%" {
+		return fmt.Sprintf("%.1f%%", v)
+	}
+	return fmt.Sprintf("%.1f %s", v, m.Unit)
+}
+
+// WriteTrendCSV writes the trend as CSV with one row per period. Periods
+// without data have an empty value.
+func WriteTrendCSV(w io.Writer, points []TrendPoint, m TrendMetric) error {
+	cw := csv.NewWriter(w)
+	header := []string{"start", "end", m.Name, "incidents", "investigations"}
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+	for _, p := range points {
+		value := ""
+		if p.HasData {
+			value = strconv.FormatFloat(p.Value, 'f', 2, 64)
+		}
+		row := []string{
+			p.Start.Format(time.RFC3339), p.End.Format(time.RFC3339), value,
+			strconv.Itoa(p.Incidents), strconv.Itoa(p.Investigations),
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,118 @@
+package service
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"hawkeye-cli/internal/api"
+)
+
+func TestTrendPeriods(t *testing.T) {
+	now := time.Date(2026, 3, 31, 12, 0, 0, 0, time.UTC)
+
+	weeks, err := TrendPeriods("12w", "", now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(weeks) != 12 {
+		t.Fatalf("got %d periods, want 12", len(weeks))
+	}
+	if !weeks[11].End.Equal(now) || !weeks[11].Start.Equal(now.AddDate(0, 0, -7)) {
+		t.Errorf("last period = %+v", weeks[11])
+	}
+	if !weeks[0].Start.Equal(now.AddDate(0, 0, -84)) {
+		t.Errorf("first period starts %v", weeks[0].Start)
+	}
+	for i := 1; i < len(weeks); i++ {
+		if !weeks[i].Start.Equal(weeks[i-1].End) {
+			t.Errorf("gap between periods %d and %d", i-1, i)
+		}
+	}
+
+	// 10 days by week: the oldest period is clipped to the window.
+	clipped, err := TrendPeriods("10d", "week", now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(clipped) != 2 || !clipped[0].Start.Equal(now.AddDate(0, 0, -10)) || !clipped[0].End.Equal(now.AddDate(0, 0, -7)) {
+		t.Errorf("clipped = %+v", clipped)
+	}
+
+	months, err := TrendPeriods("6mo", "", now)
+	if err != nil || len(months) != 6 {
+		t.Errorf("6mo = %d periods, %v", len(months), err)
+	}
+
+	for _, bad := range [][2]string{{"12", ""}, {"0w", ""}, {"12w", "hour"}, {"2y", ""}, {"365d", "day"}} {
+		if _, err := TrendPeriods(bad[0], bad[1], now); err == nil {
+			t.Errorf("TrendPeriods(%q, %q) should fail", bad[0], bad[1])
+		}
+	}
+}
+
+func TestBuildTrend(t *testing.T) {
+	m, err := ParseTrendMetric("MTTR")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ParseTrendMetric("latency"); err == nil {
+		t.Error("unknown metric should fail")
+	}
+
+	now := time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC)
+	periods, _ := TrendPeriods("4w", "", now)
+	reports := []*api.IncidentReportResponse{
+		{AvgMTTR: 40, TotalIncidents: 10},
+		{},
+		nil,
+		{AvgMTTR: 30, TotalIncidents: 8, TotalInvestigations: 8},
+	}
+	points := BuildTrend(periods, reports, m)
+	if !points[0].HasData || points[0].Value != 40 || points[1].HasData || points[2].HasData || points[3].Investigations != 8 {
+		t.Errorf("points = %+v", points)
+	}
+	if v := TrendValues(points); v[0] != 40 || !math.IsNaN(v[1]) || v[3] != 30 {
+		t.Errorf("TrendValues = %v", v)
+	}
+	if got, want := TrendSummary(points, m), "Avg MTTR fell 10.0 min (-25%): improving"; got != want {
+		t.Errorf("TrendSummary = %q, want %q", got, want)
+	}
+
+	noise, _ := ParseTrendMetric("noise")
+	if got := TrendSummary(BuildTrend(periods, reports, noise), noise); got != "Noise reduction is flat at 0.0%" {
+		t.Errorf("noise summary = %q", got)
+	}
+	if got := TrendSummary(points[:1], m); got != "not enough data for a trend" {
+		t.Errorf("single point summary = %q", got)
+	}
+
+	var b strings.Builder
+	if err := WriteTrendCSV(&b, points[:2], m); err != nil {
+		t.Fatal(err)
+	}
+	want := "start,end,mttr,incidents,investigations\n" +
+		"2026-03-03T00:00:00Z,2026-03-10T00:00:00Z,40.00,10,0\n" +
+		"2026-03-10T00:00:00Z,2026-03-17T00:00:00Z,,0,0\n"
+	if b.String() != want {
+		t.Errorf("CSV =\n%s\nwant\n%s", b.String(), want)
+	}
+}
+
+func TestReportRangeIgnored(t *testing.T) {
+	same := func() *api.IncidentReportResponse {
+		return &api.IncidentReportResponse{StartTime: "2025-01-01", EndTime: "2025-06-30"}
+	}
+	if !ReportRangeIgnored([]*api.IncidentReportResponse{same(), same(), same()}) {
+		t.Error("identical ranges should be detected")
+	}
+	other := same()
+	other.StartTime = "2025-02-01"
+	if ReportRangeIgnored([]*api.IncidentReportResponse{same(), other}) {
+		t.Error("different ranges are not ignored")
+	}
+	if ReportRangeIgnored([]*api.IncidentReportResponse{{}, {}}) {
+		t.Error("reports without a range say nothing")
+	}
+}
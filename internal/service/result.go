@@ -0,0 +1,109 @@
+package service
+
+import (
+	"encoding/json"
+	"strings"
+
+	"hawkeye-cli/internal/api"
+)
+
+// ─── One-shot investigation result ──────────────────────────────────────────
+//
+// `hawkeye investigate --quiet --output json` prints exactly one JSON
+// document once the stream has ended, so automation does not have to
+// scrape decorated output or stitch inspect and summary calls together.
+// Fields are only ever added to InvestigationResult, never renamed.
+
+// InvestigationResult is the document printed by a JSON one-shot
+// investigation. Slices are always present, empty rather than null.
+type InvestigationResult struct {
+	SessionUUID string         `json:"session_uuid"`
+	FinalAnswer string         `json:"final_answer"`
+	ActionItems []string       `json:"action_items"`
+	Sources     []StreamSource `json:"sources"`
+	Scores      *ResultScores  `json:"scores,omitempty"`
+	ConsoleURL  string         `json:"console_url"`
+	Error       string         `json:"error,omitempty"`
+}
+
+// ResultScores are the RCA quality scores, present once the backend has
+// scored the session.
+type ResultScores struct {
+	Accuracy     float64 `json:"accuracy"`
+	Completeness float64 `json:"completeness"`
+	ScoredBy     string  `json:"scored_by,omitempty"`
+}
+
+// StreamSource is a piece of evidence the stream cited.
+type StreamSource struct {
+	ID       string `json:"id,omitempty"`
+	Title    string `json:"title"`
+	Category string `json:"category,omitempty"`
+}
+
+// SourceCollector records the sources cited by a prompt stream without
+// printing anything. Use Handle as the stream callback.
+type SourceCollector struct {
+	sources []StreamSource
+	seen    map[string]bool
+}
+
+// Handle is a StreamCallback that records CONTENT_TYPE_SOURCES parts,
+// once each by ID (or title when there is none). Parts that are not JSON
+// are kept as titles.
+func (c *SourceCollector) Handle(resp *api.ProcessPromptResponse) {
+	if resp == nil || resp.Message == nil || resp.Message.Content == nil {
+		return
+	}
+	if resp.Message.Content.ContentType != "CONTENT_TYPE_SOURCES" {
+		return
+	}
+	if c.seen == nil {
+		c.seen = map[string]bool{}
+	}
+	for _, raw := range resp.Message.Content.Parts {
+		var s StreamSource
+		if err := json.Unmarshal([]byte(raw), &s); err != nil {
+			s = StreamSource{Title: strings.TrimSpace(raw)}
+		}
+		key := firstNonEmpty(s.ID, s.Title)
+		if key == "" || c.seen[key] {
+			continue
+		}
+		c.seen[key] = true
+		if s.Title == "" {
+			s.Title = s.ID
+		}
+		c.sources = append(c.sources, s)
+	}
+}
+
+// Sources returns the sources in the order they were first cited.
+func (c *SourceCollector) Sources() []StreamSource {
+	return c.sources
+}
+
+// BuildInvestigationResult assembles the one-shot document. summary may
+// be nil when it could not be fetched; action items and scores are then
+// left empty.
+func BuildInvestigationResult(sessionUUID, answer string, sources []StreamSource, summary *api.GetSessionSummaryResponse, consoleURL string) InvestigationResult {
+	r := InvestigationResult{
+		SessionUUID: sessionUUID,
+		FinalAnswer: answer,
+		ActionItems: []string{},
+		Sources:     []StreamSource{},
+		ConsoleURL:  consoleURL,
+	}
+	r.Sources = append(r.Sources, sources...)
+	if summary != nil && summary.SessionSummary != nil {
+		r.ActionItems = append(r.ActionItems, summary.SessionSummary.ActionItems...)
+	}
+	if scores := ExtractScores(summary); scores.HasScores {
+		r.Scores = &ResultScores{
+			Accuracy:     scores.Accuracy.Score,
+			Completeness: scores.Completeness.Score,
+			ScoredBy:     scores.ScoredBy,
+		}
+	}
+	return r
+}
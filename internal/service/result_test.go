@@ -0,0 +1,63 @@
+package service
+
+import (
+	"encoding/json"
+	"testing"
+
+	"hawkeye-cli/internal/api"
+)
+
+func TestSourceCollector(t *testing.T) {
+	event := func(ct string, parts ...string) *api.ProcessPromptResponse {
+		return &api.ProcessPromptResponse{Message: &api.Message{Content: &api.Content{ContentType: ct, Parts: parts}}}
+	}
+	var c SourceCollector
+	c.Handle(nil)
+	c.Handle(event("CONTENT_TYPE_CHAT_RESPONSE", `{"id":"x","title":"ignored"}`))
+	c.Handle(event("CONTENT_TYPE_SOURCES", `{"id":"s1","category":"logs","title":"checkout.errors"}`, `{"id":"s2"}`))
+	c.Handle(event("CONTENT_TYPE_SOURCES", `{"id":"s1","category":"logs","title":"checkout.errors"}`, "plain title", ""))
+
+	got := c.Sources()
+	want := []StreamSource{
+		{ID: "s1", Category: "logs", Title: "checkout.errors"},
+		{ID: "s2", Title: "s2"},
+		{Title: "plain title"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("Sources() = %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("source %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestBuildInvestigationResult(t *testing.T) {
+	t.Run("no summary", func(t *testing.T) {
+		r := BuildInvestigationResult("s-1", "answer", nil, nil, "")
+		data, _ := json.Marshal(r)
+		want := `{"session_uuid":"s-1","final_answer":"answer","action_items":[],"sources":[],"console_url":""}`
+		if string(data) != want {
+			t.Errorf("got %s\nwant %s", data, want)
+		}
+	})
+
+	t.Run("summary with scores", func(t *testing.T) {
+		summary := &api.GetSessionSummaryResponse{SessionSummary: &api.SessionSummary{
+			ActionItems: []string{"Raise the pool size"},
+			AnalysisScore: &api.AnalysisScore{
+				Accuracy:     api.ScoreSection{Score: 90},
+				Completeness: api.ScoreSection{Score: 75.5},
+				ScoredBy:     "grader",
+			},
+		}}
+		r := BuildInvestigationResult("s-1", "answer", []StreamSource{{Title: "db"}}, summary, "https://app/s-1")
+		if len(r.ActionItems) != 1 || len(r.Sources) != 1 || r.ConsoleURL != "https://app/s-1" {
+			t.Errorf("got %+v", r)
+		}
+		if r.Scores == nil || r.Scores.Accuracy != 90 || r.Scores.Completeness != 75.5 || r.Scores.ScoredBy != "grader" {
+			t.Errorf("Scores = %+v", r.Scores)
+		}
+	})
+}
@@ -0,0 +1,53 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ─── Roles ──────────────────────────────────────────────────────────────────
+//
+// The user's role in the active organization is saved at login (and when
+// switching organizations) so admin-only commands can stop up front with an
+// explanation, instead of failing with a bare 403 halfway through a wizard.
+// An unknown role never blocks anything; the server has the final word.
+
+// NormalizeRole turns a backend role such as "USER_ROLE_ADMIN" or "Admin"
+// into a short lower-case name such as "admin".
+func NormalizeRole(role string) string {
+	r := strings.ToUpper(strings.TrimSpace(role))
+	r = strings.TrimPrefix(r, "USER_ROLE_")
+	r = strings.TrimPrefix(r, "ROLE_")
+	return strings.ToLower(r)
+}
+
+// IsAdminRole reports whether role administers the organization.
+func IsAdminRole(role string) bool {
+	switch NormalizeRole(role) {
+	case "admin", "owner", "org_admin", "organization_admin", "super_admin", "superadmin":
+		return true
+	}
+	return false
+}
+
+// CheckAdmin returns an error explaining that what needs an organization
+// admin when role is known and is not one.
+func CheckAdmin(role, what string) error {
+	if strings.TrimSpace(role) == "" || IsAdminRole(role) {
+		return nil
+	}
+	return fmt.Errorf("%s needs an organization admin and your role is %s; ask an admin, or log in again if your role changed",
+		what, NormalizeRole(role))
+}
+
+// ExplainForbidden adds the user's role to a 403 from the server, which
+// otherwise only says the request was forbidden.
+func ExplainForbidden(err error, role string) error {
+	if err == nil || !strings.Contains(err.Error(), "server returned 403") {
+		return err
+	}
+	if r := NormalizeRole(role); r != "" {
+		return fmt.Errorf("permission denied for your role (%s); ask an organization admin: %w", r, err)
+	}
+	return fmt.Errorf("permission denied; your role may not allow this, ask an organization admin: %w", err)
+}
@@ -0,0 +1,61 @@
+package service
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestNormalizeRole(t *testing.T) {
+	tests := map[string]string{
+		"USER_ROLE_ADMIN": "admin",
+		"Admin":           "admin",
+		" ROLE_VIEWER ":   "viewer",
+		"member":          "member",
+		"":                "",
+	}
+	for in, want := range tests {
+		if got := NormalizeRole(in); got != want {
+			t.Errorf("NormalizeRole(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestCheckAdmin(t *testing.T) {
+	tests := []struct {
+		role    string
+		wantErr bool
+	}{
+		{"", false},
+		{"ADMIN", false},
+		{"USER_ROLE_OWNER", false},
+		{"USER_ROLE_MEMBER", true},
+		{"viewer", true},
+	}
+	for _, tt := range tests {
+		err := CheckAdmin(tt.role, "projects delete")
+		if (err != nil) != tt.wantErr {
+			t.Errorf("CheckAdmin(%q) = %v, wantErr %v", tt.role, err, tt.wantErr)
+		}
+		if err != nil && !strings.Contains(err.Error(), "projects delete needs an organization admin") {
+			t.Errorf("CheckAdmin(%q) = %v", tt.role, err)
+		}
+	}
+}
+
+func TestExplainForbidden(t *testing.T) {
+	forbidden := errors.New("deleting project: server returned 403: forbidden")
+	if got := ExplainForbidden(forbidden, "USER_ROLE_MEMBER"); !strings.Contains(got.Error(), "your role (member)") || !errors.Is(got, forbidden) {
+		t.Errorf("ExplainForbidden() = %v", got)
+	}
+	if got := ExplainForbidden(forbidden, ""); !strings.Contains(got.Error(), "permission denied") {
+		t.Errorf("ExplainForbidden() without role = %v", got)
+	}
+	other := errors.New("server returned 500: boom")
+	if got := ExplainForbidden(other, "member"); got != other {
+		t.Errorf("ExplainForbidden() changed %v", got)
+	}
+	if ExplainForbidden(nil, "member") != nil {
+		t.Error("ExplainForbidden(nil) != nil")
+	}
+}
@@ -1,6 +1,10 @@
 package service
 
 import (
+	"fmt"
+	"strings"
+	"time"
+
 	"hawkeye-cli/internal/api"
 )
 
@@ -67,3 +71,110 @@ func ExtractScores(resp *api.GetSessionSummaryResponse) ScoreDisplay {
 
 	return display
 }
+
+// ScoresMarkdown renders scores as a markdown section, e.g. for a CI job
+// summary.
+func ScoresMarkdown(sessionUUID string, s ScoreDisplay) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "### RCA Quality Scores\n\nSession `%s`\n\n", sessionUUID)
+	if !s.HasScores {
+		b.WriteString("No RCA scores available for this session.\n")
+		return b.String()
+	}
+	b.WriteString("| Metric | Score | Notes |\n|---|---|---|\n")
+	fmt.Fprintf(&b, "| Accuracy | %.1f/100 | %s |\n", s.Accuracy.Score, markdownCell(s.Accuracy.Summary))
+	fmt.Fprintf(&b, "| Completeness | %.1f/100 | %s |\n", s.Completeness.Score, markdownCell(s.Completeness.Summary))
+	if s.TimeSaved != nil {
+		fmt.Fprintf(&b, "| Time saved | %.0f min | %.0f min standard vs %.0f min with Hawkeye |\n",
+			s.TimeSaved.TimeSavedMinutes, s.TimeSaved.StandardInvestigationMin, s.TimeSaved.HawkeyeInvestigationMin)
+	}
+	for _, sec := range []struct {
+		title string
+		items []string
+	}{
+		{"Strengths", s.Qualitative.Strengths},
+		{"Improvements", s.Qualitative.Improvements},
+	} {
+		if len(sec.items) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "\n**%s**\n\n", sec.title)
+		for _, item := range sec.items {
+			fmt.Fprintf(&b, "- %s\n", item)
+		}
+	}
+	return b.String()
+}
+
+// markdownCell makes text safe for a single markdown table cell.
+func markdownCell(s string) string {
+	s = strings.ReplaceAll(s, "|", "\\|")
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// summaryPollInterval is how often WaitForSummary polls; tests shorten it.
+var summaryPollInterval = 10 * time.Second
+
+// WaitForSummary calls fetch until ready accepts the response or timeout
+// elapses. Summaries and scores are generated after an investigation
+// finishes, so the first fetches often come back empty. The last wait is
+// cut to the time left, so a timeout shorter than the poll interval still
+// gets a fetch at the deadline.
+func WaitForSummary(fetch func() (*api.GetSessionSummaryResponse, error), ready func(*api.GetSessionSummaryResponse) bool, timeout time.Duration) (*api.GetSessionSummaryResponse, error) {
+	deadline := time.Now().Add(timeout)
+	for {
+		resp, err := fetch()
+		if err != nil {
+			return nil, err
+		}
+		if ready(resp) {
+			return resp, nil
+		}
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return resp, fmt.Errorf("timed out after %s waiting for the session summary", timeout)
+		}
+		time.Sleep(min(summaryPollInterval, remaining))
+	}
+}
+
+// HasSummary reports whether a summary response carries generated summary
+// content.
+func HasSummary(resp *api.GetSessionSummaryResponse) bool {
+	if resp == nil || resp.SessionSummary == nil {
+		return false
+	}
+	s := resp.SessionSummary
+	return s.Analysis != "" || len(s.ActionItems) > 0 ||
+		(s.ShortSummary != nil && s.ShortSummary.Analysis != "")
+}
+
+// HasScores reports whether a summary response carries RCA scores.
+func HasScores(resp *api.GetSessionSummaryResponse) bool {
+	return ExtractScores(resp).HasScores
+}
+
+// ScoreThresholds are the minimum scores a session must reach; zero
+// disables a check.
+type ScoreThresholds struct {
+	MinAccuracy     float64
+	MinCompleteness float64
+}
+
+// Check returns a description of every threshold s falls below.
+func (t ScoreThresholds) Check(s ScoreDisplay) []string {
+	if t.MinAccuracy == 0 && t.MinCompleteness == 0 {
+		return nil
+	}
+	if !s.HasScores {
+		return []string{"no RCA scores available"}
+	}
+	var failures []string
+	if t.MinAccuracy > 0 && s.Accuracy.Score < t.MinAccuracy {
+		failures = append(failures, fmt.Sprintf("accuracy %.1f < %.1f", s.Accuracy.Score, t.MinAccuracy))
+	}
+	if t.MinCompleteness > 0 && s.Completeness.Score < t.MinCompleteness {
+		failures = append(failures, fmt.Sprintf("completeness %.1f < %.1f", s.Completeness.Score, t.MinCompleteness))
+	}
+	return failures
+}
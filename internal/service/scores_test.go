@@ -1,7 +1,9 @@
 package service
 
 import (
+	"strings"
 	"testing"
+	"time"
 
 	"hawkeye-cli/internal/api"
 )
@@ -130,3 +132,117 @@ func TestExtractScores(t *testing.T) {
 		}
 	})
 }
+
+func TestScoresMarkdown(t *testing.T) {
+	s := ScoreDisplay{
+		HasScores:    true,
+		Accuracy:     ScoreSectionDisplay{Score: 92, Summary: "Root cause | confirmed"},
+		Completeness: ScoreSectionDisplay{Score: 80.5},
+		Qualitative:  QualSectionDisplay{Improvements: []string{"Check replicas"}},
+	}
+	got := ScoresMarkdown("sess-1", s)
+	for _, want := range []string{"Session `sess-1`", "| Accuracy | 92.0/100 | Root cause \\| confirmed |", "| Completeness | 80.5/100 |  |", "**Improvements**\n\n- Check replicas"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("ScoresMarkdown() missing %q:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "Strengths") {
+		t.Errorf("ScoresMarkdown() rendered empty Strengths section:\n%s", got)
+	}
+
+	if got := ScoresMarkdown("sess-1", ScoreDisplay{}); !strings.Contains(got, "No RCA scores") {
+		t.Errorf("ScoresMarkdown() without scores = %q", got)
+	}
+}
+
+func TestScoreThresholdsCheck(t *testing.T) {
+	scored := ScoreDisplay{
+		HasScores:    true,
+		Accuracy:     ScoreSectionDisplay{Score: 85},
+		Completeness: ScoreSectionDisplay{Score: 70},
+	}
+	tests := []struct {
+		name       string
+		thresholds ScoreThresholds
+		scores     ScoreDisplay
+		want       []string
+	}{
+		{"no thresholds", ScoreThresholds{}, ScoreDisplay{}, nil},
+		{"all pass", ScoreThresholds{MinAccuracy: 80, MinCompleteness: 70}, scored, nil},
+		{"completeness fails", ScoreThresholds{MinAccuracy: 80, MinCompleteness: 75}, scored, []string{"completeness 70.0 < 75.0"}},
+		{"both fail", ScoreThresholds{MinAccuracy: 90, MinCompleteness: 75}, scored, []string{"accuracy 85.0 < 90.0", "completeness 70.0 < 75.0"}},
+		{"no scores", ScoreThresholds{MinAccuracy: 50}, ScoreDisplay{}, []string{"no RCA scores available"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.thresholds.Check(tt.scores)
+			if strings.Join(got, "; ") != strings.Join(tt.want, "; ") {
+				t.Errorf("Check() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWaitForSummary(t *testing.T) {
+	defer func(d time.Duration) { summaryPollInterval = d }(summaryPollInterval)
+	summaryPollInterval = time.Millisecond
+
+	scored := &api.GetSessionSummaryResponse{SessionSummary: &api.SessionSummary{AnalysisScore: &api.AnalysisScore{}}}
+	calls := 0
+	fetch := func() (*api.GetSessionSummaryResponse, error) {
+		calls++
+		if calls < 3 {
+			return &api.GetSessionSummaryResponse{}, nil
+		}
+		return scored, nil
+	}
+	resp, err := WaitForSummary(fetch, HasScores, time.Second)
+	if err != nil {
+		t.Fatalf("WaitForSummary() error = %v", err)
+	}
+	if resp != scored || calls != 3 {
+		t.Errorf("WaitForSummary() = %v after %d calls, want scored after 3", resp, calls)
+	}
+
+	never := func() (*api.GetSessionSummaryResponse, error) { return &api.GetSessionSummaryResponse{}, nil }
+	if _, err := WaitForSummary(never, HasScores, 5*time.Millisecond); err == nil || !strings.Contains(err.Error(), "timed out") {
+		t.Errorf("WaitForSummary() error = %v, want timeout", err)
+	}
+
+	// A timeout shorter than the poll interval still fetches again at the
+	// deadline instead of giving up after the first try.
+	summaryPollInterval = time.Hour
+	calls = 1
+	start := time.Now()
+	resp, err = WaitForSummary(fetch, HasScores, 20*time.Millisecond)
+	if err != nil || resp != scored || calls != 3 {
+		t.Errorf("WaitForSummary() = %v, %v after %d calls, want scored after a retry", resp, err, calls)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("WaitForSummary() took %s, want about the 20ms timeout", elapsed)
+	}
+	calls = 0
+	if _, err := WaitForSummary(fetch, HasScores, 10*time.Millisecond); err == nil || calls != 2 {
+		t.Errorf("WaitForSummary() error = %v after %d calls, want timeout after 2", err, calls)
+	}
+}
+
+func TestHasSummary(t *testing.T) {
+	tests := []struct {
+		name string
+		resp *api.GetSessionSummaryResponse
+		want bool
+	}{
+		{"nil", nil, false},
+		{"no summary", &api.GetSessionSummaryResponse{}, false},
+		{"empty summary", &api.GetSessionSummaryResponse{SessionSummary: &api.SessionSummary{}}, false},
+		{"question only", &api.GetSessionSummaryResponse{SessionSummary: &api.SessionSummary{ShortSummary: &api.ShortSessionSummary{Question: "why?"}}}, false},
+		{"analysis", &api.GetSessionSummaryResponse{SessionSummary: &api.SessionSummary{Analysis: "db pool"}}, true},
+		{"action items", &api.GetSessionSummaryResponse{SessionSummary: &api.SessionSummary{ActionItems: []string{"raise pool"}}}, true},
+	}
+	for _, tt := range tests {
+		if got := HasSummary(tt.resp); got != tt.want {
+			t.Errorf("HasSummary(%s) = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,245 @@
+package service
+
+import (
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"hawkeye-cli/internal/api"
+	"hawkeye-cli/internal/config"
+)
+
+// ─── Local session search ───────────────────────────────────────────────────
+//
+// `hawkeye search` answers "didn't we solve this before?" from a local
+// index of sessions kept in the profile's cache directory. Sessions get
+// into it as they are inspected or summarized, and in bulk with --sync.
+// Each entry holds the prompts, final answers and summary of a session;
+// a search ranks the sessions that contain every term and shows a snippet
+// around the best match. The file itself is config.SearchIndex; this file
+// only fills and ranks it.
+
+// IndexStale reports whether s is missing from the index or changed since
+// it was indexed.
+func IndexStale(idx *config.SearchIndex, s api.SessionInfo) bool {
+	doc, ok := idx.Sessions[s.SessionUUID]
+	return !ok || doc.LastUpdate != s.LastUpdate
+}
+
+// IndexInspect indexes a session from its inspect response, keeping any
+// summary already indexed for it.
+func IndexInspect(idx *config.SearchIndex, projectUUID string, resp *api.SessionInspectResponse) *config.SearchDoc {
+	if resp == nil || resp.SessionInfo == nil || resp.SessionInfo.SessionUUID == "" {
+		return nil
+	}
+	s := resp.SessionInfo
+	doc := idx.Doc(s.SessionUUID)
+	doc.ProjectUUID = firstNonEmpty(s.ProjectUUID, projectUUID, doc.ProjectUUID)
+	doc.Name = s.Name
+	doc.CreateTime = s.CreateTime
+	doc.LastUpdate = s.LastUpdate
+	doc.Prompts = nil
+	var answers []string
+	for _, pc := range resp.PromptCycle {
+		if p := promptText(pc); p != "" {
+			doc.Prompts = append(doc.Prompts, p)
+		}
+		if a := strings.TrimSpace(StripHTML(pc.FinalAnswer)); a != "" {
+			answers = append(answers, a)
+		}
+	}
+	doc.Answer = strings.Join(answers, "\n\n")
+	doc.IndexedAt = time.Now().UTC()
+	return doc
+}
+
+// IndexSummary adds a session's summary to the index.
+func IndexSummary(idx *config.SearchIndex, projectUUID, sessionUUID string, resp *api.GetSessionSummaryResponse) {
+	if resp == nil || resp.SessionSummary == nil {
+		return
+	}
+	sum := resp.SessionSummary
+	var parts []string
+	if sum.ShortSummary != nil {
+		parts = append(parts, sum.ShortSummary.Analysis)
+	}
+	parts = append(parts, sum.Analysis)
+	parts = append(parts, sum.ActionItems...)
+	text := strings.TrimSpace(strings.Join(nonEmpty(parts), "\n"))
+	if text == "" {
+		return
+	}
+	doc := idx.Doc(sessionUUID)
+	if doc.ProjectUUID == "" {
+		doc.ProjectUUID = projectUUID
+	}
+	if s := resp.SessionInfo; s != nil && doc.Name == "" {
+		doc.Name, doc.CreateTime = s.Name, s.CreateTime
+	}
+	doc.Summary = text
+	doc.IndexedAt = time.Now().UTC()
+}
+
+// SearchHit is a session that matched a search.
+type SearchHit struct {
+	Doc   *config.SearchDoc `json:"session"`
+	Score int               `json:"score"`
+	Field string            `json:"field"` // prompt, summary or answer: where the snippet is from
+	Text  string            `json:"-"`     // the text the snippet is cut from
+}
+
+// searchFields are a document's texts with the weight of a match in them.
+var searchFields = []struct {
+	name   string
+	weight int
+}{
+	{"summary", 3},
+	{"prompt", 2},
+	{"answer", 1},
+}
+
+func searchField(d *config.SearchDoc, name string) string {
+	switch name {
+	case "prompt":
+		return strings.Join(d.Prompts, "\n")
+	case "summary":
+		return d.Summary
+	case "answer":
+		return d.Answer
+	}
+	return ""
+}
+
+// SearchTerms splits a query into lowercase terms.
+func SearchTerms(query string) []string {
+	return strings.Fields(strings.ToLower(query))
+}
+
+// SearchIndexed returns the sessions of idx containing every term of
+// query, best first. project limits the search to one project unless it
+// is empty.
+func SearchIndexed(idx *config.SearchIndex, query, project string) []SearchHit {
+	terms := SearchTerms(query)
+	if len(terms) == 0 {
+		return nil
+	}
+	phrase := strings.Join(terms, " ")
+	var hits []SearchHit
+	for _, doc := range idx.Sessions {
+		if project != "" && doc.ProjectUUID != "" && doc.ProjectUUID != project {
+			continue
+		}
+		hit := SearchHit{Doc: doc}
+		found := map[string]bool{}
+		best := -1
+		for _, f := range searchFields {
+			text := strings.ToLower(strings.Join(strings.Fields(searchField(doc, f.name)), " "))
+			if text == "" {
+				continue
+			}
+			distinct := 0
+			for _, t := range terms {
+				if n := strings.Count(text, t); n > 0 {
+					found[t] = true
+					distinct++
+					hit.Score += f.weight * min(n, 5)
+				}
+			}
+			if strings.Contains(text, phrase) {
+				hit.Score += 5 * f.weight
+				distinct += len(terms)
+			}
+			if distinct > best {
+				best = distinct
+				hit.Field = f.name
+			}
+		}
+		if len(found) < len(terms) {
+			continue
+		}
+		hit.Text = searchField(doc, hit.Field)
+		hits = append(hits, hit)
+	}
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].Score != hits[j].Score {
+			return hits[i].Score > hits[j].Score
+		}
+		return hits[i].Doc.CreateTime > hits[j].Doc.CreateTime
+	})
+	return hits
+}
+
+// SearchSnippet cuts about width characters of text around the first match
+// of the terms, on one line, and wraps every match in open and close.
+func SearchSnippet(text string, terms []string, width int, open, close string) string {
+	text = strings.Join(strings.Fields(text), " ")
+	lower := strings.ToLower(text)
+	if len(lower) != len(text) {
+		// Lowercasing changed byte offsets; match on the text as is.
+		lower = text
+	}
+
+	first := -1
+	for _, t := range terms {
+		if i := strings.Index(lower, t); i >= 0 && (first < 0 || i < first) {
+			first = i
+		}
+	}
+	start := 0
+	if first > width/3 {
+		start = first - width/3
+	}
+	end := min(len(text), start+width)
+	// Keep to word boundaries.
+	if start > 0 {
+		if i := strings.IndexByte(text[start:end], ' '); i >= 0 {
+			start += i + 1
+		}
+	}
+	if end < len(text) {
+		if i := strings.LastIndexByte(text[start:end], ' '); i > 0 {
+			end = start + i
+		}
+	}
+	for start > 0 && !utf8.RuneStart(text[start]) {
+		start--
+	}
+	for end < len(text) && !utf8.RuneStart(text[end]) {
+		end++
+	}
+
+	var b strings.Builder
+	if start > 0 {
+		b.WriteString("…")
+	}
+	for i := start; i < end; {
+		matched := 0
+		for _, t := range terms {
+			if len(t) > matched && strings.HasPrefix(lower[i:end], t) {
+				matched = len(t)
+			}
+		}
+		if matched > 0 {
+			b.WriteString(open + text[i:i+matched] + close)
+			i += matched
+			continue
+		}
+		b.WriteByte(text[i])
+		i++
+	}
+	if end < len(text) {
+		b.WriteString("…")
+	}
+	return b.String()
+}
+
+func nonEmpty(parts []string) []string {
+	var out []string
+	for _, p := range parts {
+		if p = strings.TrimSpace(p); p != "" {
+			out = append(out, p)
+		}
+	}
+	return out
+}
@@ -0,0 +1,71 @@
+package service
+
+import (
+	"testing"
+
+	"hawkeye-cli/internal/api"
+	"hawkeye-cli/internal/config"
+)
+
+func inspectFixture(id, created, prompt, answer string) *api.SessionInspectResponse {
+	return &api.SessionInspectResponse{
+		SessionInfo: &api.SessionInfo{SessionUUID: id, Name: prompt, CreateTime: created, LastUpdate: created},
+		PromptCycle: []api.PromptCycle{{
+			Request:     &api.ProcessPromptRequest{Messages: []api.Message{{Content: &api.Content{Parts: []string{prompt}}}}},
+			FinalAnswer: answer,
+		}},
+	}
+}
+
+func TestSearchIndex(t *testing.T) {
+	idx := &config.SearchIndex{}
+	IndexInspect(idx, "p1", inspectFixture("s-march", "2026-03-02", "Why is checkout slow?",
+		"The orders-db <b>connection pool</b> was exhausted after the deploy."))
+	IndexInspect(idx, "p1", inspectFixture("s-april", "2026-04-10", "Checkout errors",
+		"Connection refused by payments; the pool was fine."))
+	IndexInspect(idx, "p2", inspectFixture("s-other", "2026-04-11", "Pool exhausted in search",
+		"Connection pool exhausted on the search cluster."))
+	IndexSummary(idx, "p1", "s-april", &api.GetSessionSummaryResponse{SessionSummary: &api.SessionSummary{
+		Analysis: "Payments rejected connections.", ActionItems: []string{"Raise the payments pool"},
+	}})
+
+	hits := SearchIndexed(idx, "connection pool exhausted", "p1")
+	if len(hits) != 1 || hits[0].Doc.SessionUUID != "s-march" || hits[0].Field != "answer" {
+		t.Fatalf("hits = %+v, want only s-march", hits)
+	}
+
+	// Without a project every project is searched; the phrase match in the
+	// prompt and answer ranks s-other first.
+	hits = SearchIndexed(idx, "Pool Exhausted", "")
+	if len(hits) != 2 || hits[0].Doc.SessionUUID != "s-other" {
+		t.Errorf("all projects: %d hits, first %+v", len(hits), hits)
+	}
+
+	if hits := SearchIndexed(idx, "payments pool", "p1"); len(hits) != 1 || hits[0].Field != "summary" {
+		t.Errorf("summary hit = %+v", hits)
+	}
+	if hits := SearchIndexed(idx, "  ", ""); hits != nil {
+		t.Errorf("empty query = %+v", hits)
+	}
+
+	// Staleness by last update.
+	if IndexStale(idx, api.SessionInfo{SessionUUID: "s-march", LastUpdate: "2026-03-02"}) {
+		t.Error("an unchanged session is not stale")
+	}
+	if !IndexStale(idx, api.SessionInfo{SessionUUID: "s-march", LastUpdate: "2026-03-05"}) ||
+		!IndexStale(idx, api.SessionInfo{SessionUUID: "s-new"}) {
+		t.Error("changed and new sessions are stale")
+	}
+}
+
+func TestSearchSnippet(t *testing.T) {
+	text := "We looked at the dashboards first. Then the orders-db connection pool\nwas exhausted at 09:02 after the checkout-api deploy raised concurrency."
+	got := SearchSnippet(text, SearchTerms("POOL exhausted"), 60, "[", "]")
+	want := "…connection [pool] was [exhausted] at 09:02 after the…"
+	if got != want {
+		t.Errorf("SearchSnippet() =\n%q\nwant\n%q", got, want)
+	}
+	if got := SearchSnippet("short pool", []string{"pool"}, 60, "<", ">"); got != "short <pool>" {
+		t.Errorf("short text = %q", got)
+	}
+}
@@ -0,0 +1,258 @@
+package service
+
+import (
+	"archive/tar"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"hawkeye-cli/internal/api"
+)
+
+// ─── Session export ─────────────────────────────────────────────────────────
+//
+// `hawkeye sessions export` archives every session of a project, for teams
+// that must keep investigation history outside the platform. Sessions are
+// paged through and written one record at a time, either as NDJSON or as a
+// tar of per-session JSON files. After every page a checkpoint next to the
+// output records the next page and the archive size at that point, so an
+// interrupted export can be resumed with --resume: the archive is cut back
+// to the checkpoint and paging continues from there. Reading and writing
+// the checkpoint file is left to the caller.
+
+// Export formats.
+const (
+	ExportNDJSON = "ndjson"
+	ExportTar    = "tar"
+)
+
+// DefaultExportPageSize is how many sessions an export lists per request.
+const DefaultExportPageSize = 100
+
+// ParseExportFormat validates --format. An empty format is taken from the
+// output file's extension: .tar is a tar, anything else NDJSON.
+func ParseExportFormat(format, output string) (string, error) {
+	switch strings.ToLower(format) {
+	case "":
+		if strings.EqualFold(filepath.Ext(output), ".tar") {
+			return ExportTar, nil
+		}
+		return ExportNDJSON, nil
+	case "ndjson", "jsonl":
+		return ExportNDJSON, nil
+	case "tar":
+		return ExportTar, nil
+	}
+	return "", fmt.Errorf("invalid --format %q (use ndjson or tar)", format)
+}
+
+// ExportRecord is one exported session. Inspect is only set when the
+// export includes full inspect payloads.
+type ExportRecord struct {
+	Session    api.SessionInfo             `json:"session"`
+	Inspect    *api.SessionInspectResponse `json:"inspect,omitempty"`
+	ExportedAt time.Time                   `json:"exported_at"`
+}
+
+// ExportCheckpoint is the resume state of an export. The options are kept
+// so a resume with different ones is refused rather than mixing archives.
+type ExportCheckpoint struct {
+	Output      string                 `json:"output"`
+	Format      string                 `json:"format"`
+	ProjectUUID string                 `json:"project_uuid"`
+	Inspect     bool                   `json:"inspect"`
+	Filters     []api.PaginationFilter `json:"filters,omitempty"`
+	NextStart   int                    `json:"next_start"`
+	Offset      int64                  `json:"offset"`
+	Exported    []string               `json:"exported,omitempty"`
+	UpdatedAt   time.Time              `json:"updated_at"`
+}
+
+// SameExport reports whether other describes the same export as cp, i.e.
+// whether a resume may continue it.
+func (cp *ExportCheckpoint) SameExport(other *ExportCheckpoint) error {
+	switch {
+	case cp.ProjectUUID != other.ProjectUUID:
+		return fmt.Errorf("the export being resumed is of project %s", cp.ProjectUUID)
+	case cp.Format != other.Format:
+		return fmt.Errorf("the export being resumed is a %s archive", cp.Format)
+	case cp.Inspect != other.Inspect:
+		return fmt.Errorf("the export being resumed was started with --inspect=%t", cp.Inspect)
+	case !slices.Equal(cp.Filters, other.Filters):
+		return fmt.Errorf("the export being resumed was started with different filters")
+	}
+	return nil
+}
+
+// ExportWriter writes export records to an archive.
+type ExportWriter interface {
+	Write(rec ExportRecord) error
+	// Offset is the archive size after the last complete record.
+	Offset() int64
+	// Close finishes the archive; it does not close the underlying file.
+	Close() error
+}
+
+// NewExportWriter returns a writer for format that appends to w, which
+// already holds offset bytes of a previous run.
+func NewExportWriter(format string, w io.Writer, offset int64) ExportWriter {
+	cw := &countingWriter{w: w, n: offset}
+	if format == ExportTar {
+		return &tarExportWriter{cw: cw, tw: tar.NewWriter(cw)}
+	}
+	return &ndjsonExportWriter{cw: cw}
+}
+
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
+
+type ndjsonExportWriter struct {
+	cw *countingWriter
+}
+
+func (w *ndjsonExportWriter) Write(rec ExportRecord) error {
+	data, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+	_, err = w.cw.Write(append(data, '\n'))
+	return err
+}
+
+func (w *ndjsonExportWriter) Offset() int64 { return w.cw.n }
+func (w *ndjsonExportWriter) Close() error  { return nil }
+
+type tarExportWriter struct {
+	cw *countingWriter
+	tw *tar.Writer
+}
+
+func (w *tarExportWriter) Write(rec ExportRecord) error {
+	data, err := json.MarshalIndent(rec, "", "  ")
+	if err != nil {
+		return err
+	}
+	hdr := &tar.Header{
+		Name:    "sessions/" + rec.Session.SessionUUID + ".json",
+		Mode:    0600,
+		Size:    int64(len(data)),
+		ModTime: rec.ExportedAt,
+		Format:  tar.FormatPAX,
+	}
+	if err := w.tw.WriteHeader(hdr); err != nil {
+		return err
+	}
+	if _, err := w.tw.Write(data); err != nil {
+		return err
+	}
+	// Flush pads the entry, so the offset is a clean entry boundary.
+	return w.tw.Flush()
+}
+
+func (w *tarExportWriter) Offset() int64 { return w.cw.n }
+func (w *tarExportWriter) Close() error  { return w.tw.Close() }
+
+// RateLimiter spaces calls out to at most a given number per second.
+type RateLimiter struct {
+	interval time.Duration
+	next     time.Time
+	sleep    func(time.Duration)
+	now      func() time.Time
+}
+
+// NewRateLimiter allows perSecond calls a second; zero or less is no limit.
+func NewRateLimiter(perSecond float64) *RateLimiter {
+	l := &RateLimiter{sleep: time.Sleep, now: time.Now}
+	if perSecond > 0 {
+		l.interval = time.Duration(float64(time.Second) / perSecond)
+	}
+	return l
+}
+
+// Wait blocks until the next call is allowed.
+func (l *RateLimiter) Wait() {
+	if l.interval <= 0 {
+		return
+	}
+	now := l.now()
+	if d := l.next.Sub(now); d > 0 {
+		l.sleep(d)
+		now = l.next
+	}
+	l.next = now.Add(l.interval)
+}
+
+// SessionExport pages through sessions and writes them to an archive.
+type SessionExport struct {
+	Fetch    SessionPageFetcher
+	Inspect  func(sessionUUID string) (*api.SessionInspectResponse, error) // nil: no inspect payloads
+	Writer   ExportWriter
+	Limiter  *RateLimiter
+	PageSize int
+	// Checkpoint is called with the resume state after every page.
+	Checkpoint func(*ExportCheckpoint) error
+	// Progress is called after every record with the total exported.
+	Progress func(exported int)
+}
+
+// Run exports from cp.NextStart until the last page, skipping sessions cp
+// already holds (new sessions shift later pages, so some are seen twice).
+func (e *SessionExport) Run(cp *ExportCheckpoint) error {
+	pageSize := e.PageSize
+	if pageSize <= 0 {
+		pageSize = DefaultExportPageSize
+	}
+	seen := make(map[string]bool, len(cp.Exported))
+	for _, id := range cp.Exported {
+		seen[id] = true
+	}
+	for {
+		e.Limiter.Wait()
+		sessions, err := e.Fetch(cp.NextStart, pageSize, cp.Filters)
+		if err != nil {
+			return fmt.Errorf("listing sessions from %d: %w", cp.NextStart, err)
+		}
+		for _, s := range sessions {
+			if seen[s.SessionUUID] {
+				continue
+			}
+			rec := ExportRecord{Session: s, ExportedAt: time.Now().UTC()}
+			if e.Inspect != nil {
+				e.Limiter.Wait()
+				if rec.Inspect, err = e.Inspect(s.SessionUUID); err != nil {
+					return fmt.Errorf("inspecting session %s: %w", s.SessionUUID, err)
+				}
+			}
+			if err := e.Writer.Write(rec); err != nil {
+				return fmt.Errorf("writing session %s: %w", s.SessionUUID, err)
+			}
+			seen[s.SessionUUID] = true
+			cp.Exported = append(cp.Exported, s.SessionUUID)
+			if e.Progress != nil {
+				e.Progress(len(cp.Exported))
+			}
+		}
+		cp.NextStart += len(sessions)
+		cp.Offset = e.Writer.Offset()
+		if e.Checkpoint != nil {
+			if err := e.Checkpoint(cp); err != nil {
+				return fmt.Errorf("saving checkpoint: %w", err)
+			}
+		}
+		if len(sessions) < pageSize {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,166 @@
+package service
+
+import (
+	"archive/tar"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"hawkeye-cli/internal/api"
+)
+
+func TestParseExportFormat(t *testing.T) {
+	tests := []struct {
+		format, output, want string
+	}{
+		{"", "q3.ndjson", ExportNDJSON},
+		{"", "q3.TAR", ExportTar},
+		{"jsonl", "q3.tar", ExportNDJSON},
+		{"tar", "q3.out", ExportTar},
+	}
+	for _, tt := range tests {
+		if got, err := ParseExportFormat(tt.format, tt.output); err != nil || got != tt.want {
+			t.Errorf("ParseExportFormat(%q, %q) = %q, %v; want %q", tt.format, tt.output, got, err, tt.want)
+		}
+	}
+	if _, err := ParseExportFormat("zip", "q3.zip"); err == nil {
+		t.Error("zip should be rejected")
+	}
+}
+
+// fakeSessions serves n sessions in pages and can fail once at a start.
+func fakeSessions(n int, failAt int) SessionPageFetcher {
+	return func(start, limit int, _ []api.PaginationFilter) ([]api.SessionInfo, error) {
+		if start == failAt {
+			failAt = -1
+			return nil, errors.New("server returned 502: bad gateway")
+		}
+		var page []api.SessionInfo
+		for i := start; i < n && i < start+limit; i++ {
+			page = append(page, api.SessionInfo{SessionUUID: fmt.Sprintf("s-%02d", i)})
+		}
+		return page, nil
+	}
+}
+
+func TestSessionExportResume(t *testing.T) {
+	var buf bytes.Buffer
+	cp := &ExportCheckpoint{Format: ExportNDJSON}
+	var saved ExportCheckpoint
+	fetch := fakeSessions(5, 4)
+	run := func() error {
+		e := &SessionExport{
+			Fetch:    fetch,
+			Writer:   NewExportWriter(ExportNDJSON, &buf, cp.Offset),
+			Limiter:  NewRateLimiter(0),
+			PageSize: 2,
+			Checkpoint: func(c *ExportCheckpoint) error {
+				saved = *c
+				saved.Exported = append([]string(nil), c.Exported...)
+				return nil
+			},
+		}
+		return e.Run(cp)
+	}
+
+	if err := run(); err == nil {
+		t.Fatal("expected the page at 4 to fail")
+	}
+	if saved.NextStart != 4 || len(saved.Exported) != 4 || saved.Offset != int64(buf.Len()) {
+		t.Fatalf("checkpoint = %+v after %d bytes", saved, buf.Len())
+	}
+
+	// Resume from the saved checkpoint.
+	cp = &saved
+	if err := run(); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 5 {
+		t.Fatalf("got %d records, want 5", len(lines))
+	}
+	var last ExportRecord
+	if err := json.Unmarshal([]byte(lines[4]), &last); err != nil || last.Session.SessionUUID != "s-04" {
+		t.Errorf("last record = %+v, %v", last, err)
+	}
+}
+
+func TestSessionExportSkipsShiftedSessions(t *testing.T) {
+	// A new session arrives between pages and pushes s-01 onto page two.
+	pages := map[int][]api.SessionInfo{
+		0: {{SessionUUID: "s-00"}, {SessionUUID: "s-01"}},
+		2: {{SessionUUID: "s-01"}, {SessionUUID: "s-02"}},
+		4: nil,
+	}
+	var buf bytes.Buffer
+	inspected := 0
+	e := &SessionExport{
+		Fetch: func(start, _ int, _ []api.PaginationFilter) ([]api.SessionInfo, error) { return pages[start], nil },
+		Inspect: func(id string) (*api.SessionInspectResponse, error) {
+			inspected++
+			return &api.SessionInspectResponse{SessionInfo: &api.SessionInfo{SessionUUID: id}}, nil
+		},
+		Writer:   NewExportWriter(ExportTar, &buf, 0),
+		Limiter:  NewRateLimiter(0),
+		PageSize: 2,
+	}
+	cp := &ExportCheckpoint{}
+	if err := e.Run(cp); err != nil {
+		t.Fatal(err)
+	}
+	if err := e.Writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if inspected != 3 || len(cp.Exported) != 3 {
+		t.Errorf("inspected %d, exported %v", inspected, cp.Exported)
+	}
+
+	tr := tar.NewReader(&buf)
+	var names []string
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		names = append(names, hdr.Name)
+		var rec ExportRecord
+		if err := json.NewDecoder(tr).Decode(&rec); err != nil || rec.Inspect == nil {
+			t.Errorf("%s: %+v, %v", hdr.Name, rec, err)
+		}
+	}
+	if want := "sessions/s-00.json sessions/s-01.json sessions/s-02.json"; strings.Join(names, " ") != want {
+		t.Errorf("entries = %v", names)
+	}
+}
+
+func TestSameExport(t *testing.T) {
+	cp := &ExportCheckpoint{ProjectUUID: "p1", Format: ExportTar, NextStart: 200, Offset: 4096, Exported: []string{"a"}}
+	if err := cp.SameExport(&ExportCheckpoint{ProjectUUID: "p1", Format: ExportTar}); err != nil {
+		t.Error(err)
+	}
+	if err := cp.SameExport(&ExportCheckpoint{ProjectUUID: "p1", Format: ExportTar, Inspect: true}); err == nil {
+		t.Error("a different --inspect should be refused")
+	}
+}
+
+func TestRateLimiter(t *testing.T) {
+	now := time.Unix(0, 0)
+	var slept time.Duration
+	l := NewRateLimiter(4)
+	l.now = func() time.Time { return now }
+	l.sleep = func(d time.Duration) { slept += d; now = now.Add(d) }
+	for range 3 {
+		l.Wait()
+	}
+	if slept != 500*time.Millisecond {
+		t.Errorf("slept %v, want 500ms for 3 calls at 4/s", slept)
+	}
+}
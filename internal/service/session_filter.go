@@ -0,0 +1,150 @@
+package service
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"hawkeye-cli/internal/api"
+)
+
+// ─── sessions --filter expressions ──────────────────────────────────────────
+//
+// `sessions --filter` takes a comma-separated list of conditions in the
+// style of kubectl's field selectors and turns each one into a backend
+// PaginationFilter:
+//
+//	status=investigated,type=incident,created>2025-06-01,name~timeout
+//
+// Each field accepts only the operators the backend can apply to it, so a
+// typo fails here with the valid choices instead of returning everything.
+
+// filterOps maps --filter operators to the backend's. ~ is a substring match.
+var filterOps = map[string]string{
+	"=":  "==",
+	"==": "==",
+	"!=": "!=",
+	">":  "gt",
+	">=": "gte",
+	"<":  "lt",
+	"<=": "lte",
+	"~":  "in",
+}
+
+// filterOpOrder lists the operators longest first, for scanning.
+var filterOpOrder = []string{">=", "<=", "!=", "==", "=", ">", "<", "~"}
+
+// sessionFilterField is a field --filter understands.
+type sessionFilterField struct {
+	key   string
+	ops   []string
+	value func(v string, now time.Time) (string, error)
+}
+
+var sessionFilterFields = map[string]sessionFilterField{
+	"status":  {"investigation_status", []string{"=", "!="}, filterStatusValue},
+	"type":    {"session_type", []string{"=", "!="}, filterTypeValue},
+	"created": {"create_time", []string{">", ">=", "<", "<="}, filterTimeValue},
+	"updated": {"last_update", []string{">", ">=", "<", "<="}, filterTimeValue},
+	"name":    {"incident_info.title", []string{"~"}, func(v string, _ time.Time) (string, error) { return v, nil }},
+}
+
+// SessionFilterFields are the fields --filter accepts, for help and errors.
+var SessionFilterFields = []string{"status", "type", "created", "updated", "name"}
+
+var filterFieldRe = regexp.MustCompile(`^[a-z_]+`)
+
+// ParseSessionFilter parses a --filter expression into backend filters.
+// now anchors relative times such as created>7d.
+func ParseSessionFilter(expr string, now time.Time) ([]api.PaginationFilter, error) {
+	var filters []api.PaginationFilter
+	for _, term := range strings.Split(expr, ",") {
+		term = strings.TrimSpace(term)
+		if term == "" {
+			continue
+		}
+		name := filterFieldRe.FindString(strings.ToLower(term))
+		field, ok := sessionFilterFields[name]
+		if !ok {
+			if name == "" {
+				name = term
+			}
+			return nil, fmt.Errorf("filter %q: unknown field %q (use %s)", term, name, strings.Join(SessionFilterFields, ", "))
+		}
+		rest := term[len(name):]
+		op := ""
+		for _, o := range filterOpOrder {
+			if strings.HasPrefix(rest, o) {
+				op = o
+				break
+			}
+		}
+		if op == "" {
+			return nil, fmt.Errorf("filter %q: expected an operator after %s (%s)", term, name, strings.Join(field.ops, " "))
+		}
+		if !filterOpAllowed(field.ops, op) {
+			return nil, fmt.Errorf("filter %q: %s does not support %s (use %s)", term, name, op, strings.Join(field.ops, " "))
+		}
+		raw := strings.TrimSpace(rest[len(op):])
+		if raw == "" {
+			return nil, fmt.Errorf("filter %q: missing value", term)
+		}
+		value, err := field.value(raw, now)
+		if err != nil {
+			return nil, fmt.Errorf("filter %q: %w", term, err)
+		}
+		filters = append(filters, api.PaginationFilter{Key: field.key, Value: value, Operator: filterOps[op]})
+	}
+	if len(filters) == 0 {
+		return nil, fmt.Errorf("empty filter (e.g. status=investigated,created>7d)")
+	}
+	return filters, nil
+}
+
+func filterOpAllowed(ops []string, op string) bool {
+	for _, o := range ops {
+		// == is another spelling of =.
+		if o == op || o == "=" && op == "==" {
+			return true
+		}
+	}
+	return false
+}
+
+func filterStatusValue(v string, _ time.Time) (string, error) {
+	v = strings.ToLower(v)
+	if strings.HasPrefix(v, "investigation_status_") {
+		return strings.ToUpper(v), nil
+	}
+	if s := normalizeStatus(v); s != v {
+		return s, nil
+	}
+	return "", fmt.Errorf("unknown status %q (use not_started, in_progress, investigated, paused, failed)", v)
+}
+
+func filterTypeValue(v string, _ time.Time) (string, error) {
+	switch strings.ToLower(v) {
+	case "incident", "session_type_incident":
+		return "SESSION_TYPE_INCIDENT", nil
+	case "chat", "session_type_chat":
+		return "SESSION_TYPE_CHAT", nil
+	}
+	return "", fmt.Errorf("unknown type %q (use incident or chat)", v)
+}
+
+// filterTimeValue keeps dates and timestamps as written, like --from and
+// --to, and turns a lookback such as 7d into a timestamp.
+func filterTimeValue(v string, now time.Time) (string, error) {
+	if _, err := time.Parse("2006-01-02", v); err == nil {
+		return v, nil
+	}
+	if _, err := time.Parse(time.RFC3339, v); err == nil {
+		return v, nil
+	}
+	t, err := ParseSince(v, now)
+	if err != nil {
+		return "", fmt.Errorf("invalid time %q (use e.g. 12h, 7d, 2w or 2006-01-02)", v)
+	}
+	return t.UTC().Format(time.RFC3339), nil
+}
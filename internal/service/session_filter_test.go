@@ -0,0 +1,46 @@
+package service
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"hawkeye-cli/internal/api"
+)
+
+func TestParseSessionFilter(t *testing.T) {
+	now := time.Date(2026, 6, 15, 12, 0, 0, 0, time.UTC)
+	got, err := ParseSessionFilter("status=investigated, type=incident,created>2025-06-01,updated>=7d,name~timeout,status!=paused", now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []api.PaginationFilter{
+		{Key: "investigation_status", Value: "INVESTIGATION_STATUS_COMPLETED", Operator: "=="},
+		{Key: "session_type", Value: "SESSION_TYPE_INCIDENT", Operator: "=="},
+		{Key: "create_time", Value: "2025-06-01", Operator: "gt"},
+		{Key: "last_update", Value: "2026-06-08T12:00:00Z", Operator: "gte"},
+		{Key: "incident_info.title", Value: "timeout", Operator: "in"},
+		{Key: "investigation_status", Value: "INVESTIGATION_STATUS_PAUSED", Operator: "!="},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseSessionFilter() =\n%+v\nwant\n%+v", got, want)
+	}
+
+	errs := []struct{ expr, want string }{
+		{"owner=me", `unknown field "owner"`},
+		{"name>abc", "name does not support > (use ~)"},
+		{"created=2025-01-01", "created does not support = (use > >= < <=)"},
+		{"status", "expected an operator after status (= !=)"},
+		{"status=", "missing value"},
+		{"status=done", `unknown status "done"`},
+		{"type=alert", `unknown type "alert"`},
+		{"created>yesterday", `invalid time "yesterday"`},
+		{" , ", "empty filter"},
+	}
+	for _, tt := range errs {
+		if _, err := ParseSessionFilter(tt.expr, now); err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("ParseSessionFilter(%q) error = %v, want %q", tt.expr, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"hawkeye-cli/internal/api"
+)
+
+// Session search modes for `sessions --search`.
+const (
+	SearchModeAuto   = "auto"   // try server-side, fall back to client-side
+	SearchModeServer = "server" // trust the server's text filter
+	SearchModeClient = "client" // page through sessions and match locally
+)
+
+const (
+	// searchPageSize is the page size used when scanning client-side.
+	searchPageSize = 100
+	// maxSearchPages bounds a client-side scan (searchPageSize * maxSearchPages sessions).
+	maxSearchPages = 10
+)
+
+// SessionPageFetcher fetches one page of sessions with the given filters.
+type SessionPageFetcher func(start, limit int, filters []api.PaginationFilter) ([]api.SessionInfo, error)
+
+// SessionSearchResult is the outcome of SearchSessions.
+type SessionSearchResult struct {
+	Sessions []api.SessionInfo
+	Mode     string // SearchModeServer or SearchModeClient
+	Scanned  int    // sessions examined client-side
+}
+
+// ParseSearchMode validates a --search-mode value.
+func ParseSearchMode(s string) (string, error) {
+	switch strings.ToLower(s) {
+	case "", SearchModeAuto:
+		return SearchModeAuto, nil
+	case SearchModeServer:
+		return SearchModeServer, nil
+	case SearchModeClient:
+		return SearchModeClient, nil
+	}
+	return "", fmt.Errorf("invalid search mode %q (valid: auto, server, client)", s)
+}
+
+// SessionMatchesSearch reports whether a session name contains search,
+// ignoring case.
+func SessionMatchesSearch(s api.SessionInfo, search string) bool {
+	return strings.Contains(strings.ToLower(s.Name), strings.ToLower(strings.TrimSpace(search)))
+}
+
+// SearchSessions finds up to limit sessions whose name contains search.
+// filters are the non-search filters (status, dates) and are always sent.
+//
+// In auto mode the search filter is sent to the server first. Many
+// deployments ignore text filters and return everything, so if any result
+// does not match, the server is assumed not to support search and the
+// sessions are scanned page by page and matched locally instead.
+func SearchSessions(fetch SessionPageFetcher, filters []api.PaginationFilter, search string, limit int, mode string) (*SessionSearchResult, error) {
+	if mode != SearchModeClient {
+		withSearch := append(append([]api.PaginationFilter{}, filters...), SessionSearchFilter(search))
+		sessions, err := fetch(0, limit, withSearch)
+		if err != nil {
+			return nil, err
+		}
+		if mode == SearchModeServer || allMatch(sessions, search) {
+			return &SessionSearchResult{Sessions: sessions, Mode: SearchModeServer}, nil
+		}
+	}
+
+	return ScanSessions(fetch, filters, limit, func(s api.SessionInfo) bool {
+		return SessionMatchesSearch(s, search)
+	})
+}
+
+// ScanSessions pages through sessions matching filters and returns up to
+// limit of them for which match is true. Used for criteria the server
+// cannot filter on, such as name search fallback and local tags.
+func ScanSessions(fetch SessionPageFetcher, filters []api.PaginationFilter, limit int, match func(api.SessionInfo) bool) (*SessionSearchResult, error) {
+	res := &SessionSearchResult{Mode: SearchModeClient}
+	for page := 0; page < maxSearchPages; page++ {
+		sessions, err := fetch(page*searchPageSize, searchPageSize, filters)
+		if err != nil {
+			return nil, err
+		}
+		for _, s := range sessions {
+			res.Scanned++
+			if match(s) {
+				res.Sessions = append(res.Sessions, s)
+				if len(res.Sessions) >= limit {
+					return res, nil
+				}
+			}
+		}
+		if len(sessions) < searchPageSize {
+			break
+		}
+	}
+	return res, nil
+}
+
+func allMatch(sessions []api.SessionInfo, search string) bool {
+	for _, s := range sessions {
+		if !SessionMatchesSearch(s, search) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,117 @@
+package service
+
+import (
+	"fmt"
+	"testing"
+
+	"hawkeye-cli/internal/api"
+)
+
+// fakeSessionServer serves names in pages; ignoreSearch mimics deployments
+// that drop text filters and return everything.
+type fakeSessionServer struct {
+	names        []string
+	ignoreSearch bool
+	calls        int
+}
+
+func (f *fakeSessionServer) fetch(start, limit int, filters []api.PaginationFilter) ([]api.SessionInfo, error) {
+	f.calls++
+	var search string
+	for _, flt := range filters {
+		if flt.Key == "incident_info.title" {
+			search = flt.Value
+		}
+	}
+	var all []api.SessionInfo
+	for i, n := range f.names {
+		s := api.SessionInfo{SessionUUID: fmt.Sprintf("s%d", i), Name: n}
+		if search != "" && !f.ignoreSearch && !SessionMatchesSearch(s, search) {
+			continue
+		}
+		all = append(all, s)
+	}
+	if start >= len(all) {
+		return nil, nil
+	}
+	end := start + limit
+	if end > len(all) {
+		end = len(all)
+	}
+	return all[start:end], nil
+}
+
+func TestSearchSessions(t *testing.T) {
+	names := make([]string, 250)
+	for i := range names {
+		names[i] = fmt.Sprintf("routine check %d", i)
+	}
+	names[5] = "Checkout LATENCY spike"
+	names[180] = "latency in payments"
+
+	tests := []struct {
+		name         string
+		ignoreSearch bool
+		mode         string
+		wantMode     string
+		wantCount    int
+		wantScanned  int
+	}{
+		{"server supports search", false, SearchModeAuto, SearchModeServer, 2, 0},
+		{"server ignores search", true, SearchModeAuto, SearchModeClient, 2, 250},
+		{"forced client", false, SearchModeClient, SearchModeClient, 2, 250},
+		{"forced server trusts results", true, SearchModeServer, SearchModeServer, 20, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := &fakeSessionServer{names: names, ignoreSearch: tt.ignoreSearch}
+			res, err := SearchSessions(srv.fetch, nil, "latency", 20, tt.mode)
+			if err != nil {
+				t.Fatalf("SearchSessions() error = %v", err)
+			}
+			if res.Mode != tt.wantMode || len(res.Sessions) != tt.wantCount || res.Scanned != tt.wantScanned {
+				t.Errorf("got mode=%s count=%d scanned=%d, want %s/%d/%d",
+					res.Mode, len(res.Sessions), res.Scanned, tt.wantMode, tt.wantCount, tt.wantScanned)
+			}
+		})
+	}
+
+	t.Run("client scan stops at limit", func(t *testing.T) {
+		srv := &fakeSessionServer{names: names, ignoreSearch: true}
+		res, _ := SearchSessions(srv.fetch, nil, "routine", 3, SearchModeClient)
+		if len(res.Sessions) != 3 || srv.calls != 1 {
+			t.Errorf("count=%d calls=%d, want 3 sessions from 1 page", len(res.Sessions), srv.calls)
+		}
+	})
+
+	t.Run("fetch error", func(t *testing.T) {
+		fail := func(int, int, []api.PaginationFilter) ([]api.SessionInfo, error) { return nil, fmt.Errorf("boom") }
+		if _, err := SearchSessions(fail, nil, "x", 5, SearchModeAuto); err == nil {
+			t.Error("expected error")
+		}
+	})
+}
+
+func TestScanSessions(t *testing.T) {
+	names := make([]string, 150)
+	srv := &fakeSessionServer{names: names}
+	tagged := map[string]bool{"s3": true, "s120": true}
+	res, err := ScanSessions(srv.fetch, nil, 20, func(s api.SessionInfo) bool { return tagged[s.SessionUUID] })
+	if err != nil {
+		t.Fatalf("ScanSessions() error = %v", err)
+	}
+	if len(res.Sessions) != 2 || res.Scanned != 150 || srv.calls != 2 {
+		t.Errorf("count=%d scanned=%d calls=%d, want 2/150/2", len(res.Sessions), res.Scanned, srv.calls)
+	}
+}
+
+func TestParseSearchMode(t *testing.T) {
+	for _, in := range []string{"", "auto", "SERVER", "client"} {
+		if _, err := ParseSearchMode(in); err != nil {
+			t.Errorf("ParseSearchMode(%q) error = %v", in, err)
+		}
+	}
+	if _, err := ParseSearchMode("fuzzy"); err == nil {
+		t.Error("ParseSearchMode(fuzzy) expected error")
+	}
+}
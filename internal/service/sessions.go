@@ -1,6 +1,8 @@
 package service
 
 import (
+	"strings"
+
 	"hawkeye-cli/internal/api"
 )
 
@@ -52,16 +54,21 @@ func BuildSessionFilters(status, from, to, search string, uninvestigated bool) [
 	}
 
 	if search != "" {
-		filters = append(filters, api.PaginationFilter{
-			Key:      "incident_info.title",
-			Value:    search,
-			Operator: "in",
-		})
+		filters = append(filters, SessionSearchFilter(search))
 	}
 
 	return filters
 }
 
+// SessionSearchFilter is the server-side text filter for a session search.
+func SessionSearchFilter(search string) api.PaginationFilter {
+	return api.PaginationFilter{
+		Key:      "incident_info.title",
+		Value:    search,
+		Operator: "in",
+	}
+}
+
 // normalizeStatus converts short status names to the full API enum.
 func normalizeStatus(status string) string {
 	switch status {
@@ -73,6 +80,10 @@ func normalizeStatus(status string) string {
 		return "INVESTIGATION_STATUS_COMPLETED"
 	case "completed":
 		return "INVESTIGATION_STATUS_COMPLETED"
+	case "paused":
+		return "INVESTIGATION_STATUS_PAUSED"
+	case "stopped", "failed":
+		return "INVESTIGATION_STATUS_STOPPED"
 	default:
 		return status
 	}
@@ -129,3 +140,70 @@ func FormatSessionRow(s api.SessionInfo) SessionDisplay {
 		SessionType: s.SessionType,
 	}
 }
+
+// LatestFinalAnswer returns the final answer of the most recent prompt cycle
+// that has one, with HTML stripped. Returns "" if no cycle has an answer.
+func LatestFinalAnswer(cycles []api.PromptCycle) string {
+	for i := len(cycles) - 1; i >= 0; i-- {
+		if answer := strings.TrimSpace(StripHTML(cycles[i].FinalAnswer)); answer != "" {
+			return answer
+		}
+	}
+	return ""
+}
+
+// maxSessionTitle is the rune limit for names derived from a prompt.
+const maxSessionTitle = 60
+
+// SessionTitle derives a session name from its first prompt: the first
+// non-empty line with whitespace collapsed, truncated at a word boundary
+// to maxSessionTitle runes.
+func SessionTitle(prompt string) string {
+	var line string
+	for _, l := range strings.Split(prompt, "\n") {
+		if l = strings.Join(strings.Fields(l), " "); l != "" {
+			line = l
+			break
+		}
+	}
+	runes := []rune(line)
+	if len(runes) <= maxSessionTitle {
+		return line
+	}
+	cut := runes[:maxSessionTitle-3]
+	for i := len(cut) - 1; i > maxSessionTitle/2; i-- {
+		if cut[i] == ' ' {
+			cut = cut[:i]
+			break
+		}
+	}
+	return strings.TrimRight(string(cut), " ,.;:-") + "..."
+}
+
+// FirstPrompt returns the text of the first user prompt in a session's
+// prompt cycles, or "" if none was recorded.
+func FirstPrompt(cycles []api.PromptCycle) string {
+	for _, pc := range cycles {
+		if text := CyclePrompt(pc); text != "" {
+			return text
+		}
+	}
+	return ""
+}
+
+// CyclePrompt returns the text of the user prompt that started a prompt
+// cycle, or "" if none was recorded.
+func CyclePrompt(pc api.PromptCycle) string {
+	if pc.Request == nil {
+		return ""
+	}
+	for _, msg := range pc.Request.Messages {
+		if msg.Content == nil {
+			continue
+		}
+		if text := strings.TrimSpace(strings.Join(msg.Content.Parts, " ")); text != "" {
+			return text
+		}
+	}
+	return ""
+}
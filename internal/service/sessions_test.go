@@ -118,6 +118,8 @@ func TestNormalizeStatus(t *testing.T) {
 		{"in_progress", "INVESTIGATION_STATUS_IN_PROGRESS"},
 		{"investigated", "INVESTIGATION_STATUS_COMPLETED"},
 		{"completed", "INVESTIGATION_STATUS_COMPLETED"},
+		{"failed", "INVESTIGATION_STATUS_STOPPED"},
+		{"paused", "INVESTIGATION_STATUS_PAUSED"},
 		{"INVESTIGATION_STATUS_CUSTOM", "INVESTIGATION_STATUS_CUSTOM"},
 	}
 
@@ -204,3 +206,66 @@ func TestFormatSessionRow(t *testing.T) {
 		})
 	}
 }
+
+func TestLatestFinalAnswer(t *testing.T) {
+	tests := []struct {
+		name   string
+		cycles []api.PromptCycle
+		want   string
+	}{
+		{"no cycles", nil, ""},
+		{"last has answer", []api.PromptCycle{{FinalAnswer: "first"}, {FinalAnswer: "second"}}, "second"},
+		{"skips empty last", []api.PromptCycle{{FinalAnswer: "first<br/>line"}, {FinalAnswer: "  "}}, "first\nline"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := LatestFinalAnswer(tt.cycles); got != tt.want {
+				t.Errorf("LatestFinalAnswer() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSessionTitle(t *testing.T) {
+	long := "Why is the checkout API returning intermittent 502 errors for customers in eu-west-1 since the deploy"
+	tests := []struct {
+		name   string
+		prompt string
+		want   string
+	}{
+		{"short", "Why are pods crashlooping?", "Why are pods crashlooping?"},
+		{"first line only", "\n  Check DB latency  \nmore detail here", "Check DB latency"},
+		{"collapses whitespace", "check\t\tthe   api", "check the api"},
+		{"truncates at word", long, "Why is the checkout API returning intermittent 502..."},
+		{"empty", "  \n ", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := SessionTitle(tt.prompt)
+			if got != tt.want {
+				t.Errorf("SessionTitle() = %q, want %q", got, tt.want)
+			}
+			if n := len([]rune(got)); n > maxSessionTitle {
+				t.Errorf("SessionTitle() length = %d, want <= %d", n, maxSessionTitle)
+			}
+		})
+	}
+}
+
+func TestFirstPrompt(t *testing.T) {
+	msg := func(parts ...string) api.Message {
+		return api.Message{Content: &api.Content{Parts: parts}}
+	}
+	cycles := []api.PromptCycle{
+		{ID: "1"},
+		{ID: "2", Request: &api.ProcessPromptRequest{Messages: []api.Message{{}, msg("  ")}}},
+		{ID: "3", Request: &api.ProcessPromptRequest{Messages: []api.Message{msg("why", "500s?")}}},
+		{ID: "4", Request: &api.ProcessPromptRequest{Messages: []api.Message{msg("follow up")}}},
+	}
+	if got := FirstPrompt(cycles); got != "why 500s?" {
+		t.Errorf("FirstPrompt() = %q, want %q", got, "why 500s?")
+	}
+	if got := FirstPrompt(nil); got != "" {
+		t.Errorf("FirstPrompt(nil) = %q, want empty", got)
+	}
+}
@@ -0,0 +1,72 @@
+package service
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ─── Share links ────────────────────────────────────────────────────────────
+//
+// `hawkeye share` asks the server for a read-only link to a session that
+// works without a platform account and expires. Servers without share
+// links get the console deep link in its read-only view instead, which
+// still needs a console login to open.
+
+// DefaultShareExpiry and MaxShareExpiry bound how long a share link lives.
+const (
+	DefaultShareExpiry = 7 * 24 * time.Hour
+	MaxShareExpiry     = 90 * 24 * time.Hour
+)
+
+// ParseShareExpiry parses an --expires value such as 12h, 7d or 2w.
+func ParseShareExpiry(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	var d time.Duration
+	if n, err := strconv.Atoi(strings.TrimRight(s, "dw")); err == nil && len(s) > 1 {
+		switch s[len(s)-1] {
+		case 'd':
+			d = time.Duration(n) * 24 * time.Hour
+		case 'w':
+			d = time.Duration(n) * 7 * 24 * time.Hour
+		}
+	} else if parsed, err := time.ParseDuration(s); err == nil {
+		d = parsed
+	}
+	switch {
+	case d <= 0:
+		return 0, fmt.Errorf("invalid --expires %q (use e.g. 12h, 7d or 2w)", s)
+	case d < time.Minute:
+		return 0, fmt.Errorf("--expires must be at least 1m")
+	case d > MaxShareExpiry:
+		return 0, fmt.Errorf("--expires can be at most %dd", int(MaxShareExpiry.Hours()/24))
+	}
+	return d, nil
+}
+
+// ReadOnlyDeepLink returns a console session link opened in the read-only
+// view, with the results tab selected.
+func ReadOnlyDeepLink(consoleURL string) string {
+	u, err := url.Parse(consoleURL)
+	if err != nil || consoleURL == "" {
+		return consoleURL
+	}
+	q := u.Query()
+	q.Set("tab", "results")
+	q.Set("view", "readonly")
+	u.RawQuery = q.Encode()
+	return u.String()
+}
+
+// ShareUnsupported reports whether a share request failed because the
+// server has no share links.
+func ShareUnsupported(err error) bool {
+	if err == nil {
+		return false
+	}
+	msg := err.Error()
+	return strings.Contains(msg, "server returned 404") || strings.Contains(msg, "server returned 405") ||
+		strings.Contains(msg, "server returned 501")
+}
@@ -0,0 +1,44 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseShareExpiry(t *testing.T) {
+	for in, want := range map[string]time.Duration{
+		"7d":  7 * 24 * time.Hour,
+		"2w":  14 * 24 * time.Hour,
+		"12h": 12 * time.Hour,
+		"90m": 90 * time.Minute,
+	} {
+		if got, err := ParseShareExpiry(in); err != nil || got != want {
+			t.Errorf("ParseShareExpiry(%q) = %v, %v, want %v", in, got, err, want)
+		}
+	}
+	for _, bad := range []string{"", "d", "0d", "-1h", "10s", "91d", "2026-10-20", "soon"} {
+		if _, err := ParseShareExpiry(bad); err == nil {
+			t.Errorf("ParseShareExpiry(%q) should fail", bad)
+		}
+	}
+}
+
+func TestReadOnlyDeepLink(t *testing.T) {
+	got := ReadOnlyDeepLink("https://x.neubird.ai/console/project/p/session/s?tab=chat")
+	if got != "https://x.neubird.ai/console/project/p/session/s?tab=results&view=readonly" {
+		t.Errorf("ReadOnlyDeepLink = %q", got)
+	}
+	if ReadOnlyDeepLink("") != "" {
+		t.Error("an empty link should stay empty")
+	}
+}
+
+func TestShareUnsupported(t *testing.T) {
+	if !ShareUnsupported(errors.New("server returned 404: not found")) || !ShareUnsupported(errors.New("server returned 501: ")) {
+		t.Error("404 and 501 mean the server has no share links")
+	}
+	if ShareUnsupported(errors.New("server returned 403: forbidden")) || ShareUnsupported(nil) {
+		t.Error("403 and nil are not unsupported")
+	}
+}
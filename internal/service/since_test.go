@@ -0,0 +1,36 @@
+package service
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseSince(t *testing.T) {
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		in   string
+		want time.Time
+		ok   bool
+	}{
+		{"7d", time.Date(2025, 3, 3, 12, 0, 0, 0, time.UTC), true},
+		{"2w", time.Date(2025, 2, 24, 12, 0, 0, 0, time.UTC), true},
+		{"12h", time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC), true},
+		{"90m", time.Date(2025, 3, 10, 10, 30, 0, 0, time.UTC), true},
+		{"2025-03-01", time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC), true},
+		{"2025-03-01T08:00:00Z", time.Date(2025, 3, 1, 8, 0, 0, 0, time.UTC), true},
+		{"", time.Time{}, false},
+		{"0d", time.Time{}, false},
+		{"-3h", time.Time{}, false},
+		{"yesterday", time.Time{}, false},
+	}
+	for _, tt := range tests {
+		got, err := ParseSince(tt.in, now)
+		if (err == nil) != tt.ok {
+			t.Errorf("ParseSince(%q) error = %v, want ok=%v", tt.in, err, tt.ok)
+			continue
+		}
+		if tt.ok && !got.Equal(tt.want) {
+			t.Errorf("ParseSince(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
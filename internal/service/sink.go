@@ -0,0 +1,215 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"hawkeye-cli/internal/api"
+)
+
+// ─── Output sinks ───────────────────────────────────────────────────────────
+//
+// A sink receives a copy of an investigation's result, e.g. to keep a
+// postmortem draft next to the runbook or to notify another system. Sinks
+// are named by URL-like specs given to --sink:
+//
+//	file://postmortems/{{session}}.md   write markdown (or JSON for .json)
+//	https://hooks.example.com/hawkeye   POST the result as JSON
+//	jira://OPS                          open a Jira issue in project OPS
+//
+// File paths may use {{session}}, {{name}}, {{project}} and {{date}}. This
+// file decides where a result goes and what is sent; writing the file and
+// making the request are left to the caller.
+
+// SinkResult is the investigation output handed to sinks.
+type SinkResult struct {
+	SessionUUID string              `json:"session_uuid"`
+	SessionName string              `json:"session_name,omitempty"`
+	ProjectUUID string              `json:"project_uuid,omitempty"`
+	Prompt      string              `json:"prompt,omitempty"`
+	Answer      string              `json:"answer,omitempty"`
+	Summary     *api.SessionSummary `json:"summary,omitempty"`
+	Scores      *ResultScores       `json:"scores,omitempty"`
+	Sources     []StreamSource      `json:"sources,omitempty"`
+	ConsoleURL  string              `json:"console_url,omitempty"`
+	Time        time.Time           `json:"time"`
+}
+
+// Sink kinds.
+const (
+	SinkFile    = "file"
+	SinkWebhook = "webhook"
+	SinkJira    = "jira"
+)
+
+// Sink is a parsed --sink destination.
+type Sink struct {
+	Kind   string // SinkFile, SinkWebhook or SinkJira
+	Target string // path template, URL or Jira project key
+}
+
+// Name describes the destination for status messages.
+func (s Sink) Name() string {
+	switch s.Kind {
+	case SinkFile:
+		return "file://" + s.Target
+	case SinkJira:
+		return "jira://" + s.Target
+	}
+	return s.Target
+}
+
+// ParseSink builds a sink from a --sink spec.
+func ParseSink(spec string) (Sink, error) {
+	scheme, rest, ok := strings.Cut(spec, "://")
+	if !ok || rest == "" {
+		return Sink{}, fmt.Errorf("invalid sink %q (expected file://<path> or https://<url>)", spec)
+	}
+	switch strings.ToLower(scheme) {
+	case "file":
+		return Sink{Kind: SinkFile, Target: rest}, nil
+	case "http", "https":
+		return Sink{Kind: SinkWebhook, Target: spec}, nil
+	case "jira":
+		project := strings.ToUpper(strings.Trim(rest, "/"))
+		if project == "" {
+			return Sink{}, fmt.Errorf("invalid sink \"jira://\" (expected jira://<project-key>)")
+		}
+		return Sink{Kind: SinkJira, Target: project}, nil
+	case "s3":
+		return Sink{}, fmt.Errorf("%s sinks are not supported yet (available: file://, http://, https://, jira://)", scheme)
+	}
+	return Sink{}, fmt.Errorf("unknown sink type %q (available: file://, http://, https://, jira://)", scheme)
+}
+
+// ParseSinks parses every spec, failing on the first invalid one.
+func ParseSinks(specs []string) ([]Sink, error) {
+	var sinks []Sink
+	for _, spec := range specs {
+		s, err := ParseSink(spec)
+		if err != nil {
+			return nil, err
+		}
+		sinks = append(sinks, s)
+	}
+	return sinks, nil
+}
+
+// Markdown renders a result as a standalone markdown document.
+func (r SinkResult) Markdown() string {
+	var b strings.Builder
+	title := r.SessionName
+	if title == "" {
+		title = "Investigation " + r.SessionUUID
+	}
+	fmt.Fprintf(&b, "# %s\n\n", title)
+	fmt.Fprintf(&b, "- Session: %s\n", r.SessionUUID)
+	if r.ConsoleURL != "" {
+		fmt.Fprintf(&b, "- Console: %s\n", r.ConsoleURL)
+	}
+	if !r.Time.IsZero() {
+		fmt.Fprintf(&b, "- Date: %s\n", r.Time.UTC().Format(time.RFC3339))
+	}
+	if r.Prompt != "" {
+		fmt.Fprintf(&b, "\n## Question\n\n%s\n", r.Prompt)
+	}
+	if r.Answer != "" {
+		fmt.Fprintf(&b, "\n## Answer\n\n%s\n", r.Answer)
+	}
+	if s := r.Summary; s != nil {
+		if s.ShortSummary != nil && s.ShortSummary.Analysis != "" {
+			fmt.Fprintf(&b, "\n## Summary\n\n%s\n", s.ShortSummary.Analysis)
+		}
+		if s.Analysis != "" {
+			fmt.Fprintf(&b, "\n## Analysis\n\n%s\n", s.Analysis)
+		}
+		if len(s.ActionItems) > 0 {
+			b.WriteString("\n## Action Items\n\n")
+			for _, item := range s.ActionItems {
+				fmt.Fprintf(&b, "- [ ] %s\n", item)
+			}
+		}
+	}
+	if sc := r.Scores; sc != nil {
+		b.WriteString("\n## RCA Scores\n\n")
+		fmt.Fprintf(&b, "- Accuracy: %.1f/100\n", sc.Accuracy)
+		fmt.Fprintf(&b, "- Completeness: %.1f/100\n", sc.Completeness)
+		if sc.ScoredBy != "" {
+			fmt.Fprintf(&b, "- Scored by: %s\n", sc.ScoredBy)
+		}
+	}
+	if len(r.Sources) > 0 {
+		b.WriteString("\n## Sources\n\n")
+		for _, src := range r.Sources {
+			if src.Category != "" {
+				fmt.Fprintf(&b, "- %s (%s)\n", src.Title, src.Category)
+			} else {
+				fmt.Fprintf(&b, "- %s\n", src.Title)
+			}
+		}
+	}
+	return b.String()
+}
+
+// FilePath expands a file sink's path template for r. A path ending in
+// "/" is treated as a directory and gets {{session}}.md appended.
+func (s Sink) FilePath(r SinkResult) string {
+	path := s.Target
+	if strings.HasSuffix(path, "/") {
+		path += "{{session}}.md"
+	}
+	date := r.Time
+	if date.IsZero() {
+		date = time.Now()
+	}
+	return strings.NewReplacer(
+		"{{session}}", r.SessionUUID,
+		"{{name}}", slugify(r.SessionName),
+		"{{project}}", r.ProjectUUID,
+		"{{date}}", date.Format("2006-01-02"),
+	).Replace(path)
+}
+
+// FileContent renders r for a file at path: JSON for a .json file,
+// markdown otherwise.
+func (r SinkResult) FileContent(path string) ([]byte, error) {
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		return json.MarshalIndent(r, "", "  ")
+	}
+	return []byte(r.Markdown()), nil
+}
+
+// JiraIssue builds the Jira create-issue request body for r, with the
+// markdown report as the description.
+func (r SinkResult) JiraIssue(project, issueType string) ([]byte, error) {
+	title := r.SessionName
+	if title == "" {
+		title = "Investigation " + r.SessionUUID
+	}
+	return json.Marshal(map[string]any{
+		"fields": map[string]any{
+			"project":     map[string]string{"key": project},
+			"issuetype":   map[string]string{"name": issueType},
+			"summary":     "RCA: " + title,
+			"description": r.Markdown(),
+		},
+	})
+}
+
+var slugUnsafe = regexp.MustCompile(`[^a-z0-9]+`)
+
+// slugify turns a session name into a file-name-safe fragment.
+func slugify(name string) string {
+	slug := strings.Trim(slugUnsafe.ReplaceAllString(strings.ToLower(name), "-"), "-")
+	if len(slug) > 60 {
+		slug = strings.TrimRight(slug[:60], "-")
+	}
+	if slug == "" {
+		return "unnamed"
+	}
+	return slug
+}
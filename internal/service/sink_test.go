@@ -0,0 +1,135 @@
+package service
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"hawkeye-cli/internal/api"
+)
+
+func TestParseSink(t *testing.T) {
+	tests := []struct {
+		spec    string
+		want    Sink
+		wantErr string
+	}{
+		{"file://postmortems/{{session}}.md", Sink{Kind: SinkFile, Target: "postmortems/{{session}}.md"}, ""},
+		{"https://hooks.example.com/x", Sink{Kind: SinkWebhook, Target: "https://hooks.example.com/x"}, ""},
+		{"http://localhost:9000/", Sink{Kind: SinkWebhook, Target: "http://localhost:9000/"}, ""},
+		{"jira://ops/", Sink{Kind: SinkJira, Target: "OPS"}, ""},
+		{"jira:///", Sink{}, "expected jira://<project-key>"},
+		{"s3://bucket/prefix/", Sink{}, "not supported yet"},
+		{"ftp://host/x", Sink{}, "unknown sink type"},
+		{"postmortems/x.md", Sink{}, "invalid sink"},
+		{"file://", Sink{}, "invalid sink"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			s, err := ParseSink(tt.spec)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ParseSink() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseSink() error = %v", err)
+			}
+			if s != tt.want {
+				t.Errorf("ParseSink() = %+v, want %+v", s, tt.want)
+			}
+		})
+	}
+}
+
+func TestSinkName(t *testing.T) {
+	for _, tt := range []struct {
+		sink Sink
+		want string
+	}{
+		{Sink{Kind: SinkFile, Target: "out/"}, "file://out/"},
+		{Sink{Kind: SinkWebhook, Target: "https://hooks.example.com/x"}, "https://hooks.example.com/x"},
+		{Sink{Kind: SinkJira, Target: "OPS"}, "jira://OPS"},
+	} {
+		if got := tt.sink.Name(); got != tt.want {
+			t.Errorf("Name() = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestSinkFilePath(t *testing.T) {
+	r := SinkResult{
+		SessionUUID: "sess-1",
+		SessionName: "Why is checkout slow?",
+		ProjectUUID: "proj-1",
+		Time:        time.Date(2025, 3, 4, 10, 0, 0, 0, time.UTC),
+	}
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"postmortems/{{session}}.md", "postmortems/sess-1.md"},
+		{"out/", "out/sess-1.md"},
+		{"{{project}}/{{date}}-{{name}}.json", "proj-1/2025-03-04-why-is-checkout-slow.json"},
+	}
+	for _, tt := range tests {
+		s := Sink{Kind: SinkFile, Target: tt.path}
+		if got := s.FilePath(r); got != tt.want {
+			t.Errorf("FilePath(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestSinkResultFileContent(t *testing.T) {
+	r := SinkResult{
+		SessionUUID: "sess-1",
+		Prompt:      "Why 500s?",
+		Answer:      "The DB pool is exhausted.",
+		Summary:     &api.SessionSummary{ActionItems: []string{"Raise pool size"}},
+	}
+
+	data, err := r.FileContent("pm/sess-1.md")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"# Investigation sess-1", "## Question\n\nWhy 500s?", "The DB pool is exhausted.", "- [ ] Raise pool size"} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("markdown missing %q:\n%s", want, data)
+		}
+	}
+
+	data, err = r.FileContent("sess-1.JSON")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got SinkResult
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if got.Answer != r.Answer {
+		t.Errorf("Answer = %q, want %q", got.Answer, r.Answer)
+	}
+}
+
+func TestSinkResultJiraIssue(t *testing.T) {
+	r := SinkResult{SessionUUID: "sess-1", SessionName: "Checkout latency", Answer: "Pool exhausted.",
+		Scores: &ResultScores{Accuracy: 92, Completeness: 80}}
+	body, err := r.JiraIssue("OPS", "Task")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got map[string]map[string]any
+	if err := json.Unmarshal(body, &got); err != nil {
+		t.Fatal(err)
+	}
+	fields := got["fields"]
+	if fields["summary"] != "RCA: Checkout latency" || fields["project"].(map[string]any)["key"] != "OPS" ||
+		fields["issuetype"].(map[string]any)["name"] != "Task" {
+		t.Errorf("fields = %v", fields)
+	}
+	if desc, _ := fields["description"].(string); !strings.Contains(desc, "- Accuracy: 92.0/100") {
+		t.Errorf("description = %q", desc)
+	}
+}
@@ -0,0 +1,136 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ─── Smoke test ─────────────────────────────────────────────────────────────
+//
+// `hawkeye smoke` checks an environment end to end the way a user would hit
+// it: token, projects, connections, a new session, a prompt whose answer
+// streams back, and cleanup of that session. Steps run in order and stop
+// at the first failure; cleanup runs whenever a session was created. It is
+// meant for synthetic monitoring, so each step is timed and the run can be
+// written as a check report.
+
+// SmokeSkipped marks a step not run because an earlier one failed.
+const SmokeSkipped = "skipped"
+
+// SmokePrompt is the prompt the smoke test sends: trivial, so the answer
+// comes back fast and costs little.
+const SmokePrompt = "This is an automated connectivity check. Reply with the single word OK."
+
+// SmokeSessionName labels the throwaway session, in case cleanup fails.
+const SmokeSessionName = "hawkeye smoke test (safe to delete)"
+
+// SmokeStep is the outcome of one step.
+type SmokeStep struct {
+	Name       string        `json:"name"`
+	Status     string        `json:"status"` // CheckPassed, CheckFailed or SmokeSkipped
+	Message    string        `json:"message,omitempty"`
+	DurationMS int64         `json:"duration_ms"`
+	Duration   time.Duration `json:"-"`
+}
+
+// Smoke records the steps of a smoke test run.
+type Smoke struct {
+	Steps  []SmokeStep
+	failed bool
+	now    func() time.Time
+}
+
+// NewSmoke starts a smoke test run.
+func NewSmoke() *Smoke {
+	return &Smoke{now: time.Now}
+}
+
+// Run times fn as step name and records its outcome: the message fn
+// returns when it passes, its error when it fails. After a failure later
+// steps are recorded as skipped without running. Run reports whether the
+// step passed.
+func (s *Smoke) Run(name string, fn func() (string, error)) bool {
+	if s.failed {
+		s.Steps = append(s.Steps, SmokeStep{Name: name, Status: SmokeSkipped, Message: "not run after an earlier failure"})
+		return false
+	}
+	return s.run(name, fn)
+}
+
+// Cleanup is Run for a step that undoes earlier ones, and so runs even
+// after a failure.
+func (s *Smoke) Cleanup(name string, fn func() (string, error)) bool {
+	return s.run(name, fn)
+}
+
+func (s *Smoke) run(name string, fn func() (string, error)) bool {
+	start := s.now()
+	msg, err := fn()
+	d := s.now().Sub(start)
+	step := SmokeStep{Name: name, Status: CheckPassed, Message: msg, Duration: d, DurationMS: d.Milliseconds()}
+	if err != nil {
+		step.Status, step.Message = CheckFailed, err.Error()
+		s.failed = true
+	}
+	s.Steps = append(s.Steps, step)
+	return err == nil
+}
+
+// OK reports whether every step passed.
+func (s *Smoke) OK() bool {
+	return !s.failed
+}
+
+// Duration returns the total time of the steps run.
+func (s *Smoke) Duration() time.Duration {
+	var total time.Duration
+	for _, st := range s.Steps {
+		total += st.Duration
+	}
+	return total
+}
+
+// FailedStep returns the first step that failed, or nil.
+func (s *Smoke) FailedStep() *SmokeStep {
+	for i := range s.Steps {
+		if s.Steps[i].Status == CheckFailed {
+			return &s.Steps[i]
+		}
+	}
+	return nil
+}
+
+// CheckReport converts the run for the shared JUnit/SARIF encoders.
+// Skipped steps count as errors: nothing is known about them.
+func (s *Smoke) CheckReport(server string) CheckReport {
+	report := CheckReport{Name: "hawkeye-smoke", Source: server, Duration: s.Duration()}
+	for _, st := range s.Steps {
+		c := CheckResult{ID: st.Name, Name: st.Name, Status: st.Status, Message: st.Message, Duration: st.Duration}
+		if st.Status == SmokeSkipped {
+			c.Status = CheckError
+		}
+		report.Checks = append(report.Checks, c)
+	}
+	return report
+}
+
+// DeleteUnsupported reports whether deleting a session failed because the
+// server cannot delete sessions. The smoke session is then left behind,
+// named SmokeSessionName.
+func DeleteUnsupported(err error) bool {
+	if err == nil {
+		return false
+	}
+	msg := err.Error()
+	return strings.Contains(msg, "server returned 404") || strings.Contains(msg, "server returned 405") ||
+		strings.Contains(msg, "server returned 501")
+}
+
+// FormatStepDuration renders a step's time as "850ms" or "2.3s".
+func FormatStepDuration(d time.Duration) string {
+	if d < time.Second {
+		return fmt.Sprintf("%dms", d.Milliseconds())
+	}
+	return fmt.Sprintf("%.1fs", d.Seconds())
+}
@@ -0,0 +1,101 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// fakeClock advances by step on every call.
+func fakeClock(step time.Duration) func() time.Time {
+	t := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	return func() time.Time {
+		t = t.Add(step)
+		return t
+	}
+}
+
+func TestSmokeRun(t *testing.T) {
+	s := NewSmoke()
+	s.now = fakeClock(250 * time.Millisecond)
+	ran := 0
+	step := func(msg string, err error) func() (string, error) {
+		return func() (string, error) { ran++; return msg, err }
+	}
+
+	if !s.Run("token", step("ok as alice@example.com", nil)) {
+		t.Error("a passing step should report true")
+	}
+	if s.Run("session", step("", errors.New("server returned 500: boom"))) {
+		t.Error("a failing step should report false")
+	}
+	s.Run("prompt", step("OK", nil))
+	s.Cleanup("cleanup", step("deleted", nil))
+
+	if ran != 3 {
+		t.Errorf("ran %d steps, want 3 (prompt skipped)", ran)
+	}
+	want := []struct{ name, status, msg string }{
+		{"token", CheckPassed, "ok as alice@example.com"},
+		{"session", CheckFailed, "server returned 500: boom"},
+		{"prompt", SmokeSkipped, "not run after an earlier failure"},
+		{"cleanup", CheckPassed, "deleted"},
+	}
+	if len(s.Steps) != len(want) {
+		t.Fatalf("steps = %+v", s.Steps)
+	}
+	for i, w := range want {
+		if st := s.Steps[i]; st.Name != w.name || st.Status != w.status || st.Message != w.msg {
+			t.Errorf("step %d = %+v, want %+v", i, st, w)
+		}
+	}
+	if s.OK() {
+		t.Error("a run with a failure should not be OK")
+	}
+	if f := s.FailedStep(); f == nil || f.Name != "session" {
+		t.Errorf("FailedStep() = %+v, want session", f)
+	}
+	if s.Steps[0].DurationMS != 250 || s.Duration() != 750*time.Millisecond {
+		t.Errorf("durations = %d ms, total %v", s.Steps[0].DurationMS, s.Duration())
+	}
+}
+
+func TestSmokeCheckReport(t *testing.T) {
+	s := NewSmoke()
+	s.now = fakeClock(time.Second)
+	s.Run("token", func() (string, error) { return "", errors.New("401") })
+	s.Run("projects", func() (string, error) { return "", nil })
+
+	r := s.CheckReport("https://x.neubird.ai")
+	if r.Name != "hawkeye-smoke" || r.Source != "https://x.neubird.ai" || len(r.Checks) != 2 {
+		t.Fatalf("report = %+v", r)
+	}
+	if r.Checks[0].Status != CheckFailed || r.Checks[1].Status != CheckError {
+		t.Errorf("statuses = %s, %s, want failed then error for the skipped step", r.Checks[0].Status, r.Checks[1].Status)
+	}
+	if failed, errored := r.Counts(); failed != 1 || errored != 1 {
+		t.Errorf("Counts() = %d, %d", failed, errored)
+	}
+}
+
+func TestDeleteUnsupported(t *testing.T) {
+	if !DeleteUnsupported(errors.New("server returned 405: method not allowed")) {
+		t.Error("405 means the server cannot delete sessions")
+	}
+	if DeleteUnsupported(errors.New("server returned 500: boom")) || DeleteUnsupported(nil) {
+		t.Error("500 and nil are not unsupported")
+	}
+}
+
+func TestFormatStepDuration(t *testing.T) {
+	for d, want := range map[time.Duration]string{
+		0:                       "0ms",
+		850 * time.Millisecond:  "850ms",
+		2300 * time.Millisecond: "2.3s",
+		90 * time.Second:        "90.0s",
+	} {
+		if got := FormatStepDuration(d); got != want {
+			t.Errorf("FormatStepDuration(%v) = %q, want %q", d, got, want)
+		}
+	}
+}
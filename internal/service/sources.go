@@ -0,0 +1,74 @@
+package service
+
+import (
+	"fmt"
+
+	"hawkeye-cli/internal/api"
+)
+
+// SourceQuery is a chain-of-thought step that touched a source.
+type SourceQuery struct {
+	ID          string `json:"id"`
+	Category    string `json:"category,omitempty"`
+	Description string `json:"description"`
+	Status      string `json:"status,omitempty"`
+}
+
+// SourceDetail is one piece of evidence cited by a prompt cycle, with the
+// steps that queried it.
+type SourceDetail struct {
+	Cycle       int           `json:"cycle"` // 1-based prompt cycle
+	ID          string        `json:"id,omitempty"`
+	Title       string        `json:"title"`
+	Category    string        `json:"category,omitempty"`
+	Description string        `json:"description,omitempty"`
+	Queries     []SourceQuery `json:"queries,omitempty"`
+}
+
+// CollectSources lists the sources of each prompt cycle with the
+// chain-of-thought steps whose sources_involved name them, by ID or title.
+// Sources a step names that the cycle does not list are appended so no
+// evidence is dropped. cycle selects a single 1-based cycle; 0 means all.
+func CollectSources(cycles []api.PromptCycle, cycle int) ([]SourceDetail, error) {
+	if cycle < 0 || cycle > len(cycles) {
+		return nil, fmt.Errorf("cycle %d out of range (session has %d)", cycle, len(cycles))
+	}
+	var out []SourceDetail
+	for i, pc := range cycles {
+		if cycle != 0 && i+1 != cycle {
+			continue
+		}
+		index := map[string]int{} // ID or title → position in out
+		for _, src := range pc.Sources {
+			d := SourceDetail{
+				Cycle:       i + 1,
+				ID:          src.ID,
+				Title:       firstNonEmpty(src.Title, src.ID),
+				Category:    src.Category,
+				Description: src.Description,
+			}
+			out = append(out, d)
+			for _, key := range []string{src.ID, src.Title} {
+				if _, seen := index[key]; key != "" && !seen {
+					index[key] = len(out) - 1
+				}
+			}
+		}
+		for _, cot := range pc.ChainOfThoughts {
+			q := SourceQuery{ID: cot.ID, Category: cot.Category, Description: cot.Description, Status: firstNonEmpty(cot.CotStatus, cot.Status)}
+			for _, name := range cot.Sources {
+				if name == "" {
+					continue
+				}
+				j, ok := index[name]
+				if !ok {
+					out = append(out, SourceDetail{Cycle: i + 1, Title: name})
+					j = len(out) - 1
+					index[name] = j
+				}
+				out[j].Queries = append(out[j].Queries, q)
+			}
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,53 @@
+package service
+
+import (
+	"testing"
+
+	"hawkeye-cli/internal/api"
+)
+
+func TestCollectSources(t *testing.T) {
+	cycles := []api.PromptCycle{
+		{
+			Sources: []api.Source{
+				{ID: "s1", Title: "api logs", Category: "logs", Description: "Error rate rose to 12% after 14:02"},
+				{ID: "s2", Category: "metrics"},
+			},
+			ChainOfThoughts: []api.ChainOfThought{
+				{ID: "c1", Description: "Check error logs", Sources: []string{"api logs"}, CotStatus: "COMPLETED"},
+				{ID: "c2", Description: "Check pool metrics", Sources: []string{"s2", "k8s events"}},
+			},
+		},
+		{Sources: []api.Source{{ID: "s3", Title: "deploys"}}},
+	}
+
+	got, err := CollectSources(cycles, 0)
+	if err != nil {
+		t.Fatalf("CollectSources() error = %v", err)
+	}
+	if len(got) != 4 {
+		t.Fatalf("got %d sources, want 4: %+v", len(got), got)
+	}
+	if s := got[0]; s.Title != "api logs" || len(s.Queries) != 1 || s.Queries[0].Status != "COMPLETED" {
+		t.Errorf("source 0 = %+v", s)
+	}
+	if s := got[1]; s.Title != "s2" || len(s.Queries) != 1 || s.Queries[0].ID != "c2" {
+		t.Errorf("source 1 = %+v, want titled by ID and matched by ID", s)
+	}
+	if s := got[2]; s.Title != "k8s events" || s.Cycle != 1 || len(s.Queries) != 1 {
+		t.Errorf("source 2 = %+v, want unlisted source kept", s)
+	}
+	if s := got[3]; s.Title != "deploys" || s.Cycle != 2 {
+		t.Errorf("source 3 = %+v", s)
+	}
+
+	got, _ = CollectSources(cycles, 2)
+	if len(got) != 1 || got[0].ID != "s3" {
+		t.Errorf("CollectSources(cycle 2) = %+v", got)
+	}
+	for _, cycle := range []int{-1, 3} {
+		if _, err := CollectSources(cycles, cycle); err == nil {
+			t.Errorf("CollectSources(cycle %d) error = nil, want out of range", cycle)
+		}
+	}
+}
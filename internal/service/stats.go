@@ -0,0 +1,205 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"hawkeye-cli/internal/api"
+)
+
+// StepTiming is the processing time of one chain-of-thought step.
+type StepTiming struct {
+	ID          string `json:"id"`
+	Category    string `json:"category,omitempty"`
+	Description string `json:"description"`
+	DurationMs  int64  `json:"duration_ms"`
+}
+
+// SessionStats is the performance breakdown of one investigation.
+type SessionStats struct {
+	SessionUUID  string       `json:"session_uuid"`
+	Name         string       `json:"name,omitempty"`
+	PromptCycles int          `json:"prompt_cycles"`
+	WallTimeMs   int64        `json:"wall_time_ms"`
+	StepTimeMs   int64        `json:"step_time_ms"`
+	Queries      int          `json:"queries"`
+	Sources      int          `json:"sources"`
+	Steps        []StepTiming `json:"steps,omitempty"` // slowest first
+}
+
+// ProjectStats aggregates SessionStats over recent sessions.
+type ProjectStats struct {
+	Sessions     int            `json:"sessions"`
+	AvgWallMs    int64          `json:"avg_wall_time_ms"`
+	MedianWallMs int64          `json:"median_wall_time_ms"`
+	MaxWallMs    int64          `json:"max_wall_time_ms"`
+	AvgStepMs    int64          `json:"avg_step_time_ms"`
+	AvgQueries   float64        `json:"avg_queries"`
+	AvgSources   float64        `json:"avg_sources"`
+	Slowest      []SessionStats `json:"slowest,omitempty"`
+}
+
+// ParseProcessingTime converts a CoT processing_time value to milliseconds.
+// The API reports plain millisecond counts ("1234"); Go-style durations
+// ("1.5s") are accepted as well.
+func ParseProcessingTime(s string) (int64, bool) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, false
+	}
+	if f, err := strconv.ParseFloat(s, 64); err == nil {
+		return int64(f), f > 0
+	}
+	if d, err := time.ParseDuration(s); err == nil {
+		return d.Milliseconds(), d > 0
+	}
+	return 0, false
+}
+
+// ComputeSessionStats builds the performance breakdown for a session.
+//
+// Wall time is approximate: each prompt cycle runs from its create time
+// until the next cycle starts, and the last one until the session's last
+// update. Cycles with unparseable times are skipped.
+func ComputeSessionStats(resp *api.SessionInspectResponse) SessionStats {
+	var st SessionStats
+	var lastUpdate string
+	if resp.SessionInfo != nil {
+		st.SessionUUID = resp.SessionInfo.SessionUUID
+		st.Name = resp.SessionInfo.Name
+		lastUpdate = resp.SessionInfo.LastUpdate
+	}
+	st.PromptCycles = len(resp.PromptCycle)
+
+	sources := map[string]bool{}
+	for i, pc := range resp.PromptCycle {
+		end := lastUpdate
+		if i+1 < len(resp.PromptCycle) {
+			end = resp.PromptCycle[i+1].CreateTime
+		}
+		st.WallTimeMs += elapsedMs(pc.CreateTime, end)
+
+		for _, src := range pc.Sources {
+			if key := firstNonEmpty(src.ID, src.Title); key != "" {
+				sources[key] = true
+			}
+		}
+		for _, cot := range pc.ChainOfThoughts {
+			st.Queries++
+			for _, src := range cot.Sources {
+				if src != "" {
+					sources[src] = true
+				}
+			}
+			ms, ok := ParseProcessingTime(cot.ProcessingTime)
+			if !ok {
+				continue
+			}
+			st.StepTimeMs += ms
+			st.Steps = append(st.Steps, StepTiming{
+				ID:          cot.ID,
+				Category:    cot.Category,
+				Description: cot.Description,
+				DurationMs:  ms,
+			})
+		}
+	}
+	st.Sources = len(sources)
+
+	sort.SliceStable(st.Steps, func(i, j int) bool {
+		return st.Steps[i].DurationMs > st.Steps[j].DurationMs
+	})
+	return st
+}
+
+// AggregateStats summarizes sessions; Slowest holds up to top sessions by
+// wall time.
+func AggregateStats(stats []SessionStats, top int) ProjectStats {
+	agg := ProjectStats{Sessions: len(stats)}
+	if len(stats) == 0 {
+		return agg
+	}
+
+	var wallSum, stepSum int64
+	var queries, sources int
+	walls := make([]int64, 0, len(stats))
+	for _, s := range stats {
+		wallSum += s.WallTimeMs
+		stepSum += s.StepTimeMs
+		queries += s.Queries
+		sources += s.Sources
+		walls = append(walls, s.WallTimeMs)
+		if s.WallTimeMs > agg.MaxWallMs {
+			agg.MaxWallMs = s.WallTimeMs
+		}
+	}
+	n := int64(len(stats))
+	agg.AvgWallMs = wallSum / n
+	agg.AvgStepMs = stepSum / n
+	agg.AvgQueries = float64(queries) / float64(n)
+	agg.AvgSources = float64(sources) / float64(n)
+
+	sort.Slice(walls, func(i, j int) bool { return walls[i] < walls[j] })
+	agg.MedianWallMs = walls[len(walls)/2]
+	if len(walls)%2 == 0 {
+		agg.MedianWallMs = (walls[len(walls)/2-1] + walls[len(walls)/2]) / 2
+	}
+
+	slowest := append([]SessionStats(nil), stats...)
+	sort.SliceStable(slowest, func(i, j int) bool {
+		return slowest[i].WallTimeMs > slowest[j].WallTimeMs
+	})
+	if len(slowest) > top {
+		slowest = slowest[:top]
+	}
+	for i := range slowest {
+		slowest[i].Steps = nil // keep the aggregate compact
+	}
+	agg.Slowest = slowest
+	return agg
+}
+
+// FormatDurationMs renders milliseconds compactly: "850ms", "12.3s",
+// "4m 12s", "1h 3m".
+func FormatDurationMs(ms int64) string {
+	d := time.Duration(ms) * time.Millisecond
+	switch {
+	case d < time.Second:
+		return fmt.Sprintf("%dms", ms)
+	case d < time.Minute:
+		return fmt.Sprintf("%.1fs", d.Seconds())
+	case d < time.Hour:
+		return fmt.Sprintf("%dm %ds", int(d.Minutes()), int(d.Seconds())%60)
+	default:
+		return fmt.Sprintf("%dh %dm", int(d.Hours()), int(d.Minutes())%60)
+	}
+}
+
+func elapsedMs(start, end string) int64 {
+	s, err1 := parseAPITime(start)
+	e, err2 := parseAPITime(end)
+	if err1 != nil || err2 != nil || e.Before(s) {
+		return 0
+	}
+	return e.Sub(s).Milliseconds()
+}
+
+func parseAPITime(ts string) (time.Time, error) {
+	t, err := time.Parse(time.RFC3339Nano, ts)
+	if err != nil {
+		t, err = time.Parse(time.RFC3339, ts)
+	}
+	return t, err
+}
+
+func firstNonEmpty(vals ...string) string {
+	for _, v := range vals {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
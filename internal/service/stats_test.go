@@ -0,0 +1,118 @@
+package service
+
+import (
+	"testing"
+
+	"hawkeye-cli/internal/api"
+)
+
+func TestParseProcessingTime(t *testing.T) {
+	tests := []struct {
+		in     string
+		want   int64
+		wantOK bool
+	}{
+		{"1234", 1234, true},
+		{"1.5s", 1500, true},
+		{"2m", 120000, true},
+		{"0", 0, false},
+		{"", 0, false},
+		{"soon", 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := ParseProcessingTime(tt.in)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("ParseProcessingTime(%q) = %d, %v; want %d, %v", tt.in, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestComputeSessionStats(t *testing.T) {
+	resp := &api.SessionInspectResponse{
+		SessionInfo: &api.SessionInfo{SessionUUID: "s1", Name: "slow one", LastUpdate: "2025-01-01T00:25:00Z"},
+		PromptCycle: []api.PromptCycle{
+			{
+				CreateTime: "2025-01-01T00:00:00Z",
+				Sources:    []api.Source{{ID: "dd"}, {Title: "CloudWatch"}},
+				ChainOfThoughts: []api.ChainOfThought{
+					{ID: "c1", Category: "logs", Description: "scan logs", ProcessingTime: "60000", Sources: []string{"dd"}},
+					{ID: "c2", Description: "check metrics", ProcessingTime: "300000", Sources: []string{"prom"}},
+					{ID: "c3", Description: "no timing"},
+				},
+			},
+			{
+				CreateTime: "2025-01-01T00:20:00Z",
+				ChainOfThoughts: []api.ChainOfThought{
+					{ID: "c4", Description: "follow up", ProcessingTime: "1.5s"},
+				},
+			},
+		},
+	}
+
+	st := ComputeSessionStats(resp)
+	if st.SessionUUID != "s1" || st.PromptCycles != 2 {
+		t.Errorf("identity = %+v", st)
+	}
+	if st.WallTimeMs != 25*60*1000 {
+		t.Errorf("WallTimeMs = %d, want 25m", st.WallTimeMs)
+	}
+	if st.StepTimeMs != 361500 {
+		t.Errorf("StepTimeMs = %d, want 361500", st.StepTimeMs)
+	}
+	if st.Queries != 4 || st.Sources != 3 {
+		t.Errorf("Queries = %d, Sources = %d; want 4, 3", st.Queries, st.Sources)
+	}
+	if len(st.Steps) != 3 || st.Steps[0].ID != "c2" || st.Steps[2].ID != "c4" {
+		t.Errorf("Steps not sorted slowest first: %+v", st.Steps)
+	}
+
+	empty := ComputeSessionStats(&api.SessionInspectResponse{})
+	if empty.WallTimeMs != 0 || empty.Queries != 0 {
+		t.Errorf("empty stats = %+v", empty)
+	}
+}
+
+func TestAggregateStats(t *testing.T) {
+	stats := []SessionStats{
+		{SessionUUID: "a", WallTimeMs: 1000, StepTimeMs: 500, Queries: 2, Sources: 1, Steps: []StepTiming{{ID: "x"}}},
+		{SessionUUID: "b", WallTimeMs: 9000, StepTimeMs: 4000, Queries: 6, Sources: 3},
+		{SessionUUID: "c", WallTimeMs: 2000, StepTimeMs: 1500, Queries: 4, Sources: 2},
+	}
+	agg := AggregateStats(stats, 2)
+	if agg.Sessions != 3 || agg.AvgWallMs != 4000 || agg.MedianWallMs != 2000 || agg.MaxWallMs != 9000 {
+		t.Errorf("wall aggregates = %+v", agg)
+	}
+	if agg.AvgStepMs != 2000 || agg.AvgQueries != 4 || agg.AvgSources != 2 {
+		t.Errorf("averages = %+v", agg)
+	}
+	if len(agg.Slowest) != 2 || agg.Slowest[0].SessionUUID != "b" || agg.Slowest[1].SessionUUID != "c" {
+		t.Errorf("Slowest = %+v", agg.Slowest)
+	}
+	if stats[0].Steps == nil {
+		t.Error("AggregateStats modified its input")
+	}
+
+	if got := AggregateStats(nil, 5); got.Sessions != 0 {
+		t.Errorf("empty aggregate = %+v", got)
+	}
+	if got := AggregateStats(stats[:2], 5); got.MedianWallMs != 5000 {
+		t.Errorf("even median = %d, want 5000", got.MedianWallMs)
+	}
+}
+
+func TestFormatDurationMs(t *testing.T) {
+	tests := []struct {
+		ms   int64
+		want string
+	}{
+		{850, "850ms"},
+		{12300, "12.3s"},
+		{252000, "4m 12s"},
+		{3780000, "1h 3m"},
+	}
+	for _, tt := range tests {
+		if got := FormatDurationMs(tt.ms); got != tt.want {
+			t.Errorf("FormatDurationMs(%d) = %q, want %q", tt.ms, got, tt.want)
+		}
+	}
+}
@@ -3,6 +3,8 @@ package service
 import (
 	"regexp"
 	"strings"
+
+	"hawkeye-cli/internal/api"
 )
 
 var htmlTagRe = regexp.MustCompile(`<[^>]+>`)
@@ -57,3 +59,34 @@ func ExtractProgressDisplay(text string) string {
 	}
 	return text
 }
+
+// AnswerCollector accumulates the final chat response from a prompt stream
+// without printing anything. Use Handle as the stream callback.
+type AnswerCollector struct {
+	text string
+}
+
+// Handle is a StreamCallback that records CHAT_RESPONSE content.
+// Delta events are appended; full-text events replace the buffer.
+func (a *AnswerCollector) Handle(resp *api.ProcessPromptResponse) {
+	if resp == nil || resp.Message == nil || resp.Message.Content == nil {
+		return
+	}
+	if resp.Message.Content.ContentType != "CONTENT_TYPE_CHAT_RESPONSE" {
+		return
+	}
+	parts := resp.Message.Content.Parts
+	if len(parts) == 0 {
+		return
+	}
+	if resp.Message.Metadata.IsDeltaTrue() {
+		a.text += StripHTML(parts[0])
+	} else {
+		a.text = StripHTML(strings.Join(parts, "\n"))
+	}
+}
+
+// Answer returns the accumulated response text, trimmed.
+func (a *AnswerCollector) Answer() string {
+	return strings.TrimSpace(a.text)
+}
@@ -2,6 +2,8 @@ package service
 
 import (
 	"testing"
+
+	"hawkeye-cli/internal/api"
 )
 
 func TestStripHTML(t *testing.T) {
@@ -99,3 +101,28 @@ func TestExtractProgressDisplay(t *testing.T) {
 		})
 	}
 }
+
+func TestAnswerCollector(t *testing.T) {
+	chat := func(delta bool, parts ...string) *api.ProcessPromptResponse {
+		return &api.ProcessPromptResponse{Message: &api.Message{
+			Content:  &api.Content{ContentType: "CONTENT_TYPE_CHAT_RESPONSE", Parts: parts},
+			Metadata: &api.Metadata{IsDelta: delta},
+		}}
+	}
+
+	var a AnswerCollector
+	a.Handle(nil)
+	a.Handle(&api.ProcessPromptResponse{Message: &api.Message{
+		Content: &api.Content{ContentType: "CONTENT_TYPE_PROGRESS_STATUS", Parts: []string{"ignored"}},
+	}})
+	a.Handle(chat(true, "Root "))
+	a.Handle(chat(true, "cause<br/>"))
+	if got := a.Answer(); got != "Root cause" {
+		t.Errorf("delta Answer() = %q, want %q", got, "Root cause")
+	}
+
+	a.Handle(chat(false, "Full", "answer"))
+	if got := a.Answer(); got != "Full\nanswer" {
+		t.Errorf("full Answer() = %q, want %q", got, "Full\nanswer")
+	}
+}
@@ -0,0 +1,192 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"hawkeye-cli/internal/api"
+)
+
+// Summary presentation formats for `hawkeye summary --format`.
+const (
+	SummaryFull      = "full"
+	SummaryExecutive = "executive"
+	SummaryEngineer  = "engineer"
+	SummaryTimeline  = "timeline"
+)
+
+// ParseSummaryFormat validates a --format value. Empty means full.
+func ParseSummaryFormat(s string) (string, error) {
+	switch f := strings.ToLower(s); f {
+	case "":
+		return SummaryFull, nil
+	case SummaryFull, SummaryExecutive, SummaryEngineer, SummaryTimeline:
+		return f, nil
+	}
+	return "", fmt.Errorf("invalid --format %q (use executive, engineer, timeline or full)", s)
+}
+
+// ExecutiveSummary is the slice of a summary meant for stakeholders: what
+// happened, what it affected, how it was resolved and what is left to do.
+type ExecutiveSummary struct {
+	Issue       string   `json:"issue,omitempty"`
+	Impact      string   `json:"impact,omitempty"`
+	Resolution  string   `json:"resolution,omitempty"`
+	ActionItems []string `json:"action_items,omitempty"`
+}
+
+// BuildExecutiveSummary extracts the executive view. The issue is the
+// investigated question; impact and resolution come from matching sections
+// of the full analysis, with the quick analysis standing in for a missing
+// resolution.
+func BuildExecutiveSummary(s *api.SessionSummary) ExecutiveSummary {
+	var e ExecutiveSummary
+	if s == nil {
+		return e
+	}
+	if s.ShortSummary != nil {
+		e.Issue = strings.TrimSpace(s.ShortSummary.Question)
+	}
+	e.Impact = MarkdownSection(s.Analysis, "impact", "customer impact", "business impact")
+	e.Resolution = MarkdownSection(s.Analysis, "resolution", "remediation", "root cause")
+	if e.Resolution == "" && s.ShortSummary != nil {
+		e.Resolution = strings.TrimSpace(s.ShortSummary.Analysis)
+	}
+	e.ActionItems = s.ActionItems
+	return e
+}
+
+// MarkdownSection returns the body of the first markdown section whose
+// heading (any level, or a line of bold text) matches one of names, up to
+// the next heading of the same or a higher level. Matching ignores case and
+// a trailing colon.
+func MarkdownSection(markdown string, names ...string) string {
+	lines := strings.Split(markdown, "\n")
+	start, level := -1, 0
+	for i, line := range lines {
+		l, title := headingOf(line)
+		if l == 0 {
+			continue
+		}
+		if start >= 0 {
+			if l <= level {
+				return strings.TrimSpace(strings.Join(lines[start:i], "\n"))
+			}
+			continue
+		}
+		for _, name := range names {
+			if strings.EqualFold(title, name) {
+				start, level = i+1, l
+				break
+			}
+		}
+	}
+	if start < 0 {
+		return ""
+	}
+	return strings.TrimSpace(strings.Join(lines[start:], "\n"))
+}
+
+// headingOf returns a line's heading level and title, or 0 when it is not
+// a heading. A line that is entirely bold counts as a level-6 heading.
+func headingOf(line string) (int, string) {
+	line = strings.TrimSpace(line)
+	if strings.HasPrefix(line, "#") {
+		level := len(line) - len(strings.TrimLeft(line, "#"))
+		title := strings.TrimSpace(line[level:])
+		return level, strings.TrimSuffix(title, ":")
+	}
+	if len(line) > 4 && strings.HasPrefix(line, "**") && strings.HasSuffix(line, "**") {
+		title := strings.TrimSpace(line[2 : len(line)-2])
+		return 6, strings.TrimSuffix(title, ":")
+	}
+	return 0, ""
+}
+
+// KeyQueries returns up to n queries that succeeded, those returning the
+// most results first.
+func KeyQueries(queries []api.QueryExecution, n int) []api.QueryExecution {
+	var ok []api.QueryExecution
+	for _, q := range queries {
+		status := strings.ToUpper(q.Status)
+		if q.Query == "" || strings.Contains(status, "FAIL") || strings.Contains(status, "ERROR") {
+			continue
+		}
+		ok = append(ok, q)
+	}
+	sort.SliceStable(ok, func(i, j int) bool { return ok[i].ResultCount > ok[j].ResultCount })
+	if n > 0 && len(ok) > n {
+		ok = ok[:n]
+	}
+	return ok
+}
+
+// TimelineEvent is one entry of an investigation timeline.
+type TimelineEvent struct {
+	Time       time.Time `json:"time,omitempty"`
+	Kind       string    `json:"kind"` // "prompt", "step" or "answer"
+	Category   string    `json:"category,omitempty"`
+	Text       string    `json:"text"`
+	Status     string    `json:"status,omitempty"`
+	DurationMs int64     `json:"duration_ms,omitempty"`
+}
+
+// BuildTimeline orders a session's prompts, chain-of-thought steps and
+// answers chronologically. Steps carry no timestamps of their own, so each
+// is placed at its cycle's start plus the processing time of the steps
+// before it. Events of cycles with unparseable times have a zero Time.
+func BuildTimeline(resp *api.SessionInspectResponse) []TimelineEvent {
+	var events []TimelineEvent
+	for _, pc := range resp.PromptCycle {
+		start, err := parseAPITime(pc.CreateTime)
+		known := err == nil
+		at := func(offsetMs int64) time.Time {
+			if !known {
+				return time.Time{}
+			}
+			return start.Add(time.Duration(offsetMs) * time.Millisecond)
+		}
+
+		if prompt := promptText(pc); prompt != "" {
+			events = append(events, TimelineEvent{Time: at(0), Kind: "prompt", Text: prompt})
+		}
+		var offset int64
+		for _, cot := range pc.ChainOfThoughts {
+			ms, _ := ParseProcessingTime(cot.ProcessingTime)
+			events = append(events, TimelineEvent{
+				Time:       at(offset),
+				Kind:       "step",
+				Category:   cot.Category,
+				Text:       firstNonEmpty(cot.Description, cot.Explanation, cot.ID),
+				Status:     firstNonEmpty(cot.CotStatus, cot.Status),
+				DurationMs: ms,
+			})
+			offset += ms
+		}
+		if pc.FinalAnswer != "" {
+			events = append(events, TimelineEvent{Time: at(offset), Kind: "answer", Text: ShortSummary(pc.FinalAnswer, 120)})
+		}
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		if events[i].Time.IsZero() || events[j].Time.IsZero() {
+			return false
+		}
+		return events[i].Time.Before(events[j].Time)
+	})
+	return events
+}
+
+// promptText returns the question asked in a prompt cycle.
+func promptText(pc api.PromptCycle) string {
+	if pc.Request == nil {
+		return ""
+	}
+	for _, msg := range pc.Request.Messages {
+		if msg.Content != nil && len(msg.Content.Parts) > 0 {
+			return strings.TrimSpace(msg.Content.Parts[0])
+		}
+	}
+	return ""
+}
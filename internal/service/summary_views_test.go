@@ -0,0 +1,120 @@
+package service
+
+import (
+	"testing"
+
+	"hawkeye-cli/internal/api"
+)
+
+func TestParseSummaryFormat(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{"", SummaryFull, false},
+		{"executive", SummaryExecutive, false},
+		{"Engineer", SummaryEngineer, false},
+		{"timeline", SummaryTimeline, false},
+		{"brief", "", true},
+	}
+	for _, tt := range tests {
+		got, err := ParseSummaryFormat(tt.in)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseSummaryFormat(%q) = %q, %v; want %q", tt.in, got, err, tt.want)
+		}
+	}
+}
+
+func TestMarkdownSection(t *testing.T) {
+	md := "# Report\n\n## Impact\nCheckout errors for 12% of users.\n\n### Details\nEU only.\n\n## Root Cause\nBad deploy.\n\n**Resolution:**\nRolled back."
+	tests := []struct {
+		names []string
+		want  string
+	}{
+		{[]string{"impact"}, "Checkout errors for 12% of users.\n\n### Details\nEU only."},
+		{[]string{"remediation", "root cause"}, "Bad deploy.\n\n**Resolution:**\nRolled back."},
+		{[]string{"resolution"}, "Rolled back."},
+		{[]string{"timeline"}, ""},
+	}
+	for _, tt := range tests {
+		if got := MarkdownSection(md, tt.names...); got != tt.want {
+			t.Errorf("MarkdownSection(%v) = %q, want %q", tt.names, got, tt.want)
+		}
+	}
+}
+
+func TestBuildExecutiveSummary(t *testing.T) {
+	s := &api.SessionSummary{
+		Analysis:     "## Impact\nLogin failures.\n\n## Evidence\nLogs.",
+		ActionItems:  []string{"Add an alert"},
+		ShortSummary: &api.ShortSessionSummary{Question: "Why are logins failing?", Analysis: "Expired certificate."},
+	}
+	got := BuildExecutiveSummary(s)
+	if got.Issue != "Why are logins failing?" || got.Impact != "Login failures." ||
+		got.Resolution != "Expired certificate." || len(got.ActionItems) != 1 {
+		t.Errorf("BuildExecutiveSummary() = %+v", got)
+	}
+	if got := BuildExecutiveSummary(nil); got.Issue != "" {
+		t.Errorf("BuildExecutiveSummary(nil) = %+v", got)
+	}
+}
+
+func TestKeyQueries(t *testing.T) {
+	queries := []api.QueryExecution{
+		{Query: "a", Status: "COMPLETED", ResultCount: 3},
+		{Query: "b", Status: "FAILED", ResultCount: 50},
+		{Query: "c", Status: "COMPLETED", ResultCount: 10},
+		{Query: "", Status: "COMPLETED", ResultCount: 99},
+		{Query: "d", Status: "COMPLETED", ResultCount: 1},
+	}
+	got := KeyQueries(queries, 2)
+	if len(got) != 2 || got[0].Query != "c" || got[1].Query != "a" {
+		t.Errorf("KeyQueries() = %+v", got)
+	}
+}
+
+func TestBuildTimeline(t *testing.T) {
+	prompt := func(text string) *api.ProcessPromptRequest {
+		return &api.ProcessPromptRequest{Messages: []api.Message{{Content: &api.Content{Parts: []string{text}}}}}
+	}
+	resp := &api.SessionInspectResponse{
+		PromptCycle: []api.PromptCycle{
+			{
+				CreateTime: "2026-03-01T10:05:00Z",
+				Request:    prompt("follow up"),
+			},
+			{
+				CreateTime:  "2026-03-01T10:00:00Z",
+				Request:     prompt("why 500s?"),
+				FinalAnswer: "Bad deploy.",
+				ChainOfThoughts: []api.ChainOfThought{
+					{Category: "logs", Description: "Search logs", ProcessingTime: "2000"},
+					{Category: "metrics", Description: "Check latency", ProcessingTime: "1500"},
+				},
+			},
+		},
+	}
+	events := BuildTimeline(resp)
+	want := []struct {
+		kind, text, clock string
+	}{
+		{"prompt", "why 500s?", "10:00:00"},
+		{"step", "Search logs", "10:00:00"},
+		{"step", "Check latency", "10:00:02"},
+		{"answer", "Bad deploy.", "10:00:03"},
+		{"prompt", "follow up", "10:05:00"},
+	}
+	if len(events) != len(want) {
+		t.Fatalf("got %d events, want %d: %+v", len(events), len(want), events)
+	}
+	for i, w := range want {
+		ev := events[i]
+		if ev.Kind != w.kind || ev.Text != w.text || ev.Time.UTC().Format("15:04:05") != w.clock {
+			t.Errorf("event %d = %s %q at %s, want %s %q at %s", i, ev.Kind, ev.Text, ev.Time.UTC().Format("15:04:05"), w.kind, w.text, w.clock)
+		}
+	}
+	if events[2].DurationMs != 1500 {
+		t.Errorf("step duration = %d, want 1500", events[2].DurationMs)
+	}
+}
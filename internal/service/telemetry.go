@@ -0,0 +1,66 @@
+package service
+
+import (
+	"maps"
+	"runtime"
+	"strings"
+	"time"
+
+	"hawkeye-cli/internal/api"
+	"hawkeye-cli/internal/config"
+)
+
+// Telemetry error categories. Only the category of a failure is counted,
+// never its message.
+const (
+	ErrCategoryUsage    = "usage"
+	ErrCategoryAuth     = "auth"
+	ErrCategoryNotFound = "not_found"
+	ErrCategoryNetwork  = "network"
+	ErrCategoryServer   = "server"
+	ErrCategoryOther    = "other"
+)
+
+// ErrorCategory sorts a command error into a telemetry category. It
+// returns "" for a nil error.
+func ErrorCategory(err error) string {
+	if err == nil {
+		return ""
+	}
+	msg := strings.ToLower(err.Error())
+	switch {
+	case strings.Contains(msg, "server returned 401"), strings.Contains(msg, "server returned 403"),
+		strings.Contains(msg, "not logged in"), strings.Contains(msg, "token"):
+		return ErrCategoryAuth
+	case strings.Contains(msg, "server returned 404"), strings.Contains(msg, "not found"):
+		return ErrCategoryNotFound
+	case strings.Contains(msg, "server returned 5"), strings.Contains(msg, "server error"):
+		return ErrCategoryServer
+	case strings.Contains(msg, "request failed"), strings.Contains(msg, "stream error"),
+		strings.Contains(msg, "timeout"), strings.Contains(msg, "connection refused"):
+		return ErrCategoryNetwork
+	case strings.Contains(msg, "requires a value"), strings.Contains(msg, "unknown flag"),
+		strings.Contains(msg, "invalid"), strings.Contains(msg, "usage"), strings.Contains(msg, "must be"):
+		return ErrCategoryUsage
+	}
+	return ErrCategoryOther
+}
+
+// NewTelemetryBatch returns the upload for t's pending counts, exactly as
+// it would be sent.
+func NewTelemetryBatch(t *config.Telemetry, version string, now time.Time) *api.TelemetryBatch {
+	b := &api.TelemetryBatch{
+		InstallID: t.InstallID,
+		Version:   version,
+		OS:        runtime.GOOS,
+		Arch:      runtime.GOARCH,
+		From:      t.Since.UTC(),
+		To:        now.UTC(),
+		Commands:  maps.Clone(t.Commands),
+		Errors:    maps.Clone(t.Errors),
+	}
+	if b.Commands == nil {
+		b.Commands = map[string]int{}
+	}
+	return b
+}
@@ -0,0 +1,46 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"hawkeye-cli/internal/config"
+)
+
+func TestErrorCategory(t *testing.T) {
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{nil, ""},
+		{errors.New("server returned 401: unauthorized"), ErrCategoryAuth},
+		{errors.New("not logged in. Run: hawkeye login <url>"), ErrCategoryAuth},
+		{errors.New(`project "x" not found`), ErrCategoryNotFound},
+		{errors.New("server returned 503: unavailable"), ErrCategoryServer},
+		{errors.New("request failed: dial tcp: connection refused"), ErrCategoryNetwork},
+		{errors.New("--limit requires a value"), ErrCategoryUsage},
+		{errors.New("something odd"), ErrCategoryOther},
+	}
+	for _, tt := range tests {
+		if got := ErrorCategory(tt.err); got != tt.want {
+			t.Errorf("ErrorCategory(%v) = %q, want %q", tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestNewTelemetryBatch(t *testing.T) {
+	since := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	tel := &config.Telemetry{Enabled: true, InstallID: "id", Since: since, Commands: map[string]int{"sessions": 3}}
+	b := NewTelemetryBatch(tel, "1.2.3", since.Add(time.Hour))
+	if b.InstallID != "id" || b.Version != "1.2.3" || !b.From.Equal(since) || b.Commands["sessions"] != 3 {
+		t.Errorf("batch = %+v", b)
+	}
+	b.Commands["sessions"] = 99
+	if tel.Commands["sessions"] != 3 {
+		t.Error("batch shares the pending counts map")
+	}
+	if empty := NewTelemetryBatch(&config.Telemetry{}, "dev", since); empty.Commands == nil {
+		t.Error("empty batch has nil commands")
+	}
+}
@@ -0,0 +1,177 @@
+package service
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"hawkeye-cli/internal/config"
+)
+
+// ─── Investigation templates ────────────────────────────────────────────────
+//
+// A template prompt may contain {{name}} placeholders, filled from
+// `investigate --template <name> --var name=value`. The same variables are
+// substituted in the template's instructions.
+
+var templateVarRE = regexp.MustCompile(`\{\{\s*([A-Za-z_][A-Za-z0-9_-]*)\s*\}\}`)
+
+var templateNameRE = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.-]*$`)
+
+// TelemetryTypes returns the telemetry types a template can be scoped to.
+func TelemetryTypes() []string {
+	return []string{"metric", "log", "trace"}
+}
+
+// ValidTemplateName checks that a name can be typed as a single argument.
+func ValidTemplateName(name string) error {
+	if !templateNameRE.MatchString(name) {
+		return fmt.Errorf("invalid template name %q (use letters, digits, '-', '_' and '.')", name)
+	}
+	return nil
+}
+
+// ParseTemplateInstruction parses a `type:content` --instruction value.
+func ParseTemplateInstruction(spec string) (config.TemplateInstruction, error) {
+	typ, content, ok := strings.Cut(spec, ":")
+	typ = strings.ToLower(strings.TrimSpace(typ))
+	content = strings.TrimSpace(content)
+	if !ok || content == "" {
+		return config.TemplateInstruction{}, fmt.Errorf("invalid instruction %q (use type:content, e.g. rca:\"focus on the database layer\")", spec)
+	}
+	if !ValidInstructionType(typ) {
+		return config.TemplateInstruction{}, fmt.Errorf("invalid instruction type %q (use %s)", typ, strings.Join(InstructionTypes(), ", "))
+	}
+	return config.TemplateInstruction{Type: typ, Content: content}, nil
+}
+
+// ParseTelemetryScope parses a comma-separated --telemetry list, dropping
+// duplicates. Plural forms ("logs") are accepted.
+func ParseTelemetryScope(s string) ([]string, error) {
+	var out []string
+	seen := map[string]bool{}
+	for _, part := range strings.Split(s, ",") {
+		t := strings.TrimSuffix(strings.ToLower(strings.TrimSpace(part)), "s")
+		if t == "" {
+			continue
+		}
+		valid := false
+		for _, v := range TelemetryTypes() {
+			valid = valid || v == t
+		}
+		if !valid {
+			return nil, fmt.Errorf("invalid telemetry type %q (use %s)", strings.TrimSpace(part), strings.Join(TelemetryTypes(), ", "))
+		}
+		if !seen[t] {
+			seen[t] = true
+			out = append(out, t)
+		}
+	}
+	return out, nil
+}
+
+// ParseTemplateVars parses --var name=value flags.
+func ParseTemplateVars(specs []string) (map[string]string, error) {
+	vars := map[string]string{}
+	for _, spec := range specs {
+		name, value, ok := strings.Cut(spec, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid --var %q (use name=value)", spec)
+		}
+		vars[name] = value
+	}
+	return vars, nil
+}
+
+// TemplateVars returns the placeholder names used in text, sorted.
+func TemplateVars(text string) []string {
+	seen := map[string]bool{}
+	var names []string
+	for _, m := range templateVarRE.FindAllStringSubmatch(text, -1) {
+		if !seen[m[1]] {
+			seen[m[1]] = true
+			names = append(names, m[1])
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// TemplateVariables returns the placeholder names used in a template's
+// prompt and instructions, sorted.
+func TemplateVariables(t config.Template) []string {
+	text := t.Prompt
+	for _, in := range t.Instructions {
+		text += "\n" + in.Content
+	}
+	return TemplateVars(text)
+}
+
+// RenderTemplateText fills {{name}} placeholders from vars. Every
+// placeholder must have a value.
+func RenderTemplateText(text string, vars map[string]string) (string, error) {
+	var missing []string
+	for _, name := range TemplateVars(text) {
+		if _, ok := vars[name]; !ok {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		return "", fmt.Errorf("missing template variable(s): %s (pass --var %s=<value>)", strings.Join(missing, ", "), missing[0])
+	}
+	return templateVarRE.ReplaceAllStringFunc(text, func(m string) string {
+		return vars[templateVarRE.FindStringSubmatch(m)[1]]
+	}), nil
+}
+
+// RenderedTemplate is a template with its variables filled in, ready to run.
+type RenderedTemplate struct {
+	Prompt       string
+	Instructions []config.TemplateInstruction
+}
+
+// RenderTemplate fills in a template's prompt and instructions and turns
+// its telemetry scope into a system instruction.
+func RenderTemplate(t config.Template, vars map[string]string) (RenderedTemplate, error) {
+	known := map[string]bool{}
+	for _, name := range TemplateVariables(t) {
+		known[name] = true
+	}
+	for name := range vars {
+		if !known[name] {
+			return RenderedTemplate{}, fmt.Errorf("template %s has no variable %q", t.Name, name)
+		}
+	}
+
+	var r RenderedTemplate
+	var err error
+	if r.Prompt, err = RenderTemplateText(t.Prompt, vars); err != nil {
+		return RenderedTemplate{}, err
+	}
+	for _, in := range t.Instructions {
+		content, err := RenderTemplateText(in.Content, vars)
+		if err != nil {
+			return RenderedTemplate{}, err
+		}
+		r.Instructions = append(r.Instructions, config.TemplateInstruction{Type: in.Type, Content: content})
+	}
+	if scope := TelemetryScopeInstruction(t.Telemetry); scope != "" {
+		r.Instructions = append(r.Instructions, config.TemplateInstruction{Type: "system", Content: scope})
+	}
+	return r, nil
+}
+
+// TelemetryScopeInstruction is the system instruction that limits an
+// investigation to the given telemetry types, or "" for no limit.
+func TelemetryScopeInstruction(telemetry []string) string {
+	if len(telemetry) == 0 {
+		return ""
+	}
+	list := telemetry[0]
+	if n := len(telemetry); n > 1 {
+		list = strings.Join(telemetry[:n-1], ", ") + " and " + telemetry[n-1]
+	}
+	return fmt.Sprintf("Only use %s telemetry for this investigation.", list)
+}
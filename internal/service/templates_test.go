@@ -0,0 +1,91 @@
+package service
+
+import (
+	"reflect"
+	"testing"
+
+	"hawkeye-cli/internal/config"
+)
+
+func TestParseTemplateInstruction(t *testing.T) {
+	got, err := ParseTemplateInstruction("RCA: focus on database layer")
+	if err != nil || got != (config.TemplateInstruction{Type: "rca", Content: "focus on database layer"}) {
+		t.Errorf("got %+v, %v", got, err)
+	}
+	for _, bad := range []string{"rca", "rca:", "bogus:text"} {
+		if _, err := ParseTemplateInstruction(bad); err == nil {
+			t.Errorf("ParseTemplateInstruction(%q) should fail", bad)
+		}
+	}
+}
+
+func TestParseTelemetryScope(t *testing.T) {
+	got, err := ParseTelemetryScope("metric, logs,log,")
+	if err != nil || !reflect.DeepEqual(got, []string{"metric", "log"}) {
+		t.Errorf("got %v, %v", got, err)
+	}
+	if _, err := ParseTelemetryScope("metric,events"); err == nil {
+		t.Error("unknown telemetry type should fail")
+	}
+}
+
+func TestParseTemplateVars(t *testing.T) {
+	got, err := ParseTemplateVars([]string{"db=orders", "window=last 1h", "q=a=b"})
+	want := map[string]string{"db": "orders", "window": "last 1h", "q": "a=b"}
+	if err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, %v", got, err)
+	}
+	if _, err := ParseTemplateVars([]string{"db"}); err == nil {
+		t.Error("a var without = should fail")
+	}
+}
+
+func TestRenderTemplate(t *testing.T) {
+	tmpl := config.Template{
+		Name:         "db-latency",
+		Prompt:       "Why is {{db}} slow {{ window }}? Check {{db}} replicas.",
+		Instructions: []config.TemplateInstruction{{Type: "rca", Content: "focus on the {{db}} database layer"}},
+		Telemetry:    []string{"metric", "log"},
+	}
+	if got := TemplateVariables(tmpl); !reflect.DeepEqual(got, []string{"db", "window"}) {
+		t.Errorf("TemplateVariables = %v", got)
+	}
+
+	r, err := RenderTemplate(tmpl, map[string]string{"db": "orders", "window": "since 10:00"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "Why is orders slow since 10:00? Check orders replicas."; r.Prompt != want {
+		t.Errorf("Prompt = %q, want %q", r.Prompt, want)
+	}
+	wantInstr := []config.TemplateInstruction{
+		{Type: "rca", Content: "focus on the orders database layer"},
+		{Type: "system", Content: "Only use metric and log telemetry for this investigation."},
+	}
+	if !reflect.DeepEqual(r.Instructions, wantInstr) {
+		t.Errorf("Instructions = %+v", r.Instructions)
+	}
+
+	if _, err := RenderTemplate(tmpl, map[string]string{"db": "orders"}); err == nil {
+		t.Error("a missing variable should fail")
+	}
+	if _, err := RenderTemplate(tmpl, map[string]string{"db": "orders", "window": "x", "typo": "y"}); err == nil {
+		t.Error("an unknown variable should fail")
+	}
+}
+
+func TestTelemetryScopeInstruction(t *testing.T) {
+	tests := []struct {
+		in   []string
+		want string
+	}{
+		{nil, ""},
+		{[]string{"log"}, "Only use log telemetry for this investigation."},
+		{[]string{"metric", "log", "trace"}, "Only use metric, log and trace telemetry for this investigation."},
+	}
+	for _, tt := range tests {
+		if got := TelemetryScopeInstruction(tt.in); got != tt.want {
+			t.Errorf("TelemetryScopeInstruction(%v) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
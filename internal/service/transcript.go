@@ -0,0 +1,160 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"hawkeye-cli/internal/api"
+)
+
+// ─── Investigation transcripts ──────────────────────────────────────────────
+//
+// With `set transcript-dir` every investigation is recorded as a markdown
+// file: the prompt and session link, a timeline of progress milestones and
+// chain-of-thought steps, and the answer. Each piece is written out as it
+// arrives, so the record survives a lost terminal. The caller creates the
+// file; Transcript only formats what goes into it.
+
+// TranscriptInfo describes the investigation a transcript records.
+type TranscriptInfo struct {
+	Prompt      string
+	SessionUUID string
+	ConsoleURL  string
+	Project     string
+	Started     time.Time
+}
+
+// Transcript writes one investigation's markdown record. Use Handle as (or
+// alongside) the stream callback and Close when the stream ends.
+type Transcript struct {
+	w   io.Writer
+	err error
+
+	seen     map[string]bool // progress messages and step IDs already listed
+	steps    int
+	inAnswer bool
+	answer   string // answer text written so far
+}
+
+// TranscriptName returns the file name for an investigation started at t,
+// e.g. 20261016-140305-1a2b3c4d.md, so transcripts sort by start time.
+func TranscriptName(t time.Time, sessionUUID string) string {
+	id := sessionUUID
+	if len(id) > 8 {
+		id = id[:8]
+	}
+	if id == "" {
+		id = "session"
+	}
+	return t.Format("20060102-150405") + "-" + id + ".md"
+}
+
+// NewTranscript starts a transcript on w by writing its header.
+func NewTranscript(w io.Writer, info TranscriptInfo) (*Transcript, error) {
+	t := &Transcript{w: w, seen: map[string]bool{}}
+
+	var b strings.Builder
+	b.WriteString("# Hawkeye investigation\n\n")
+	fmt.Fprintf(&b, "- **Prompt:** %s\n", oneLine(info.Prompt))
+	fmt.Fprintf(&b, "- **Session:** `%s`\n", info.SessionUUID)
+	if info.ConsoleURL != "" {
+		fmt.Fprintf(&b, "- **Console:** %s\n", info.ConsoleURL)
+	}
+	if info.Project != "" {
+		fmt.Fprintf(&b, "- **Project:** %s\n", info.Project)
+	}
+	fmt.Fprintf(&b, "- **Started:** %s\n\n## Timeline\n\n", info.Started.Format("2006-01-02 15:04:05 MST"))
+	t.write(b.String())
+	if t.err != nil {
+		return nil, fmt.Errorf("writing transcript: %w", t.err)
+	}
+	return t, nil
+}
+
+// Handle records one stream event.
+func (t *Transcript) Handle(resp *api.ProcessPromptResponse) {
+	if resp == nil || resp.Message == nil || resp.Message.Content == nil {
+		return
+	}
+	parts := resp.Message.Content.Parts
+	switch resp.Message.Content.ContentType {
+	case "CONTENT_TYPE_PROGRESS_STATUS":
+		if len(parts) == 0 || strings.HasPrefix(parts[0], "(") {
+			return // activity-only updates such as "(Found 9 results)"
+		}
+		t.milestone("progress:"+NormalizeProgress(ExtractProgressDisplay(parts[0])), ExtractProgressDisplay(parts[0]))
+	case "CONTENT_TYPE_CHAIN_OF_THOUGHT":
+		for _, raw := range parts {
+			var cot api.ChainOfThought
+			if json.Unmarshal([]byte(raw), &cot) != nil || cot.ID == "" {
+				continue
+			}
+			label := firstNonEmpty(cot.Explanation, cot.Description)
+			if label == "" || t.seen["step:"+cot.ID] {
+				continue
+			}
+			t.steps++
+			t.milestone("step:"+cot.ID, fmt.Sprintf("Step %d: %s", t.steps, oneLine(label)))
+		}
+	case "CONTENT_TYPE_CHAT_RESPONSE":
+		if len(parts) == 0 {
+			return
+		}
+		if !t.inAnswer {
+			t.inAnswer = true
+			t.write("\n## Answer\n\n")
+		}
+		if resp.Message.Metadata.IsDeltaTrue() {
+			delta := StripHTML(parts[0])
+			t.answer += delta
+			t.write(delta)
+			return
+		}
+		// Full-text events repeat the whole answer; write what is new.
+		text := StripHTML(strings.Join(parts, "\n"))
+		if strings.HasPrefix(text, t.answer) {
+			t.write(text[len(t.answer):])
+			t.answer = text
+		}
+	}
+}
+
+// milestone adds a timeline entry once per key. Milestones after the answer
+// has started are left out so the answer stays in one piece.
+func (t *Transcript) milestone(key, text string) {
+	if t.seen[key] || t.inAnswer || text == "" {
+		return
+	}
+	t.seen[key] = true
+	t.write(fmt.Sprintf("- `%s` %s\n", time.Now().Format("15:04:05"), text))
+}
+
+// Close writes the footer, noting streamErr if the investigation failed.
+// It returns the first write error; closing the writer is left to the
+// caller.
+func (t *Transcript) Close(streamErr error) error {
+	if t.inAnswer && !strings.HasSuffix(t.answer, "\n") {
+		t.write("\n")
+	}
+	end := time.Now().Format("2006-01-02 15:04:05 MST")
+	if streamErr != nil {
+		t.write(fmt.Sprintf("\n---\n\n*Failed %s: %s*\n", end, oneLine(streamErr.Error())))
+	} else {
+		t.write(fmt.Sprintf("\n---\n\n*Completed %s*\n", end))
+	}
+	return t.err
+}
+
+func (t *Transcript) write(s string) {
+	if t.err != nil || s == "" {
+		return
+	}
+	_, t.err = io.WriteString(t.w, s)
+}
+
+func oneLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
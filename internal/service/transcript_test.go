@@ -0,0 +1,108 @@
+package service
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"hawkeye-cli/internal/api"
+)
+
+func TestTranscriptName(t *testing.T) {
+	at := time.Date(2026, 10, 16, 14, 3, 5, 0, time.UTC)
+	tests := []struct{ session, want string }{
+		{"1a2b3c4d-5e6f-7a8b-9c0d-1e2f3a4b5c6d", "20261016-140305-1a2b3c4d.md"},
+		{"abc", "20261016-140305-abc.md"},
+		{"", "20261016-140305-session.md"},
+	}
+	for _, tt := range tests {
+		if got := TranscriptName(at, tt.session); got != tt.want {
+			t.Errorf("TranscriptName(%q) = %q, want %q", tt.session, got, tt.want)
+		}
+	}
+}
+
+func TestTranscript(t *testing.T) {
+	event := func(ct string, delta bool, parts ...string) *api.ProcessPromptResponse {
+		msg := &api.Message{Content: &api.Content{ContentType: ct, Parts: parts}}
+		if delta {
+			msg.Metadata = &api.Metadata{IsDelta: true}
+		}
+		return &api.ProcessPromptResponse{Message: msg}
+	}
+
+	tests := []struct {
+		name      string
+		events    []*api.ProcessPromptResponse
+		streamErr error
+		want      []string
+		dontWant  []string
+	}{
+		{
+			name: "delta answer",
+			events: []*api.ProcessPromptResponse{
+				event("CONTENT_TYPE_PROGRESS_STATUS", false, "Planner (Planning the investigation)"),
+				event("CONTENT_TYPE_PROGRESS_STATUS", false, "Planner (Planning the investigation)"),
+				event("CONTENT_TYPE_PROGRESS_STATUS", false, "(Found 9 results)"),
+				event("CONTENT_TYPE_CHAIN_OF_THOUGHT", false, `{"id":"c1","explanation":"Check API errors"}`, `{"id":"c2","description":"Check DB"}`),
+				event("CONTENT_TYPE_CHAIN_OF_THOUGHT", false, `{"id":"c1","explanation":"Check API errors"}`),
+				event("CONTENT_TYPE_CHAT_RESPONSE", true, "The DB "),
+				event("CONTENT_TYPE_CHAT_RESPONSE", true, "was <b>full</b>."),
+				event("CONTENT_TYPE_PROGRESS_STATUS", false, "Summary (Summarizing)"),
+			},
+			want: []string{
+				"- **Prompt:** Why is checkout slow?\n",
+				"- **Session:** `sess-1`\n",
+				"- **Console:** https://app/console/project/p/session/sess-1\n",
+				"Planning the investigation\n",
+				"Step 1: Check API errors\n",
+				"Step 2: Check DB\n",
+				"## Answer\n\nThe DB was full.\n",
+				"*Completed ",
+			},
+			dontWant: []string{"Found 9 results", "Summarizing", "Step 3"},
+		},
+		{
+			name: "full-text answer and failure",
+			events: []*api.ProcessPromptResponse{
+				event("CONTENT_TYPE_CHAT_RESPONSE", false, "The DB"),
+				event("CONTENT_TYPE_CHAT_RESPONSE", false, "The DB was full."),
+			},
+			streamErr: errors.New("connection reset"),
+			want:      []string{"## Answer\n\nThe DB was full.\n", "*Failed ", ": connection reset*"},
+			dontWant:  []string{"The DBThe DB"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf strings.Builder
+			tr, err := NewTranscript(&buf, TranscriptInfo{
+				Prompt:      "Why is\ncheckout slow?",
+				SessionUUID: "sess-1",
+				ConsoleURL:  "https://app/console/project/p/session/sess-1",
+				Started:     time.Now(),
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, ev := range tt.events {
+				tr.Handle(ev)
+			}
+			if err := tr.Close(tt.streamErr); err != nil {
+				t.Fatalf("Close() error = %v", err)
+			}
+			out := buf.String()
+			for _, s := range tt.want {
+				if !strings.Contains(out, s) {
+					t.Errorf("transcript missing %q:\n%s", s, out)
+				}
+			}
+			for _, s := range tt.dontWant {
+				if strings.Contains(out, s) {
+					t.Errorf("transcript contains %q:\n%s", s, out)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,178 @@
+package service
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"hawkeye-cli/internal/api"
+)
+
+// ─── watch scheduling ───────────────────────────────────────────────────────
+//
+// `watch --auto-investigate` queues every uninvestigated incident it sees
+// and starts investigations from the queue: at most --max-concurrent at a
+// time, the priorities of --priority-order first, and only inside
+// --business-hours. Priority comes from the incident title, where alert
+// sources put it ("[P1] checkout 5xx", "SEV2: disk full").
+
+var (
+	priorityRE     = regexp.MustCompile(`(?i)\b(P[0-9]|SEV[0-9])\b`)
+	priorityNameRE = regexp.MustCompile(`^(P[0-9]|SEV[0-9])$`)
+)
+
+// IncidentPriority returns the priority named in an incident title, such
+// as P1 or SEV2, or "" when there is none.
+func IncidentPriority(title string) string {
+	return strings.ToUpper(priorityRE.FindString(title))
+}
+
+// ParsePriorityOrder parses a --priority-order list such as "P1,P2".
+func ParsePriorityOrder(s string) ([]string, error) {
+	var order []string
+	for _, p := range strings.Split(s, ",") {
+		p = strings.ToUpper(strings.TrimSpace(p))
+		if p == "" {
+			continue
+		}
+		if !priorityNameRE.MatchString(p) {
+			return nil, fmt.Errorf("invalid priority %q (use e.g. P1 or SEV2)", p)
+		}
+		order = append(order, p)
+	}
+	if len(order) == 0 {
+		return nil, fmt.Errorf("--priority-order needs at least one priority, e.g. P1,P2")
+	}
+	return order, nil
+}
+
+// WatchCandidate is an incident waiting in the watch queue.
+type WatchCandidate struct {
+	SessionUUID string
+	Priority    string
+	CreateTime  string
+}
+
+// OrderWatchQueue sorts candidates for starting: priorities in the order
+// given, then those without a listed priority; oldest first within each.
+func OrderWatchQueue(items []WatchCandidate, order []string) {
+	rank := func(p string) int {
+		for i, o := range order {
+			if o == p {
+				return i
+			}
+		}
+		return len(order)
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		ri, rj := rank(items[i].Priority), rank(items[j].Priority)
+		if ri != rj {
+			return ri < rj
+		}
+		return items[i].CreateTime < items[j].CreateTime
+	})
+}
+
+// UninvestigatedIncidents keeps the incident sessions of a not-started
+// listing, dropping sessions of other types.
+func UninvestigatedIncidents(sessions []api.SessionInfo) []api.SessionInfo {
+	var out []api.SessionInfo
+	for _, s := range sessions {
+		if s.SessionType != "" && s.SessionType != "SESSION_TYPE_INCIDENT" {
+			continue
+		}
+		out = append(out, s)
+	}
+	return out
+}
+
+// BusinessHours is a weekly window in which investigations may start.
+type BusinessHours struct {
+	Start, End int     // hours of the day, End exclusive
+	Days       [7]bool // indexed by time.Weekday
+	spec       string  // as given, for messages
+}
+
+// AnyTime is the window when --business-hours is not given.
+var AnyTime = BusinessHours{End: 24, Days: [7]bool{true, true, true, true, true, true, true}, spec: "any time"}
+
+var weekdays = []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}
+
+// ParseBusinessHours parses a --business-hours value: an hour range and an
+// optional day range or list, such as "9-18 Mon-Fri" or "8-20 Mon,Wed,Fri".
+// Without days the window applies every day.
+func ParseBusinessHours(s string) (BusinessHours, error) {
+	b := BusinessHours{spec: strings.TrimSpace(s)}
+	fields := strings.Fields(s)
+	if len(fields) == 0 || len(fields) > 2 {
+		return b, fmt.Errorf("invalid --business-hours %q (use e.g. 9-18 Mon-Fri)", s)
+	}
+	from, to, ok := strings.Cut(fields[0], "-")
+	start, err1 := strconv.Atoi(from)
+	end, err2 := strconv.Atoi(to)
+	if !ok || err1 != nil || err2 != nil || start < 0 || end > 24 || start >= end {
+		return b, fmt.Errorf("invalid hours %q (use start-end in 0-24, e.g. 9-18)", fields[0])
+	}
+	b.Start, b.End = start, end
+
+	if len(fields) == 1 {
+		b.Days = AnyTime.Days
+		return b, nil
+	}
+	for _, part := range strings.Split(strings.ToLower(fields[1]), ",") {
+		first, last, isRange := strings.Cut(part, "-")
+		i, j := weekdayIndex(first), weekdayIndex(last)
+		if !isRange {
+			j = i
+		}
+		if i < 0 || j < 0 {
+			return b, fmt.Errorf("invalid days %q (use e.g. Mon-Fri or Mon,Wed,Fri)", fields[1])
+		}
+		for d := i; ; d = (d + 1) % 7 {
+			b.Days[d] = true
+			if d == j {
+				break
+			}
+		}
+	}
+	return b, nil
+}
+
+func weekdayIndex(s string) int {
+	s = strings.TrimSpace(s)
+	if len(s) < 3 {
+		return -1
+	}
+	for i, d := range weekdays {
+		if strings.HasPrefix(s, d) {
+			return i
+		}
+	}
+	return -1
+}
+
+// Open reports whether t falls inside the window.
+func (b BusinessHours) Open(t time.Time) bool {
+	return b.Days[t.Weekday()] && t.Hour() >= b.Start && t.Hour() < b.End
+}
+
+// NextOpen returns when the window next opens after t, or t itself when it
+// is open now.
+func (b BusinessHours) NextOpen(t time.Time) time.Time {
+	if b.Open(t) {
+		return t
+	}
+	day := time.Date(t.Year(), t.Month(), t.Day(), b.Start, 0, 0, 0, t.Location())
+	for i := 0; i < 8; i++ {
+		if open := day.AddDate(0, 0, i); open.After(t) && b.Days[open.Weekday()] {
+			return open
+		}
+	}
+	return t
+}
+
+// String returns the window as given.
+func (b BusinessHours) String() string { return b.spec }
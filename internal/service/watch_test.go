@@ -0,0 +1,104 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"hawkeye-cli/internal/api"
+)
+
+func TestIncidentPriority(t *testing.T) {
+	for title, want := range map[string]string{
+		"[P1] checkout 5xx":   "P1",
+		"sev2: disk full":     "SEV2",
+		"PagerDuty: p3 alert": "P3",
+		"HTTP2 errors":        "",
+		"API v1 latency":      "",
+	} {
+		if got := IncidentPriority(title); got != want {
+			t.Errorf("IncidentPriority(%q) = %q, want %q", title, got, want)
+		}
+	}
+}
+
+func TestParsePriorityOrder(t *testing.T) {
+	order, err := ParsePriorityOrder("p1, P2,,sev1")
+	if err != nil || len(order) != 3 || order[0] != "P1" || order[2] != "SEV1" {
+		t.Errorf("ParsePriorityOrder = %q, %v", order, err)
+	}
+	for _, bad := range []string{"", ",", "high", "P10"} {
+		if _, err := ParsePriorityOrder(bad); err == nil {
+			t.Errorf("ParsePriorityOrder(%q) should fail", bad)
+		}
+	}
+}
+
+func TestOrderWatchQueue(t *testing.T) {
+	items := []WatchCandidate{
+		{SessionUUID: "a", Priority: "", CreateTime: "2026-01-01T00:00:00Z"},
+		{SessionUUID: "b", Priority: "P2", CreateTime: "2026-01-01T00:00:00Z"},
+		{SessionUUID: "c", Priority: "P1", CreateTime: "2026-01-03T00:00:00Z"},
+		{SessionUUID: "d", Priority: "P3", CreateTime: "2025-12-01T00:00:00Z"},
+		{SessionUUID: "e", Priority: "P1", CreateTime: "2026-01-02T00:00:00Z"},
+	}
+	OrderWatchQueue(items, []string{"P1", "P2"})
+	got := ""
+	for _, it := range items {
+		got += it.SessionUUID
+	}
+	if got != "ecbda" {
+		t.Errorf("order = %s, want ecbda", got)
+	}
+}
+
+func TestUninvestigatedIncidents(t *testing.T) {
+	got := UninvestigatedIncidents([]api.SessionInfo{
+		{SessionUUID: "a", SessionType: "SESSION_TYPE_INCIDENT"},
+		{SessionUUID: "b", SessionType: "SESSION_TYPE_CHAT"},
+		{SessionUUID: "c"},
+	})
+	if len(got) != 2 || got[0].SessionUUID != "a" || got[1].SessionUUID != "c" {
+		t.Errorf("UninvestigatedIncidents = %+v", got)
+	}
+}
+
+func TestParseBusinessHours(t *testing.T) {
+	b, err := ParseBusinessHours("9-18 Mon-Fri")
+	if err != nil {
+		t.Fatal(err)
+	}
+	at := func(day, hour, min int) time.Time { return time.Date(2026, 3, day, hour, min, 0, 0, time.UTC) } // 2 March 2026 is a Monday
+	if !b.Open(at(2, 9, 0)) || !b.Open(at(6, 17, 59)) {
+		t.Error("should be open Monday 9:00 and Friday 17:59")
+	}
+	if b.Open(at(2, 18, 0)) || b.Open(at(2, 8, 59)) || b.Open(at(7, 12, 0)) {
+		t.Error("should be closed at 18:00, before 9:00 and on Saturday")
+	}
+	if got := b.NextOpen(at(6, 19, 0)); !got.Equal(at(9, 9, 0)) {
+		t.Errorf("NextOpen(Friday 19:00) = %v, want Monday 9:00", got)
+	}
+	if got := b.NextOpen(at(3, 7, 30)); !got.Equal(at(3, 9, 0)) {
+		t.Errorf("NextOpen(Tuesday 7:30) = %v, want 9:00 the same day", got)
+	}
+	if b.String() != "9-18 Mon-Fri" {
+		t.Errorf("String() = %q", b.String())
+	}
+
+	list, err := ParseBusinessHours("8-20 mon,wed,fri")
+	if err != nil || !list.Days[time.Wednesday] || list.Days[time.Tuesday] {
+		t.Errorf("day list = %+v, %v", list.Days, err)
+	}
+	wrap, err := ParseBusinessHours("0-24 Fri-Mon")
+	if err != nil || !wrap.Days[time.Sunday] || wrap.Days[time.Wednesday] {
+		t.Errorf("wrapping range = %+v, %v", wrap.Days, err)
+	}
+	if daily, err := ParseBusinessHours("9-18"); err != nil || !daily.Open(at(7, 10, 0)) {
+		t.Errorf("hours alone should apply every day: %v", err)
+	}
+
+	for _, bad := range []string{"", "9", "18-9", "9-25", "9-18 Someday", "9-18 Mon Tue"} {
+		if _, err := ParseBusinessHours(bad); err == nil {
+			t.Errorf("ParseBusinessHours(%q) should fail", bad)
+		}
+	}
+}
@@ -0,0 +1,160 @@
+package service
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// ─── Alert webhooks ─────────────────────────────────────────────────────────
+//
+// `hawkeye listen` accepts alert webhooks and turns each firing alert into
+// an investigation. Two payload shapes are understood:
+//
+//	PagerDuty v3 webhooks   {"event": {"event_type": "incident.triggered", ...}}
+//	Alertmanager webhooks   {"version": "4", "alerts": [...]}
+
+// Webhook alert sources.
+const (
+	WebhookSourcePagerDuty    = "pagerduty"
+	WebhookSourceAlertmanager = "alertmanager"
+)
+
+// WebhookAlert is one alert to investigate from a webhook delivery.
+type WebhookAlert struct {
+	Source string `json:"source"`
+	// AlertID is the sender's alert ID, used to create the session from the
+	// alert. Empty when the sender has no ID the backend would know.
+	AlertID string `json:"alert_id,omitempty"`
+	Title   string `json:"title"`
+	Prompt  string `json:"prompt"`
+}
+
+type pagerDutyWebhook struct {
+	Event *struct {
+		EventType string `json:"event_type"`
+		Data      struct {
+			ID      string `json:"id"`
+			Title   string `json:"title"`
+			Urgency string `json:"urgency"`
+			HTMLURL string `json:"html_url"`
+			Service struct {
+				Summary string `json:"summary"`
+			} `json:"service"`
+		} `json:"data"`
+	} `json:"event"`
+}
+
+// ParseAlertWebhook extracts the alerts to investigate from a webhook body.
+// Only newly firing alerts are returned: resolved Alertmanager alerts and
+// PagerDuty events other than incident.triggered yield an empty list.
+func ParseAlertWebhook(body []byte) ([]WebhookAlert, error) {
+	var probe map[string]json.RawMessage
+	if err := json.Unmarshal(body, &probe); err != nil {
+		return nil, fmt.Errorf("invalid webhook payload: %w", err)
+	}
+
+	switch {
+	case probe["event"] != nil:
+		var pd pagerDutyWebhook
+		if err := json.Unmarshal(body, &pd); err != nil {
+			return nil, fmt.Errorf("invalid PagerDuty payload: %w", err)
+		}
+		if pd.Event == nil {
+			return nil, fmt.Errorf("PagerDuty payload has no event")
+		}
+		if pd.Event.EventType != "incident.triggered" {
+			return nil, nil
+		}
+		d := pd.Event.Data
+		if d.ID == "" {
+			return nil, fmt.Errorf("PagerDuty payload has no incident id")
+		}
+		var b strings.Builder
+		fmt.Fprintf(&b, "Investigate PagerDuty incident %s: %s", d.ID, d.Title)
+		if d.Service.Summary != "" {
+			fmt.Fprintf(&b, "\nService: %s", d.Service.Summary)
+		}
+		if d.Urgency != "" {
+			fmt.Fprintf(&b, "\nUrgency: %s", d.Urgency)
+		}
+		if d.HTMLURL != "" {
+			fmt.Fprintf(&b, "\nIncident: %s", d.HTMLURL)
+		}
+		return []WebhookAlert{{
+			Source:  WebhookSourcePagerDuty,
+			AlertID: d.ID,
+			Title:   d.Title,
+			Prompt:  b.String(),
+		}}, nil
+
+	case probe["alerts"] != nil:
+		am, err := ParseAlertmanagerPayload(body)
+		if err != nil {
+			return nil, err
+		}
+		var alerts []WebhookAlert
+		for _, a := range am.Firing() {
+			alerts = append(alerts, WebhookAlert{
+				Source: WebhookSourceAlertmanager,
+				Title:  a.Name(),
+				Prompt: AlertmanagerPrompt([]AlertmanagerAlert{a}),
+			})
+		}
+		return alerts, nil
+	}
+	return nil, fmt.Errorf("unrecognized webhook payload (expected PagerDuty or Alertmanager)")
+}
+
+// VerifyWebhookSignature checks a delivery's HMAC-SHA256 signature against
+// secret. PagerDuty's X-PagerDuty-Signature ("v1=<hex>", possibly several
+// comma-separated) and a generic X-Hawkeye-Signature ("sha256=<hex>") are
+// accepted.
+func VerifyWebhookSignature(body []byte, secret string, header http.Header) bool {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	want := mac.Sum(nil)
+
+	var candidates []string
+	for _, sig := range strings.Split(header.Get("X-PagerDuty-Signature"), ",") {
+		if v, ok := strings.CutPrefix(strings.TrimSpace(sig), "v1="); ok {
+			candidates = append(candidates, v)
+		}
+	}
+	if v, ok := strings.CutPrefix(strings.TrimSpace(header.Get("X-Hawkeye-Signature")), "sha256="); ok {
+		candidates = append(candidates, v)
+	}
+	for _, c := range candidates {
+		got, err := hex.DecodeString(c)
+		if err == nil && hmac.Equal(got, want) {
+			return true
+		}
+	}
+	return false
+}
+
+// VerifyWebhookToken checks a delivery's shared secret for senders that
+// cannot sign bodies, such as Alertmanager: either "Authorization: Bearer
+// <secret>" or basic auth with the secret as the password (any username).
+func VerifyWebhookToken(secret string, header http.Header) bool {
+	r := http.Request{Header: header}
+	if _, password, ok := r.BasicAuth(); ok {
+		return subtle.ConstantTimeCompare([]byte(password), []byte(secret)) == 1
+	}
+	scheme, token, ok := strings.Cut(header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(strings.TrimSpace(token)), []byte(secret)) == 1
+}
+
+// AuthenticateWebhook accepts a delivery carrying either a valid HMAC
+// signature or the shared secret itself.
+func AuthenticateWebhook(body []byte, secret string, header http.Header) bool {
+	return VerifyWebhookSignature(body, secret, header) || VerifyWebhookToken(secret, header)
+}
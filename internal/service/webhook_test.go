@@ -0,0 +1,149 @@
+package service
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestParseAlertWebhook(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		wantCount  int
+		wantSource string
+		wantID     string
+		wantPrompt string
+		wantErr    string
+	}{
+		{
+			name:       "pagerduty triggered",
+			body:       `{"event":{"event_type":"incident.triggered","data":{"id":"PGR0VU2","title":"Checkout 500s","urgency":"high","service":{"summary":"checkout"}}}}`,
+			wantCount:  1,
+			wantSource: WebhookSourcePagerDuty,
+			wantID:     "PGR0VU2",
+			wantPrompt: "Investigate PagerDuty incident PGR0VU2: Checkout 500s\nService: checkout\nUrgency: high",
+		},
+		{
+			name: "pagerduty acknowledged is ignored",
+			body: `{"event":{"event_type":"incident.acknowledged","data":{"id":"PGR0VU2"}}}`,
+		},
+		{
+			name:    "pagerduty without id",
+			body:    `{"event":{"event_type":"incident.triggered","data":{}}}`,
+			wantErr: "no incident id",
+		},
+		{
+			name:       "alertmanager firing and resolved",
+			body:       `{"version":"4","alerts":[{"status":"firing","labels":{"alertname":"HighLatency","job":"api","instance":"api-1"},"annotations":{"summary":"p99 above 2s"}},{"status":"resolved","labels":{"alertname":"DiskFull"}}]}`,
+			wantCount:  1,
+			wantSource: WebhookSourceAlertmanager,
+			wantPrompt: "Investigate the firing Prometheus alert HighLatency.\nInstance: api-1\nSummary: p99 above 2s\nLabels: job=api",
+		},
+		{
+			name:    "unknown shape",
+			body:    `{"hello":"world"}`,
+			wantErr: "unrecognized",
+		},
+		{
+			name:    "not json",
+			body:    `nope`,
+			wantErr: "invalid webhook payload",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			alerts, err := ParseAlertWebhook([]byte(tt.body))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ParseAlertWebhook() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseAlertWebhook() error = %v", err)
+			}
+			if len(alerts) != tt.wantCount {
+				t.Fatalf("got %d alerts, want %d", len(alerts), tt.wantCount)
+			}
+			if tt.wantCount == 0 {
+				return
+			}
+			a := alerts[0]
+			if a.Source != tt.wantSource || a.AlertID != tt.wantID || a.Prompt != tt.wantPrompt {
+				t.Errorf("got %+v", a)
+			}
+		})
+	}
+}
+
+func TestVerifyWebhookSignature(t *testing.T) {
+	body := []byte(`{"alerts":[]}`)
+	mac := hmac.New(sha256.New, []byte("s3cret"))
+	mac.Write(body)
+	sig := hex.EncodeToString(mac.Sum(nil))
+
+	tests := []struct {
+		name   string
+		header string
+		value  string
+		want   bool
+	}{
+		{"pagerduty", "X-PagerDuty-Signature", "v1=" + sig, true},
+		{"pagerduty rotated", "X-PagerDuty-Signature", "v1=deadbeef, v1=" + sig, true},
+		{"hawkeye", "X-Hawkeye-Signature", "sha256=" + sig, true},
+		{"wrong", "X-Hawkeye-Signature", "sha256=deadbeef", false},
+		{"missing prefix", "X-Hawkeye-Signature", sig, false},
+		{"absent", "X-Other", sig, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := http.Header{}
+			h.Set(tt.header, tt.value)
+			if got := VerifyWebhookSignature(body, "s3cret", h); got != tt.want {
+				t.Errorf("VerifyWebhookSignature() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAuthenticateWebhook(t *testing.T) {
+	body := []byte(`{"alerts":[]}`)
+	mac := hmac.New(sha256.New, []byte("s3cret"))
+	mac.Write(body)
+	sig := hex.EncodeToString(mac.Sum(nil))
+	basic := func(user, pass string) string {
+		return "Basic " + base64.StdEncoding.EncodeToString([]byte(user+":"+pass))
+	}
+
+	tests := []struct {
+		name   string
+		header string
+		value  string
+		want   bool
+	}{
+		{"signature", "X-Hawkeye-Signature", "sha256=" + sig, true},
+		{"bearer", "Authorization", "Bearer s3cret", true},
+		{"bearer lowercase", "Authorization", "bearer s3cret", true},
+		{"bearer wrong", "Authorization", "Bearer nope", false},
+		{"basic", "Authorization", basic("alertmanager", "s3cret"), true},
+		{"basic no user", "Authorization", basic("", "s3cret"), true},
+		{"basic wrong", "Authorization", basic("s3cret", "nope"), false},
+		{"other scheme", "Authorization", "Token s3cret", false},
+		{"bare secret", "Authorization", "s3cret", false},
+		{"absent", "X-Other", "s3cret", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := http.Header{}
+			h.Set(tt.header, tt.value)
+			if got := AuthenticateWebhook(body, "s3cret", h); got != tt.want {
+				t.Errorf("AuthenticateWebhook() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
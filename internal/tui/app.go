@@ -3,14 +3,26 @@ package tui
 import (
 	"fmt"
 
+	"hawkeye-cli/internal/display"
+
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
 )
 
-// Run launches the interactive TUI mode (inline, like Claude Code).
+// Run launches the interactive TUI mode. The conversation fills the screen;
+// with a screen reader it is printed inline instead, see inlineOutput.
 func Run(version, profile, resumeSessionID string) error {
+	lipgloss.SetColorProfile(colorProfile(display.CurrentColorLevel()))
+	applyTheme(display.CurrentPalette())
 	m := initialModel(version, profile, resumeSessionID)
 
-	p := tea.NewProgram(m)
+	inlineOutput = display.Accessible()
+	var opts []tea.ProgramOption
+	if !inlineOutput {
+		opts = append(opts, tea.WithAltScreen())
+	}
+	p := tea.NewProgram(m, opts...)
 
 	if _, err := p.Run(); err != nil {
 		return fmt.Errorf("TUI error: %w", err)
@@ -18,3 +30,17 @@ func Run(version, profile, resumeSessionID string) error {
 
 	return nil
 }
+
+// colorProfile maps the detected color level to lipgloss's profile, so the
+// TUI degrades on the same terminals (ConHost, TERM=linux) as the CLI.
+func colorProfile(l display.ColorLevel) termenv.Profile {
+	switch l {
+	case display.ColorNone:
+		return termenv.Ascii
+	case display.Color16:
+		return termenv.ANSI
+	case display.Color256:
+		return termenv.ANSI256
+	}
+	return termenv.TrueColor
+}
@@ -0,0 +1,62 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"hawkeye-cli/internal/display"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+func TestColorProfile(t *testing.T) {
+	tests := map[display.ColorLevel]termenv.Profile{
+		display.ColorNone: termenv.Ascii,
+		display.Color16:   termenv.ANSI,
+		display.Color256:  termenv.ANSI256,
+		display.ColorTrue: termenv.TrueColor,
+	}
+	for level, want := range tests {
+		if got := colorProfile(level); got != want {
+			t.Errorf("colorProfile(%s) = %v, want %v", level, got, want)
+		}
+	}
+}
+
+func TestViewOnConsoleHost(t *testing.T) {
+	// A console host without emoji glyphs or escape sequences is detected
+	// as ASCII with no color; the view must then be plain ASCII.
+	display.SetASCII(true)
+	defer display.SetASCII(false)
+	lipgloss.SetColorProfile(colorProfile(display.ColorNone))
+	defer lipgloss.SetColorProfile(termenv.TrueColor)
+
+	m := newTestModel()
+	for _, mode := range []appMode{modeIdle, modeTriageBoard, modeDashboard, modeResourceBrowser} {
+		m.mode = mode
+		view := m.View()
+		for i, r := range view {
+			if r > 0x7e || (r < 0x20 && r != '\n') {
+				t.Fatalf("mode %d: non-ASCII %q at %d in view:\n%s", mode, r, i, view)
+			}
+		}
+	}
+}
+
+func TestAccessibleStreaming(t *testing.T) {
+	display.SetAccessible(true)
+	defer display.SetAccessible(false)
+	lipgloss.SetColorProfile(colorProfile(display.ColorNone))
+	defer lipgloss.SetColorProfile(termenv.TrueColor)
+
+	m := newTestModel()
+	m.mode = modeStreaming
+	view := m.View()
+	if !strings.Contains(view, "Investigating... (Ctrl+C to cancel)") || strings.ContainsAny(view, "⣾⣽⣻⢿⡿⣟⣯⣷") {
+		t.Errorf("streaming view = %q, want a fixed status without a spinner", view)
+	}
+	if got := renderOutputEvent(OutputEvent{Type: OutputProgress, Text: "Querying logs"}); got != "  Querying logs" {
+		t.Errorf("progress line = %q", got)
+	}
+}
@@ -0,0 +1,148 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"hawkeye-cli/internal/config"
+	"hawkeye-cli/internal/display"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ─── Startup banner ─────────────────────────────────────────────────────────
+//
+// Interactive mode opens with a banner picked by `hawkeye set banner`: the
+// full hawk, one of two three-line birds with the title beside them, or
+// none. --no-banner turns it off for one run. A banner that does not fit
+// the terminal steps down to a smaller one instead of wrapping: the hawk
+// to the toucan, a compact bird with its text beside it to the bird with
+// the text underneath, and finally to the text alone.
+
+// Banner names.
+const (
+	BannerHawk    = "hawk"
+	BannerToucan  = "toucan"
+	BannerMinimal = "minimal"
+	BannerNone    = "none"
+)
+
+// BannerNames are the banners `set banner` accepts, default first.
+var BannerNames = []string{BannerHawk, BannerToucan, BannerMinimal, BannerNone}
+
+// ParseBanner validates a banner name. "" and "default" mean the hawk.
+func ParseBanner(s string) (string, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if s == "" || s == "default" {
+		return BannerHawk, nil
+	}
+	for _, name := range BannerNames {
+		if s == name {
+			return s, nil
+		}
+	}
+	return "", fmt.Errorf("unknown banner %q (use %s)", s, strings.Join(BannerNames, ", "))
+}
+
+var noBanner bool
+
+// SetNoBanner suppresses the startup banner for this run (--no-banner).
+func SetNoBanner(v bool) { noBanner = v }
+
+// welcomeBanner is the banner to open with.
+func welcomeBanner(cfg *config.Config) string {
+	if noBanner {
+		return BannerNone
+	}
+	if cfg == nil {
+		return BannerHawk
+	}
+	name, err := ParseBanner(cfg.Banner)
+	if err != nil {
+		return BannerHawk
+	}
+	return name
+}
+
+// compactLogo is a three-line bird. mask gives each rune of art its
+// style: b body, k beak, e eye; anything else is left plain.
+type compactLogo struct {
+	art  [3]string
+	mask [3]string
+}
+
+var compactLogos = map[string]compactLogo{
+	BannerToucan: {
+		art: [3]string{
+			" ▄▀▀▀▄",
+			"▐ ◉ ▌▀▀▀▀▀▀▀▌",
+			" ▀▄▄▄▀▄▄▄▄▄▄▄",
+		},
+		mask: [3]string{
+			" bbbbb",
+			"b e bkkkkkkkk",
+			" bbbbbkkkkkkk",
+		},
+	},
+	BannerMinimal: {
+		art: [3]string{
+			"▄██▄",
+			"█◉▐█▀▀▀▀▀▀▀▀▌",
+			"▀██▀▄▄▄▄▄▄▄▄",
+		},
+		mask: [3]string{
+			"bbbb",
+			"bebbkkkkkkkkk",
+			"bbbbkkkkkkkk",
+		},
+	},
+}
+
+func (l compactLogo) width() int {
+	w := 0
+	for _, line := range l.art {
+		w = max(w, lipgloss.Width(line))
+	}
+	return w
+}
+
+// line renders line i of the logo.
+func (l compactLogo) line(i int) string {
+	art, mask := []rune(l.art[i]), []rune(l.mask[i])
+	var b strings.Builder
+	for j, r := range art {
+		s := string(r)
+		switch mask[j] {
+		case 'b':
+			s = logoBodyStyle.Render(s)
+		case 'k':
+			s = logoBeakStyle.Render(s)
+		case 'e':
+			s = logoEyeStyle.Render(s)
+		}
+		b.WriteString(s)
+	}
+	return b.String()
+}
+
+// beside renders line i of the logo with text after it, in a column clear
+// of the widest line.
+func (l compactLogo) beside(i int, text string) string {
+	return l.line(i) + strings.Repeat(" ", l.width()-lipgloss.Width(l.art[i])) + text
+}
+
+const bannerGap = "   " // between a compact logo and its text
+
+// renderCompactBanner puts the title and info lines beside the logo when
+// they fit in width, under it when only the logo fits, and alone
+// otherwise. A width of 0 means unknown.
+func renderCompactBanner(l compactLogo, title, info string, width int) string {
+	textWidth := max(lipgloss.Width(title), lipgloss.Width(info))
+	switch {
+	case display.ASCII() || width > 0 && width < l.width():
+		return fmt.Sprintf("\n%s\n%s\n", title, info)
+	case width > 0 && width < l.width()+len(bannerGap)+textWidth:
+		return fmt.Sprintf("\n%s\n%s\n%s\n\n%s\n%s\n", l.line(0), l.line(1), l.line(2), title, info)
+	}
+	return fmt.Sprintf("\n%s\n%s\n%s\n", l.line(0), l.beside(1, bannerGap+title), l.beside(2, bannerGap+info))
+}
@@ -0,0 +1,68 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"hawkeye-cli/internal/config"
+	"hawkeye-cli/internal/display"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestParseBanner(t *testing.T) {
+	for in, want := range map[string]string{"": BannerHawk, "Toucan": BannerToucan, "none": BannerNone, "default": BannerHawk} {
+		if got, err := ParseBanner(in); err != nil || got != want {
+			t.Errorf("ParseBanner(%q) = %q, %v; want %q", in, got, err, want)
+		}
+	}
+	if _, err := ParseBanner("eagle"); err == nil || !strings.Contains(err.Error(), "hawk, toucan, minimal, none") {
+		t.Errorf("ParseBanner(eagle) error = %v", err)
+	}
+
+	cfg := &config.Config{Banner: BannerMinimal}
+	if got := welcomeBanner(cfg); got != BannerMinimal {
+		t.Errorf("welcomeBanner = %q", got)
+	}
+	SetNoBanner(true)
+	defer SetNoBanner(false)
+	if got := welcomeBanner(cfg); got != BannerNone {
+		t.Errorf("welcomeBanner with --no-banner = %q", got)
+	}
+}
+
+func TestRenderWelcomeFitsWidth(t *testing.T) {
+	const server, project = "https://myenv.app.neubird.ai/api", "payments-production"
+	for _, banner := range []string{BannerHawk, BannerToucan, BannerMinimal} {
+		for _, width := range []int{120, 50, 30, 20} {
+			out := renderWelcome(banner, "1.2.3", server, project, width)
+			if !strings.Contains(stripANSI(out), "Hawkeye CLI v1.2.3") {
+				t.Errorf("%s at %d: no title:\n%s", banner, width, out)
+			}
+			for _, line := range strings.Split(out, "\n") {
+				if w := lipgloss.Width(line); w > width {
+					t.Errorf("%s at %d: line of %d cells: %q", banner, width, w, stripANSI(line))
+				}
+			}
+		}
+	}
+
+	// Wide enough, the text sits beside a compact bird.
+	out := stripANSI(renderWelcome(BannerToucan, "1.2.3", server, project, 120))
+	if !strings.Contains(out, "▌   Hawkeye CLI") {
+		t.Errorf("title not beside the toucan:\n%s", out)
+	}
+	// The hawk steps down to the toucan when it does not fit.
+	if out := stripANSI(renderWelcome(BannerHawk, "1.2.3", "", "", 40)); strings.Contains(out, "****") || !strings.Contains(out, "◉") {
+		t.Errorf("narrow hawk:\n%s", out)
+	}
+	if out := renderWelcome(BannerNone, "1.2.3", server, project, 120); out != "" {
+		t.Errorf("none = %q", out)
+	}
+
+	display.SetASCII(true)
+	defer display.SetASCII(false)
+	if out := stripANSI(renderWelcome(BannerMinimal, "1.2.3", "", "", 120)); strings.Contains(out, "█") {
+		t.Errorf("ASCII mode drew block art:\n%s", out)
+	}
+}
@@ -3,6 +3,8 @@ package tui
 import (
 	"fmt"
 	"os"
+	"os/exec"
+	"path/filepath"
 	"strconv"
 	"strings"
 
@@ -35,60 +37,49 @@ func (m model) dispatchCommand(input string) (tea.Model, tea.Cmd) {
 	}
 
 	cmd := strings.ToLower(parts[0])
-	args := parts[1:]
+	args := m.resolveSessionAliases(cmd, parts[1:])
 
-	switch cmd {
-	case "/help", "/h":
-		return m.cmdHelp()
-	case "/login":
-		return m.cmdLogin(args)
-	case "/projects":
-		return m.cmdProjects(args)
-	case "/inspect":
-		return m.cmdInspect(args)
-	case "/summary":
-		return m.cmdSummary(args)
-	case "/feedback", "/td":
-		return m.cmdFeedback(args)
-	case "/prompts":
-		return m.cmdPrompts()
-	case "/config":
-		return m.cmdConfig()
-	case "/set":
-		return m.cmdSet(args)
-	case "/clear":
-		return m.cmdClear()
-	case "/score":
-		return m.cmdScore(args)
-	case "/link":
-		return m.cmdLink(args)
-	case "/report":
-		return m.cmdReport()
-	case "/connections":
-		return m.cmdConnections(args)
-	case "/instructions":
-		return m.cmdInstructions(args)
-	case "/rerun":
-		return m.cmdRerun(args)
-	case "/investigate-alert":
-		return m.cmdInvestigateAlert(args)
-	case "/queries":
-		return m.cmdQueries(args)
-	case "/discover":
-		return m.cmdDiscover()
-	case "/session-report":
-		return m.cmdSessionReport(args)
-	case "/incidents":
-		return m.cmdIncidents(args)
-	case "/open":
-		return m.cmdOpen(args)
-	case "/session":
-		return m.cmdSetSession(args)
-	case "/quit", "/exit", "/q":
-		return m, tea.Quit
-	default:
-		return m, tea.Println(errorMsgStyle.Render(fmt.Sprintf("  ✗ Unknown command: %s — type /help", cmd)))
+	if command, ok := slashRegistry().Lookup(cmd); ok {
+		return command.Run(m, args)
 	}
+	if path, ok := config.FindPlugin(cmd[1:], exec.LookPath); ok {
+		return m, m.runPlugin(path, args)
+	}
+	return m, printLine(errorMsgStyle.Render(fmt.Sprintf("  ✗ Unknown command: %s — type /help", cmd)))
+}
+
+// runPlugin hands the terminal to a hawkeye-<cmd> plugin, as the CLI does
+// for an unknown command, and reports how it exited.
+func (m model) runPlugin(path string, args []string) tea.Cmd {
+	self, _ := os.Executable()
+	c := exec.Command(path, args...)
+	c.Env = config.PluginEnv(os.Environ(), self, m.profile, m.cfg)
+	name := filepath.Base(path)
+	return tea.ExecProcess(c, func(err error) tea.Msg {
+		if err != nil {
+			return printLine(errorMsgStyle.Render(fmt.Sprintf("  ✗ %s: %v", name, err)))()
+		}
+		return nil
+	})
+}
+
+// resolveSessionAliases swaps session aliases (see `hawkeye alias`) for
+// UUIDs in the arguments of commands that take a session. Only the first
+// argument is a session, except for /session-report which takes several.
+func (m model) resolveSessionAliases(cmd string, args []string) []string {
+	if uuidArgCommands[cmd] != uuidSession || len(args) == 0 {
+		return args
+	}
+	resolved := append([]string(nil), args...)
+	for i, a := range resolved {
+		if i > 0 && cmd != "/session-report" {
+			break
+		}
+		if !strings.HasPrefix(a, "-") {
+			resolved[i] = m.cfg.ResolveSession(a)
+		}
+	}
+	return resolved
 }
 
 // ─── /help ──────────────────────────────────────────────────────────────────
@@ -102,35 +93,20 @@ func (m model) cmdHelp() (tea.Model, tea.Cmd) {
 	}
 
 	lines := []tea.Cmd{
-		tea.Println(""),
-		tea.Println(dimStyle.Render("  Shortcuts:")),
-		tea.Println(""),
-		tea.Println("  " + pad(hintKeyStyle.Render("/login <url>"), 30) + dimStyle.Render("Login to a Hawkeye server")),
-		tea.Println("  " + pad(hintKeyStyle.Render("/projects"), 30) + dimStyle.Render("List available projects")),
-		tea.Println("  " + pad(hintKeyStyle.Render("/session [uuid]"), 30) + dimStyle.Render("Pick or set active session")),
-		tea.Println("  " + pad(hintKeyStyle.Render("/inspect <uuid>"), 30) + dimStyle.Render("View session details")),
-		tea.Println("  " + pad(hintKeyStyle.Render("/summary <uuid>"), 30) + dimStyle.Render("Get session summary")),
-		tea.Println("  " + pad(hintKeyStyle.Render("/score <uuid>"), 30) + dimStyle.Render("Show RCA quality scores")),
-		tea.Println("  " + pad(hintKeyStyle.Render("/link <uuid>"), 30) + dimStyle.Render("Get web UI URL for session")),
-		tea.Println("  " + pad(hintKeyStyle.Render("/open <url>"), 30) + dimStyle.Render("Open session from web URL")),
-		tea.Println("  " + pad(hintKeyStyle.Render("/report"), 30) + dimStyle.Render("Show incident analytics")),
-		tea.Println("  " + pad(hintKeyStyle.Render("/connections"), 30) + dimStyle.Render("Manage data source connections")),
-		tea.Println("  " + pad(hintKeyStyle.Render("/incidents"), 30) + dimStyle.Render("Add incident tool connections (add)")),
-		tea.Println("  " + pad(hintKeyStyle.Render("/instructions"), 30) + dimStyle.Render("Manage project instructions")),
-		tea.Println("  " + pad(hintKeyStyle.Render("/investigate-alert <id>"), 30) + dimStyle.Render("Investigate an alert")),
-		tea.Println("  " + pad(hintKeyStyle.Render("/queries [uuid]"), 30) + dimStyle.Render("Show investigation queries")),
-		tea.Println("  " + pad(hintKeyStyle.Render("/rerun [uuid]"), 30) + dimStyle.Render("Rerun an investigation")),
-		tea.Println("  " + pad(hintKeyStyle.Render("/discover"), 30) + dimStyle.Render("Discover project resources")),
-		tea.Println("  " + pad(hintKeyStyle.Render("/session-report [uuid]"), 30) + dimStyle.Render("Per-session time-saved report")),
-		tea.Println("  " + pad(hintKeyStyle.Render("/prompts"), 30) + dimStyle.Render("Browse investigation prompts")),
-		tea.Println("  " + pad(hintKeyStyle.Render("/set project <uuid>"), 30) + dimStyle.Render("Set the active project")),
-		tea.Println("  " + pad(hintKeyStyle.Render("/config"), 30) + dimStyle.Render("Show current configuration")),
-		tea.Println("  " + pad(hintKeyStyle.Render("/clear"), 30) + dimStyle.Render("Clear the screen")),
-		tea.Println("  " + pad(hintKeyStyle.Render("/quit"), 30) + dimStyle.Render("Exit Hawkeye")),
-		tea.Println(""),
-		tea.Println(dimStyle.Render("  Or just type a question to start investigating!")),
-		tea.Println(""),
-	}
+		printLine(""),
+		printLine(dimStyle.Render("  Shortcuts:")),
+		printLine(""),
+	}
+	for _, c := range slashRegistry().Commands() {
+		usage := strings.TrimSpace(c.Name + " " + c.Args)
+		lines = append(lines, printLine("  "+pad(hintKeyStyle.Render(usage), 30)+dimStyle.Render(c.Summary)))
+	}
+	lines = append(lines,
+		printLine(""),
+		printLine(dimStyle.Render("  Or just type a question to start investigating!")),
+		printLine(dimStyle.Render("  /<name> runs a hawkeye-<name> plugin from PATH.")),
+		printLine(""),
+	)
 	return m, tea.Sequence(lines...)
 }
 
@@ -143,13 +119,13 @@ func (m model) cmdLogin(args []string) (tea.Model, tea.Cmd) {
 		m.mode = modeLoginUser
 		m.loginInput.Placeholder = "Username / Email..."
 		m.loginInput.SetValue("")
-		return m, tea.Println(dimStyle.Render(fmt.Sprintf("  Logging in to %s", m.loginURL)))
+		return m, printLine(dimStyle.Render(fmt.Sprintf("  Logging in to %s", m.loginURL)))
 	}
 
 	m.mode = modeLoginURL
 	m.loginInput.Placeholder = "Server URL (e.g. https://myenv.app.neubird.ai/)..."
 	m.loginInput.SetValue("")
-	return m, tea.Println(dimStyle.Render("  Enter the Hawkeye server URL:"))
+	return m, printLine(dimStyle.Render("  Enter the Hawkeye server URL:"))
 }
 
 func (m model) handleLoginURLSubmit(value string) (tea.Model, tea.Cmd) {
@@ -158,8 +134,8 @@ func (m model) handleLoginURLSubmit(value string) (tea.Model, tea.Cmd) {
 	m.loginInput.Placeholder = "Username / Email..."
 	m.loginInput.SetValue("")
 	return m, tea.Sequence(
-		tea.Println(dimStyle.Render(fmt.Sprintf("  Server: %s", value))),
-		tea.Println(dimStyle.Render("  Enter your username/email:")),
+		printLine(dimStyle.Render(fmt.Sprintf("  Server: %s", value))),
+		printLine(dimStyle.Render("  Enter your username/email:")),
 	)
 }
 
@@ -171,8 +147,8 @@ func (m model) handleLoginUserSubmit(value string) (tea.Model, tea.Cmd) {
 	m.loginInput.EchoCharacter = '•'
 	m.loginInput.EchoMode = textinput.EchoPassword
 	return m, tea.Sequence(
-		tea.Println(dimStyle.Render(fmt.Sprintf("  User: %s", value))),
-		tea.Println(dimStyle.Render("  Enter your password:")),
+		printLine(dimStyle.Render(fmt.Sprintf("  User: %s", value))),
+		printLine(dimStyle.Render("  Enter your password:")),
 	)
 }
 
@@ -192,21 +168,22 @@ func (m model) handleLoginPassSubmit(value string) (tea.Model, tea.Cmd) {
 	profile := m.profile
 
 	return m, tea.Sequence(
-		tea.Println(statusStyle.Render("  ⟳ Authenticating...")),
+		printLine(statusStyle.Render("  ⟳ Authenticating...")),
 		func() tea.Msg {
+			cfg, err := config.Load(profile)
+			if err != nil {
+				return loginResultMsg{err: err}
+			}
+
 			backendURL := api.NormalizeBackendURL(serverURL)
 			client := api.NewClientWithServer(backendURL)
+			client.SetTransport(cfg)
 
 			loginResp, err := client.Login(username, password)
 			if err != nil {
 				return loginResultMsg{err: fmt.Errorf("authentication failed: %w", err)}
 			}
 
-			cfg, err := config.Load(profile)
-			if err != nil {
-				return loginResultMsg{err: err}
-			}
-
 			cfg.Server = backendURL
 			// Frontend URL should not have /api suffix
 			frontendURL := strings.TrimRight(serverURL, "/")
@@ -220,8 +197,11 @@ func (m model) handleLoginPassSubmit(value string) (tea.Model, tea.Cmd) {
 			// Auto-fetch org UUID
 			authedClient := api.NewClient(cfg)
 			userInfo, userErr := authedClient.FetchUserInfo()
-			if userErr == nil && userInfo != nil && userInfo.OrgUUID != "" {
-				cfg.OrgUUID = userInfo.OrgUUID
+			if userErr == nil && userInfo != nil {
+				if userInfo.OrgUUID != "" {
+					cfg.OrgUUID = userInfo.OrgUUID
+				}
+				cfg.UserRole = userInfo.UserRole
 			}
 
 			if err := cfg.Save(); err != nil {
@@ -238,7 +218,7 @@ func (m model) handleLoginResult(msg loginResultMsg) (tea.Model, tea.Cmd) {
 	m.input.Placeholder = "Ask a question or type /help..."
 
 	if msg.err != nil {
-		return m, tea.Println(errorMsgStyle.Render(fmt.Sprintf("  ✗ %v", msg.err)))
+		return m, printLine(errorMsgStyle.Render(fmt.Sprintf("  ✗ %v", msg.err)))
 	}
 
 	m.cfg = msg.cfg
@@ -246,17 +226,17 @@ func (m model) handleLoginResult(msg loginResultMsg) (tea.Model, tea.Cmd) {
 
 	var cmds []tea.Cmd
 	cmds = append(cmds,
-		tea.Println(successMsgStyle.Render("  ✓ Logged in successfully!")),
-		tea.Println(dimStyle.Render(fmt.Sprintf("    Server: %s", m.cfg.Server))),
-		tea.Println(dimStyle.Render(fmt.Sprintf("    User: %s", m.cfg.Username))),
+		printLine(successMsgStyle.Render("  ✓ Logged in successfully!")),
+		printLine(dimStyle.Render(fmt.Sprintf("    Server: %s", m.cfg.Server))),
+		printLine(dimStyle.Render(fmt.Sprintf("    User: %s", m.cfg.Username))),
 	)
 	if m.cfg.OrgUUID != "" {
-		cmds = append(cmds, tea.Println(dimStyle.Render(fmt.Sprintf("    Org: %s", m.cfg.OrgUUID))))
+		cmds = append(cmds, printLine(dimStyle.Render(fmt.Sprintf("    Org: %s", m.cfg.OrgUUID))))
 	}
 	if m.cfg.ProjectID == "" {
-		cmds = append(cmds, tea.Println(dimStyle.Render("    Next: type /projects to select a project")))
+		cmds = append(cmds, printLine(dimStyle.Render("    Next: type /projects to select a project")))
 	}
-	cmds = append(cmds, tea.Println(""))
+	cmds = append(cmds, printLine(""))
 
 	m.loginURL = ""
 	m.loginUser = ""
@@ -267,7 +247,7 @@ func (m model) handleLoginResult(msg loginResultMsg) (tea.Model, tea.Cmd) {
 
 func (m model) cmdConfig() (tea.Model, tea.Cmd) {
 	if m.cfg == nil {
-		return m, tea.Println(warnMsgStyle.Render("  ! No configuration found. Run /login first."))
+		return m, printLine(warnMsgStyle.Render("  ! No configuration found. Run /login first."))
 	}
 
 	val := func(s string) string {
@@ -286,15 +266,15 @@ func (m model) cmdConfig() (tea.Model, tea.Cmd) {
 	}
 
 	return m, tea.Sequence(
-		tea.Println(""),
-		tea.Println(dimStyle.Render("  Configuration:")),
-		tea.Println(fmt.Sprintf("    Profile:      %s", config.ProfileName(m.profile))),
-		tea.Println(fmt.Sprintf("    Server:       %s", val(m.cfg.Server))),
-		tea.Println(fmt.Sprintf("    User:         %s", val(m.cfg.Username))),
-		tea.Println(fmt.Sprintf("    Project:      %s", val(m.cfg.ProjectID))),
-		tea.Println(fmt.Sprintf("    Organization: %s", val(m.cfg.OrgUUID))),
-		tea.Println(fmt.Sprintf("    Token:        %s", token)),
-		tea.Println(""),
+		printLine(""),
+		printLine(dimStyle.Render("  Configuration:")),
+		printLine(fmt.Sprintf("    Profile:      %s", config.ProfileName(m.profile))),
+		printLine(fmt.Sprintf("    Server:       %s", val(m.cfg.Server))),
+		printLine(fmt.Sprintf("    User:         %s", val(m.cfg.Username))),
+		printLine(fmt.Sprintf("    Project:      %s", val(m.cfg.ProjectID))),
+		printLine(fmt.Sprintf("    Organization: %s", val(api.EffectiveOrg(m.cfg)))),
+		printLine(fmt.Sprintf("    Token:        %s", token)),
+		printLine(""),
 	)
 }
 
@@ -305,158 +285,18 @@ type sessionsLoadedMsg struct {
 
 func (m model) handleSessionsLoaded(msg sessionsLoadedMsg) (tea.Model, tea.Cmd) {
 	if msg.err != nil {
-		return m, tea.Println(errorMsgStyle.Render(fmt.Sprintf("  ✗ Failed to load sessions: %v", msg.err)))
+		return m, printLine(errorMsgStyle.Render(fmt.Sprintf("  ✗ Failed to load sessions: %v", msg.err)))
 	}
 
 	if len(msg.sessions) == 0 {
-		return m, tea.Println(warnMsgStyle.Render("  ! No sessions found."))
+		return m, printLine(warnMsgStyle.Render("  ! No sessions found."))
 	}
 
 	sortSessionsNewestFirst(msg.sessions)
 	m.mode = modeSessionSelect
 	m.sessionList = msg.sessions
 	m.sessionListIdx = 0
-	return m, nil
-}
-
-// ─── /incidents list ────────────────────────────────────────────────────────
-
-const openIncidentsPageSize = 20
-
-type openIncidentsLoadedMsg struct {
-	sessions []api.SessionInfo
-	page     int
-	hasMore  bool
-	err      error
-}
-
-func (m model) cmdOpenIncidentsList(args []string) (tea.Model, tea.Cmd) {
-	if m.client == nil {
-		return m, tea.Println(errorMsgStyle.Render("  ✗ Not logged in. Run /login first."))
-	}
-	if m.cfg.ProjectID == "" {
-		return m, tea.Println(errorMsgStyle.Render("  ✗ No project set. Run /projects first."))
-	}
-
-	page := 1
-	if len(args) > 0 {
-		if n, err := strconv.Atoi(args[0]); err == nil && n > 0 {
-			page = n
-		}
-	}
-
-	client := m.client
-	projectID := m.cfg.ProjectID
-	start := (page - 1) * openIncidentsPageSize
-
-	return m, tea.Sequence(
-		tea.Println(statusStyle.Render(fmt.Sprintf("  ⟳ Loading open incidents (page %d)...", page))),
-		func() tea.Msg {
-			filters := []api.PaginationFilter{
-				{Key: "session_type", Value: "SESSION_TYPE_INCIDENT", Operator: "=="},
-			}
-			resp, err := client.SessionList(projectID, start, openIncidentsPageSize, filters)
-			if err != nil {
-				return openIncidentsLoadedMsg{err: err, page: page}
-			}
-			hasMore := len(resp.Sessions) == openIncidentsPageSize
-			return openIncidentsLoadedMsg{sessions: resp.Sessions, page: page, hasMore: hasMore}
-		},
-	)
-}
-
-func (m model) handleOpenIncidentsLoaded(msg openIncidentsLoadedMsg) (tea.Model, tea.Cmd) {
-	if msg.err != nil {
-		m.mode = modeIdle
-		return m, tea.Println(errorMsgStyle.Render(fmt.Sprintf("  ✗ Failed to load incidents: %v", msg.err)))
-	}
-
-	if len(msg.sessions) == 0 {
-		m.mode = modeIdle
-		if msg.page == 1 {
-			return m, tea.Println(warnMsgStyle.Render("  ! No open incidents found."))
-		}
-		return m, tea.Println(warnMsgStyle.Render("  ! No more incidents."))
-	}
-
-	m.incidentList = msg.sessions
-	m.incidentListIdx = 0
-	m.incidentListPage = msg.page
-	m.incidentListHasMore = msg.hasMore
-	m.mode = modeIncidentList
-	return m, nil
-}
-
-func formatInvestigationStatus(s string) string {
-	switch s {
-	case "INVESTIGATION_STATUS_NOT_STARTED":
-		return dimStyle.Render("[not started]")
-	case "INVESTIGATION_STATUS_IN_PROGRESS":
-		return statusStyle.Render("[in progress]")
-	case "INVESTIGATION_STATUS_INVESTIGATED":
-		return successMsgStyle.Render("[investigated]")
-	case "INVESTIGATION_STATUS_COMPLETED":
-		return successMsgStyle.Render("[completed]")
-	default:
-		if s == "" {
-			return ""
-		}
-		return dimStyle.Render("[" + s + "]")
-	}
-}
-
-// ─── Incident list renderer ──────────────────────────────────────────────────
-
-func (m model) renderIncidentList() string {
-	var b strings.Builder
-	b.WriteString("\n")
-	header := fmt.Sprintf("  🚨 Open Incidents — page %d (%d shown)", m.incidentListPage, len(m.incidentList))
-	b.WriteString(dimStyle.Render(header) + "\n\n")
-
-	// Cap visible rows to avoid overflowing short terminals (reserve ~6 lines for header/footer)
-	maxVisible := m.height - 6
-	if maxVisible < 5 {
-		maxVisible = 5
-	}
-
-	start := 0
-	if m.incidentListIdx >= maxVisible {
-		start = m.incidentListIdx - maxVisible + 1
-	}
-	end := start + maxVisible
-	if end > len(m.incidentList) {
-		end = len(m.incidentList)
-	}
-
-	for i := start; i < end; i++ {
-		s := m.incidentList[i]
-		name := s.Name
-		if name == "" {
-			name = "(unnamed)"
-		}
-		if len(name) > 50 {
-			name = name[:47] + "..."
-		}
-		status := formatInvestigationStatus(s.InvestigationStatus)
-		padded := fmt.Sprintf("%-52s %s", name, status)
-		if i == m.incidentListIdx {
-			b.WriteString("  " + incidentRowSelectedStyle.Render("🦜 "+padded) + "\n")
-		} else {
-			b.WriteString("  " + incidentRowStyle.Render("   "+padded) + "\n")
-		}
-	}
-
-	b.WriteString("\n")
-	hints := "  ↑↓ navigate  Enter select"
-	if m.incidentListHasMore {
-		hints += "  n next"
-	}
-	if m.incidentListPage > 1 {
-		hints += "  p prev"
-	}
-	hints += "  Esc cancel"
-	b.WriteString(hintBarStyle.Render(hints))
-	return b.String()
+	return m, m.prefetchSummaries()
 }
 
 // ─── /inspect ───────────────────────────────────────────────────────────────
@@ -468,13 +308,13 @@ type inspectResultMsg struct {
 
 func (m model) cmdInspect(args []string) (tea.Model, tea.Cmd) {
 	if m.client == nil {
-		return m, tea.Println(errorMsgStyle.Render("  ✗ Not logged in. Run /login first."))
+		return m, printLine(errorMsgStyle.Render("  ✗ Not logged in. Run /login first."))
 	}
 	if len(args) == 0 {
 		if m.sessionID != "" {
 			args = []string{m.sessionID}
 		} else {
-			return m, tea.Println(warnMsgStyle.Render("  ! Usage: /inspect <session-uuid>"))
+			return m, printLine(warnMsgStyle.Render("  ! Usage: /inspect <session-uuid>"))
 		}
 	}
 
@@ -483,7 +323,7 @@ func (m model) cmdInspect(args []string) (tea.Model, tea.Cmd) {
 	projectID := m.cfg.ProjectID
 
 	return m, tea.Sequence(
-		tea.Println(statusStyle.Render(fmt.Sprintf("  ⟳ Inspecting %s...", truncateUUID(sessionUUID)))),
+		printLine(statusStyle.Render(fmt.Sprintf("  ⟳ Inspecting %s...", truncateUUID(sessionUUID)))),
 		func() tea.Msg {
 			resp, err := client.SessionInspect(projectID, sessionUUID)
 			if err != nil {
@@ -496,12 +336,12 @@ func (m model) cmdInspect(args []string) (tea.Model, tea.Cmd) {
 
 func (m model) handleInspectResult(msg inspectResultMsg) (tea.Model, tea.Cmd) {
 	if msg.err != nil {
-		return m, tea.Println(errorMsgStyle.Render(fmt.Sprintf("  ✗ Inspect failed: %v", msg.err)))
+		return m, printLine(errorMsgStyle.Render(fmt.Sprintf("  ✗ Inspect failed: %v", msg.err)))
 	}
 
 	resp := msg.resp
 	var cmds []tea.Cmd
-	cmds = append(cmds, tea.Println(""))
+	cmds = append(cmds, printLine(""))
 
 	if resp.SessionInfo != nil {
 		s := resp.SessionInfo
@@ -510,27 +350,27 @@ func (m model) handleInspectResult(msg inspectResultMsg) (tea.Model, tea.Cmd) {
 			name = "(unnamed)"
 		}
 		cmds = append(cmds,
-			tea.Println(fmt.Sprintf("  Session: %s", name)),
-			tea.Println(dimStyle.Render(fmt.Sprintf("    UUID: %s", s.SessionUUID))),
-			tea.Println(dimStyle.Render(fmt.Sprintf("    Created: %s  Type: %s", s.CreateTime, s.SessionType))),
+			printLine(fmt.Sprintf("  Session: %s", name)),
+			printLine(dimStyle.Render(fmt.Sprintf("    UUID: %s", s.SessionUUID))),
+			printLine(dimStyle.Render(fmt.Sprintf("    Created: %s  Type: %s", formatSessionTime(s.CreateTime), s.SessionType))),
 		)
 	}
 
 	if len(resp.PromptCycle) == 0 {
-		cmds = append(cmds, tea.Println(warnMsgStyle.Render("  ! No prompt cycles found.")))
+		cmds = append(cmds, printLine(warnMsgStyle.Render("  ! No prompt cycles found.")))
 		return m, tea.Sequence(cmds...)
 	}
 
 	for i, pc := range resp.PromptCycle {
 		cmds = append(cmds,
-			tea.Println(""),
-			tea.Println(dimStyle.Render(fmt.Sprintf("  ── Prompt Cycle %d ──", i+1))),
+			printLine(""),
+			printLine(dimStyle.Render(fmt.Sprintf("  ── Prompt Cycle %d ──", i+1))),
 		)
 
 		if pc.Request != nil && len(pc.Request.Messages) > 0 {
 			for _, msg := range pc.Request.Messages {
 				if msg.Content != nil && len(msg.Content.Parts) > 0 {
-					cmds = append(cmds, tea.Println(userPromptStyle.Render("  ❯ "+strings.Join(msg.Content.Parts, " "))))
+					cmds = append(cmds, printLine(userPromptStyle.Render("  ❯ "+strings.Join(msg.Content.Parts, " "))))
 				}
 			}
 		}
@@ -542,39 +382,134 @@ func (m model) handleInspectResult(msg inspectResultMsg) (tea.Model, tea.Cmd) {
 					cat = "analysis"
 				}
 				if cot.Description != "" {
-					cmds = append(cmds, tea.Println(cotHeaderStyle.Render(fmt.Sprintf("  🔍 [%s] %s", cat, cot.Description))))
+					cmds = append(cmds, printLine(cotHeaderStyle.Render(fmt.Sprintf("  🔍 [%s] %s", cat, cot.Description))))
 				}
 			}
 		}
 
 		if len(pc.Sources) > 0 {
-			cmds = append(cmds, tea.Println(sourceHeaderStyle.Render("  📎 Sources:")))
+			cmds = append(cmds, printLine(sourceHeaderStyle.Render("  📎 Sources:")))
 			for _, src := range pc.Sources {
 				name := src.Title
 				if name == "" {
 					name = src.ID
 				}
-				cmds = append(cmds, tea.Println(dimStyle.Render("     • "+name)))
+				cmds = append(cmds, printLine(dimStyle.Render("     • "+name)))
 			}
 		}
 
 		if pc.FinalAnswer != "" {
 			rendered := renderMarkdownBlock(pc.FinalAnswer)
-			cmds = append(cmds, tea.Println(""))
+			cmds = append(cmds, printLine(""))
 			for _, line := range strings.Split(rendered, "\n") {
-				cmds = append(cmds, tea.Println("  "+line))
+				cmds = append(cmds, printLine("  "+line))
 			}
 		}
 
 		if len(pc.FollowUpSuggestions) > 0 {
-			cmds = append(cmds, tea.Println(followUpStyle.Render("  💡 Follow-ups:")))
+			cmds = append(cmds, printLine(followUpStyle.Render("  💡 Follow-ups:")))
 			for j, s := range pc.FollowUpSuggestions {
-				cmds = append(cmds, tea.Println(followUpStyle.Render(fmt.Sprintf("     %d. %s", j+1, s))))
+				cmds = append(cmds, printLine(followUpStyle.Render(fmt.Sprintf("     %d. %s", j+1, s))))
 			}
 		}
 	}
 
-	cmds = append(cmds, tea.Println(""))
+	if answer := service.LatestFinalAnswer(resp.PromptCycle); answer != "" {
+		m.lastAnswer = answer
+	}
+
+	cmds = append(cmds, printLine(""))
+	return m, tea.Sequence(cmds...)
+}
+
+// ─── /resume ────────────────────────────────────────────────────────────────
+//
+// /resume (and `hawkeye resume`, which opens the TUI with it) re-opens the
+// last session, shows its latest answer and leaves the prompt ready for a
+// follow-up in that session.
+
+type resumeResultMsg struct {
+	sessionUUID string
+	resp        *api.SessionInspectResponse
+	err         error
+}
+
+func (m model) cmdResume(args []string) (tea.Model, tea.Cmd) {
+	if m.client == nil {
+		return m, printLine(errorMsgStyle.Render("  ✗ Not logged in. Run /login first."))
+	}
+	sessionUUID := ""
+	if len(args) > 0 {
+		sessionUUID = args[0]
+	} else if m.cfg != nil {
+		sessionUUID = m.cfg.LastSession
+	}
+	if sessionUUID == "" {
+		return m, printLine(warnMsgStyle.Render("  ! No previous session found. Run an investigation first."))
+	}
+	return m, resumeSession(m.client, m.cfg.ProjectID, sessionUUID)
+}
+
+func resumeSession(client api.HawkeyeAPI, projectID, sessionUUID string) tea.Cmd {
+	return tea.Sequence(
+		printLine(statusStyle.Render(fmt.Sprintf("  ⟳ Resuming session %s...", truncateUUID(sessionUUID)))),
+		func() tea.Msg {
+			resp, err := client.SessionInspect(projectID, sessionUUID)
+			return resumeResultMsg{sessionUUID: sessionUUID, resp: resp, err: err}
+		},
+	)
+}
+
+func (m model) handleResumeResult(msg resumeResultMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		return m, printLine(errorMsgStyle.Render(fmt.Sprintf("  ✗ Resume failed: %v", msg.err)))
+	}
+	m.sessionID = msg.sessionUUID
+
+	cmds := []tea.Cmd{printLine("")}
+	name := "(unnamed)"
+	if msg.resp.SessionInfo != nil && msg.resp.SessionInfo.Name != "" {
+		name = msg.resp.SessionInfo.Name
+	}
+	cmds = append(cmds,
+		printLine(successMsgStyle.Render(fmt.Sprintf("  ✓ Resumed: %s", name))),
+		printLine(dimStyle.Render(fmt.Sprintf("    Session: %s", msg.sessionUUID))),
+	)
+
+	var last *api.PromptCycle
+	for i := len(msg.resp.PromptCycle) - 1; i >= 0; i-- {
+		if strings.TrimSpace(msg.resp.PromptCycle[i].FinalAnswer) != "" {
+			last = &msg.resp.PromptCycle[i]
+			break
+		}
+	}
+	if last == nil {
+		cmds = append(cmds,
+			printLine(warnMsgStyle.Render("  ! This session has no answer yet.")),
+			printLine(""),
+		)
+		return m, tea.Sequence(cmds...)
+	}
+
+	m.lastAnswer = service.LatestFinalAnswer(msg.resp.PromptCycle)
+	if prompt := service.CyclePrompt(*last); prompt != "" {
+		cmds = append(cmds, printLine(""), printLine(userPromptStyle.Render("  ❯ "+prompt)))
+	}
+	cmds = append(cmds, printLine(""))
+	for _, line := range strings.Split(renderMarkdownBlock(last.FinalAnswer), "\n") {
+		cmds = append(cmds, printLine("  "+line))
+	}
+	if len(last.FollowUpSuggestions) > 0 {
+		cmds = append(cmds, printLine(followUpStyle.Render("  💡 Follow-ups:")))
+		for j, s := range last.FollowUpSuggestions {
+			cmds = append(cmds, printLine(followUpStyle.Render(fmt.Sprintf("     %d. %s", j+1, s))))
+		}
+	}
+	cmds = append(cmds,
+		printLine(""),
+		printLine(dimStyle.Render("  Type a follow-up question to continue this session.")),
+		printLine(""),
+	)
 	return m, tea.Sequence(cmds...)
 }
 
@@ -587,13 +522,13 @@ type summaryResultMsg struct {
 
 func (m model) cmdSummary(args []string) (tea.Model, tea.Cmd) {
 	if m.client == nil {
-		return m, tea.Println(errorMsgStyle.Render("  ✗ Not logged in. Run /login first."))
+		return m, printLine(errorMsgStyle.Render("  ✗ Not logged in. Run /login first."))
 	}
 	if len(args) == 0 {
 		if m.sessionID != "" {
 			args = []string{m.sessionID}
 		} else {
-			return m, tea.Println(warnMsgStyle.Render("  ! Usage: /summary <session-uuid>"))
+			return m, printLine(warnMsgStyle.Render("  ! Usage: /summary <session-uuid>"))
 		}
 	}
 
@@ -602,7 +537,7 @@ func (m model) cmdSummary(args []string) (tea.Model, tea.Cmd) {
 	projectID := m.cfg.ProjectID
 
 	return m, tea.Sequence(
-		tea.Println(statusStyle.Render(fmt.Sprintf("  ⟳ Loading summary for %s...", truncateUUID(sessionUUID)))),
+		printLine(statusStyle.Render(fmt.Sprintf("  ⟳ Loading summary for %s...", truncateUUID(sessionUUID)))),
 		func() tea.Msg {
 			resp, err := client.GetSessionSummary(projectID, sessionUUID)
 			if err != nil {
@@ -615,23 +550,23 @@ func (m model) cmdSummary(args []string) (tea.Model, tea.Cmd) {
 
 func (m model) handleSummaryResult(msg summaryResultMsg) (tea.Model, tea.Cmd) {
 	if msg.err != nil {
-		return m, tea.Println(errorMsgStyle.Render(fmt.Sprintf("  ✗ Summary failed: %v", msg.err)))
+		return m, printLine(errorMsgStyle.Render(fmt.Sprintf("  ✗ Summary failed: %v", msg.err)))
 	}
 
 	resp := msg.resp
 	var cmds []tea.Cmd
-	cmds = append(cmds, tea.Println(""))
+	cmds = append(cmds, printLine(""))
 
 	if resp.SessionInfo != nil {
 		name := resp.SessionInfo.Name
 		if name == "" {
 			name = "Session Summary"
 		}
-		cmds = append(cmds, tea.Println(fmt.Sprintf("  Summary: %s", name)))
+		cmds = append(cmds, printLine(fmt.Sprintf("  Summary: %s", name)))
 	}
 
 	if resp.SessionSummary == nil {
-		cmds = append(cmds, tea.Println(warnMsgStyle.Render("  ! No summary available yet.")))
+		cmds = append(cmds, printLine(warnMsgStyle.Render("  ! No summary available yet.")))
 		return m, tea.Sequence(cmds...)
 	}
 
@@ -639,29 +574,29 @@ func (m model) handleSummaryResult(msg summaryResultMsg) (tea.Model, tea.Cmd) {
 
 	if summary.ShortSummary != nil {
 		if summary.ShortSummary.Question != "" {
-			cmds = append(cmds, tea.Println(dimStyle.Render(fmt.Sprintf("  Question: %s", summary.ShortSummary.Question))))
+			cmds = append(cmds, printLine(dimStyle.Render(fmt.Sprintf("  Question: %s", summary.ShortSummary.Question))))
 		}
 		if summary.ShortSummary.Analysis != "" {
-			cmds = append(cmds, tea.Println(dimStyle.Render(fmt.Sprintf("  Quick Analysis: %s", summary.ShortSummary.Analysis))))
+			cmds = append(cmds, printLine(dimStyle.Render(fmt.Sprintf("  Quick Analysis: %s", summary.ShortSummary.Analysis))))
 		}
 	}
 
 	if summary.Analysis != "" {
 		rendered := renderMarkdownBlock(summary.Analysis)
-		cmds = append(cmds, tea.Println(""))
+		cmds = append(cmds, printLine(""))
 		for _, line := range strings.Split(rendered, "\n") {
-			cmds = append(cmds, tea.Println("  "+line))
+			cmds = append(cmds, printLine("  "+line))
 		}
 	}
 
 	if len(summary.ActionItems) > 0 {
-		cmds = append(cmds, tea.Println(""), tea.Println("  🎯 Action Items:"))
+		cmds = append(cmds, printLine(""), printLine("  🎯 Action Items:"))
 		for i, item := range summary.ActionItems {
-			cmds = append(cmds, tea.Println(fmt.Sprintf("     %d. %s", i+1, item)))
+			cmds = append(cmds, printLine(fmt.Sprintf("     %d. %s", i+1, item)))
 		}
 	}
 
-	cmds = append(cmds, tea.Println(""))
+	cmds = append(cmds, printLine(""))
 	return m, tea.Sequence(cmds...)
 }
 
@@ -673,7 +608,7 @@ type feedbackResultMsg struct {
 
 func (m model) cmdFeedback(args []string) (tea.Model, tea.Cmd) {
 	if m.client == nil {
-		return m, tea.Println(errorMsgStyle.Render("  ✗ Not logged in. Run /login first."))
+		return m, printLine(errorMsgStyle.Render("  ✗ Not logged in. Run /login first."))
 	}
 
 	sessionUUID := ""
@@ -692,14 +627,14 @@ func (m model) cmdFeedback(args []string) (tea.Model, tea.Cmd) {
 		sessionUUID = m.sessionID
 	}
 	if sessionUUID == "" {
-		return m, tea.Println(warnMsgStyle.Render("  ! Usage: /feedback [session-uuid] [-r reason]"))
+		return m, printLine(warnMsgStyle.Render("  ! Usage: /feedback [session-uuid] [-r reason]"))
 	}
 
 	client := m.client
 	projectID := m.cfg.ProjectID
 
 	return m, tea.Sequence(
-		tea.Println(statusStyle.Render(fmt.Sprintf("  ⟳ Submitting feedback for %s...", truncateUUID(sessionUUID)))),
+		printLine(statusStyle.Render(fmt.Sprintf("  ⟳ Submitting feedback for %s...", truncateUUID(sessionUUID)))),
 		func() tea.Msg {
 			resp, err := client.SessionInspect(projectID, sessionUUID)
 			if err != nil {
@@ -718,9 +653,9 @@ func (m model) cmdFeedback(args []string) (tea.Model, tea.Cmd) {
 
 func (m model) handleFeedbackResult(msg feedbackResultMsg) (tea.Model, tea.Cmd) {
 	if msg.err != nil {
-		return m, tea.Println(errorMsgStyle.Render(fmt.Sprintf("  ✗ Feedback failed: %v", msg.err)))
+		return m, printLine(errorMsgStyle.Render(fmt.Sprintf("  ✗ Feedback failed: %v", msg.err)))
 	}
-	return m, tea.Println(statusStyle.Render("  ✓ Thumbs down submitted"))
+	return m, printLine(statusStyle.Render("  ✓ Thumbs down submitted"))
 }
 
 // ─── /prompts ───────────────────────────────────────────────────────────────
@@ -732,17 +667,17 @@ type promptsLoadedMsg struct {
 
 func (m model) cmdPrompts() (tea.Model, tea.Cmd) {
 	if m.client == nil {
-		return m, tea.Println(errorMsgStyle.Render("  ✗ Not logged in. Run /login first."))
+		return m, printLine(errorMsgStyle.Render("  ✗ Not logged in. Run /login first."))
 	}
 	if m.cfg.ProjectID == "" {
-		return m, tea.Println(errorMsgStyle.Render("  ✗ No project set. Run /projects first."))
+		return m, printLine(errorMsgStyle.Render("  ✗ No project set. Run /projects first."))
 	}
 
 	client := m.client
 	projectID := m.cfg.ProjectID
 
 	return m, tea.Sequence(
-		tea.Println(statusStyle.Render("  ⟳ Loading prompts...")),
+		printLine(statusStyle.Render("  ⟳ Loading prompts...")),
 		func() tea.Msg {
 			resp, err := client.PromptLibrary(projectID)
 			if err != nil {
@@ -755,18 +690,18 @@ func (m model) cmdPrompts() (tea.Model, tea.Cmd) {
 
 func (m model) handlePromptsLoaded(msg promptsLoadedMsg) (tea.Model, tea.Cmd) {
 	if msg.err != nil {
-		return m, tea.Println(errorMsgStyle.Render(fmt.Sprintf("  ✗ Failed to load prompts: %v", msg.err)))
+		return m, printLine(errorMsgStyle.Render(fmt.Sprintf("  ✗ Failed to load prompts: %v", msg.err)))
 	}
 
 	if len(msg.items) == 0 {
-		return m, tea.Println(warnMsgStyle.Render("  ! No prompts found."))
+		return m, printLine(warnMsgStyle.Render("  ! No prompts found."))
 	}
 
 	var cmds []tea.Cmd
 	cmds = append(cmds,
-		tea.Println(""),
-		tea.Println(dimStyle.Render(fmt.Sprintf("  Prompt Library (%d):", len(msg.items)))),
-		tea.Println(""),
+		printLine(""),
+		printLine(dimStyle.Render(fmt.Sprintf("  Prompt Library (%d):", len(msg.items)))),
+		printLine(""),
 	)
 
 	for i, p := range msg.items {
@@ -777,13 +712,13 @@ func (m model) handlePromptsLoaded(msg promptsLoadedMsg) (tea.Model, tea.Cmd) {
 				label = label[:77] + "..."
 			}
 		}
-		cmds = append(cmds, tea.Println(fmt.Sprintf("  %d. %s", i+1, label)))
+		cmds = append(cmds, printLine(fmt.Sprintf("  %d. %s", i+1, label)))
 	}
 
 	cmds = append(cmds,
-		tea.Println(""),
-		tea.Println(dimStyle.Render("  Tip: Copy a prompt and paste it to investigate")),
-		tea.Println(""),
+		printLine(""),
+		printLine(dimStyle.Render("  Tip: Copy a prompt and paste it to investigate")),
+		printLine(""),
 	)
 
 	return m, tea.Sequence(cmds...)
@@ -798,7 +733,7 @@ type projectsLoadedMsg struct {
 
 func (m model) cmdProjects(args []string) (tea.Model, tea.Cmd) {
 	if m.client == nil {
-		return m, tea.Println(errorMsgStyle.Render("  ✗ Not logged in. Run /login first."))
+		return m, printLine(errorMsgStyle.Render("  ✗ Not logged in. Run /login first."))
 	}
 
 	// Subcommand dispatch
@@ -816,7 +751,7 @@ func (m model) cmdProjects(args []string) (tea.Model, tea.Cmd) {
 	client := m.client
 
 	return m, tea.Sequence(
-		tea.Println(statusStyle.Render("  ⟳ Loading projects...")),
+		printLine(statusStyle.Render("  ⟳ Loading projects...")),
 		func() tea.Msg {
 			resp, err := client.ListProjects()
 			if err != nil {
@@ -829,11 +764,11 @@ func (m model) cmdProjects(args []string) (tea.Model, tea.Cmd) {
 
 func (m model) handleProjectsLoaded(msg projectsLoadedMsg) (tea.Model, tea.Cmd) {
 	if msg.err != nil {
-		return m, tea.Println(errorMsgStyle.Render(fmt.Sprintf("  ✗ Failed to load projects: %v", msg.err)))
+		return m, printLine(errorMsgStyle.Render(fmt.Sprintf("  ✗ Failed to load projects: %v", msg.err)))
 	}
 
 	if len(msg.projects) == 0 {
-		return m, tea.Println(warnMsgStyle.Render("  ! No projects found."))
+		return m, printLine(warnMsgStyle.Render("  ! No projects found."))
 	}
 
 	// Find current project index to pre-select it
@@ -853,6 +788,181 @@ func (m model) handleProjectsLoaded(msg projectsLoadedMsg) (tea.Model, tea.Cmd)
 	return m, nil
 }
 
+// ─── /orgs ──────────────────────────────────────────────────────────────────
+
+type orgsLoadedMsg struct {
+	orgs []api.OrgSpec
+	err  error
+}
+
+// orgProjectCheckMsg reports whether the active project still exists after
+// switching organizations.
+type orgProjectCheckMsg struct {
+	projectUUID string
+	found       bool
+	err         error
+}
+
+func (m model) cmdOrgs() (tea.Model, tea.Cmd) {
+	if m.client == nil {
+		return m, printLine(errorMsgStyle.Render("  ✗ Not logged in. Run /login first."))
+	}
+	client := m.client
+	return m, tea.Sequence(
+		printLine(statusStyle.Render("  ⟳ Loading organizations...")),
+		func() tea.Msg {
+			orgs, err := client.ListOrganizations()
+			return orgsLoadedMsg{orgs: orgs, err: err}
+		},
+	)
+}
+
+func (m model) handleOrgsLoaded(msg orgsLoadedMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		return m, printLine(errorMsgStyle.Render(fmt.Sprintf("  ✗ Failed to load organizations: %v", msg.err)))
+	}
+	if len(msg.orgs) == 0 {
+		return m, printLine(warnMsgStyle.Render("  ! No organizations found."))
+	}
+
+	selectedIdx := 0
+	for i, o := range msg.orgs {
+		if o.UUID == api.EffectiveOrg(m.cfg) {
+			selectedIdx = i
+			break
+		}
+	}
+	m.mode = modeOrgSelect
+	m.orgList = msg.orgs
+	m.orgListIdx = selectedIdx
+	return m, nil
+}
+
+// handleOrgSelectKey handles navigation in the organization picker.
+func (m model) handleOrgSelectKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEsc, tea.KeyCtrlC:
+		m.mode = modeIdle
+		m.orgList = nil
+		m.orgListIdx = 0
+		return m, printLine(warnMsgStyle.Render("  ! Organization selection cancelled."))
+	case tea.KeyUp:
+		m.orgListIdx--
+		if m.orgListIdx < 0 {
+			m.orgListIdx = len(m.orgList) - 1
+		}
+	case tea.KeyDown:
+		m.orgListIdx++
+		if m.orgListIdx >= len(m.orgList) {
+			m.orgListIdx = 0
+		}
+	case tea.KeyEnter:
+		selected := m.orgList[m.orgListIdx]
+		m.mode = modeIdle
+		m.orgList = nil
+		m.orgListIdx = 0
+		return m.selectOrg(selected)
+	}
+	return m, nil
+}
+
+// userRole is the user's role in the organization the TUI acts in, or ""
+// when unknown: the saved role does not apply under an --org override.
+func (m model) userRole() string {
+	if m.cfg == nil || api.EffectiveOrg(m.cfg) != m.cfg.OrgUUID {
+		return ""
+	}
+	return m.cfg.UserRole
+}
+
+// selectOrg switches the active organization and re-checks that the active
+// project belongs to it.
+func (m model) selectOrg(o api.OrgSpec) (tea.Model, tea.Cmd) {
+	label := service.OrgLabel(o)
+	if o.UUID == api.EffectiveOrg(m.cfg) {
+		return m, printLine(dimStyle.Render(fmt.Sprintf("  Already using organization %s.", label)))
+	}
+
+	m.cfg.OrgUUID = o.UUID
+	m.cfg.UserRole = o.Role
+	if err := m.cfg.Save(); err != nil {
+		return m, printLine(errorMsgStyle.Render(fmt.Sprintf("  ✗ Failed to save config: %v", err)))
+	}
+	api.SetOrg("") // switching explicitly ends a --org override
+	if m.cfg.Server != "" && m.cfg.Token != "" {
+		m.client = api.NewClient(m.cfg)
+	}
+
+	cmds := []tea.Cmd{
+		printLine(successMsgStyle.Render(fmt.Sprintf("  ✓ Organization set to: %s", label))),
+	}
+	if m.cfg.ProjectID != "" && m.client != nil {
+		client := m.client
+		projectID := m.cfg.ProjectID
+		cmds = append(cmds, func() tea.Msg {
+			resp, err := client.ListProjects()
+			if err != nil {
+				return orgProjectCheckMsg{projectUUID: projectID, err: err}
+			}
+			return orgProjectCheckMsg{projectUUID: projectID, found: service.FindProject(resp.Specs, projectID) != nil}
+		})
+	}
+	return m, tea.Sequence(cmds...)
+}
+
+func (m model) handleOrgProjectCheck(msg orgProjectCheckMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		return m, printLine(warnMsgStyle.Render(fmt.Sprintf("  ! Could not verify the active project in this organization: %v", msg.err)))
+	}
+	// Ignore stale results if the project changed in the meantime.
+	if msg.found || m.cfg == nil || m.cfg.ProjectID != msg.projectUUID {
+		return m, nil
+	}
+
+	name := projectNameStr(m.cfg)
+	m.cfg.ProjectID = ""
+	m.cfg.ProjectName = ""
+	m.cfg.LastSession = ""
+	m.sessionID = ""
+	if err := m.cfg.Save(); err != nil {
+		return m, printLine(errorMsgStyle.Render(fmt.Sprintf("  ✗ Failed to save config: %v", err)))
+	}
+	return m, tea.Sequence(
+		printLine(warnMsgStyle.Render(fmt.Sprintf("  ! Project %s is not in this organization; cleared it.", name))),
+		printLine(dimStyle.Render("    Use /projects to pick one.")),
+	)
+}
+
+// renderOrgList renders the interactive organization selection list
+func (m model) renderOrgList() string {
+	var lines []string
+	lines = append(lines, "")
+	lines = append(lines, "  Select an organization:")
+	lines = append(lines, "")
+
+	for i, o := range m.orgList {
+		label := service.OrgLabel(o)
+		active := ""
+		if o.UUID == m.cfg.OrgUUID {
+			active = "  " + successMsgStyle.Render("active")
+		}
+		if i == m.orgListIdx {
+			lines = append(lines, fmt.Sprintf("  %s %s%s",
+				cmdSelectedNameStyle.Render("▸"),
+				cmdSelectedNameStyle.Render(label),
+				active))
+		} else {
+			lines = append(lines, fmt.Sprintf("    %s%s", label, active))
+		}
+		if o.Name != "" {
+			lines = append(lines, fmt.Sprintf("    %s", dimStyle.Render(o.UUID)))
+		}
+	}
+	lines = append(lines, "")
+
+	return strings.Join(lines, "\n")
+}
+
 // ─── /projects info ──────────────────────────────────────────────────────────
 
 type projectInfoMsg struct {
@@ -862,13 +972,13 @@ type projectInfoMsg struct {
 
 func (m model) cmdProjectInfo(args []string) (tea.Model, tea.Cmd) {
 	if len(args) == 0 {
-		return m, tea.Println(warnMsgStyle.Render("  ! Usage: /projects info <uuid>"))
+		return m, printLine(warnMsgStyle.Render("  ! Usage: /projects info <uuid>"))
 	}
 	projectUUID := args[0]
 	client := m.client
 
 	return m, tea.Sequence(
-		tea.Println(statusStyle.Render(fmt.Sprintf("  ⟳ Loading project %s...", truncateUUID(projectUUID)))),
+		printLine(statusStyle.Render(fmt.Sprintf("  ⟳ Loading project %s...", truncateUUID(projectUUID)))),
 		func() tea.Msg {
 			resp, err := client.GetProject(projectUUID)
 			if err != nil {
@@ -881,26 +991,26 @@ func (m model) cmdProjectInfo(args []string) (tea.Model, tea.Cmd) {
 
 func (m model) handleProjectInfo(msg projectInfoMsg) (tea.Model, tea.Cmd) {
 	if msg.err != nil {
-		return m, tea.Println(errorMsgStyle.Render(fmt.Sprintf("  ✗ Failed: %v", msg.err)))
+		return m, printLine(errorMsgStyle.Render(fmt.Sprintf("  ✗ Failed: %v", msg.err)))
 	}
 
 	p := msg.detail
 	var cmds []tea.Cmd
-	cmds = append(cmds, tea.Println(""))
-	cmds = append(cmds, tea.Println(fmt.Sprintf("  Project: %s", p.Name)))
-	cmds = append(cmds, tea.Println(dimStyle.Render(fmt.Sprintf("    UUID: %s", p.UUID))))
+	cmds = append(cmds, printLine(""))
+	cmds = append(cmds, printLine(fmt.Sprintf("  Project: %s", p.Name)))
+	cmds = append(cmds, printLine(dimStyle.Render(fmt.Sprintf("    UUID: %s", p.UUID))))
 	if p.Description != "" {
-		cmds = append(cmds, tea.Println(dimStyle.Render(fmt.Sprintf("    Description: %s", p.Description))))
+		cmds = append(cmds, printLine(dimStyle.Render(fmt.Sprintf("    Description: %s", p.Description))))
 	}
 	ready := successMsgStyle.Render("ready")
 	if !p.Ready {
 		ready = warnMsgStyle.Render("not ready")
 	}
-	cmds = append(cmds, tea.Println(fmt.Sprintf("    Status: %s", ready)))
+	cmds = append(cmds, printLine(fmt.Sprintf("    Status: %s", ready)))
 	if p.CreateTime != "" {
-		cmds = append(cmds, tea.Println(dimStyle.Render(fmt.Sprintf("    Created: %s", p.CreateTime))))
+		cmds = append(cmds, printLine(dimStyle.Render(fmt.Sprintf("    Created: %s", formatSessionTime(p.CreateTime)))))
 	}
-	cmds = append(cmds, tea.Println(""))
+	cmds = append(cmds, printLine(""))
 	return m, tea.Sequence(cmds...)
 }
 
@@ -913,13 +1023,13 @@ type projectCreateMsg struct {
 
 func (m model) cmdProjectCreate(args []string) (tea.Model, tea.Cmd) {
 	if len(args) == 0 {
-		return m, tea.Println(warnMsgStyle.Render("  ! Usage: /projects create <name>"))
+		return m, printLine(warnMsgStyle.Render("  ! Usage: /projects create <name>"))
 	}
 	name := strings.Join(args, " ")
 	client := m.client
 
 	return m, tea.Sequence(
-		tea.Println(statusStyle.Render(fmt.Sprintf("  ⟳ Creating project '%s'...", name))),
+		printLine(statusStyle.Render(fmt.Sprintf("  ⟳ Creating project '%s'...", name))),
 		func() tea.Msg {
 			resp, err := client.CreateProject(name, "")
 			if err != nil {
@@ -932,17 +1042,17 @@ func (m model) cmdProjectCreate(args []string) (tea.Model, tea.Cmd) {
 
 func (m model) handleProjectCreate(msg projectCreateMsg) (tea.Model, tea.Cmd) {
 	if msg.err != nil {
-		return m, tea.Println(errorMsgStyle.Render(fmt.Sprintf("  ✗ Failed: %v", msg.err)))
+		return m, printLine(errorMsgStyle.Render(fmt.Sprintf("  ✗ Failed: %v", msg.err)))
 	}
 	if msg.spec != nil {
 		return m, tea.Sequence(
-			tea.Println(successMsgStyle.Render(fmt.Sprintf("  ✓ Project created: %s", msg.spec.Name))),
-			tea.Println(dimStyle.Render(fmt.Sprintf("    UUID: %s", msg.spec.UUID))),
-			tea.Println(dimStyle.Render("    Use /set project <uuid> to activate")),
-			tea.Println(""),
+			printLine(successMsgStyle.Render(fmt.Sprintf("  ✓ Project created: %s", msg.spec.Name))),
+			printLine(dimStyle.Render(fmt.Sprintf("    UUID: %s", msg.spec.UUID))),
+			printLine(dimStyle.Render("    Use /set project <uuid> to activate")),
+			printLine(""),
 		)
 	}
-	return m, tea.Println(successMsgStyle.Render("  ✓ Project created"))
+	return m, printLine(successMsgStyle.Render("  ✓ Project created"))
 }
 
 // ─── /projects delete ───────────────────────────────────────────────────────
@@ -954,13 +1064,16 @@ type projectDeleteMsg struct {
 
 func (m model) cmdProjectDelete(args []string) (tea.Model, tea.Cmd) {
 	if len(args) == 0 {
-		return m, tea.Println(warnMsgStyle.Render("  ! Usage: /projects delete <uuid>"))
+		return m, printLine(warnMsgStyle.Render("  ! Usage: /projects delete <uuid>"))
+	}
+	if err := service.CheckAdmin(m.userRole(), "/projects delete"); err != nil {
+		return m, printLine(errorMsgStyle.Render("  ✗ " + err.Error()))
 	}
 	projectUUID := args[0]
 	client := m.client
 
 	return m, tea.Sequence(
-		tea.Println(statusStyle.Render(fmt.Sprintf("  ⟳ Deleting project %s...", truncateUUID(projectUUID)))),
+		printLine(statusStyle.Render(fmt.Sprintf("  ⟳ Deleting project %s...", truncateUUID(projectUUID)))),
 		func() tea.Msg {
 			err := client.DeleteProject(projectUUID)
 			return projectDeleteMsg{uuid: projectUUID, err: err}
@@ -970,9 +1083,9 @@ func (m model) cmdProjectDelete(args []string) (tea.Model, tea.Cmd) {
 
 func (m model) handleProjectDelete(msg projectDeleteMsg) (tea.Model, tea.Cmd) {
 	if msg.err != nil {
-		return m, tea.Println(errorMsgStyle.Render(fmt.Sprintf("  ✗ Delete failed: %v", msg.err)))
+		return m, printLine(errorMsgStyle.Render(fmt.Sprintf("  ✗ Delete failed: %v", msg.err)))
 	}
-	return m, tea.Println(successMsgStyle.Render(fmt.Sprintf("  ✓ Project %s deleted", truncateUUID(msg.uuid))))
+	return m, printLine(successMsgStyle.Render(fmt.Sprintf("  ✓ Project %s deleted", truncateUUID(msg.uuid))))
 }
 
 // ─── /set ───────────────────────────────────────────────────────────────────
@@ -987,10 +1100,10 @@ type setProjectResultMsg struct {
 func (m model) cmdSet(args []string) (tea.Model, tea.Cmd) {
 	if len(args) == 0 {
 		return m, tea.Sequence(
-			tea.Println(""),
-			tea.Println(dimStyle.Render("  Usage: /set project [uuid-or-name]")),
-			tea.Println(dimStyle.Render("  Or use /projects for interactive selection")),
-			tea.Println(""),
+			printLine(""),
+			printLine(dimStyle.Render("  Usage: /set project [uuid-or-name]")),
+			printLine(dimStyle.Render("  Or use /projects for interactive selection")),
+			printLine(""),
 		)
 	}
 
@@ -999,10 +1112,10 @@ func (m model) cmdSet(args []string) (tea.Model, tea.Cmd) {
 	switch key {
 	case "project":
 		if m.cfg == nil {
-			return m, tea.Println(errorMsgStyle.Render("  ✗ Not logged in. Run /login first."))
+			return m, printLine(errorMsgStyle.Render("  ✗ Not logged in. Run /login first."))
 		}
 		if m.client == nil {
-			return m, tea.Println(errorMsgStyle.Render("  ✗ Not logged in. Run /login first."))
+			return m, printLine(errorMsgStyle.Render("  ✗ Not logged in. Run /login first."))
 		}
 
 		// If no value provided, show interactive selector
@@ -1013,7 +1126,7 @@ func (m model) cmdSet(args []string) (tea.Model, tea.Cmd) {
 		value := args[1]
 		client := m.client
 		return m, tea.Sequence(
-			tea.Println(statusStyle.Render("  ⟳ Looking up project...")),
+			printLine(statusStyle.Render("  ⟳ Looking up project...")),
 			func() tea.Msg {
 				resp, err := client.ListProjects()
 				if err != nil {
@@ -1032,27 +1145,27 @@ func (m model) cmdSet(args []string) (tea.Model, tea.Cmd) {
 		)
 
 	default:
-		return m, tea.Println(errorMsgStyle.Render(fmt.Sprintf("  ✗ Unknown key: %s (valid: project)", key)))
+		return m, printLine(errorMsgStyle.Render(fmt.Sprintf("  ✗ Unknown key: %s (valid: project)", key)))
 	}
 }
 
 func (m model) handleSetProjectResult(msg setProjectResultMsg) (tea.Model, tea.Cmd) {
 	if msg.err != nil {
-		return m, tea.Println(errorMsgStyle.Render(fmt.Sprintf("  ✗ Failed to set project: %v", msg.err)))
+		return m, printLine(errorMsgStyle.Render(fmt.Sprintf("  ✗ Failed to set project: %v", msg.err)))
 	}
 
 	m.cfg.ProjectID = msg.projectID
 	m.cfg.ProjectName = msg.projectName
 	if err := m.cfg.Save(); err != nil {
-		return m, tea.Println(errorMsgStyle.Render(fmt.Sprintf("  ✗ Failed to save config: %v", err)))
+		return m, printLine(errorMsgStyle.Render(fmt.Sprintf("  ✗ Failed to save config: %v", err)))
 	}
 	if m.cfg.Server != "" && m.cfg.Token != "" {
 		m.client = api.NewClient(m.cfg)
 	}
 
 	return m, tea.Sequence(
-		tea.Println(successMsgStyle.Render(fmt.Sprintf("  ✓ Project set to: %s", msg.projectName))),
-		tea.Println(dimStyle.Render("    You can now start investigating!")),
+		printLine(successMsgStyle.Render(fmt.Sprintf("  ✓ Project set to: %s", msg.projectName))),
+		printLine(dimStyle.Render("    You can now start investigating!")),
 	)
 }
 
@@ -1062,23 +1175,23 @@ func (m model) cmdSetSession(args []string) (tea.Model, tea.Cmd) {
 	if len(args) > 0 {
 		m.sessionID = args[0]
 		return m, tea.Sequence(
-			tea.Println(successMsgStyle.Render(fmt.Sprintf("  ✓ Session set to: %s", m.sessionID))),
-			tea.Println(dimStyle.Render("    Follow-up questions will continue in this session.")),
+			printLine(successMsgStyle.Render(fmt.Sprintf("  ✓ Session set to: %s", m.sessionID))),
+			printLine(dimStyle.Render("    Follow-up questions will continue in this session.")),
 		)
 	}
 
 	if m.client == nil {
-		return m, tea.Println(errorMsgStyle.Render("  ✗ Not logged in. Run /login first."))
+		return m, printLine(errorMsgStyle.Render("  ✗ Not logged in. Run /login first."))
 	}
 	if m.cfg.ProjectID == "" {
-		return m, tea.Println(errorMsgStyle.Render("  ✗ No project set. Run /projects first."))
+		return m, printLine(errorMsgStyle.Render("  ✗ No project set. Run /projects first."))
 	}
 
 	client := m.client
 	projectID := m.cfg.ProjectID
 
 	return m, tea.Sequence(
-		tea.Println(statusStyle.Render("  ⟳ Loading sessions...")),
+		printLine(statusStyle.Render("  ⟳ Loading sessions...")),
 		func() tea.Msg {
 			filters := []api.PaginationFilter{{
 				Key:      "session_type",
@@ -1103,13 +1216,13 @@ type scoreResultMsg struct {
 
 func (m model) cmdScore(args []string) (tea.Model, tea.Cmd) {
 	if m.client == nil {
-		return m, tea.Println(errorMsgStyle.Render("  ✗ Not logged in. Run /login first."))
+		return m, printLine(errorMsgStyle.Render("  ✗ Not logged in. Run /login first."))
 	}
 	if len(args) == 0 {
 		if m.sessionID != "" {
 			args = []string{m.sessionID}
 		} else {
-			return m, tea.Println(warnMsgStyle.Render("  ! Usage: /score <session-uuid>"))
+			return m, printLine(warnMsgStyle.Render("  ! Usage: /score <session-uuid>"))
 		}
 	}
 
@@ -1118,7 +1231,7 @@ func (m model) cmdScore(args []string) (tea.Model, tea.Cmd) {
 	projectID := m.cfg.ProjectID
 
 	return m, tea.Sequence(
-		tea.Println(statusStyle.Render(fmt.Sprintf("  ⟳ Loading scores for %s...", truncateUUID(sessionUUID)))),
+		printLine(statusStyle.Render(fmt.Sprintf("  ⟳ Loading scores for %s...", truncateUUID(sessionUUID)))),
 		func() tea.Msg {
 			resp, err := client.GetSessionSummary(projectID, sessionUUID)
 			if err != nil {
@@ -1131,51 +1244,51 @@ func (m model) cmdScore(args []string) (tea.Model, tea.Cmd) {
 
 func (m model) handleScoreResult(msg scoreResultMsg) (tea.Model, tea.Cmd) {
 	if msg.err != nil {
-		return m, tea.Println(errorMsgStyle.Render(fmt.Sprintf("  ✗ Score failed: %v", msg.err)))
+		return m, printLine(errorMsgStyle.Render(fmt.Sprintf("  ✗ Score failed: %v", msg.err)))
 	}
 	if !msg.scores.HasScores {
-		return m, tea.Println(warnMsgStyle.Render("  ! No RCA scores available for this session."))
+		return m, printLine(warnMsgStyle.Render("  ! No RCA scores available for this session."))
 	}
 
 	s := msg.scores
 	var cmds []tea.Cmd
-	cmds = append(cmds, tea.Println(""))
-	cmds = append(cmds, tea.Println(dimStyle.Render("  RCA Quality Scores:")))
+	cmds = append(cmds, printLine(""))
+	cmds = append(cmds, printLine(dimStyle.Render("  RCA Quality Scores:")))
 
 	if s.ScoredBy != "" {
-		cmds = append(cmds, tea.Println(dimStyle.Render(fmt.Sprintf("    Scored by: %s", s.ScoredBy))))
+		cmds = append(cmds, printLine(dimStyle.Render(fmt.Sprintf("    Scored by: %s", s.ScoredBy))))
 	}
 
-	cmds = append(cmds, tea.Println(fmt.Sprintf("    📊 Accuracy:     %.1f/100", s.Accuracy.Score)))
+	cmds = append(cmds, printLine(fmt.Sprintf("    📊 Accuracy:     %.1f/100", s.Accuracy.Score)))
 	if s.Accuracy.Summary != "" {
-		cmds = append(cmds, tea.Println(dimStyle.Render("       "+s.Accuracy.Summary)))
+		cmds = append(cmds, printLine(dimStyle.Render("       "+s.Accuracy.Summary)))
 	}
-	cmds = append(cmds, tea.Println(fmt.Sprintf("    📊 Completeness: %.1f/100", s.Completeness.Score)))
+	cmds = append(cmds, printLine(fmt.Sprintf("    📊 Completeness: %.1f/100", s.Completeness.Score)))
 	if s.Completeness.Summary != "" {
-		cmds = append(cmds, tea.Println(dimStyle.Render("       "+s.Completeness.Summary)))
+		cmds = append(cmds, printLine(dimStyle.Render("       "+s.Completeness.Summary)))
 	}
 
 	if len(s.Qualitative.Strengths) > 0 {
-		cmds = append(cmds, tea.Println(successMsgStyle.Render("    ✅ Strengths:")))
+		cmds = append(cmds, printLine(successMsgStyle.Render("    ✅ Strengths:")))
 		for _, str := range s.Qualitative.Strengths {
-			cmds = append(cmds, tea.Println("      • "+str))
+			cmds = append(cmds, printLine("      • "+str))
 		}
 	}
 	if len(s.Qualitative.Improvements) > 0 {
-		cmds = append(cmds, tea.Println(warnMsgStyle.Render("    💡 Improvements:")))
+		cmds = append(cmds, printLine(warnMsgStyle.Render("    💡 Improvements:")))
 		for _, imp := range s.Qualitative.Improvements {
-			cmds = append(cmds, tea.Println("      • "+imp))
+			cmds = append(cmds, printLine("      • "+imp))
 		}
 	}
 
 	if s.TimeSaved != nil {
-		cmds = append(cmds, tea.Println(fmt.Sprintf("    ⏱  Time saved: %.0f min (%.0f → %.0f)",
+		cmds = append(cmds, printLine(fmt.Sprintf("    ⏱  Time saved: %.0f min (%.0f → %.0f)",
 			s.TimeSaved.TimeSavedMinutes,
 			s.TimeSaved.StandardInvestigationMin,
 			s.TimeSaved.HawkeyeInvestigationMin)))
 	}
 
-	cmds = append(cmds, tea.Println(""))
+	cmds = append(cmds, printLine(""))
 	return m, tea.Sequence(cmds...)
 }
 
@@ -1183,39 +1296,54 @@ func (m model) handleScoreResult(msg scoreResultMsg) (tea.Model, tea.Cmd) {
 
 func (m model) cmdLink(args []string) (tea.Model, tea.Cmd) {
 	if m.cfg == nil || m.cfg.Server == "" {
-		return m, tea.Println(errorMsgStyle.Render("  ✗ Not logged in. Run /login first."))
+		return m, printLine(errorMsgStyle.Render("  ✗ Not logged in. Run /login first."))
 	}
 	if m.cfg.ProjectID == "" {
-		return m, tea.Println(errorMsgStyle.Render("  ✗ No project set. Run /projects first."))
+		return m, printLine(errorMsgStyle.Render("  ✗ No project set. Run /projects first."))
+	}
+
+	copyURL := false
+	var positional []string
+	for _, a := range args {
+		if a == "--copy" {
+			copyURL = true
+			continue
+		}
+		positional = append(positional, a)
 	}
 
 	sessionUUID := ""
-	if len(args) > 0 {
-		sessionUUID = args[0]
+	if len(positional) > 0 {
+		sessionUUID = positional[0]
 	} else if m.sessionID != "" {
 		sessionUUID = m.sessionID
 	} else {
-		return m, tea.Println(warnMsgStyle.Render("  ! Usage: /link <session-uuid>"))
+		return m, printLine(warnMsgStyle.Render("  ! Usage: /link <session-uuid> [--copy]"))
 	}
 
 	url := service.BuildSessionURL(m.cfg.Server, m.cfg.ProjectID, sessionUUID)
-	return m, tea.Sequence(
-		tea.Println(""),
-		tea.Println("  "+url),
-		tea.Println(""),
-	)
+	cmds := []tea.Cmd{
+		printLine(""),
+		printLine("  " + url),
+	}
+	if copyURL {
+		cmds = append(cmds, printLine("  "+m.copySessionLink(sessionUUID)))
+	}
+	cmds = append(cmds, printLine(""))
+	return m, tea.Sequence(cmds...)
 }
 
 // ─── /open ──────────────────────────────────────────────────────────────────
 
 func (m model) cmdOpen(args []string) (tea.Model, tea.Cmd) {
 	if len(args) == 0 {
-		return m, tea.Println(warnMsgStyle.Render("  ! Usage: /open <url>"))
+		return m, printLine(warnMsgStyle.Render("  ! Usage: /open <url>"))
 	}
 
-	_, projectUUID, sessionUUID, err := service.ParseSessionURL(args[0])
+	// A pasted Slack link may arrive split at the spaces in its label.
+	_, projectUUID, sessionUUID, err := service.ParseSessionURL(strings.Join(args, " "))
 	if err != nil {
-		return m, tea.Println(errorMsgStyle.Render(fmt.Sprintf("  ✗ Invalid URL: %v", err)))
+		return m, printLine(errorMsgStyle.Render(fmt.Sprintf("  ✗ Cannot open link: %v", err)))
 	}
 
 	m.sessionID = sessionUUID
@@ -1234,13 +1362,13 @@ type reportResultMsg struct {
 
 func (m model) cmdReport() (tea.Model, tea.Cmd) {
 	if m.client == nil {
-		return m, tea.Println(errorMsgStyle.Render("  ✗ Not logged in. Run /login first."))
+		return m, printLine(errorMsgStyle.Render("  ✗ Not logged in. Run /login first."))
 	}
 
 	client := m.client
 
 	return m, tea.Sequence(
-		tea.Println(statusStyle.Render("  ⟳ Loading incident report...")),
+		printLine(statusStyle.Render("  ⟳ Loading incident report...")),
 		func() tea.Msg {
 			resp, err := client.GetIncidentReport()
 			if err != nil {
@@ -1253,40 +1381,40 @@ func (m model) cmdReport() (tea.Model, tea.Cmd) {
 
 func (m model) handleReportResult(msg reportResultMsg) (tea.Model, tea.Cmd) {
 	if msg.err != nil {
-		return m, tea.Println(errorMsgStyle.Render(fmt.Sprintf("  ✗ Report failed: %v", msg.err)))
+		return m, printLine(errorMsgStyle.Render(fmt.Sprintf("  ✗ Report failed: %v", msg.err)))
 	}
 
 	r := msg.report
 	var cmds []tea.Cmd
-	cmds = append(cmds, tea.Println(""))
-	cmds = append(cmds, tea.Println(dimStyle.Render("  Incident Analytics Report:")))
+	cmds = append(cmds, printLine(""))
+	cmds = append(cmds, printLine(dimStyle.Render("  Incident Analytics Report:")))
 
 	if r.Period != "" {
-		cmds = append(cmds, tea.Println(dimStyle.Render("    Period: "+r.Period)))
+		cmds = append(cmds, printLine(dimStyle.Render("    Period: "+r.Period)))
 	}
 
 	cmds = append(cmds,
-		tea.Println(fmt.Sprintf("    Total incidents:      %d", r.TotalIncidents)),
-		tea.Println(fmt.Sprintf("    Total investigations: %d", r.TotalInvestigations)),
-		tea.Println(fmt.Sprintf("    Avg time saved:       %s", r.AvgTimeSavedMinutes)),
-		tea.Println(fmt.Sprintf("    Avg MTTR:             %s", r.AvgMTTR)),
-		tea.Println(fmt.Sprintf("    Noise reduction:      %s", r.NoiseReduction)),
-		tea.Println(fmt.Sprintf("    Total time saved:     %s", r.TotalTimeSavedHours)),
+		printLine(fmt.Sprintf("    Total incidents:      %d", r.TotalIncidents)),
+		printLine(fmt.Sprintf("    Total investigations: %d", r.TotalInvestigations)),
+		printLine(fmt.Sprintf("    Avg time saved:       %s", r.AvgTimeSavedMinutes)),
+		printLine(fmt.Sprintf("    Avg MTTR:             %s", r.AvgMTTR)),
+		printLine(fmt.Sprintf("    Noise reduction:      %s", r.NoiseReduction)),
+		printLine(fmt.Sprintf("    Total time saved:     %s", r.TotalTimeSavedHours)),
 	)
 
 	if len(r.IncidentTypes) > 0 {
-		cmds = append(cmds, tea.Println(""))
-		cmds = append(cmds, tea.Println(dimStyle.Render("    By type:")))
+		cmds = append(cmds, printLine(""))
+		cmds = append(cmds, printLine(dimStyle.Render("    By type:")))
 		for _, it := range r.IncidentTypes {
-			cmds = append(cmds, tea.Println(fmt.Sprintf("      %s", it.Type)))
+			cmds = append(cmds, printLine(fmt.Sprintf("      %s", it.Type)))
 			for _, pr := range it.Priorities {
-				cmds = append(cmds, tea.Println(fmt.Sprintf("        [%s]  incidents: %-5d  investigated: %-3d  grouped: %-6s  saved: %s",
+				cmds = append(cmds, printLine(fmt.Sprintf("        [%s]  incidents: %-5d  investigated: %-3d  grouped: %-6s  saved: %s",
 					pr.Priority, pr.TotalIncidents, pr.Investigated, pr.PercentGrouped, pr.AvgTimeSaved)))
 			}
 		}
 	}
 
-	cmds = append(cmds, tea.Println(""))
+	cmds = append(cmds, printLine(""))
 	return m, tea.Sequence(cmds...)
 }
 
@@ -1305,10 +1433,10 @@ type resourcesResultMsg struct {
 
 func (m model) cmdConnections(args []string) (tea.Model, tea.Cmd) {
 	if m.client == nil {
-		return m, tea.Println(errorMsgStyle.Render("  ✗ Not logged in. Run /login first."))
+		return m, printLine(errorMsgStyle.Render("  ✗ Not logged in. Run /login first."))
 	}
 	if m.cfg.ProjectID == "" {
-		return m, tea.Println(errorMsgStyle.Render("  ✗ No project set. Run /projects first."))
+		return m, printLine(errorMsgStyle.Render("  ✗ No project set. Run /projects first."))
 	}
 
 	// Subcommand dispatch
@@ -1318,7 +1446,7 @@ func (m model) cmdConnections(args []string) (tea.Model, tea.Cmd) {
 			client := m.client
 			projectID := m.cfg.ProjectID
 			return m, tea.Sequence(
-				tea.Println(statusStyle.Render("  ⟳ Loading connections...")),
+				printLine(statusStyle.Render("  ⟳ Loading connections...")),
 				func() tea.Msg {
 					resp, err := client.ListConnections(projectID)
 					if err != nil {
@@ -1333,12 +1461,12 @@ func (m model) cmdConnections(args []string) (tea.Model, tea.Cmd) {
 			)
 		case "resources":
 			if len(args) < 2 {
-				return m, tea.Println(warnMsgStyle.Render("  ! Usage: /connections resources <connection-uuid>"))
+				return m, printLine(warnMsgStyle.Render("  ! Usage: /connections resources <connection-uuid>"))
 			}
 			connUUID := args[1]
 			client := m.client
 			return m, tea.Sequence(
-				tea.Println(statusStyle.Render(fmt.Sprintf("  ⟳ Loading resources for %s...", truncateUUID(connUUID)))),
+				printLine(statusStyle.Render(fmt.Sprintf("  ⟳ Loading resources for %s...", truncateUUID(connUUID)))),
 				func() tea.Msg {
 					resp, err := client.ListConnectionResources(connUUID, 100)
 					if err != nil {
@@ -1352,6 +1480,8 @@ func (m model) cmdConnections(args []string) (tea.Model, tea.Cmd) {
 			)
 		case "types":
 			return m.cmdConnectionTypes()
+		case "create":
+			return m.cmdConnectionCreate(args[1:])
 		case "info":
 			return m.cmdConnectionInfo(args[1:])
 		case "add":
@@ -1365,7 +1495,7 @@ func (m model) cmdConnections(args []string) (tea.Model, tea.Cmd) {
 	client := m.client
 	projectID := m.cfg.ProjectID
 	return m, tea.Sequence(
-		tea.Println(statusStyle.Render("  ⟳ Loading connections...")),
+		printLine(statusStyle.Render("  ⟳ Loading connections...")),
 		func() tea.Msg {
 			resp, err := client.ListConnections(projectID)
 			if err != nil {
@@ -1383,11 +1513,11 @@ func (m model) cmdConnections(args []string) (tea.Model, tea.Cmd) {
 func (m model) cmdConnectionTypes() (tea.Model, tea.Cmd) {
 	types := service.GetConnectionTypes()
 	var cmds []tea.Cmd
-	cmds = append(cmds, tea.Println(""), tea.Println(dimStyle.Render(fmt.Sprintf("  Connection Types (%d):", len(types)))), tea.Println(""))
+	cmds = append(cmds, printLine(""), printLine(dimStyle.Render(fmt.Sprintf("  Connection Types (%d):", len(types)))), printLine(""))
 	for _, ct := range types {
-		cmds = append(cmds, tea.Println(fmt.Sprintf("  • %-15s %s", ct.Type, dimStyle.Render(ct.Description))))
+		cmds = append(cmds, printLine(fmt.Sprintf("  • %-15s %s", ct.Type, dimStyle.Render(ct.Description))))
 	}
-	cmds = append(cmds, tea.Println(""))
+	cmds = append(cmds, printLine(""))
 	return m, tea.Sequence(cmds...)
 }
 
@@ -1398,13 +1528,13 @@ type connInfoMsg struct {
 
 func (m model) cmdConnectionInfo(args []string) (tea.Model, tea.Cmd) {
 	if len(args) == 0 {
-		return m, tea.Println(warnMsgStyle.Render("  ! Usage: /connections info <uuid>"))
+		return m, printLine(warnMsgStyle.Render("  ! Usage: /connections info <uuid>"))
 	}
 	connUUID := args[0]
 	client := m.client
 
 	return m, tea.Sequence(
-		tea.Println(statusStyle.Render(fmt.Sprintf("  ⟳ Loading connection %s...", truncateUUID(connUUID)))),
+		printLine(statusStyle.Render(fmt.Sprintf("  ⟳ Loading connection %s...", truncateUUID(connUUID)))),
 		func() tea.Msg {
 			resp, err := client.GetConnectionInfo(connUUID)
 			if err != nil {
@@ -1417,15 +1547,15 @@ func (m model) cmdConnectionInfo(args []string) (tea.Model, tea.Cmd) {
 
 func (m model) handleConnInfo(msg connInfoMsg) (tea.Model, tea.Cmd) {
 	if msg.err != nil {
-		return m, tea.Println(errorMsgStyle.Render(fmt.Sprintf("  ✗ Failed: %v", msg.err)))
+		return m, printLine(errorMsgStyle.Render(fmt.Sprintf("  ✗ Failed: %v", msg.err)))
 	}
 	c := msg.detail
 	return m, tea.Sequence(
-		tea.Println(""),
-		tea.Println(fmt.Sprintf("  Connection: %s", c.Name)),
-		tea.Println(dimStyle.Render(fmt.Sprintf("    UUID: %s  Type: %s", c.UUID, c.Type))),
-		tea.Println(dimStyle.Render(fmt.Sprintf("    Sync: %s  Training: %s", c.SyncState, c.TrainingState))),
-		tea.Println(""),
+		printLine(""),
+		printLine(fmt.Sprintf("  Connection: %s", c.Name)),
+		printLine(dimStyle.Render(fmt.Sprintf("    UUID: %s  Type: %s", c.UUID, c.Type))),
+		printLine(dimStyle.Render(fmt.Sprintf("    Sync: %s  Training: %s", c.SyncState, c.TrainingState))),
+		printLine(""),
 	)
 }
 
@@ -1436,14 +1566,14 @@ type connAddMsg struct {
 
 func (m model) cmdConnectionAdd(args []string) (tea.Model, tea.Cmd) {
 	if len(args) == 0 {
-		return m, tea.Println(warnMsgStyle.Render("  ! Usage: /connections add <uuid>"))
+		return m, printLine(warnMsgStyle.Render("  ! Usage: /connections add <uuid>"))
 	}
 	connUUID := args[0]
 	client := m.client
 	projectID := m.cfg.ProjectID
 
 	return m, tea.Sequence(
-		tea.Println(statusStyle.Render(fmt.Sprintf("  ⟳ Adding connection %s...", truncateUUID(connUUID)))),
+		printLine(statusStyle.Render(fmt.Sprintf("  ⟳ Adding connection %s...", truncateUUID(connUUID)))),
 		func() tea.Msg {
 			err := client.AddConnectionToProject(projectID, connUUID)
 			return connAddMsg{connUUID: connUUID, err: err}
@@ -1453,9 +1583,9 @@ func (m model) cmdConnectionAdd(args []string) (tea.Model, tea.Cmd) {
 
 func (m model) handleConnAdd(msg connAddMsg) (tea.Model, tea.Cmd) {
 	if msg.err != nil {
-		return m, tea.Println(errorMsgStyle.Render(fmt.Sprintf("  ✗ Failed: %v", msg.err)))
+		return m, printLine(errorMsgStyle.Render(fmt.Sprintf("  ✗ Failed: %v", msg.err)))
 	}
-	return m, tea.Println(successMsgStyle.Render(fmt.Sprintf("  ✓ Connection %s added to project", truncateUUID(msg.connUUID))))
+	return m, printLine(successMsgStyle.Render(fmt.Sprintf("  ✓ Connection %s added to project", truncateUUID(msg.connUUID))))
 }
 
 type connRemoveMsg struct {
@@ -1465,14 +1595,14 @@ type connRemoveMsg struct {
 
 func (m model) cmdConnectionRemove(args []string) (tea.Model, tea.Cmd) {
 	if len(args) == 0 {
-		return m, tea.Println(warnMsgStyle.Render("  ! Usage: /connections remove <uuid>"))
+		return m, printLine(warnMsgStyle.Render("  ! Usage: /connections remove <uuid>"))
 	}
 	connUUID := args[0]
 	client := m.client
 	projectID := m.cfg.ProjectID
 
 	return m, tea.Sequence(
-		tea.Println(statusStyle.Render(fmt.Sprintf("  ⟳ Removing connection %s...", truncateUUID(connUUID)))),
+		printLine(statusStyle.Render(fmt.Sprintf("  ⟳ Removing connection %s...", truncateUUID(connUUID)))),
 		func() tea.Msg {
 			err := client.RemoveConnectionFromProject(projectID, connUUID)
 			return connRemoveMsg{connUUID: connUUID, err: err}
@@ -1482,25 +1612,25 @@ func (m model) cmdConnectionRemove(args []string) (tea.Model, tea.Cmd) {
 
 func (m model) handleConnRemove(msg connRemoveMsg) (tea.Model, tea.Cmd) {
 	if msg.err != nil {
-		return m, tea.Println(errorMsgStyle.Render(fmt.Sprintf("  ✗ Failed: %v", msg.err)))
+		return m, printLine(errorMsgStyle.Render(fmt.Sprintf("  ✗ Failed: %v", msg.err)))
 	}
-	return m, tea.Println(successMsgStyle.Render(fmt.Sprintf("  ✓ Connection %s removed from project", truncateUUID(msg.connUUID))))
+	return m, printLine(successMsgStyle.Render(fmt.Sprintf("  ✓ Connection %s removed from project", truncateUUID(msg.connUUID))))
 }
 
 func (m model) handleConnectionsResult(msg connectionsResultMsg) (tea.Model, tea.Cmd) {
 	if msg.err != nil {
-		return m, tea.Println(errorMsgStyle.Render(fmt.Sprintf("  ✗ Connections failed: %v", msg.err)))
+		return m, printLine(errorMsgStyle.Render(fmt.Sprintf("  ✗ Connections failed: %v", msg.err)))
 	}
 
 	if len(msg.connections) == 0 {
-		return m, tea.Println(warnMsgStyle.Render("  ! No connections found."))
+		return m, printLine(warnMsgStyle.Render("  ! No connections found."))
 	}
 
 	var cmds []tea.Cmd
 	cmds = append(cmds,
-		tea.Println(""),
-		tea.Println(dimStyle.Render(fmt.Sprintf("  Connections (%d):", len(msg.connections)))),
-		tea.Println(""),
+		printLine(""),
+		printLine(dimStyle.Render(fmt.Sprintf("  Connections (%d):", len(msg.connections)))),
+		printLine(""),
 	)
 
 	for _, c := range msg.connections {
@@ -1509,15 +1639,15 @@ func (m model) handleConnectionsResult(msg connectionsResultMsg) (tea.Model, tea
 			syncIcon = "✅"
 		}
 		cmds = append(cmds,
-			tea.Println(fmt.Sprintf("  %s %s  (%s)", syncIcon, c.Name, c.Type)),
-			tea.Println(dimStyle.Render(fmt.Sprintf("    %s  sync: %s  training: %s", c.UUID, c.SyncState, c.TrainingState))),
+			printLine(fmt.Sprintf("  %s %s  (%s)", syncIcon, c.Name, c.Type)),
+			printLine(dimStyle.Render(fmt.Sprintf("    %s  sync: %s  training: %s", c.UUID, c.SyncState, c.TrainingState))),
 		)
 	}
 
 	cmds = append(cmds,
-		tea.Println(""),
-		tea.Println(dimStyle.Render("  Tip: /connections resources <uuid> to list resources")),
-		tea.Println(""),
+		printLine(""),
+		printLine(dimStyle.Render("  Tip: /connections resources <uuid> to list resources")),
+		printLine(""),
 	)
 
 	return m, tea.Sequence(cmds...)
@@ -1525,25 +1655,25 @@ func (m model) handleConnectionsResult(msg connectionsResultMsg) (tea.Model, tea
 
 func (m model) handleResourcesResult(msg resourcesResultMsg) (tea.Model, tea.Cmd) {
 	if msg.err != nil {
-		return m, tea.Println(errorMsgStyle.Render(fmt.Sprintf("  ✗ Resources failed: %v", msg.err)))
+		return m, printLine(errorMsgStyle.Render(fmt.Sprintf("  ✗ Resources failed: %v", msg.err)))
 	}
 
 	if len(msg.resources) == 0 {
-		return m, tea.Println(warnMsgStyle.Render("  ! No resources found."))
+		return m, printLine(warnMsgStyle.Render("  ! No resources found."))
 	}
 
 	var cmds []tea.Cmd
 	cmds = append(cmds,
-		tea.Println(""),
-		tea.Println(dimStyle.Render(fmt.Sprintf("  Resources for %s (%d):", truncateUUID(msg.connUUID), len(msg.resources)))),
-		tea.Println(""),
+		printLine(""),
+		printLine(dimStyle.Render(fmt.Sprintf("  Resources for %s (%d):", truncateUUID(msg.connUUID), len(msg.resources)))),
+		printLine(""),
 	)
 
 	for _, r := range msg.resources {
-		cmds = append(cmds, tea.Println(fmt.Sprintf("  • %-30s  %s", r.Name, dimStyle.Render(r.TelemetryType))))
+		cmds = append(cmds, printLine(fmt.Sprintf("  • %-30s  %s", r.Name, dimStyle.Render(r.TelemetryType))))
 	}
 
-	cmds = append(cmds, tea.Println(""))
+	cmds = append(cmds, printLine(""))
 	return m, tea.Sequence(cmds...)
 }
 
@@ -1556,10 +1686,10 @@ type instructionsLoadedMsg struct {
 
 func (m model) cmdInstructions(args []string) (tea.Model, tea.Cmd) {
 	if m.client == nil {
-		return m, tea.Println(errorMsgStyle.Render("  ✗ Not logged in. Run /login first."))
+		return m, printLine(errorMsgStyle.Render("  ✗ Not logged in. Run /login first."))
 	}
 	if m.cfg.ProjectID == "" {
-		return m, tea.Println(errorMsgStyle.Render("  ✗ No project set. Run /projects first."))
+		return m, printLine(errorMsgStyle.Render("  ✗ No project set. Run /projects first."))
 	}
 
 	// Subcommand dispatch
@@ -1580,7 +1710,7 @@ func (m model) cmdInstructions(args []string) (tea.Model, tea.Cmd) {
 	projectID := m.cfg.ProjectID
 
 	return m, tea.Sequence(
-		tea.Println(statusStyle.Render("  ⟳ Loading instructions...")),
+		printLine(statusStyle.Render("  ⟳ Loading instructions...")),
 		func() tea.Msg {
 			resp, err := client.ListInstructions(projectID)
 			if err != nil {
@@ -1593,15 +1723,15 @@ func (m model) cmdInstructions(args []string) (tea.Model, tea.Cmd) {
 
 func (m model) handleInstructionsLoaded(msg instructionsLoadedMsg) (tea.Model, tea.Cmd) {
 	if msg.err != nil {
-		return m, tea.Println(errorMsgStyle.Render(fmt.Sprintf("  ✗ Failed: %v", msg.err)))
+		return m, printLine(errorMsgStyle.Render(fmt.Sprintf("  ✗ Failed: %v", msg.err)))
 	}
 
 	if len(msg.instructions) == 0 {
-		return m, tea.Println(warnMsgStyle.Render("  ! No instructions found."))
+		return m, printLine(warnMsgStyle.Render("  ! No instructions found."))
 	}
 
 	var cmds []tea.Cmd
-	cmds = append(cmds, tea.Println(""), tea.Println(dimStyle.Render(fmt.Sprintf("  Instructions (%d):", len(msg.instructions)))), tea.Println(""))
+	cmds = append(cmds, printLine(""), printLine(dimStyle.Render(fmt.Sprintf("  Instructions (%d):", len(msg.instructions)))), printLine(""))
 
 	for _, instr := range msg.instructions {
 		status := successMsgStyle.Render("enabled")
@@ -1609,11 +1739,11 @@ func (m model) handleInstructionsLoaded(msg instructionsLoadedMsg) (tea.Model, t
 			status = dimStyle.Render("disabled")
 		}
 		cmds = append(cmds,
-			tea.Println(fmt.Sprintf("  %s  [%s]  %s", instr.Name, instr.Type, status)),
-			tea.Println(dimStyle.Render(fmt.Sprintf("    %s", instr.UUID))),
+			printLine(fmt.Sprintf("  %s  [%s]  %s", instr.Name, instr.Type, status)),
+			printLine(dimStyle.Render(fmt.Sprintf("    %s", instr.UUID))),
 		)
 	}
-	cmds = append(cmds, tea.Println(""))
+	cmds = append(cmds, printLine(""))
 	return m, tea.Sequence(cmds...)
 }
 
@@ -1624,14 +1754,14 @@ type instructionCreateMsg struct {
 
 func (m model) cmdInstructionCreate(args []string) (tea.Model, tea.Cmd) {
 	if len(args) == 0 {
-		return m, tea.Println(warnMsgStyle.Render("  ! Usage: /instructions create <name>"))
+		return m, printLine(warnMsgStyle.Render("  ! Usage: /instructions create <name>"))
 	}
 	name := strings.Join(args, " ")
 	client := m.client
 	projectID := m.cfg.ProjectID
 
 	return m, tea.Sequence(
-		tea.Println(statusStyle.Render(fmt.Sprintf("  ⟳ Creating instruction '%s'...", name))),
+		printLine(statusStyle.Render(fmt.Sprintf("  ⟳ Creating instruction '%s'...", name))),
 		func() tea.Msg {
 			resp, err := client.CreateInstruction(projectID, name, "system", "")
 			if err != nil {
@@ -1644,15 +1774,15 @@ func (m model) cmdInstructionCreate(args []string) (tea.Model, tea.Cmd) {
 
 func (m model) handleInstructionCreate(msg instructionCreateMsg) (tea.Model, tea.Cmd) {
 	if msg.err != nil {
-		return m, tea.Println(errorMsgStyle.Render(fmt.Sprintf("  ✗ Failed: %v", msg.err)))
+		return m, printLine(errorMsgStyle.Render(fmt.Sprintf("  ✗ Failed: %v", msg.err)))
 	}
 	if msg.spec != nil {
 		return m, tea.Sequence(
-			tea.Println(successMsgStyle.Render(fmt.Sprintf("  ✓ Instruction created: %s", msg.spec.Name))),
-			tea.Println(dimStyle.Render(fmt.Sprintf("    UUID: %s", msg.spec.UUID))),
+			printLine(successMsgStyle.Render(fmt.Sprintf("  ✓ Instruction created: %s", msg.spec.Name))),
+			printLine(dimStyle.Render(fmt.Sprintf("    UUID: %s", msg.spec.UUID))),
 		)
 	}
-	return m, tea.Println(successMsgStyle.Render("  ✓ Instruction created"))
+	return m, printLine(successMsgStyle.Render("  ✓ Instruction created"))
 }
 
 type instructionToggleMsg struct {
@@ -1667,7 +1797,7 @@ func (m model) cmdInstructionToggle(args []string, enable bool) (tea.Model, tea.
 		if !enable {
 			action = "disable"
 		}
-		return m, tea.Println(warnMsgStyle.Render(fmt.Sprintf("  ! Usage: /instructions %s <uuid>", action)))
+		return m, printLine(warnMsgStyle.Render(fmt.Sprintf("  ! Usage: /instructions %s <uuid>", action)))
 	}
 	instrUUID := args[0]
 	client := m.client
@@ -1678,7 +1808,7 @@ func (m model) cmdInstructionToggle(args []string, enable bool) (tea.Model, tea.
 	}
 
 	return m, tea.Sequence(
-		tea.Println(statusStyle.Render(fmt.Sprintf("  ⟳ %s %s...", action, truncateUUID(instrUUID)))),
+		printLine(statusStyle.Render(fmt.Sprintf("  ⟳ %s %s...", action, truncateUUID(instrUUID)))),
 		func() tea.Msg {
 			err := client.UpdateInstructionStatus(instrUUID, enable)
 			return instructionToggleMsg{uuid: instrUUID, enabled: enable, err: err}
@@ -1688,13 +1818,13 @@ func (m model) cmdInstructionToggle(args []string, enable bool) (tea.Model, tea.
 
 func (m model) handleInstructionToggle(msg instructionToggleMsg) (tea.Model, tea.Cmd) {
 	if msg.err != nil {
-		return m, tea.Println(errorMsgStyle.Render(fmt.Sprintf("  ✗ Failed: %v", msg.err)))
+		return m, printLine(errorMsgStyle.Render(fmt.Sprintf("  ✗ Failed: %v", msg.err)))
 	}
 	action := "enabled"
 	if !msg.enabled {
 		action = "disabled"
 	}
-	return m, tea.Println(successMsgStyle.Render(fmt.Sprintf("  ✓ Instruction %s %s", truncateUUID(msg.uuid), action)))
+	return m, printLine(successMsgStyle.Render(fmt.Sprintf("  ✓ Instruction %s %s", truncateUUID(msg.uuid), action)))
 }
 
 type instructionDeleteMsg struct {
@@ -1704,13 +1834,13 @@ type instructionDeleteMsg struct {
 
 func (m model) cmdInstructionDelete(args []string) (tea.Model, tea.Cmd) {
 	if len(args) == 0 {
-		return m, tea.Println(warnMsgStyle.Render("  ! Usage: /instructions delete <uuid>"))
+		return m, printLine(warnMsgStyle.Render("  ! Usage: /instructions delete <uuid>"))
 	}
 	instrUUID := args[0]
 	client := m.client
 
 	return m, tea.Sequence(
-		tea.Println(statusStyle.Render(fmt.Sprintf("  ⟳ Deleting instruction %s...", truncateUUID(instrUUID)))),
+		printLine(statusStyle.Render(fmt.Sprintf("  ⟳ Deleting instruction %s...", truncateUUID(instrUUID)))),
 		func() tea.Msg {
 			err := client.DeleteInstruction(instrUUID)
 			return instructionDeleteMsg{uuid: instrUUID, err: err}
@@ -1720,9 +1850,9 @@ func (m model) cmdInstructionDelete(args []string) (tea.Model, tea.Cmd) {
 
 func (m model) handleInstructionDelete(msg instructionDeleteMsg) (tea.Model, tea.Cmd) {
 	if msg.err != nil {
-		return m, tea.Println(errorMsgStyle.Render(fmt.Sprintf("  ✗ Failed: %v", msg.err)))
+		return m, printLine(errorMsgStyle.Render(fmt.Sprintf("  ✗ Failed: %v", msg.err)))
 	}
-	return m, tea.Println(successMsgStyle.Render(fmt.Sprintf("  ✓ Instruction %s deleted", truncateUUID(msg.uuid))))
+	return m, printLine(successMsgStyle.Render(fmt.Sprintf("  ✓ Instruction %s deleted", truncateUUID(msg.uuid))))
 }
 
 // ─── /rerun ─────────────────────────────────────────────────────────────────
@@ -1734,7 +1864,7 @@ type rerunResultMsg struct {
 
 func (m model) cmdRerun(args []string) (tea.Model, tea.Cmd) {
 	if m.client == nil {
-		return m, tea.Println(errorMsgStyle.Render("  ✗ Not logged in. Run /login first."))
+		return m, printLine(errorMsgStyle.Render("  ✗ Not logged in. Run /login first."))
 	}
 
 	sessionUUID := ""
@@ -1743,13 +1873,13 @@ func (m model) cmdRerun(args []string) (tea.Model, tea.Cmd) {
 	} else if m.sessionID != "" {
 		sessionUUID = m.sessionID
 	} else {
-		return m, tea.Println(warnMsgStyle.Render("  ! Usage: /rerun [session-uuid]"))
+		return m, printLine(warnMsgStyle.Render("  ! Usage: /rerun [session-uuid]"))
 	}
 
 	client := m.client
 
 	return m, tea.Sequence(
-		tea.Println(statusStyle.Render(fmt.Sprintf("  ⟳ Rerunning session %s...", truncateUUID(sessionUUID)))),
+		printLine(statusStyle.Render(fmt.Sprintf("  ⟳ Rerunning session %s...", truncateUUID(sessionUUID)))),
 		func() tea.Msg {
 			resp, err := client.RerunSession(sessionUUID)
 			if err != nil {
@@ -1762,22 +1892,22 @@ func (m model) cmdRerun(args []string) (tea.Model, tea.Cmd) {
 
 func (m model) handleRerunResult(msg rerunResultMsg) (tea.Model, tea.Cmd) {
 	if msg.err != nil {
-		return m, tea.Println(errorMsgStyle.Render(fmt.Sprintf("  ✗ Rerun failed: %v", msg.err)))
+		return m, printLine(errorMsgStyle.Render(fmt.Sprintf("  ✗ Rerun failed: %v", msg.err)))
 	}
-	return m, tea.Println(successMsgStyle.Render(fmt.Sprintf("  ✓ Rerun started (session: %s)", truncateUUID(msg.sessionUUID))))
+	return m, printLine(successMsgStyle.Render(fmt.Sprintf("  ✓ Rerun started (session: %s)", truncateUUID(msg.sessionUUID))))
 }
 
 // ─── /investigate-alert ──────────────────────────────────────────────────────
 
 func (m model) cmdInvestigateAlert(args []string) (tea.Model, tea.Cmd) {
 	if m.client == nil {
-		return m, tea.Println(errorMsgStyle.Render("  ✗ Not logged in. Run /login first."))
+		return m, printLine(errorMsgStyle.Render("  ✗ Not logged in. Run /login first."))
 	}
 	if m.cfg == nil || m.cfg.ProjectID == "" {
-		return m, tea.Println(errorMsgStyle.Render("  ✗ No project set. Use /set project <uuid>"))
+		return m, printLine(errorMsgStyle.Render("  ✗ No project set. Use /set project <uuid>"))
 	}
 	if len(args) == 0 {
-		return m, tea.Println(warnMsgStyle.Render("  ! Usage: /investigate-alert <alert-id>"))
+		return m, printLine(warnMsgStyle.Render("  ! Usage: /investigate-alert <alert-id>"))
 	}
 
 	alertID := args[0]
@@ -1789,10 +1919,10 @@ func (m model) cmdInvestigateAlert(args []string) (tea.Model, tea.Cmd) {
 	projectID := m.cfg.ProjectID
 
 	return m, tea.Sequence(
-		tea.Println(""),
-		tea.Println(userPromptStyle.Render("  ❯ Investigate alert: "+alertID)),
-		tea.Println(""),
-		tea.Println(statusStyle.Render("  ⟳ Creating session from alert...")),
+		printLine(""),
+		printLine(userPromptStyle.Render("  ❯ Investigate alert: "+alertID)),
+		printLine(""),
+		printLine(statusStyle.Render("  ⟳ Creating session from alert...")),
 		func() tea.Msg {
 			sessResp, err := client.CreateSessionFromAlert(projectID, alertID)
 			if err != nil {
@@ -1812,7 +1942,7 @@ type queriesResultMsg struct {
 
 func (m model) cmdQueries(args []string) (tea.Model, tea.Cmd) {
 	if m.client == nil {
-		return m, tea.Println(errorMsgStyle.Render("  ✗ Not logged in. Run /login first."))
+		return m, printLine(errorMsgStyle.Render("  ✗ Not logged in. Run /login first."))
 	}
 
 	sessionUUID := ""
@@ -1821,13 +1951,13 @@ func (m model) cmdQueries(args []string) (tea.Model, tea.Cmd) {
 	} else if m.sessionID != "" {
 		sessionUUID = m.sessionID
 	} else {
-		return m, tea.Println(warnMsgStyle.Render("  ! Usage: /queries [session-uuid]"))
+		return m, printLine(warnMsgStyle.Render("  ! Usage: /queries [session-uuid]"))
 	}
 
 	client := m.client
 
 	return m, tea.Sequence(
-		tea.Println(statusStyle.Render(fmt.Sprintf("  ⟳ Loading queries for %s...", truncateUUID(sessionUUID)))),
+		printLine(statusStyle.Render(fmt.Sprintf("  ⟳ Loading queries for %s...", truncateUUID(sessionUUID)))),
 		func() tea.Msg {
 			resp, err := client.GetInvestigationQueries(m.cfg.ProjectID, sessionUUID)
 			if err != nil {
@@ -1840,32 +1970,32 @@ func (m model) cmdQueries(args []string) (tea.Model, tea.Cmd) {
 
 func (m model) handleQueriesResult(msg queriesResultMsg) (tea.Model, tea.Cmd) {
 	if msg.err != nil {
-		return m, tea.Println(errorMsgStyle.Render(fmt.Sprintf("  ✗ Queries failed: %v", msg.err)))
+		return m, printLine(errorMsgStyle.Render(fmt.Sprintf("  ✗ Queries failed: %v", msg.err)))
 	}
 
 	if len(msg.queries) == 0 {
-		return m, tea.Println(warnMsgStyle.Render("  ! No queries found."))
+		return m, printLine(warnMsgStyle.Render("  ! No queries found."))
 	}
 
 	var cmds []tea.Cmd
-	cmds = append(cmds, tea.Println(""), tea.Println(dimStyle.Render(fmt.Sprintf("  Queries (%d):", len(msg.queries)))), tea.Println(""))
+	cmds = append(cmds, printLine(""), printLine(dimStyle.Render(fmt.Sprintf("  Queries (%d):", len(msg.queries)))), printLine(""))
 
 	for i, q := range msg.queries {
 		statusIcon := "✅"
 		if q.Status == "FAILED" || q.Status == "ERROR" {
 			statusIcon = "❌"
 		}
-		cmds = append(cmds, tea.Println(fmt.Sprintf("  %s Query %d  (%s)", statusIcon, i+1, q.Source)))
+		cmds = append(cmds, printLine(fmt.Sprintf("  %s Query %d  (%s)", statusIcon, i+1, q.Source)))
 		if q.Query != "" {
 			query := q.Query
 			if len(query) > 80 {
 				query = query[:77] + "..."
 			}
-			cmds = append(cmds, tea.Println(dimStyle.Render("    "+query)))
+			cmds = append(cmds, printLine(dimStyle.Render("    "+query)))
 		}
 	}
 
-	cmds = append(cmds, tea.Println(""))
+	cmds = append(cmds, printLine(""))
 	return m, tea.Sequence(cmds...)
 }
 
@@ -1878,17 +2008,17 @@ type discoverResultMsg struct {
 
 func (m model) cmdDiscover() (tea.Model, tea.Cmd) {
 	if m.client == nil {
-		return m, tea.Println(errorMsgStyle.Render("  ✗ Not logged in. Run /login first."))
+		return m, printLine(errorMsgStyle.Render("  ✗ Not logged in. Run /login first."))
 	}
 	if m.cfg.ProjectID == "" {
-		return m, tea.Println(errorMsgStyle.Render("  ✗ No project set. Run /projects first."))
+		return m, printLine(errorMsgStyle.Render("  ✗ No project set. Run /projects first."))
 	}
 
 	client := m.client
 	projectID := m.cfg.ProjectID
 
 	return m, tea.Sequence(
-		tea.Println(statusStyle.Render("  ⟳ Discovering project resources...")),
+		printLine(statusStyle.Render("  ⟳ Discovering project resources...")),
 		func() tea.Msg {
 			resp, err := client.DiscoverProjectResources(projectID, "", "")
 			if err != nil {
@@ -1901,21 +2031,21 @@ func (m model) cmdDiscover() (tea.Model, tea.Cmd) {
 
 func (m model) handleDiscoverResult(msg discoverResultMsg) (tea.Model, tea.Cmd) {
 	if msg.err != nil {
-		return m, tea.Println(errorMsgStyle.Render(fmt.Sprintf("  ✗ Discovery failed: %v", msg.err)))
+		return m, printLine(errorMsgStyle.Render(fmt.Sprintf("  ✗ Discovery failed: %v", msg.err)))
 	}
 
 	if len(msg.resources) == 0 {
-		return m, tea.Println(warnMsgStyle.Render("  ! No resources discovered."))
+		return m, printLine(warnMsgStyle.Render("  ! No resources discovered."))
 	}
 
 	var cmds []tea.Cmd
-	cmds = append(cmds, tea.Println(""), tea.Println(dimStyle.Render(fmt.Sprintf("  Discovered Resources (%d):", len(msg.resources)))), tea.Println(""))
+	cmds = append(cmds, printLine(""), printLine(dimStyle.Render(fmt.Sprintf("  Discovered Resources (%d):", len(msg.resources)))), printLine(""))
 
 	for _, r := range msg.resources {
-		cmds = append(cmds, tea.Println(fmt.Sprintf("  • %-30s %s", r.Name, dimStyle.Render(r.TelemetryType))))
+		cmds = append(cmds, printLine(fmt.Sprintf("  • %-30s %s", r.Name, dimStyle.Render(r.TelemetryType))))
 	}
 
-	cmds = append(cmds, tea.Println(""))
+	cmds = append(cmds, printLine(""))
 	return m, tea.Sequence(cmds...)
 }
 
@@ -1928,7 +2058,7 @@ type sessionReportMsg struct {
 
 func (m model) cmdSessionReport(args []string) (tea.Model, tea.Cmd) {
 	if m.client == nil {
-		return m, tea.Println(errorMsgStyle.Render("  ✗ Not logged in. Run /login first."))
+		return m, printLine(errorMsgStyle.Render("  ✗ Not logged in. Run /login first."))
 	}
 
 	sessionUUID := ""
@@ -1937,14 +2067,14 @@ func (m model) cmdSessionReport(args []string) (tea.Model, tea.Cmd) {
 	} else if m.sessionID != "" {
 		sessionUUID = m.sessionID
 	} else {
-		return m, tea.Println(warnMsgStyle.Render("  ! Usage: /session-report [session-uuid]"))
+		return m, printLine(warnMsgStyle.Render("  ! Usage: /session-report [session-uuid]"))
 	}
 
 	client := m.client
 	projectUUID := m.cfg.ProjectID
 
 	return m, tea.Sequence(
-		tea.Println(statusStyle.Render(fmt.Sprintf("  ⟳ Loading report for %s...", truncateUUID(sessionUUID)))),
+		printLine(statusStyle.Render(fmt.Sprintf("  ⟳ Loading report for %s...", truncateUUID(sessionUUID)))),
 		func() tea.Msg {
 			items, err := client.GetSessionReport(projectUUID, []string{sessionUUID})
 			if err != nil {
@@ -1957,26 +2087,26 @@ func (m model) cmdSessionReport(args []string) (tea.Model, tea.Cmd) {
 
 func (m model) handleSessionReport(msg sessionReportMsg) (tea.Model, tea.Cmd) {
 	if msg.err != nil {
-		return m, tea.Println(errorMsgStyle.Render(fmt.Sprintf("  ✗ Report failed: %v", msg.err)))
+		return m, printLine(errorMsgStyle.Render(fmt.Sprintf("  ✗ Report failed: %v", msg.err)))
 	}
 
 	var cmds []tea.Cmd
-	cmds = append(cmds, tea.Println(""), tea.Println(dimStyle.Render("  Session Report:")))
+	cmds = append(cmds, printLine(""), printLine(dimStyle.Render("  Session Report:")))
 
 	for _, item := range msg.items {
 		if item.Summary != "" {
-			cmds = append(cmds, tea.Println(dimStyle.Render("    Summary: "+item.Summary)))
+			cmds = append(cmds, printLine(dimStyle.Render("    Summary: "+item.Summary)))
 		}
 		if item.TimeSaved > 0 {
-			cmds = append(cmds, tea.Println(fmt.Sprintf("    ⏱  Time saved: %d min", item.TimeSaved/60)))
+			cmds = append(cmds, printLine(fmt.Sprintf("    ⏱  Time saved: %d min", item.TimeSaved/60)))
 		}
 	}
 
 	if len(msg.items) == 0 {
-		cmds = append(cmds, tea.Println(dimStyle.Render("    No report data available.")))
+		cmds = append(cmds, printLine(dimStyle.Render("    No report data available.")))
 	}
 
-	cmds = append(cmds, tea.Println(""))
+	cmds = append(cmds, printLine(""))
 	return m, tea.Sequence(cmds...)
 }
 
@@ -2010,11 +2140,11 @@ func parseAddConnectionArgs(args []string) (name, apiKey string) {
 func (m model) cmdConnectionAddPagerDuty(args []string) (tea.Model, tea.Cmd) {
 	name, apiKey := parseAddConnectionArgs(args)
 	if name == "" || apiKey == "" {
-		return m, tea.Println(warnMsgStyle.Render("  ! Usage: /incidents add pagerduty --name <name> --api-key <key>"))
+		return m, printLine(warnMsgStyle.Render("  ! Usage: /incidents add pagerduty --name <name> --api-key <key>"))
 	}
 	client := m.client
 	return m, tea.Sequence(
-		tea.Println(statusStyle.Render(fmt.Sprintf("  ⟳ Adding PagerDuty connection %q...", name))),
+		printLine(statusStyle.Render(fmt.Sprintf("  ⟳ Adding PagerDuty connection %q...", name))),
 		func() tea.Msg {
 			resp, err := client.AddConnection(&api.AddConnectionRequest{
 				Connection: api.AddConnectionInput{
@@ -2039,11 +2169,11 @@ func (m model) cmdConnectionAddPagerDuty(args []string) (tea.Model, tea.Cmd) {
 func (m model) cmdConnectionAddFirehydrant(args []string) (tea.Model, tea.Cmd) {
 	name, apiKey := parseAddConnectionArgs(args)
 	if name == "" || apiKey == "" {
-		return m, tea.Println(warnMsgStyle.Render("  ! Usage: /incidents add firehydrant --name <name> --api-key <key>"))
+		return m, printLine(warnMsgStyle.Render("  ! Usage: /incidents add firehydrant --name <name> --api-key <key>"))
 	}
 	client := m.client
 	return m, tea.Sequence(
-		tea.Println(statusStyle.Render(fmt.Sprintf("  ⟳ Adding FireHydrant connection %q...", name))),
+		printLine(statusStyle.Render(fmt.Sprintf("  ⟳ Adding FireHydrant connection %q...", name))),
 		func() tea.Msg {
 			resp, err := client.AddConnection(&api.AddConnectionRequest{
 				Connection: api.AddConnectionInput{
@@ -2068,11 +2198,11 @@ func (m model) cmdConnectionAddFirehydrant(args []string) (tea.Model, tea.Cmd) {
 func (m model) cmdConnectionAddIncidentio(args []string) (tea.Model, tea.Cmd) {
 	name, apiKey := parseAddConnectionArgs(args)
 	if name == "" || apiKey == "" {
-		return m, tea.Println(warnMsgStyle.Render("  ! Usage: /incidents add incidentio --name <name> --api-key <key>"))
+		return m, printLine(warnMsgStyle.Render("  ! Usage: /incidents add incidentio --name <name> --api-key <key>"))
 	}
 	client := m.client
 	return m, tea.Sequence(
-		tea.Println(statusStyle.Render(fmt.Sprintf("  ⟳ Adding incident.io connection %q...", name))),
+		printLine(statusStyle.Render(fmt.Sprintf("  ⟳ Adding incident.io connection %q...", name))),
 		func() tea.Msg {
 			resp, err := client.AddConnection(&api.AddConnectionRequest{
 				Connection: api.AddConnectionInput{
@@ -2101,7 +2231,7 @@ type incidentTestResultMsg struct {
 }
 
 // parseIncidentTestArgs extracts --api-key, --routing-key, --file, --run-level from args.
-// Defaults: file = ~/.hawkeye/test_config, run-level = 1.
+// Defaults: file = test_config in the config directory, run-level = 1.
 func parseIncidentTestArgs(args []string) (apiKey, routingKey, filename string, runLevel int) {
 	for i := 0; i < len(args); i++ {
 		switch args[i] {
@@ -2131,8 +2261,8 @@ func parseIncidentTestArgs(args []string) (apiKey, routingKey, filename string,
 		}
 	}
 	if filename == "" {
-		home, _ := os.UserHomeDir()
-		candidate := home + "/.hawkeye/test_config"
+		dir, _ := config.Dir()
+		candidate := filepath.Join(dir, "test_config")
 		if _, statErr := os.Stat(candidate); statErr == nil {
 			filename = candidate
 		}
@@ -2146,7 +2276,7 @@ func parseIncidentTestArgs(args []string) (apiKey, routingKey, filename string,
 func (m model) cmdIncidentsTest(providerType string, args []string) (tea.Model, tea.Cmd) {
 	apiKey, routingKey, filename, runLevel := parseIncidentTestArgs(args)
 	if apiKey == "" && routingKey == "" {
-		return m, tea.Println(warnMsgStyle.Render("  ! --api-key is required (use --routing-key for PagerDuty Events API)"))
+		return m, printLine(warnMsgStyle.Render("  ! --api-key is required (use --routing-key for PagerDuty Events API)"))
 	}
 	creds := incidents.Creds{
 		ApiKey:     apiKey,
@@ -2154,7 +2284,7 @@ func (m model) cmdIncidentsTest(providerType string, args []string) (tea.Model,
 	}
 	input := incidents.IncidentInput{Count: runLevel}
 	return m, tea.Sequence(
-		tea.Println(statusStyle.Render(fmt.Sprintf("  ⟳ Running incident test via %s (run-level %d)...", providerType, runLevel))),
+		printLine(statusStyle.Render(fmt.Sprintf("  ⟳ Running incident test via %s (run-level %d)...", providerType, runLevel))),
 		func() tea.Msg {
 			created, err := incidents.RunTest(providerType, creds, filename, input)
 			return incidentTestResultMsg{providerType: providerType, created: created, err: err}
@@ -2164,47 +2294,47 @@ func (m model) cmdIncidentsTest(providerType string, args []string) (tea.Model,
 
 func (m model) handleIncidentTestResult(msg incidentTestResultMsg) (tea.Model, tea.Cmd) {
 	if msg.err != nil {
-		return m, tea.Println(errorMsgStyle.Render(fmt.Sprintf("  ✗ Incident test failed: %v", msg.err)))
+		return m, printLine(errorMsgStyle.Render(fmt.Sprintf("  ✗ Incident test failed: %v", msg.err)))
 	}
 	cmds := []tea.Cmd{
-		tea.Println(""),
-		tea.Println(dimStyle.Render(fmt.Sprintf("  Created %d incident(s) via %s:", len(msg.created), msg.providerType))),
-		tea.Println(""),
+		printLine(""),
+		printLine(dimStyle.Render(fmt.Sprintf("  Created %d incident(s) via %s:", len(msg.created), msg.providerType))),
+		printLine(""),
 	}
 	for _, inc := range msg.created {
 		cmds = append(cmds,
-			tea.Println(fmt.Sprintf("  %-12s %s", dimStyle.Render("source:"), inc.SourceID)),
-			tea.Println(fmt.Sprintf("  %-12s %s", dimStyle.Render("remote:"), inc.RemoteID)),
-			tea.Println(fmt.Sprintf("  %-12s %s", dimStyle.Render("title:"), inc.Title)),
+			printLine(fmt.Sprintf("  %-12s %s", dimStyle.Render("source:"), inc.SourceID)),
+			printLine(fmt.Sprintf("  %-12s %s", dimStyle.Render("remote:"), inc.RemoteID)),
+			printLine(fmt.Sprintf("  %-12s %s", dimStyle.Render("title:"), inc.Title)),
 		)
 		if inc.URL != "" {
-			cmds = append(cmds, tea.Println(fmt.Sprintf("  %-12s %s", dimStyle.Render("url:"), inc.URL)))
+			cmds = append(cmds, printLine(fmt.Sprintf("  %-12s %s", dimStyle.Render("url:"), inc.URL)))
 		}
-		cmds = append(cmds, tea.Println(""))
+		cmds = append(cmds, printLine(""))
 	}
 	return m, tea.Sequence(cmds...)
 }
 
 func (m model) handleAddConnectionResult(msg addConnectionResultMsg) (tea.Model, tea.Cmd) {
 	if msg.err != nil {
-		return m, tea.Println(errorMsgStyle.Render(fmt.Sprintf("  ✗ Add connection failed: %v", msg.err)))
+		return m, printLine(errorMsgStyle.Render(fmt.Sprintf("  ✗ Add connection failed: %v", msg.err)))
 	}
 	return m, tea.Sequence(
-		tea.Println(""),
-		tea.Println(dimStyle.Render(fmt.Sprintf("  %s connection added:", msg.label))),
-		tea.Println(""),
-		tea.Println(fmt.Sprintf("  %-12s %s", dimStyle.Render("name:"), msg.name)),
-		tea.Println(fmt.Sprintf("  %-12s %s", dimStyle.Render("uuid:"), msg.uuid)),
-		tea.Println(""),
+		printLine(""),
+		printLine(dimStyle.Render(fmt.Sprintf("  %s connection added:", msg.label))),
+		printLine(""),
+		printLine(fmt.Sprintf("  %-12s %s", dimStyle.Render("name:"), msg.name)),
+		printLine(fmt.Sprintf("  %-12s %s", dimStyle.Render("uuid:"), msg.uuid)),
+		printLine(""),
 	)
 }
 
 func (m model) cmdIncidents(args []string) (tea.Model, tea.Cmd) {
 	if m.client == nil {
-		return m, tea.Println(errorMsgStyle.Render("  ✗ Not logged in. Run /login first."))
+		return m, printLine(errorMsgStyle.Render("  ✗ Not logged in. Run /login first."))
 	}
 	if m.cfg.ProjectID == "" {
-		return m, tea.Println(errorMsgStyle.Render("  ✗ No project set. Run /projects first."))
+		return m, printLine(errorMsgStyle.Render("  ✗ No project set. Run /projects first."))
 	}
 
 	pad := func(s string, w int) string {
@@ -2216,13 +2346,13 @@ func (m model) cmdIncidents(args []string) (tea.Model, tea.Cmd) {
 
 	if len(args) == 0 {
 		return m, tea.Sequence(
-			tea.Println(""),
-			tea.Println(dimStyle.Render("  /incidents subcommands:")),
-			tea.Println(""),
-			tea.Println("  "+pad(hintKeyStyle.Render("list"), 30)+dimStyle.Render("Show open incidents (paginated)")),
-			tea.Println("  "+pad(hintKeyStyle.Render("add"), 30)+dimStyle.Render("Add an incident management connection")),
-			tea.Println("  "+pad(hintKeyStyle.Render("test"), 30)+dimStyle.Render("Test incident creation")),
-			tea.Println(""),
+			printLine(""),
+			printLine(dimStyle.Render("  /incidents subcommands:")),
+			printLine(""),
+			printLine("  "+pad(hintKeyStyle.Render("list"), 30)+dimStyle.Render("Triage board of open incidents (live)")),
+			printLine("  "+pad(hintKeyStyle.Render("add"), 30)+dimStyle.Render("Add an incident management connection")),
+			printLine("  "+pad(hintKeyStyle.Render("test"), 30)+dimStyle.Render("Test incident creation")),
+			printLine(""),
 		)
 	}
 
@@ -2233,13 +2363,13 @@ func (m model) cmdIncidents(args []string) (tea.Model, tea.Cmd) {
 	if args[0] == "add" {
 		if len(args) < 2 {
 			return m, tea.Sequence(
-				tea.Println(""),
-				tea.Println(dimStyle.Render("  /incidents add <type>:")),
-				tea.Println(""),
-				tea.Println("  "+pad(hintKeyStyle.Render("add pagerduty"), 30)+dimStyle.Render("Add a PagerDuty connection (--name, --api-key)")),
-				tea.Println("  "+pad(hintKeyStyle.Render("add firehydrant"), 30)+dimStyle.Render("Add a FireHydrant connection (--name, --api-key)")),
-				tea.Println("  "+pad(hintKeyStyle.Render("add incidentio"), 30)+dimStyle.Render("Add an incident.io connection (--name, --api-key)")),
-				tea.Println(""),
+				printLine(""),
+				printLine(dimStyle.Render("  /incidents add <type>:")),
+				printLine(""),
+				printLine("  "+pad(hintKeyStyle.Render("add pagerduty"), 30)+dimStyle.Render("Add a PagerDuty connection (--name, --api-key)")),
+				printLine("  "+pad(hintKeyStyle.Render("add firehydrant"), 30)+dimStyle.Render("Add a FireHydrant connection (--name, --api-key)")),
+				printLine("  "+pad(hintKeyStyle.Render("add incidentio"), 30)+dimStyle.Render("Add an incident.io connection (--name, --api-key)")),
+				printLine(""),
 			)
 		}
 		switch args[1] {
@@ -2250,47 +2380,48 @@ func (m model) cmdIncidents(args []string) (tea.Model, tea.Cmd) {
 		case "incidentio":
 			return m.cmdConnectionAddIncidentio(args[2:])
 		default:
-			return m, tea.Println(warnMsgStyle.Render(fmt.Sprintf("  ! Unknown type %q. Types: pagerduty, firehydrant, incidentio", args[1])))
+			return m, printLine(warnMsgStyle.Render(fmt.Sprintf("  ! Unknown type %q. Types: pagerduty, firehydrant, incidentio", args[1])))
 		}
 	}
 
 	if args[0] == "test" {
 		if len(args) < 2 {
 			return m, tea.Sequence(
-				tea.Println(""),
-				tea.Println(dimStyle.Render("  /incidents test <type>:")),
-				tea.Println(""),
-				tea.Println("  "+pad(hintKeyStyle.Render("test pagerduty"), 30)+dimStyle.Render("Test PagerDuty incidents (--api-key or --routing-key; --file, --run-level optional)")),
-				tea.Println("  "+pad(hintKeyStyle.Render("test firehydrant"), 30)+dimStyle.Render("Test FireHydrant incidents (--api-key; --file, --run-level optional)")),
-				tea.Println("  "+pad(hintKeyStyle.Render("test incidentio"), 30)+dimStyle.Render("Test incident.io incidents (--api-key; --file, --run-level optional)")),
-				tea.Println(""),
+				printLine(""),
+				printLine(dimStyle.Render("  /incidents test <type>:")),
+				printLine(""),
+				printLine("  "+pad(hintKeyStyle.Render("test pagerduty"), 30)+dimStyle.Render("Test PagerDuty incidents (--api-key or --routing-key; --file, --run-level optional)")),
+				printLine("  "+pad(hintKeyStyle.Render("test firehydrant"), 30)+dimStyle.Render("Test FireHydrant incidents (--api-key; --file, --run-level optional)")),
+				printLine("  "+pad(hintKeyStyle.Render("test incidentio"), 30)+dimStyle.Render("Test incident.io incidents (--api-key; --file, --run-level optional)")),
+				printLine(""),
 			)
 		}
 		switch args[1] {
 		case "pagerduty", "firehydrant", "incidentio":
 			return m.cmdIncidentsTest(args[1], args[2:])
 		default:
-			return m, tea.Println(warnMsgStyle.Render(fmt.Sprintf("  ! Unknown type %q. Types: pagerduty, firehydrant, incidentio", args[1])))
+			return m, printLine(warnMsgStyle.Render(fmt.Sprintf("  ! Unknown type %q. Types: pagerduty, firehydrant, incidentio", args[1])))
 		}
 	}
 
-	return m, tea.Println(warnMsgStyle.Render(fmt.Sprintf("  ! Unknown subcommand %q — try /incidents add | test", args[0])))
+	return m, printLine(warnMsgStyle.Render(fmt.Sprintf("  ! Unknown subcommand %q — try /incidents add | test", args[0])))
 }
 
 // ─── /clear ─────────────────────────────────────────────────────────────────
 
 func (m model) cmdClear() (tea.Model, tea.Cmd) {
-	return m, tea.ClearScreen
+	printedOutput.reset()
+	return m, tea.Batch(tea.ClearScreen, func() tea.Msg { return outputMsg{} })
 }
 
 // ─── Investigate ────────────────────────────────────────────────────────────
 
 func (m model) cmdInvestigate(prompt string) (tea.Model, tea.Cmd) {
 	if m.client == nil {
-		return m, tea.Println(errorMsgStyle.Render("  ✗ Not logged in. Type /login to get started."))
+		return m, printLine(errorMsgStyle.Render("  ✗ Not logged in. Type /login to get started."))
 	}
 	if m.cfg == nil || m.cfg.ProjectID == "" {
-		return m, tea.Println(errorMsgStyle.Render("  ✗ No project set. Use: hawkeye set project <uuid>"))
+		return m, printLine(errorMsgStyle.Render("  ✗ No project set. Use: hawkeye set project <uuid>"))
 	}
 
 	m.mode = modeStreaming
@@ -2298,10 +2429,10 @@ func (m model) cmdInvestigate(prompt string) (tea.Model, tea.Cmd) {
 	m.streamPrompt = prompt
 
 	return m, tea.Sequence(
-		tea.Println(""),
-		tea.Println(userPromptStyle.Render("  ❯ "+prompt)),
-		tea.Println(""),
-		tea.Println(statusStyle.Render("  ⟳ Starting investigation...")),
-		startInvestigation(m.client, m.cfg.ProjectID, m.sessionID, prompt),
+		printLine(""),
+		printLine(userPromptStyle.Render("  ❯ "+prompt)),
+		printLine(""),
+		printLine(statusStyle.Render("  ⟳ Starting investigation...")),
+		startInvestigation(m.client, m.cfg.ProjectID, m.sessionID, prompt, !m.cfg.NoAutoName),
 	)
 }
@@ -0,0 +1,169 @@
+package tui
+
+import (
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ─── Tab completion ─────────────────────────────────────────────────────────
+//
+// Tab completes slash commands and, after a command that takes a UUID, the
+// UUIDs seen so far in this run plus any session aliases. Pressing Tab
+// again cycles through the remaining candidates.
+
+// UUID kinds cached for completion.
+const (
+	uuidSession    = "session"
+	uuidConnection = "connection"
+	uuidProject    = "project"
+)
+
+// maxRecentUUIDs caps the completion cache per kind.
+const maxRecentUUIDs = 50
+
+// uuidArgCommands maps commands whose next argument is a UUID to its kind.
+var uuidArgCommands = map[string]string{
+	"/inspect":               uuidSession,
+	"/summary":               uuidSession,
+	"/score":                 uuidSession,
+	"/link":                  uuidSession,
+	"/session":               uuidSession,
+	"/rerun":                 uuidSession,
+	"/resume":                uuidSession,
+	"/queries":               uuidSession,
+	"/feedback":              uuidSession,
+	"/td":                    uuidSession,
+	"/session-report":        uuidSession,
+	"/connections resources": uuidConnection,
+	"/connections info":      uuidConnection,
+	"/connections remove":    uuidConnection,
+	"/projects info":         uuidProject,
+	"/projects delete":       uuidProject,
+	"/set project":           uuidProject,
+}
+
+// rememberUUIDs moves ids to the front of the completion cache for kind.
+func (m *model) rememberUUIDs(kind string, ids ...string) {
+	if m.recentUUIDs == nil {
+		m.recentUUIDs = map[string][]string{}
+	}
+	list := m.recentUUIDs[kind]
+	for i := len(ids) - 1; i >= 0; i-- {
+		id := ids[i]
+		if id == "" {
+			continue
+		}
+		kept := []string{id}
+		for _, existing := range list {
+			if existing != id {
+				kept = append(kept, existing)
+			}
+		}
+		list = kept
+	}
+	if len(list) > maxRecentUUIDs {
+		list = list[:maxRecentUUIDs]
+	}
+	m.recentUUIDs[kind] = list
+}
+
+// rememberFromMsg records the UUIDs carried by command results.
+func (m *model) rememberFromMsg(msg tea.Msg) {
+	switch msg := msg.(type) {
+	case sessionCreatedMsg:
+		m.rememberUUIDs(uuidSession, msg.sessionID)
+	case streamDoneMsg:
+		m.rememberUUIDs(uuidSession, msg.sessionID)
+	case sessionsLoadedMsg:
+		ids := make([]string, len(msg.sessions))
+		for i, s := range msg.sessions {
+			ids[i] = s.SessionUUID
+		}
+		m.rememberUUIDs(uuidSession, ids...)
+	case inspectResultMsg:
+		if msg.resp != nil && msg.resp.SessionInfo != nil {
+			m.rememberUUIDs(uuidSession, msg.resp.SessionInfo.SessionUUID)
+		}
+	case resumeResultMsg:
+		m.rememberUUIDs(uuidSession, msg.sessionUUID)
+	case projectsLoadedMsg:
+		ids := make([]string, len(msg.projects))
+		for i, p := range msg.projects {
+			ids[i] = p.UUID
+		}
+		m.rememberUUIDs(uuidProject, ids...)
+	case connectionsResultMsg:
+		ids := make([]string, len(msg.connections))
+		for i, c := range msg.connections {
+			ids[i] = c.UUID
+		}
+		m.rememberUUIDs(uuidConnection, ids...)
+	}
+}
+
+// completions returns the full input values Tab can produce for value.
+func (m model) completions(value string) []string {
+	if !strings.HasPrefix(value, "/") {
+		return nil
+	}
+
+	// Longest command prefix that takes a UUID argument, e.g.
+	// "/connections resources" before "/connections".
+	var cmds []string
+	for c := range uuidArgCommands {
+		cmds = append(cmds, c)
+	}
+	sort.Slice(cmds, func(i, j int) bool { return len(cmds[i]) > len(cmds[j]) })
+	lower := strings.ToLower(value)
+	for _, c := range cmds {
+		if !strings.HasPrefix(lower, c+" ") {
+			continue
+		}
+		arg := value[len(c)+1:]
+		if strings.ContainsAny(arg, " \t") {
+			return nil
+		}
+		kind := uuidArgCommands[c]
+		candidates := m.recentUUIDs[kind]
+		if kind == uuidSession && m.cfg != nil {
+			candidates = append(m.cfg.AliasNames(), candidates...)
+		}
+		var out []string
+		for _, id := range candidates {
+			if strings.HasPrefix(id, arg) {
+				out = append(out, value[:len(c)+1]+id)
+			}
+		}
+		return out
+	}
+
+	var out []string
+	for _, c := range matchCommands(value) {
+		out = append(out, c.name+" ")
+	}
+	return out
+}
+
+// nextCompletion advances tab completion. The first Tab completes to the
+// candidate highlighted in the command menu (the first, by default); each
+// further Tab, while the input still holds the last completion, moves on
+// to the next candidate.
+func (m *model) nextCompletion() (string, bool) {
+	value := m.input.Value()
+	if len(m.tabCands) > 0 && value == m.tabLast {
+		m.tabIdx = (m.tabIdx + 1) % len(m.tabCands)
+	} else {
+		m.tabCands = m.completions(value)
+		if len(m.tabCands) == 0 {
+			return "", false
+		}
+		m.tabIdx = 0
+		if m.cmdMenuOpen && m.cmdMenuIdx > 0 && m.cmdMenuIdx < len(m.tabCands) {
+			m.tabIdx = m.cmdMenuIdx
+		}
+	}
+	m.tabLast = m.tabCands[m.tabIdx]
+	return m.tabLast, true
+}
@@ -0,0 +1,102 @@
+package tui
+
+import (
+	"fmt"
+	"testing"
+
+	"hawkeye-cli/internal/api"
+	"hawkeye-cli/internal/service"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestRememberUUIDs(t *testing.T) {
+	m := newTestModel()
+	m.rememberUUIDs(uuidSession, "a", "b")
+	m.rememberUUIDs(uuidSession, "c", "a", "")
+	if got := fmt.Sprint(m.recentUUIDs[uuidSession]); got != "[c a b]" {
+		t.Errorf("sessions = %s, want [c a b]", got)
+	}
+
+	for i := 0; i < maxRecentUUIDs+5; i++ {
+		m.rememberUUIDs(uuidProject, fmt.Sprintf("p%d", i))
+	}
+	if got := m.recentUUIDs[uuidProject]; len(got) != maxRecentUUIDs || got[0] != fmt.Sprintf("p%d", maxRecentUUIDs+4) {
+		t.Errorf("projects len=%d first=%q", len(got), got[0])
+	}
+}
+
+func TestCompletions(t *testing.T) {
+	m := newTestModel()
+	m.rememberFromMsg(sessionsLoadedMsg{sessions: []api.SessionInfo{{SessionUUID: "abc-1"}, {SessionUUID: "abd-2"}}})
+	m.rememberFromMsg(connectionsResultMsg{connections: []service.ConnectionDisplay{{UUID: "conn-9"}}})
+
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"/in", "[/incidents  /inspect  /instructions  /investigate-alert ]"},
+		{"/inspect ", "[/inspect abc-1 /inspect abd-2]"},
+		{"/inspect abd", "[/inspect abd-2]"},
+		{"/inspect abc-1 extra", "[]"},
+		{"/connections resources c", "[/connections resources conn-9]"},
+		{"/set project ", "[]"},
+		{"plain prompt", "[]"},
+	}
+	for _, tt := range tests {
+		if got := fmt.Sprint(m.completions(tt.input)); got != tt.want {
+			t.Errorf("completions(%q) = %s, want %s", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestTabCycles(t *testing.T) {
+	m := newTestModel()
+	m.input.SetValue("/in")
+	tab := tea.KeyMsg{Type: tea.KeyTab}
+
+	want := []string{"/incidents ", "/inspect ", "/instructions ", "/investigate-alert ", "/incidents "}
+	for i, w := range want {
+		result, _ := m.Update(tab)
+		m = result.(model)
+		if got := m.input.Value(); got != w {
+			t.Fatalf("Tab #%d = %q, want %q", i+1, got, w)
+		}
+	}
+
+	m.input.SetValue("/summary ")
+	m.rememberUUIDs(uuidSession, "sess-42")
+	result, _ := m.Update(tab)
+	if got := result.(model).input.Value(); got != "/summary sess-42" {
+		t.Errorf("UUID completion = %q", got)
+	}
+}
+
+func TestResolveSessionAliases(t *testing.T) {
+	m := newTestModel()
+	if err := m.cfg.SetAlias("outage", "sess-7"); err != nil {
+		t.Fatal(err)
+	}
+	m.cfg.SetAlias("other", "sess-8")
+
+	tests := []struct {
+		cmd  string
+		args []string
+		want string
+	}{
+		{"/inspect", []string{"outage"}, "[sess-7]"},
+		{"/inspect", []string{"raw-uuid"}, "[raw-uuid]"},
+		{"/feedback", []string{"outage", "-r", "other"}, "[sess-7 -r other]"},
+		{"/session-report", []string{"outage", "other"}, "[sess-7 sess-8]"},
+		{"/find", []string{"outage"}, "[outage]"},
+	}
+	for _, tt := range tests {
+		if got := fmt.Sprint(m.resolveSessionAliases(tt.cmd, tt.args)); got != tt.want {
+			t.Errorf("resolveSessionAliases(%s %v) = %s, want %s", tt.cmd, tt.args, got, tt.want)
+		}
+	}
+
+	if got := fmt.Sprint(m.completions("/inspect ou")); got != "[/inspect outage]" {
+		t.Errorf("alias completion = %s", got)
+	}
+}
@@ -0,0 +1,315 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"hawkeye-cli/internal/service"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ─── /connections create wizard ─────────────────────────────────────────────
+//
+// The wizard runs in modeConnWizard: pick a type, enter a name and each of
+// the type's fields (secrets masked), create the connection, then optionally
+// add it to the active project and wait for it to sync.
+
+// connSyncTimeout bounds how long the wizard waits for a new connection to sync.
+const connSyncTimeout = 300 // seconds
+
+type connWizardStep int
+
+const (
+	wizPickType connWizardStep = iota
+	wizName
+	wizField
+	wizAddToProject
+)
+
+// connWizard holds the state of an in-progress /connections create.
+type connWizard struct {
+	step     connWizardStep
+	types    []service.ConnectionType
+	typeIdx  int
+	connType service.ConnectionType
+	name     string
+	fieldIdx int
+	values   map[string]string
+	connUUID string // set once created, for the add-to-project step
+}
+
+type connWizardCreatedMsg struct {
+	connUUID string
+	name     string
+	err      error
+}
+
+type connWizardSyncedMsg struct {
+	connUUID  string
+	syncState string
+	added     bool // false if adding to the project failed
+	err       error
+}
+
+// cmdConnectionCreate starts the wizard. An optional type argument skips
+// the type picker.
+func (m model) cmdConnectionCreate(args []string) (tea.Model, tea.Cmd) {
+	if m.client == nil {
+		return m, printLine(errorMsgStyle.Render("  ✗ Not logged in. Run /login first."))
+	}
+	if err := service.CheckAdmin(m.userRole(), "/connections create"); err != nil {
+		return m, printLine(errorMsgStyle.Render("  ✗ " + err.Error()))
+	}
+	m.wiz = connWizard{types: service.GetConnectionTypes(), values: map[string]string{}}
+	m.mode = modeConnWizard
+	m.resetWizardInput("")
+
+	if len(args) > 0 {
+		for _, ct := range m.wiz.types {
+			if ct.Type == strings.ToLower(args[0]) {
+				return m.wizardSelectType(ct)
+			}
+		}
+		m.mode = modeIdle
+		return m, printLine(warnMsgStyle.Render(fmt.Sprintf("  ! Unknown connection type %q. See /connections types.", args[0])))
+	}
+	return m, nil
+}
+
+// handleConnWizardKey handles keys for every wizard step.
+func (m model) handleConnWizardKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if msg.Type == tea.KeyEsc || msg.Type == tea.KeyCtrlC {
+		if m.wiz.step == wizAddToProject {
+			return m.wizardSkipAdd()
+		}
+		m.mode = modeIdle
+		m.wiz = connWizard{}
+		m.resetWizardInput("")
+		return m, printLine(warnMsgStyle.Render("  ! Connection setup cancelled."))
+	}
+
+	if m.wiz.step == wizPickType {
+		switch msg.Type {
+		case tea.KeyUp:
+			m.wiz.typeIdx--
+			if m.wiz.typeIdx < 0 {
+				m.wiz.typeIdx = len(m.wiz.types) - 1
+			}
+		case tea.KeyDown:
+			m.wiz.typeIdx++
+			if m.wiz.typeIdx >= len(m.wiz.types) {
+				m.wiz.typeIdx = 0
+			}
+		case tea.KeyEnter:
+			return m.wizardSelectType(m.wiz.types[m.wiz.typeIdx])
+		}
+		return m, nil
+	}
+
+	if msg.Type != tea.KeyEnter {
+		var cmd tea.Cmd
+		m.loginInput, cmd = m.loginInput.Update(msg)
+		return m, cmd
+	}
+
+	value := strings.TrimSpace(m.loginInput.Value())
+	switch m.wiz.step {
+	case wizName:
+		if value == "" {
+			return m, printLine(warnMsgStyle.Render("  ! A connection name is required."))
+		}
+		m.wiz.name = value
+		return m.wizardNextField(printLine(dimStyle.Render(fmt.Sprintf("  Name: %s", value))))
+	case wizField:
+		f := m.wiz.connType.Fields[m.wiz.fieldIdx]
+		if value == "" && !f.Optional {
+			return m, printLine(warnMsgStyle.Render(fmt.Sprintf("  ! %s is required.", f.Label)))
+		}
+		m.wiz.values[f.Key] = value
+		m.wiz.fieldIdx++
+		shown := value
+		switch {
+		case value == "":
+			shown = "(skipped)"
+		case f.Secret:
+			shown = strings.Repeat("•", 8)
+		}
+		return m.wizardNextField(printLine(dimStyle.Render(fmt.Sprintf("  %s: %s", f.Label, shown))))
+	case wizAddToProject:
+		switch strings.ToLower(value) {
+		case "", "y", "yes":
+			return m.wizardAddToProject()
+		case "n", "no":
+			return m.wizardSkipAdd()
+		}
+		m.loginInput.SetValue("")
+		return m, printLine(warnMsgStyle.Render("  ! Please answer y or n."))
+	}
+	return m, nil
+}
+
+func (m model) wizardSelectType(ct service.ConnectionType) (tea.Model, tea.Cmd) {
+	m.wiz.connType = ct
+	m.wiz.step = wizName
+	m.resetWizardInput(ct.Type + "-prod")
+	return m, tea.Sequence(
+		printLine(""),
+		printLine(dimStyle.Render(fmt.Sprintf("  New %s connection — %s", ct.Type, ct.Description))),
+	)
+}
+
+// wizardNextField prompts for the next field, or creates the connection
+// once every field has been entered.
+func (m model) wizardNextField(echo tea.Cmd) (tea.Model, tea.Cmd) {
+	if m.wiz.fieldIdx < len(m.wiz.connType.Fields) {
+		f := m.wiz.connType.Fields[m.wiz.fieldIdx]
+		m.wiz.step = wizField
+		m.resetWizardInput(f.Key)
+		if f.Secret {
+			m.loginInput.EchoCharacter = '•'
+			m.loginInput.EchoMode = textinput.EchoPassword
+		}
+		return m, echo
+	}
+	return m.wizardCreate(echo)
+}
+
+func (m model) wizardCreate(echo tea.Cmd) (tea.Model, tea.Cmd) {
+	connConfig, err := service.ValidateConnectionConfig(m.wiz.connType, m.wiz.values)
+	m.mode = modeIdle
+	m.resetWizardInput("")
+	if err != nil {
+		m.wiz = connWizard{}
+		return m, tea.Sequence(echo, printLine(errorMsgStyle.Render(fmt.Sprintf("  ✗ %v", err))))
+	}
+
+	client := m.client
+	name, connType := m.wiz.name, m.wiz.connType.Type
+	return m, tea.Sequence(
+		echo,
+		printLine(statusStyle.Render(fmt.Sprintf("  ⟳ Creating %s connection %q...", connType, name))),
+		func() tea.Msg {
+			resp, err := client.CreateConnection(name, connType, connConfig)
+			if err != nil {
+				return connWizardCreatedMsg{name: name, err: err}
+			}
+			connUUID := ""
+			if resp.Spec != nil {
+				connUUID = resp.Spec.UUID
+			}
+			return connWizardCreatedMsg{connUUID: connUUID, name: name}
+		},
+	)
+}
+
+func (m model) handleConnWizardCreated(msg connWizardCreatedMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		m.wiz = connWizard{}
+		return m, printLine(errorMsgStyle.Render(fmt.Sprintf("  ✗ Failed to create connection: %v", msg.err)))
+	}
+	done := printLine(successMsgStyle.Render(fmt.Sprintf("  ✓ Connection %q created (%s)", msg.name, msg.connUUID)))
+	if m.cfg == nil || m.cfg.ProjectID == "" || msg.connUUID == "" {
+		m.wiz = connWizard{}
+		return m, done
+	}
+
+	m.wiz.connUUID = msg.connUUID
+	m.wiz.step = wizAddToProject
+	m.mode = modeConnWizard
+	m.resetWizardInput("Y/n")
+	return m, tea.Sequence(
+		done,
+		printLine(dimStyle.Render(fmt.Sprintf("  Add it to project %s and wait for sync? [Y/n]", projectNameStr(m.cfg)))),
+	)
+}
+
+func (m model) wizardAddToProject() (tea.Model, tea.Cmd) {
+	client := m.client
+	projectID := m.cfg.ProjectID
+	connUUID := m.wiz.connUUID
+	m.mode = modeIdle
+	m.wiz = connWizard{}
+	m.resetWizardInput("")
+	return m, tea.Sequence(
+		printLine(statusStyle.Render("  ⟳ Adding to project and waiting for sync...")),
+		func() tea.Msg {
+			if err := client.AddConnectionToProject(projectID, connUUID); err != nil {
+				return connWizardSyncedMsg{connUUID: connUUID, err: err}
+			}
+			resp, err := client.WaitForConnectionSync(connUUID, connSyncTimeout)
+			state := ""
+			if resp != nil && resp.Spec != nil {
+				state = resp.Spec.SyncState
+			}
+			return connWizardSyncedMsg{connUUID: connUUID, syncState: state, added: true, err: err}
+		},
+	)
+}
+
+func (m model) wizardSkipAdd() (tea.Model, tea.Cmd) {
+	connUUID := m.wiz.connUUID
+	m.mode = modeIdle
+	m.wiz = connWizard{}
+	m.resetWizardInput("")
+	return m, printLine(dimStyle.Render(fmt.Sprintf("  Not added. Use /connections add %s later.", connUUID)))
+}
+
+func (m model) handleConnWizardSynced(msg connWizardSyncedMsg) (tea.Model, tea.Cmd) {
+	if !msg.added {
+		return m, printLine(errorMsgStyle.Render(fmt.Sprintf("  ✗ Failed to add connection to project: %v", msg.err)))
+	}
+	if msg.err != nil {
+		return m, tea.Sequence(
+			printLine(successMsgStyle.Render(fmt.Sprintf("  ✓ Connection %s added to project", truncateUUID(msg.connUUID)))),
+			printLine(warnMsgStyle.Render(fmt.Sprintf("  ! %v", msg.err))),
+		)
+	}
+	return m, printLine(successMsgStyle.Render(fmt.Sprintf("  ✓ Connection %s added to project and synced", truncateUUID(msg.connUUID))))
+}
+
+// resetWizardInput clears the shared single-line input for the next prompt.
+func (m *model) resetWizardInput(placeholder string) {
+	m.loginInput.SetValue("")
+	m.loginInput.Placeholder = placeholder
+	m.loginInput.EchoMode = textinput.EchoNormal
+	m.loginInput.Focus()
+}
+
+// renderConnWizard renders the type picker or the prompt for the current
+// field.
+func (m model) renderConnWizard() string {
+	var lines []string
+	lines = append(lines, "")
+	switch m.wiz.step {
+	case wizPickType:
+		lines = append(lines, "  Select a connection type:")
+		lines = append(lines, "")
+		for i, ct := range m.wiz.types {
+			if i == m.wiz.typeIdx {
+				lines = append(lines, fmt.Sprintf("  %s %s  %s",
+					cmdSelectedNameStyle.Render("▸"),
+					cmdSelectedNameStyle.Render(fmt.Sprintf("%-15s", ct.Type)),
+					dimStyle.Render(ct.Description)))
+			} else {
+				lines = append(lines, fmt.Sprintf("    %-15s  %s", ct.Type, dimStyle.Render(ct.Description)))
+			}
+		}
+		lines = append(lines, "")
+		return strings.Join(lines, "\n")
+	case wizName:
+		lines = append(lines, "  Connection name:")
+	case wizField:
+		f := m.wiz.connType.Fields[m.wiz.fieldIdx]
+		label := fmt.Sprintf("  %s (%d/%d)", f.Label, m.wiz.fieldIdx+1, len(m.wiz.connType.Fields))
+		if f.Optional {
+			label += dimStyle.Render(" optional")
+		}
+		lines = append(lines, label+":")
+	case wizAddToProject:
+		lines = append(lines, "  Add to project and wait for sync?")
+	}
+	lines = append(lines, m.loginInput.View())
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,353 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"hawkeye-cli/internal/api"
+	"hawkeye-cli/internal/service"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ─── /dashboard summary screen ──────────────────────────────────────────────
+//
+// The dashboard runs in modeDashboard and composites the project at a
+// glance: connection sync and training health, open incidents by status,
+// the last few sessions with a one-line summary, and the org's MTTR numbers
+// from the incident report. Each section is loaded independently, so one
+// failing call leaves the rest of the screen intact. It refreshes every
+// dashboardRefresh, or on r.
+
+const (
+	dashboardRecent  = 5
+	dashboardRefresh = time.Minute
+	dashboardSummary = 90 // runes of a recent session's summary
+)
+
+// dashboardSession is a recent session and its short summary.
+type dashboardSession struct {
+	info    api.SessionInfo
+	summary string
+}
+
+// dashboardData is one load of the dashboard. Each section carries its own
+// error.
+type dashboardData struct {
+	conns     []service.ConnectionDisplay
+	connErr   error
+	incidents []api.SessionInfo
+	incErr    error
+	recent    []dashboardSession
+	recentErr error
+	report    service.ReportDisplay
+	reportErr error
+}
+
+// dashboard holds the state of modeDashboard.
+type dashboard struct {
+	data    *dashboardData
+	row     int
+	loading bool
+	gen     int // bumped per load, so stale refresh ticks are dropped
+	updated time.Time
+}
+
+type dashboardLoadedMsg struct{ data *dashboardData }
+
+type dashboardTickMsg struct{ gen int }
+
+// cmdDashboard opens the dashboard.
+func (m model) cmdDashboard() (tea.Model, tea.Cmd) {
+	if m.client == nil {
+		return m, printLine(errorMsgStyle.Render("  ✗ Not logged in. Run /login first."))
+	}
+	if m.cfg.ProjectID == "" {
+		return m, printLine(errorMsgStyle.Render("  ✗ No project set. Run /projects first."))
+	}
+	m.dash = dashboard{loading: true}
+	m.mode = modeDashboard
+	return m, m.loadDashboard()
+}
+
+func (m model) loadDashboard() tea.Cmd {
+	client := m.client
+	projectID := m.cfg.ProjectID
+	return func() tea.Msg {
+		var d dashboardData
+		var wg sync.WaitGroup
+		wg.Add(4)
+		go func() {
+			defer wg.Done()
+			resp, err := client.ListProjectConnections(projectID)
+			if err != nil {
+				d.connErr = err
+				return
+			}
+			for _, c := range resp.Specs {
+				d.conns = append(d.conns, service.FormatConnection(c))
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			filters := []api.PaginationFilter{
+				{Key: "session_type", Value: "SESSION_TYPE_INCIDENT", Operator: "=="},
+			}
+			resp, err := client.SessionList(projectID, 0, triageLimit, filters)
+			if err != nil {
+				d.incErr = err
+				return
+			}
+			d.incidents = resp.Sessions
+		}()
+		go func() {
+			defer wg.Done()
+			d.recent, d.recentErr = loadRecentSessions(client, projectID)
+		}()
+		go func() {
+			defer wg.Done()
+			resp, err := client.GetIncidentReport()
+			if err != nil {
+				d.reportErr = err
+				return
+			}
+			d.report = service.FormatReport(resp)
+		}()
+		wg.Wait()
+		return dashboardLoadedMsg{data: &d}
+	}
+}
+
+// loadRecentSessions fetches the newest sessions and their summaries. A
+// session whose summary cannot be fetched is shown without one.
+func loadRecentSessions(client api.HawkeyeAPI, projectID string) ([]dashboardSession, error) {
+	resp, err := client.SessionList(projectID, 0, dashboardRecent, nil)
+	if err != nil {
+		return nil, err
+	}
+	sessions := resp.Sessions
+	sortSessionsNewestFirst(sessions)
+	if len(sessions) > dashboardRecent {
+		sessions = sessions[:dashboardRecent]
+	}
+
+	recent := make([]dashboardSession, len(sessions))
+	var wg sync.WaitGroup
+	for i, s := range sessions {
+		recent[i].info = s
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sum, err := client.GetSessionSummary(projectID, s.SessionUUID)
+			if err != nil || sum.SessionSummary == nil {
+				return
+			}
+			text := sum.SessionSummary.Analysis
+			if short := sum.SessionSummary.ShortSummary; short != nil && short.Analysis != "" {
+				text = short.Analysis
+			}
+			recent[i].summary = service.ShortSummary(text, dashboardSummary)
+		}()
+	}
+	wg.Wait()
+	return recent, nil
+}
+
+func (m model) handleDashboardLoaded(msg dashboardLoadedMsg) (tea.Model, tea.Cmd) {
+	if m.mode != modeDashboard {
+		return m, nil
+	}
+	m.dash.loading = false
+	m.dash.data = msg.data
+	m.dash.updated = time.Now()
+	if n := len(msg.data.recent); m.dash.row >= n {
+		m.dash.row = max(n-1, 0)
+	}
+	m.dash.gen++
+	gen := m.dash.gen
+	return m, tea.Tick(dashboardRefresh, func(time.Time) tea.Msg { return dashboardTickMsg{gen: gen} })
+}
+
+func (m model) handleDashboardTick(msg dashboardTickMsg) (tea.Model, tea.Cmd) {
+	if m.mode != modeDashboard || msg.gen != m.dash.gen || m.dash.loading {
+		return m, nil
+	}
+	m.dash.loading = true
+	return m, m.loadDashboard()
+}
+
+func (m model) handleDashboardKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	var recent []dashboardSession
+	if m.dash.data != nil {
+		recent = m.dash.data.recent
+	}
+	switch msg.Type {
+	case tea.KeyEsc, tea.KeyCtrlC:
+		m.mode = modeIdle
+		m.dash = dashboard{}
+		return m, printLine(dimStyle.Render("  Dashboard closed."))
+	case tea.KeyUp:
+		if m.dash.row > 0 {
+			m.dash.row--
+		}
+	case tea.KeyDown:
+		if m.dash.row < len(recent)-1 {
+			m.dash.row++
+		}
+	case tea.KeyEnter:
+		if m.dash.row < len(recent) {
+			uuid := recent[m.dash.row].info.SessionUUID
+			m.sessionID = uuid
+			m.mode = modeIdle
+			m.dash = dashboard{}
+			return m.cmdInspect([]string{uuid})
+		}
+	case tea.KeyRunes:
+		if string(msg.Runes) == "r" && !m.dash.loading {
+			m.dash.loading = true
+			return m, m.loadDashboard()
+		}
+	}
+	return m, nil
+}
+
+// ─── Dashboard renderer ─────────────────────────────────────────────────────
+
+func (m model) renderDashboard() string {
+	var b strings.Builder
+	b.WriteString("\n")
+	header := "  📊 Dashboard"
+	switch {
+	case m.dash.loading && m.dash.data == nil:
+		header += " (loading...)"
+	case m.dash.loading:
+		header += " · refreshing..."
+	case !m.dash.updated.IsZero():
+		header += " · updated " + m.dash.updated.Format("15:04:05")
+	}
+	b.WriteString(dimStyle.Render(header) + "\n\n")
+
+	project := m.cfg.ProjectName
+	if project == "" {
+		project = m.cfg.ProjectID
+	} else {
+		project += dimStyle.Render(" (" + truncateUUID(m.cfg.ProjectID) + ")")
+	}
+	b.WriteString(dashboardRow("Project", project))
+
+	d := m.dash.data
+	if d == nil {
+		return b.String()
+	}
+	b.WriteString(dashboardRow("Connections", dashboardConnections(d)))
+	b.WriteString(dashboardRow("Incidents", dashboardIncidents(d)))
+	b.WriteString(dashboardRow("MTTR", dashboardReport(d)))
+
+	b.WriteString("\n" + dimStyle.Render("  Recent sessions") + "\n")
+	switch {
+	case d.recentErr != nil:
+		b.WriteString("  " + errorMsgStyle.Render(fmt.Sprintf("✗ %v", d.recentErr)) + "\n")
+	case len(d.recent) == 0:
+		b.WriteString(dimStyle.Render("  —") + "\n")
+	}
+	width := max(m.width, 60) - 6
+	for i, s := range d.recent {
+		name := incidentRowStyle.Render("    " + triageName(s.info))
+		if i == m.dash.row {
+			name = incidentRowSelectedStyle.Render("  ▸ " + triageName(s.info))
+		}
+		b.WriteString(name + "  " + formatSessionStatus(s.info.InvestigationStatus) + "\n")
+		if s.summary != "" {
+			b.WriteString(dimStyle.Render("    "+truncateToWidth(s.summary, width)) + "\n")
+		}
+	}
+	return b.String()
+}
+
+// dashboardRow renders one labelled line of the dashboard.
+func dashboardRow(label, value string) string {
+	return "  " + hintKeyStyle.Render(fmt.Sprintf("%-13s", label)) + " " + value + "\n"
+}
+
+// dashboardConnections summarizes connection sync and training health,
+// naming the connections that failed.
+func dashboardConnections(d *dashboardData) string {
+	if d.connErr != nil {
+		return errorMsgStyle.Render(fmt.Sprintf("✗ %v", d.connErr))
+	}
+	if len(d.conns) == 0 {
+		return dimStyle.Render("none — /connections create to add one")
+	}
+	var ready, pending int
+	var failed []string
+	for _, c := range d.conns {
+		synced, trained := service.SyncProgress(c.SyncState), service.TrainingProgress(c.TrainingState)
+		switch {
+		case synced == service.ProgressFailed || trained == service.ProgressFailed:
+			failed = append(failed, c.Name)
+		case synced == service.ProgressPending || trained == service.ProgressPending:
+			pending++
+		default:
+			ready++
+		}
+	}
+	parts := []string{
+		fmt.Sprintf("%d total", len(d.conns)),
+		successMsgStyle.Render(fmt.Sprintf("%d ready", ready)),
+	}
+	if pending > 0 {
+		parts = append(parts, statusStyle.Render(fmt.Sprintf("%d syncing/training", pending)))
+	}
+	if len(failed) > 0 {
+		parts = append(parts, errorMsgStyle.Render(fmt.Sprintf("%d failed: %s", len(failed), strings.Join(failed, ", "))))
+	}
+	return strings.Join(parts, dimStyle.Render(" · "))
+}
+
+// dashboardIncidents counts the open incidents by the triage board's
+// columns.
+func dashboardIncidents(d *dashboardData) string {
+	if d.incErr != nil {
+		return errorMsgStyle.Render(fmt.Sprintf("✗ %v", d.incErr))
+	}
+	counts := dashboardIncidentCounts(d.incidents)
+	parts := []string{fmt.Sprintf("%d open", len(d.incidents))}
+	for i, c := range triageColumns {
+		parts = append(parts, fmt.Sprintf("%s %d", strings.ToLower(c.title), counts[i]))
+	}
+	return strings.Join(parts, dimStyle.Render(" · "))
+}
+
+// dashboardIncidentCounts buckets incidents as triageColumnsFor does,
+// without snoozing.
+func dashboardIncidentCounts(sessions []api.SessionInfo) []int {
+	counts := make([]int, len(triageColumns))
+	for _, s := range sessions {
+		idx := 0
+		for i, c := range triageColumns {
+			for _, status := range c.statuses {
+				if s.InvestigationStatus == status {
+					idx = i
+				}
+			}
+		}
+		counts[idx]++
+	}
+	return counts
+}
+
+// dashboardReport shows the org-level MTTR numbers.
+func dashboardReport(d *dashboardData) string {
+	if d.reportErr != nil {
+		return errorMsgStyle.Render(fmt.Sprintf("✗ %v", d.reportErr))
+	}
+	r := d.report
+	s := fmt.Sprintf("avg %s · %d incidents · %d investigations · %s saved · %s noise reduction",
+		r.AvgMTTR, r.TotalIncidents, r.TotalInvestigations, r.TotalTimeSavedHours, r.NoiseReduction)
+	if r.Period != "" {
+		s += dimStyle.Render(" (" + r.Period + ")")
+	}
+	return s
+}
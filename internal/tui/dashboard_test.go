@@ -0,0 +1,107 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"hawkeye-cli/internal/api"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func dashboardTestModel(t *testing.T, client *mockAPI) model {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("SNAP_USER_COMMON", "")
+	m := newTestModel()
+	m.client = client
+	result, cmd := m.cmdDashboard()
+	m = result.(model)
+	if m.mode != modeDashboard || cmd == nil {
+		t.Fatalf("mode = %v, cmd = %v; want the dashboard loading", m.mode, cmd)
+	}
+	result, _ = m.Update(cmd())
+	return result.(model)
+}
+
+func TestDashboardRender(t *testing.T) {
+	m := dashboardTestModel(t, &mockAPI{
+		sessions: []api.SessionInfo{
+			{SessionUUID: "s-1", Name: "Disk full", InvestigationStatus: "INVESTIGATION_STATUS_NOT_STARTED", CreateTime: "2026-01-02T00:00:00Z"},
+			{SessionUUID: "s-2", Name: "API 5xx", InvestigationStatus: "INVESTIGATION_STATUS_INVESTIGATED", CreateTime: "2026-01-03T00:00:00Z"},
+		},
+		projConns: []api.ConnectionSpec{
+			{Name: "prod-aws", SyncState: "SYNC_STATE_SYNCED", TrainingState: "TRAINING_STATE_TRAINED"},
+			{Name: "datadog", SyncState: "SYNC_STATE_FAILED"},
+		},
+		summary: &api.GetSessionSummaryResponse{SessionSummary: &api.SessionSummary{
+			ShortSummary: &api.ShortSessionSummary{Analysis: "**Root cause:** the log volume filled up"},
+		}},
+		report: &api.IncidentReportResponse{AvgMTTR: 12.5, TotalIncidents: 7},
+	})
+
+	view := m.renderDashboard()
+	for _, s := range []string{
+		"2 total", "1 ready", "1 failed: datadog",
+		"2 open", "not started 1", "investigated 1",
+		"avg 12.5 min", "7 incidents",
+		"Disk full", "Root cause: the log volume filled up",
+	} {
+		if !strings.Contains(view, s) {
+			t.Errorf("dashboard missing %q:\n%s", s, view)
+		}
+	}
+	// Newest first.
+	if strings.Index(view, "API 5xx") > strings.Index(view, "Disk full") {
+		t.Errorf("recent sessions not newest first:\n%s", view)
+	}
+}
+
+func TestDashboardPartialFailure(t *testing.T) {
+	m := dashboardTestModel(t, &mockAPI{err: fmt.Errorf("boom")})
+	if m.mode != modeDashboard {
+		t.Fatalf("mode = %v, want the dashboard to stay open", m.mode)
+	}
+	if view := m.renderDashboard(); strings.Count(view, "boom") != 4 {
+		t.Errorf("want each section to show its error:\n%s", view)
+	}
+}
+
+func TestDashboardKeys(t *testing.T) {
+	m := dashboardTestModel(t, &mockAPI{sessions: []api.SessionInfo{
+		{SessionUUID: "s-old", Name: "Old", CreateTime: "2026-01-01T00:00:00Z"},
+		{SessionUUID: "s-new", Name: "New", CreateTime: "2026-01-02T00:00:00Z"},
+	}})
+
+	result, _ := m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	m = result.(model)
+	if m.dash.row != 1 {
+		t.Fatalf("row = %d, want 1", m.dash.row)
+	}
+	result, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
+	if got := result.(model); !got.dash.loading {
+		t.Error("r should start a refresh")
+	}
+	result, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = result.(model)
+	if m.mode == modeDashboard || m.sessionID != "s-old" {
+		t.Errorf("Enter: mode = %v, session = %q; want s-old inspected", m.mode, m.sessionID)
+	}
+
+	m = dashboardTestModel(t, &mockAPI{})
+	result, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if got := result.(model); got.mode != modeIdle {
+		t.Errorf("Esc: mode = %v, want idle", got.mode)
+	}
+}
+
+func TestDashboardNeedsProject(t *testing.T) {
+	m := newTestModel()
+	m.client = &mockAPI{}
+	m.cfg.ProjectID = ""
+	result, _ := m.cmdDashboard()
+	if result.(model).mode == modeDashboard {
+		t.Error("dashboard opened without a project")
+	}
+}
@@ -0,0 +1,247 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"hawkeye-cli/internal/display"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ─── Chain-of-thought folding ───────────────────────────────────────────────
+//
+// A long investigation prints dozens of chain-of-thought steps, which bury
+// the answer when every step is shown in full and hide the reasoning when
+// they are dropped. Each step is recorded in the output log as a fold: its
+// one-line header plus the body lines below it. Collapsed (the default),
+// only headers are shown while a stream runs; the bodies are kept and can
+// be expanded in the conversation viewport (PgUp) with Enter or z. The
+// choice is saved in the profile (cot_view) by /cot and by Z there.
+
+// foldPart says how a printed line takes part in folding.
+type foldPart int
+
+const (
+	foldNone   foldPart = iota // not part of a fold
+	foldHeader                 // starts a fold: a chain-of-thought step header
+	foldBody                   // belongs to the fold started right before it
+)
+
+// foldRange is one fold in the output log: lines [header, end), where the
+// header line is always shown and the rest is the body.
+type foldRange struct {
+	header, end int
+}
+
+// bodyLen is the number of lines the fold hides when collapsed.
+func (f foldRange) bodyLen() int {
+	return f.end - f.header - 1
+}
+
+// shiftFolds moves folds up by n dropped lines, discarding folds whose
+// header was dropped.
+func shiftFolds(folds []foldRange, n int) []foldRange {
+	var out []foldRange
+	for _, f := range folds {
+		if f.header-n < 0 {
+			continue
+		}
+		out = append(out, foldRange{header: f.header - n, end: f.end - n})
+	}
+	return out
+}
+
+// printPart is printLine for a foldable line. A hidden line is recorded
+// but not printed inline; the viewport shows it once its fold is opened.
+func printPart(text string, part foldPart, hidden bool) tea.Cmd {
+	return func() tea.Msg {
+		text := display.Apply(text)
+		printedOutput.appendPart(text, part)
+		if hidden && inlineOutput {
+			return nil
+		}
+		return showOutput(text)
+	}
+}
+
+// streamFoldPart classifies a stream output event. inStep tracks whether a
+// chain-of-thought step is being printed: a step header opens one, and any
+// event that is not step content closes it.
+func streamFoldPart(ev OutputEvent, inStep *bool) foldPart {
+	switch ev.Type {
+	case OutputCOTHeader:
+		*inStep = true
+		return foldHeader
+	case OutputCOTExplanation, OutputCOTText, OutputCodeFence, OutputCodeLine, OutputTable, OutputBlank:
+		// Code and tables inside a step belong to it; the same events
+		// after the step (in the answer) do not.
+		if *inStep && (ev.CotID != "" || ev.Type == OutputTable || ev.Type == OutputBlank) {
+			return foldBody
+		}
+	}
+	*inStep = false
+	return foldNone
+}
+
+// cotCollapsed reports whether chain-of-thought steps start collapsed.
+func (m model) cotCollapsed() bool {
+	return m.cfg == nil || m.cfg.CoTView != "expanded"
+}
+
+// ─── Folds in the conversation viewport ─────────────────────────────────────
+
+// foldRows returns the indexes of the lines shown with the given folds
+// open or collapsed.
+func foldRows(lines []string, folds []foldRange, open []bool) []int {
+	rows := make([]int, 0, len(lines))
+	f := 0
+	for i := 0; i < len(lines); i++ {
+		for f < len(folds) && folds[f].end <= i {
+			f++
+		}
+		if f < len(folds) && i > folds[f].header && i < folds[f].end && !open[f] {
+			i = folds[f].end - 1
+			continue
+		}
+		rows = append(rows, i)
+	}
+	return rows
+}
+
+// rowOf returns the viewer row showing log line i, or the row of the
+// nearest line above it when i is folded away.
+func (m model) rowOf(i int) int {
+	row := 0
+	for r, line := range m.scrollRows {
+		if line > i {
+			break
+		}
+		row = r
+	}
+	return row
+}
+
+// foldAt returns the fold whose body holds log line i, or -1.
+func (m model) foldAt(i int) int {
+	for f, fr := range m.scrollFolds {
+		if i > fr.header && i < fr.end {
+			return f
+		}
+	}
+	return -1
+}
+
+// focusedFold is the fold Enter and z act on: the one selected with Tab,
+// else the first whose header is on screen.
+func (m model) focusedFold() int {
+	if m.scrollFold >= 0 && m.scrollFold < len(m.scrollFolds) {
+		return m.scrollFold
+	}
+	top := m.scrollView.YOffset
+	for f, fr := range m.scrollFolds {
+		row := m.rowOf(fr.header)
+		if row >= top && row < top+m.scrollView.Height {
+			return f
+		}
+	}
+	return -1
+}
+
+// toggleFold expands or collapses fold f, keeping its header where it was
+// on screen.
+func (m *model) toggleFold(f int) {
+	if f < 0 || f >= len(m.scrollFolds) || m.scrollFolds[f].bodyLen() == 0 {
+		return
+	}
+	screen := m.rowOf(m.scrollFolds[f].header) - m.scrollView.YOffset
+	m.scrollOpen[f] = !m.scrollOpen[f]
+	m.scrollFold = f
+	m.refreshScrollback()
+	m.scrollView.SetYOffset(m.rowOf(m.scrollFolds[f].header) - screen)
+}
+
+// stepFold selects the next (dir > 0) or previous fold and scrolls to it.
+func (m *model) stepFold(dir int) {
+	if len(m.scrollFolds) == 0 {
+		return
+	}
+	f := m.focusedFold()
+	switch {
+	case f < 0 && dir > 0:
+		f = 0
+	case f < 0:
+		f = len(m.scrollFolds) - 1
+	default:
+		f = (f + dir + len(m.scrollFolds)) % len(m.scrollFolds)
+	}
+	m.scrollFold = f
+	m.refreshScrollback()
+	m.scrollView.SetYOffset(m.rowOf(m.scrollFolds[f].header) - m.scrollView.Height/3)
+}
+
+// setAllFolds expands or collapses every fold and saves the choice as the
+// preferred view.
+func (m *model) setAllFolds(open bool) {
+	for f := range m.scrollOpen {
+		m.scrollOpen[f] = open
+	}
+	m.refreshScrollback()
+	m.scrollStatus = m.saveCoTView(open)
+}
+
+// saveCoTView stores the chain-of-thought view in the profile and returns
+// a short styled status.
+func (m model) saveCoTView(expanded bool) string {
+	view, label := "", "collapsed"
+	if expanded {
+		view, label = "expanded", "expanded"
+	}
+	if m.cfg == nil {
+		return warnMsgStyle.Render("! No profile to save the view in")
+	}
+	m.cfg.CoTView = view
+	if err := m.cfg.Save(); err != nil {
+		return errorMsgStyle.Render(fmt.Sprintf("✗ Failed to save config: %v", err))
+	}
+	return successMsgStyle.Render("✓ Chain of thought " + label + " by default")
+}
+
+// foldMarker is shown after a fold header: ▸ with the hidden line count
+// when collapsed, ▾ when open.
+func foldMarker(fr foldRange, open bool) string {
+	if fr.bodyLen() == 0 {
+		return ""
+	}
+	if open {
+		return dimStyle.Render("  ▾")
+	}
+	if fr.bodyLen() == 1 {
+		return dimStyle.Render("  ▸ 1 more line")
+	}
+	return dimStyle.Render(fmt.Sprintf("  ▸ %d more lines", fr.bodyLen()))
+}
+
+// ─── /cot ───────────────────────────────────────────────────────────────────
+
+func (m model) cmdCoT(args []string) (tea.Model, tea.Cmd) {
+	if len(args) == 0 {
+		view := "collapsed"
+		if !m.cotCollapsed() {
+			view = "expanded"
+		}
+		return m, tea.Sequence(
+			printLine(fmt.Sprintf("  Chain of thought: %s", hintKeyStyle.Render(view))),
+			printLine(dimStyle.Render("    /cot expanded|collapsed to change it; in the scrollback (PgUp) Enter or z folds a step, Z all")),
+		)
+	}
+	var expanded bool
+	switch strings.ToLower(args[0]) {
+	case "expanded", "expand", "full", "on":
+		expanded = true
+	case "collapsed", "collapse", "headers", "off":
+	default:
+		return m, printLine(warnMsgStyle.Render("  ! Usage: /cot [expanded|collapsed]"))
+	}
+	return m, printLine("  " + m.saveCoTView(expanded))
+}
@@ -0,0 +1,174 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"hawkeye-cli/internal/config"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestOutputLogFolds(t *testing.T) {
+	var l outputLog
+	l.append("  ❯ why is checkout slow")
+	l.appendPart("  🔍 Check the pool", foldHeader)
+	l.appendPart("    pool at 50/50", foldBody)
+	l.appendPart("| a |\n| 1 |", foldBody)
+	l.append("  answer")
+	l.appendPart("    stray body", foldBody) // not right after a fold
+
+	lines, folds, _ := l.snapshotFolds()
+	if len(lines) != 7 {
+		t.Fatalf("lines = %q", lines)
+	}
+	if len(folds) != 1 || folds[0] != (foldRange{header: 1, end: 5}) {
+		t.Fatalf("folds = %+v, want [{1 5}]", folds)
+	}
+
+	if got := shiftFolds([]foldRange{{0, 3}, {4, 6}}, 2); fmt.Sprint(got) != "[{2 4}]" {
+		t.Errorf("shiftFolds = %v, want the fold with a dropped header gone", got)
+	}
+}
+
+func TestFoldRows(t *testing.T) {
+	lines := []string{"p", "h1", "b", "b", "h2", "b", "answer"}
+	folds := []foldRange{{1, 4}, {4, 6}}
+	tests := []struct {
+		open []bool
+		want string
+	}{
+		{[]bool{false, false}, "[0 1 4 6]"},
+		{[]bool{true, false}, "[0 1 2 3 4 6]"},
+		{[]bool{true, true}, "[0 1 2 3 4 5 6]"},
+	}
+	for _, tt := range tests {
+		if got := fmt.Sprint(foldRows(lines, folds, tt.open)); got != tt.want {
+			t.Errorf("foldRows(%v) = %s, want %s", tt.open, got, tt.want)
+		}
+	}
+}
+
+func TestStreamFoldPart(t *testing.T) {
+	var inStep bool
+	events := []OutputEvent{
+		{Type: OutputCOTHeader, Text: "Check the pool", CotID: "c1"},
+		{Type: OutputCOTExplanation, Text: "why", CotID: "c1"},
+		{Type: OutputCodeLine, Text: "SELECT 1", CotID: "c1"},
+		{Type: OutputTable, Text: "| a |"},
+		{Type: OutputDivider},
+		{Type: OutputChat, Text: "answer"},
+		{Type: OutputTable, Text: "| b |"},
+	}
+	want := []foldPart{foldHeader, foldBody, foldBody, foldBody, foldNone, foldNone, foldNone}
+	for i, ev := range events {
+		if got := streamFoldPart(ev, &inStep); got != want[i] {
+			t.Errorf("event %d (%v) = %v, want %v", i, ev.Type, got, want[i])
+		}
+	}
+}
+
+func TestStreamCollapsesCoT(t *testing.T) {
+	printedOutput.reset()
+	defer printedOutput.reset()
+
+	m := newTestModel()
+	m.mode = modeStreaming
+	for _, ev := range []OutputEvent{
+		{Type: OutputCOTHeader, Text: "Check the pool", CotID: "c1"},
+		{Type: OutputCOTText, Text: "pool at 50/50", CotID: "c1"},
+		{Type: OutputDivider},
+	} {
+		m.printStreamEvent(ev)()
+	}
+	if m.cotHidden != 1 {
+		t.Errorf("cotHidden = %d, want the step text collapsed", m.cotHidden)
+	}
+	lines, folds, _ := printedOutput.snapshotFolds()
+	if len(lines) != 3 || len(folds) != 1 || folds[0].bodyLen() != 1 {
+		t.Errorf("lines = %q, folds = %+v; want the hidden text kept for scrollback", lines, folds)
+	}
+
+	m.cfg.CoTView = "expanded"
+	m.cotHidden = 0
+	m.printStreamEvent(OutputEvent{Type: OutputCOTHeader, Text: "Check the deploy", CotID: "c2"})
+	m.printStreamEvent(OutputEvent{Type: OutputCOTText, Text: "deploy at 09:00", CotID: "c2"})
+	if m.cotHidden != 0 {
+		t.Errorf("expanded view hid %d events", m.cotHidden)
+	}
+}
+
+func TestScrollbackFolding(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("SNAP_USER_COMMON", "")
+	printedOutput.reset()
+	defer printedOutput.reset()
+
+	printedOutput.append("  ❯ why is checkout slow")
+	printedOutput.appendPart("  🔍 Check the pool", foldHeader)
+	for i := 0; i < 5; i++ {
+		printedOutput.appendPart(fmt.Sprintf("    pool sample %d", i), foldBody)
+	}
+	printedOutput.append("  Root cause: pool exhausted")
+
+	m := newTestModel()
+	result, _ := m.openScrollback("")
+	rm := result.(model)
+	view := rm.scrollView.View()
+	if strings.Contains(view, "pool sample") || !strings.Contains(view, "5 more lines") {
+		t.Fatalf("collapsed view =\n%s", view)
+	}
+
+	result, _ = rm.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	rm = result.(model)
+	if !strings.Contains(rm.scrollView.View(), "pool sample 4") {
+		t.Errorf("Enter did not expand the step:\n%s", rm.scrollView.View())
+	}
+	result, _ = rm.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("z")})
+	rm = result.(model)
+	if strings.Contains(rm.scrollView.View(), "pool sample") {
+		t.Error("z did not collapse the step")
+	}
+
+	// Z expands everything and saves it as the preferred view.
+	result, _ = rm.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("Z")})
+	rm = result.(model)
+	if !rm.scrollOpen[0] || rm.cfg.CoTView != "expanded" {
+		t.Errorf("Z: open = %v, CoTView = %q", rm.scrollOpen, rm.cfg.CoTView)
+	}
+	saved, err := config.Load("")
+	if err != nil || saved.CoTView != "expanded" {
+		t.Errorf("saved CoTView = %q, %v", saved.CoTView, err)
+	}
+
+	// A search match inside a collapsed step opens it.
+	result, _ = rm.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("z")})
+	if rm = result.(model); rm.scrollOpen[0] {
+		t.Fatal("z did not collapse the step again")
+	}
+	result, _ = rm.closeScrollback()
+	result, _ = result.(model).openScrollback("sample 3")
+	rm = result.(model)
+	if !rm.scrollOpen[0] || !strings.Contains(rm.scrollView.View(), "pool sample 3") {
+		t.Errorf("match not revealed:\n%s", rm.scrollView.View())
+	}
+}
+
+func TestCmdCoT(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("SNAP_USER_COMMON", "")
+
+	m := newTestModel()
+	if !m.cotCollapsed() {
+		t.Fatal("chain of thought should start collapsed")
+	}
+	result, _ := m.dispatchInput("/cot expanded")
+	if rm := result.(model); rm.cotCollapsed() {
+		t.Error("/cot expanded did not switch the view")
+	}
+	result, _ = m.dispatchInput("/cot collapsed")
+	if rm := result.(model); !rm.cotCollapsed() || rm.cfg.CoTView != "" {
+		t.Errorf("/cot collapsed: CoTView = %q", rm.cfg.CoTView)
+	}
+}
@@ -0,0 +1,110 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ─── Reverse history search (Ctrl+R) ────────────────────────────────────────
+//
+// Works like readline's reverse-i-search: typing narrows to the most recent
+// prompt containing the query, Ctrl+R steps to older matches, Enter runs the
+// match and Tab puts it in the input for editing.
+
+// searchHistory returns the index of the newest entry before `before` that
+// contains query (case-insensitive), or -1.
+func searchHistory(history []string, query string, before int) int {
+	query = strings.ToLower(query)
+	if before > len(history) {
+		before = len(history)
+	}
+	for i := before - 1; i >= 0; i-- {
+		if strings.Contains(strings.ToLower(history[i]), query) {
+			return i
+		}
+	}
+	return -1
+}
+
+func (m model) startHistorySearch() (tea.Model, tea.Cmd) {
+	m.mode = modeHistorySearch
+	m.cmdMenuOpen = false
+	m.histQuery = ""
+	m.histMatch = -1
+	m.histSaved = m.input.Value()
+	return m, nil
+}
+
+// handleHistorySearchKey handles keys while reverse search is active.
+func (m model) handleHistorySearchKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEsc, tea.KeyCtrlC, tea.KeyCtrlG:
+		m.mode = modeIdle
+		m.input.SetValue(m.histSaved)
+		return m, nil
+
+	case tea.KeyCtrlR:
+		before := len(m.history)
+		if m.histMatch >= 0 {
+			before = m.histMatch
+		}
+		if i := searchHistory(m.history, m.histQuery, before); i >= 0 {
+			m.histMatch = i
+		}
+		return m, nil
+
+	case tea.KeyBackspace:
+		if r := []rune(m.histQuery); len(r) > 0 {
+			m.histQuery = string(r[:len(r)-1])
+		}
+		m.histMatch = searchHistory(m.history, m.histQuery, len(m.history))
+		return m, nil
+
+	case tea.KeyRunes, tea.KeySpace:
+		m.histQuery += string(msg.Runes)
+		if msg.Type == tea.KeySpace {
+			m.histQuery += " "
+		}
+		m.histMatch = searchHistory(m.history, m.histQuery, len(m.history))
+		return m, nil
+
+	case tea.KeyTab, tea.KeyRight:
+		m.mode = modeIdle
+		m.input.SetValue(m.historyMatchValue())
+		m.input.CursorEnd()
+		return m, nil
+
+	case tea.KeyEnter:
+		value := m.historyMatchValue()
+		m.mode = modeIdle
+		m.input.SetValue(value)
+		if strings.TrimSpace(value) == "" {
+			return m, nil
+		}
+		return m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	}
+	return m, nil
+}
+
+// historyMatchValue is the current match, or the saved input when nothing
+// matched.
+func (m model) historyMatchValue() string {
+	if m.histMatch >= 0 && m.histMatch < len(m.history) {
+		return m.history[m.histMatch]
+	}
+	return m.histSaved
+}
+
+func (m model) renderHistorySearch() string {
+	label := "(reverse-i-search)"
+	if m.histQuery != "" && m.histMatch < 0 {
+		label = "(failed reverse-i-search)"
+	}
+	match := ""
+	if m.histMatch >= 0 && m.histMatch < len(m.history) {
+		match = strings.ReplaceAll(m.history[m.histMatch], "\n", " ")
+	}
+	return dimStyle.Render(label) + fmt.Sprintf("`%s': ", m.histQuery) + match
+}
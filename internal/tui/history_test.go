@@ -0,0 +1,65 @@
+package tui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestSearchHistory(t *testing.T) {
+	history := []string{"why is checkout slow", "/sessions", "Checkout errors since deploy", "/help"}
+	tests := []struct {
+		query  string
+		before int
+		want   int
+	}{
+		{"checkout", 4, 2},
+		{"checkout", 2, 0},
+		{"checkout", 0, -1},
+		{"/", 99, 3},
+		{"missing", 4, -1},
+		{"", 4, 3},
+	}
+	for _, tt := range tests {
+		if got := searchHistory(history, tt.query, tt.before); got != tt.want {
+			t.Errorf("searchHistory(%q, %d) = %d, want %d", tt.query, tt.before, got, tt.want)
+		}
+	}
+}
+
+func TestHistorySearchKeys(t *testing.T) {
+	m := newTestModel()
+	m.history = []string{"why is checkout slow", "/sessions", "checkout errors"}
+	m.input.SetValue("draft")
+
+	key := func(m model, msg tea.KeyMsg) model {
+		result, _ := m.Update(msg)
+		return result.(model)
+	}
+	m = key(m, tea.KeyMsg{Type: tea.KeyCtrlR})
+	if m.mode != modeHistorySearch {
+		t.Fatalf("mode = %d, want modeHistorySearch", m.mode)
+	}
+	m = key(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("check")})
+	if m.histMatch != 2 {
+		t.Errorf("histMatch = %d, want 2", m.histMatch)
+	}
+	m = key(m, tea.KeyMsg{Type: tea.KeyCtrlR})
+	if m.histMatch != 0 {
+		t.Errorf("after Ctrl+R histMatch = %d, want 0", m.histMatch)
+	}
+	m = key(m, tea.KeyMsg{Type: tea.KeyCtrlR}) // no older match: stays put
+	if m.histMatch != 0 {
+		t.Errorf("after second Ctrl+R histMatch = %d, want 0", m.histMatch)
+	}
+
+	edited := key(m, tea.KeyMsg{Type: tea.KeyTab})
+	if edited.mode != modeIdle || edited.input.Value() != "why is checkout slow" {
+		t.Errorf("Tab: mode=%d input=%q", edited.mode, edited.input.Value())
+	}
+
+	cancelled := key(m, tea.KeyMsg{Type: tea.KeyEsc})
+	if cancelled.mode != modeIdle || cancelled.input.Value() != "draft" {
+		t.Errorf("Esc: mode=%d input=%q", cancelled.mode, cancelled.input.Value())
+	}
+}
@@ -0,0 +1,233 @@
+package tui
+
+import (
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"hawkeye-cli/internal/config"
+	"hawkeye-cli/internal/display"
+	"hawkeye-cli/internal/service"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ─── Background investigations ──────────────────────────────────────────────
+//
+// Ctrl+B while streaming moves the investigation to the background: it keeps
+// running server-side, its events are still read (so nothing is lost) but
+// not printed, and the input is free again. Several can run at once; /jobs
+// lists them and /jobs <n> brings one back to the foreground, printing what
+// it produced in the meantime. A notification is printed when any finishes,
+// and with `set notifications on` a desktop notification is raised too.
+// Ctrl+C asks for confirmation before cancelling.
+
+// desktopNotify shows a desktop notification. Tests replace it.
+var desktopNotify = func(n service.Notification) error {
+	name, args, err := service.NotifyCommand(runtime.GOOS, n, exec.LookPath)
+	if err != nil {
+		return err
+	}
+	return display.RunNotifier(name, args)
+}
+
+// notifyFinished raises a desktop notification for a finished
+// investigation when notifications are on: always for a background job,
+// for the foreground one when it ran for service.NotifyAfter. failure is
+// why it failed, or "" when it completed.
+func (m model) notifyFinished(job *streamJob, background bool, failure string) tea.Cmd {
+	if job == nil || m.cfg == nil || !m.cfg.Notifications {
+		return nil
+	}
+	if !background && time.Since(job.started) < service.NotifyAfter {
+		return nil
+	}
+	n := service.InvestigationNotification(service.SessionTitle(job.prompt), failure, m.cfg.ConsoleSessionURL(job.sessionID))
+	return func() tea.Msg {
+		_ = desktopNotify(n)
+		return nil
+	}
+}
+
+// cancelStream stops following the foreground investigation.
+func (m model) cancelStream() (tea.Model, tea.Cmd) {
+	if activeStream != nil {
+		activeStream.cancel()
+		activeStream = nil
+	}
+	m.mode = modeIdle
+	m.confirmCancel = false
+	m.resetStreamState()
+	return m, printLine(warnMsgStyle.Render("  ! Investigation cancelled."))
+}
+
+// backgroundStream moves the foreground investigation to the background.
+func (m model) backgroundStream() (tea.Model, tea.Cmd) {
+	job := activeStream
+	if job == nil {
+		return m, printLine(warnMsgStyle.Render("  ! The investigation is still starting — try again in a moment."))
+	}
+	// The job picks up where the foreground left off.
+	job.processor = m.processor
+	job.answer = m.streamAnswer
+	m.jobs = append(m.jobs, job)
+	activeStream = nil
+
+	m.mode = modeIdle
+	m.confirmCancel = false
+	m.resetStreamState()
+	// The session is busy until the job finishes, so the next question
+	// starts a new one.
+	m.sessionID = ""
+
+	// No new waitForStream: the read already in flight delivers the job's
+	// next message, which handleJobMsg recognizes as a background one.
+	return m, tea.Sequence(
+		printLine(dimStyle.Render(fmt.Sprintf("  ⇢ Investigation moved to the background (session %s).", job.sessionID))),
+		printLine(dimStyle.Render("    You'll be notified when it finishes; /jobs to switch back. The next question starts a new session.")),
+	)
+}
+
+// foregroundJob brings background job i back to the foreground, printing
+// the output it produced while in the background.
+func (m model) foregroundJob(i int) (tea.Model, tea.Cmd) {
+	job := m.jobs[i]
+	m.jobs = append(m.jobs[:i], m.jobs[i+1:]...)
+
+	activeStream = job
+	m.mode = modeStreaming
+	m.confirmCancel = false
+	m.processor = job.processor
+	m.streamAnswer = job.answer
+	m.streamPrompt = job.prompt
+	m.sessionID = job.sessionID
+
+	cmds := []tea.Cmd{
+		printLine(""),
+		printLine(userPromptStyle.Render("  ❯ " + job.prompt)),
+		printLine(dimStyle.Render(fmt.Sprintf("    Session: %s (from the background)", job.sessionID))),
+		printLine(""),
+	}
+	for _, line := range job.output {
+		cmds = append(cmds, printLine(line))
+	}
+	job.output = nil
+	// As with backgrounding, the read already in flight delivers the job's
+	// next message, now to the foreground.
+	return m, tea.Sequence(cmds...)
+}
+
+// cmdJobs lists the background investigations, or with a number brings
+// that one to the foreground.
+func (m model) cmdJobs(args []string) (tea.Model, tea.Cmd) {
+	if len(m.jobs) == 0 {
+		return m, printLine(dimStyle.Render("  No background investigations. Press Ctrl+B while one is running to move it here."))
+	}
+	if len(args) > 0 {
+		n, err := strconv.Atoi(args[0])
+		if err != nil || n < 1 || n > len(m.jobs) {
+			return m, printLine(errorMsgStyle.Render(fmt.Sprintf("  ✗ No background investigation %q — /jobs lists them (1-%d)", args[0], len(m.jobs))))
+		}
+		return m.foregroundJob(n - 1)
+	}
+
+	lines := []tea.Cmd{
+		printLine(""),
+		printLine(dimStyle.Render(fmt.Sprintf("  Background investigations (%d):", len(m.jobs)))),
+		printLine(""),
+	}
+	for i, j := range m.jobs {
+		elapsed := time.Since(j.started).Round(time.Second)
+		lines = append(lines,
+			printLine(fmt.Sprintf("  %s %s  %s", hintKeyStyle.Render(strconv.Itoa(i+1)), service.SessionTitle(j.prompt), dimStyle.Render(elapsed.String()))),
+			printLine(dimStyle.Render(fmt.Sprintf("    %s · %s", truncateUUID(j.sessionID), j.status()))),
+		)
+	}
+	lines = append(lines,
+		printLine(""),
+		printLine(dimStyle.Render("  /jobs <n> brings one to the foreground.")),
+		printLine(""),
+	)
+	return m, tea.Sequence(lines...)
+}
+
+// findJob returns the background job with the given id.
+func (m model) findJob(id int) (int, *streamJob) {
+	for i, j := range m.jobs {
+		if j.id == id {
+			return i, j
+		}
+	}
+	return -1, nil
+}
+
+// handleJobMsg processes a stream message from a background job. Messages
+// from jobs that are neither in the foreground nor the background, such as
+// cancelled ones, are dropped.
+func (m *model) handleJobMsg(id int, msg tea.Msg) tea.Cmd {
+	i, job := m.findJob(id)
+	if job == nil {
+		return nil
+	}
+
+	switch msg := msg.(type) {
+	case streamChunkMsg:
+		job.record(job.processor.Process(msg))
+		return waitForStream(job)
+
+	case streamDoneMsg:
+		m.jobs = append(m.jobs[:i], m.jobs[i+1:]...)
+		job.record(job.processor.Flush())
+		if job.prompt != "" && job.sessionID != "" {
+			_ = config.SetHistorySession(m.profile, job.prompt, job.sessionID)
+		}
+		lines := []tea.Cmd{
+			printLine(""),
+			printLine(successMsgStyle.Render("  ✓ Background investigation finished: " + service.SessionTitle(job.prompt))),
+		}
+		if answer := strings.TrimSpace(job.answer); answer != "" {
+			m.lastAnswer = answer
+			lines = append(lines, printLine(dimStyle.Render("    "+service.ShortSummary(answer, 100))))
+		}
+		lines = append(lines,
+			printLine(dimStyle.Render(fmt.Sprintf("    Session: %s — /inspect %s for the full answer", job.sessionID, job.sessionID))),
+			printLine(""),
+		)
+		return tea.Batch(tea.Sequence(lines...), m.notifyFinished(job, true, ""))
+
+	case streamErrMsg:
+		m.jobs = append(m.jobs[:i], m.jobs[i+1:]...)
+		return tea.Batch(
+			printLine(errorMsgStyle.Render(fmt.Sprintf("  ✗ Background investigation failed (session %s): %v", job.sessionID, msg.err))),
+			m.notifyFinished(job, true, msg.err.Error()),
+		)
+	}
+	return nil
+}
+
+// record keeps a background job's output events for when it returns to
+// the foreground, and its answer text.
+func (j *streamJob) record(events []OutputEvent) {
+	for _, ev := range events {
+		if ev.Type == OutputProgress {
+			continue
+		}
+		if ev.Type == OutputChat {
+			j.answer += ev.Text + "\n"
+		}
+		j.output = append(j.output, renderOutputEvent(ev))
+	}
+}
+
+// renderJobsIndicator shows the background investigations above the input.
+func (m model) renderJobsIndicator() string {
+	if len(m.jobs) > 1 {
+		return statusStyle.Render(fmt.Sprintf("  ◷ %d investigations in the background · /jobs", len(m.jobs)))
+	}
+	j := m.jobs[0]
+	elapsed := time.Since(j.started).Round(time.Second)
+	return statusStyle.Render(fmt.Sprintf("  ◷ Background: %s · %s", j.status(), elapsed))
+}
@@ -0,0 +1,157 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"hawkeye-cli/internal/service"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestCtrlCConfirmsCancel(t *testing.T) {
+	m := newTestModel()
+	m.mode = modeStreaming
+
+	result, _ := m.Update(tea.KeyMsg{Type: tea.KeyCtrlC})
+	rm := result.(model)
+	if rm.mode != modeStreaming || !rm.confirmCancel {
+		t.Fatalf("first Ctrl+C: mode = %d, confirm = %v; want still streaming, asking", rm.mode, rm.confirmCancel)
+	}
+
+	result, _ = rm.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")})
+	rm = result.(model)
+	if rm.mode != modeStreaming || rm.confirmCancel {
+		t.Fatalf("other key: mode = %d, confirm = %v; want streaming, not asking", rm.mode, rm.confirmCancel)
+	}
+
+	result, _ = rm.Update(tea.KeyMsg{Type: tea.KeyCtrlC})
+	result, _ = result.(model).Update(tea.KeyMsg{Type: tea.KeyCtrlC})
+	rm = result.(model)
+	if rm.mode != modeIdle {
+		t.Errorf("second Ctrl+C: mode = %d, want modeIdle", rm.mode)
+	}
+}
+
+func TestCtrlBBackgroundsStream(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("SNAP_USER_COMMON", "")
+	defer func() { activeStream = nil }()
+
+	m := newTestModel()
+	m.mode = modeStreaming
+	m.sessionID = "s1"
+	m.streamPrompt = "Why is checkout slow?"
+	wait := beginStream(m.client, "proj-1", "s1", m.streamPrompt)
+
+	result, _ := m.Update(tea.KeyMsg{Type: tea.KeyCtrlB})
+	rm := result.(model)
+	if rm.mode != modeIdle || len(rm.jobs) != 1 || activeStream != nil {
+		t.Fatalf("after Ctrl+B: mode = %d, jobs = %d; want idle with one job", rm.mode, len(rm.jobs))
+	}
+	if rm.sessionID != "" {
+		t.Errorf("sessionID = %q, want a new session for the next question", rm.sessionID)
+	}
+	if rm.View() == "" {
+		t.Error("View() is empty")
+	}
+
+	// The read in flight delivers the job's events, which are followed in
+	// the background until the stream ends.
+	msg := wait()
+	for i := 0; i < 10 && len(rm.jobs) > 0; i++ {
+		result, cmd := rm.Update(msg)
+		rm = result.(model)
+		if cmd == nil {
+			break
+		}
+		if len(rm.jobs) > 0 {
+			msg = cmd()
+		}
+	}
+	if len(rm.jobs) != 0 {
+		t.Fatalf("jobs = %d after the stream ended, want 0", len(rm.jobs))
+	}
+	if rm.lastAnswer != "test response" {
+		t.Errorf("lastAnswer = %q, want the background answer", rm.lastAnswer)
+	}
+}
+
+func TestJobsSwitchToBackgroundJob(t *testing.T) {
+	defer func() { activeStream = nil }()
+
+	m := newTestModel()
+	for i, prompt := range []string{"Why is checkout slow?", "Why did payments fail?"} {
+		m.jobs = append(m.jobs, &streamJob{
+			id:        100 + i,
+			ch:        make(chan tea.Msg),
+			cancelled: make(chan struct{}),
+			sessionID: fmt.Sprintf("s%d", i),
+			prompt:    prompt,
+			processor: NewStreamProcessor(),
+			output:    []string{"  partial answer"},
+		})
+	}
+	if got := m.renderJobsIndicator(); !strings.Contains(got, "2 investigations") {
+		t.Errorf("indicator = %q", got)
+	}
+
+	result, _ := m.dispatchInput("/jobs")
+	if rm := result.(model); len(rm.jobs) != 2 || rm.mode != modeIdle {
+		t.Fatalf("/jobs changed state: jobs = %d, mode = %d", len(rm.jobs), rm.mode)
+	}
+
+	result, _ = m.dispatchInput("/jobs 3")
+	if rm := result.(model); len(rm.jobs) != 2 {
+		t.Fatalf("/jobs 3 with two jobs should be rejected")
+	}
+
+	result, _ = m.dispatchInput("/jobs 2")
+	rm := result.(model)
+	if rm.mode != modeStreaming || len(rm.jobs) != 1 || activeStreamID() != 101 {
+		t.Fatalf("/jobs 2: mode = %d, jobs = %d, active = %d", rm.mode, len(rm.jobs), activeStreamID())
+	}
+	if rm.streamPrompt != "Why did payments fail?" || rm.jobs[0].id != 100 {
+		t.Errorf("wrong job in the foreground: prompt = %q", rm.streamPrompt)
+	}
+
+	// Events of the job left in the background are still routed to it.
+	result, _ = rm.Update(streamChunkMsg{job: 100, contentType: "CONTENT_TYPE_PROGRESS_STATUS", text: "Querying metrics"})
+	rm = result.(model)
+	if got := rm.jobs[0].status(); got != "Querying metrics" {
+		t.Errorf("background job status = %q, want its latest progress", got)
+	}
+}
+
+func TestNotifyFinished(t *testing.T) {
+	var got []service.Notification
+	orig := desktopNotify
+	desktopNotify = func(n service.Notification) error { got = append(got, n); return nil }
+	defer func() { desktopNotify = orig }()
+
+	m := newTestModel()
+	job := &streamJob{sessionID: "s1", prompt: "Why is checkout slow?", started: time.Now()}
+	if cmd := m.notifyFinished(job, true, ""); cmd != nil {
+		t.Fatal("notified with notifications off")
+	}
+
+	m.cfg.Notifications = true
+	if cmd := m.notifyFinished(job, false, ""); cmd != nil {
+		t.Error("notified for a short foreground investigation")
+	}
+	m.notifyFinished(job, true, "")()
+	job.started = time.Now().Add(-time.Minute)
+	m.notifyFinished(job, false, "stream idle")()
+
+	if len(got) != 2 {
+		t.Fatalf("notifications = %+v, want 2", got)
+	}
+	if got[0].Body != "Why is checkout slow?" || !strings.HasSuffix(got[0].URL, "/console/project/proj-1/session/s1") {
+		t.Errorf("background notification = %+v", got[0])
+	}
+	if got[1].Title != "Hawkeye: investigation failed" || !strings.Contains(got[1].Body, "stream idle") {
+		t.Errorf("foreground failure notification = %+v", got[1])
+	}
+}
@@ -8,11 +8,13 @@ import (
 
 	"hawkeye-cli/internal/api"
 	"hawkeye-cli/internal/config"
+	"hawkeye-cli/internal/display"
 	"hawkeye-cli/internal/service"
 
 	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/bubbles/textarea"
 	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 )
@@ -27,9 +29,15 @@ const (
 	modeLoginURL
 	modeLoginUser
 	modeLoginPass
-	modeIncidentList // interactive incident selection list
+	modeTriageBoard // /incidents list triage board
+	modeDashboard   // /dashboard summary screen
 	modeProjectSelect
 	modeSessionSelect
+	modeScrollback // conversation viewport has the keys (PgUp, /find)
+	modeOrgSelect
+	modeHistorySearch   // Ctrl+R reverse search over history
+	modeConnWizard      // /connections create
+	modeResourceBrowser // /resources two-pane browser
 )
 
 // ─── Slash command registry ─────────────────────────────────────────────────
@@ -39,17 +47,58 @@ type slashCmd struct {
 	desc string
 }
 
-var slashCommands = []slashCmd{
-	{"/clear", "Clear the screen"},
-	{"/config", "Show current configuration"},
-	{"/connections", "Manage data source connections"},
+// slashHandler runs a slash command with the arguments after its name.
+type slashHandler = func(m model, args []string) (tea.Model, tea.Cmd)
+
+// slashRegistry lists the slash commands in /help order. Dispatch, /help
+// and the command menu all read from it.
+func slashRegistry() *service.CommandRegistry[slashHandler] {
+	noArgs := func(run func(model) (tea.Model, tea.Cmd)) slashHandler {
+		return func(m model, _ []string) (tea.Model, tea.Cmd) { return run(m) }
+	}
+	type c = service.Command[slashHandler]
+	return service.NewCommandRegistry(
+		c{Name: "/login", Args: "<url>", Summary: "Login to a Hawkeye server", Run: model.cmdLogin},
+		c{Name: "/projects", Summary: "Select a project (interactive)", Run: model.cmdProjects},
+		c{Name: "/orgs", Summary: "Switch organization (interactive)", Run: noArgs(model.cmdOrgs)},
+		c{Name: "/session", Args: "[uuid]", Summary: "Pick or set active session", Run: model.cmdSetSession},
+		c{Name: "/resume", Args: "[uuid]", Summary: "Continue the last session", Run: model.cmdResume},
+		c{Name: "/inspect", Args: "<uuid>", Summary: "View session details", Run: model.cmdInspect},
+		c{Name: "/summary", Args: "<uuid>", Summary: "Get session summary", Run: model.cmdSummary},
+		c{Name: "/score", Args: "<uuid>", Summary: "Show RCA quality scores", Run: model.cmdScore},
+		c{Name: "/feedback", Aliases: []string{"/td"}, Args: "[uuid]", Summary: "Thumbs down feedback", Run: model.cmdFeedback},
+		c{Name: "/link", Args: "<uuid>", Summary: "Get web UI URL for session (--copy)", Run: model.cmdLink},
+		c{Name: "/open", Args: "<url>", Summary: "Open session from web URL", Run: model.cmdOpen},
+		c{Name: "/report", Summary: "Show incident analytics", Run: noArgs(model.cmdReport)},
+		c{Name: "/dashboard", Summary: "Project dashboard: connections, incidents, MTTR", Run: noArgs(model.cmdDashboard)},
+		c{Name: "/connections", Summary: "Manage data source connections", Run: model.cmdConnections},
+		c{Name: "/resources", Summary: "Browse connections and their resources", Run: noArgs(model.cmdResources)},
+		c{Name: "/incidents", Summary: "Add incident tool connections", Run: model.cmdIncidents},
+		c{Name: "/instructions", Summary: "Manage project instructions", Run: model.cmdInstructions},
+		c{Name: "/investigate-alert", Args: "<id>", Summary: "Investigate an alert", Run: model.cmdInvestigateAlert},
+		c{Name: "/queries", Args: "[uuid]", Summary: "Show investigation queries", Run: model.cmdQueries},
+		c{Name: "/rerun", Args: "[uuid]", Summary: "Rerun an investigation", Run: model.cmdRerun},
+		c{Name: "/discover", Summary: "Discover project resources", Run: noArgs(model.cmdDiscover)},
+		c{Name: "/session-report", Args: "[uuid]", Summary: "Per-session report", Run: model.cmdSessionReport},
+		c{Name: "/prompts", Summary: "Browse investigation prompts", Run: noArgs(model.cmdPrompts)},
+		c{Name: "/set", Args: "project <uuid>", Summary: "Set project or config", Run: model.cmdSet},
+		c{Name: "/config", Summary: "Show current configuration", Run: noArgs(model.cmdConfig)},
+		c{Name: "/jobs", Args: "[n]", Summary: "List background investigations, /jobs <n> to switch", Run: model.cmdJobs},
+		c{Name: "/find", Args: "<text>", Summary: "Search the output scrollback (PgUp opens it)", Run: model.cmdFind},
+		c{Name: "/cot", Args: "[expanded|collapsed]", Summary: "Chain of thought view (fold steps in PgUp with Enter/z)", Run: model.cmdCoT},
+		c{Name: "/clear", Summary: "Clear the screen", Run: noArgs(model.cmdClear)},
+		c{Name: "/help", Aliases: []string{"/h"}, Summary: "Show all commands", Run: noArgs(model.cmdHelp)},
+		c{Name: "/quit", Aliases: []string{"/exit", "/q"}, Summary: "Exit Hawkeye", Run: noArgs(func(m model) (tea.Model, tea.Cmd) { return m, tea.Quit })},
+	)
+}
+
+// slashSubcommands are offered in the command menu once their parent
+// command and a space are typed.
+var slashSubcommands = []slashCmd{
+	{"/connections create", "Create a connection (interactive)"},
 	{"/connections list", "List data source connections"},
 	{"/connections resources", "List resources for a connection"},
-	{"/discover", "Discover project resources"},
-	{"/feedback", "Thumbs down feedback"},
-	{"/help", "Show all commands"},
-	{"/incidents", "Add incident tool connections"},
-	{"/incidents list", "Show open incidents (paginated)"},
+	{"/incidents list", "Triage board of open incidents"},
 	{"/incidents add", "Add an incident management connection"},
 	{"/incidents test", "Test incident creation"},
 	{"/incidents add pagerduty", "Add a PagerDuty connection (--name, --api-key)"},
@@ -58,25 +107,19 @@ var slashCommands = []slashCmd{
 	{"/incidents test pagerduty", "Test PagerDuty incidents (--api-key, --routing-key, --file, --run-level)"},
 	{"/incidents test firehydrant", "Test FireHydrant incidents (--api-key, --file, --run-level)"},
 	{"/incidents test incidentio", "Test incident.io incidents (--api-key, --file, --run-level)"},
-	{"/inspect", "View session details"},
-	{"/instructions", "Manage project instructions"},
-	{"/investigate-alert", "Investigate an alert"},
-	{"/link", "Get web UI URL for session"},
-	{"/login", "Login to a Hawkeye server"},
-	{"/open", "Open session from web URL"},
-	{"/projects", "Select a project (interactive)"},
-	{"/prompts", "Browse investigation prompts"},
-	{"/queries", "Show investigation queries"},
-	{"/quit", "Exit Hawkeye"},
-	{"/report", "Show incident analytics"},
-	{"/rerun", "Rerun an investigation"},
-	{"/score", "Show RCA quality scores"},
-	{"/session", "Pick or set active session"},
-	{"/session-report", "Per-session report"},
-	{"/set", "Set project or config"},
-	{"/summary", "Get session summary"},
 }
 
+// slashCommands is the command menu: every registered command and
+// subcommand, sorted by name.
+var slashCommands = func() []slashCmd {
+	menu := append([]slashCmd(nil), slashSubcommands...)
+	for _, c := range slashRegistry().Commands() {
+		menu = append(menu, slashCmd{c.Name, c.Summary})
+	}
+	sort.Slice(menu, func(i, j int) bool { return menu[i].name < menu[j].name })
+	return menu
+}()
+
 // ─── Model ──────────────────────────────────────────────────────────────────
 
 type model struct {
@@ -98,6 +141,8 @@ type model struct {
 	// Stream processor (manages all buffering, gating, block transitions)
 	processor    *StreamProcessor
 	streamPrompt string // the prompt being streamed
+	inCotStep    bool   // a chain-of-thought step is being printed, see streamFoldPart
+	cotHidden    int    // step events collapsed away in the current stream
 
 	// Login flow state
 	loginURL  string
@@ -107,9 +152,17 @@ type model struct {
 	projectList    []api.ProjectSpec
 	projectListIdx int
 
+	// Organization selection state
+	orgList    []api.OrgSpec
+	orgListIdx int
+
+	// Connection wizard state (modeConnWizard)
+	wiz connWizard
+
 	// Session selection state
 	sessionList    []api.SessionInfo
 	sessionListIdx int
+	summaries      map[string]pickerSummary // session UUID → short summary, see prefetch.go
 
 	// UI state
 	ready        bool
@@ -123,13 +176,50 @@ type model struct {
 	historyIdx   int
 	historySaved string
 
+	// Tab completion: UUIDs seen this run, by kind, and the cycle state
+	recentUUIDs map[string][]string
+	tabCands    []string
+	tabIdx      int
+	tabLast     string
+
+	// Ctrl+R reverse search
+	histQuery string
+	histMatch int // index into history, -1 when nothing matches
+	histSaved string
+
 	resumeSessionID string
 
-	// Incident list state (modeIncidentList)
-	incidentList        []api.SessionInfo
-	incidentListIdx     int
-	incidentListPage    int
-	incidentListHasMore bool
+	// Incident triage board state (modeTriageBoard)
+	board triageBoard
+
+	// Dashboard state (modeDashboard)
+	dash dashboard
+
+	// Resource browser state (modeResourceBrowser)
+	res resourceBrowser
+
+	// Conversation viewport over the output log; modeScrollback gives it
+	// the keys
+	scrollView     viewport.Model
+	scrollLines    []string
+	scrollQuery    string
+	scrollMatches  []int
+	scrollMatchIdx int
+	scrollStatus   string
+	scrollFolds    []foldRange // chain-of-thought steps in scrollLines, see fold.go
+	scrollOpen     []bool      // which of scrollFolds are expanded
+	scrollFold     int         // fold selected with Tab, -1 for none
+	scrollRows     []int       // the scrollLines index shown on each viewer row
+	scrollDropped  int         // output log folds dropped before scrollOpen[0]
+
+	// Answer text of the current stream and of the last finished one (y yanks it)
+	streamAnswer string
+	lastAnswer   string
+
+	// Investigations moved to the background with Ctrl+B, see jobs.go
+	jobs []*streamJob
+	// Ctrl+C was pressed once while streaming; a second press cancels
+	confirmCancel bool
 }
 
 func initialModel(version, profile, resumeSessionID string) model {
@@ -142,6 +232,9 @@ func initialModel(version, profile, resumeSessionID string) model {
 	ta.SetHeight(1) // Start with single line, grows dynamically
 	ta.ShowLineNumbers = false
 	ta.Prompt = "❯ "
+	if display.ASCII() {
+		ta.Prompt = "> "
+	}
 	ta.FocusedStyle.Prompt = promptSymbol
 	ta.FocusedStyle.CursorLine = lipgloss.NewStyle() // No highlight on cursor line
 	ta.FocusedStyle.Base = lipgloss.NewStyle()       // No base styling
@@ -158,6 +251,9 @@ func initialModel(version, profile, resumeSessionID string) model {
 
 	sp := spinner.New()
 	sp.Spinner = spinner.Dot
+	if display.ASCII() {
+		sp.Spinner = spinner.Line
+	}
 	sp.Style = lipgloss.NewStyle().Foreground(colorOrange)
 
 	cfg, _ := config.Load(profile)
@@ -180,15 +276,17 @@ func initialModel(version, profile, resumeSessionID string) model {
 		history:         config.LoadHistory(profile),
 		historyIdx:      -1,
 		resumeSessionID: resumeSessionID,
+		scrollView:      viewport.New(0, 0),
+		scrollFold:      -1,
 	}
 }
 
 // ─── Init ───────────────────────────────────────────────────────────────────
 
 func (m model) Init() tea.Cmd {
-	cmds := []tea.Cmd{
-		textarea.Blink,
-		m.spinner.Tick,
+	cmds := []tea.Cmd{textarea.Blink}
+	if !display.Accessible() {
+		cmds = append(cmds, m.spinner.Tick)
 	}
 	// If we have a project ID but no project name, fetch it in the background
 	if m.client != nil && m.cfg != nil && m.cfg.ProjectID != "" && m.cfg.ProjectName == "" {
@@ -211,18 +309,7 @@ func (m model) Init() tea.Cmd {
 		sessionUUID := m.resumeSessionID
 		m.sessionID = sessionUUID
 		m.resumeSessionID = ""
-		client := m.client
-		projectID := m.cfg.ProjectID
-		cmds = append(cmds,
-			tea.Println(statusStyle.Render(fmt.Sprintf("  ⟳ Resuming session %s...", truncateUUID(sessionUUID)))),
-			func() tea.Msg {
-				resp, err := client.SessionInspect(projectID, sessionUUID)
-				if err != nil {
-					return inspectResultMsg{err: err}
-				}
-				return inspectResultMsg{resp: resp}
-			},
-		)
+		cmds = append(cmds, resumeSession(m.client, m.cfg.ProjectID, sessionUUID))
 	}
 	return tea.Batch(cmds...)
 }
@@ -234,106 +321,135 @@ type projectNameFetchedMsg struct {
 
 // ─── Update ─────────────────────────────────────────────────────────────────
 
+// Update handles msg, then fits the conversation viewport to whatever the
+// rest of the view now takes up.
 func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	result, cmd := m.update(msg)
+	if next, ok := result.(model); ok && !inlineOutput {
+		next.fitConversation()
+		return next, cmd
+	}
+	return result, cmd
+}
+
+func (m model) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmds []tea.Cmd
+	m.rememberFromMsg(msg)
 
 	switch msg := msg.(type) {
 
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
+		if w := display.Width(); w > 0 && w < m.width {
+			m.width = w
+		}
 		m.height = msg.Height
 		m.input.SetWidth(m.width - 6)
 		m.loginInput.Width = m.width - 6
+		if m.mode == modeScrollback {
+			m.scrollView.Width = m.scrollWidth()
+			m.scrollView.Height = m.scrollHeight()
+		}
 
 		if !m.ready {
 			m.ready = true
 			// Print welcome header on first render
-			welcome := renderWelcome(m.version, serverStr(m.cfg), projectNameStr(m.cfg), m.width)
-			cmds = append(cmds, tea.Println(welcome))
+			welcome := renderWelcome(welcomeBanner(m.cfg), m.version, serverStr(m.cfg), projectNameStr(m.cfg), m.width)
+			if welcome != "" {
+				cmds = append(cmds, printLine(welcome))
+			}
 		}
 
+	case outputMsg:
+		m.syncOutput()
+		return m, nil
+
 	case tea.KeyMsg:
-		// ── Incident list navigation ──────────────────────────────────────
-		if m.mode == modeIncidentList {
-			switch msg.Type {
-			case tea.KeyEsc, tea.KeyCtrlC:
-				m.mode = modeIdle
-				return m, tea.Println(dimStyle.Render("  Incident list closed."))
-			case tea.KeyUp:
-				if m.incidentListIdx > 0 {
-					m.incidentListIdx--
-				}
-				return m, nil
-			case tea.KeyDown:
-				if m.incidentListIdx < len(m.incidentList)-1 {
-					m.incidentListIdx++
-				}
-				return m, nil
-			case tea.KeyEnter:
-				selected := m.incidentList[m.incidentListIdx]
-				m.sessionID = selected.SessionUUID
-				m.mode = modeIdle
-				return m.cmdInspect([]string{selected.SessionUUID})
-			case tea.KeyRunes:
-				switch string(msg.Runes) {
-				case "n":
-					if m.incidentListHasMore {
-						return m.cmdOpenIncidentsList([]string{fmt.Sprintf("%d", m.incidentListPage+1)})
-					}
-				case "p":
-					if m.incidentListPage > 1 {
-						return m.cmdOpenIncidentsList([]string{fmt.Sprintf("%d", m.incidentListPage-1)})
-					}
-				}
+		if m.mode == modeScrollback {
+			return m.handleScrollbackKey(msg)
+		}
+		if m.mode == modeOrgSelect {
+			return m.handleOrgSelectKey(msg)
+		}
+		if m.mode == modeHistorySearch {
+			return m.handleHistorySearchKey(msg)
+		}
+		if m.mode == modeConnWizard {
+			return m.handleConnWizardKey(msg)
+		}
+
+		if m.mode == modeTriageBoard {
+			return m.handleTriageKey(msg)
+		}
+		if m.mode == modeDashboard {
+			return m.handleDashboardKey(msg)
+		}
+		if m.mode == modeResourceBrowser {
+			return m.handleResourceBrowserKey(msg)
+		}
+
+		// ── Session picker copy shortcuts ─────────────────────────────────
+		if m.mode == modeSessionSelect && msg.Type == tea.KeyRunes && len(m.sessionList) > 0 {
+			selected := m.sessionList[m.sessionListIdx].SessionUUID
+			switch string(msg.Runes) {
+			case "c":
+				return m, printLine("  " + m.copySessionLink(selected))
+			case "u":
+				return m, printLine("  " + m.copySessionUUID(selected))
 			}
-			return m, nil
+		}
+
+		// Any key but a second Ctrl+C keeps the investigation running.
+		if m.confirmCancel && msg.Type != tea.KeyCtrlC {
+			m.confirmCancel = false
 		}
 
 		switch msg.Type {
 		case tea.KeyCtrlC:
 			if m.mode == modeStreaming {
-				m.mode = modeIdle
-				activeStreamCh = nil
-				m.resetStreamState()
-				cmds = append(cmds, tea.Println(warnMsgStyle.Render("  ! Investigation cancelled.")))
-				return m, tea.Batch(cmds...)
+				if !m.confirmCancel {
+					m.confirmCancel = true
+					return m, nil
+				}
+				return m.cancelStream()
 			}
 			if m.mode == modeSessionSelect {
 				m.mode = modeIdle
 				m.sessionList = nil
 				m.sessionListIdx = 0
-				cmds = append(cmds, tea.Println(warnMsgStyle.Render("  ! Session selection cancelled.")))
+				cmds = append(cmds, printLine(warnMsgStyle.Render("  ! Session selection cancelled.")))
 				return m, tea.Batch(cmds...)
 			}
 			return m, tea.Quit
 
+		case tea.KeyCtrlB:
+			if m.mode == modeStreaming {
+				return m.backgroundStream()
+			}
+
 		case tea.KeyEsc:
 			if m.mode == modeStreaming {
-				m.mode = modeIdle
-				activeStreamCh = nil
-				m.resetStreamState()
-				cmds = append(cmds, tea.Println(warnMsgStyle.Render("  ! Investigation cancelled.")))
-				return m, tea.Batch(cmds...)
+				return m.cancelStream()
 			}
 			if m.mode == modeLoginURL || m.mode == modeLoginUser || m.mode == modeLoginPass {
 				m.mode = modeIdle
 				m.loginInput.SetValue("")
 				m.loginInput.EchoMode = textinput.EchoNormal
-				cmds = append(cmds, tea.Println(warnMsgStyle.Render("  ! Login cancelled.")))
+				cmds = append(cmds, printLine(warnMsgStyle.Render("  ! Login cancelled.")))
 				return m, tea.Batch(cmds...)
 			}
 			if m.mode == modeProjectSelect {
 				m.mode = modeIdle
 				m.projectList = nil
 				m.projectListIdx = 0
-				cmds = append(cmds, tea.Println(warnMsgStyle.Render("  ! Project selection cancelled.")))
+				cmds = append(cmds, printLine(warnMsgStyle.Render("  ! Project selection cancelled.")))
 				return m, tea.Batch(cmds...)
 			}
 			if m.mode == modeSessionSelect {
 				m.mode = modeIdle
 				m.sessionList = nil
 				m.sessionListIdx = 0
-				cmds = append(cmds, tea.Println(warnMsgStyle.Render("  ! Session selection cancelled.")))
+				cmds = append(cmds, printLine(warnMsgStyle.Render("  ! Session selection cancelled.")))
 				return m, tea.Batch(cmds...)
 			}
 			if m.cmdMenuOpen {
@@ -350,7 +466,7 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 						m.sessionListIdx = len(m.sessionList) - 1
 					}
 				}
-				return m, nil
+				return m, m.prefetchSummaries()
 			}
 			if m.mode == modeProjectSelect {
 				if len(m.projectList) > 0 {
@@ -382,7 +498,7 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 						m.sessionListIdx = 0
 					}
 				}
-				return m, nil
+				return m, m.prefetchSummaries()
 			}
 			if m.mode == modeProjectSelect {
 				if len(m.projectList) > 0 {
@@ -407,24 +523,39 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 
 		case tea.KeyTab:
-			if m.mode == modeIdle && m.cmdMenuOpen {
-				matches := matchCommands(m.input.Value())
-				if len(matches) > 0 {
-					idx := m.cmdMenuIdx
-					if idx < 0 || idx >= len(matches) {
-						idx = 0
-					}
-					m.input.SetValue(matches[idx].name + " ")
+			if m.mode == modeIdle {
+				if next, ok := m.nextCompletion(); ok {
+					m.input.SetValue(next)
+					m.input.CursorEnd()
+					m.lastInputVal = next
 					m.cmdMenuOpen = false
 					m.cmdMenuIdx = 0
 				}
 				return m, nil
 			}
 
+		case tea.KeyPgUp, tea.KeyPgDown:
+			if m.mode == modeIdle {
+				result, cmd := m.openScrollback("")
+				if next, ok := result.(model); ok && next.mode == modeScrollback && !inlineOutput {
+					return next.handleScrollbackKey(msg)
+				}
+				return result, cmd
+			}
+			if m.mode == modeStreaming && !inlineOutput {
+				m.scrollView, _ = m.scrollView.Update(msg)
+				return m, nil
+			}
+
 		case tea.KeyShiftTab:
 			// Shift+Tab does nothing special
 			return m, nil
 
+		case tea.KeyCtrlR:
+			if m.mode == modeIdle && len(m.history) > 0 {
+				return m.startHistorySearch()
+			}
+
 		case tea.KeyCtrlJ:
 			// Ctrl+J inserts newline (works in all terminals)
 			if m.mode == modeIdle {
@@ -467,9 +598,9 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					name = "(unnamed)"
 				}
 				return m, tea.Sequence(
-					tea.Println(successMsgStyle.Render(fmt.Sprintf("  ✓ Session set to: %s", name))),
-					tea.Println(dimStyle.Render(fmt.Sprintf("    %s", selected.SessionUUID))),
-					tea.Println(dimStyle.Render("    Follow-up questions will continue in this session.")),
+					printLine(successMsgStyle.Render(fmt.Sprintf("  ✓ Session set to: %s", name))),
+					printLine(dimStyle.Render(fmt.Sprintf("    %s", selected.SessionUUID))),
+					printLine(dimStyle.Render("    Follow-up questions will continue in this session.")),
 				)
 			}
 
@@ -504,7 +635,7 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				if len(m.history) > 1000 {
 					m.history = m.history[len(m.history)-1000:]
 				}
-				_ = config.SaveHistory(m.profile, m.history)
+				_ = config.AppendHistory(m.profile, config.HistoryEntry{Prompt: value, Time: time.Now()})
 			}
 			m.historyIdx = -1
 			m.historySaved = ""
@@ -522,10 +653,10 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case sessionCreatedMsg:
 		m.sessionID = msg.sessionID
 		cmds = append(cmds,
-			tea.Println(successMsgStyle.Render(fmt.Sprintf("  ✓ Session: %s", m.sessionID))),
+			printLine(successMsgStyle.Render(fmt.Sprintf("  ✓ Session: %s", m.sessionID))),
 		)
 		if consoleURL := m.cfg.ConsoleSessionURL(m.sessionID); consoleURL != "" {
-			cmds = append(cmds, tea.Println(dimStyle.Render(fmt.Sprintf("    🔗 %s", consoleURL))))
+			cmds = append(cmds, printLine(dimStyle.Render(fmt.Sprintf("    🔗 %s", consoleURL))))
 		}
 		cmds = append(cmds,
 			beginStream(m.client, m.cfg.ProjectID, m.sessionID, m.streamPrompt),
@@ -533,6 +664,9 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, tea.Batch(cmds...)
 
 	case streamChunkMsg:
+		if msg.job != activeStreamID() {
+			return m, m.handleJobMsg(msg.job, msg)
+		}
 		printCmd := m.handleStreamChunk(msg)
 		if printCmd != nil {
 			cmds = append(cmds, printCmd)
@@ -543,42 +677,69 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// With tea.Batch, waitForStream resolves immediately from the
 		// buffered channel, sending a new streamChunkMsg that races ahead
 		// of queued prints — causing output to stall then dump in bursts.
-		if activeStreamCh != nil {
-			cmds = append(cmds, waitForStream(activeStreamCh))
+		if activeStream != nil {
+			cmds = append(cmds, waitForStream(activeStream))
 		}
 		return m, tea.Sequence(cmds...)
 
 	case streamDoneMsg:
+		if msg.job != activeStreamID() {
+			return m, m.handleJobMsg(msg.job, msg)
+		}
 		m.mode = modeIdle
-		activeStreamCh = nil
+		m.confirmCancel = false
+		finished := activeStream
+		activeStream = nil
 		if msg.sessionID != "" {
 			m.sessionID = msg.sessionID
 		}
+		if m.streamPrompt != "" && m.sessionID != "" {
+			_ = config.SetHistorySession(m.profile, m.streamPrompt, m.sessionID)
+		}
+		if m.sessionID != "" && m.cfg != nil && m.cfg.LastSession != m.sessionID {
+			// Remember it for `hawkeye resume` and /resume.
+			m.cfg.LastSession = m.sessionID
+			_ = m.cfg.Save()
+		}
 		// Flush any remaining buffers via the processor
 		var flushCmds []tea.Cmd
 		for _, ev := range m.processor.Flush() {
-			flushCmds = append(flushCmds, tea.Println(renderOutputEvent(ev)))
+			m.recordAnswer(ev)
+			flushCmds = append(flushCmds, m.printStreamEvent(ev))
 		}
 		flushCmds = append(flushCmds,
-			tea.Println(""),
-			tea.Println(successMsgStyle.Render("  ✓ Investigation complete")),
-			tea.Println(dimStyle.Render(fmt.Sprintf("    Session: %s", m.sessionID))),
-			tea.Println(""),
+			printLine(""),
+			printLine(successMsgStyle.Render("  ✓ Investigation complete")),
+			printLine(dimStyle.Render(fmt.Sprintf("    Session: %s", m.sessionID))),
 		)
+		if m.cotHidden > 0 {
+			flushCmds = append(flushCmds, printLine(dimStyle.Render(
+				"    Chain of thought collapsed to step headers · PgUp then Enter/z expands a step · /cot expanded shows all")))
+		}
+		flushCmds = append(flushCmds, printLine(""))
+		if answer := strings.TrimSpace(m.streamAnswer); answer != "" {
+			m.lastAnswer = answer
+		}
 		m.resetStreamState()
+		cmds = append(cmds, m.notifyFinished(finished, false, ""))
 		return m, tea.Batch(append(cmds, tea.Sequence(flushCmds...))...)
 
 	case streamErrMsg:
+		if msg.job != activeStreamID() {
+			return m, m.handleJobMsg(msg.job, msg)
+		}
 		m.mode = modeIdle
-		activeStreamCh = nil
+		m.confirmCancel = false
+		cmds = append(cmds, m.notifyFinished(activeStream, false, msg.err.Error()))
+		activeStream = nil
 		m.resetStreamState()
 
 		// Check if this is a "project does not exist" error - offer to select a project
 		errStr := msg.err.Error()
 		if strings.Contains(errStr, "does not exist") || strings.Contains(errStr, "not found") {
 			cmds = append(cmds,
-				tea.Println(errorMsgStyle.Render(fmt.Sprintf("  ✗ %v", msg.err))),
-				tea.Println(warnMsgStyle.Render("  ! Loading available projects...")),
+				printLine(errorMsgStyle.Render(fmt.Sprintf("  ✗ %v", msg.err))),
+				printLine(warnMsgStyle.Render("  ! Loading available projects...")),
 			)
 			// Auto-trigger project selection
 			if m.client != nil {
@@ -594,7 +755,7 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, tea.Batch(cmds...)
 		}
 
-		cmds = append(cmds, tea.Println(errorMsgStyle.Render(fmt.Sprintf("  ✗ Stream error: %v", msg.err))))
+		cmds = append(cmds, printLine(errorMsgStyle.Render(fmt.Sprintf("  ✗ Stream error: %v", msg.err))))
 		return m, tea.Batch(cmds...)
 
 	// ── Login result ──────────────────────────────────────────────────
@@ -605,15 +766,27 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case sessionsLoadedMsg:
 		return m.handleSessionsLoaded(msg)
 
+	case sessionSummaryMsg:
+		return m.handleSessionSummary(msg)
+
 	case promptsLoadedMsg:
 		return m.handlePromptsLoaded(msg)
 
 	case projectsLoadedMsg:
 		return m.handleProjectsLoaded(msg)
 
+	case orgsLoadedMsg:
+		return m.handleOrgsLoaded(msg)
+
+	case orgProjectCheckMsg:
+		return m.handleOrgProjectCheck(msg)
+
 	case inspectResultMsg:
 		return m.handleInspectResult(msg)
 
+	case resumeResultMsg:
+		return m.handleResumeResult(msg)
+
 	case summaryResultMsg:
 		return m.handleSummaryResult(msg)
 
@@ -650,6 +823,12 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case connRemoveMsg:
 		return m.handleConnRemove(msg)
 
+	case connWizardCreatedMsg:
+		return m.handleConnWizardCreated(msg)
+
+	case connWizardSyncedMsg:
+		return m.handleConnWizardSynced(msg)
+
 	case instructionsLoadedMsg:
 		return m.handleInstructionsLoaded(msg)
 
@@ -680,8 +859,23 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case incidentTestResultMsg:
 		return m.handleIncidentTestResult(msg)
 
-	case openIncidentsLoadedMsg:
-		return m.handleOpenIncidentsLoaded(msg)
+	case triageLoadedMsg:
+		return m.handleTriageLoaded(msg)
+
+	case triageTickMsg:
+		return m.handleTriageTick(msg)
+
+	case dashboardLoadedMsg:
+		return m.handleDashboardLoaded(msg)
+
+	case dashboardTickMsg:
+		return m.handleDashboardTick(msg)
+
+	case resourceConnsMsg:
+		return m.handleResourceConns(msg)
+
+	case resourceListMsg:
+		return m.handleResourceList(msg)
 
 	case setProjectResultMsg:
 		return m.handleSetProjectResult(msg)
@@ -750,21 +944,47 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 // ─── View ───────────────────────────────────────────────────────────────────
 //
-// Inline mode: View() only shows the input prompt + hints.
-// All output is printed above via tea.Println.
-// During streaming, only the spinner + status is shown (single line).
-// This prevents terminal rendering artifacts where spinner text bleeds
-// into the permanent scrollback output.
+// The conversation (everything printed with printLine) fills the screen
+// above the input prompt + hints, in a viewport that PgUp and /find hand
+// the keys to. During streaming, only the spinner + status is shown below
+// it (single line).
+// With a screen reader the output is printed inline above the view
+// instead (inlineOutput), and View() only shows the prompt + hints.
 
 func (m model) View() string {
+	if display.Accessible() {
+		return display.ToAccessible(m.view())
+	}
+	if display.ASCII() {
+		return display.ToASCII(m.view())
+	}
+	return m.view()
+}
+
+func (m model) view() string {
 	if !m.ready {
 		return ""
 	}
 
 	var s strings.Builder
 
-	if m.mode == modeIncidentList {
-		s.WriteString(m.renderIncidentList())
+	if m.mode == modeScrollback {
+		s.WriteString(m.renderScrollback())
+		s.WriteString("\n")
+		s.WriteString(separatorStyle.Render(strings.Repeat("─", min(m.scrollWidth(), 80))))
+		s.WriteString("\n")
+		s.WriteString(m.renderHints())
+		return s.String()
+	}
+
+	if m.mode == modeTriageBoard || m.mode == modeDashboard || m.mode == modeResourceBrowser {
+		if m.mode == modeDashboard {
+			s.WriteString(m.renderDashboard())
+		} else if m.mode == modeResourceBrowser {
+			s.WriteString(m.renderResourceBrowser())
+		} else {
+			s.WriteString(m.renderTriageBoard())
+		}
 		s.WriteString("\n")
 		sepWidth := min(m.width, 80)
 		if sepWidth < 20 {
@@ -775,21 +995,52 @@ func (m model) View() string {
 		return s.String()
 	}
 
+	if inlineOutput {
+		return m.renderBottom()
+	}
+	return m.scrollView.View() + "\n" + m.renderBottom()
+}
+
+// renderBottom renders what sits below the conversation: the prompt or
+// the current picker, the separator and the hint bar.
+func (m model) renderBottom() string {
+	var s strings.Builder
+
 	if m.mode == modeStreaming {
 		status := "Investigating..."
 		if ps := m.processor.LastStatus(); ps != "" {
 			status = ps
 		}
-		// Add blank lines to prevent spinner from overwriting last printed content
-		s.WriteString("\n\n")
-		s.WriteString(m.spinner.View() + " " + statusStyle.Render(status))
+		if inlineOutput {
+			// Add blank lines to prevent spinner from overwriting last printed content
+			s.WriteString("\n\n")
+		}
+		if m.confirmCancel {
+			s.WriteString(warnMsgStyle.Render("  ! Press Ctrl+C again to cancel the investigation"))
+		} else if display.Accessible() {
+			// Progress is printed as it arrives; a line that keeps
+			// changing here would only be read out again and again.
+			s.WriteString(statusStyle.Render("  Investigating... (Ctrl+C to cancel)"))
+		} else {
+			s.WriteString(m.spinner.View() + " " + statusStyle.Render(status))
+		}
 	} else if m.mode == modeProjectSelect {
 		s.WriteString(m.renderProjectList())
+	} else if m.mode == modeOrgSelect {
+		s.WriteString(m.renderOrgList())
+	} else if m.mode == modeHistorySearch {
+		s.WriteString(m.renderHistorySearch())
+	} else if m.mode == modeConnWizard {
+		s.WriteString(m.renderConnWizard())
 	} else if m.mode == modeSessionSelect {
 		s.WriteString(m.renderSessionList())
 	} else if m.mode == modeLoginURL || m.mode == modeLoginUser || m.mode == modeLoginPass {
 		s.WriteString(m.loginInput.View())
 	} else {
+		if len(m.jobs) > 0 {
+			s.WriteString(m.renderJobsIndicator())
+			s.WriteString("\n")
+		}
 		s.WriteString(m.input.View())
 	}
 	// Ensure we're on a new line after textarea content
@@ -846,20 +1097,7 @@ func (m model) renderSessionList() string {
 	lines = append(lines, fmt.Sprintf("  Select a session (%d):", len(m.sessionList)))
 	lines = append(lines, "")
 
-	maxVisible := m.height - 6
-	if maxVisible < 5 {
-		maxVisible = 5
-	}
-
-	start := 0
-	if m.sessionListIdx >= maxVisible {
-		start = m.sessionListIdx - maxVisible + 1
-	}
-	end := start + maxVisible
-	if end > len(m.sessionList) {
-		end = len(m.sessionList)
-	}
-
+	start, end := m.sessionListWindow()
 	for i := start; i < end; i++ {
 		s := m.sessionList[i]
 		name := s.Name
@@ -881,6 +1119,9 @@ func (m model) renderSessionList() string {
 		} else {
 			lines = append(lines, fmt.Sprintf("    %s  %s  %s", name, status, dimStyle.Render(ts)))
 		}
+		if summary := m.renderPickerSummary(s.SessionUUID); summary != "" {
+			lines = append(lines, summary)
+		}
 	}
 	lines = append(lines, "")
 
@@ -901,14 +1142,11 @@ func formatSessionStatus(s string) string {
 }
 
 func formatSessionTime(ts string) string {
-	t, err := time.Parse(time.RFC3339Nano, ts)
-	if err != nil {
-		t, err = time.Parse(time.RFC3339, ts)
-		if err != nil {
-			return ts
-		}
+	t, ok := display.ParseTime(ts)
+	if !ok {
+		return ts
 	}
-	return t.Local().Format("Jan 02 15:04")
+	return display.InZone(t).Format("Jan 02 15:04")
 }
 
 func sortSessionsNewestFirst(sessions []api.SessionInfo) {
@@ -921,17 +1159,60 @@ func sortSessionsNewestFirst(sessions []api.SessionInfo) {
 
 func (m model) renderHints() string {
 	if m.mode == modeStreaming {
-		return hintBarStyle.Render("  Esc cancel")
+		if m.confirmCancel {
+			return hintBarStyle.Render("  Ctrl+C cancel   any other key keep waiting")
+		}
+		return hintBarStyle.Render("  Ctrl+B background   Esc/Ctrl+C cancel")
 	}
 
 	if m.mode == modeLoginURL || m.mode == modeLoginUser || m.mode == modeLoginPass {
 		return hintBarStyle.Render("  Enter submit   Esc cancel")
 	}
 
-	if m.mode == modeProjectSelect || m.mode == modeSessionSelect {
+	if m.mode == modeSessionSelect {
+		return hintBarStyle.Render("  ↑↓ navigate   Enter select   c copy link   u copy UUID   Esc cancel")
+	}
+
+	if m.mode == modeProjectSelect || m.mode == modeOrgSelect {
 		return hintBarStyle.Render("  ↑↓ navigate   Enter select   Esc cancel")
 	}
 
+	if m.mode == modeConnWizard {
+		if m.wiz.step == wizPickType {
+			return hintBarStyle.Render("  ↑↓ navigate   Enter select   Esc cancel")
+		}
+		return hintBarStyle.Render("  Enter submit   Esc cancel")
+	}
+
+	if m.mode == modeTriageBoard {
+		if m.board.tagging {
+			return hintBarStyle.Render("  Enter tag   Esc cancel")
+		}
+		return hintBarStyle.Render("  ←→↑↓ move   i investigate   s snooze   t tag   o open   Enter inspect   z snoozed   r refresh   Esc close")
+	}
+
+	if m.mode == modeDashboard {
+		return hintBarStyle.Render("  ↑↓ select session   Enter inspect   r refresh   Esc close")
+	}
+
+	if m.mode == modeResourceBrowser {
+		if m.res.filtering {
+			return hintBarStyle.Render("  type to filter   ↑↓ move   Enter keep   Esc clear")
+		}
+		return hintBarStyle.Render("  ↑↓ move   Tab/←→ pane   / filter   1-9 toggle type   a all types   c copy   r reload   Esc close")
+	}
+
+	if m.mode == modeHistorySearch {
+		return hintBarStyle.Render("  Ctrl+R older   Enter run   Tab edit   Esc cancel")
+	}
+
+	if m.mode == modeScrollback {
+		if len(m.scrollFolds) > 0 {
+			return hintBarStyle.Render("  ↑↓ PgUp/PgDn scroll   Tab step   Enter/z fold   Z all   n/N match   Y copy answer   c copy link   Esc close")
+		}
+		return hintBarStyle.Render("  ↑↓ PgUp/PgDn scroll   n/N match   Y copy answer   c copy link   u copy UUID   Esc close")
+	}
+
 	// Show vertical command menu when menu is open
 	if m.cmdMenuOpen {
 		val := m.input.Value()
@@ -946,7 +1227,7 @@ func (m model) renderHints() string {
 		return hintBarStyle.Render("  Enter send   Ctrl+J newline   ? help")
 	}
 
-	return hintBarStyle.Render("  Enter send   Ctrl+J newline   ? help")
+	return hintBarStyle.Render("  Enter send   Ctrl+J newline   Ctrl+R history   ? help")
 }
 
 // renderCommandMenu renders a vertical list of matching commands, Claude Code style.
@@ -1022,6 +1303,18 @@ func matchCommands(prefix string) []slashCmd {
 func (m *model) resetStreamState() {
 	m.processor = NewStreamProcessor()
 	m.streamPrompt = ""
+	m.streamAnswer = ""
+	m.inCotStep = false
+	m.cotHidden = 0
+}
+
+// recordAnswer accumulates chat response lines so the finished answer can
+// be copied from the scrollback viewer.
+func (m *model) recordAnswer(ev OutputEvent) {
+	if ev.Type != OutputChat {
+		return
+	}
+	m.streamAnswer += ev.Text + "\n"
 }
 
 // insertNewline adds a newline to the input and adjusts the textarea height
@@ -1078,19 +1371,19 @@ func (m model) selectProject(p api.ProjectSpec) (tea.Model, tea.Cmd) {
 	m.cfg.ProjectID = p.UUID
 	m.cfg.ProjectName = p.Name
 	if err := m.cfg.Save(); err != nil {
-		return m, tea.Println(errorMsgStyle.Render(fmt.Sprintf("  ✗ Failed to save config: %v", err)))
+		return m, printLine(errorMsgStyle.Render(fmt.Sprintf("  ✗ Failed to save config: %v", err)))
 	}
 	if m.cfg.Server != "" && m.cfg.Token != "" {
 		m.client = api.NewClient(m.cfg)
 	}
 	return m, tea.Sequence(
-		tea.Println(successMsgStyle.Render(fmt.Sprintf("  ✓ Project set to: %s", p.Name))),
-		tea.Println(dimStyle.Render("    You can now start investigating!")),
+		printLine(successMsgStyle.Render(fmt.Sprintf("  ✓ Project set to: %s", p.Name))),
+		printLine(dimStyle.Render("    You can now start investigating!")),
 	)
 }
 
 // handleStreamChunk processes a streaming event via the StreamProcessor
-// and converts structured OutputEvents into styled tea.Println commands.
+// and converts structured OutputEvents into styled print commands.
 func (m *model) handleStreamChunk(msg streamChunkMsg) tea.Cmd {
 	events := m.processor.Process(msg)
 	if len(events) == 0 {
@@ -1098,12 +1391,13 @@ func (m *model) handleStreamChunk(msg streamChunkMsg) tea.Cmd {
 	}
 	var cmds []tea.Cmd
 	for _, ev := range events {
-		// Skip progress events - they're shown in the spinner only
-		if ev.Type == OutputProgress {
+		// Skip progress events - they're shown in the spinner only,
+		// except in accessible mode, which has no spinner
+		if ev.Type == OutputProgress && !display.Accessible() {
 			continue
 		}
-		rendered := renderOutputEvent(ev)
-		cmds = append(cmds, tea.Println(rendered))
+		m.recordAnswer(ev)
+		cmds = append(cmds, m.printStreamEvent(ev))
 	}
 	if len(cmds) == 0 {
 		return nil
@@ -1111,6 +1405,17 @@ func (m *model) handleStreamChunk(msg streamChunkMsg) tea.Cmd {
 	return tea.Sequence(cmds...)
 }
 
+// printStreamEvent prints a stream event, folding chain-of-thought steps:
+// while they are collapsed only the step headers reach the terminal.
+func (m *model) printStreamEvent(ev OutputEvent) tea.Cmd {
+	part := streamFoldPart(ev, &m.inCotStep)
+	hidden := part == foldBody && m.cotCollapsed()
+	if hidden {
+		m.cotHidden++
+	}
+	return printPart(renderOutputEvent(ev), part, hidden)
+}
+
 // renderOutputEvent converts a structured OutputEvent into a styled string.
 // This is the single rendering point — change styles or hide blocks here.
 func renderOutputEvent(ev OutputEvent) string {
@@ -1118,6 +1423,10 @@ func renderOutputEvent(ev OutputEvent) string {
 	case OutputProgress:
 		// Progress is shown in the spinner (View), not printed to scrollback.
 		// This keeps the output clean - only meaningful content is permanent.
+		// Accessible mode announces it as a line instead.
+		if display.Accessible() {
+			return "  " + ev.Text
+		}
 		return ""
 	case OutputCOTHeader:
 		return cotHeaderStyle.Render(fmt.Sprintf("  🔍 %s", ev.Text))
@@ -1146,7 +1455,7 @@ func renderOutputEvent(ev OutputEvent) string {
 	case OutputCodeFence:
 		return renderCodeFence(ev.Text)
 	case OutputCodeLine:
-		return renderCodeLine(ev.Text)
+		return renderCodeLine(display.HighlightCode(ev.Text, ev.Lang))
 	default:
 		return ev.Text
 	}
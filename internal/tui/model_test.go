@@ -2,11 +2,15 @@ package tui
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 
 	"hawkeye-cli/internal/api"
 	"hawkeye-cli/internal/config"
 	"hawkeye-cli/internal/service"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
 )
 
 // mockAPI implements api.HawkeyeAPI for testing.
@@ -18,7 +22,10 @@ type mockAPI struct {
 	inspect     *api.SessionInspectResponse
 	report      *api.IncidentReportResponse
 	connections *api.ListConnectionsResponse
+	projConns   []api.ConnectionSpec
 	resources   *api.ListResourcesResponse
+	orgs        []api.OrgSpec
+	renamed     map[string]string // session UUID → name passed to RenameSession
 
 	err error // if set, all methods return this error
 }
@@ -37,6 +44,13 @@ func (m *mockAPI) FetchUserInfo() (*api.UserSpec, error) {
 	return &api.UserSpec{UUID: "user-1", OrgUUID: "org-1"}, nil
 }
 
+func (m *mockAPI) ListOrganizations() ([]api.OrgSpec, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return m.orgs, nil
+}
+
 func (m *mockAPI) NewSession(projectUUID string) (*api.NewSessionResponse, error) {
 	if m.err != nil {
 		return nil, m.err
@@ -190,6 +204,17 @@ func (m *mockAPI) WaitForConnectionSync(connUUID string, timeoutSeconds int) (*a
 	return &api.GetConnectionResponse{Spec: &api.ConnectionDetail{UUID: connUUID, SyncState: "SYNCED"}}, nil
 }
 
+func (m *mockAPI) TrainConnection(connUUID string) error {
+	return m.err
+}
+
+func (m *mockAPI) WaitForConnectionTraining(connUUID string, timeoutSeconds int) (*api.GetConnectionResponse, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &api.GetConnectionResponse{Spec: &api.ConnectionDetail{UUID: connUUID, TrainingState: "TRAINED"}}, nil
+}
+
 func (m *mockAPI) AddConnectionToProject(projectUUID, connUUID string) error {
 	return m.err
 }
@@ -202,7 +227,7 @@ func (m *mockAPI) ListProjectConnections(projectUUID string) (*api.ListProjectCo
 	if m.err != nil {
 		return nil, m.err
 	}
-	return &api.ListProjectConnectionsResponse{}, nil
+	return &api.ListProjectConnectionsResponse{Specs: m.projConns}, nil
 }
 
 func (m *mockAPI) AddConnection(req *api.AddConnectionRequest) (*api.AddConnectionResponse, error) {
@@ -230,6 +255,13 @@ func (m *mockAPI) UpdateInstructionStatus(instrUUID string, enabled bool) error
 	return m.err
 }
 
+func (m *mockAPI) UpdateInstruction(instrUUID, name, content string) (*api.UpdateInstructionResponse, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &api.UpdateInstructionResponse{Instruction: &api.InstructionSpec{UUID: instrUUID, Name: name, Content: content}}, nil
+}
+
 func (m *mockAPI) DeleteInstruction(instrUUID string) error {
 	return m.err
 }
@@ -245,6 +277,17 @@ func (m *mockAPI) ApplySessionInstruction(sessionUUID, instrType, content string
 	return m.err
 }
 
+func (m *mockAPI) RenameSession(projectUUID, sessionUUID, name string) error {
+	if m.err != nil {
+		return m.err
+	}
+	if m.renamed == nil {
+		m.renamed = map[string]string{}
+	}
+	m.renamed[sessionUUID] = name
+	return nil
+}
+
 func (m *mockAPI) RerunSession(sessionUUID string) (*api.RerunSessionResponse, error) {
 	if m.err != nil {
 		return nil, m.err
@@ -901,6 +944,113 @@ func TestConnectionSubcommandDispatch(t *testing.T) {
 	}
 }
 
+func TestConnectionCreateWizard(t *testing.T) {
+	m := newTestModel()
+	typeText := func(m model, text string) model {
+		r, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(text)})
+		r, _ = r.(model).Update(tea.KeyMsg{Type: tea.KeyEnter})
+		return r.(model)
+	}
+
+	result, _ := m.cmdConnections([]string{"create"})
+	rm := result.(model)
+	if rm.mode != modeConnWizard || rm.wiz.step != wizPickType {
+		t.Fatalf("mode = %d step = %d, want type picker", rm.mode, rm.wiz.step)
+	}
+	if !strings.Contains(rm.View(), "prometheus") {
+		t.Error("picker does not list connection types")
+	}
+	result, _ = rm.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if rm = result.(model); rm.wiz.connType.Type != rm.wiz.types[0].Type || rm.wiz.step != wizName {
+		t.Fatalf("after select: type = %q step = %d", rm.wiz.connType.Type, rm.wiz.step)
+	}
+
+	result, _ = m.cmdConnectionCreate([]string{"grafana"})
+	rm = typeText(result.(model), "graf-prod")
+	if rm.wiz.name != "graf-prod" || rm.wiz.step != wizField || rm.wiz.fieldIdx != 0 {
+		t.Fatalf("after name: %+v", rm.wiz)
+	}
+	if rm = typeText(rm, ""); rm.wiz.fieldIdx != 0 {
+		t.Fatal("empty required field was accepted")
+	}
+	rm = typeText(rm, "https://grafana.example.com")
+	if rm.loginInput.EchoMode != textinput.EchoPassword {
+		t.Error("secret field is not masked")
+	}
+	if !strings.Contains(rm.View(), "API key (2/2)") {
+		t.Errorf("view does not prompt for the API key:\n%s", rm.View())
+	}
+	result, cmd := rm.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("secret")})
+	result, cmd = result.(model).Update(tea.KeyMsg{Type: tea.KeyEnter})
+	rm = result.(model)
+	if rm.mode != modeIdle || cmd == nil {
+		t.Fatalf("after last field: mode = %d cmd = %v, want create", rm.mode, cmd)
+	}
+	if rm.loginInput.EchoMode != textinput.EchoNormal {
+		t.Error("input left masked after the wizard")
+	}
+
+	result, _ = rm.handleConnWizardCreated(connWizardCreatedMsg{connUUID: "conn-1", name: "graf-prod"})
+	rm = result.(model)
+	if rm.mode != modeConnWizard || rm.wiz.step != wizAddToProject {
+		t.Fatalf("after create: mode = %d step = %d, want add-to-project prompt", rm.mode, rm.wiz.step)
+	}
+	rm = typeText(rm, "y")
+	if rm.mode != modeIdle {
+		t.Errorf("after y: mode = %d, want idle", rm.mode)
+	}
+
+	t.Run("no project skips prompt", func(t *testing.T) {
+		m := newTestModel()
+		m.cfg.ProjectID = ""
+		result, _ := m.handleConnWizardCreated(connWizardCreatedMsg{connUUID: "conn-1", name: "x"})
+		if result.(model).mode != modeIdle {
+			t.Error("prompted to add to a project with none selected")
+		}
+	})
+
+	t.Run("esc cancels", func(t *testing.T) {
+		result, _ := m.cmdConnectionCreate([]string{"slack"})
+		result, cmd := result.(model).Update(tea.KeyMsg{Type: tea.KeyEsc})
+		if result.(model).mode != modeIdle || cmd == nil {
+			t.Error("Esc did not cancel the wizard")
+		}
+	})
+
+	t.Run("unknown type", func(t *testing.T) {
+		result, _ := m.cmdConnectionCreate([]string{"nope"})
+		if result.(model).mode != modeIdle {
+			t.Error("unknown type started the wizard")
+		}
+	})
+
+	t.Run("members are stopped before the wizard", func(t *testing.T) {
+		member := newTestModel()
+		member.cfg.UserRole = "USER_ROLE_MEMBER"
+		result, cmd := member.cmdConnectionCreate([]string{"slack"})
+		if result.(model).mode != modeIdle || cmd == nil {
+			t.Error("a member started the connection wizard")
+		}
+		member.cfg.UserRole = "USER_ROLE_ADMIN"
+		if result, _ := member.cmdConnectionCreate([]string{"slack"}); result.(model).mode != modeConnWizard {
+			t.Error("an admin could not start the wizard")
+		}
+	})
+}
+
+func TestHandleConnWizardSynced(t *testing.T) {
+	m := newTestModel()
+	for _, msg := range []connWizardSyncedMsg{
+		{connUUID: "c1", added: true, syncState: "SYNCED"},
+		{connUUID: "c1", added: true, err: fmt.Errorf("sync timed out")},
+		{connUUID: "c1", err: fmt.Errorf("forbidden")},
+	} {
+		if _, cmd := m.handleConnWizardSynced(msg); cmd == nil {
+			t.Errorf("handleConnWizardSynced(%+v) returned no output", msg)
+		}
+	}
+}
+
 // ─── Phase 3: Instructions ──────────────────────────────────────────────────
 
 func TestInstructionsCommand(t *testing.T) {
@@ -1525,3 +1675,124 @@ func TestMultilineInput(t *testing.T) {
 		// Should handle unicode without panicking
 	})
 }
+
+func TestOrgSwitcher(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("SNAP_USER_COMMON", "")
+
+	m := newTestModel()
+	m.client = &mockAPI{
+		orgs:     []api.OrgSpec{{UUID: "org-1", Name: "Acme"}, {UUID: "org-2", Name: "Globex"}},
+		projects: []api.ProjectSpec{{UUID: "proj-2", Name: "other"}},
+	}
+
+	result, cmd := m.dispatchInput("/orgs")
+	if cmd == nil {
+		t.Fatal("expected load cmd")
+	}
+	rm := result.(model)
+	result, _ = rm.handleOrgsLoaded(orgsLoadedMsg{orgs: m.client.(*mockAPI).orgs})
+	rm = result.(model)
+	if rm.mode != modeOrgSelect || rm.orgListIdx != 0 {
+		t.Fatalf("mode = %d idx = %d, want org select on the active org", rm.mode, rm.orgListIdx)
+	}
+	if !strings.Contains(rm.View(), "Globex") {
+		t.Error("picker does not list organizations")
+	}
+
+	result, _ = rm.Update(tea.KeyMsg{Type: tea.KeyDown})
+	result, cmd = result.(model).Update(tea.KeyMsg{Type: tea.KeyEnter})
+	rm = result.(model)
+	if rm.mode != modeIdle || rm.cfg.OrgUUID != "org-2" {
+		t.Fatalf("after select: mode = %d org = %q", rm.mode, rm.cfg.OrgUUID)
+	}
+	if cmd == nil {
+		t.Fatal("expected project check cmd")
+	}
+
+	// proj-1 is not in the new org's project list, so it is cleared.
+	result, _ = rm.handleOrgProjectCheck(orgProjectCheckMsg{projectUUID: "proj-1", found: false})
+	if rm = result.(model); rm.cfg.ProjectID != "" {
+		t.Errorf("ProjectID = %q, want cleared", rm.cfg.ProjectID)
+	}
+
+	// A stale check for a different project is ignored.
+	rm.cfg.ProjectID = "proj-3"
+	result, _ = rm.handleOrgProjectCheck(orgProjectCheckMsg{projectUUID: "proj-1", found: false})
+	if rm = result.(model); rm.cfg.ProjectID != "proj-3" {
+		t.Errorf("stale check cleared ProjectID")
+	}
+}
+
+func TestResume(t *testing.T) {
+	m := newTestModel()
+	m.client = &mockAPI{inspect: &api.SessionInspectResponse{
+		SessionInfo: &api.SessionInfo{SessionUUID: "sess-last", Name: "db latency"},
+		PromptCycle: []api.PromptCycle{
+			{FinalAnswer: "First answer"},
+			{FinalAnswer: "Disk full on db-1"},
+			{}, // follow-up still running
+		},
+	}}
+
+	if _, cmd := m.dispatchInput("/resume"); cmd == nil {
+		t.Fatal("expected a warning without a previous session")
+	}
+
+	m.cfg.LastSession = "sess-last"
+	result, cmd := m.dispatchInput("/resume")
+	if cmd == nil {
+		t.Fatal("expected resume cmd")
+	}
+	if rm := result.(model); rm.sessionID != "" {
+		t.Errorf("sessionID = %q before the session loaded", rm.sessionID)
+	}
+
+	resp, _ := m.client.SessionInspect("proj-1", "sess-last")
+	result, _ = m.Update(resumeResultMsg{sessionUUID: "sess-last", resp: resp})
+	rm := result.(model)
+	if rm.sessionID != "sess-last" {
+		t.Errorf("sessionID = %q, want follow-ups to go to sess-last", rm.sessionID)
+	}
+	if rm.lastAnswer != "Disk full on db-1" {
+		t.Errorf("lastAnswer = %q", rm.lastAnswer)
+	}
+	if rm.mode != modeIdle {
+		t.Errorf("mode = %d, want idle prompt", rm.mode)
+	}
+
+	m = newTestModel()
+	result, _ = m.Update(resumeResultMsg{sessionUUID: "gone", err: fmt.Errorf("server returned 404")})
+	if rm := result.(model); rm.sessionID != "" {
+		t.Errorf("failed resume set sessionID = %q", rm.sessionID)
+	}
+}
+
+func TestDemoFixtures(t *testing.T) {
+	m := newTestModel()
+	m.cfg.ProjectID = "proj-demo"
+	m.client = api.NewFixtureClient("../../docs/fixtures/demo")
+
+	list, err := m.client.SessionList("proj-demo", 0, 50, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, cmd := m.handleSessionsLoaded(sessionsLoadedMsg{sessions: list.Sessions})
+	m = result.(model)
+	for _, msg := range runBatch(cmd) {
+		result, _ = m.Update(msg)
+		m = result.(model)
+	}
+	if got := m.summaries["sess-demo-0001"].text; !strings.Contains(got, "pool exhausted") {
+		t.Errorf("picker summary = %q", got)
+	}
+
+	resp, err := m.client.SessionInspect("proj-demo", "sess-demo-0001")
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, _ = m.Update(resumeResultMsg{sessionUUID: "sess-demo-0001", resp: resp})
+	if rm := result.(model); !strings.Contains(rm.lastAnswer, "connection pool is exhausted") {
+		t.Errorf("lastAnswer = %q", rm.lastAnswer)
+	}
+}
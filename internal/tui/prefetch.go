@@ -0,0 +1,123 @@
+package tui
+
+import (
+	"hawkeye-cli/internal/api"
+	"hawkeye-cli/internal/service"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ─── Session picker summary prefetch ────────────────────────────────────────
+//
+// While the session picker is open, summaries of the sessions on screen are
+// fetched in the background, at most summaryPrefetchConcurrency at a time,
+// and shown as one line under each entry. Results are cached for the rest
+// of the TUI run, so scrolling back or reopening the picker costs nothing.
+
+const (
+	summaryPrefetchConcurrency = 4
+	pickerSummaryWidth         = 70
+)
+
+// summaryFetchSlots bounds how many summary requests run at once.
+var summaryFetchSlots = make(chan struct{}, summaryPrefetchConcurrency)
+
+// pickerSummary is a cached short summary. An entry that is not loading
+// with empty text means the session has no summary (or fetching failed).
+type pickerSummary struct {
+	text    string
+	loading bool
+}
+
+type sessionSummaryMsg struct {
+	sessionUUID string
+	summary     string
+	err         error
+}
+
+// sessionListWindow returns the range of sessionList on screen. Each entry
+// takes two lines: the session and its summary.
+func (m model) sessionListWindow() (start, end int) {
+	maxVisible := (m.height - 6) / 2
+	if maxVisible < 3 {
+		maxVisible = 3
+	}
+	if m.sessionListIdx >= maxVisible {
+		start = m.sessionListIdx - maxVisible + 1
+	}
+	end = min(start+maxVisible, len(m.sessionList))
+	return start, end
+}
+
+// prefetchSummaries starts fetching summaries for the visible sessions
+// that are neither cached nor in flight. Sessions that were never
+// investigated have no summary and are skipped.
+func (m *model) prefetchSummaries() tea.Cmd {
+	if m.client == nil {
+		return nil
+	}
+	if m.summaries == nil {
+		m.summaries = map[string]pickerSummary{}
+	}
+	start, end := m.sessionListWindow()
+	var cmds []tea.Cmd
+	for _, s := range m.sessionList[start:end] {
+		if _, ok := m.summaries[s.SessionUUID]; ok || s.InvestigationStatus == "INVESTIGATION_STATUS_NOT_STARTED" {
+			continue
+		}
+		m.summaries[s.SessionUUID] = pickerSummary{loading: true}
+		cmds = append(cmds, fetchSessionSummary(m.client, m.cfg.ProjectID, s.SessionUUID))
+	}
+	return tea.Batch(cmds...)
+}
+
+func fetchSessionSummary(client api.HawkeyeAPI, projectID, sessionUUID string) tea.Cmd {
+	return func() tea.Msg {
+		summaryFetchSlots <- struct{}{}
+		defer func() { <-summaryFetchSlots }()
+		resp, err := client.GetSessionSummary(projectID, sessionUUID)
+		if err != nil {
+			return sessionSummaryMsg{sessionUUID: sessionUUID, err: err}
+		}
+		return sessionSummaryMsg{sessionUUID: sessionUUID, summary: pickerSummaryText(resp)}
+	}
+}
+
+// pickerSummaryText picks the shortest form of a session summary the
+// server has and cuts it to one line.
+func pickerSummaryText(resp *api.GetSessionSummaryResponse) string {
+	if resp == nil || resp.SessionSummary == nil {
+		return ""
+	}
+	ss := resp.SessionSummary
+	text := ss.Analysis
+	if ss.ShortSummary != nil && ss.ShortSummary.Analysis != "" {
+		text = ss.ShortSummary.Analysis
+	}
+	return service.ShortSummary(text, pickerSummaryWidth)
+}
+
+func (m model) handleSessionSummary(msg sessionSummaryMsg) (tea.Model, tea.Cmd) {
+	if m.summaries == nil {
+		m.summaries = map[string]pickerSummary{}
+	}
+	// Failures are cached as "no summary" so scrolling does not retry
+	// them over and over; reopening the TUI does.
+	m.summaries[msg.sessionUUID] = pickerSummary{text: msg.summary}
+	return m, nil
+}
+
+// renderPickerSummary returns the line shown under a session in the
+// picker, or "" when there is nothing to show.
+func (m model) renderPickerSummary(sessionUUID string) string {
+	s, ok := m.summaries[sessionUUID]
+	switch {
+	case !ok:
+		return ""
+	case s.loading:
+		return dimStyle.Render("      …")
+	case s.text == "":
+		return ""
+	}
+	return dimStyle.Render("      " + s.text)
+}
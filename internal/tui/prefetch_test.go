@@ -0,0 +1,146 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"hawkeye-cli/internal/api"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// runBatch executes cmd, expanding batches, and returns the messages.
+func runBatch(cmd tea.Cmd) []tea.Msg {
+	if cmd == nil {
+		return nil
+	}
+	msg := cmd()
+	batch, ok := msg.(tea.BatchMsg)
+	if !ok {
+		return []tea.Msg{msg}
+	}
+	var msgs []tea.Msg
+	for _, c := range batch {
+		msgs = append(msgs, runBatch(c)...)
+	}
+	return msgs
+}
+
+func pickerSessions(n int) []api.SessionInfo {
+	var sessions []api.SessionInfo
+	for i := 0; i < n; i++ {
+		sessions = append(sessions, api.SessionInfo{
+			SessionUUID:         fmt.Sprintf("sess-%02d", i),
+			Name:                fmt.Sprintf("Session %d", i),
+			InvestigationStatus: "INVESTIGATION_STATUS_COMPLETED",
+		})
+	}
+	return sessions
+}
+
+func TestPickerSummaryText(t *testing.T) {
+	tests := []struct {
+		name string
+		resp *api.GetSessionSummaryResponse
+		want string
+	}{
+		{"nil", nil, ""},
+		{"no summary", &api.GetSessionSummaryResponse{}, ""},
+		{"analysis only", &api.GetSessionSummaryResponse{SessionSummary: &api.SessionSummary{
+			Analysis: "## Root cause\nDisk full on db-1",
+		}}, "Root cause"},
+		{"short preferred", &api.GetSessionSummaryResponse{SessionSummary: &api.SessionSummary{
+			Analysis:     "long analysis",
+			ShortSummary: &api.ShortSessionSummary{Analysis: "Disk full on db-1"},
+		}}, "Disk full on db-1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := pickerSummaryText(tt.resp); got != tt.want {
+				t.Errorf("pickerSummaryText() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPrefetchSummaries(t *testing.T) {
+	m := newTestModel()
+	m.client = &mockAPI{summary: &api.GetSessionSummaryResponse{SessionSummary: &api.SessionSummary{
+		ShortSummary: &api.ShortSessionSummary{Analysis: "Disk full on db-1"},
+	}}}
+	sessions := pickerSessions(20)
+	sessions[1].InvestigationStatus = "INVESTIGATION_STATUS_NOT_STARTED"
+
+	result, cmd := m.handleSessionsLoaded(sessionsLoadedMsg{sessions: sessions})
+	m = result.(model)
+	_, end := m.sessionListWindow()
+	if end >= len(sessions) {
+		t.Fatalf("window end = %d, want fewer than %d sessions visible", end, len(sessions))
+	}
+	if s := m.summaries["sess-00"]; !s.loading {
+		t.Errorf("sess-00 should be loading, got %+v", s)
+	}
+	if _, ok := m.summaries["sess-01"]; ok {
+		t.Error("not-started session should not be fetched")
+	}
+	if _, ok := m.summaries[sessions[end].SessionUUID]; ok {
+		t.Error("off-screen session should not be fetched")
+	}
+	if !strings.Contains(m.renderSessionList(), "…") {
+		t.Error("picker should show a placeholder while loading")
+	}
+
+	msgs := runBatch(cmd)
+	if len(msgs) != end-1 {
+		t.Fatalf("got %d fetches, want %d", len(msgs), end-1)
+	}
+	for _, msg := range msgs {
+		result, _ = m.Update(msg)
+		m = result.(model)
+	}
+	if got := m.summaries["sess-00"].text; got != "Disk full on db-1" {
+		t.Errorf("sess-00 summary = %q", got)
+	}
+	if !strings.Contains(m.renderSessionList(), "Disk full on db-1") {
+		t.Error("picker should show the summary under the entry")
+	}
+
+	// Already cached sessions are not fetched again.
+	if cmds := runBatch(m.prefetchSummaries()); len(cmds) != 0 {
+		t.Errorf("got %d fetches for cached sessions, want 0", len(cmds))
+	}
+
+	// Scrolling past the window fetches the newly visible entry.
+	for i := 0; i < end; i++ {
+		result, cmd = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+		m = result.(model)
+	}
+	msgs = runBatch(cmd)
+	if len(msgs) != 1 {
+		t.Fatalf("got %d fetches after scrolling, want 1", len(msgs))
+	}
+	if got := msgs[0].(sessionSummaryMsg).sessionUUID; got != sessions[end].SessionUUID {
+		t.Errorf("fetched %s, want %s", got, sessions[end].SessionUUID)
+	}
+}
+
+func TestSessionSummaryErrorCached(t *testing.T) {
+	m := newTestModel()
+	m.client = &mockAPI{err: fmt.Errorf("server returned 500")}
+	m.mode = modeSessionSelect
+	m.sessionList = pickerSessions(1)
+
+	msgs := runBatch(m.prefetchSummaries())
+	if len(msgs) != 1 {
+		t.Fatalf("got %d fetches, want 1", len(msgs))
+	}
+	result, _ := m.Update(msgs[0])
+	m = result.(model)
+	if s, ok := m.summaries["sess-00"]; !ok || s.loading || s.text != "" {
+		t.Errorf("failed fetch should be cached as empty, got %+v (cached %v)", s, ok)
+	}
+	if m.prefetchSummaries() != nil {
+		t.Error("failed fetch should not be retried")
+	}
+}
@@ -4,11 +4,18 @@ import (
 	"fmt"
 	"strings"
 	"unicode/utf8"
+
+	"hawkeye-cli/internal/display"
+
+	"github.com/charmbracelet/lipgloss"
 )
 
 // ─── Welcome Screen ─────────────────────────────────────────────────────────
 
-func renderWelcome(version, server, projectName string, width int) string {
+func renderWelcome(banner, version, server, projectName string, width int) string {
+	if banner == BannerNone {
+		return ""
+	}
 	// Version may already have "v" prefix from git describe, don't double it
 	versionDisplay := version
 	if !strings.HasPrefix(version, "v") {
@@ -31,11 +38,21 @@ func renderWelcome(version, server, projectName string, width int) string {
 				projectDisplay = projectDisplay[:33] + "..."
 			}
 		}
-		infoLine = welcomeInfoLabel.Render(fmt.Sprintf("%s · %s", serverDisplay, projectDisplay))
+		info := fmt.Sprintf("%s · %s", serverDisplay, projectDisplay)
+		if width > 0 {
+			info = truncateToWidth(info, width-1)
+		}
+		infoLine = welcomeInfoLabel.Render(info)
 	}
 
-	bird := renderBirdASCIIArt()
-	return fmt.Sprintf("\n%s\n\n%s\n%s\n", bird, titleLine, infoLine)
+	if banner == BannerHawk {
+		bird := renderBirdASCIIArt()
+		if width <= 0 || lipgloss.Width(bird) <= width {
+			return fmt.Sprintf("\n%s\n\n%s\n%s\n", bird, titleLine, infoLine)
+		}
+		banner = BannerToucan
+	}
+	return renderCompactBanner(compactLogos[banner], titleLine, infoLine, width)
 }
 
 const hawkASCIIArt = `
@@ -175,34 +192,36 @@ func colorizeBirdLine(line string) string {
 // ANSI escape codes for styling
 // See docs/tui-color-format-guide.md for the full color & format specification.
 
-// Dark mode
 const (
 	ansiReset     = "\033[0m"
 	ansiBold      = "\033[1m"
 	ansiItalic    = "\033[3m"
 	ansiUnderline = "\033[4m"
+)
 
-	ansiHeading = "\033[1;97m"     // bold bright white — all header levels (distinct from **bold**)
-	ansiInfo    = "\033[38;5;39m"  // cyan 39    — links, session, icons
-	ansiWarning = "\033[38;5;220m" // yellow 220 — inline code
-	ansiSuccess = "\033[38;5;78m"  // green 78   — ✓, code borders (softer than neon 46)
-	ansiAccent  = "\033[38;5;73m"  // teal 73    — │, ──, numbered dots
-	ansiBody    = "\033[38;5;252m" // light 252  — body text (readable content, not metadata)
+// Colors are set from the active theme by applyANSITheme.
+var (
+	ansiHeading string // bold text color — all header levels (distinct from **bold**)
+	ansiInfo    string // info    — links, session, icons
+	ansiWarning string // warning — inline code
+	ansiSuccess string // success — ✓, code borders
+	ansiAccent  string // teal    — │, ──, numbered dots
+	ansiBody    string // body    — body text (readable content, not metadata)
 )
 
-// Light mode — swap these in when bg is light
-// const (
-// 	ansiInfo    = "\033[38;5;25m"  // blue 25
-// 	ansiWarning = "\033[38;5;136m" // amber 136
-// 	ansiSuccess = "\033[38;5;28m"  // green 28
-// 	ansiAccent  = "\033[38;5;30m"  // teal 30
-// 	ansiBody    = "\033[38;5;239m" // dark mid 239 — readable on light bg
-// 	// ansiHeading: use \033[1;30m (bold + near-black) on light bg
-// )
+func applyANSITheme(p display.Palette) {
+	ansiHeading = ansiBold + display.ANSIColor(p.Text)
+	ansiInfo = display.ANSIColor(p.Info)
+	ansiWarning = display.ANSIColor(p.Warning)
+	ansiSuccess = display.ANSIColor(p.Success)
+	ansiAccent = display.ANSIColor(p.Teal)
+	ansiBody = display.ANSIColor(p.Body)
+}
 
 // mdState tracks state across lines (e.g., inside code block)
 type mdState struct {
 	inCodeBlock bool
+	codeLang    string
 }
 
 // renderMarkdownLine renders a single line of markdown to styled terminal output.
@@ -215,6 +234,7 @@ func renderMarkdownLine(line string, state *mdState) string {
 		if !state.inCodeBlock {
 			state.inCodeBlock = true
 			lang := strings.TrimSpace(trimmed[3:])
+			state.codeLang = lang
 			if lang != "" {
 				return fmt.Sprintf("%s┌─ %s ─%s", ansiSuccess, lang, ansiReset)
 			}
@@ -224,9 +244,9 @@ func renderMarkdownLine(line string, state *mdState) string {
 		return fmt.Sprintf("%s└──%s", ansiSuccess, ansiReset)
 	}
 
-	// Inside code block — green border, body color text
+	// Inside code block — green border, syntax highlighted or body color text
 	if state.inCodeBlock {
-		return fmt.Sprintf("%s│%s %s%s%s", ansiSuccess, ansiReset, ansiBody, line, ansiReset)
+		return fmt.Sprintf("%s│%s %s%s%s", ansiSuccess, ansiReset, ansiBody, display.HighlightCode(line, state.codeLang), ansiReset)
 	}
 
 	// Headers — bold only, no color (all levels)
@@ -462,14 +482,27 @@ func renderInlineMarkdown(text string) string {
 	return out.String()
 }
 
-// renderMarkdownBlock renders a full markdown block (multiple lines)
+// renderMarkdownBlock renders a full markdown block (multiple lines).
+// Consecutive table rows outside code blocks are laid out with renderTable.
 func renderMarkdownBlock(content string) string {
 	lines := strings.Split(content, "\n")
 	state := &mdState{}
-	var result []string
+	var result, table []string
+	flushTable := func() {
+		if len(table) > 0 {
+			result = append(result, renderTable(strings.Join(table, "\n")))
+			table = nil
+		}
+	}
 	for _, line := range lines {
+		if !state.inCodeBlock && strings.HasPrefix(strings.TrimSpace(line), "|") {
+			table = append(table, line)
+			continue
+		}
+		flushTable()
 		result = append(result, renderMarkdownLine(line, state))
 	}
+	flushTable()
 	return strings.Join(result, "\n")
 }
 
@@ -558,9 +591,14 @@ func renderTable(raw string) string {
 		}
 	}
 
-	// Cap total table width to fit a reasonable terminal.
+	// Cap total table width to fit a reasonable terminal, or the configured
+	// --width when set.
 	// Overhead per column: 1 border + 2 padding spaces = 3; plus 1 for the final border.
-	const maxTableWidth = 140 // content area (excluding table's own indent)
+	maxTableWidth := 140 // content area (excluding table's own indent)
+	fixedWidth := display.Width() > 0
+	if fixedWidth {
+		maxTableWidth = display.Width() - len(indent)
+	}
 	overhead := 3*numCols + 1
 	available := maxTableWidth - overhead
 
@@ -575,7 +613,10 @@ func renderTable(raw string) string {
 		minColWidth = 35
 	}
 
-	if available < numCols*minColWidth {
+	if fixedWidth {
+		// Never widen past the configured width; just keep one rune per column.
+		available = max(available, numCols)
+	} else if available < numCols*minColWidth {
 		available = numCols * minColWidth
 	}
 
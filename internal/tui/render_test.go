@@ -4,6 +4,11 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+	"unicode/utf8"
+
+	"hawkeye-cli/internal/display"
+
+	"github.com/charmbracelet/lipgloss"
 )
 
 // ─── renderTable ─────────────────────────────────────────────────────────────
@@ -144,6 +149,18 @@ func TestRenderTable_TopBorderMatchesColumns(t *testing.T) {
 	}
 }
 
+func TestRenderTable_ConfiguredWidth(t *testing.T) {
+	defer display.SetWidth(display.Width())
+	display.SetWidth(40)
+
+	raw := "| Check | Result |\n|---|---|\n| connection pool | exhausted after the deploy at 14:02 rolled out a smaller pool size |"
+	for _, line := range strings.Split(renderTable(raw), "\n") {
+		if w := utf8.RuneCountInString(stripANSI(line)); w > 40 {
+			t.Errorf("line is %d columns, want <= 40: %q", w, stripANSI(line))
+		}
+	}
+}
+
 // ─── wrapCell ────────────────────────────────────────────────────────────────
 
 func TestWrapCell_NoWrapNeeded(t *testing.T) {
@@ -272,6 +289,25 @@ func TestRenderMarkdownText_PlainText(t *testing.T) {
 	}
 }
 
+// ─── renderMarkdownBlock ──────────────────────────────────────────────────────
+
+func TestRenderMarkdownBlock_TableAndCode(t *testing.T) {
+	content := "Summary:\n| Pod | Restarts |\n|-----|----------|\n| api | 7 |\n```bash\necho | grep x\n```\ndone"
+	out := stripANSI(renderMarkdownBlock(content))
+
+	for _, want := range []string{"Summary:", "┌", "│ api", "echo | grep x", "done"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("renderMarkdownBlock output missing %q\nOutput:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "|-----|") {
+		t.Errorf("table separator row not laid out:\n%s", out)
+	}
+	if strings.Count(out, "┌") != 2 { // table top border + code fence
+		t.Errorf("pipe inside code block rendered as a table:\n%s", out)
+	}
+}
+
 // ─── renderInlineMarkdown ─────────────────────────────────────────────────────
 
 func TestRenderInlineMarkdown_Bold(t *testing.T) {
@@ -351,3 +387,25 @@ func TestRenderCodeLine(t *testing.T) {
 		t.Errorf("code line border should use success (green) color, got %q", out)
 	}
 }
+
+func TestApplyTheme(t *testing.T) {
+	defer applyTheme(display.CurrentPalette())
+
+	light, err := display.LoadTheme("light")
+	if err != nil {
+		t.Fatal(err)
+	}
+	applyTheme(light)
+	if ansiInfo != "\033[38;5;25m" || ansiHeading != ansiBold+"\033[38;5;232m" {
+		t.Errorf("light: ansiInfo=%q ansiHeading=%q", ansiInfo, ansiHeading)
+	}
+
+	mono, _ := display.LoadTheme("mono")
+	applyTheme(mono)
+	if ansiInfo != "" || ansiBody != "" {
+		t.Errorf("mono: ansiInfo=%q ansiBody=%q", ansiInfo, ansiBody)
+	}
+	if _, ok := colorOrange.(lipgloss.NoColor); !ok {
+		t.Errorf("mono: colorOrange = %#v, want NoColor", colorOrange)
+	}
+}
@@ -0,0 +1,550 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"hawkeye-cli/internal/api"
+	"hawkeye-cli/internal/service"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ─── /resources browser ─────────────────────────────────────────────────────
+//
+// The browser runs in modeResourceBrowser: the project's connections on the
+// left, the resources of the selected one on the right and the details of
+// whatever is selected underneath. A connection's resources are fetched the
+// first time it is selected. / filters the focused pane with a fuzzy match
+// and 1-9 hide or show the telemetry types of the selected connection, so
+// "what metrics can Hawkeye see in prod-aws?" is a few keystrokes instead
+// of a series of listing commands.
+
+const resourceBrowserLimit = 1000 // resources fetched per connection
+
+const (
+	resourcePaneConns = iota
+	resourcePaneResources
+)
+
+// resourceBrowser holds the state of modeResourceBrowser.
+type resourceBrowser struct {
+	conns      []api.ConnectionSpec
+	connErr    error
+	loading    bool
+	resources  map[string][]api.ResourceSpec // by connection UUID
+	resErr     map[string]error
+	resLoading map[string]bool
+
+	focus      int // resourcePaneConns or resourcePaneResources
+	connRow    int // index into visibleConns
+	resRow     int // index into visibleResources
+	connFilter string
+	resFilter  string
+	filtering  bool
+	hidden     map[string]bool // telemetry types toggled off
+	status     string
+}
+
+type resourceConnsMsg struct {
+	conns []api.ConnectionSpec
+	err   error
+}
+
+type resourceListMsg struct {
+	connUUID string
+	specs    []api.ResourceSpec
+	err      error
+}
+
+// cmdResources opens the resource browser.
+func (m model) cmdResources() (tea.Model, tea.Cmd) {
+	if m.client == nil {
+		return m, printLine(errorMsgStyle.Render("  ✗ Not logged in. Run /login first."))
+	}
+	if m.cfg.ProjectID == "" {
+		return m, printLine(errorMsgStyle.Render("  ✗ No project set. Run /projects first."))
+	}
+	m.res = newResourceBrowser()
+	m.mode = modeResourceBrowser
+	return m, m.loadResourceConns()
+}
+
+func newResourceBrowser() resourceBrowser {
+	return resourceBrowser{
+		loading:    true,
+		resources:  map[string][]api.ResourceSpec{},
+		resErr:     map[string]error{},
+		resLoading: map[string]bool{},
+		hidden:     map[string]bool{},
+	}
+}
+
+func (m model) loadResourceConns() tea.Cmd {
+	client := m.client
+	projectID := m.cfg.ProjectID
+	return func() tea.Msg {
+		resp, err := client.ListProjectConnections(projectID)
+		if err != nil {
+			return resourceConnsMsg{err: err}
+		}
+		conns := resp.Specs
+		sort.SliceStable(conns, func(i, j int) bool {
+			return strings.ToLower(conns[i].Name) < strings.ToLower(conns[j].Name)
+		})
+		return resourceConnsMsg{conns: conns}
+	}
+}
+
+// loadSelectedResources fetches the selected connection's resources unless
+// they are loaded or on their way.
+func (m model) loadSelectedResources() tea.Cmd {
+	conn, ok := m.res.selectedConn()
+	if !ok || m.res.resLoading[conn.UUID] {
+		return nil
+	}
+	if _, loaded := m.res.resources[conn.UUID]; loaded {
+		return nil
+	}
+	if _, failed := m.res.resErr[conn.UUID]; failed {
+		return nil
+	}
+	m.res.resLoading[conn.UUID] = true
+	client := m.client
+	uuid := conn.UUID
+	return func() tea.Msg {
+		resp, err := client.ListConnectionResources(uuid, resourceBrowserLimit)
+		if err != nil {
+			return resourceListMsg{connUUID: uuid, err: err}
+		}
+		specs := resp.Specs
+		sort.SliceStable(specs, func(i, j int) bool {
+			return strings.ToLower(resourceName(specs[i])) < strings.ToLower(resourceName(specs[j]))
+		})
+		return resourceListMsg{connUUID: uuid, specs: specs}
+	}
+}
+
+func (m model) handleResourceConns(msg resourceConnsMsg) (tea.Model, tea.Cmd) {
+	if m.mode != modeResourceBrowser {
+		return m, nil
+	}
+	m.res.loading = false
+	m.res.conns, m.res.connErr = msg.conns, msg.err
+	if n := len(m.res.visibleConns()); m.res.connRow >= n {
+		m.res.connRow = max(n-1, 0)
+	}
+	return m, m.loadSelectedResources()
+}
+
+func (m model) handleResourceList(msg resourceListMsg) (tea.Model, tea.Cmd) {
+	if m.mode != modeResourceBrowser {
+		return m, nil
+	}
+	delete(m.res.resLoading, msg.connUUID)
+	if msg.err != nil {
+		m.res.resErr[msg.connUUID] = msg.err
+		return m, nil
+	}
+	if msg.specs == nil {
+		msg.specs = []api.ResourceSpec{}
+	}
+	m.res.resources[msg.connUUID] = msg.specs
+	return m, nil
+}
+
+func (m model) handleResourceBrowserKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	rb := &m.res
+	if rb.filtering {
+		switch msg.Type {
+		case tea.KeyEsc:
+			rb.filtering = false
+			rb.setFilter("")
+			return m, m.loadSelectedResources()
+		case tea.KeyEnter:
+			rb.filtering = false
+			return m, nil
+		case tea.KeyBackspace:
+			if r := []rune(rb.filter()); len(r) > 0 {
+				rb.setFilter(string(r[:len(r)-1]))
+			}
+			return m, m.loadSelectedResources()
+		case tea.KeyRunes, tea.KeySpace:
+			text := string(msg.Runes)
+			if msg.Type == tea.KeySpace {
+				text = " "
+			}
+			rb.setFilter(rb.filter() + text)
+			return m, m.loadSelectedResources()
+		}
+	}
+
+	switch msg.Type {
+	case tea.KeyEsc, tea.KeyCtrlC:
+		if msg.Type == tea.KeyEsc && rb.filter() != "" {
+			rb.setFilter("")
+			return m, m.loadSelectedResources()
+		}
+		m.mode = modeIdle
+		m.res = resourceBrowser{}
+		return m, printLine(dimStyle.Render("  Resource browser closed."))
+	case tea.KeyUp:
+		rb.move(-1)
+		return m, m.loadSelectedResources()
+	case tea.KeyDown:
+		rb.move(1)
+		return m, m.loadSelectedResources()
+	case tea.KeyTab, tea.KeyShiftTab:
+		rb.focus = 1 - rb.focus
+		rb.status = ""
+	case tea.KeyLeft:
+		rb.focus = resourcePaneConns
+		rb.status = ""
+	case tea.KeyRight, tea.KeyEnter:
+		if _, ok := rb.selectedConn(); ok {
+			rb.focus = resourcePaneResources
+			rb.status = ""
+		}
+	case tea.KeyRunes:
+		key := string(msg.Runes)
+		switch {
+		case key == "/":
+			rb.filtering = true
+			rb.status = ""
+		case len(key) == 1 && key >= "1" && key <= "9":
+			types := rb.telemetryTypes()
+			if n := int(key[0] - '1'); n < len(types) {
+				t := types[n].name
+				rb.hidden[t] = !rb.hidden[t]
+				rb.resRow = 0
+			}
+		case key == "a":
+			rb.hidden = map[string]bool{}
+		case key == "c":
+			if text, what := rb.copyTarget(); text != "" {
+				rb.status = copyText(text, what)
+			}
+		case key == "r" && !rb.loading:
+			*rb = newResourceBrowser()
+			return m, m.loadResourceConns()
+		}
+	}
+	return m, nil
+}
+
+// filter is the filter of the focused pane.
+func (rb *resourceBrowser) filter() string {
+	if rb.focus == resourcePaneResources {
+		return rb.resFilter
+	}
+	return rb.connFilter
+}
+
+func (rb *resourceBrowser) setFilter(f string) {
+	if rb.focus == resourcePaneResources {
+		rb.resFilter, rb.resRow = f, 0
+		return
+	}
+	rb.connFilter, rb.connRow, rb.resRow = f, 0, 0
+}
+
+func (rb *resourceBrowser) move(delta int) {
+	if rb.focus == resourcePaneResources {
+		rb.resRow = max(min(rb.resRow+delta, len(rb.visibleResources())-1), 0)
+		return
+	}
+	row := max(min(rb.connRow+delta, len(rb.visibleConns())-1), 0)
+	if row != rb.connRow {
+		rb.connRow, rb.resRow = row, 0
+	}
+}
+
+// visibleConns are the connections matching the connection filter.
+func (rb *resourceBrowser) visibleConns() []api.ConnectionSpec {
+	idx := service.FuzzyFilter(rb.connFilter, len(rb.conns), func(i int) string { return rb.conns[i].Name })
+	out := make([]api.ConnectionSpec, len(idx))
+	for i, j := range idx {
+		out[i] = rb.conns[j]
+	}
+	return out
+}
+
+func (rb *resourceBrowser) selectedConn() (api.ConnectionSpec, bool) {
+	conns := rb.visibleConns()
+	if rb.connRow < len(conns) {
+		return conns[rb.connRow], true
+	}
+	return api.ConnectionSpec{}, false
+}
+
+// visibleResources are the selected connection's resources of the types
+// not hidden, matching the resource filter.
+func (rb *resourceBrowser) visibleResources() []api.ResourceSpec {
+	conn, ok := rb.selectedConn()
+	if !ok {
+		return nil
+	}
+	var shown []api.ResourceSpec
+	for _, r := range rb.resources[conn.UUID] {
+		if !rb.hidden[telemetryType(r)] {
+			shown = append(shown, r)
+		}
+	}
+	idx := service.FuzzyFilter(rb.resFilter, len(shown), func(i int) string { return resourceName(shown[i]) })
+	out := make([]api.ResourceSpec, len(idx))
+	for i, j := range idx {
+		out[i] = shown[j]
+	}
+	return out
+}
+
+func (rb *resourceBrowser) selectedResource() (api.ResourceSpec, bool) {
+	res := rb.visibleResources()
+	if rb.resRow < len(res) {
+		return res[rb.resRow], true
+	}
+	return api.ResourceSpec{}, false
+}
+
+// telemetryCount is a telemetry type and how many resources have it.
+type telemetryCount struct {
+	name  string
+	count int
+}
+
+// telemetryTypes counts the selected connection's resources by telemetry
+// type, most common first. The order is what 1-9 toggle.
+func (rb *resourceBrowser) telemetryTypes() []telemetryCount {
+	conn, ok := rb.selectedConn()
+	if !ok {
+		return nil
+	}
+	counts := map[string]int{}
+	for _, r := range rb.resources[conn.UUID] {
+		counts[telemetryType(r)]++
+	}
+	var types []telemetryCount
+	for name, n := range counts {
+		types = append(types, telemetryCount{name, n})
+	}
+	sort.Slice(types, func(i, j int) bool {
+		if types[i].count != types[j].count {
+			return types[i].count > types[j].count
+		}
+		return types[i].name < types[j].name
+	})
+	return types
+}
+
+// copyTarget is what c copies: the selected resource's name, or the
+// selected connection's UUID.
+func (rb *resourceBrowser) copyTarget() (text, what string) {
+	if rb.focus == resourcePaneResources {
+		if r, ok := rb.selectedResource(); ok {
+			return resourceName(r), "resource name"
+		}
+		return "", ""
+	}
+	if c, ok := rb.selectedConn(); ok {
+		return c.UUID, "connection UUID"
+	}
+	return "", ""
+}
+
+func resourceName(r api.ResourceSpec) string {
+	if r.ID.Name != "" {
+		return r.ID.Name
+	}
+	return r.ID.UUID
+}
+
+func telemetryType(r api.ResourceSpec) string {
+	t := strings.ToLower(strings.TrimPrefix(r.TelemetryType, "TELEMETRY_TYPE_"))
+	if t == "" {
+		return "unknown"
+	}
+	return t
+}
+
+// ─── Resource browser renderer ──────────────────────────────────────────────
+
+func (m model) renderResourceBrowser() string {
+	rb := &m.res
+	var b strings.Builder
+	b.WriteString("\n")
+	header := "  🔍 Resources"
+	if project := m.cfg.ProjectName; project != "" {
+		header += " — " + project
+	}
+	if rb.loading {
+		header += " (loading...)"
+	}
+	b.WriteString(dimStyle.Render(header) + "\n\n")
+
+	if rb.connErr != nil {
+		b.WriteString("  " + errorMsgStyle.Render(fmt.Sprintf("✗ %v", rb.connErr)) + "\n")
+		return b.String()
+	}
+	if !rb.loading && len(rb.conns) == 0 {
+		b.WriteString(dimStyle.Render("  No connections in this project — /connections create to add one") + "\n")
+		return b.String()
+	}
+
+	width := max(m.width, 60) - 4
+	leftWidth := min(max(width/3, 20), 36)
+	rows := max(m.height-16, 5)
+
+	left := rb.renderConnPane(leftWidth-2, rows)
+	right := rb.renderResourcePane(width-leftWidth-2, rows)
+	b.WriteString("  " + lipgloss.JoinHorizontal(lipgloss.Top,
+		lipgloss.NewStyle().Width(leftWidth).Render(left),
+		lipgloss.NewStyle().Width(width-leftWidth).Render(right),
+	) + "\n\n")
+
+	b.WriteString(rb.renderDetails(width))
+
+	switch {
+	case rb.filtering:
+		b.WriteString("\n  Filter: " + rb.filter() + "_\n")
+	case rb.status != "":
+		b.WriteString("\n  " + rb.status + "\n")
+	}
+	return b.String()
+}
+
+func (rb *resourceBrowser) paneTitle(pane int, title string) string {
+	if rb.focus == pane {
+		return cmdSelectedNameStyle.Render(title)
+	}
+	return dimStyle.Render(title)
+}
+
+func (rb *resourceBrowser) renderConnPane(width, rows int) string {
+	conns := rb.visibleConns()
+	title := fmt.Sprintf("Connections (%d)", len(conns))
+	if rb.connFilter != "" {
+		title += " /" + rb.connFilter
+	}
+	lines := []string{rb.paneTitle(resourcePaneConns, title)}
+	start := max(rb.connRow-rows+1, 0)
+	for i := start; i < len(conns) && i < start+rows; i++ {
+		text := truncateToWidth(service.FormatConnection(conns[i]).Name, width-2)
+		if i == rb.connRow {
+			lines = append(lines, incidentRowSelectedStyle.Render("▸ "+text))
+		} else {
+			lines = append(lines, incidentRowStyle.Render("  "+text))
+		}
+	}
+	if len(conns) == 0 && !rb.loading {
+		lines = append(lines, dimStyle.Render("  —"))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (rb *resourceBrowser) renderResourcePane(width, rows int) string {
+	conn, ok := rb.selectedConn()
+	if !ok {
+		return rb.paneTitle(resourcePaneResources, "Resources")
+	}
+	all, loaded := rb.resources[conn.UUID]
+	res := rb.visibleResources()
+
+	title := fmt.Sprintf("Resources (%d)", len(res))
+	if len(all) >= resourceBrowserLimit {
+		title = fmt.Sprintf("Resources (%d of the first %d)", len(res), len(all))
+	}
+	if rb.resFilter != "" {
+		title += " /" + rb.resFilter
+	}
+	lines := []string{rb.paneTitle(resourcePaneResources, title)}
+
+	if types := rb.telemetryTypes(); len(types) > 0 {
+		var toggles []string
+		for i, t := range types {
+			if i == 9 {
+				break
+			}
+			text := fmt.Sprintf("%d %s (%d)", i+1, t.name, t.count)
+			if rb.hidden[t.name] {
+				toggles = append(toggles, dimStyle.Render("["+text+" off]"))
+			} else {
+				toggles = append(toggles, hintKeyStyle.Render("["+text+"]"))
+			}
+		}
+		lines = append(lines, truncateToWidth(strings.Join(toggles, " "), width))
+	}
+
+	switch {
+	case rb.resErr[conn.UUID] != nil:
+		lines = append(lines, "  "+errorMsgStyle.Render(fmt.Sprintf("✗ %v", rb.resErr[conn.UUID])))
+	case !loaded:
+		lines = append(lines, dimStyle.Render("  Loading..."))
+	case len(res) == 0:
+		lines = append(lines, dimStyle.Render("  —"))
+	}
+
+	nameWidth := max(width-14, 10)
+	start := max(rb.resRow-rows+1, 0)
+	for i := start; i < len(res) && i < start+rows; i++ {
+		text := fmt.Sprintf("%-*s %s", nameWidth, truncateToWidth(resourceName(res[i]), nameWidth), telemetryType(res[i]))
+		text = truncateToWidth(text, width-2)
+		if rb.focus == resourcePaneResources && i == rb.resRow {
+			lines = append(lines, incidentRowSelectedStyle.Render("▸ "+text))
+		} else {
+			lines = append(lines, incidentRowStyle.Render("  "+text))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// renderDetails describes the selected resource, or the selected
+// connection when the connection pane has focus.
+func (rb *resourceBrowser) renderDetails(width int) string {
+	conn, ok := rb.selectedConn()
+	if !ok {
+		return ""
+	}
+	var b strings.Builder
+	row := func(label, value string) {
+		b.WriteString("  " + hintKeyStyle.Render(fmt.Sprintf("%-11s", label)) + " " + truncateToWidth(value, width-12) + "\n")
+	}
+
+	if r, ok := rb.selectedResource(); ok && rb.focus == resourcePaneResources {
+		b.WriteString(dimStyle.Render("  Resource") + "\n")
+		row("Name", resourceName(r))
+		row("UUID", r.ID.UUID)
+		row("Telemetry", telemetryType(r))
+		row("Connection", service.FormatConnection(conn).Name)
+		return b.String()
+	}
+
+	c := service.FormatConnection(conn)
+	b.WriteString(dimStyle.Render("  Connection") + "\n")
+	row("Name", c.Name)
+	row("UUID", c.UUID)
+	row("Type", c.Type)
+	row("Sync", connState(c.SyncState, "SYNC_STATE_"))
+	row("Training", connState(c.TrainingState, "TRAINING_STATE_"))
+	if all, loaded := rb.resources[conn.UUID]; loaded {
+		var parts []string
+		for _, t := range rb.telemetryTypes() {
+			parts = append(parts, fmt.Sprintf("%d %s", t.count, t.name))
+		}
+		summary := fmt.Sprintf("%d", len(all))
+		if len(parts) > 0 {
+			summary += " (" + strings.Join(parts, ", ") + ")"
+		}
+		row("Resources", summary)
+	}
+	return b.String()
+}
+
+// connState shows a sync or training state without its enum prefix.
+func connState(state, prefix string) string {
+	if state == "" {
+		return "—"
+	}
+	return strings.ToLower(strings.TrimPrefix(state, prefix))
+}
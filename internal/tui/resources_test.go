@@ -0,0 +1,129 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"hawkeye-cli/internal/api"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func resourceTestModel(t *testing.T, client *mockAPI) model {
+	t.Helper()
+	m := newTestModel()
+	m.client = client
+	result, cmd := m.cmdResources()
+	m = result.(model)
+	if m.mode != modeResourceBrowser || cmd == nil {
+		t.Fatalf("mode = %v, cmd = %v; want the browser loading", m.mode, cmd)
+	}
+	// Connections, then the first connection's resources.
+	for cmd != nil {
+		result, cmd = m.Update(cmd())
+		m = result.(model)
+	}
+	return m
+}
+
+func resourceKeys(m model, keys ...tea.KeyMsg) model {
+	for _, k := range keys {
+		result, cmd := m.Update(k)
+		m = result.(model)
+		for cmd != nil {
+			msg := cmd()
+			if _, ok := msg.(resourceListMsg); !ok {
+				break
+			}
+			result, cmd = m.Update(msg)
+			m = result.(model)
+		}
+	}
+	return m
+}
+
+func keyRunes(s string) tea.KeyMsg { return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)} }
+
+func TestResourceBrowser(t *testing.T) {
+	m := resourceTestModel(t, &mockAPI{
+		projConns: []api.ConnectionSpec{
+			{UUID: "c-2", Name: "prod-aws", Type: "CONNECTION_TYPE_AWS", SyncState: "SYNC_STATE_SYNCED"},
+			{UUID: "c-1", Name: "datadog", Type: "CONNECTION_TYPE_DATADOG"},
+		},
+		resources: &api.ListResourcesResponse{Specs: []api.ResourceSpec{
+			{ID: api.ResourceID{Name: "request_latency", UUID: "r-1"}, TelemetryType: "metric"},
+			{ID: api.ResourceID{Name: "checkout-api", UUID: "r-2"}, TelemetryType: "log"},
+			{ID: api.ResourceID{Name: "cpu_utilization", UUID: "r-3"}, TelemetryType: "metric"},
+		}},
+	})
+
+	view := m.renderResourceBrowser()
+	for _, s := range []string{
+		"Connections (2)", "datadog", "prod-aws", "Resources (3)",
+		"[1 metric (2)]", "[2 log (1)]", "checkout-api", "cpu_utilization",
+		"Type", "datadog", "3 (2 metric, 1 log)",
+	} {
+		if !strings.Contains(view, s) {
+			t.Errorf("browser missing %q:\n%s", s, view)
+		}
+	}
+	// Sorted by name: datadog is selected first.
+	if c, _ := m.res.selectedConn(); c.UUID != "c-1" {
+		t.Errorf("selected %q, want datadog", c.Name)
+	}
+
+	// Hide metrics: only the log is left.
+	m = resourceKeys(m, keyRunes("1"))
+	if res := m.res.visibleResources(); len(res) != 1 || res[0].ID.UUID != "r-2" {
+		t.Errorf("with metrics hidden = %+v", res)
+	}
+	if view := m.renderResourceBrowser(); !strings.Contains(view, "[1 metric (2) off]") {
+		t.Errorf("toggle not shown off:\n%s", view)
+	}
+	m = resourceKeys(m, keyRunes("a"))
+
+	// Fuzzy filter the resources pane, then look at the details.
+	m = resourceKeys(m, tea.KeyMsg{Type: tea.KeyTab}, keyRunes("/"), keyRunes("r"), keyRunes("l"), tea.KeyMsg{Type: tea.KeyEnter})
+	if res := m.res.visibleResources(); len(res) != 1 || res[0].ID.Name != "request_latency" {
+		t.Fatalf("filter rl = %+v", res)
+	}
+	if m.res.filtering {
+		t.Error("Enter should end filtering and keep the filter")
+	}
+	if view := m.renderResourceBrowser(); !strings.Contains(view, "Telemetry") || !strings.Contains(view, "r-1") {
+		t.Errorf("details missing the resource:\n%s", view)
+	}
+
+	// Esc clears the filter first, then closes.
+	m = resourceKeys(m, tea.KeyMsg{Type: tea.KeyEsc})
+	if m.mode != modeResourceBrowser || m.res.resFilter != "" {
+		t.Fatalf("first Esc: mode %v, filter %q", m.mode, m.res.resFilter)
+	}
+
+	// Moving to another connection loads its resources.
+	m = resourceKeys(m, tea.KeyMsg{Type: tea.KeyLeft}, tea.KeyMsg{Type: tea.KeyDown})
+	if c, _ := m.res.selectedConn(); c.UUID != "c-2" {
+		t.Fatalf("selected %q, want prod-aws", c.Name)
+	}
+	if _, ok := m.res.resources["c-2"]; !ok {
+		t.Error("prod-aws resources not loaded")
+	}
+
+	m = resourceKeys(m, tea.KeyMsg{Type: tea.KeyEsc})
+	if m.mode != modeIdle {
+		t.Errorf("mode = %v after Esc, want idle", m.mode)
+	}
+}
+
+func TestResourceBrowserErrors(t *testing.T) {
+	m := resourceTestModel(t, &mockAPI{err: fmt.Errorf("boom")})
+	if view := m.renderResourceBrowser(); !strings.Contains(view, "boom") {
+		t.Errorf("want the error shown:\n%s", view)
+	}
+
+	m = resourceTestModel(t, &mockAPI{})
+	if view := m.renderResourceBrowser(); !strings.Contains(view, "No connections") {
+		t.Errorf("want the empty state:\n%s", view)
+	}
+}
@@ -0,0 +1,448 @@
+package tui
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+
+	"hawkeye-cli/internal/display"
+	"hawkeye-cli/internal/service"
+
+	"github.com/atotto/clipboard"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ─── Output log ─────────────────────────────────────────────────────────────
+//
+// Every printed line is kept here. The conversation viewport above the
+// input renders the log, so long investigations stay scrollable (PgUp) and
+// searchable (/find) instead of running off the top of the terminal. With a
+// screen reader (inlineOutput) lines are printed above the inline view with
+// tea.Println as well, and the log backs the scrollback viewer.
+
+// inlineOutput prints output above the view instead of rendering it in the
+// conversation viewport. Run sets it in accessible mode, where screen
+// readers follow text as it is printed.
+var inlineOutput bool
+
+// outputMsg tells the model that lines reached the output log.
+type outputMsg struct{}
+
+// maxOutputLines caps the in-memory scrollback buffer.
+const maxOutputLines = 10000
+
+type outputLog struct {
+	mu    sync.Mutex
+	lines []string
+	folds []foldRange // chain-of-thought steps, see fold.go
+	// dropped counts folds trimmed or reset away, so a viewer can line
+	// its fold state up with the folds that remain.
+	dropped int
+}
+
+func (l *outputLog) append(text string) {
+	l.appendPart(text, foldNone)
+}
+
+// appendPart records text as part of a foldable block: a header starts a
+// new fold and a body line extends the fold right before it.
+func (l *outputLog) appendPart(text string, part foldPart) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	start := len(l.lines)
+	l.lines = append(l.lines, strings.Split(text, "\n")...)
+	switch part {
+	case foldHeader:
+		l.folds = append(l.folds, foldRange{header: start, end: len(l.lines)})
+	case foldBody:
+		if n := len(l.folds); n > 0 && l.folds[n-1].end == start {
+			l.folds[n-1].end = len(l.lines)
+		}
+	}
+	if over := len(l.lines) - maxOutputLines; over > 0 {
+		l.lines = append([]string(nil), l.lines[over:]...)
+		kept := shiftFolds(l.folds, over)
+		l.dropped += len(l.folds) - len(kept)
+		l.folds = kept
+	}
+}
+
+func (l *outputLog) snapshot() []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]string(nil), l.lines...)
+}
+
+// snapshotFolds returns the lines with the folds that index into them and
+// the number of folds dropped so far.
+func (l *outputLog) snapshotFolds() ([]string, []foldRange, int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]string(nil), l.lines...), append([]foldRange(nil), l.folds...), l.dropped
+}
+
+func (l *outputLog) reset() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.dropped += len(l.folds)
+	l.lines = nil
+	l.folds = nil
+}
+
+// printedOutput is shared by all print commands, like activeStream.
+var printedOutput = &outputLog{}
+
+// printLine adds text to the conversation. The line is recorded when the
+// command runs, so the log follows the order in which lines were printed.
+// ASCII mode and --width are applied here, the one place all printed
+// output passes through.
+func printLine(text string) tea.Cmd {
+	return func() tea.Msg {
+		text := display.Apply(text)
+		printedOutput.append(text)
+		return showOutput(text)
+	}
+}
+
+// showOutput prints a recorded line above the inline view, or tells the
+// model to bring the conversation viewport up to date.
+func showOutput(text string) tea.Msg {
+	if inlineOutput {
+		return tea.Println(text)()
+	}
+	return outputMsg{}
+}
+
+// writeClipboard is swapped out in tests.
+var writeClipboard = clipboard.WriteAll
+
+var ansiPattern = regexp.MustCompile(`\x1b\[[0-9;?]*[ -/]*[@-~]`)
+
+// stripANSI removes terminal escape sequences from s.
+func stripANSI(s string) string {
+	return ansiPattern.ReplaceAllString(s, "")
+}
+
+// findLines returns the indexes of lines containing query, ignoring case
+// and styling. An empty query matches nothing.
+func findLines(lines []string, query string) []int {
+	query = strings.ToLower(strings.TrimSpace(query))
+	if query == "" {
+		return nil
+	}
+	var matches []int
+	for i, line := range lines {
+		if strings.Contains(strings.ToLower(stripANSI(line)), query) {
+			matches = append(matches, i)
+		}
+	}
+	return matches
+}
+
+// ─── Conversation viewport ──────────────────────────────────────────────────
+//
+// The viewport always shows the output log. modeScrollback gives it the
+// keys (PgUp, /find): scrolling, match stepping, folding and copying.
+
+// syncOutput brings the viewport up to date with the output log. Fold
+// state carries over for the folds still in the log and new steps start
+// open or collapsed as /cot says. A viewport at the bottom stays there.
+func (m *model) syncOutput() {
+	follow := m.scrollView.AtBottom()
+	lines, folds, dropped := printedOutput.snapshotFolds()
+
+	gone := min(dropped-m.scrollDropped, len(m.scrollOpen))
+	m.scrollDropped = dropped
+	m.scrollOpen = m.scrollOpen[gone:min(len(m.scrollOpen), gone+len(folds))]
+	for len(m.scrollOpen) < len(folds) {
+		m.scrollOpen = append(m.scrollOpen, !m.cotCollapsed())
+	}
+	if m.scrollFold -= gone; m.scrollFold < 0 || m.scrollFold >= len(folds) {
+		m.scrollFold = -1
+	}
+
+	m.scrollLines = lines
+	m.scrollFolds = folds
+	m.scrollMatches = findLines(lines, m.scrollQuery)
+	m.scrollMatchIdx = min(m.scrollMatchIdx, len(m.scrollMatches)-1)
+	m.refreshScrollback()
+	if follow {
+		m.scrollView.GotoBottom()
+	}
+}
+
+// fitConversation sizes the viewport to the rows the rest of the view
+// leaves it, keeping it at the bottom if it was there.
+func (m *model) fitConversation() {
+	follow := m.scrollView.AtBottom()
+	m.scrollView.Width = m.scrollWidth()
+	m.scrollView.Height = m.conversationHeight()
+	if follow {
+		m.scrollView.GotoBottom()
+	} else {
+		m.scrollView.SetYOffset(m.scrollView.YOffset)
+	}
+}
+
+// conversationHeight is the viewport height for the current mode.
+func (m model) conversationHeight() int {
+	if m.mode == modeScrollback {
+		return m.scrollHeight()
+	}
+	return max(1, m.height-lipgloss.Height(m.renderBottom()))
+}
+
+// openScrollback hands the keys to the viewport. With a query the view
+// jumps to the most recent match; otherwise it starts at the bottom.
+func (m model) openScrollback(query string) (tea.Model, tea.Cmd) {
+	m.syncOutput()
+	if len(m.scrollLines) == 0 {
+		return m, printLine(dimStyle.Render("  No output yet."))
+	}
+
+	m.mode = modeScrollback
+	m.cmdMenuOpen = false
+	m.scrollFold = -1
+	m.scrollQuery = strings.TrimSpace(query)
+	m.scrollMatches = findLines(m.scrollLines, query)
+	m.scrollMatchIdx = len(m.scrollMatches) - 1
+	m.scrollStatus = ""
+	if m.scrollQuery != "" && len(m.scrollMatches) == 0 {
+		m.scrollStatus = warnMsgStyle.Render(fmt.Sprintf("No matches for %q", m.scrollQuery))
+	}
+
+	m.scrollView.Width = m.scrollWidth()
+	m.scrollView.Height = m.scrollHeight()
+	if len(m.scrollMatches) > 0 {
+		m.scrollToMatch()
+	} else {
+		m.refreshScrollback()
+		m.scrollView.GotoBottom()
+	}
+	return m, nil
+}
+
+// handleScrollbackKey handles keys while the viewport has them.
+func (m model) handleScrollbackKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEsc, tea.KeyCtrlC:
+		return m.closeScrollback()
+	case tea.KeyHome:
+		m.scrollView.GotoTop()
+		return m, nil
+	case tea.KeyEnd:
+		m.scrollView.GotoBottom()
+		return m, nil
+	case tea.KeyEnter:
+		m.toggleFold(m.focusedFold())
+		return m, nil
+	case tea.KeyTab:
+		m.stepFold(1)
+		return m, nil
+	case tea.KeyShiftTab:
+		m.stepFold(-1)
+		return m, nil
+	case tea.KeyRunes:
+		switch string(msg.Runes) {
+		case "q":
+			return m.closeScrollback()
+		case "n":
+			m.stepMatch(1)
+			return m, nil
+		case "N":
+			m.stepMatch(-1)
+			return m, nil
+		case "g":
+			m.scrollView.GotoTop()
+			return m, nil
+		case "G":
+			m.scrollView.GotoBottom()
+			return m, nil
+		case "y", "Y":
+			m.scrollStatus = m.copyAnswer()
+			return m, nil
+		case "c":
+			m.scrollStatus = m.copySessionLink(m.sessionID)
+			return m, nil
+		case "u":
+			m.scrollStatus = m.copySessionUUID(m.sessionID)
+			return m, nil
+		case "z":
+			m.toggleFold(m.focusedFold())
+			return m, nil
+		case "Z":
+			m.setAllFolds(slices.Contains(m.scrollOpen, false))
+			return m, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	m.scrollView, cmd = m.scrollView.Update(msg)
+	return m, cmd
+}
+
+// closeScrollback gives the keys back to the input and returns the
+// viewport to the latest output.
+func (m model) closeScrollback() (tea.Model, tea.Cmd) {
+	m.mode = modeIdle
+	m.scrollMatches = nil
+	m.scrollQuery = ""
+	m.scrollStatus = ""
+	m.scrollFold = -1
+	m.refreshScrollback()
+	m.scrollView.GotoBottom()
+	return m, nil
+}
+
+// stepMatch moves to the next (dir > 0) or previous match, wrapping around.
+func (m *model) stepMatch(dir int) {
+	if len(m.scrollMatches) == 0 {
+		return
+	}
+	m.scrollMatchIdx = (m.scrollMatchIdx + dir + len(m.scrollMatches)) % len(m.scrollMatches)
+	m.scrollToMatch()
+}
+
+// scrollToMatch centres the current match in the viewport, expanding the
+// chain-of-thought step it is folded into.
+func (m *model) scrollToMatch() {
+	if m.scrollMatchIdx < 0 || m.scrollMatchIdx >= len(m.scrollMatches) {
+		m.refreshScrollback()
+		return
+	}
+	line := m.scrollMatches[m.scrollMatchIdx]
+	if f := m.foldAt(line); f >= 0 {
+		m.scrollOpen[f] = true
+	}
+	m.refreshScrollback()
+	m.scrollView.SetYOffset(m.rowOf(line) - m.scrollView.Height/2)
+}
+
+// ─── Clipboard ──────────────────────────────────────────────────────────────
+//
+// Copying straight out of the terminal picks up wrapping and ANSI styling,
+// so these helpers copy the plain source text instead. Each returns a short
+// styled status line for the caller to show.
+
+// copyText writes text to the clipboard and reports the outcome.
+func copyText(text, what string) string {
+	if err := writeClipboard(text); err != nil {
+		return errorMsgStyle.Render(fmt.Sprintf("✗ Copy failed: %v", err))
+	}
+	return successMsgStyle.Render("✓ Copied " + what)
+}
+
+// copyAnswer copies the most recent investigation answer as raw markdown.
+func (m model) copyAnswer() string {
+	if m.lastAnswer == "" {
+		return warnMsgStyle.Render("! No answer to copy yet")
+	}
+	return copyText(m.lastAnswer, fmt.Sprintf("answer (%d chars)", len(m.lastAnswer)))
+}
+
+// copySessionLink copies the web UI URL for a session.
+func (m model) copySessionLink(sessionUUID string) string {
+	if sessionUUID == "" {
+		return warnMsgStyle.Render("! No active session")
+	}
+	if m.cfg == nil || m.cfg.Server == "" || m.cfg.ProjectID == "" {
+		return warnMsgStyle.Render("! No project set")
+	}
+	return copyText(service.BuildSessionURL(m.cfg.Server, m.cfg.ProjectID, sessionUUID), "session link")
+}
+
+// copySessionUUID copies a session UUID.
+func (m model) copySessionUUID(sessionUUID string) string {
+	if sessionUUID == "" {
+		return warnMsgStyle.Render("! No active session")
+	}
+	return copyText(sessionUUID, "session UUID")
+}
+
+// refreshScrollback rebuilds the viewport content, leaving out the bodies
+// of collapsed chain-of-thought steps. While the viewport has the keys a
+// gutter marks matches and the selected step.
+func (m *model) refreshScrollback() {
+	gutter := m.mode == modeScrollback
+	current := -1
+	if m.scrollMatchIdx >= 0 && m.scrollMatchIdx < len(m.scrollMatches) {
+		current = m.scrollMatches[m.scrollMatchIdx]
+	}
+	matched := make(map[int]bool, len(m.scrollMatches))
+	for _, i := range m.scrollMatches {
+		matched[i] = true
+	}
+	headers := make(map[int]int, len(m.scrollFolds))
+	for f, fr := range m.scrollFolds {
+		headers[fr.header] = f
+	}
+
+	m.scrollRows = foldRows(m.scrollLines, m.scrollFolds, m.scrollOpen)
+	var b strings.Builder
+	for r, i := range m.scrollRows {
+		f, isHeader := headers[i]
+		switch {
+		case !gutter:
+		case i == current:
+			b.WriteString(scrollCurrentMatchStyle.Render("▶ "))
+		case matched[i]:
+			b.WriteString(scrollMatchStyle.Render("│ "))
+		case isHeader && f == m.scrollFold:
+			b.WriteString(scrollCurrentMatchStyle.Render("› "))
+		default:
+			b.WriteString("  ")
+		}
+		b.WriteString(m.scrollLines[i])
+		if isHeader {
+			b.WriteString(foldMarker(m.scrollFolds[f], m.scrollOpen[f]))
+		}
+		if r < len(m.scrollRows)-1 {
+			b.WriteString("\n")
+		}
+	}
+	m.scrollView.SetContent(b.String())
+}
+
+func (m model) scrollWidth() int {
+	if m.width < 20 {
+		return 80
+	}
+	return m.width
+}
+
+// scrollHeight leaves room for the title, separator and hint bar.
+func (m model) scrollHeight() int {
+	h := m.height - 4
+	if h < 5 {
+		h = 5
+	}
+	return h
+}
+
+// renderScrollback renders the title line and the viewport while the
+// viewport has the keys.
+func (m model) renderScrollback() string {
+	title := fmt.Sprintf("  Scrollback · %d lines", len(m.scrollLines))
+	if m.scrollQuery != "" && len(m.scrollMatches) > 0 {
+		title += fmt.Sprintf(" · %q %d/%d", m.scrollQuery, m.scrollMatchIdx+1, len(m.scrollMatches))
+	}
+	title += fmt.Sprintf(" · %3.f%%", m.scrollView.ScrollPercent()*100)
+	header := dimStyle.Render(title)
+	if m.scrollStatus != "" {
+		header += "  " + m.scrollStatus
+	}
+	return header + "\n" + m.scrollView.View()
+}
+
+// ─── /find ──────────────────────────────────────────────────────────────────
+
+func (m model) cmdFind(args []string) (tea.Model, tea.Cmd) {
+	query := strings.Join(args, " ")
+	if strings.TrimSpace(query) == "" {
+		return m, printLine(warnMsgStyle.Render("  ! Usage: /find <text>"))
+	}
+	return m.openScrollback(query)
+}
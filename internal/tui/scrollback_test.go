@@ -0,0 +1,296 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"hawkeye-cli/internal/api"
+	"hawkeye-cli/internal/display"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestStripANSI(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"plain", "plain"},
+		{"\x1b[38;5;78m✓ done\x1b[0m", "✓ done"},
+		{"\x1b[1mbold\x1b[22m and \x1b[3mitalic\x1b[23m", "bold and italic"},
+	}
+	for _, tt := range tests {
+		if got := stripANSI(tt.in); got != tt.want {
+			t.Errorf("stripANSI(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestFindLines(t *testing.T) {
+	lines := []string{
+		"  ❯ why is checkout slow",
+		"\x1b[1m  Root cause:\x1b[0m DB pool exhausted",
+		"  db connections at 100%",
+		"",
+	}
+	tests := []struct {
+		query string
+		want  []int
+	}{
+		{"", nil},
+		{"   ", nil},
+		{"root cause", []int{1}},
+		{"DB", []int{1, 2}},
+		{"missing", nil},
+	}
+	for _, tt := range tests {
+		got := findLines(lines, tt.query)
+		if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+			t.Errorf("findLines(%q) = %v, want %v", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestOutputLogCap(t *testing.T) {
+	var l outputLog
+	l.append("a\nb")
+	if got := l.snapshot(); len(got) != 2 || got[1] != "b" {
+		t.Fatalf("snapshot = %q, want [a b]", got)
+	}
+	for i := 0; i < maxOutputLines; i++ {
+		l.append(fmt.Sprintf("line-%d", i))
+	}
+	got := l.snapshot()
+	if len(got) != maxOutputLines {
+		t.Fatalf("len = %d, want %d", len(got), maxOutputLines)
+	}
+	if got[0] != "line-0" || got[len(got)-1] != fmt.Sprintf("line-%d", maxOutputLines-1) {
+		t.Errorf("oldest lines not dropped: first=%q last=%q", got[0], got[len(got)-1])
+	}
+	l.reset()
+	if len(l.snapshot()) != 0 {
+		t.Error("reset() left lines behind")
+	}
+}
+
+func TestPrintLineRecordsOutput(t *testing.T) {
+	printedOutput.reset()
+	defer printedOutput.reset()
+
+	cmd := printLine("hello\nworld")
+	if got := printedOutput.snapshot(); len(got) != 0 {
+		t.Fatalf("recorded before the command ran: %q", got)
+	}
+	cmd()
+	if got := printedOutput.snapshot(); strings.Join(got, "|") != "hello|world" {
+		t.Errorf("snapshot = %q", got)
+	}
+}
+
+func TestScrollbackFind(t *testing.T) {
+	printedOutput.reset()
+	defer printedOutput.reset()
+	for i := 0; i < 50; i++ {
+		printedOutput.append(fmt.Sprintf("  step %d", i))
+	}
+	printedOutput.append("  error: timeout talking to db")
+	printedOutput.append("  step 50")
+
+	m := newTestModel()
+	result, _ := m.dispatchInput("/find timeout")
+	rm := result.(model)
+	if rm.mode != modeScrollback {
+		t.Fatalf("mode = %d, want modeScrollback", rm.mode)
+	}
+	if len(rm.scrollMatches) != 1 || rm.scrollMatches[0] != 50 {
+		t.Errorf("scrollMatches = %v, want [50]", rm.scrollMatches)
+	}
+	if !strings.Contains(rm.scrollView.View(), "timeout") {
+		t.Error("viewport does not show the match")
+	}
+	if !strings.Contains(rm.View(), "1/1") {
+		t.Error("view missing match counter")
+	}
+
+	// n wraps around with a single match; Esc closes.
+	result, _ = rm.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")})
+	rm = result.(model)
+	if rm.scrollMatchIdx != 0 {
+		t.Errorf("scrollMatchIdx = %d, want 0", rm.scrollMatchIdx)
+	}
+	result, _ = rm.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if rm = result.(model); rm.mode != modeIdle {
+		t.Errorf("mode after Esc = %d, want modeIdle", rm.mode)
+	}
+}
+
+func TestScrollbackOpen(t *testing.T) {
+	printedOutput.reset()
+	defer printedOutput.reset()
+
+	m := newTestModel()
+	result, _ := m.Update(tea.KeyMsg{Type: tea.KeyPgUp})
+	if rm := result.(model); rm.mode != modeIdle {
+		t.Errorf("PgUp with no output: mode = %d, want modeIdle", rm.mode)
+	}
+
+	printedOutput.append("  hello")
+	result, _ = m.Update(tea.KeyMsg{Type: tea.KeyPgUp})
+	if rm := result.(model); rm.mode != modeScrollback {
+		t.Errorf("PgUp: mode = %d, want modeScrollback", rm.mode)
+	}
+
+	result, _ = m.dispatchInput("/find")
+	if rm := result.(model); rm.mode != modeIdle {
+		t.Errorf("/find without text: mode = %d, want modeIdle", rm.mode)
+	}
+}
+
+func TestConversationViewport(t *testing.T) {
+	printedOutput.reset()
+	defer printedOutput.reset()
+
+	var result tea.Model = newTestModel()
+	for i := 0; i < 40; i++ {
+		result, _ = result.Update(printLine(fmt.Sprintf("  line %d", i))())
+	}
+	rm := result.(model)
+	view := rm.View()
+	if !strings.Contains(view, "line 39") || !strings.Contains(view, "❯") {
+		t.Fatalf("view is missing the latest output or the prompt:\n%s", view)
+	}
+	for _, line := range strings.Split(view, "\n") {
+		if strings.TrimSpace(line) == "line 0" {
+			t.Errorf("view shows the oldest line instead of following the output:\n%s", view)
+		}
+	}
+	if got := strings.Count(view, "\n") + 1; got != rm.height {
+		t.Errorf("view is %d rows, want the terminal height %d", got, rm.height)
+	}
+
+	// PgUp scrolls while a stream runs, and new output leaves a scrolled
+	// viewport where it is.
+	rm.mode = modeStreaming
+	result, _ = rm.Update(tea.KeyMsg{Type: tea.KeyPgUp})
+	if rm = result.(model); rm.mode != modeStreaming || rm.scrollView.AtBottom() {
+		t.Fatalf("PgUp while streaming: mode = %d, at bottom = %v", rm.mode, rm.scrollView.AtBottom())
+	}
+	result, _ = rm.Update(printLine("  line 40")())
+	if rm = result.(model); rm.scrollView.AtBottom() {
+		t.Error("new output pulled the scrolled viewport back to the bottom")
+	}
+}
+
+func TestScrollbackYank(t *testing.T) {
+	printedOutput.reset()
+	defer printedOutput.reset()
+	printedOutput.append("  answer")
+
+	var copied string
+	orig := writeClipboard
+	writeClipboard = func(s string) error { copied = s; return nil }
+	defer func() { writeClipboard = orig }()
+
+	m := newTestModel()
+	result, _ := m.openScrollback("")
+	rm := result.(model)
+	result, _ = rm.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	rm = result.(model)
+	if copied != "" || !strings.Contains(rm.scrollStatus, "No answer") {
+		t.Errorf("yank with no answer: copied=%q status=%q", copied, rm.scrollStatus)
+	}
+
+	rm.lastAnswer = "Root cause: DB pool exhausted"
+	result, _ = rm.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	rm = result.(model)
+	if copied != "Root cause: DB pool exhausted" {
+		t.Errorf("copied = %q", copied)
+	}
+	if rm.mode != modeScrollback {
+		t.Error("yank should keep the viewer open")
+	}
+}
+
+func TestStreamRecordsLastAnswer(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("SNAP_USER_COMMON", "")
+
+	m := newTestModel()
+	m.mode = modeStreaming
+	m.recordAnswer(OutputEvent{Type: OutputChat, Text: "line one"})
+	m.recordAnswer(OutputEvent{Type: OutputCOTHeader, Text: "ignored"})
+	m.recordAnswer(OutputEvent{Type: OutputChat, Text: "line two"})
+
+	result, _ := m.Update(streamDoneMsg{sessionID: "s1"})
+	rm := result.(model)
+	if rm.lastAnswer != "line one\nline two" {
+		t.Errorf("lastAnswer = %q", rm.lastAnswer)
+	}
+	if rm.streamAnswer != "" {
+		t.Errorf("streamAnswer not reset: %q", rm.streamAnswer)
+	}
+	if rm.cfg.LastSession != "s1" {
+		t.Errorf("LastSession = %q, want s1 for resume", rm.cfg.LastSession)
+	}
+}
+
+func TestCopyHelpers(t *testing.T) {
+	var copied string
+	orig := writeClipboard
+	writeClipboard = func(s string) error { copied = s; return nil }
+	defer func() { writeClipboard = orig }()
+
+	m := newTestModel()
+	tests := []struct {
+		name       string
+		copy       func() string
+		wantCopied string
+		wantStatus string
+	}{
+		{"link", func() string { return m.copySessionLink("sess-1") }, "http://localhost:8080/console/project/proj-1/session/sess-1?tab=results", "session link"},
+		{"uuid", func() string { return m.copySessionUUID("sess-1") }, "sess-1", "session UUID"},
+		{"no session", func() string { return m.copySessionUUID("") }, "", "No active session"},
+		{"no answer", m.copyAnswer, "", "No answer"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			copied = ""
+			status := tt.copy()
+			if copied != tt.wantCopied {
+				t.Errorf("copied = %q, want %q", copied, tt.wantCopied)
+			}
+			if !strings.Contains(status, tt.wantStatus) {
+				t.Errorf("status = %q, want %q", status, tt.wantStatus)
+			}
+		})
+	}
+
+	copied = ""
+	m.sessionList = []api.SessionInfo{{SessionUUID: "sess-9"}}
+	m.mode = modeSessionSelect
+	result, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("u")})
+	if copied != "sess-9" {
+		t.Errorf("session picker u copied %q, want sess-9", copied)
+	}
+	if rm := result.(model); rm.mode != modeSessionSelect || rm.input.Value() != "" {
+		t.Errorf("picker state changed: mode=%d input=%q", rm.mode, rm.input.Value())
+	}
+
+	writeClipboard = func(string) error { return fmt.Errorf("no clipboard") }
+	if got := m.copySessionUUID("x"); !strings.Contains(got, "no clipboard") {
+		t.Errorf("failure status = %q", got)
+	}
+}
+
+func TestPrintLineASCII(t *testing.T) {
+	printedOutput.reset()
+	defer printedOutput.reset()
+	display.SetASCII(true)
+	defer display.SetASCII(false)
+
+	printLine("  ✓ Project set")()
+	if got := printedOutput.snapshot(); len(got) != 1 || got[0] != "  [ok] Project set" {
+		t.Errorf("snapshot = %q", got)
+	}
+}
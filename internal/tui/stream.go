@@ -3,6 +3,7 @@ package tui
 import (
 	"encoding/json"
 	"strings"
+	"time"
 
 	"hawkeye-cli/internal/api"
 	"hawkeye-cli/internal/service"
@@ -17,6 +18,7 @@ type sessionCreatedMsg struct {
 }
 
 type streamChunkMsg struct {
+	job         int // streamJob.id; 0 for messages not tied to a job
 	contentType string
 	eventType   string
 	text        string
@@ -24,10 +26,12 @@ type streamChunkMsg struct {
 }
 
 type streamDoneMsg struct {
+	job       int
 	sessionID string
 }
 
 type streamErrMsg struct {
+	job int
 	err error
 }
 
@@ -37,34 +41,106 @@ type streamErrMsg struct {
 // channel, and returns a tea.Cmd that keeps reading from that channel
 // until the stream ends.
 
-func startInvestigation(client api.HawkeyeAPI, projectID, sessionID, prompt string) tea.Cmd {
+func startInvestigation(client api.HawkeyeAPI, projectID, sessionID, prompt string, autoName bool) tea.Cmd {
 	return func() tea.Msg {
-		// Create session if none provided
+		// Create session if none provided, named after its first prompt.
+		// Naming is best effort; the investigation runs either way.
 		if sessionID == "" {
 			resp, err := client.NewSession(projectID)
 			if err != nil {
 				return streamErrMsg{err: err}
 			}
 			sessionID = resp.SessionUUID
+			if autoName {
+				_ = client.RenameSession(projectID, sessionID, service.SessionTitle(prompt))
+			}
 		}
 		// Return the session ID first, then start streaming
 		return sessionCreatedMsg{sessionID: sessionID}
 	}
 }
 
-// streamChannel is stored in the model so we can keep reading from it.
-// We use a simple pattern: each waitForStream call reads one message
-// and returns it. The model's Update dispatches another waitForStream
-// after each chunk.
+// streamJob is one running investigation stream. Its goroutine forwards
+// events on ch until the server ends the stream or the job is cancelled.
+// Each waitForStream call reads one message and returns it; the model's
+// Update dispatches another waitForStream after each chunk.
+//
+// A job is either the foreground stream (activeStream), printed as it
+// arrives, or a background job in model.jobs, whose events are processed
+// without printing until it finishes.
+type streamJob struct {
+	id        int
+	ch        chan tea.Msg
+	cancelled chan struct{} // closed to stop forwarding events
+	sessionID string
+	prompt    string
+	started   time.Time
+
+	// Background state, carried over from the model when the job leaves
+	// the foreground.
+	processor *StreamProcessor
+	answer    string
+	output    []string // rendered lines not yet printed
+}
+
+// activeStream is the foreground stream, nil when none is running.
+var activeStream *streamJob
 
-var activeStreamCh chan tea.Msg
+// lastJobID numbers stream jobs; ids start at 1 so that 0 means "no job".
+var lastJobID int
+
+// activeStreamID returns the id of the foreground stream, 0 when none.
+func activeStreamID() int {
+	if activeStream == nil {
+		return 0
+	}
+	return activeStream.id
+}
+
+// send forwards msg unless the job has been cancelled, so a cancelled
+// stream's goroutine never blocks on a channel nobody reads.
+func (j *streamJob) send(msg tea.Msg) {
+	select {
+	case j.ch <- msg:
+	case <-j.cancelled:
+	}
+}
+
+// cancel stops forwarding the job's events. The server-side investigation
+// is not stopped.
+func (j *streamJob) cancel() {
+	select {
+	case <-j.cancelled:
+	default:
+		close(j.cancelled)
+	}
+}
+
+// status is the job's latest progress line.
+func (j *streamJob) status() string {
+	if j.processor != nil {
+		if s := j.processor.LastStatus(); s != "" {
+			return s
+		}
+	}
+	return "Investigating..."
+}
 
 func beginStream(client api.HawkeyeAPI, projectID, sessionID, prompt string) tea.Cmd {
-	ch := make(chan tea.Msg, 64)
-	activeStreamCh = ch
+	lastJobID++
+	job := &streamJob{
+		id:        lastJobID,
+		ch:        make(chan tea.Msg, 64),
+		cancelled: make(chan struct{}),
+		sessionID: sessionID,
+		prompt:    prompt,
+		started:   time.Now(),
+	}
+	activeStream = job
+	id, send := job.id, job.send
 
 	go func() {
-		defer close(ch)
+		defer close(job.ch)
 
 		err := client.ProcessPromptStream(projectID, sessionID, prompt, func(resp *api.ProcessPromptResponse) {
 			if resp.Message == nil || resp.Message.Content == nil {
@@ -77,12 +153,12 @@ func beginStream(client api.HawkeyeAPI, projectID, sessionID, prompt string) tea
 			switch ct {
 			case "CONTENT_TYPE_PROGRESS_STATUS":
 				if len(parts) > 0 {
-					ch <- streamChunkMsg{contentType: ct, text: parts[0], raw: resp}
+					send(streamChunkMsg{job: id, contentType: ct, text: parts[0], raw: resp})
 				}
 
 			case "CONTENT_TYPE_SOURCES":
 				for _, raw := range parts {
-					ch <- streamChunkMsg{contentType: ct, text: raw, raw: resp}
+					send(streamChunkMsg{job: id, contentType: ct, text: raw, raw: resp})
 				}
 
 			case "CONTENT_TYPE_CHAIN_OF_THOUGHT":
@@ -91,7 +167,7 @@ func beginStream(client api.HawkeyeAPI, projectID, sessionID, prompt string) tea
 				case "cot_start", "cot_delta", "cot_end":
 					// Delta protocol: parts[0] is the active COT
 					if len(parts) > 0 {
-						ch <- streamChunkMsg{contentType: ct, eventType: et, text: parts[0], raw: resp}
+						send(streamChunkMsg{job: id, contentType: ct, eventType: et, text: parts[0], raw: resp})
 					}
 				default:
 					// Legacy: server sends ALL COT steps in parts[].
@@ -100,7 +176,7 @@ func beginStream(client api.HawkeyeAPI, projectID, sessionID, prompt string) tea
 					// This mirrors what stream_display.go does.
 					activePart := findActiveCOTPart(parts)
 					if activePart != "" {
-						ch <- streamChunkMsg{contentType: ct, eventType: et, text: activePart, raw: resp}
+						send(streamChunkMsg{job: id, contentType: ct, eventType: et, text: activePart, raw: resp})
 					}
 				}
 
@@ -110,58 +186,58 @@ func beginStream(client api.HawkeyeAPI, projectID, sessionID, prompt string) tea
 					// Delta mode: parts[0] is the new fragment
 					if len(parts) > 0 {
 						text := service.StripHTML(parts[0])
-						ch <- streamChunkMsg{contentType: ct, eventType: "chat_delta", text: text, raw: resp}
+						send(streamChunkMsg{job: id, contentType: ct, eventType: "chat_delta", text: text, raw: resp})
 					}
 				} else {
 					// Full text mode: join all parts
 					text := strings.Join(parts, "\n")
 					text = service.StripHTML(text)
-					ch <- streamChunkMsg{contentType: ct, eventType: "chat_full", text: text, raw: resp}
+					send(streamChunkMsg{job: id, contentType: ct, eventType: "chat_full", text: text, raw: resp})
 				}
 
 			case "CONTENT_TYPE_FOLLOW_UP_SUGGESTIONS":
-				ch <- streamChunkMsg{contentType: ct, text: strings.Join(parts, "\n"), raw: resp}
+				send(streamChunkMsg{job: id, contentType: ct, text: strings.Join(parts, "\n"), raw: resp})
 
 			case "CONTENT_TYPE_SESSION_NAME":
 				if len(parts) > 0 {
-					ch <- streamChunkMsg{contentType: ct, text: parts[0], raw: resp}
+					send(streamChunkMsg{job: id, contentType: ct, text: parts[0], raw: resp})
 				}
 
 			case "CONTENT_TYPE_ERROR_MESSAGE":
 				if len(parts) > 0 {
-					ch <- streamChunkMsg{contentType: ct, text: strings.Join(parts, "\n"), raw: resp}
+					send(streamChunkMsg{job: id, contentType: ct, text: strings.Join(parts, "\n"), raw: resp})
 				}
 
 			case "CONTENT_TYPE_EXECUTION_TIME":
 				if len(parts) > 0 {
-					ch <- streamChunkMsg{contentType: ct, text: parts[0], raw: resp}
+					send(streamChunkMsg{job: id, contentType: ct, text: parts[0], raw: resp})
 				}
 
 			default:
 				if len(parts) > 0 {
-					ch <- streamChunkMsg{contentType: ct, text: parts[0], raw: resp}
+					send(streamChunkMsg{job: id, contentType: ct, text: parts[0], raw: resp})
 				}
 			}
 
 			if resp.Message.EndTurn {
-				ch <- streamDoneMsg{sessionID: sessionID}
+				send(streamDoneMsg{job: id, sessionID: sessionID})
 			}
 		})
 
 		if err != nil {
-			ch <- streamErrMsg{err: err}
+			send(streamErrMsg{job: id, err: err})
 		}
 	}()
 
-	return waitForStream(ch)
+	return waitForStream(job)
 }
 
-// waitForStream reads the next message from the channel.
-func waitForStream(ch <-chan tea.Msg) tea.Cmd {
+// waitForStream reads the next message from a job's channel.
+func waitForStream(job *streamJob) tea.Cmd {
 	return func() tea.Msg {
-		msg, ok := <-ch
+		msg, ok := <-job.ch
 		if !ok {
-			return streamDoneMsg{}
+			return streamDoneMsg{job: job.id}
 		}
 		return msg
 	}
@@ -38,6 +38,7 @@ type OutputEvent struct {
 	CotDesc  string // COT step description (COTHeader only)
 	Finished bool   // true when this block/step is complete
 	Index    int    // 1-based index for numbered items (follow-ups)
+	Lang     string // code block language (CodeLine only)
 }
 
 // ─── StreamProcessor ────────────────────────────────────────────────────────
@@ -66,7 +67,9 @@ type StreamProcessor struct {
 	tableBuffer []string
 
 	// Code block state — tracks whether we're inside a fenced code block
+	// and the language named on its opening fence
 	inCodeBlock bool
+	codeLang    string
 
 	// Gating
 	seenSources     map[string]bool
@@ -294,7 +297,7 @@ func (sp *StreamProcessor) handleCOT(msg streamChunkMsg) []OutputEvent {
 					out = append(out, sp.flushTableBuffer()...)
 					// Check for code block fences
 					if strings.HasPrefix(trimmed, "```") {
-						sp.inCodeBlock = !sp.inCodeBlock
+						sp.toggleCodeBlock(trimmed)
 						out = append(out, OutputEvent{
 							Type:  OutputCodeFence,
 							Text:  line,
@@ -305,6 +308,7 @@ func (sp *StreamProcessor) handleCOT(msg streamChunkMsg) []OutputEvent {
 							Type:  OutputCodeLine,
 							Text:  line,
 							CotID: cotID,
+							Lang:  sp.codeLang,
 						})
 					} else {
 						out = append(out, OutputEvent{
@@ -452,16 +456,25 @@ func (sp *StreamProcessor) routeChatLine(line string) []OutputEvent {
 
 	// Check for code block fences
 	if strings.HasPrefix(trimmed, "```") {
-		sp.inCodeBlock = !sp.inCodeBlock
+		sp.toggleCodeBlock(trimmed)
 		out = append(out, OutputEvent{Type: OutputCodeFence, Text: line})
 	} else if sp.inCodeBlock {
-		out = append(out, OutputEvent{Type: OutputCodeLine, Text: line})
+		out = append(out, OutputEvent{Type: OutputCodeLine, Text: line, Lang: sp.codeLang})
 	} else {
 		out = append(out, OutputEvent{Type: OutputChat, Text: line})
 	}
 	return out
 }
 
+// toggleCodeBlock enters or leaves a fenced code block at a ``` line.
+func (sp *StreamProcessor) toggleCodeBlock(fence string) {
+	sp.inCodeBlock = !sp.inCodeBlock
+	sp.codeLang = ""
+	if sp.inCodeBlock {
+		sp.codeLang = strings.TrimSpace(strings.TrimPrefix(fence, "```"))
+	}
+}
+
 // flushTableBuffer emits the accumulated table rows as a single OutputTable event.
 func (sp *StreamProcessor) flushTableBuffer() []OutputEvent {
 	if len(sp.tableBuffer) == 0 {
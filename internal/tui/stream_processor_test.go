@@ -802,6 +802,22 @@ func TestCodeBlockInCOT(t *testing.T) {
 	}
 }
 
+func TestCodeBlockLanguage(t *testing.T) {
+	sp := NewStreamProcessor()
+
+	out := sp.Process(chatDeltaMsg("```go\nx := 1\n```\n```\nplain\n```\n"))
+
+	var langs []string
+	for _, ev := range out {
+		if ev.Type == OutputCodeLine {
+			langs = append(langs, ev.Lang)
+		}
+	}
+	if len(langs) != 2 || langs[0] != "go" || langs[1] != "" {
+		t.Errorf("code line languages = %q, want [go \"\"]", langs)
+	}
+}
+
 func TestCodeBlockPreventsPipeAsTable(t *testing.T) {
 	sp := NewStreamProcessor()
 
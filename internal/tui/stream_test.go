@@ -198,7 +198,7 @@ func TestMatchCommands(t *testing.T) {
 		{"/login", 1},            // /login
 		{"/se", 3},               // /session, /session-report, /set
 		{"/connections", 1},      // only /connections itself (no space yet)
-		{"/connections ", 2},     // subcommands: list, resources
+		{"/connections ", 3},     // subcommands: create, list, resources
 		{"/connections a", 0},    // no subcommands under connections starting with a
 		{"/connections list", 1}, // /connections list
 		{"/incidents", 1},        // only /incidents itself
@@ -244,3 +244,42 @@ func TestTruncateUUID(t *testing.T) {
 		})
 	}
 }
+
+func TestStartInvestigationAutoName(t *testing.T) {
+	tests := []struct {
+		name      string
+		sessionID string
+		autoName  bool
+		wantName  string
+	}{
+		{"new session named", "", true, "Why are pods crashlooping?"},
+		{"auto-name disabled", "", false, ""},
+		{"existing session untouched", "sess-1", true, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &mockAPI{}
+			msg := startInvestigation(client, "proj-1", tt.sessionID, "Why are pods crashlooping?", tt.autoName)()
+			created, ok := msg.(sessionCreatedMsg)
+			if !ok {
+				t.Fatalf("msg = %T, want sessionCreatedMsg", msg)
+			}
+			if got := client.renamed[created.sessionID]; got != tt.wantName {
+				t.Errorf("renamed %s to %q, want %q", created.sessionID, got, tt.wantName)
+			}
+		})
+	}
+}
+
+func TestSlashSubcommandsHaveParents(t *testing.T) {
+	registry := slashRegistry()
+	for _, c := range slashSubcommands {
+		parent, _, _ := strings.Cut(c.name, " ")
+		if _, ok := registry.Lookup(parent); !ok {
+			t.Errorf("%s: %s is not a registered command", c.name, parent)
+		}
+	}
+	if c, ok := registry.Lookup("/td"); !ok || c.Name != "/feedback" {
+		t.Errorf("/td = %+v, %v; want /feedback", c, ok)
+	}
+}
@@ -1,188 +1,207 @@
 package tui
 
-import "github.com/charmbracelet/lipgloss"
+import (
+	"hawkeye-cli/internal/display"
 
-// ─── Adaptive Colors ─────────────────────────────────────────────────────────
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ─── Color Palette ───────────────────────────────────────────────────────────
 //
-// We detect the terminal background at init time and pick a color scheme
-// that contrasts well. Dark backgrounds get brighter colors; light backgrounds
-// get darker colors.
+// Colors come from the display package's active theme, so the CLI and the
+// TUI share one palette. The styles below are rebuilt by applyTheme, which
+// Run calls once the configured theme has been loaded.
 
-var hasDarkBg = lipgloss.HasDarkBackground()
+var (
+	colorOrange    lipgloss.TerminalColor // primary accent
+	colorGreen     lipgloss.TerminalColor
+	colorYellow    lipgloss.TerminalColor
+	colorRed       lipgloss.TerminalColor
+	colorBlue      lipgloss.TerminalColor
+	colorCyan      lipgloss.TerminalColor // info — links, session names, interactive elements
+	colorLightCyan lipgloss.TerminalColor // accent teal — COT, structural chrome
+	colorGray      lipgloss.TerminalColor
+	colorDimGray   lipgloss.TerminalColor
+	colorWhite     lipgloss.TerminalColor // body text: white on dark, near-black on light
+)
 
-// adaptiveColor returns the appropriate color based on terminal background.
-func adaptiveColor(dark, light lipgloss.Color) lipgloss.Color {
-	if hasDarkBg {
-		return dark
+// themeColor converts a palette color; empty means the terminal default.
+func themeColor(c string) lipgloss.TerminalColor {
+	if c == "" {
+		return lipgloss.NoColor{}
 	}
-	return light
+	return lipgloss.Color(c)
 }
 
-// ─── Color Palette ───────────────────────────────────────────────────────────
-
 var (
-	// Primary accent — orange (works on both, slightly adjusted)
-	colorOrange = adaptiveColor(
-		lipgloss.Color("#F28C28"), // warm orange for dark bg
-		lipgloss.Color("#D2691E"), // darker orange for light bg
-	)
-
-	// Greens — 78/28: matches ansiSuccess in render.go
-	colorGreen = adaptiveColor(
-		lipgloss.Color("78"), // green 78 for dark bg — softer than neon 46
-		lipgloss.Color("28"), // green 28 for light bg
-	)
-
-	// Yellows
-	colorYellow = adaptiveColor(
-		lipgloss.Color("220"), // bright yellow for dark bg
-		lipgloss.Color("136"), // darker gold for light bg
-	)
-
-	// Reds
-	colorRed = adaptiveColor(
-		lipgloss.Color("196"), // bright red for dark bg
-		lipgloss.Color("160"), // darker red for light bg
-	)
-
-	// Blues
-	colorBlue = adaptiveColor(
-		lipgloss.Color("111"), // bright blue for dark bg
-		lipgloss.Color("26"),  // darker blue for light bg
-	)
-
-	// Cyans — unified to one family
-	// 39/25: info cyan — links, session names, interactive elements
-	colorCyan = adaptiveColor(
-		lipgloss.Color("39"), // cyan 39 for dark bg — matches ansiInfo in render.go
-		lipgloss.Color("25"), // blue 25 for light bg
-	)
-	// 73/30: accent teal — COT, structural chrome (softer than cyan)
-	colorLightCyan = adaptiveColor(
-		lipgloss.Color("73"), // teal 73 for dark bg — matches ansiAccent in render.go
-		lipgloss.Color("30"), // teal 30 for light bg
-	)
-
-	// Grays — these need the most adjustment
-	colorGray = adaptiveColor(
-		lipgloss.Color("250"), // light gray for dark bg (visible!)
-		lipgloss.Color("240"), // darker gray for light bg
-	)
-	colorDimGray = adaptiveColor(
-		lipgloss.Color("240"), // gray 240 for dark bg — visible but dimmer than body (252)
-		lipgloss.Color("245"), // mid gray for light bg — dimmer than body text
-	)
-
-	// White/Black swap for text
-	colorWhite = adaptiveColor(
-		lipgloss.Color("255"), // white for dark bg
-		lipgloss.Color("232"), // near-black for light bg
-	)
+	logoBodyStyle            lipgloss.Style
+	logoBeakStyle            lipgloss.Style
+	logoEyeStyle             lipgloss.Style
+	logoTitleStyle           lipgloss.Style
+	versionStyle             lipgloss.Style
+	welcomeHintStyle         lipgloss.Style
+	welcomeInfoLabel         lipgloss.Style
+	promptSymbol             lipgloss.Style
+	hintBarStyle             lipgloss.Style
+	hintKeyStyle             lipgloss.Style
+	cmdNameStyle             lipgloss.Style
+	cmdDescStyle             lipgloss.Style
+	cmdSelectedNameStyle     lipgloss.Style
+	cmdSelectedDescStyle     lipgloss.Style
+	successMsgStyle          lipgloss.Style
+	errorMsgStyle            lipgloss.Style
+	warnMsgStyle             lipgloss.Style
+	statusStyle              lipgloss.Style
+	userPromptStyle          lipgloss.Style
+	sourceHeaderStyle        lipgloss.Style
+	cotHeaderStyle           lipgloss.Style
+	cotExplanationStyle      lipgloss.Style
+	sessionNameStyle         lipgloss.Style
+	followUpStyle            lipgloss.Style
+	dimStyle                 lipgloss.Style
+	separatorStyle           lipgloss.Style
+	incidentRowStyle         lipgloss.Style
+	incidentRowSelectedStyle lipgloss.Style
+	scrollMatchStyle         lipgloss.Style
+	scrollCurrentMatchStyle  lipgloss.Style
 )
 
-// ─── Welcome ────────────────────────────────────────────────────────────────
+func init() {
+	applyTheme(display.CurrentPalette())
+}
+
+// applyTheme rebuilds the TUI colors and styles from a palette.
+func applyTheme(p display.Palette) {
+	colorOrange = themeColor(p.Accent)
+	colorGreen = themeColor(p.Success)
+	colorYellow = themeColor(p.Warning)
+	colorRed = themeColor(p.Error)
+	colorBlue = themeColor(p.Link)
+	colorCyan = themeColor(p.Info)
+	colorLightCyan = themeColor(p.Teal)
+	colorGray = themeColor(p.Muted)
+	colorDimGray = themeColor(p.Subtle)
+	colorWhite = themeColor(p.Text)
+
+	applyANSITheme(p)
+
+	// Welcome
+
+	logoBodyStyle = lipgloss.NewStyle().
+		Foreground(colorGray)
 
-var logoBodyStyle = lipgloss.NewStyle().
-	Foreground(colorGray)
+	logoBeakStyle = lipgloss.NewStyle().
+		Foreground(colorOrange)
 
-var logoBeakStyle = lipgloss.NewStyle().
-	Foreground(colorOrange)
+	logoEyeStyle = lipgloss.NewStyle().
+		Bold(true).
+		Foreground(colorWhite)
 
-var logoTitleStyle = lipgloss.NewStyle().
-	Bold(true).
-	Foreground(colorOrange)
+	logoTitleStyle = lipgloss.NewStyle().
+		Bold(true).
+		Foreground(colorOrange)
 
-var versionStyle = lipgloss.NewStyle().
-	Foreground(colorGray)
+	versionStyle = lipgloss.NewStyle().
+		Foreground(colorGray)
 
-var welcomeHintStyle = lipgloss.NewStyle().
-	Foreground(colorGray).
-	Italic(true)
+	welcomeHintStyle = lipgloss.NewStyle().
+		Foreground(colorGray).
+		Italic(true)
 
-var welcomeInfoLabel = lipgloss.NewStyle().
-	Foreground(colorGray)
+	welcomeInfoLabel = lipgloss.NewStyle().
+		Foreground(colorGray)
 
-// ─── Input / Prompt ─────────────────────────────────────────────────────────
+	// Input / Prompt
 
-var promptSymbol = lipgloss.NewStyle().
-	Foreground(colorOrange).
-	Bold(true)
+	promptSymbol = lipgloss.NewStyle().
+		Foreground(colorOrange).
+		Bold(true)
 
-// ─── Hint Bar ───────────────────────────────────────────────────────────────
+	// Hint Bar
 
-var hintBarStyle = lipgloss.NewStyle().
-	Foreground(colorGray)
+	hintBarStyle = lipgloss.NewStyle().
+		Foreground(colorGray)
 
-var hintKeyStyle = lipgloss.NewStyle().
-	Foreground(colorGray).
-	Bold(true)
+	hintKeyStyle = lipgloss.NewStyle().
+		Foreground(colorGray).
+		Bold(true)
 
-// Command menu styles
-var cmdNameStyle = lipgloss.NewStyle().
-	Foreground(colorOrange)
+	// Command menu styles
+	cmdNameStyle = lipgloss.NewStyle().
+		Foreground(colorOrange)
 
-var cmdDescStyle = lipgloss.NewStyle().
-	Foreground(colorGray)
+	cmdDescStyle = lipgloss.NewStyle().
+		Foreground(colorGray)
 
-// Selected/highlighted command in the menu
-var cmdSelectedNameStyle = lipgloss.NewStyle().
-	Foreground(colorOrange).
-	Bold(true).
-	Reverse(true)
+	// Selected/highlighted command in the menu
+	cmdSelectedNameStyle = lipgloss.NewStyle().
+		Foreground(colorOrange).
+		Bold(true).
+		Reverse(true)
 
-var cmdSelectedDescStyle = lipgloss.NewStyle().
-	Foreground(colorWhite).
-	Bold(true)
+	cmdSelectedDescStyle = lipgloss.NewStyle().
+		Foreground(colorWhite).
+		Bold(true)
 
-// ─── Output Styles ──────────────────────────────────────────────────────────
+	// Output Styles
 
-var successMsgStyle = lipgloss.NewStyle().
-	Foreground(colorGreen)
+	successMsgStyle = lipgloss.NewStyle().
+		Foreground(colorGreen)
 
-var errorMsgStyle = lipgloss.NewStyle().
-	Foreground(colorRed)
+	errorMsgStyle = lipgloss.NewStyle().
+		Foreground(colorRed)
 
-var warnMsgStyle = lipgloss.NewStyle().
-	Foreground(colorYellow)
+	warnMsgStyle = lipgloss.NewStyle().
+		Foreground(colorYellow)
 
-var statusStyle = lipgloss.NewStyle().
-	Foreground(colorYellow)
+	statusStyle = lipgloss.NewStyle().
+		Foreground(colorYellow)
 
-var userPromptStyle = lipgloss.NewStyle().
-	Foreground(colorOrange).
-	Bold(true)
+	userPromptStyle = lipgloss.NewStyle().
+		Foreground(colorOrange).
+		Bold(true)
 
-var sourceHeaderStyle = lipgloss.NewStyle().
-	Foreground(colorBlue).
-	Bold(true)
+	sourceHeaderStyle = lipgloss.NewStyle().
+		Foreground(colorBlue).
+		Bold(true)
 
-var cotHeaderStyle = lipgloss.NewStyle().
-	Foreground(colorCyan). // info cyan — investigation step marker
-	Bold(true)
+	cotHeaderStyle = lipgloss.NewStyle().
+		Foreground(colorCyan). // info cyan — investigation step marker
+		Bold(true)
 
-// COT explanation text (↳ line) — visible on both backgrounds
-var cotExplanationStyle = lipgloss.NewStyle().
-	Foreground(colorLightCyan)
+	// COT explanation text (↳ line) — visible on both backgrounds
+	cotExplanationStyle = lipgloss.NewStyle().
+		Foreground(colorLightCyan)
 
-// Session name style — visible and distinct
-var sessionNameStyle = lipgloss.NewStyle().
-	Foreground(colorCyan)
+	// Session name style — visible and distinct
+	sessionNameStyle = lipgloss.NewStyle().
+		Foreground(colorCyan)
 
-var followUpStyle = lipgloss.NewStyle().
-	Foreground(colorOrange)
+	followUpStyle = lipgloss.NewStyle().
+		Foreground(colorOrange)
 
-var dimStyle = lipgloss.NewStyle().
-	Foreground(colorGray)
+	dimStyle = lipgloss.NewStyle().
+		Foreground(colorGray)
 
-var separatorStyle = lipgloss.NewStyle().
-	Foreground(colorDimGray)
+	separatorStyle = lipgloss.NewStyle().
+		Foreground(colorDimGray)
 
-// ─── Incident List ───────────────────────────────────────────────────────────
+	// Incident List
 
-var incidentRowStyle = lipgloss.NewStyle().
-	Foreground(colorWhite)
+	incidentRowStyle = lipgloss.NewStyle().
+		Foreground(colorWhite)
 
-var incidentRowSelectedStyle = lipgloss.NewStyle().
-	Foreground(colorOrange).
-	Bold(true).
-	Reverse(true)
+	incidentRowSelectedStyle = lipgloss.NewStyle().
+		Foreground(colorOrange).
+		Bold(true).
+		Reverse(true)
+
+	// Scrollback
+
+	scrollMatchStyle = lipgloss.NewStyle().
+		Foreground(colorYellow)
+
+	scrollCurrentMatchStyle = lipgloss.NewStyle().
+		Foreground(colorOrange).
+		Bold(true)
+}
@@ -0,0 +1,426 @@
+package tui
+
+import (
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+
+	"hawkeye-cli/internal/api"
+	"hawkeye-cli/internal/display"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ─── /incidents list triage board ───────────────────────────────────────────
+//
+// The board runs in modeTriageBoard: open incidents sorted into not-started,
+// in-progress and investigated columns, refreshed every triageRefresh. Keys
+// act on the selected incident: investigate it, snooze it (a local, per
+// profile setting that hides it for triageSnooze), tag it, open it in the
+// browser or inspect it.
+
+const (
+	triageLimit   = 100
+	triageRefresh = 30 * time.Second
+	triageSnooze  = time.Hour
+)
+
+// triageColumns are the board's columns and the investigation statuses
+// each holds. Sessions with any other status land in the first column.
+var triageColumns = []struct {
+	title    string
+	statuses []string
+}{
+	{"Not started", []string{"INVESTIGATION_STATUS_NOT_STARTED"}},
+	{"In progress", []string{"INVESTIGATION_STATUS_IN_PROGRESS"}},
+	{"Investigated", []string{"INVESTIGATION_STATUS_INVESTIGATED", "INVESTIGATION_STATUS_COMPLETED"}},
+}
+
+// triageBoard holds the state of modeTriageBoard.
+type triageBoard struct {
+	sessions    []api.SessionInfo
+	col, row    int
+	showSnoozed bool
+	tagging     bool // t pressed: loginInput collects a tag
+	loading     bool
+	gen         int // bumped per load, so stale refresh ticks are dropped
+	updated     time.Time
+	status      string // result of the last action
+}
+
+type triageLoadedMsg struct {
+	sessions []api.SessionInfo
+	err      error
+}
+
+type triageTickMsg struct{ gen int }
+
+// openURL opens a URL in the default browser. Tests replace it.
+var openURL = func(url string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", url)
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+	default:
+		cmd = exec.Command("xdg-open", url)
+	}
+	return cmd.Start()
+}
+
+// cmdOpenIncidentsList opens the triage board.
+func (m model) cmdOpenIncidentsList(args []string) (tea.Model, tea.Cmd) {
+	if m.client == nil {
+		return m, printLine(errorMsgStyle.Render("  ✗ Not logged in. Run /login first."))
+	}
+	if m.cfg.ProjectID == "" {
+		return m, printLine(errorMsgStyle.Render("  ✗ No project set. Run /projects first."))
+	}
+	m.board = triageBoard{loading: true}
+	m.mode = modeTriageBoard
+	return m, m.loadTriage()
+}
+
+func (m model) loadTriage() tea.Cmd {
+	client := m.client
+	projectID := m.cfg.ProjectID
+	return func() tea.Msg {
+		filters := []api.PaginationFilter{
+			{Key: "session_type", Value: "SESSION_TYPE_INCIDENT", Operator: "=="},
+		}
+		resp, err := client.SessionList(projectID, 0, triageLimit, filters)
+		if err != nil {
+			return triageLoadedMsg{err: err}
+		}
+		return triageLoadedMsg{sessions: resp.Sessions}
+	}
+}
+
+func (m model) handleTriageLoaded(msg triageLoadedMsg) (tea.Model, tea.Cmd) {
+	if m.mode != modeTriageBoard {
+		return m, nil
+	}
+	m.board.loading = false
+	if msg.err != nil {
+		if m.board.sessions == nil {
+			m.mode = modeIdle
+			return m, printLine(errorMsgStyle.Render(fmt.Sprintf("  ✗ Failed to load incidents: %v", msg.err)))
+		}
+		m.board.status = fmt.Sprintf("Refresh failed: %v", msg.err)
+	} else {
+		selected := m.triageSelected()
+		sortSessionsNewestFirst(msg.sessions)
+		m.board.sessions = msg.sessions
+		m.board.updated = time.Now()
+		m.triageReselect(selected)
+	}
+	m.board.gen++
+	gen := m.board.gen
+	return m, tea.Tick(triageRefresh, func(time.Time) tea.Msg { return triageTickMsg{gen: gen} })
+}
+
+func (m model) handleTriageTick(msg triageTickMsg) (tea.Model, tea.Cmd) {
+	if m.mode != modeTriageBoard || msg.gen != m.board.gen || m.board.loading {
+		return m, nil
+	}
+	m.board.loading = true
+	return m, m.loadTriage()
+}
+
+// triageColumnsFor sorts sessions into the board's columns, leaving out
+// snoozed ones unless showSnoozed is set.
+func (m model) triageColumnsFor(now time.Time) [][]api.SessionInfo {
+	cols := make([][]api.SessionInfo, len(triageColumns))
+	for _, s := range m.board.sessions {
+		if !m.board.showSnoozed && !m.cfg.SnoozedUntil(s.SessionUUID, now).IsZero() {
+			continue
+		}
+		idx := 0
+		for i, c := range triageColumns {
+			for _, status := range c.statuses {
+				if s.InvestigationStatus == status {
+					idx = i
+				}
+			}
+		}
+		cols[idx] = append(cols[idx], s)
+	}
+	return cols
+}
+
+// triageSelected returns the selected session, or nil.
+func (m model) triageSelected() *api.SessionInfo {
+	cols := m.triageColumnsFor(time.Now())
+	if m.board.col < len(cols) && m.board.row < len(cols[m.board.col]) {
+		s := cols[m.board.col][m.board.row]
+		return &s
+	}
+	return nil
+}
+
+// triageReselect moves the cursor back to a session after the board
+// changed, or keeps it within bounds when the session is gone.
+func (m *model) triageReselect(prev *api.SessionInfo) {
+	cols := m.triageColumnsFor(time.Now())
+	if prev != nil {
+		for c, col := range cols {
+			for r, s := range col {
+				if s.SessionUUID == prev.SessionUUID {
+					m.board.col, m.board.row = c, r
+					return
+				}
+			}
+		}
+	}
+	if n := len(cols[m.board.col]); m.board.row >= n {
+		m.board.row = max(n-1, 0)
+	}
+}
+
+func (m model) handleTriageKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.board.tagging {
+		return m.handleTriageTagKey(msg)
+	}
+
+	cols := m.triageColumnsFor(time.Now())
+	switch msg.Type {
+	case tea.KeyEsc, tea.KeyCtrlC:
+		m.mode = modeIdle
+		m.board = triageBoard{}
+		return m, printLine(dimStyle.Render("  Triage board closed."))
+	case tea.KeyUp:
+		if m.board.row > 0 {
+			m.board.row--
+		}
+		return m, nil
+	case tea.KeyDown:
+		if m.board.row < len(cols[m.board.col])-1 {
+			m.board.row++
+		}
+		return m, nil
+	case tea.KeyLeft, tea.KeyRight:
+		if msg.Type == tea.KeyLeft {
+			m.board.col = (m.board.col + len(cols) - 1) % len(cols)
+		} else {
+			m.board.col = (m.board.col + 1) % len(cols)
+		}
+		m.board.row = min(m.board.row, max(len(cols[m.board.col])-1, 0))
+		return m, nil
+	case tea.KeyEnter:
+		if s := m.triageSelected(); s != nil {
+			m.sessionID = s.SessionUUID
+			m.mode = modeIdle
+			m.board = triageBoard{}
+			return m.cmdInspect([]string{s.SessionUUID})
+		}
+		return m, nil
+	case tea.KeyRunes:
+	default:
+		return m, nil
+	}
+
+	switch string(msg.Runes) {
+	case "r":
+		if !m.board.loading {
+			m.board.loading = true
+			return m, m.loadTriage()
+		}
+	case "z":
+		selected := m.triageSelected()
+		m.board.showSnoozed = !m.board.showSnoozed
+		m.triageReselect(selected)
+	case "i":
+		return m.triageInvestigate()
+	case "s":
+		return m.triageSnooze()
+	case "t":
+		if m.triageSelected() != nil {
+			m.board.tagging = true
+			m.resetWizardInput("tag")
+		}
+	case "o":
+		s := m.triageSelected()
+		if s == nil {
+			return m, nil
+		}
+		url := m.cfg.ConsoleSessionURL(s.SessionUUID)
+		if url == "" {
+			m.board.status = "No console URL for this server"
+		} else if err := openURL(url); err != nil {
+			m.board.status = fmt.Sprintf("Could not open browser: %v", err)
+		} else {
+			m.board.status = "Opened " + url
+		}
+	}
+	return m, nil
+}
+
+func (m model) handleTriageTagKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEsc, tea.KeyCtrlC:
+		m.board.tagging = false
+		m.resetWizardInput("")
+		return m, nil
+	case tea.KeyEnter:
+		tag := strings.TrimSpace(m.loginInput.Value())
+		m.board.tagging = false
+		m.resetWizardInput("")
+		s := m.triageSelected()
+		if tag == "" || s == nil {
+			return m, nil
+		}
+		if err := m.cfg.AddTags(s.SessionUUID, tag); err != nil {
+			m.board.status = err.Error()
+			return m, nil
+		}
+		if err := m.cfg.Save(); err != nil {
+			m.board.status = fmt.Sprintf("Could not save tag: %v", err)
+			return m, nil
+		}
+		m.board.status = fmt.Sprintf("Tagged %s #%s", triageName(*s), strings.ToLower(tag))
+		return m, nil
+	}
+	var cmd tea.Cmd
+	m.loginInput, cmd = m.loginInput.Update(msg)
+	return m, cmd
+}
+
+// triageInvestigate leaves the board and runs an investigation in the
+// selected incident's session.
+func (m model) triageInvestigate() (tea.Model, tea.Cmd) {
+	s := m.triageSelected()
+	if s == nil {
+		return m, nil
+	}
+	m.board = triageBoard{}
+	m.mode = modeStreaming
+	m.resetStreamState()
+	m.sessionID = s.SessionUUID
+	m.streamPrompt = fmt.Sprintf("Investigate alert %s", triageName(*s))
+	return m, tea.Sequence(
+		printLine(""),
+		printLine(userPromptStyle.Render("  ❯ Investigate incident: "+triageName(*s))),
+		printLine(""),
+		beginStream(m.client, m.cfg.ProjectID, m.sessionID, m.streamPrompt),
+	)
+}
+
+// triageSnooze snoozes the selected incident, or wakes it if it is
+// already snoozed.
+func (m model) triageSnooze() (tea.Model, tea.Cmd) {
+	s := m.triageSelected()
+	if s == nil {
+		return m, nil
+	}
+	if m.cfg.Unsnooze(s.SessionUUID) {
+		m.board.status = "Unsnoozed " + triageName(*s)
+	} else {
+		m.cfg.Snooze(s.SessionUUID, time.Now().Add(triageSnooze))
+		m.board.status = fmt.Sprintf("Snoozed %s for %s", triageName(*s), triageSnooze)
+	}
+	if err := m.cfg.Save(); err != nil {
+		m.board.status = fmt.Sprintf("Could not save snooze: %v", err)
+	}
+	m.triageReselect(nil)
+	return m, nil
+}
+
+func triageName(s api.SessionInfo) string {
+	if s.Name != "" {
+		return s.Name
+	}
+	return truncateUUID(s.SessionUUID)
+}
+
+// ─── Triage board renderer ──────────────────────────────────────────────────
+
+func (m model) renderTriageBoard() string {
+	now := time.Now()
+	cols := m.triageColumnsFor(now)
+
+	var b strings.Builder
+	b.WriteString("\n")
+	header := fmt.Sprintf("  🚨 Incident Triage — %d open", len(m.board.sessions))
+	switch {
+	case m.board.loading && m.board.sessions == nil:
+		header += " (loading...)"
+	case !m.board.updated.IsZero():
+		header += " · updated " + m.board.updated.Format("15:04:05")
+	}
+	if m.board.showSnoozed {
+		header += " · showing snoozed"
+	}
+	b.WriteString(dimStyle.Render(header) + "\n\n")
+
+	width := max(m.width, 60)
+	colWidth := (width - 4) / len(cols)
+	maxRows := max(m.height-10, 5)
+
+	blocks := make([]string, len(cols))
+	for c, col := range cols {
+		var lines []string
+		title := fmt.Sprintf("%s (%d)", triageColumns[c].title, len(col))
+		if c == m.board.col {
+			lines = append(lines, cmdSelectedNameStyle.Render(title))
+		} else {
+			lines = append(lines, dimStyle.Render(title))
+		}
+
+		start := 0
+		if c == m.board.col && m.board.row >= maxRows {
+			start = m.board.row - maxRows + 1
+		}
+		for r := start; r < len(col) && r < start+maxRows; r++ {
+			lines = append(lines, m.triageCell(col[r], colWidth-2, c == m.board.col && r == m.board.row, now))
+		}
+		if len(col) == 0 {
+			lines = append(lines, dimStyle.Render("  —"))
+		}
+		blocks[c] = lipgloss.NewStyle().Width(colWidth).Render(strings.Join(lines, "\n"))
+	}
+	b.WriteString("  " + lipgloss.JoinHorizontal(lipgloss.Top, blocks...) + "\n\n")
+
+	if m.board.tagging {
+		b.WriteString("  Tag:\n" + m.loginInput.View() + "\n")
+	} else if m.board.status != "" {
+		b.WriteString("  " + statusStyle.Render(m.board.status) + "\n")
+	}
+	return b.String()
+}
+
+// triageCell renders one incident in at most width cells.
+func (m model) triageCell(s api.SessionInfo, width int, selected bool, now time.Time) string {
+	text := triageName(s)
+	for _, tag := range m.cfg.SessionTags(s.SessionUUID) {
+		text += " #" + tag
+	}
+	if !m.cfg.SnoozedUntil(s.SessionUUID, now).IsZero() {
+		if display.ASCII() {
+			text = "(z) " + text
+		} else {
+			text = "💤 " + text
+		}
+	}
+	text = truncateToWidth(text, width-2)
+	if selected {
+		return incidentRowSelectedStyle.Render("▸ " + text)
+	}
+	return incidentRowStyle.Render("  " + text)
+}
+
+// truncateToWidth cuts s to at most width terminal cells, marking the cut
+// with an ellipsis.
+func truncateToWidth(s string, width int) string {
+	if lipgloss.Width(s) <= width {
+		return s
+	}
+	runes := []rune(s)
+	for len(runes) > 0 && lipgloss.Width(string(runes))+1 > width {
+		runes = runes[:len(runes)-1]
+	}
+	return string(runes) + "…"
+}
@@ -0,0 +1,156 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"hawkeye-cli/internal/api"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func triageTestModel(t *testing.T) model {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("SNAP_USER_COMMON", "")
+	m := newTestModel()
+	m.client = &mockAPI{sessions: []api.SessionInfo{
+		{SessionUUID: "s-new", Name: "Disk full", InvestigationStatus: "INVESTIGATION_STATUS_NOT_STARTED"},
+		{SessionUUID: "s-run", Name: "API 5xx", InvestigationStatus: "INVESTIGATION_STATUS_IN_PROGRESS"},
+		{SessionUUID: "s-done", Name: "OOM kill", InvestigationStatus: "INVESTIGATION_STATUS_INVESTIGATED"},
+		{SessionUUID: "s-odd", Name: "Unknown", InvestigationStatus: ""},
+	}}
+	result, cmd := m.cmdOpenIncidentsList(nil)
+	m = result.(model)
+	if m.mode != modeTriageBoard || cmd == nil {
+		t.Fatalf("mode = %v, cmd = %v; want the board loading", m.mode, cmd)
+	}
+	result, _ = m.Update(cmd())
+	return result.(model)
+}
+
+func triageNames(cols [][]api.SessionInfo) [][]string {
+	out := make([][]string, len(cols))
+	for i, col := range cols {
+		for _, s := range col {
+			out[i] = append(out[i], s.SessionUUID)
+		}
+	}
+	return out
+}
+
+func TestTriageColumns(t *testing.T) {
+	m := triageTestModel(t)
+	got := triageNames(m.triageColumnsFor(time.Now()))
+	want := [][]string{{"s-new", "s-odd"}, {"s-run"}, {"s-done"}}
+	for i := range want {
+		if strings.Join(got[i], ",") != strings.Join(want[i], ",") {
+			t.Errorf("column %d = %v, want %v", i, got[i], want[i])
+		}
+	}
+	view := m.renderTriageBoard()
+	for _, s := range []string{"Not started", "In progress", "Investigated", "Disk full", "OOM kill"} {
+		if !strings.Contains(view, s) {
+			t.Errorf("board missing %q", s)
+		}
+	}
+}
+
+func TestTriageKeys(t *testing.T) {
+	runes := func(s string) tea.KeyMsg { return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)} }
+	press := func(m model, keys ...tea.KeyMsg) model {
+		for _, k := range keys {
+			result, _ := m.Update(k)
+			m = result.(model)
+		}
+		return m
+	}
+
+	t.Run("navigate", func(t *testing.T) {
+		m := press(triageTestModel(t), tea.KeyMsg{Type: tea.KeyDown}, tea.KeyMsg{Type: tea.KeyRight})
+		if s := m.triageSelected(); s == nil || s.SessionUUID != "s-run" {
+			t.Errorf("selected = %v, want s-run", s)
+		}
+		m = press(m, tea.KeyMsg{Type: tea.KeyLeft}, tea.KeyMsg{Type: tea.KeyLeft})
+		if s := m.triageSelected(); s == nil || s.SessionUUID != "s-done" {
+			t.Errorf("selected after wrapping left = %v, want s-done", s)
+		}
+	})
+
+	t.Run("snooze hides until shown", func(t *testing.T) {
+		m := press(triageTestModel(t), runes("s"))
+		if until := m.cfg.SnoozedUntil("s-new", time.Now()); until.IsZero() {
+			t.Fatal("s-new not snoozed")
+		}
+		if s := m.triageSelected(); s == nil || s.SessionUUID != "s-odd" {
+			t.Errorf("selected after snooze = %v, want s-odd", s)
+		}
+		m = press(m, runes("z"))
+		if got := triageNames(m.triageColumnsFor(time.Now()))[0]; len(got) != 2 {
+			t.Errorf("with snoozed shown, column 0 = %v", got)
+		}
+	})
+
+	t.Run("tag", func(t *testing.T) {
+		m := press(triageTestModel(t), runes("t"))
+		if !m.board.tagging {
+			t.Fatal("t did not start tagging")
+		}
+		m = press(m, runes("db"), tea.KeyMsg{Type: tea.KeyEnter})
+		if tags := m.cfg.Tags["s-new"]; len(tags) != 1 || tags[0] != "db" {
+			t.Errorf("tags = %v, want [db]", tags)
+		}
+	})
+
+	t.Run("open", func(t *testing.T) {
+		var opened string
+		orig := openURL
+		openURL = func(url string) error { opened = url; return nil }
+		defer func() { openURL = orig }()
+		m := press(triageTestModel(t), runes("o"))
+		if !strings.Contains(opened, "s-new") {
+			t.Errorf("opened %q, want the s-new console URL (status %q)", opened, m.board.status)
+		}
+	})
+
+	t.Run("investigate", func(t *testing.T) {
+		m := press(triageTestModel(t), runes("i"))
+		if m.mode != modeStreaming || m.sessionID != "s-new" {
+			t.Errorf("mode = %v, session = %q; want streaming in s-new", m.mode, m.sessionID)
+		}
+	})
+
+	t.Run("esc closes", func(t *testing.T) {
+		m := press(triageTestModel(t), tea.KeyMsg{Type: tea.KeyEsc})
+		if m.mode != modeIdle {
+			t.Errorf("mode = %v, want idle", m.mode)
+		}
+	})
+}
+
+func TestTriageRefresh(t *testing.T) {
+	m := triageTestModel(t)
+	gen := m.board.gen
+
+	if _, cmd := m.handleTriageTick(triageTickMsg{gen: gen - 1}); cmd != nil {
+		t.Error("stale tick started a refresh")
+	}
+	result, cmd := m.handleTriageTick(triageTickMsg{gen: gen})
+	if cmd == nil {
+		t.Fatal("current tick did not refresh")
+	}
+	m = result.(model)
+
+	m.client.(*mockAPI).sessions = nil
+	m.client.(*mockAPI).err = fmt.Errorf("unavailable")
+	result, _ = m.Update(cmd())
+	m = result.(model)
+	if m.mode != modeTriageBoard || len(m.board.sessions) != 4 {
+		t.Errorf("failed refresh dropped the board: mode %v, %d sessions", m.mode, len(m.board.sessions))
+	}
+	if !strings.Contains(m.board.status, "Refresh failed") {
+		t.Errorf("status = %q", m.board.status)
+	}
+}
@@ -1,12 +1,26 @@
 package main
 
 import (
+	"bufio"
+	"context"
 	_ "embed"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"maps"
+	"net/http"
 	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"runtime"
+	"slices"
 	"strconv"
 	"strings"
+	"sync"
+	"syscall"
+	"time"
 
 	"hawkeye-cli/internal/api"
 	"hawkeye-cli/internal/config"
@@ -14,6 +28,9 @@ import (
 	"hawkeye-cli/internal/incidents"
 	"hawkeye-cli/internal/service"
 	"hawkeye-cli/internal/tui"
+
+	"github.com/atotto/clipboard"
+	"golang.org/x/term"
 )
 
 //go:embed datasets/alert_config.yaml
@@ -45,6 +62,22 @@ func versionString() string {
 var activeProfile string
 var jsonOutput bool
 var continueLastSession bool
+var relativeTimes bool
+var noEmoji bool
+var termCaps display.TermCaps // detected at startup, shown by config show-term
+var outputWidth int
+var wideOutput bool
+var proxyFlag *string // --proxy value; nil when the flag is absent
+var orgFlag *string   // --org value; nil when the flag is absent
+var orgRole *string   // the user's role in the --org organization
+var insecureTLS bool
+var noDefaults bool
+var noCache bool
+var noBanner bool
+var accessible bool
+var outputFormat string
+var fieldsFlag *string // --fields value; nil when the flag is absent
+var jsonFields []string
 
 func main() {
 	args := os.Args[1:]
@@ -52,114 +85,297 @@ func main() {
 	// Parse global flags first (--profile)
 	args = parseGlobalFlags(args)
 
-	// Resolve --continue to last session from config
-	var resumeSessionID string
-	if continueLastSession {
-		cfg, err := config.Load(activeProfile)
+	if outputWidth < 0 {
+		display.Error("--width requires a positive number of columns")
+		os.Exit(1)
+	}
+	switch outputFormat {
+	case "", "text":
+	case "json":
+		jsonOutput = true
+	case "gha":
+	default:
+		display.Error("--output must be text, json or gha")
+		os.Exit(1)
+	}
+
+	if fieldsFlag != nil {
+		fields, err := service.ParseFields(*fieldsFlag)
 		if err != nil {
 			display.Error(err.Error())
 			os.Exit(1)
 		}
-		if cfg.LastSession == "" {
-			display.Error("no previous session found. Run an investigation first")
+		jsonFields, jsonOutput = fields, true
+	}
+
+	if proxyFlag != nil {
+		if _, err := api.ParseProxyURL(*proxyFlag); err != nil {
+			display.Error(fmt.Sprintf("--proxy: %v", err))
 			os.Exit(1)
 		}
-		resumeSessionID = cfg.LastSession
+		api.SetProxy(*proxyFlag)
+	}
+	if noCache {
+		api.SetCacheDisabled(true)
+	}
+	if noBanner {
+		tui.SetNoBanner(true)
+	}
+	if insecureTLS {
+		api.SetInsecureSkipVerify(true)
+		fmt.Fprintf(os.Stderr, "%s!%s TLS certificate verification is disabled (--insecure-skip-verify)\n", display.Yellow, display.Reset)
 	}
 
-	// No args (or just --continue) → launch interactive mode
-	if len(args) == 0 {
-		if jsonOutput {
-			display.Error("--json is not supported in interactive mode")
-			os.Exit(1)
+	unlockProfiles()
+
+	if dir := os.Getenv(api.FixturesEnv); dir != "" {
+		fmt.Fprintf(os.Stderr, "%s!%s Answering from fixtures in %s (%s), not a server\n", display.Yellow, display.Reset, dir, api.FixturesEnv)
+	}
+
+	if err := api.StartTracing(traceName(args), version); err != nil {
+		fmt.Fprintf(os.Stderr, "%s!%s Tracing disabled: %v\n", display.Yellow, display.Reset, err)
+	}
+
+	// Apply display settings before any output is rendered
+	display.SetRelativeTime(relativeTimes)
+	termCaps = display.DetectTerm()
+	display.SetASCII(noEmoji || !termCaps.Unicode)
+	display.SetColorLevel(termCaps.Colors)
+	if accessible || os.Getenv("HAWKEYE_ACCESSIBLE") == "1" {
+		display.SetAccessible(true)
+		display.SetColorLevel(display.ColorNone)
+	}
+	display.SetWidth(outputWidth)
+	theme := ""
+	if cfg, err := config.Load(activeProfile); err == nil {
+		for _, w := range cfg.Warnings() {
+			fmt.Fprintf(os.Stderr, "%s!%s %s\n", display.Yellow, display.Reset, w)
+		}
+		if cfg.Timezone != "" {
+			if err := display.SetTimeZone(cfg.Timezone); err != nil {
+				display.Warn(fmt.Sprintf("Ignoring configured timezone: %v", err))
+			}
 		}
-		if err := tui.Run(version, activeProfile, resumeSessionID); err != nil {
+		theme = cfg.Theme
+	}
+	if err := display.SetTheme(theme); err != nil {
+		display.SetTheme("")
+		display.Warn(fmt.Sprintf("Ignoring configured theme: %v", err))
+	}
+
+	// --org needs a logged-in profile to check membership against, so it
+	// is left to login itself to complain when there is none.
+	if orgFlag != nil && (len(args) == 0 || args[0] != "login") {
+		if err := applyOrgOverride(*orgFlag); err != nil {
 			display.Error(err.Error())
 			os.Exit(1)
 		}
-		return
 	}
 
-	// Explicit -i flag also launches interactive mode
-	if args[0] == "-i" || args[0] == "--interactive" || args[0] == "interactive" {
-		if jsonOutput {
-			display.Error("--json is not supported in interactive mode")
-			os.Exit(1)
+	started := time.Now()
+
+	// No args (or just --continue) → launch interactive mode
+	if len(args) == 0 {
+		args = []string{"interactive"}
+	}
+
+	// An unknown command runs a hawkeye-<cmd> plugin from PATH, as git
+	// does. It gets the terminal itself, so this happens before defaults
+	// and output filtering apply.
+	commands := cliCommands()
+	if _, ok := commands.Lookup(args[0]); !ok {
+		if path, found := config.FindPlugin(args[0], exec.LookPath); found {
+			err := runPlugin(path, args[1:])
+			endTracing(err)
+			recordAudit(args, started, err)
+			recordTelemetry([]string{"plugin"}, err)
+			var exit *exec.ExitError
+			if errors.As(err, &exit) {
+				os.Exit(exit.ExitCode())
+			}
+			if err != nil {
+				display.Error(err.Error())
+				os.Exit(1)
+			}
+			return
 		}
-		if err := tui.Run(version, activeProfile, resumeSessionID); err != nil {
-			display.Error(err.Error())
-			os.Exit(1)
+	}
+
+	// Per-command defaults from `config set-default` fill in flags the
+	// command line leaves out.
+	if !noDefaults {
+		if cfg, err := config.Load(activeProfile); err == nil {
+			var subcommands []string
+			if command, ok := commands.Lookup(args[0]); ok {
+				subcommands = command.Subcommands
+			}
+			args = cfg.ApplyDefaults(args, subcommands)
 		}
-		return
+	}
+
+	// ASCII mode and --width rewrite everything the command prints. JSON
+	// output is left untouched so it stays machine-readable.
+	// Commands that hand the terminal to the TUI are left unfiltered.
+	command, known := commands.Lookup(args[0])
+	restoreOutput := func() {}
+	if !jsonOutput && outputFormat != "gha" && !slices.Contains(args, "--json-stream") && !(known && command.Terminal) {
+		restoreOutput = display.FilterStdio()
 	}
 
 	var err error
 
-	switch args[0] {
-	case "login":
-		err = cmdLogin(args[1:])
-	case "set":
-		err = cmdSet(args[1:])
-	case "config":
-		err = cmdConfig()
-	case "investigate", "ask":
-		err = cmdInvestigate(args[1:])
-	case "sessions":
-		err = cmdSessions(args[1:])
-	case "inspect":
-		err = cmdInspect(args[1:])
-	case "summary":
-		err = cmdSummary(args[1:])
-	case "feedback", "td":
-		err = cmdFeedback(args[1:])
-	case "prompts":
-		err = cmdPrompts()
-	case "projects":
-		err = cmdProjects(args[1:])
-	case "score":
-		err = cmdScore(args[1:])
-	case "link":
-		err = cmdLink(args[1:])
-	case "open":
-		err = cmdOpen(args[1:])
-	case "parse":
-		err = cmdParse(args[1:])
-	case "report":
-		err = cmdReport()
-	case "connections":
-		err = cmdConnections(args[1:])
-	case "investigate-alert":
-		err = cmdInvestigateAlert(args[1:])
-	case "queries":
-		err = cmdQueries(args[1:])
-	case "discover":
-		err = cmdDiscover(args[1:])
-	case "resource-types":
-		err = cmdResourceTypes(args[1:])
-	case "session-report":
-		err = cmdSessionReport(args[1:])
-	case "instructions":
-		err = cmdInstructions(args[1:])
-	case "rerun":
-		err = cmdRerun(args[1:])
-	case "incidents":
-		err = cmdIncidents(args[1:])
-	case "profiles":
-		err = cmdProfiles()
-	case "help", "--help", "-h":
-		printUsage()
-	case "version", "--version", "-v":
-		fmt.Println(versionString())
-	default:
+	if known {
+		err = command.Run(args[1:])
+	} else {
 		display.Error(fmt.Sprintf("Unknown command: %s", args[0]))
 		printUsage()
+		restoreOutput()
 		os.Exit(1)
 	}
 
+	endTracing(err)
+	recordAudit(args, started, err)
+	recordTelemetry(args, err)
+	var failed *investigationFailedError
+	if errors.As(err, &failed) {
+		if !failed.reported {
+			display.Error(err.Error())
+		}
+		restoreOutput()
+		os.Exit(exitInvestigationFailed)
+	}
 	if err != nil {
+		if cfg, lerr := config.Load(activeProfile); lerr == nil {
+			err = service.ExplainForbidden(err, userRole(cfg))
+		}
 		display.Error(err.Error())
+		restoreOutput()
 		os.Exit(1)
 	}
+	restoreOutput()
+}
+
+// ─── Command registry ────────────────────────────────────────────────────────
+
+// cliCommand runs a command with the arguments after its name.
+type cliCommand = func(args []string) error
+
+// cliCommands lists every built-in command. Help, dispatch and the alias
+// check in tests all read from it; the full flag reference is in usageText.
+func cliCommands() *service.CommandRegistry[cliCommand] {
+	noArgs := func(run func() error) cliCommand {
+		return func([]string) error { return run() }
+	}
+	return service.NewCommandRegistry[cliCommand](
+		service.Command[cliCommand]{Name: "interactive", Aliases: []string{"-i", "--interactive"}, Summary: "Launch interactive mode (the default with no command)", Terminal: true, Run: cmdInteractive},
+		service.Command[cliCommand]{Name: "resume", Args: "[session-uuid]", Summary: "Reopen the last session interactively", Terminal: true, Run: cmdResume},
+		service.Command[cliCommand]{Name: "login", Args: "<url> -u <user> -p <pass>", Summary: "Authenticate against a Hawkeye server", Run: cmdLogin},
+		service.Command[cliCommand]{Name: "set", Args: "<key> <value>", Summary: "Change a profile setting", Run: cmdSet},
+		service.Command[cliCommand]{Name: "config", Args: "[subcommand]", Summary: "Show, validate, encrypt, export or import the configuration", Subcommands: []string{"encrypt", "decrypt", "validate", "set-default", "unset-default", "defaults", "export", "import", "show-term"}, Run: cmdConfig},
+		service.Command[cliCommand]{Name: "investigate", Aliases: []string{"ask"}, Args: `"<question>"`, Summary: "Run an AI-powered investigation", Run: cmdInvestigate},
+		service.Command[cliCommand]{Name: "replay", Args: "<file>", Summary: "Re-render a recorded stream offline", Run: cmdReplay},
+		service.Command[cliCommand]{Name: "sessions", Args: "[subcommand]", Summary: "List, tag, name and export investigation sessions", Subcommands: []string{"autoname", "tag", "untag", "tags", "export"}, Run: cmdSessions},
+		service.Command[cliCommand]{Name: "inspect", Args: "[session-uuid]", Summary: "View session details", Run: cmdInspect},
+		service.Command[cliCommand]{Name: "chat", Args: "[session-uuid]", Summary: "Read a session as a conversation", Run: cmdChat},
+		service.Command[cliCommand]{Name: "rca", Args: `"<question>"`, Summary: "Investigate and print one RCA report with summary and scores", Run: cmdRCA},
+		service.Command[cliCommand]{Name: "search", Args: `"<text>"`, Summary: "Search locally indexed sessions", Run: cmdSearch},
+		service.Command[cliCommand]{Name: "summary", Args: "[session-uuid]", Summary: "Get a session's executive summary", Run: cmdSummary},
+		service.Command[cliCommand]{Name: "digest", Summary: "Roll up the last day's sessions for standup notes", Run: cmdDigest},
+		service.Command[cliCommand]{Name: "actions", Args: "[subcommand]", Summary: "Track action items from session summaries", Subcommands: []string{"done", "undo", "export"}, Run: cmdActions},
+		service.Command[cliCommand]{Name: "feedback", Aliases: []string{"td"}, Args: "[session-uuid]", Summary: "Rate an investigation", Run: cmdFeedback},
+		service.Command[cliCommand]{Name: "prompts", Args: "[subcommand]", Summary: "Browse and manage investigation prompts", Subcommands: []string{"push", "delete"}, Run: cmdPrompts},
+		service.Command[cliCommand]{Name: "template", Aliases: []string{"templates"}, Args: "<subcommand>", Summary: "Manage investigation templates", Subcommands: []string{"create", "list", "show", "delete"}, Run: cmdTemplate},
+		service.Command[cliCommand]{Name: "projects", Args: "[subcommand]", Summary: "List and manage projects", Subcommands: []string{"info", "create", "clone", "update", "delete"}, Run: cmdProjects},
+		service.Command[cliCommand]{Name: "orgs", Summary: "List organizations you belong to", Run: noArgs(cmdOrgs)},
+		service.Command[cliCommand]{Name: "score", Args: "[session-uuid]", Summary: "Show RCA quality scores", Run: cmdScore},
+		service.Command[cliCommand]{Name: "link", Args: "[session-uuid]", Summary: "Get the web UI URL for a session", Run: cmdLink},
+		service.Command[cliCommand]{Name: "share", Args: "[session-uuid]", Summary: "Create an expiring read-only link to a session", Run: cmdShare},
+		service.Command[cliCommand]{Name: "open", Args: "<url>", Summary: "Open a web console URL in interactive mode", Terminal: true, Run: cmdOpen},
+		service.Command[cliCommand]{Name: "parse", Args: "<url>", Summary: "Parse a web console URL, set project and session", Run: cmdParse},
+		service.Command[cliCommand]{Name: "open-url", Args: "<url>", Summary: "Set project and session from a console URL and inspect it", Run: cmdOpenURL},
+		service.Command[cliCommand]{Name: "report", Args: "[trend]", Summary: "Show org-wide incident analytics", Subcommands: []string{"trend"}, Run: cmdReport},
+		service.Command[cliCommand]{Name: "connections", Args: "[subcommand]", Summary: "Manage data source connections", Subcommands: []string{"resources", "types", "info", "create", "sync", "rotate-secret", "train", "status", "add", "remove", "project", "import"}, Run: cmdConnections},
+		service.Command[cliCommand]{Name: "investigate-alert", Args: "<alert-id>", Summary: "Investigate from an alert", Run: cmdInvestigateAlert},
+		service.Command[cliCommand]{Name: "watch", Args: "[queue]", Summary: "Watch for uninvestigated incidents and optionally investigate them", Subcommands: []string{"queue"}, Run: cmdWatch},
+		service.Command[cliCommand]{Name: "listen", Summary: "Receive alert webhooks and investigate each firing alert", Run: cmdListen},
+		service.Command[cliCommand]{Name: "queries", Args: "[session-uuid]", Summary: "Show investigation queries", Run: cmdQueries},
+		service.Command[cliCommand]{Name: "sources", Args: "[session-uuid]", Summary: "List cited sources with the queries that touched them", Run: cmdSources},
+		service.Command[cliCommand]{Name: "stats", Args: "[session-uuid]", Summary: "Timing breakdown of an investigation", Run: cmdStats},
+		service.Command[cliCommand]{Name: "discover", Summary: "Discover project resources", Run: cmdDiscover},
+		service.Command[cliCommand]{Name: "resource-types", Args: "<conn> <telemetry>", Summary: "List resource types supported by the server", Run: cmdResourceTypes},
+		service.Command[cliCommand]{Name: "session-report", Args: "<uuid>...", Summary: "Per-session report with time-saved metrics", Run: cmdSessionReport},
+		service.Command[cliCommand]{Name: "instructions", Args: "[subcommand]", Summary: "Manage project instructions", Subcommands: []string{"create", "update", "export", "import", "enable", "disable", "delete", "validate", "apply", "info"}, Run: cmdInstructions},
+		service.Command[cliCommand]{Name: "apply", Args: "-f <project.yaml>", Summary: "Create or update a project from a manifest", Run: cmdApply},
+		service.Command[cliCommand]{Name: "rerun", Args: "<session-uuid>", Summary: "Rerun an investigation", Run: cmdRerun},
+		service.Command[cliCommand]{Name: "incidents", Args: "[subcommand]", Summary: "Connect incident management tools", Subcommands: []string{"add", "test"}, Run: cmdIncidents},
+		service.Command[cliCommand]{Name: "groups", Args: "[subcommand]", Summary: "List and investigate incident groups", Subcommands: []string{"show", "investigate"}, Run: cmdGroups},
+		service.Command[cliCommand]{Name: "profiles", Summary: "List all config profiles", Run: noArgs(cmdProfiles)},
+		service.Command[cliCommand]{Name: "history", Summary: "List recent prompts with their session UUIDs", Run: cmdHistory},
+		service.Command[cliCommand]{Name: "audit", Args: "[list|path]", Summary: "Show the local log of commands run on this machine", Subcommands: []string{"path"}, Run: cmdAudit},
+		service.Command[cliCommand]{Name: "telemetry", Args: "[show|on|off]", Summary: "Show or change anonymous usage telemetry", Subcommands: []string{"on", "off"}, Run: cmdTelemetry},
+		service.Command[cliCommand]{Name: "alias", Aliases: []string{"aliases"}, Args: "[subcommand]", Summary: "Name sessions", Subcommands: []string{"add", "set", "rm", "remove", "delete"}, Run: cmdAlias},
+		service.Command[cliCommand]{Name: "eval", Args: "run <suite.yaml|->", Summary: "Run golden questions and check the answers", Subcommands: []string{"run"}, Run: cmdEval},
+		service.Command[cliCommand]{Name: "smoke", Summary: "Check the environment end to end with a throwaway session", Run: cmdSmoke},
+		service.Command[cliCommand]{Name: "help", Aliases: []string{"--help", "-h"}, Args: "[command]", Summary: "Show usage, or the usage of one command", Run: cmdHelp},
+		service.Command[cliCommand]{Name: "version", Aliases: []string{"--version", "-v"}, Summary: "Print the version", Run: noArgs(func() error {
+			fmt.Println(versionString())
+			return nil
+		})},
+	)
+}
+
+// cmdHelp prints the usage, or with a command name the part of it about
+// that command. Plugins are asked for their own --help.
+func cmdHelp(args []string) error {
+	if len(args) == 0 {
+		printUsage()
+		return nil
+	}
+	command, ok := cliCommands().Lookup(args[0])
+	if !ok {
+		if path, found := config.FindPlugin(args[0], exec.LookPath); found {
+			return runPlugin(path, []string{"--help"})
+		}
+		return fmt.Errorf("unknown command %q (run hawkeye help for the list)", args[0])
+	}
+	fmt.Printf("\n%shawkeye %s%s %s\n", display.Bold, command.Name, display.Reset, command.Args)
+	fmt.Printf("  %s\n", command.Summary)
+	if len(command.Aliases) > 0 {
+		fmt.Printf("  %sAlso:%s %s\n", display.Dim, display.Reset, strings.Join(command.Aliases, ", "))
+	}
+	if block := service.UsageBlock(usageText(), append([]string{command.Name}, command.Aliases...)...); block != "" {
+		fmt.Println()
+		fmt.Print(block)
+	}
+	fmt.Println()
+	return nil
+}
+
+// runPlugin runs a hawkeye-<cmd> executable attached to the terminal,
+// with the active profile's context in its environment.
+func runPlugin(path string, args []string) error {
+	self, _ := os.Executable()
+	cfg, _ := config.Load(activeProfile)
+	cmd := exec.Command(path, args...)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	cmd.Env = config.PluginEnv(os.Environ(), self, activeProfile, cfg)
+	return cmd.Run()
+}
+
+// traceName names the root span for a run after its command, leaving out
+// arguments such as prompts.
+func traceName(args []string) string {
+	if len(args) == 0 || args[0] == "-i" || args[0] == "--interactive" {
+		return "hawkeye interactive"
+	}
+	return "hawkeye " + args[0]
+}
+
+// endTracing sends the run's spans to the collector, if tracing is on.
+func endTracing(err error) {
+	if terr := api.EndTracing(err); terr != nil {
+		fmt.Fprintf(os.Stderr, "%s!%s %v\n", display.Yellow, display.Reset, terr)
+	}
 }
 
 // ─── login ───────────────────────────────────────────────────────────────────
@@ -213,26 +429,26 @@ func cmdLogin(args []string) error {
 		return fmt.Errorf("username and password are required")
 	}
 
+	cfg, err := config.Load(activeProfile)
+	if err != nil {
+		return err
+	}
+
 	fmt.Println()
 	serverURL := api.NormalizeBackendURL(frontendURL)
 	display.Info("Backend:", serverURL)
-	display.Spinner("Authenticating...")
+	sp := display.Spin("Authenticating...")
 
 	client := api.NewClientWithServer(serverURL)
+	client.SetTransport(cfg)
 	loginResp, err := client.Login(username, password)
+	sp.Stop()
 	if err != nil {
-		display.ClearLine()
 		return fmt.Errorf("authentication failed: %w", err)
 	}
 
-	display.ClearLine()
 	display.Success("Authenticated successfully")
 
-	cfg, err := config.Load(activeProfile)
-	if err != nil {
-		return err
-	}
-
 	cfg.Server = serverURL
 	cfg.FrontendURL = strings.TrimRight(frontendURL, "/")
 	cfg.Username = username
@@ -244,8 +460,11 @@ func cmdLogin(args []string) error {
 	if userErr != nil {
 		display.Warn(fmt.Sprintf("Could not auto-detect organization: %v", userErr))
 		display.Warn("You can set it manually: hawkeye set org <uuid>")
-	} else if userInfo != nil && userInfo.OrgUUID != "" {
-		cfg.OrgUUID = userInfo.OrgUUID
+	} else if userInfo != nil {
+		if userInfo.OrgUUID != "" {
+			cfg.OrgUUID = userInfo.OrgUUID
+		}
+		cfg.UserRole = userInfo.UserRole
 	}
 
 	if err := cfg.Save(); err != nil {
@@ -257,6 +476,9 @@ func cmdLogin(args []string) error {
 	if cfg.OrgUUID != "" {
 		display.Info("Organization:", cfg.OrgUUID)
 	}
+	if cfg.UserRole != "" {
+		display.Info("Role:", service.NormalizeRole(cfg.UserRole))
+	}
 
 	pf := ""
 	if activeProfile != "" {
@@ -287,7 +509,19 @@ func cmdSet(args []string) error {
 		fmt.Println("  server   Hawkeye server URL  (e.g. http://server:8080)")
 		fmt.Println("  project  Active project UUID or name")
 		fmt.Println("  token    JWT authentication token")
-		fmt.Println("  org      Organization UUID")
+		fmt.Println("  org      Organization UUID or name (--interactive to pick)")
+		fmt.Println("  timezone Display time zone, e.g. Europe/Berlin (local to reset)")
+		fmt.Println("  theme    Color theme: auto, dark, light, mono or a .json palette")
+		fmt.Println("  banner   Interactive-mode startup banner: hawk, toucan, minimal or none")
+		fmt.Println("  auto-name Name new sessions after their first prompt: on or off")
+		fmt.Println("  notifications Desktop notification when a long or background investigation finishes: on or off")
+		fmt.Println("  language Response language for answers and summaries, e.g. ja or pt-BR (auto to reset)")
+		fmt.Println("  proxy    HTTP(S) or socks5 proxy URL for API requests (none to reset)")
+		fmt.Println("  ca-cert  Extra CA bundle (PEM) to trust, e.g. for TLS interception (none to reset)")
+		fmt.Println("  client-cert / client-key  Client certificate and key (PEM) for mTLS (none to reset)")
+		fmt.Println("  transcript-dir Write a markdown transcript of every investigation here (none to reset)")
+		fmt.Println("  timeout  Give up on API calls after this long, e.g. 60s (default 2m; none for no limit)")
+		fmt.Println("  stream-idle-timeout Abort an investigation stream after this long without events (default 10m)")
 		return nil
 	}
 
@@ -320,10 +554,146 @@ func cmdSet(args []string) error {
 		cfg.ProjectName = found.Name
 	case "token":
 		cfg.Token = value
+	case "timezone", "tz":
+		if _, err := display.LoadTimeZone(value); err != nil {
+			return err
+		}
+		key = "timezone"
+		if strings.EqualFold(value, "local") {
+			value = ""
+		}
+		cfg.Timezone = value
+	case "theme":
+		if _, err := display.LoadTheme(value); err != nil {
+			return err
+		}
+		if strings.HasSuffix(strings.ToLower(value), ".json") {
+			abs, err := filepath.Abs(value)
+			if err != nil {
+				return err
+			}
+			value = abs
+		}
+		if strings.EqualFold(value, "auto") {
+			value = ""
+		}
+		cfg.Theme = value
+	case "banner":
+		banner, err := tui.ParseBanner(value)
+		if err != nil {
+			return err
+		}
+		value = banner
+		cfg.Banner = banner
+	case "auto-name":
+		switch strings.ToLower(value) {
+		case "on", "true", "yes":
+			cfg.NoAutoName = false
+			value = "on"
+		case "off", "false", "no":
+			cfg.NoAutoName = true
+			value = "off"
+		default:
+			return fmt.Errorf("auto-name must be on or off")
+		}
+	case "notifications", "notify":
+		switch strings.ToLower(value) {
+		case "on", "true", "yes":
+			if err := desktopNotify(service.Notification{Title: "Hawkeye", Body: "Notifications are on."}); err != nil {
+				return fmt.Errorf("sending a test notification: %w", err)
+			}
+			cfg.Notifications = true
+			value = "on"
+		case "off", "false", "no":
+			cfg.Notifications = false
+			value = "off"
+		default:
+			return fmt.Errorf("notifications must be on or off")
+		}
+		key = "notifications"
+	case "language", "lang":
+		lang, err := service.NormalizeLanguage(value)
+		if err != nil {
+			return err
+		}
+		key, value = "language", lang
+		cfg.Language = lang
+	case "proxy":
+		if isUnsetValue(value) {
+			value = ""
+		} else if _, err := api.ParseProxyURL(value); err != nil {
+			return err
+		}
+		cfg.Proxy = value
+	case "ca-cert", "client-cert", "client-key":
+		if isUnsetValue(value) {
+			value = ""
+		} else {
+			abs, err := filepath.Abs(value)
+			if err != nil {
+				return err
+			}
+			value = abs
+		}
+		switch key {
+		case "ca-cert":
+			if value != "" {
+				if _, err := api.LoadCAPool(value); err != nil {
+					return err
+				}
+			}
+			cfg.CACert = value
+		case "client-cert":
+			cfg.ClientCert = value
+		case "client-key":
+			cfg.ClientKey = value
+		}
+		// The pair is checked once both halves are set.
+		if cfg.ClientCert != "" && cfg.ClientKey != "" {
+			if err := api.CheckTransport(cfg); err != nil {
+				return err
+			}
+		}
+	case "transcript-dir":
+		if isUnsetValue(value) {
+			value = ""
+		} else {
+			dir, err := expandHome(value)
+			if err != nil {
+				return err
+			}
+			if value, err = filepath.Abs(dir); err != nil {
+				return err
+			}
+			if err := os.MkdirAll(value, 0700); err != nil {
+				return fmt.Errorf("creating transcript directory: %w", err)
+			}
+		}
+		cfg.TranscriptDir = value
+	case "timeout", "stream-idle-timeout":
+		d, err := api.ParseTimeout(value)
+		if err != nil {
+			return err
+		}
+		value = api.FormatTimeout(d)
+		if key == "timeout" {
+			cfg.Timeout = value
+		} else {
+			cfg.StreamIdleTimeout = value
+		}
 	case "org":
-		cfg.OrgUUID = value
+		org, err := resolveOrg(cfg, value)
+		if err != nil {
+			return err
+		}
+		value = org.UUID
+		if org.UUID != cfg.OrgUUID {
+			cfg.OrgUUID = org.UUID
+			cfg.UserRole = org.Role
+			reconcileProjectOrg(cfg)
+		}
 	default:
-		return fmt.Errorf("unknown config key: %s (valid: server, project, token, org)", key)
+		return fmt.Errorf("unknown config key: %s (valid: server, project, token, org, timezone, theme, banner, auto-name, notifications, language, proxy, ca-cert, client-cert, client-key, transcript-dir, timeout, stream-idle-timeout)", key)
 	}
 
 	if err := cfg.Save(); err != nil {
@@ -332,15 +702,272 @@ func cmdSet(args []string) error {
 
 	if key == "project" {
 		display.Success(fmt.Sprintf("project set to %s (%s)", cfg.ProjectName, cfg.ProjectID))
+	} else if key == "timezone" && value == "" {
+		display.Success("timezone reset to system local time")
+	} else if key == "theme" && value == "" {
+		display.Success("theme reset to auto")
+	} else if key == "language" && value == "" {
+		display.Success("language reset to auto")
+	} else if value == "" {
+		display.Success(fmt.Sprintf("%s cleared", key))
 	} else {
 		display.Success(fmt.Sprintf("%s set to %s", key, value))
 	}
 	return nil
 }
 
+// resolveOrg turns a `set org` argument into an organization and the
+// user's role in it. When logged in, names are resolved against the user's
+// memberships and --interactive shows a numbered picker. Before login, or
+// if the server cannot list organizations, a raw UUID is accepted as-is
+// with an unknown role.
+func resolveOrg(cfg *config.Config, value string) (api.OrgSpec, error) {
+	interactive := value == "--interactive" || value == "-i"
+	if err := cfg.Validate(); err != nil {
+		if interactive {
+			return api.OrgSpec{}, err
+		}
+		return api.OrgSpec{UUID: value}, nil
+	}
+
+	client := api.NewClient(cfg)
+	orgs, err := client.ListOrganizations()
+	if err != nil {
+		if interactive {
+			return api.OrgSpec{}, fmt.Errorf("listing organizations: %w", err)
+		}
+		return api.OrgSpec{UUID: value}, nil
+	}
+
+	if interactive {
+		return pickOrg(orgs, cfg.OrgUUID)
+	}
+	found := service.FindOrg(orgs, value)
+	if found == nil {
+		return api.OrgSpec{}, fmt.Errorf("organization %q not found (run: hawkeye orgs)", value)
+	}
+	return *found, nil
+}
+
+func pickOrg(orgs []api.OrgSpec, current string) (api.OrgSpec, error) {
+	display.Header("Select an organization")
+	for i, o := range orgs {
+		active := ""
+		if o.UUID == current {
+			active = display.Green + "  (active)" + display.Reset
+		}
+		fmt.Printf("  %d) %s%s%s  %s%s%s%s\n", i+1, display.Bold, service.OrgLabel(o), display.Reset, display.Dim, o.UUID, display.Reset, active)
+	}
+	fmt.Println()
+	fmt.Print("Organization number: ")
+	var choice string
+	fmt.Scanln(&choice)
+
+	idx, err := service.ParseOrgChoice(choice, len(orgs))
+	if err != nil {
+		return api.OrgSpec{}, err
+	}
+	return orgs[idx], nil
+}
+
+// applyOrgOverride checks that the logged-in user belongs to the --org
+// organization (a UUID or name) and points every API client at it for this
+// run. The profile keeps its own organization.
+func applyOrgOverride(value string) error {
+	if strings.TrimSpace(value) == "" {
+		return fmt.Errorf("--org requires an organization UUID or name")
+	}
+	cfg, err := config.Load(activeProfile)
+	if err != nil {
+		return err
+	}
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+	orgs, err := api.NewClient(cfg).ListOrganizations()
+	if err != nil {
+		return fmt.Errorf("--org: checking access to %s: %w", value, err)
+	}
+	found := service.FindOrg(orgs, value)
+	if found == nil {
+		return fmt.Errorf("--org: you are not a member of organization %q (run: hawkeye orgs)", value)
+	}
+	api.SetOrg(found.UUID)
+	orgRole = &found.Role
+	return nil
+}
+
+// userRole is the user's role in the organization this run acts in, or ""
+// when it is unknown.
+func userRole(cfg *config.Config) string {
+	if orgRole != nil {
+		return *orgRole
+	}
+	return cfg.UserRole
+}
+
+// requireAdmin stops an admin-only command before it starts when the
+// user's role is known not to allow it.
+func requireAdmin(cfg *config.Config, what string) error {
+	return service.CheckAdmin(userRole(cfg), what)
+}
+
+// reconcileProjectOrg clears the active project (and last session) when it
+// does not belong to the newly selected organization.
+func reconcileProjectOrg(cfg *config.Config) {
+	if cfg.ProjectID == "" || cfg.Validate() != nil {
+		return
+	}
+	resp, err := api.NewClient(cfg).ListProjects()
+	if err != nil {
+		display.Warn(fmt.Sprintf("Could not verify the active project in this organization: %v", err))
+		return
+	}
+	if service.FindProject(resp.Specs, cfg.ProjectID) != nil {
+		return
+	}
+	name := cfg.ProjectName
+	if name == "" {
+		name = cfg.ProjectID
+	}
+	display.Warn(fmt.Sprintf("Project %s is not in this organization; clearing it.", name))
+	cfg.ProjectID = ""
+	cfg.ProjectName = ""
+	cfg.LastSession = ""
+}
+
+// splitProjectFlag removes --project <uuid|name> from the arguments of a
+// session-scoped command and returns the rest with the value.
+func splitProjectFlag(args []string) ([]string, string, error) {
+	var rest []string
+	project := ""
+	for i := 0; i < len(args); i++ {
+		if args[i] != "--project" {
+			rest = append(rest, args[i])
+			continue
+		}
+		if i+1 >= len(args) || strings.TrimSpace(args[i+1]) == "" {
+			return nil, "", fmt.Errorf("--project requires a project UUID or name")
+		}
+		i++
+		project = args[i]
+	}
+	return rest, project, nil
+}
+
+// useProjectFlag points cfg at the --project project for this run. The
+// profile keeps its own project.
+func useProjectFlag(cfg *config.Config, value string) error {
+	if value == "" {
+		return nil
+	}
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+	resp, err := api.NewClient(cfg).ListProjects()
+	if err != nil {
+		return fmt.Errorf("--project: listing projects: %w", err)
+	}
+	found := service.FindProject(service.FilterSystemProjects(resp.Specs), value)
+	if found == nil {
+		return fmt.Errorf("--project: project %q not found (run: hawkeye projects)", value)
+	}
+	cfg.UseProject(found.UUID, found.Name)
+	return nil
+}
+
+// inSessionProject runs call, which looks the session up in cfg.ProjectID.
+// When that 404s and no --project was given, the session may belong to
+// another project: the others are searched and, if one has it, cfg is
+// switched to it for this run and call is retried.
+func inSessionProject(cfg *config.Config, client *api.Client, pinned bool, sessionUUID string, call func() error) error {
+	err := call()
+	if err == nil || pinned || !service.IsNotFound(err) {
+		return err
+	}
+	resp, lerr := client.ListProjects()
+	if lerr != nil {
+		return err
+	}
+	found, ferr := service.FindSessionProject(service.FilterSystemProjects(resp.Specs), cfg.ProjectID, func(projectUUID string) error {
+		_, err := client.SessionInspect(projectUUID, sessionUUID)
+		return err
+	})
+	if ferr != nil || found == nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "%sSession %s is in project %s; using it for this command (pass --project to skip the search).%s\n",
+		display.Dim, sessionUUID, found.Name, display.Reset)
+	cfg.UseProject(found.UUID, found.Name)
+	return call()
+}
+
+// ─── orgs ───────────────────────────────────────────────────────────────────
+
+func cmdOrgs() error {
+	cfg, err := config.Load(activeProfile)
+	if err != nil {
+		return err
+	}
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+
+	client := api.NewClient(cfg)
+	orgs, err := client.ListOrganizations()
+	if err != nil {
+		return fmt.Errorf("listing organizations: %w", err)
+	}
+
+	if jsonOutput {
+		return printJSON(orgs)
+	}
+
+	display.Header(fmt.Sprintf("Organizations (%d)", len(orgs)))
+	for _, o := range orgs {
+		marker := "⏺"
+		active := ""
+		if o.UUID == api.EffectiveOrg(cfg) {
+			marker = display.Green + "●" + display.Reset
+			active = display.Green + "  (active)" + display.Reset
+		}
+		role := ""
+		if o.Role != "" {
+			role = fmt.Sprintf("  %s[%s]%s", display.Dim, o.Role, display.Reset)
+		}
+		fmt.Printf("  %s %s%-20s%s %s%s%s%s%s\n", marker, display.Bold, service.OrgLabel(o), display.Reset, display.Dim, o.UUID, display.Reset, role, active)
+	}
+
+	fmt.Println()
+	fmt.Printf("  %sTip:%s Run %shawkeye set org <uuid|name>%s or %shawkeye set org --interactive%s to switch.\n\n",
+		display.Dim, display.Reset, display.Cyan, display.Reset, display.Cyan, display.Reset)
+	return nil
+}
+
 // ─── config ─────────────────────────────────────────────────────────────────
 
-func cmdConfig() error {
+func cmdConfig(args []string) error {
+	if len(args) > 0 {
+		switch args[0] {
+		case "encrypt":
+			return cmdConfigEncrypt()
+		case "decrypt":
+			return cmdConfigDecrypt()
+		case "validate":
+			return cmdConfigValidate()
+		case "set-default", "unset-default", "defaults":
+			return cmdConfigDefaults(args)
+		case "export":
+			return cmdConfigExport(args[1:])
+		case "import":
+			return cmdConfigImport(args[1:])
+		case "show-term":
+			return cmdConfigShowTerm()
+		default:
+			return fmt.Errorf("unknown config subcommand: %s (valid: encrypt, decrypt, validate, set-default, unset-default, defaults, export, import, show-term)", args[0])
+		}
+	}
+
 	cfg, err := config.Load(activeProfile)
 	if err != nil {
 		return err
@@ -348,12 +975,20 @@ func cmdConfig() error {
 
 	if jsonOutput {
 		return printJSON(map[string]string{
-			"profile":      config.ProfileName(activeProfile),
-			"server":       cfg.Server,
-			"username":     cfg.Username,
-			"project":      cfg.ProjectID,
-			"org":          cfg.OrgUUID,
-			"last_session": cfg.LastSession,
+			"profile":             config.ProfileName(activeProfile),
+			"server":              cfg.Server,
+			"username":            cfg.Username,
+			"project":             cfg.ProjectID,
+			"org":                 api.EffectiveOrg(cfg),
+			"role":                service.NormalizeRole(userRole(cfg)),
+			"timezone":            cfg.Timezone,
+			"theme":               cfg.Theme,
+			"banner":              cfg.Banner,
+			"auto_name":           strconv.FormatBool(!cfg.NoAutoName),
+			"notifications":       strconv.FormatBool(cfg.Notifications),
+			"timeout":             api.FormatTimeout(api.RequestTimeout(cfg)),
+			"stream_idle_timeout": api.FormatTimeout(api.StreamIdleTimeout(cfg)),
+			"last_session":        cfg.LastSession,
 		})
 	}
 
@@ -379,11 +1014,52 @@ func cmdConfig() error {
 	}
 	display.Info("Project:", project)
 
-	org := cfg.OrgUUID
+	org := api.EffectiveOrg(cfg)
 	if org == "" {
 		org = display.Dim + "(not set)" + display.Reset
+	} else if org != cfg.OrgUUID {
+		org += display.Dim + " (--org; profile: " + cfg.OrgUUID + ")" + display.Reset
 	}
 	display.Info("Organization:", org)
+	if role := userRole(cfg); role != "" {
+		display.Info("Role:", service.NormalizeRole(role))
+	}
+
+	tz := cfg.Timezone
+	if tz == "" {
+		tz = display.Dim + "(local)" + display.Reset
+	}
+	display.Info("Timezone:", tz)
+
+	theme := cfg.Theme
+	if theme == "" {
+		theme = display.Dim + "(auto)" + display.Reset
+	}
+	display.Info("Theme:", theme)
+
+	banner := cfg.Banner
+	if banner == "" {
+		banner = display.Dim + "(hawk)" + display.Reset
+	}
+	display.Info("Banner:", banner)
+
+	autoName := "on"
+	if cfg.NoAutoName {
+		autoName = "off"
+	}
+	display.Info("Auto-name:", autoName)
+
+	notifications := "off"
+	if cfg.Notifications {
+		notifications = "on"
+	}
+	display.Info("Notifications:", notifications)
+
+	language := cfg.Language
+	if language == "" {
+		language = display.Dim + "(auto)" + display.Reset
+	}
+	display.Info("Language:", language)
 
 	token := display.Dim + "(not set)" + display.Reset
 	if cfg.Token != "" {
@@ -395,6 +1071,33 @@ func cmdConfig() error {
 	}
 	display.Info("Token:", token)
 
+	timeouts := fmt.Sprintf("%s per API call, stream idle %s", api.FormatTimeout(api.RequestTimeout(cfg)), api.FormatTimeout(api.StreamIdleTimeout(cfg)))
+	if cfg.Timeout == "" && cfg.StreamIdleTimeout == "" {
+		timeouts += display.Dim + " (default)" + display.Reset
+	}
+	display.Info("Timeouts:", timeouts)
+
+	for _, f := range []struct{ label, value string }{
+		{"Proxy:", cfg.Proxy},
+		{"CA cert:", cfg.CACert},
+		{"Client cert:", cfg.ClientCert},
+		{"Client key:", cfg.ClientKey},
+		{"Transcripts:", cfg.TranscriptDir},
+	} {
+		if f.value != "" {
+			display.Info(f.label, f.value)
+		}
+	}
+
+	encrypted := "no"
+	if cfg.Encrypted() {
+		encrypted = "yes"
+	}
+	display.Info("Encrypted:", encrypted)
+	if n := len(cfg.Defaults); n > 0 {
+		display.Info("Defaults:", fmt.Sprintf("%d (hawkeye config defaults)", n))
+	}
+
 	session := cfg.LastSession
 	if session == "" {
 		session = display.Dim + "(none)" + display.Reset
@@ -405,143 +1108,141 @@ func cmdConfig() error {
 	return nil
 }
 
-// ─── investigate ────────────────────────────────────────────────────────────
-
-func cmdInvestigate(args []string) error {
-	var sessionUUID string
-	var debugMode bool
-	var positional []string
+// cmdConfigDefaults lists, sets and removes per-command default flags.
+func cmdConfigShowTerm() error {
+	if jsonOutput {
+		return printJSON(map[string]any{
+			"detected":    termCaps,
+			"ascii":       display.ASCII(),
+			"accessible":  display.Accessible(),
+			"color_level": display.CurrentColorLevel().String(),
+			"no_color":    display.NoColor(),
+		})
+	}
 
-	for i := 0; i < len(args); i++ {
-		switch args[i] {
-		case "-s", "--session":
-			if i+1 < len(args) {
-				i++
-				sessionUUID = args[i]
-			} else {
-				return fmt.Errorf("--session requires a value")
-			}
-		case "--debug":
-			debugMode = true
-		default:
-			positional = append(positional, args[i])
+	orNone := func(s string) string {
+		if s == "" {
+			return display.Dim + "(not set)" + display.Reset
 		}
+		return s
 	}
-
-	if len(positional) == 0 {
-		fmt.Println("Usage: hawkeye investigate <question> [--session <uuid>]")
-		fmt.Println()
-		fmt.Println("Examples:")
-		fmt.Println(`  hawkeye investigate "Why is the API returning 500 errors?"`)
-		fmt.Println(`  hawkeye investigate "Check database latency" --session <uuid>`)
-		return nil
+	yesNo := func(b bool) string {
+		if b {
+			return "yes"
+		}
+		return "no"
 	}
-	prompt := strings.Join(positional, " ")
 
-	cfg, err := config.Load(activeProfile)
-	if err != nil {
-		return err
-	}
-	if err := cfg.ValidateProject(); err != nil {
-		return err
+	display.Header("Terminal Capabilities")
+	display.Info("TERM:", orNone(termCaps.Term))
+	display.Info("COLORTERM:", orNone(termCaps.ColorTerm))
+	display.Info("Locale:", orNone(termCaps.Locale))
+	display.Info("OS:", termCaps.OS)
+	display.Info("Terminal:", yesNo(termCaps.Terminal))
+	if termCaps.OS == "windows" {
+		display.Info("Console VT:", yesNo(termCaps.VT))
 	}
+	display.Info("Unicode:", fmt.Sprintf("%s %s(%s)%s", yesNo(termCaps.Unicode), display.Dim, termCaps.UnicodeReason, display.Reset))
+	display.Info("Colors:", fmt.Sprintf("%s %s(%s)%s", termCaps.Colors, display.Dim, termCaps.ColorsReason, display.Reset))
 
-	client := api.NewClient(cfg)
-	client.SetDebug(debugMode)
-
-	// Create session if needed
-	if sessionUUID == "" {
-		fmt.Println()
-		display.Spinner("Creating new investigation session...")
-		sessResp, err := client.NewSession(cfg.ProjectID)
-		if err != nil {
-			display.ClearLine()
-			return fmt.Errorf("creating session: %w", err)
-		}
-		sessionUUID = sessResp.SessionUUID
-		display.ClearLine()
-		display.Success(fmt.Sprintf("Session created: %s", sessionUUID))
-	} else {
-		fmt.Println()
-		display.Success(fmt.Sprintf("Continuing session: %s", sessionUUID))
+	icons := "unicode"
+	if display.Accessible() {
+		icons = "words (accessible)"
+	} else if display.ASCII() {
+		icons = "ascii"
 	}
-
-	cfg.LastSession = sessionUUID
-	_ = cfg.Save()
-
-	fmt.Printf("\n %s── 🦅 Hawkeye Investigation ──────────────────────────────────────────────%s\n", display.Dim, display.Reset)
-	fmt.Println()
-	fmt.Printf("    %sPrompt:%s   %s\n", display.Dim, display.Reset, prompt)
-	fmt.Printf("    %sSession:%s  %s\n", display.Dim, display.Reset, sessionUUID)
-	if consoleURL := cfg.ConsoleSessionURL(sessionUUID); consoleURL != "" {
-		fmt.Printf("    %sConsole:%s  %s\n", display.Dim, display.Reset, consoleURL)
+	colors := display.CurrentColorLevel().String()
+	if display.NoColor() {
+		colors = "none (NO_COLOR)"
+	} else if display.Accessible() {
+		colors = "none (accessible)"
 	}
 	fmt.Println()
-	fmt.Printf(" %s──────────────────────────────────────────────────────────────────────────%s\n", display.Dim, display.Reset)
-
-	// Use the StreamDisplay handler — it deduplicates progress messages,
-	// compresses chain-of-thought token streams, parses source JSON,
-	// and strips HTML from chat responses.
-	streamDisplay := api.NewStreamDisplay(debugMode)
-
-	err = client.ProcessPromptStream(cfg.ProjectID, sessionUUID, prompt, streamDisplay.HandleEvent)
-
+	display.Info("Icons:", icons)
+	display.Info("Color output:", colors)
 	fmt.Println()
-	fmt.Printf(" %s──────────────────────────────────────────────────────────────────────────%s\n", display.Dim, display.Reset)
+	fmt.Printf("  %sOverride with HAWKEYE_ASCII=1|0, HAWKEYE_COLOR=none|16|256|truecolor, NO_COLOR=1 or HAWKEYE_ACCESSIBLE=1.%s\n", display.Dim, display.Reset)
+	return nil
+}
 
+func cmdConfigDefaults(args []string) error {
+	cfg, err := config.Load(activeProfile)
 	if err != nil {
-		return fmt.Errorf("stream error: %w", err)
+		return err
 	}
 
-	display.Success("Investigation complete")
-	fmt.Printf("\n  %sTip:%s Run %shawkeye inspect %s%s to review the full session.\n",
-		display.Dim, display.Reset, display.Cyan, sessionUUID, display.Reset)
-	fmt.Printf("  %sTip:%s Run %shawkeye summary %s%s for an executive summary.\n\n",
-		display.Dim, display.Reset, display.Cyan, sessionUUID, display.Reset)
+	switch args[0] {
+	case "set-default":
+		if len(args) != 3 {
+			fmt.Println("Usage: hawkeye config set-default <command[.subcommand].flag> <value>")
+			fmt.Println()
+			fmt.Println("A command.flag default applies to the command alone, not to its subcommands.")
+			fmt.Println()
+			fmt.Println("Examples:")
+			fmt.Println("  hawkeye config set-default sessions.limit 50")
+			fmt.Println("  hawkeye config set-default investigate.debug true")
+			fmt.Println("  hawkeye config set-default connections.list.limit 5")
+			return nil
+		}
+		if err := cfg.SetDefault(args[1], args[2]); err != nil {
+			return err
+		}
+		if err := cfg.Save(); err != nil {
+			return err
+		}
+		display.Success(fmt.Sprintf("Default %s set to %s", args[1], args[2]))
+		return nil
+	case "unset-default":
+		if len(args) != 2 {
+			fmt.Println("Usage: hawkeye config unset-default <command.flag>")
+			return nil
+		}
+		if !cfg.UnsetDefault(args[1]) {
+			return fmt.Errorf("no default set for %s", args[1])
+		}
+		if err := cfg.Save(); err != nil {
+			return err
+		}
+		display.Success(fmt.Sprintf("Default %s removed", args[1]))
+		return nil
+	}
 
+	if jsonOutput {
+		defaults := cfg.Defaults
+		if defaults == nil {
+			defaults = map[string]string{}
+		}
+		return printJSON(defaults)
+	}
+	if len(cfg.Defaults) == 0 {
+		fmt.Println("No command defaults. Set one with: hawkeye config set-default <command.flag> <value>")
+		return nil
+	}
+	display.Header("Command Defaults")
+	for _, key := range cfg.DefaultKeys() {
+		fmt.Printf("  %s%-32s%s %s\n", display.Bold, key, display.Reset, cfg.Defaults[key])
+	}
+	fmt.Printf("\n  %sTip:%s Explicit flags override these; --no-defaults skips them for one run.\n\n",
+		display.Dim, display.Reset)
 	return nil
 }
 
-// ─── sessions ───────────────────────────────────────────────────────────────
-
-func cmdSessions(args []string) error {
-	limit := 20
-	var status, from, to, search string
-	var uninvestigated bool
-
+// cmdConfigExport writes the profile's shareable settings as YAML.
+func cmdConfigExport(args []string) error {
+	var out string
+	var includeSecrets bool
 	for i := 0; i < len(args); i++ {
 		switch args[i] {
-		case "-n", "--limit":
-			if i+1 < len(args) {
-				i++
-				n, err := strconv.Atoi(args[i])
-				if err != nil {
-					return fmt.Errorf("invalid limit: %s", args[i])
-				}
-				limit = n
-			}
-		case "--status":
-			if i+1 < len(args) {
-				i++
-				status = args[i]
+		case "--include-secrets":
+			includeSecrets = true
+		case "--out", "-o":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--out requires a value")
 			}
-		case "--from":
-			if i+1 < len(args) {
-				i++
-				from = args[i]
-			}
-		case "--to":
-			if i+1 < len(args) {
-				i++
-				to = args[i]
-			}
-		case "--search":
-			if i+1 < len(args) {
-				i++
-				search = args[i]
-			}
-		case "--uninvestigated":
-			uninvestigated = true
+			i++
+			out = args[i]
+		default:
+			return fmt.Errorf("unknown flag for config export: %s", args[i])
 		}
 	}
 
@@ -549,285 +1250,6536 @@ func cmdSessions(args []string) error {
 	if err != nil {
 		return err
 	}
-	if err := cfg.ValidateProject(); err != nil {
+	settings := cfg.ExportTeamSettings(includeSecrets)
+	data, err := config.MarshalTeamSettings(settings)
+	if err != nil {
 		return err
 	}
-
-	client := api.NewClient(cfg)
-
-	filters := service.BuildSessionFilters(status, from, to, search, uninvestigated)
-	resp, err := client.SessionList(cfg.ProjectID, 0, limit, filters)
-	if err != nil {
-		return fmt.Errorf("listing sessions: %w", err)
+	if settings.Token != "" {
+		fmt.Fprintf(os.Stderr, "%s!%s The export includes your API token; keep it to yourself or leave out --include-secrets.\n", display.Yellow, display.Reset)
 	}
 
-	if jsonOutput {
-		return printJSON(resp.Sessions)
+	if out == "" {
+		_, err := os.Stdout.Write(data)
+		return err
 	}
+	if err := os.WriteFile(out, data, 0600); err != nil {
+		return fmt.Errorf("writing %s: %w", out, err)
+	}
+	display.Success(fmt.Sprintf("Settings of profile %s written to %s", config.ProfileName(activeProfile), out))
+	return nil
+}
 
-	display.Header(fmt.Sprintf("Sessions (%d)", len(resp.Sessions)))
-
-	if len(resp.Sessions) == 0 {
-		display.Warn("No sessions found.")
+// cmdConfigImport applies a team settings file to the active profile.
+func cmdConfigImport(args []string) error {
+	var file string
+	var dryRun bool
+	for _, a := range args {
+		switch a {
+		case "--dry-run":
+			dryRun = true
+		default:
+			if strings.HasPrefix(a, "-") && a != "-" {
+				return fmt.Errorf("unknown flag for config import: %s", a)
+			}
+			file = a
+		}
+	}
+	if file == "" {
+		fmt.Println("Usage: hawkeye config import <team.yaml|-> [--profile <name>] [--dry-run]")
 		return nil
 	}
 
-	for _, s := range resp.Sessions {
-		name := s.Name
-		if name == "" {
-			name = display.Dim + "(unnamed)" + display.Reset
-		}
+	data, err := readInputFile(file)
+	if err != nil {
+		return fmt.Errorf("reading team settings: %w", err)
+	}
+	settings, err := config.ParseTeamSettings(data)
+	if err != nil {
+		return err
+	}
 
-		pinned := ""
-		if s.Pinned {
-			pinned = " 📌"
+	cfg, err := config.Load(activeProfile)
+	if err != nil {
+		return err
+	}
+	changed := cfg.ImportTeamSettings(settings)
+	if !dryRun && len(changed) > 0 {
+		if err := cfg.Save(); err != nil {
+			return err
 		}
+	}
 
-		typeIcon := "💬"
-		if s.SessionType == "SESSION_TYPE_INCIDENT" {
-			typeIcon = "🚨"
+	if jsonOutput {
+		return printJSON(map[string]any{
+			"profile": config.ProfileName(activeProfile),
+			"changed": append([]string{}, changed...),
+			"dry_run": dryRun,
+		})
+	}
+	profile := config.ProfileName(activeProfile)
+	if len(changed) == 0 {
+		display.Success(fmt.Sprintf("Profile %s already matches %s", profile, file))
+	} else if dryRun {
+		display.Header(fmt.Sprintf("Would change profile %s", profile))
+		for _, k := range changed {
+			fmt.Printf("  • %s\n", k)
 		}
-
-		created := display.FormatTime(s.CreateTime)
-		status := display.InvestigationStatusLabel(s.InvestigationStatus)
-
-		fmt.Printf("\n  %s %s%s%s%s\n", typeIcon, display.Bold, name, display.Reset, pinned)
-		fmt.Printf("    %sID:%s      %s\n", display.Dim, display.Reset, s.SessionUUID)
-		fmt.Printf("    %sCreated:%s %s\n", display.Dim, display.Reset, created)
-		fmt.Printf("    %sStatus:%s  %s\n", display.Dim, display.Reset, status)
+	} else {
+		display.Success(fmt.Sprintf("Imported %d setting(s) into profile %s: %s", len(changed), profile, strings.Join(changed, ", ")))
+	}
+	if cfg.Token == "" && cfg.Server != "" {
+		pf := ""
+		if activeProfile != "" {
+			pf = " --profile " + activeProfile
+		}
+		fmt.Printf("\n  %sNext:%s %shawkeye%s login %s -u <username>%s\n\n", display.Dim, display.Reset, display.Cyan, pf, cfg.Server, display.Reset)
 	}
-
-	fmt.Println()
-	fmt.Println(strings.Repeat("─", 80))
-	fmt.Printf("  %sTip:%s Run %shawkeye inspect <session-uuid>%s to see details.\n\n",
-		display.Dim, display.Reset, display.Cyan, display.Reset)
-
 	return nil
 }
 
-// ─── inspect ────────────────────────────────────────────────────────────────
-
-func cmdInspect(args []string) error {
+func cmdConfigValidate() error {
 	cfg, err := config.Load(activeProfile)
 	if err != nil {
 		return err
 	}
-	if err := cfg.ValidateProject(); err != nil {
-		return err
+	problems := cfg.Check()
+	if cfg.Timezone != "" {
+		if _, err := display.LoadTimeZone(cfg.Timezone); err != nil {
+			problems = append(problems, fmt.Sprintf("timezone: %v", err))
+		}
 	}
-
-	sessionUUID := ""
-	if len(args) > 0 {
-		sessionUUID = args[0]
-	} else if cfg.LastSession != "" {
-		sessionUUID = cfg.LastSession
-	} else {
-		fmt.Println("Usage: hawkeye inspect [session-uuid]")
-		return nil
+	if cfg.Theme != "" {
+		if _, err := display.LoadTheme(cfg.Theme); err != nil {
+			problems = append(problems, fmt.Sprintf("theme: %v", err))
+		}
 	}
-
-	client := api.NewClient(cfg)
-
-	resp, err := client.SessionInspect(cfg.ProjectID, sessionUUID)
-	if err != nil {
-		return fmt.Errorf("inspecting session: %w", err)
+	if cfg.Language != "" {
+		if _, err := service.NormalizeLanguage(cfg.Language); err != nil {
+			problems = append(problems, fmt.Sprintf("language: %v", err))
+		}
 	}
-
-	if jsonOutput {
-		return printJSON(resp)
+	if err := api.CheckTransport(cfg); err != nil {
+		problems = append(problems, fmt.Sprintf("transport: %v", err))
 	}
-
-	if resp.SessionInfo != nil {
-		s := resp.SessionInfo
-		name := s.Name
-		if name == "" {
-			name = "(unnamed)"
+	for _, t := range []struct{ key, value string }{{"timeout", cfg.Timeout}, {"stream-idle-timeout", cfg.StreamIdleTimeout}} {
+		if t.value == "" {
+			continue
+		}
+		if _, err := api.ParseTimeout(t.value); err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %v", t.key, err))
 		}
-		display.Header(fmt.Sprintf("Session: %s", name))
-		display.Info("UUID:", s.SessionUUID)
-		display.Info("Created:", display.FormatTime(s.CreateTime))
-		display.Info("Updated:", display.FormatTime(s.LastUpdate))
-		display.Info("Type:", s.SessionType)
-		display.Info("Investigation:", display.InvestigationStatusLabel(s.InvestigationStatus))
 	}
 
-	if len(resp.PromptCycle) == 0 {
-		fmt.Println()
-		display.Warn("No prompt cycles found.")
+	if jsonOutput {
+		if err := printJSON(map[string]any{
+			"profile":        config.ProfileName(activeProfile),
+			"config_version": cfg.Version,
+			"valid":          len(problems) == 0,
+			"problems":       append([]string{}, problems...),
+			"warnings":       append([]string{}, cfg.Warnings()...),
+		}); err != nil {
+			return err
+		}
+	} else {
+		// Load warnings were already printed at startup.
+		display.Header(fmt.Sprintf("Config check: profile %s (version %d)", config.ProfileName(activeProfile), cfg.Version))
+		for _, p := range problems {
+			fmt.Printf("%s✗%s %s\n", display.Red, display.Reset, p)
+		}
+		if len(problems) == 0 {
+			display.Success("Config is valid")
+		}
+		fmt.Println()
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("config has %d problem(s)", len(problems))
+	}
+	return nil
+}
+
+func cmdConfigEncrypt() error {
+	cfg, err := config.Load(activeProfile)
+	if err != nil {
+		return err
+	}
+	passphrase := os.Getenv(config.PassphraseEnv)
+	if passphrase == "" {
+		if passphrase, err = readPassphrase("New passphrase: "); err != nil {
+			return err
+		}
+		confirm, err := readPassphrase("Confirm passphrase: ")
+		if err != nil {
+			return err
+		}
+		if confirm != passphrase {
+			return fmt.Errorf("passphrases do not match")
+		}
+	}
+	rotated := cfg.Encrypted()
+	if err := cfg.Encrypt(passphrase); err != nil {
+		return err
+	}
+	if rotated {
+		display.Success(fmt.Sprintf("Profile %s re-encrypted with the new passphrase", config.ProfileName(activeProfile)))
+	} else {
+		display.Success(fmt.Sprintf("Profile %s encrypted", config.ProfileName(activeProfile)))
+	}
+	display.Info("Unlock:", fmt.Sprintf("enter the passphrase when prompted, or set %s", config.PassphraseEnv))
+	return nil
+}
+
+func cmdConfigDecrypt() error {
+	cfg, err := config.Load(activeProfile)
+	if err != nil {
+		return err
+	}
+	if !cfg.Encrypted() {
+		display.Info("Profile is not encrypted:", config.ProfileName(activeProfile))
 		return nil
 	}
+	if err := cfg.Decrypt(); err != nil {
+		return err
+	}
+	display.Success(fmt.Sprintf("Profile %s stored as plaintext", config.ProfileName(activeProfile)))
+	return nil
+}
 
-	for i, pc := range resp.PromptCycle {
-		fmt.Println()
-		display.SubHeader(fmt.Sprintf("── Prompt Cycle %d ──", i+1))
+// readPassphrase reads a passphrase from the terminal without echoing it.
+func readPassphrase(prompt string) (string, error) {
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		return "", fmt.Errorf("no terminal to read a passphrase from; set %s", config.PassphraseEnv)
+	}
+	fmt.Fprint(os.Stderr, prompt)
+	b, err := term.ReadPassword(fd)
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return "", fmt.Errorf("reading passphrase: %w", err)
+	}
+	return string(b), nil
+}
 
-		if pc.Request != nil && len(pc.Request.Messages) > 0 {
-			for _, msg := range pc.Request.Messages {
-				if msg.Content != nil && len(msg.Content.Parts) > 0 {
-					fmt.Printf("  %s❯%s %s\n", display.Cyan, display.Reset,
-						strings.Join(msg.Content.Parts, " "))
+// unlockProfiles makes config.Load prompt for the passphrase of an
+// encrypted profile, once per profile per run.
+func unlockProfiles() {
+	fromEnv := config.PassphraseFunc
+	unlocked := map[string]string{}
+	config.PassphraseFunc = func(profile string) (string, error) {
+		if p, ok := unlocked[profile]; ok {
+			return p, nil
+		}
+		if os.Getenv(config.PassphraseEnv) != "" || !term.IsTerminal(int(os.Stdin.Fd())) {
+			return fromEnv(profile)
+		}
+		p, err := readPassphrase(fmt.Sprintf("Passphrase for profile %s: ", config.ProfileName(profile)))
+		if err != nil {
+			return "", err
+		}
+		unlocked[profile] = p
+		return p, nil
+	}
+}
+
+// expandHome replaces a leading ~ in path with the home directory, for
+// paths given in quotes that the shell did not expand.
+func expandHome(path string) (string, error) {
+	if path != "~" && !strings.HasPrefix(path, "~/") && !strings.HasPrefix(path, `~\`) {
+		return path, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, path[1:]), nil
+}
+
+// isUnsetValue reports whether a set value asks to clear the setting.
+func isUnsetValue(v string) bool {
+	switch strings.ToLower(v) {
+	case "", "none", "off":
+		return true
+	}
+	return false
+}
+
+// ─── investigate ────────────────────────────────────────────────────────────
+
+func cmdInvestigate(args []string) error {
+	var sessionUUID, kubeContext, namespace, recordPath, lang, projectList, verbosityFlag, templateName, promptFile string
+	var debugMode, answerOnly, quiet, jsonStream, noAutoName, allProjects bool
+	var positional, sinkSpecs, contextSpecs, varSpecs []string
+	concurrency := 3
+
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "-s", "--session":
+			if i+1 < len(args) {
+				i++
+				sessionUUID = args[i]
+			} else {
+				return fmt.Errorf("--session requires a value")
+			}
+		case "--k8s-context":
+			if i+1 < len(args) {
+				i++
+				kubeContext = args[i]
+			} else {
+				return fmt.Errorf("--k8s-context requires a value")
+			}
+		case "--namespace":
+			if i+1 < len(args) {
+				i++
+				namespace = args[i]
+			} else {
+				return fmt.Errorf("--namespace requires a value")
+			}
+		case "--debug":
+			debugMode = true
+		case "--answer-only":
+			answerOnly = true
+		case "-q", "--quiet":
+			quiet = true
+		case "--json-stream":
+			jsonStream = true
+		case "--no-auto-name":
+			noAutoName = true
+		case "--lang":
+			if i+1 < len(args) {
+				i++
+				lang = args[i]
+			} else {
+				return fmt.Errorf("--lang requires a value")
+			}
+		case "--sink":
+			if i+1 < len(args) {
+				i++
+				sinkSpecs = append(sinkSpecs, args[i])
+			} else {
+				return fmt.Errorf("--sink requires a value")
+			}
+		case "--record":
+			if i+1 < len(args) {
+				i++
+				recordPath = args[i]
+			} else {
+				return fmt.Errorf("--record requires a value")
+			}
+		case "--projects":
+			if i+1 < len(args) {
+				i++
+				projectList = args[i]
+			} else {
+				return fmt.Errorf("--projects requires a value")
+			}
+		case "--all-projects":
+			allProjects = true
+		case "--context":
+			if i+1 < len(args) {
+				i++
+				contextSpecs = append(contextSpecs, args[i])
+			} else {
+				return fmt.Errorf("--context requires a file or - for stdin")
+			}
+		case "--verbosity":
+			if i+1 < len(args) {
+				i++
+				verbosityFlag = args[i]
+			} else {
+				return fmt.Errorf("--verbosity requires a value")
+			}
+		case "--template":
+			if i+1 < len(args) {
+				i++
+				templateName = args[i]
+			} else {
+				return fmt.Errorf("--template requires a name")
+			}
+		case "-f", "--file":
+			if i+1 < len(args) {
+				i++
+				promptFile = args[i]
+			} else {
+				return fmt.Errorf("--file requires a file or - for stdin")
+			}
+		case "--var":
+			if i+1 < len(args) {
+				i++
+				varSpecs = append(varSpecs, args[i])
+			} else {
+				return fmt.Errorf("--var requires name=value")
+			}
+		case "--concurrency":
+			if i+1 < len(args) {
+				i++
+				n, err := strconv.Atoi(args[i])
+				if err != nil || n <= 0 {
+					return fmt.Errorf("invalid concurrency: %s", args[i])
 				}
+				concurrency = n
+			} else {
+				return fmt.Errorf("--concurrency requires a value")
 			}
+		default:
+			positional = append(positional, args[i])
 		}
+	}
 
-		if pc.Status != "" {
-			fmt.Printf("  %sStatus:%s %s\n", display.Dim, display.Reset, pc.Status)
+	// --template supplies the prompt, with its --var values filled in,
+	// and the session instructions to apply before it is sent.
+	var tmplInstructions []config.TemplateInstruction
+	if templateName != "" {
+		if len(positional) > 0 {
+			return fmt.Errorf("--template supplies the question; drop %q or leave out --template", strings.Join(positional, " "))
+		}
+		rendered, err := loadTemplateRun(templateName, varSpecs)
+		if err != nil {
+			return err
 		}
+		positional = []string{rendered.Prompt}
+		tmplInstructions = rendered.Instructions
+	} else if len(varSpecs) > 0 {
+		return fmt.Errorf("--var only applies with --template")
+	}
+
+	// --file sends the prompt as written, so markdown and code blocks
+	// never meet the shell's quoting.
+	if promptFile != "" {
+		switch {
+		case len(positional) > 0:
+			return fmt.Errorf("--file supplies the question; drop %q or leave out --file", strings.Join(positional, " "))
+		case templateName != "":
+			return fmt.Errorf("--file and --template both supply the question; use one")
+		case promptFile == "-" && slices.Contains(contextSpecs, "-"):
+			return fmt.Errorf("--file - and --context - both read stdin; pass one of them a file")
+		}
+		text, err := readPromptFile(promptFile)
+		if err != nil {
+			return err
+		}
+		positional = []string{text}
+	}
 
-		// Chain of Thoughts
-		if len(pc.ChainOfThoughts) > 0 {
-			fmt.Printf("\n  %s🧠 Chain of Thought:%s\n", display.Magenta, display.Reset)
-			for _, cot := range pc.ChainOfThoughts {
-				status := display.CoTStatusLabel(cot.CotStatus)
-				if cot.CotStatus == "" {
-					status = display.CoTStatusLabel(cot.Status)
-				}
-				category := cot.Category
-				if category == "" {
-					category = "analysis"
-				}
+	if len(positional) == 0 {
+		fmt.Println("Usage: hawkeye investigate <question> [--session <uuid>]")
+		fmt.Println("       hawkeye investigate <question> --projects <uuid|name,...> | --all-projects")
+		fmt.Println()
+		fmt.Println("Examples:")
+		fmt.Println(`  hawkeye investigate "Why is the API returning 500 errors?"`)
+		fmt.Println(`  hawkeye investigate "Check database latency" --session <uuid>`)
+		fmt.Println(`  hawkeye investigate --k8s-context prod --namespace checkout "Why are pods crashlooping?"`)
+		fmt.Println(`  kubectl logs deploy/checkout | hawkeye investigate "Why is this failing?" --context -`)
+		fmt.Println(`  hawkeye investigate "Where did checkout latency come from?" --projects payments,checkout`)
+		fmt.Println(`  hawkeye investigate --template db-latency --var db=orders`)
+		fmt.Println(`  hawkeye investigate -f incident.md`)
+		return nil
+	}
+	prompt := strings.Join(positional, " ")
+	fanout := projectList != "" || allProjects
+	if fanout && (sessionUUID != "" || jsonStream || answerOnly || quiet || recordPath != "" || len(sinkSpecs) > 0 || outputFormat == "gha" || templateName != "") {
+		return fmt.Errorf("--projects and --all-projects cannot be combined with --session, --json-stream, --answer-only, --quiet, --record, --sink, --template or --output gha")
+	}
 
-				fmt.Printf("    %s[%s]%s %s\n", display.Bold, category, display.Reset, status)
+	sinks, err := parseSinks(sinkSpecs)
+	if err != nil {
+		return err
+	}
+	verbosity, err := api.ParseVerbosity(verbosityFlag)
+	if err != nil {
+		return err
+	}
+	inputParts, err := readInputContext(contextSpecs)
+	if err != nil {
+		return err
+	}
+	if lang, err = service.NormalizeLanguage(lang); err != nil {
+		return err
+	}
 
-				if cot.Description != "" {
-					for _, line := range strings.Split(api.RenderMarkdown(cot.Description), "\n") {
-						fmt.Printf("      %s\n", line)
-					}
-				}
+	cfg, err := config.Load(activeProfile)
+	if err != nil {
+		return err
+	}
+	if fanout {
+		if err := cfg.Validate(); err != nil {
+			return err
+		}
+		client := api.NewClient(cfg)
+		client.SetLanguage(lang)
+		return runFanout(cfg, client, prompt, projectList, allProjects, concurrency, !noAutoName && !cfg.NoAutoName, kubeContext, namespace, inputParts)
+	}
+	if err := cfg.ValidateProject(); err != nil {
+		return err
+	}
 
-				if cot.Investigation != "" {
-					fmt.Printf("      %sInvestigation:%s\n", display.Dim, display.Reset)
-					for _, line := range strings.Split(api.RenderMarkdown(cot.Investigation), "\n") {
-						fmt.Printf("        %s\n", line)
-					}
-				}
+	sessionUUID = cfg.ResolveSession(sessionUUID)
 
-				if len(cot.Sources) > 0 {
-					fmt.Printf("      %sSources:%s %s\n", display.Dim, display.Reset,
-						strings.Join(cot.Sources, ", "))
-				}
+	client := api.NewClient(cfg)
+	client.SetDebug(debugMode)
+	if lang != "" {
+		client.SetLanguage(lang)
+	}
+	autoName := !noAutoName && !cfg.NoAutoName
 
-				if cot.ProcessingTime != "" && cot.ProcessingTime != "0" {
-					fmt.Printf("      %sTime:%s %sms\n", display.Dim, display.Reset, cot.ProcessingTime)
+	// A template's instructions must be on the session before the prompt
+	// is sent, so a new session is created here rather than by the run.
+	templateSession := false
+	if len(tmplInstructions) > 0 {
+		if sessionUUID == "" {
+			sessResp, err := client.NewSession(cfg.ProjectID)
+			if err != nil {
+				return fmt.Errorf("creating session: %w", err)
+			}
+			sessionUUID = sessResp.SessionUUID
+			templateSession = true
+			if autoName {
+				if err := client.RenameSession(cfg.ProjectID, sessionUUID, service.SessionTitle(prompt)); err != nil {
+					fmt.Fprintf(os.Stderr, "warning: could not name session: %v\n", err)
 				}
 			}
 		}
+		for _, in := range tmplInstructions {
+			if err := client.ApplySessionInstruction(sessionUUID, in.Type, in.Content); err != nil {
+				return fmt.Errorf("applying %s instruction of template %s: %w", in.Type, templateName, err)
+			}
+		}
+	}
 
-		// Sources
-		if len(pc.Sources) > 0 {
-			fmt.Printf("\n  %s📎 Sources:%s\n", display.Blue, display.Reset)
-			for _, src := range pc.Sources {
-				title := src.Title
-				if title == "" {
-					title = src.ID
-				}
-				cat := ""
-				if src.Category != "" {
-					cat = fmt.Sprintf(" %s(%s)%s", display.Dim, src.Category, display.Reset)
-				}
-				fmt.Printf("    • %s%s\n", title, cat)
-				if src.Description != "" {
-					fmt.Printf("      %s%s%s\n", display.Gray, truncate(src.Description, 100), display.Reset)
-				}
+	if outputFormat == "gha" {
+		return runGHA(cfg, client, sessionUUID, prompt, kubeContext, namespace, inputParts, autoName, sinks)
+	}
+	if jsonStream {
+		return runJSONStream(cfg, client, sessionUUID, prompt, kubeContext, namespace, inputParts, autoName, sinks)
+	}
+	// Streaming output is never JSON, so --output json implies --quiet.
+	if jsonOutput {
+		return runJSONResult(cfg, client, sessionUUID, prompt, kubeContext, namespace, inputParts, autoName, sinks)
+	}
+	if answerOnly || quiet {
+		return runAnswerOnly(cfg, client, sessionUUID, prompt, kubeContext, namespace, inputParts, autoName, sinks)
+	}
+
+	// Gather live cluster state before creating the session so a missing
+	// kubectl doesn't leave an empty session behind.
+	var contextParts, contextLabels []string
+	if kubeContext != "" || namespace != "" {
+		fmt.Println()
+		sp := display.Spin("Gathering Kubernetes context...")
+		contextParts, contextLabels, err = gatherKubeContext(kubeContext, namespace)
+		sp.Stop()
+		if err != nil {
+			display.Warn(fmt.Sprintf("Kubernetes context unavailable: %v", err))
+		}
+	}
+	contextParts = append(contextParts, inputParts...)
+
+	// Create session if needed
+	if sessionUUID == "" {
+		fmt.Println()
+		sp := display.Spin("Creating new investigation session...")
+		sessResp, err := client.NewSession(cfg.ProjectID)
+		sp.Stop()
+		if err != nil {
+			return fmt.Errorf("creating session: %w", err)
+		}
+		sessionUUID = sessResp.SessionUUID
+		display.Success(fmt.Sprintf("Session created: %s", sessionUUID))
+		if autoName {
+			if err := client.RenameSession(cfg.ProjectID, sessionUUID, service.SessionTitle(prompt)); err != nil {
+				display.Warn(fmt.Sprintf("Could not name session: %v", err))
 			}
 		}
+	} else if templateSession {
+		fmt.Println()
+		display.Success(fmt.Sprintf("Session created: %s", sessionUUID))
+	} else {
+		fmt.Println()
+		display.Success(fmt.Sprintf("Continuing session: %s", sessionUUID))
+	}
+
+	cfg.LastSession = sessionUUID
+	_ = cfg.Save()
+	recordHistory(prompt, sessionUUID)
+
+	fmt.Printf("\n %s── 🦅 Hawkeye Investigation ──────────────────────────────────────────────%s\n", display.Dim, display.Reset)
+	fmt.Println()
+	fmt.Printf("    %sPrompt:%s   %s\n", display.Dim, display.Reset, prompt)
+	fmt.Printf("    %sSession:%s  %s\n", display.Dim, display.Reset, sessionUUID)
+	if templateName != "" {
+		fmt.Printf("    %sTemplate:%s %s (%d instruction(s) applied)\n", display.Dim, display.Reset, templateName, len(tmplInstructions))
+	}
+	if consoleURL := cfg.ConsoleSessionURL(sessionUUID); consoleURL != "" {
+		fmt.Printf("    %sConsole:%s  %s\n", display.Dim, display.Reset, consoleURL)
+	}
+	if len(contextLabels) > 0 {
+		fmt.Printf("    %sContext:%s  kubectl %s\n", display.Dim, display.Reset, strings.Join(contextLabels, ", "))
+	}
+	for _, spec := range contextSpecs {
+		fmt.Printf("    %sContext:%s  %s\n", display.Dim, display.Reset, inputContextSource(spec))
+	}
+	fmt.Println()
+	fmt.Printf(" %s──────────────────────────────────────────────────────────────────────────%s\n", display.Dim, display.Reset)
+
+	// Use the StreamDisplay handler — it deduplicates progress messages,
+	// compresses chain-of-thought token streams, parses source JSON,
+	// and strips HTML from chat responses.
+	streamDisplay := api.NewStreamDisplay(debugMode)
+	streamDisplay.SetVerbosity(verbosity)
+	handler := streamDisplay.HandleEvent
+
+	// --sink needs the final answer, so collect it alongside the display,
+	// and watch for the backend giving up on the investigation.
+	var collector service.AnswerCollector
+	var failures service.FailureCollector
+	handler = func(resp *api.ProcessPromptResponse) {
+		collector.Handle(resp)
+		failures.Handle(resp)
+		streamDisplay.HandleEvent(resp)
+	}
+
+	// --record tees every raw event to an NDJSON file for `hawkeye replay`.
+	var recorder *api.EventWriter
+	if recordPath != "" {
+		f, err := os.Create(recordPath)
+		if err != nil {
+			return fmt.Errorf("creating recording: %w", err)
+		}
+		defer f.Close()
+		recorder = api.NewEventWriter(f)
+		recorder.Write(api.StreamEvent{Time: time.Now(), EventType: "session", SessionUUID: sessionUUID})
+		next := handler
+		handler = func(resp *api.ProcessPromptResponse) {
+			recorder.HandleEvent(resp)
+			next(resp)
+		}
+	}
+
+	handler, finishTranscript := recordTranscript(cfg, cfg.ProjectID, cfg.ProjectName, sessionUUID, prompt, handler)
+	started := time.Now()
+	err = client.ProcessPromptStreamWithContext(cfg.ProjectID, sessionUUID, prompt, contextParts, handler)
+	transcriptPath := finishTranscript(err)
+
+	fmt.Println()
+	fmt.Printf(" %s──────────────────────────────────────────────────────────────────────────%s\n", display.Dim, display.Reset)
+
+	if err != nil {
+		if transcriptPath != "" {
+			display.Info("Transcript:", transcriptPath)
+		}
+		notifyInvestigation(cfg, sessionUUID, prompt, err.Error(), started)
+		return fmt.Errorf("stream error: %w", err)
+	}
+
+	if f := failures.Failure(); f != nil {
+		if transcriptPath != "" {
+			display.Info("Transcript:", transcriptPath)
+		}
+		notifyInvestigation(cfg, sessionUUID, prompt, f.Reason, started)
+		return reportInvestigationFailure(sessionUUID, f)
+	}
+
+	display.Success("Investigation complete")
+	notifyInvestigation(cfg, sessionUUID, prompt, "", started)
+	if transcriptPath != "" {
+		display.Success(fmt.Sprintf("Transcript written to %s", transcriptPath))
+	}
+	if recorder != nil {
+		if err := recorder.Err(); err != nil {
+			display.Warn(fmt.Sprintf("Recording incomplete: %v", err))
+		} else {
+			display.Success(fmt.Sprintf("Stream recorded to %s", recordPath))
+		}
+	}
+	deliverSinks(sinks, investigationSinkResult(cfg, sessionUUID, prompt, collector.Answer()), false)
+	fmt.Printf("\n  %sTip:%s Run %shawkeye inspect %s%s to review the full session.\n",
+		display.Dim, display.Reset, display.Cyan, sessionUUID, display.Reset)
+	fmt.Printf("  %sTip:%s Run %shawkeye summary %s%s for an executive summary.\n\n",
+		display.Dim, display.Reset, display.Cyan, sessionUUID, display.Reset)
+
+	return nil
+}
+
+// exitInvestigationFailed is the exit code when the backend reports that
+// an investigation failed, so scripts can tell it apart from CLI, network
+// and API errors (exit code 1).
+const exitInvestigationFailed = 3
+
+// investigationFailedError is returned when the stream ended normally but
+// the backend gave up on the investigation. reported is set when the
+// reason has already been shown, so main does not print it twice.
+type investigationFailedError struct {
+	sessionUUID string
+	failure     service.InvestigationFailure
+	reported    bool
+}
+
+func (e *investigationFailedError) Error() string {
+	return fmt.Sprintf("investigation failed (session %s): %s", e.sessionUUID, e.failure.Reason)
+}
+
+// reportInvestigationFailure shows why the backend gave up on an
+// investigation and how to dig into it, in place of "Investigation complete".
+func reportInvestigationFailure(sessionUUID string, f *service.InvestigationFailure) error {
+	fmt.Printf("%s✗ Investigation failed%s\n", display.Bold+display.Red, display.Reset)
+	fmt.Println()
+	fmt.Printf("    %sReason:%s   %s%s%s\n", display.Dim, display.Reset, display.Red, f.Reason, display.Reset)
+	if f.Status != "" {
+		fmt.Printf("    %sStatus:%s   %s\n", display.Dim, display.Reset, service.FailureStatusLabel(f.Status))
+	}
+	fmt.Printf("    %sSession:%s  %s\n", display.Dim, display.Reset, sessionUUID)
+	fmt.Printf("\n  %sTip:%s Run %shawkeye queries %s%s to see the queries that ran.\n",
+		display.Dim, display.Reset, display.Cyan, sessionUUID, display.Reset)
+	fmt.Printf("  %sTip:%s Run %shawkeye rerun %s%s to try the investigation again.\n\n",
+		display.Dim, display.Reset, display.Cyan, sessionUUID, display.Reset)
+	return &investigationFailedError{sessionUUID: sessionUUID, failure: *f, reported: true}
+}
+
+// runJSONStream runs an investigation and writes every stream event to
+// stdout as newline-delimited JSON instead of rendering it. The first line
+// is a synthetic "session" event carrying the session UUID.
+func runJSONStream(cfg *config.Config, client *api.Client, sessionUUID, prompt, kubeContext, namespace string, inputParts []string, autoName bool, sinks []service.Sink) error {
+	w := api.NewEventWriter(os.Stdout)
+
+	sessionUUID, contextParts, err := prepareQuietRun(cfg, client, sessionUUID, prompt, kubeContext, namespace, inputParts, autoName)
+	if err != nil {
+		w.Write(api.StreamEvent{Time: time.Now(), EventType: "error", Error: err.Error()})
+		return err
+	}
+
+	w.Write(api.StreamEvent{Time: time.Now(), EventType: "session", SessionUUID: sessionUUID})
+
+	var collector service.AnswerCollector
+	var failures service.FailureCollector
+	handler, finishTranscript := recordTranscript(cfg, cfg.ProjectID, cfg.ProjectName, sessionUUID, prompt, func(resp *api.ProcessPromptResponse) {
+		collector.Handle(resp)
+		failures.Handle(resp)
+		w.HandleEvent(resp)
+	})
+	err = client.ProcessPromptStreamWithContext(cfg.ProjectID, sessionUUID, prompt, contextParts, handler)
+	finishTranscript(err)
+	if err != nil {
+		w.Write(api.StreamEvent{Time: time.Now(), EventType: "error", SessionUUID: sessionUUID, Error: err.Error()})
+		return fmt.Errorf("stream error: %w", err)
+	}
+	if f := failures.Failure(); f != nil {
+		err := &investigationFailedError{sessionUUID: sessionUUID, failure: *f}
+		w.Write(api.StreamEvent{Time: time.Now(), EventType: "investigation_failed", SessionUUID: sessionUUID, Error: f.Reason})
+		return err
+	}
+	deliverSinks(sinks, investigationSinkResult(cfg, sessionUUID, prompt, collector.Answer()), true)
+	return w.Err()
+}
+
+// runAnswerOnly runs an investigation without any decoration and prints
+// only the final answer, so the output can be piped to other tools.
+// Warnings still go to stderr.
+func runAnswerOnly(cfg *config.Config, client *api.Client, sessionUUID, prompt, kubeContext, namespace string, inputParts []string, autoName bool, sinks []service.Sink) error {
+	sessionUUID, contextParts, err := prepareQuietRun(cfg, client, sessionUUID, prompt, kubeContext, namespace, inputParts, autoName)
+	if err != nil {
+		return err
+	}
+
+	var collector service.AnswerCollector
+	var failures service.FailureCollector
+	handler, finishTranscript := recordTranscript(cfg, cfg.ProjectID, cfg.ProjectName, sessionUUID, prompt, func(resp *api.ProcessPromptResponse) {
+		collector.Handle(resp)
+		failures.Handle(resp)
+	})
+	err = client.ProcessPromptStreamWithContext(cfg.ProjectID, sessionUUID, prompt, contextParts, handler)
+	finishTranscript(err)
+	if err != nil {
+		return fmt.Errorf("stream error: %w", err)
+	}
+	if f := failures.Failure(); f != nil {
+		return &investigationFailedError{sessionUUID: sessionUUID, failure: *f}
+	}
+
+	answer := collector.Answer()
+	if answer == "" {
+		return fmt.Errorf("investigation finished without an answer (session %s)", sessionUUID)
+	}
+	fmt.Println(answer)
+	deliverSinks(sinks, investigationSinkResult(cfg, sessionUUID, prompt, answer), true)
+	return nil
+}
+
+// runJSONResult runs an investigation without printing the stream and,
+// once it has ended, writes a single service.InvestigationResult document
+// to stdout: the answer, the sources cited, and the action items and
+// scores from the session summary as far as they are ready. Failures
+// still produce a document, with its error field set, and a non-zero exit.
+func runJSONResult(cfg *config.Config, client *api.Client, sessionUUID, prompt, kubeContext, namespace string, inputParts []string, autoName bool, sinks []service.Sink) error {
+	fail := func(result service.InvestigationResult, err error) error {
+		result.Error = err.Error()
+		printJSON(result)
+		return err
+	}
+
+	sessionUUID, contextParts, err := prepareQuietRun(cfg, client, sessionUUID, prompt, kubeContext, namespace, inputParts, autoName)
+	if err != nil {
+		return fail(service.BuildInvestigationResult(sessionUUID, "", nil, nil, ""), err)
+	}
+	consoleURL := cfg.ConsoleSessionURL(sessionUUID)
+
+	var answers service.AnswerCollector
+	var sources service.SourceCollector
+	var failures service.FailureCollector
+	handler, finishTranscript := recordTranscript(cfg, cfg.ProjectID, cfg.ProjectName, sessionUUID, prompt, func(resp *api.ProcessPromptResponse) {
+		answers.Handle(resp)
+		sources.Handle(resp)
+		failures.Handle(resp)
+	})
+	err = client.ProcessPromptStreamWithContext(cfg.ProjectID, sessionUUID, prompt, contextParts, handler)
+	finishTranscript(err)
+	answer := answers.Answer()
+	partial := service.BuildInvestigationResult(sessionUUID, answer, sources.Sources(), nil, consoleURL)
+	if err != nil {
+		return fail(partial, fmt.Errorf("stream error: %w", err))
+	}
+	if f := failures.Failure(); f != nil {
+		return fail(partial, &investigationFailedError{sessionUUID: sessionUUID, failure: *f})
+	}
+	if answer == "" {
+		return fail(partial, fmt.Errorf("investigation finished without an answer (session %s)", sessionUUID))
+	}
+
+	summary, err := client.GetSessionSummary(cfg.ProjectID, sessionUUID)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "warning: could not fetch the session summary: %v\n", err)
+		summary = nil
+	}
+	if err := printJSON(service.BuildInvestigationResult(sessionUUID, answer, sources.Sources(), summary, consoleURL)); err != nil {
+		return err
+	}
+	deliverSinks(sinks, investigationSinkResult(cfg, sessionUUID, prompt, answer), true)
+	return nil
+}
+
+// runGHA runs an investigation for a GitHub Actions step: the answer goes
+// to a collapsible log group, a notice annotation carries its first line
+// and the job summary gets the full markdown report. Failures become error
+// annotations as well as a non-zero exit.
+func runGHA(cfg *config.Config, client *api.Client, sessionUUID, prompt, kubeContext, namespace string, inputParts []string, autoName bool, sinks []service.Sink) error {
+	const title = "Hawkeye investigation"
+	fail := func(err error) error {
+		display.GHAError(title, err.Error())
+		return err
+	}
+
+	sessionUUID, contextParts, err := prepareQuietRun(cfg, client, sessionUUID, prompt, kubeContext, namespace, inputParts, autoName)
+	if err != nil {
+		return fail(err)
+	}
+
+	var collector service.AnswerCollector
+	var failures service.FailureCollector
+	handler, finishTranscript := recordTranscript(cfg, cfg.ProjectID, cfg.ProjectName, sessionUUID, prompt, func(resp *api.ProcessPromptResponse) {
+		collector.Handle(resp)
+		failures.Handle(resp)
+	})
+	err = client.ProcessPromptStreamWithContext(cfg.ProjectID, sessionUUID, prompt, contextParts, handler)
+	finishTranscript(err)
+	if err != nil {
+		return fail(fmt.Errorf("stream error: %w", err))
+	}
+	if f := failures.Failure(); f != nil {
+		return fail(&investigationFailedError{sessionUUID: sessionUUID, failure: *f})
+	}
+	answer := collector.Answer()
+	if answer == "" {
+		return fail(fmt.Errorf("investigation finished without an answer (session %s)", sessionUUID))
+	}
+
+	result := investigationSinkResult(cfg, sessionUUID, prompt, answer)
+	display.GHAGroup(title+": "+result.SessionName, answer)
+	notice := service.ShortSummary(answer, 200)
+	if result.ConsoleURL != "" {
+		notice += "\n" + result.ConsoleURL
+	}
+	display.GHANotice(title, notice)
+	if err := display.GHASummary(result.Markdown()); err != nil {
+		display.GHAWarning(title, err.Error())
+	}
+	deliverSinks(sinks, result, true)
+	return nil
+}
+
+// desktopNotify shows n as a desktop notification on this system. Tests
+// replace it.
+var desktopNotify = func(n service.Notification) error {
+	name, args, err := service.NotifyCommand(runtime.GOOS, n, exec.LookPath)
+	if err != nil {
+		return err
+	}
+	return display.RunNotifier(name, args)
+}
+
+// notifyInvestigation raises a desktop notification for an investigation
+// that ran for at least service.NotifyAfter, when notifications are on.
+// failure is why it failed, or "" when it completed.
+func notifyInvestigation(cfg *config.Config, sessionUUID, prompt, failure string, started time.Time) {
+	if !cfg.Notifications || time.Since(started) < service.NotifyAfter {
+		return
+	}
+	n := service.InvestigationNotification(service.SessionTitle(prompt), failure, cfg.ConsoleSessionURL(sessionUUID))
+	if err := desktopNotify(n); err != nil {
+		display.Warn(fmt.Sprintf("Desktop notification failed: %v", err))
+	}
+}
+
+// investigationSinkResult packages a finished investigation for --sink.
+func investigationSinkResult(cfg *config.Config, sessionUUID, prompt, answer string) service.SinkResult {
+	return service.SinkResult{
+		SessionUUID: sessionUUID,
+		SessionName: service.SessionTitle(prompt),
+		ProjectUUID: cfg.ProjectID,
+		Prompt:      prompt,
+		Answer:      answer,
+		ConsoleURL:  cfg.ConsoleSessionURL(sessionUUID),
+		Time:        time.Now(),
+	}
+}
+
+// parseSinks parses --sink specs, checking up front that the environment
+// says where Jira is when a jira:// sink is given.
+func parseSinks(specs []string) ([]service.Sink, error) {
+	sinks, err := service.ParseSinks(specs)
+	if err != nil {
+		return nil, err
+	}
+	if slices.ContainsFunc(sinks, func(s service.Sink) bool { return s.Kind == service.SinkJira }) {
+		if _, err := config.LoadJiraSite(); err != nil {
+			return nil, err
+		}
+	}
+	return sinks, nil
+}
+
+// deliverSinks writes r to every sink. A failing sink is reported but does
+// not fail the command; quiet modes report on stderr only.
+func deliverSinks(sinks []service.Sink, r service.SinkResult, quiet bool) {
+	for _, s := range sinks {
+		dest, err := writeSink(s, r)
+		switch {
+		case err != nil && quiet:
+			fmt.Fprintf(os.Stderr, "warning: sink %s: %v\n", s.Name(), err)
+		case err != nil:
+			display.Warn(fmt.Sprintf("Sink %s failed: %v", s.Name(), err))
+		case !quiet:
+			display.Success(fmt.Sprintf("Sent to %s", dest))
+		}
+	}
+}
+
+// writeSink delivers r to one sink and describes where it went.
+func writeSink(s service.Sink, r service.SinkResult) (string, error) {
+	switch s.Kind {
+	case service.SinkFile:
+		path := s.FilePath(r)
+		return path, writeSinkFile(path, r)
+	case service.SinkJira:
+		site, err := config.LoadJiraSite()
+		if err != nil {
+			return "", err
+		}
+		body, err := r.JiraIssue(s.Target, site.IssueType)
+		if err != nil {
+			return "", err
+		}
+		key, err := api.CreateJiraIssue(site, body)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("Jira issue %s (%s)", key, site.IssueURL(key)), nil
+	}
+	body, err := json.Marshal(r)
+	if err != nil {
+		return "", err
+	}
+	return s.Name(), api.PostWebhook(s.Target, body)
+}
+
+// writeSinkFile writes r to path as markdown, or JSON for a .json path,
+// creating the directory it goes in.
+func writeSinkFile(path string, r service.SinkResult) error {
+	data, err := r.FileContent(path)
+	if err != nil {
+		return err
+	}
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return err
+		}
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// prepareQuietRun does the undecorated setup shared by the machine-readable
+// investigate modes: optional kubectl context (warnings to stderr) and
+// session creation, naming a new session after the prompt when autoName is
+// set. The session is saved as the last session and the prompt is added to
+// history.
+func prepareQuietRun(cfg *config.Config, client *api.Client, sessionUUID, prompt, kubeContext, namespace string, inputParts []string, autoName bool) (string, []string, error) {
+	var contextParts []string
+	if kubeContext != "" || namespace != "" {
+		parts, _, err := gatherKubeContext(kubeContext, namespace)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "warning: Kubernetes context unavailable: %v\n", err)
+		}
+		contextParts = parts
+	}
+	contextParts = append(contextParts, inputParts...)
+
+	if sessionUUID == "" {
+		sessResp, err := client.NewSession(cfg.ProjectID)
+		if err != nil {
+			return "", nil, fmt.Errorf("creating session: %w", err)
+		}
+		sessionUUID = sessResp.SessionUUID
+		if autoName {
+			if err := client.RenameSession(cfg.ProjectID, sessionUUID, service.SessionTitle(prompt)); err != nil {
+				fmt.Fprintf(os.Stderr, "warning: could not name session: %v\n", err)
+			}
+		}
+	}
+	cfg.LastSession = sessionUUID
+	_ = cfg.Save()
+	recordHistory(prompt, sessionUUID)
+
+	return sessionUUID, contextParts, nil
+}
+
+// readInputContext reads the --context inputs, a file path or - for
+// stdin, into context parts for the prompt.
+func readInputContext(specs []string) ([]string, error) {
+	var parts []string
+	for _, spec := range specs {
+		var data []byte
+		var err error
+		if spec == "-" {
+			if term.IsTerminal(int(os.Stdin.Fd())) {
+				return nil, fmt.Errorf("--context - reads stdin; pipe something in, e.g. kubectl logs deploy/checkout | hawkeye investigate \"...\" --context -")
+			}
+			// Read a bounded amount: repeated lines are collapsed and the
+			// rest truncated anyway, but a runaway pipe should not fill memory.
+			data, err = io.ReadAll(io.LimitReader(os.Stdin, 64*service.MaxInputContextBytes))
+		} else {
+			data, err = os.ReadFile(spec)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading --context %s: %w", spec, err)
+		}
+		part := service.FormatInputContext(inputContextSource(spec), string(data), service.MaxInputContextBytes)
+		if part == "" {
+			return nil, fmt.Errorf("--context %s: no input", spec)
+		}
+		parts = append(parts, part)
+	}
+	return parts, nil
+}
+
+// readInputFile reads path, or stdin when path is "-", with Windows line
+// endings turned into plain newlines.
+func readInputFile(path string) ([]byte, error) {
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return []byte(service.NormalizeNewlines(string(data))), nil
+}
+
+// readPromptFile reads an investigation prompt from path, or stdin for
+// "-". The text is kept as written apart from surrounding blank lines.
+func readPromptFile(path string) (string, error) {
+	data, err := readInputFile(path)
+	if err != nil {
+		return "", fmt.Errorf("reading prompt: %w", err)
+	}
+	text := strings.Trim(string(data), "\n")
+	if strings.TrimSpace(text) == "" {
+		if path == "-" {
+			return "", fmt.Errorf("--file -: no prompt on stdin")
+		}
+		return "", fmt.Errorf("--file %s: the prompt is empty", path)
+	}
+	return text, nil
+}
+
+// inputContextSource names a --context input for the prompt and header.
+func inputContextSource(spec string) string {
+	if spec == "-" {
+		return "stdin"
+	}
+	return filepath.Base(spec)
+}
+
+// recordTranscript starts a markdown transcript of an investigation when
+// transcript-dir is set and returns handler wrapped to write every event to
+// it. finish closes the transcript and returns its path, or "" when none
+// was written. Transcript problems are reported on stderr and never stop
+// the investigation.
+func recordTranscript(cfg *config.Config, projectUUID, projectName, sessionUUID, prompt string, handler api.StreamCallback) (wrapped api.StreamCallback, finish func(streamErr error) string) {
+	if cfg.TranscriptDir == "" {
+		return handler, func(error) string { return "" }
+	}
+	projectCfg := *cfg
+	projectCfg.ProjectID = projectUUID
+	info := service.TranscriptInfo{
+		Prompt:      prompt,
+		SessionUUID: sessionUUID,
+		ConsoleURL:  projectCfg.ConsoleSessionURL(sessionUUID),
+		Project:     projectName,
+		Started:     time.Now(),
+	}
+	f, err := createTranscriptFile(cfg.TranscriptDir, service.TranscriptName(info.Started, sessionUUID))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "warning: %v\n", err)
+		return handler, func(error) string { return "" }
+	}
+	t, err := service.NewTranscript(f, info)
+	if err != nil {
+		f.Close()
+		fmt.Fprintf(os.Stderr, "warning: %v\n", err)
+		return handler, func(error) string { return "" }
+	}
+	wrapped = func(resp *api.ProcessPromptResponse) {
+		t.Handle(resp)
+		handler(resp)
+	}
+	return wrapped, func(streamErr error) string {
+		err := t.Close(streamErr)
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "warning: transcript %s is incomplete: %v\n", f.Name(), err)
+		}
+		return f.Name()
+	}
+}
+
+// createTranscriptFile creates dir if needed and a new transcript file in
+// it, refusing to overwrite an existing one.
+func createTranscriptFile(dir, name string) (*os.File, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("creating transcript directory: %w", err)
+	}
+	f, err := os.OpenFile(filepath.Join(dir, name), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("creating transcript: %w", err)
+	}
+	return f, nil
+}
+
+// gatherKubeContext runs read-only kubectl queries and returns the formatted
+// context parts plus the labels of the queries that produced output.
+// Individual query failures are skipped; an error is returned only when
+// kubectl itself is unavailable or nothing could be collected.
+func gatherKubeContext(kubeContext, namespace string) (parts, labels []string, err error) {
+	if _, err := exec.LookPath("kubectl"); err != nil {
+		return nil, nil, fmt.Errorf("kubectl not found in PATH")
+	}
+
+	var lastErr error
+	for _, q := range service.KubeQueries(kubeContext, namespace) {
+		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+		out, err := exec.CommandContext(ctx, "kubectl", q.Args...).Output()
+		cancel()
+		if err != nil {
+			lastErr = fmt.Errorf("kubectl %s: %w", strings.Join(q.Args, " "), err)
+			continue
+		}
+		if part := service.FormatKubeContext(kubeContext, namespace, q.Label, string(out)); part != "" {
+			parts = append(parts, part)
+			labels = append(labels, q.Label)
+		}
+	}
+
+	if len(parts) == 0 && lastErr != nil {
+		return nil, nil, lastErr
+	}
+	return parts, labels, nil
+}
+
+// ─── replay ─────────────────────────────────────────────────────────────────
+
+func cmdReplay(args []string) error {
+	speed := 1.0
+	var debugMode bool
+	var positional []string
+
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--speed":
+			if i+1 < len(args) {
+				i++
+				v, err := service.ParseReplaySpeed(args[i])
+				if err != nil {
+					return err
+				}
+				speed = v
+			} else {
+				return fmt.Errorf("--speed requires a value")
+			}
+		case "--debug":
+			debugMode = true
+		default:
+			positional = append(positional, args[i])
+		}
+	}
+
+	if len(positional) == 0 {
+		fmt.Println("Usage: hawkeye replay <recording.ndjson> [--speed <2x|0.5x|max>]")
+		return nil
+	}
+
+	f, err := os.Open(positional[0])
+	if err != nil {
+		return fmt.Errorf("opening recording: %w", err)
+	}
+	defer f.Close()
+
+	events, err := api.ReadStreamEvents(f)
+	if err != nil {
+		return fmt.Errorf("reading recording: %w", err)
+	}
+
+	sessionUUID := ""
+	for _, ev := range events {
+		if ev.SessionUUID != "" {
+			sessionUUID = ev.SessionUUID
+			break
+		}
+	}
+
+	fmt.Printf("\n %s── 🦅 Hawkeye Replay ─────────────────────────────────────────────────────%s\n", display.Dim, display.Reset)
+	fmt.Println()
+	fmt.Printf("    %sFile:%s     %s\n", display.Dim, display.Reset, positional[0])
+	if sessionUUID != "" {
+		fmt.Printf("    %sSession:%s  %s\n", display.Dim, display.Reset, sessionUUID)
+	}
+	fmt.Printf("    %sEvents:%s   %d\n", display.Dim, display.Reset, len(events))
+	fmt.Println()
+	fmt.Printf(" %s──────────────────────────────────────────────────────────────────────────%s\n", display.Dim, display.Reset)
+
+	streamDisplay := api.NewStreamDisplay(debugMode)
+	var prev time.Time
+	for _, ev := range events {
+		// Synthetic events added by the CLI are not part of the SSE stream.
+		if ev.EventType == "session" || ev.EventType == "error" {
+			continue
+		}
+		time.Sleep(service.ReplayDelay(prev, ev.Time, speed))
+		prev = ev.Time
+		streamDisplay.HandleEvent(ev.Response())
+	}
+	streamDisplay.Stop()
+
+	fmt.Println()
+	fmt.Printf(" %s──────────────────────────────────────────────────────────────────────────%s\n", display.Dim, display.Reset)
+	display.Success("Replay complete")
+	fmt.Println()
+	return nil
+}
+
+// ─── sessions ───────────────────────────────────────────────────────────────
+
+func cmdSessions(args []string) error {
+	if len(args) > 0 {
+		switch args[0] {
+		case "autoname":
+			return cmdSessionsAutoname(args[1:])
+		case "tag":
+			return cmdSessionsTag(args[1:])
+		case "untag":
+			return cmdSessionsUntag(args[1:])
+		case "tags":
+			return cmdSessionsTags()
+		case "export":
+			return cmdSessionsExport(args[1:])
+		}
+	}
+
+	limit := 20
+	var status, from, to, search, searchMode string
+	var tags, filterExprs []string
+	var uninvestigated bool
+
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "-n", "--limit":
+			if i+1 < len(args) {
+				i++
+				n, err := strconv.Atoi(args[i])
+				if err != nil {
+					return fmt.Errorf("invalid limit: %s", args[i])
+				}
+				limit = n
+			}
+		case "--status":
+			if i+1 < len(args) {
+				i++
+				status = args[i]
+			}
+		case "--from":
+			if i+1 < len(args) {
+				i++
+				from = args[i]
+			}
+		case "--to":
+			if i+1 < len(args) {
+				i++
+				to = args[i]
+			}
+		case "--search":
+			if i+1 < len(args) {
+				i++
+				search = args[i]
+			}
+		case "--search-mode":
+			if i+1 < len(args) {
+				i++
+				searchMode = args[i]
+			} else {
+				return fmt.Errorf("--search-mode requires a value")
+			}
+		case "--tag":
+			if i+1 < len(args) {
+				i++
+				tags = append(tags, args[i])
+			} else {
+				return fmt.Errorf("--tag requires a value")
+			}
+		case "--filter":
+			if i+1 < len(args) {
+				i++
+				filterExprs = append(filterExprs, args[i])
+			} else {
+				return fmt.Errorf("--filter requires an expression (e.g. status=investigated,created>7d)")
+			}
+		case "--uninvestigated":
+			uninvestigated = true
+		}
+	}
+
+	filters := service.BuildSessionFilters(status, from, to, "", uninvestigated)
+	for _, expr := range filterExprs {
+		parsed, err := service.ParseSessionFilter(expr, time.Now())
+		if err != nil {
+			return fmt.Errorf("--filter: %w", err)
+		}
+		filters = append(filters, parsed...)
+	}
+
+	cfg, err := config.Load(activeProfile)
+	if err != nil {
+		return err
+	}
+	if err := cfg.ValidateProject(); err != nil {
+		return err
+	}
+
+	mode, err := service.ParseSearchMode(searchMode)
+	if err != nil {
+		return err
+	}
+
+	client := api.NewClient(cfg)
+
+	var sessions []api.SessionInfo
+	var searchNote string
+	if len(tags) > 0 {
+		// Tags are local to this profile, so scan pages and match here.
+		fetch := func(start, n int, filters []api.PaginationFilter) ([]api.SessionInfo, error) {
+			resp, err := client.SessionList(cfg.ProjectID, start, n, filters)
+			if err != nil {
+				return nil, err
+			}
+			return resp.Sessions, nil
+		}
+		res, err := service.ScanSessions(fetch, filters, limit, func(s api.SessionInfo) bool {
+			return cfg.HasTags(s.SessionUUID, tags) && (search == "" || service.SessionMatchesSearch(s, search))
+		})
+		if err != nil {
+			return fmt.Errorf("listing sessions: %w", err)
+		}
+		sessions = res.Sessions
+		searchNote = fmt.Sprintf("Tags: %s (scanned %d sessions)", strings.Join(tags, ", "), res.Scanned)
+	} else if search == "" {
+		resp, err := client.SessionList(cfg.ProjectID, 0, limit, filters)
+		if err != nil {
+			return fmt.Errorf("listing sessions: %w", err)
+		}
+		sessions = resp.Sessions
+	} else {
+		fetch := func(start, n int, filters []api.PaginationFilter) ([]api.SessionInfo, error) {
+			resp, err := client.SessionList(cfg.ProjectID, start, n, filters)
+			if err != nil {
+				return nil, err
+			}
+			return resp.Sessions, nil
+		}
+		res, err := service.SearchSessions(fetch, filters, search, limit, mode)
+		if err != nil {
+			return fmt.Errorf("searching sessions: %w", err)
+		}
+		sessions = res.Sessions
+		searchNote = "Search: server-side filter"
+		if res.Mode == service.SearchModeClient {
+			searchNote = fmt.Sprintf("Search: client-side name match (scanned %d sessions)", res.Scanned)
+		}
+	}
+
+	if jsonOutput {
+		return printJSON(sessions)
+	}
+
+	display.Header(fmt.Sprintf("Sessions (%d)", len(sessions)))
+	if searchNote != "" {
+		fmt.Printf("  %s%s%s\n", display.Dim, searchNote, display.Reset)
+	}
+
+	if len(sessions) == 0 {
+		display.Warn("No sessions found.")
+		return nil
+	}
+
+	hasTags := slices.ContainsFunc(sessions, func(s api.SessionInfo) bool {
+		return len(cfg.SessionTags(s.SessionUUID)) > 0
+	})
+	table := newTable(
+		display.Column{Title: "NAME", Max: 48},
+		display.Column{Title: "STATUS"},
+		display.Column{Title: "CREATED"},
+		display.Column{Title: "UPDATED", Wide: true},
+		display.Column{Title: "TYPE", Wide: true},
+		display.Column{Title: "SESSION", Keep: true},
+	)
+	if hasTags {
+		table.Columns = append(table.Columns, display.Column{Title: "TAGS", Max: 30})
+	}
+	for _, s := range sessions {
+		name := s.Name
+		if name == "" {
+			name = display.Dim + "(unnamed)" + display.Reset
+		}
+		if s.Pinned {
+			name = "📌 " + name
+		}
+		typeLabel := "chat"
+		if s.SessionType == "SESSION_TYPE_INCIDENT" {
+			typeLabel = "incident"
+		}
+		table.Row(name,
+			display.InvestigationStatusLabel(s.InvestigationStatus),
+			display.FormatTime(s.CreateTime),
+			display.FormatTime(s.LastUpdate),
+			typeLabel,
+			s.SessionUUID,
+			display.Cyan+strings.Join(cfg.SessionTags(s.SessionUUID), ", ")+display.Reset,
+		)
+	}
+	fmt.Println()
+	table.Print()
+
+	fmt.Println()
+	fmt.Println(strings.Repeat("─", display.FitWidth(80)))
+	fmt.Printf("  %sTip:%s Run %shawkeye inspect <session-uuid>%s to see details.\n\n",
+		display.Dim, display.Reset, display.Cyan, display.Reset)
+
+	return nil
+}
+
+// cmdSessionsTag adds local tags to a session. Tags live in the profile's
+// config, keyed by session UUID, and drive `sessions --tag`.
+func cmdSessionsTag(args []string) error {
+	if len(args) < 2 {
+		fmt.Println("Usage: hawkeye sessions tag <session-uuid> <tag> [tag...]")
+		return nil
+	}
+	cfg, err := config.Load(activeProfile)
+	if err != nil {
+		return err
+	}
+	sessionUUID := cfg.ResolveSession(args[0])
+	if err := cfg.AddTags(sessionUUID, args[1:]...); err != nil {
+		return err
+	}
+	if err := cfg.Save(); err != nil {
+		return err
+	}
+	if jsonOutput {
+		return printJSON(map[string]any{"session_uuid": sessionUUID, "tags": cfg.SessionTags(sessionUUID)})
+	}
+	display.Success(fmt.Sprintf("Tagged %s: %s", sessionUUID, strings.Join(cfg.SessionTags(sessionUUID), ", ")))
+	return nil
+}
+
+// cmdSessionsUntag removes tags from a session, or all of them when none
+// are named.
+func cmdSessionsUntag(args []string) error {
+	if len(args) == 0 {
+		fmt.Println("Usage: hawkeye sessions untag <session-uuid> [tag...]  (no tags removes all)")
+		return nil
+	}
+	cfg, err := config.Load(activeProfile)
+	if err != nil {
+		return err
+	}
+	sessionUUID := cfg.ResolveSession(args[0])
+	removed := cfg.RemoveTags(sessionUUID, args[1:]...)
+	if removed == 0 {
+		return fmt.Errorf("session %s has no matching tags", sessionUUID)
+	}
+	if err := cfg.Save(); err != nil {
+		return err
+	}
+	if jsonOutput {
+		return printJSON(map[string]any{"session_uuid": sessionUUID, "tags": cfg.SessionTags(sessionUUID)})
+	}
+	display.Success(fmt.Sprintf("Removed %d tag(s) from %s", removed, sessionUUID))
+	return nil
+}
+
+// cmdSessionsTags lists every tag in use with its session count.
+func cmdSessionsTags() error {
+	cfg, err := config.Load(activeProfile)
+	if err != nil {
+		return err
+	}
+	counts := cfg.TagCounts()
+	if jsonOutput {
+		return printJSON(counts)
+	}
+	if len(counts) == 0 {
+		fmt.Println("No tags. Add some with: hawkeye sessions tag <session-uuid> <tag>")
+		return nil
+	}
+	names := make([]string, 0, len(counts))
+	for tag := range counts {
+		names = append(names, tag)
+	}
+	slices.Sort(names)
+	display.Header(fmt.Sprintf("Session Tags (%d)", len(names)))
+	for _, tag := range names {
+		fmt.Printf("  %s%-24s%s %d session(s)\n", display.Bold, tag, display.Reset, counts[tag])
+	}
+	fmt.Printf("\n  %sTip:%s Filter with %shawkeye sessions --tag %s%s\n\n",
+		display.Dim, display.Reset, display.Cyan, names[0], display.Reset)
+	return nil
+}
+
+// autonameResult records what `sessions autoname` did with one session.
+type autonameResult struct {
+	SessionUUID string `json:"session_uuid"`
+	Name        string `json:"name,omitempty"`
+	Skipped     string `json:"skipped,omitempty"`
+}
+
+// cmdSessionsExport archives every session of the project, optionally with
+// full inspect payloads, as NDJSON or a tar of per-session JSON files. A
+// checkpoint next to the output lets --resume pick up an interrupted run.
+func cmdSessionsExport(args []string) error {
+	var output, format, status, from, to string
+	var inspect, uninvestigated, resume, force bool
+	rate := 5.0
+	pageSize := service.DefaultExportPageSize
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "-o", "--out":
+			if i+1 < len(args) {
+				i++
+				output = args[i]
+			} else {
+				return fmt.Errorf("%s requires a file", args[i])
+			}
+		case "--format":
+			if i+1 < len(args) {
+				i++
+				format = args[i]
+			} else {
+				return fmt.Errorf("--format requires a value")
+			}
+		case "--inspect":
+			inspect = true
+		case "--status":
+			if i+1 < len(args) {
+				i++
+				status = args[i]
+			}
+		case "--from":
+			if i+1 < len(args) {
+				i++
+				from = args[i]
+			}
+		case "--to":
+			if i+1 < len(args) {
+				i++
+				to = args[i]
+			}
+		case "--uninvestigated":
+			uninvestigated = true
+		case "--rate":
+			if i+1 < len(args) {
+				i++
+				r, err := strconv.ParseFloat(args[i], 64)
+				if err != nil || r < 0 {
+					return fmt.Errorf("--rate must be a number of requests per second (0 for no limit)")
+				}
+				rate = r
+			} else {
+				return fmt.Errorf("--rate requires a value")
+			}
+		case "--page-size":
+			if i+1 < len(args) {
+				i++
+				n, err := strconv.Atoi(args[i])
+				if err != nil || n < 1 {
+					return fmt.Errorf("--page-size must be a positive number")
+				}
+				pageSize = n
+			} else {
+				return fmt.Errorf("--page-size requires a value")
+			}
+		case "--resume":
+			resume = true
+		case "--force":
+			force = true
+		default:
+			return fmt.Errorf("unknown flag: %s", args[i])
+		}
+	}
+	if output == "" {
+		return fmt.Errorf("usage: hawkeye sessions export --out <file> [--format ndjson|tar] [--inspect] [--resume]")
+	}
+	format, err := service.ParseExportFormat(format, output)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := config.Load(activeProfile)
+	if err != nil {
+		return err
+	}
+	if err := cfg.ValidateProject(); err != nil {
+		return err
+	}
+	client := api.NewClient(cfg)
+
+	cpPath := config.ExportCheckpointPath(output)
+	cp := &service.ExportCheckpoint{
+		Output:      output,
+		Format:      format,
+		ProjectUUID: cfg.ProjectID,
+		Inspect:     inspect,
+		Filters:     service.BuildSessionFilters(status, from, to, "", uninvestigated),
+	}
+
+	var f *os.File
+	if resume {
+		saved := &service.ExportCheckpoint{}
+		if err := config.LoadExportCheckpoint(cpPath, saved); err != nil {
+			return err
+		}
+		if err := saved.SameExport(cp); err != nil {
+			return fmt.Errorf("cannot resume: %w", err)
+		}
+		cp = saved
+		if f, err = os.OpenFile(output, os.O_RDWR, 0); err != nil {
+			return fmt.Errorf("opening %s: %w", output, err)
+		}
+		// Drop anything written after the last checkpoint, such as a
+		// half-written record.
+		if err := f.Truncate(cp.Offset); err != nil {
+			f.Close()
+			return fmt.Errorf("truncating %s: %w", output, err)
+		}
+		if _, err := f.Seek(cp.Offset, io.SeekStart); err != nil {
+			f.Close()
+			return err
+		}
+	} else {
+		if _, err := os.Stat(output); err == nil && !force {
+			if _, err := os.Stat(cpPath); err == nil {
+				return fmt.Errorf("%s is an unfinished export; continue it with --resume or start over with --force", output)
+			}
+			return fmt.Errorf("%s already exists (use --force to overwrite)", output)
+		}
+		if f, err = os.OpenFile(output, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0600); err != nil {
+			return fmt.Errorf("creating %s: %w", output, err)
+		}
+	}
+	defer f.Close()
+
+	inspectFn := func(sessionUUID string) (*api.SessionInspectResponse, error) {
+		return client.SessionInspect(cfg.ProjectID, sessionUUID)
+	}
+	if !inspect {
+		inspectFn = nil
+	}
+	writer := service.NewExportWriter(cp.Format, f, cp.Offset)
+	resumedFrom := len(cp.Exported)
+
+	fmt.Println()
+	if resume {
+		display.Info("Resuming:", fmt.Sprintf("%s (%d sessions already exported)", output, resumedFrom))
+	}
+	progress := display.NewProgress()
+	task := progress.Add("Exporting sessions")
+	export := &service.SessionExport{
+		Fetch: func(start, n int, filters []api.PaginationFilter) ([]api.SessionInfo, error) {
+			resp, err := client.SessionList(cfg.ProjectID, start, n, filters)
+			if err != nil {
+				return nil, err
+			}
+			return resp.Sessions, nil
+		},
+		Inspect:  inspectFn,
+		Writer:   writer,
+		Limiter:  service.NewRateLimiter(rate),
+		PageSize: pageSize,
+		Checkpoint: func(cp *service.ExportCheckpoint) error {
+			cp.UpdatedAt = time.Now().UTC()
+			return config.SaveExportCheckpoint(cpPath, cp)
+		},
+		Progress: func(n int) {
+			task.SetText(fmt.Sprintf("Exporting sessions (%d written)", n))
+		},
+	}
+	err = export.Run(cp)
+	progress.Stop()
+	if err != nil {
+		display.Warn(fmt.Sprintf("Export interrupted after %d sessions", len(cp.Exported)))
+		fmt.Printf("  %sTip:%s Run %shawkeye sessions export --out %s --resume%s to continue.\n",
+			display.Dim, display.Reset, display.Cyan, output, display.Reset)
+		return err
+	}
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("finishing %s: %w", output, err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("writing %s: %w", output, err)
+	}
+	if err := config.RemoveExportCheckpoint(cpPath); err != nil {
+		display.Warn(fmt.Sprintf("Could not remove checkpoint: %v", err))
+	}
+
+	if jsonOutput {
+		return printJSON(map[string]any{
+			"output":   output,
+			"format":   cp.Format,
+			"inspect":  cp.Inspect,
+			"sessions": len(cp.Exported),
+			"resumed":  resumedFrom,
+		})
+	}
+	what := "sessions"
+	if cp.Inspect {
+		what = "sessions with inspect payloads"
+	}
+	display.Success(fmt.Sprintf("Exported %d %s to %s (%s)", len(cp.Exported), what, output, cp.Format))
+	return nil
+}
+
+// cmdSessionsAutoname backfills names for unnamed sessions from their first
+// prompt, the same title new sessions get at creation.
+func cmdSessionsAutoname(args []string) error {
+	limit := 20
+	var uninvestigated, dryRun bool
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "-n", "--limit":
+			if i+1 < len(args) {
+				i++
+				n, err := strconv.Atoi(args[i])
+				if err != nil || n < 1 {
+					return fmt.Errorf("--limit must be a positive number")
+				}
+				limit = n
+			} else {
+				return fmt.Errorf("--limit requires a value")
+			}
+		case "--uninvestigated":
+			uninvestigated = true
+		case "--dry-run":
+			dryRun = true
+		default:
+			return fmt.Errorf("unknown flag: %s", args[i])
+		}
+	}
+
+	cfg, err := config.Load(activeProfile)
+	if err != nil {
+		return err
+	}
+	if err := cfg.ValidateProject(); err != nil {
+		return err
+	}
+	client := api.NewClient(cfg)
+
+	filters := service.BuildSessionFilters("", "", "", "", uninvestigated)
+	resp, err := client.SessionList(cfg.ProjectID, 0, limit, filters)
+	if err != nil {
+		return fmt.Errorf("listing sessions: %w", err)
+	}
+
+	var results []autonameResult
+	for _, s := range resp.Sessions {
+		if s.Name != "" {
+			continue
+		}
+		r := autonameResult{SessionUUID: s.SessionUUID}
+		inspect, err := client.SessionInspect(cfg.ProjectID, s.SessionUUID)
+		if err != nil {
+			r.Skipped = err.Error()
+			results = append(results, r)
+			continue
+		}
+		r.Name = service.SessionTitle(service.FirstPrompt(inspect.PromptCycle))
+		if r.Name == "" {
+			r.Skipped = "no prompt"
+		} else if !dryRun {
+			if err := client.RenameSession(cfg.ProjectID, s.SessionUUID, r.Name); err != nil {
+				r.Skipped = err.Error()
+			}
+		}
+		results = append(results, r)
+	}
+
+	if jsonOutput {
+		if results == nil {
+			results = []autonameResult{}
+		}
+		return printJSON(results)
+	}
+
+	if len(results) == 0 {
+		display.Success(fmt.Sprintf("No unnamed sessions among the last %d.", len(resp.Sessions)))
+		return nil
+	}
+
+	verb := "Named"
+	if dryRun {
+		verb = "Would name"
+	}
+	display.Header(fmt.Sprintf("Auto-naming %d unnamed sessions", len(results)))
+	named := 0
+	for _, r := range results {
+		if r.Skipped != "" {
+			fmt.Printf("  %s⊘ %s  skipped: %s%s\n", display.Dim, r.SessionUUID, r.Skipped, display.Reset)
+			continue
+		}
+		named++
+		fmt.Printf("  %s✓%s %s  %s\n", display.Green, display.Reset, r.SessionUUID, r.Name)
+	}
+	fmt.Println()
+	display.Success(fmt.Sprintf("%s %d of %d sessions.", verb, named, len(results)))
+	return nil
+}
+
+// ─── search ─────────────────────────────────────────────────────────────────
+
+// indexSession adds what inspect or summary fetched to the local search
+// index. It never fails the command: the index is only a convenience.
+func indexSession(update func(idx *config.SearchIndex)) {
+	if noCache {
+		return
+	}
+	idx, err := config.LoadSearchIndex(activeProfile)
+	if err != nil {
+		return
+	}
+	update(idx)
+	_ = idx.Save()
+}
+
+func cmdSearch(args []string) error {
+	var sync, allProjects bool
+	limit, syncLimit := 10, 200
+	var terms []string
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--sync":
+			sync = true
+		case "--sync-limit":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--sync-limit requires a value")
+			}
+			i++
+			n, err := strconv.Atoi(args[i])
+			if err != nil || n <= 0 {
+				return fmt.Errorf("invalid --sync-limit: %s", args[i])
+			}
+			syncLimit, sync = n, true
+		case "-n", "--limit":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--limit requires a value")
+			}
+			i++
+			n, err := strconv.Atoi(args[i])
+			if err != nil || n <= 0 {
+				return fmt.Errorf("invalid limit: %s", args[i])
+			}
+			limit = n
+		case "--all-projects":
+			allProjects = true
+		default:
+			if strings.HasPrefix(args[i], "-") {
+				return fmt.Errorf("unknown flag for search: %s", args[i])
+			}
+			terms = append(terms, args[i])
+		}
+	}
+	query := strings.Join(terms, " ")
+	if strings.TrimSpace(query) == "" && !sync {
+		fmt.Println(`Usage: hawkeye search "<text>" [-n <count>] [--all-projects] [--sync [--sync-limit <n>]]`)
+		return nil
+	}
+
+	cfg, err := config.Load(activeProfile)
+	if err != nil {
+		return err
+	}
+	idx, err := config.LoadSearchIndex(activeProfile)
+	if err != nil {
+		path, _ := config.SearchIndexPath(activeProfile)
+		return fmt.Errorf("reading the search index %s: %w (delete it to start over)", path, err)
+	}
+
+	if sync {
+		if err := cfg.ValidateProject(); err != nil {
+			return err
+		}
+		if err := syncSearchIndex(cfg, idx, syncLimit); err != nil {
+			return err
+		}
+		if err := idx.Save(); err != nil {
+			return fmt.Errorf("saving the search index: %w", err)
+		}
+		if strings.TrimSpace(query) == "" {
+			return nil
+		}
+	}
+
+	project := cfg.ProjectID
+	if allProjects {
+		project = ""
+	}
+	hits := service.SearchIndexed(idx, query, project)
+	if len(hits) > limit {
+		hits = hits[:limit]
+	}
+	searchTerms := service.SearchTerms(query)
+
+	if jsonOutput {
+		type jsonHit struct {
+			SessionUUID string `json:"session_uuid"`
+			ProjectUUID string `json:"project_uuid,omitempty"`
+			Name        string `json:"name,omitempty"`
+			CreateTime  string `json:"create_time,omitempty"`
+			Score       int    `json:"score"`
+			Field       string `json:"field"`
+			Snippet     string `json:"snippet"`
+		}
+		out := make([]jsonHit, 0, len(hits))
+		for _, h := range hits {
+			out = append(out, jsonHit{
+				SessionUUID: h.Doc.SessionUUID,
+				ProjectUUID: h.Doc.ProjectUUID,
+				Name:        h.Doc.Name,
+				CreateTime:  h.Doc.CreateTime,
+				Score:       h.Score,
+				Field:       h.Field,
+				Snippet:     service.SearchSnippet(h.Text, searchTerms, 160, "", ""),
+			})
+		}
+		return printJSON(out)
+	}
+
+	display.Header(fmt.Sprintf("Search: %q (%d of %d cached sessions)", query, len(hits), len(idx.Sessions)))
+	if len(idx.Sessions) == 0 {
+		display.Warn("No sessions cached yet.")
+		fmt.Printf("\n  %sTip:%s Run %shawkeye search --sync%s to cache recent sessions, or inspect some first.\n\n",
+			display.Dim, display.Reset, display.Cyan, display.Reset)
+		return nil
+	}
+	if len(hits) == 0 {
+		display.Warn("No cached session matches.")
+		if !allProjects {
+			fmt.Printf("\n  %sTip:%s Add %s--all-projects%s to search every cached project, or %s--sync%s to cache more sessions.\n\n",
+				display.Dim, display.Reset, display.Cyan, display.Reset, display.Cyan, display.Reset)
+		}
+		return nil
+	}
+
+	for _, h := range hits {
+		name := h.Doc.Name
+		if name == "" {
+			name = "(unnamed)"
+		}
+		fmt.Printf("\n  %s%s%s\n", display.Bold, truncate(name, 90), display.Reset)
+		when := ""
+		if h.Doc.CreateTime != "" {
+			when = display.FormatTime(h.Doc.CreateTime) + " · "
+		}
+		fmt.Printf("  %s%s%s · in %s%s\n", display.Dim, when, h.Doc.SessionUUID, h.Field, display.Reset)
+		fmt.Printf("    %s\n", service.SearchSnippet(h.Text, searchTerms, 160, display.Yellow+display.Bold, display.Reset))
+	}
+	fmt.Printf("\n  %sTip:%s Run %shawkeye inspect <session-uuid>%s for the full session.\n\n",
+		display.Dim, display.Reset, display.Cyan, display.Reset)
+	return nil
+}
+
+// syncSearchIndex caches the most recent sessions of the active project,
+// inspecting only those that changed since they were indexed.
+func syncSearchIndex(cfg *config.Config, idx *config.SearchIndex, limit int) error {
+	client := api.NewClient(cfg)
+	limiter := service.NewRateLimiter(5)
+	progress := display.NewProgress()
+	task := progress.Add("Listing sessions")
+
+	var sessions []api.SessionInfo
+	for len(sessions) < limit {
+		n := min(service.DefaultExportPageSize, limit-len(sessions))
+		limiter.Wait()
+		resp, err := client.SessionList(cfg.ProjectID, len(sessions), n, nil)
+		if err != nil {
+			progress.Stop()
+			return fmt.Errorf("listing sessions: %w", err)
+		}
+		sessions = append(sessions, resp.Sessions...)
+		if len(resp.Sessions) < n {
+			break
+		}
+	}
+
+	var stale []api.SessionInfo
+	for _, s := range sessions {
+		if service.IndexStale(idx, s) {
+			stale = append(stale, s)
+		}
+	}
+	var failed int
+	task.SetText("Caching sessions")
+	for i, s := range stale {
+		task.SetProgress(i, len(stale))
+		limiter.Wait()
+		resp, err := client.SessionInspect(cfg.ProjectID, s.SessionUUID)
+		if err != nil {
+			failed++
+			continue
+		}
+		service.IndexInspect(idx, cfg.ProjectID, resp)
+		limiter.Wait()
+		if sum, err := client.GetSessionSummary(cfg.ProjectID, s.SessionUUID); err == nil {
+			service.IndexSummary(idx, cfg.ProjectID, s.SessionUUID, sum)
+		}
+	}
+	progress.Stop()
+	display.Success(fmt.Sprintf("Cached %d new or changed sessions (%d already up to date)", len(stale)-failed, len(sessions)-len(stale)))
+	if failed > 0 {
+		display.Warn(fmt.Sprintf("%d sessions could not be inspected; they are retried on the next --sync", failed))
+	}
+	return nil
+}
+
+// ─── interactive ────────────────────────────────────────────────────────────
+
+// cmdInteractive launches interactive mode, on the last used session when
+// --continue is given.
+func cmdInteractive([]string) error {
+	if jsonOutput {
+		return fmt.Errorf("--json is not supported in interactive mode")
+	}
+	var resumeSessionID string
+	if continueLastSession {
+		cfg, err := config.Load(activeProfile)
+		if err != nil {
+			return err
+		}
+		if cfg.LastSession == "" {
+			return fmt.Errorf("no previous session found. Run an investigation first")
+		}
+		resumeSessionID = cfg.LastSession
+	}
+	return tui.Run(version, activeProfile, resumeSessionID)
+}
+
+// ─── resume ─────────────────────────────────────────────────────────────────
+
+// cmdResume opens interactive mode on the last session (or the given one),
+// showing its latest answer with the prompt ready for a follow-up.
+func cmdResume(args []string) error {
+	if jsonOutput {
+		return fmt.Errorf("--json is not supported by resume")
+	}
+	cfg, err := config.Load(activeProfile)
+	if err != nil {
+		return err
+	}
+	if err := cfg.ValidateProject(); err != nil {
+		return err
+	}
+	if len(args) > 1 {
+		return fmt.Errorf("usage: hawkeye resume [session-uuid]")
+	}
+	sessionUUID := cfg.LastSession
+	if len(args) == 1 {
+		sessionUUID = cfg.ResolveSession(args[0])
+	}
+	if sessionUUID == "" {
+		return fmt.Errorf("no previous session found. Run an investigation first")
+	}
+	return tui.Run(version, activeProfile, sessionUUID)
+}
+
+// ─── inspect ────────────────────────────────────────────────────────────────
+
+func cmdInspect(args []string) error {
+	args, projectRef, err := splitProjectFlag(args)
+	if err != nil {
+		return err
+	}
+	var answerOnly, copyAnswer bool
+	var verifyFile string
+	var filter service.CoTFilter
+	var positional []string
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--answer-only":
+			answerOnly = true
+		case "--verify":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--verify requires a value")
+			}
+			i++
+			verifyFile = args[i]
+		case "--copy-answer":
+			copyAnswer = true
+		case "--cot":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--cot requires a value")
+			}
+			i++
+			level, err := service.ParseCoTLevel(args[i])
+			if err != nil {
+				return err
+			}
+			filter.Level = level
+		case "--category":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--category requires a value")
+			}
+			i++
+			filter.Categories = append(filter.Categories, args[i])
+		case "--cycle":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--cycle requires a value")
+			}
+			i++
+			n, err := strconv.Atoi(args[i])
+			if err != nil || n <= 0 {
+				return fmt.Errorf("invalid cycle: %s", args[i])
+			}
+			filter.Cycle = n
+		case "--failed-only":
+			filter.FailedOnly = true
+		default:
+			positional = append(positional, args[i])
+		}
+	}
+
+	cfg, err := config.Load(activeProfile)
+	if err != nil {
+		return err
+	}
+	if err := useProjectFlag(cfg, projectRef); err != nil {
+		return err
+	}
+	if err := cfg.ValidateProject(); err != nil {
+		return err
+	}
+
+	var record *api.SessionInspectResponse
+	if verifyFile != "" {
+		data, err := os.ReadFile(verifyFile)
+		if err != nil {
+			return fmt.Errorf("reading record: %w", err)
+		}
+		if record, err = service.ParseInspectRecord(verifyFile, data); err != nil {
+			return err
+		}
+	}
+
+	sessionUUID := ""
+	if len(positional) > 0 {
+		sessionUUID = cfg.ResolveSession(positional[0])
+	} else if record != nil && record.SessionInfo != nil && record.SessionInfo.SessionUUID != "" {
+		sessionUUID = record.SessionInfo.SessionUUID
+	} else if cfg.LastSession != "" {
+		sessionUUID = cfg.LastSession
+	} else {
+		fmt.Println("Usage: hawkeye inspect [session-uuid]")
+		return nil
+	}
+
+	client := api.NewClient(cfg)
+
+	var resp *api.SessionInspectResponse
+	err = inSessionProject(cfg, client, projectRef != "", sessionUUID, func() (err error) {
+		resp, err = client.SessionInspect(cfg.ProjectID, sessionUUID)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("inspecting session: %w", err)
+	}
+	indexSession(func(idx *config.SearchIndex) { service.IndexInspect(idx, cfg.ProjectID, resp) })
+
+	if record != nil {
+		return verifyInspect(sessionUUID, verifyFile, record, resp)
+	}
+
+	if copyAnswer {
+		answer := service.LatestFinalAnswer(resp.PromptCycle)
+		if answer == "" {
+			return fmt.Errorf("session %s has no final answer yet", sessionUUID)
+		}
+		if err := copyToClipboard(answer, "final answer"); err != nil {
+			return err
+		}
+	}
+
+	if answerOnly {
+		answer := service.LatestFinalAnswer(resp.PromptCycle)
+		if answer == "" {
+			return fmt.Errorf("session %s has no final answer yet", sessionUUID)
+		}
+		fmt.Println(answer)
+		return nil
+	}
+
+	resp.PromptCycle, err = filter.Apply(resp.PromptCycle)
+	if err != nil {
+		return err
+	}
+
+	if jsonOutput {
+		return printJSON(resp)
+	}
+
+	if resp.SessionInfo != nil {
+		s := resp.SessionInfo
+		name := s.Name
+		if name == "" {
+			name = "(unnamed)"
+		}
+		display.Header(fmt.Sprintf("Session: %s", name))
+		display.Info("UUID:", s.SessionUUID)
+		display.Info("Created:", display.FormatTime(s.CreateTime))
+		display.Info("Updated:", display.FormatTime(s.LastUpdate))
+		display.Info("Type:", s.SessionType)
+		display.Info("Investigation:", display.InvestigationStatusLabel(s.InvestigationStatus))
+	}
+
+	if len(resp.PromptCycle) == 0 {
+		fmt.Println()
+		display.Warn("No prompt cycles found.")
+		return nil
+	}
+
+	for i, pc := range resp.PromptCycle {
+		cycleNum := i + 1
+		if filter.Cycle > 0 {
+			cycleNum = filter.Cycle
+		}
+		fmt.Println()
+		display.SubHeader(fmt.Sprintf("── Prompt Cycle %d ──", cycleNum))
+
+		if pc.Request != nil && len(pc.Request.Messages) > 0 {
+			for _, msg := range pc.Request.Messages {
+				if msg.Content != nil && len(msg.Content.Parts) > 0 {
+					fmt.Printf("  %s❯%s %s\n", display.Cyan, display.Reset,
+						strings.Join(msg.Content.Parts, " "))
+				}
+			}
+		}
+
+		if pc.Status != "" {
+			fmt.Printf("  %sStatus:%s %s\n", display.Dim, display.Reset, pc.Status)
+		}
+		if rating := service.RatingLabel(pc.Rating); rating != "" {
+			fmt.Printf("  %sRating:%s %s", display.Dim, display.Reset, rating)
+			if pc.RatingReason != "" {
+				fmt.Printf(" (%s)", pc.RatingReason)
+			}
+			fmt.Println()
+		}
+
+		// Chain of Thoughts
+		if len(pc.ChainOfThoughts) == 0 && filter.Filtered() && filter.Level != service.CoTNone {
+			fmt.Printf("\n  %sNo matching chain-of-thought steps.%s\n", display.Dim, display.Reset)
+		}
+		if len(pc.ChainOfThoughts) > 0 {
+			fmt.Printf("\n  %s🧠 Chain of Thought:%s\n", display.Magenta, display.Reset)
+			for _, cot := range pc.ChainOfThoughts {
+				status := display.CoTStatusLabel(cot.CotStatus)
+				if cot.CotStatus == "" {
+					status = display.CoTStatusLabel(cot.Status)
+				}
+				category := cot.Category
+				if category == "" {
+					category = "analysis"
+				}
+
+				if filter.Level == service.CoTSummary {
+					desc, _, _ := strings.Cut(strings.TrimSpace(cot.Description), "\n")
+					fmt.Printf("    %s[%s]%s %s  %s\n", display.Bold, category, display.Reset, status, truncate(desc, 80))
+					continue
+				}
+
+				fmt.Printf("    %s[%s]%s %s\n", display.Bold, category, display.Reset, status)
+
+				if cot.Description != "" {
+					for _, line := range strings.Split(api.RenderMarkdown(cot.Description), "\n") {
+						fmt.Printf("      %s\n", line)
+					}
+				}
+
+				if cot.Investigation != "" {
+					fmt.Printf("      %sInvestigation:%s\n", display.Dim, display.Reset)
+					for _, line := range strings.Split(api.RenderMarkdown(cot.Investigation), "\n") {
+						fmt.Printf("        %s\n", line)
+					}
+				}
+
+				if len(cot.Sources) > 0 {
+					fmt.Printf("      %sSources:%s %s\n", display.Dim, display.Reset,
+						strings.Join(cot.Sources, ", "))
+				}
+
+				if cot.ProcessingTime != "" && cot.ProcessingTime != "0" {
+					fmt.Printf("      %sTime:%s %sms\n", display.Dim, display.Reset, cot.ProcessingTime)
+				}
+			}
+		}
+
+		// Sources
+		if len(pc.Sources) > 0 {
+			fmt.Printf("\n  %s📎 Sources:%s\n", display.Blue, display.Reset)
+			for _, src := range pc.Sources {
+				title := src.Title
+				if title == "" {
+					title = src.ID
+				}
+				cat := ""
+				if src.Category != "" {
+					cat = fmt.Sprintf(" %s(%s)%s", display.Dim, src.Category, display.Reset)
+				}
+				fmt.Printf("    • %s%s\n", title, cat)
+				if src.Description != "" {
+					fmt.Printf("      %s%s%s\n", display.Gray, truncate(src.Description, 100), display.Reset)
+				}
+			}
+		}
+
+		// Final Answer
+		if pc.FinalAnswer != "" {
+			fmt.Printf("\n  %s💬 Answer:%s\n", display.Green, display.Reset)
+			for _, line := range strings.Split(api.RenderMarkdown(pc.FinalAnswer), "\n") {
+				fmt.Printf("    %s\n", line)
+			}
+		}
+
+		// Follow-ups
+		if len(pc.FollowUpSuggestions) > 0 {
+			fmt.Printf("\n  %s💡 Follow-up suggestions:%s\n", display.Cyan, display.Reset)
+			for j, s := range pc.FollowUpSuggestions {
+				fmt.Printf("    %d. %s\n", j+1, s)
+			}
+		}
+	}
+
+	fmt.Println()
+	return nil
+}
+
+// verifyInspect reports how a session differs from a record saved with
+// `inspect --json`. It fails when anything changed, so audits can script it.
+func verifyInspect(sessionUUID, path string, record, current *api.SessionInspectResponse) error {
+	changes, err := service.VerifyInspect(record, current)
+	if err != nil {
+		return err
+	}
+	if jsonOutput {
+		if changes == nil {
+			changes = []service.InspectChange{}
+		}
+		if err := printJSON(map[string]any{
+			"session_uuid": sessionUUID,
+			"record":       path,
+			"unchanged":    len(changes) == 0,
+			"changes":      changes,
+		}); err != nil {
+			return err
+		}
+	} else {
+		display.Header(fmt.Sprintf("Verifying %s against %s", sessionUUID, path))
+		for _, c := range changes {
+			fmt.Printf("  %s✗%s %s\n", display.Red, display.Reset, c.Summary())
+		}
+		if len(changes) == 0 {
+			display.Success(fmt.Sprintf("Unchanged: %d prompt cycles match the record.", len(record.PromptCycle)))
+			return nil
+		}
+		fmt.Println()
+	}
+	if len(changes) == 0 {
+		return nil
+	}
+	return fmt.Errorf("session changed since the record: %d differences", len(changes))
+}
+
+// ─── chat ───────────────────────────────────────────────────────────────────
+
+// chatFollowInterval is how often chat --follow polls by default.
+const chatFollowInterval = 5 * time.Second
+
+func cmdChat(args []string) error {
+	args, projectRef, err := splitProjectFlag(args)
+	if err != nil {
+		return err
+	}
+	var follow bool
+	interval := chatFollowInterval
+	var positional []string
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--follow", "-f":
+			follow = true
+		case "--interval":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--interval requires a value")
+			}
+			i++
+			d, err := time.ParseDuration(args[i])
+			if err != nil || d < time.Second {
+				return fmt.Errorf("--interval must be a duration of at least 1s, e.g. 5s")
+			}
+			interval = d
+		default:
+			positional = append(positional, args[i])
+		}
+	}
+
+	cfg, err := config.Load(activeProfile)
+	if err != nil {
+		return err
+	}
+	if err := useProjectFlag(cfg, projectRef); err != nil {
+		return err
+	}
+	if err := cfg.ValidateProject(); err != nil {
+		return err
+	}
+
+	sessionUUID := ""
+	if len(positional) > 0 {
+		sessionUUID = cfg.ResolveSession(positional[0])
+	} else if cfg.LastSession != "" {
+		sessionUUID = cfg.LastSession
+	} else {
+		fmt.Println("Usage: hawkeye chat [session-uuid] [--follow]")
+		return nil
+	}
+
+	client := api.NewClient(cfg)
+	var resp *api.SessionInspectResponse
+	err = inSessionProject(cfg, client, projectRef != "", sessionUUID, func() (err error) {
+		resp, err = client.SessionInspect(cfg.ProjectID, sessionUUID)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("inspecting session: %w", err)
+	}
+
+	messages := service.ChatMessages(resp.PromptCycle)
+	if jsonOutput && !follow {
+		if messages == nil {
+			messages = []service.ChatMessage{}
+		}
+		return printJSON(messages)
+	}
+
+	if !jsonOutput {
+		name := "(unnamed)"
+		if resp.SessionInfo != nil && resp.SessionInfo.Name != "" {
+			name = resp.SessionInfo.Name
+		}
+		display.Header("Chat: " + name)
+		if len(messages) == 0 && !follow {
+			display.Warn("No messages yet.")
+			return nil
+		}
+	}
+	seen := map[string]bool{}
+	printChatMessages(service.NewChatMessages(messages, seen))
+	pending := service.ChatPending(resp.PromptCycle)
+	if !follow {
+		if pending {
+			fmt.Printf("  %sHawkeye is still answering; add --follow to wait for it.%s\n\n", display.Dim, display.Reset)
+		}
+		return nil
+	}
+
+	if !jsonOutput {
+		fmt.Printf("  %sFollowing %s; Ctrl+C to stop.%s\n\n", display.Dim, sessionUUID, display.Reset)
+	}
+	if pending {
+		printChatPending()
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	failing := false
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(interval):
+		}
+		resp, err := client.SessionInspect(cfg.ProjectID, sessionUUID)
+		if err != nil {
+			// Report a failing poll once, not every interval.
+			if !failing {
+				fmt.Fprintf(os.Stderr, "%s!%s %v (retrying)\n", display.Yellow, display.Reset, err)
+			}
+			failing = true
+			continue
+		}
+		failing = false
+		printChatMessages(service.NewChatMessages(service.ChatMessages(resp.PromptCycle), seen))
+		now := service.ChatPending(resp.PromptCycle)
+		if now && !pending {
+			printChatPending()
+		}
+		pending = now
+	}
+}
+
+// printChatMessages prints turns of a conversation, or one JSON object per
+// line with --json, so --follow output can be piped.
+func printChatMessages(messages []service.ChatMessage) {
+	for _, m := range messages {
+		if jsonOutput {
+			_ = printJSONLine(m)
+			continue
+		}
+		if m.Role == service.ChatUser {
+			stamp := ""
+			if m.Time != "" {
+				stamp = fmt.Sprintf(" %s· %s%s", display.Dim, display.FormatTime(m.Time), display.Reset)
+			}
+			fmt.Printf("  %s❯ User%s%s\n", display.Cyan+display.Bold, display.Reset, stamp)
+			for _, line := range strings.Split(m.Text, "\n") {
+				fmt.Printf("    %s\n", line)
+			}
+		} else {
+			fmt.Printf("  %s◆ Hawkeye%s\n", display.Green+display.Bold, display.Reset)
+			for _, line := range strings.Split(api.RenderMarkdown(m.Text), "\n") {
+				fmt.Printf("    %s\n", line)
+			}
+		}
+		fmt.Println()
+	}
+}
+
+func printChatPending() {
+	if !jsonOutput {
+		fmt.Printf("  %s◆ Hawkeye is investigating…%s\n\n", display.Dim, display.Reset)
+	}
+}
+
+// ─── rca ────────────────────────────────────────────────────────────────────
+
+// cmdRCA runs the whole incident workflow in one go: investigate, wait for
+// the summary and scores, print one consolidated report and optionally
+// export it, open a ticket or hand it to sinks.
+func cmdRCA(args []string) error {
+	var positional, sinkSpecs []string
+	var exportFormat, outPath, ticket string
+	var noScores bool
+	timeout := summaryWaitTimeout
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--export":
+			if i+1 < len(args) {
+				i++
+				exportFormat = strings.ToLower(args[i])
+			} else {
+				return fmt.Errorf("--export requires a format (md or json)")
+			}
+		case "-o", "--out":
+			if i+1 < len(args) {
+				i++
+				outPath = args[i]
+			} else {
+				return fmt.Errorf("%s requires a path", args[i])
+			}
+		case "--ticket":
+			if i+1 < len(args) {
+				i++
+				ticket = args[i]
+			} else {
+				return fmt.Errorf("--ticket requires a value (jira or jira:<project-key>)")
+			}
+		case "--sink":
+			if i+1 < len(args) {
+				i++
+				sinkSpecs = append(sinkSpecs, args[i])
+			} else {
+				return fmt.Errorf("--sink requires a value")
+			}
+		case "--timeout":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--timeout requires a value")
+			}
+			i++
+			d, err := parseWaitTimeout(args[i])
+			if err != nil {
+				return err
+			}
+			timeout = d
+		case "--no-scores":
+			noScores = true
+		default:
+			if strings.HasPrefix(args[i], "-") {
+				return fmt.Errorf("unknown flag: %s", args[i])
+			}
+			positional = append(positional, args[i])
+		}
+	}
+	if len(positional) == 0 {
+		return fmt.Errorf("usage: hawkeye rca \"<question>\" [--export md|json] [--ticket jira[:<key>]] [--sink <spec>]")
+	}
+	prompt := strings.Join(positional, " ")
+
+	switch exportFormat {
+	case "", "md", "json":
+	case "markdown":
+		exportFormat = "md"
+	default:
+		return fmt.Errorf("invalid --export %q (use md or json)", exportFormat)
+	}
+	if outPath != "" && exportFormat == "" {
+		exportFormat = "md"
+		if strings.EqualFold(filepath.Ext(outPath), ".json") {
+			exportFormat = "json"
+		}
+	}
+	var export *service.Sink
+	if exportFormat != "" {
+		if outPath == "" {
+			outPath = "rca-{{date}}-{{name}}." + exportFormat
+		}
+		export = &service.Sink{Kind: service.SinkFile, Target: outPath}
+	}
+	if ticket != "" {
+		system, key, _ := strings.Cut(ticket, ":")
+		if !strings.EqualFold(system, "jira") {
+			return fmt.Errorf("invalid --ticket %q (only jira is supported)", ticket)
+		}
+		if key == "" {
+			key = os.Getenv("JIRA_PROJECT")
+		}
+		if key == "" {
+			return fmt.Errorf("--ticket jira needs a project key: --ticket jira:<key> or JIRA_PROJECT")
+		}
+		sinkSpecs = append(sinkSpecs, "jira://"+key)
+	}
+	sinks, err := parseSinks(sinkSpecs)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := config.Load(activeProfile)
+	if err != nil {
+		return err
+	}
+	if err := cfg.ValidateProject(); err != nil {
+		return err
+	}
+	client := api.NewClient(cfg)
+
+	quiet := jsonOutput
+	progress := display.NewProgress()
+	if quiet {
+		progress.Stop()
+	}
+	task := progress.Add("Creating session")
+	sessionUUID, _, err := prepareQuietRun(cfg, client, "", prompt, "", "", nil, !cfg.NoAutoName)
+	if err != nil {
+		progress.Stop()
+		return err
+	}
+
+	task.SetText("Investigating")
+	var answers service.AnswerCollector
+	var sources service.SourceCollector
+	var failures service.FailureCollector
+	handler, finishTranscript := recordTranscript(cfg, cfg.ProjectID, cfg.ProjectName, sessionUUID, prompt, func(resp *api.ProcessPromptResponse) {
+		answers.Handle(resp)
+		sources.Handle(resp)
+		failures.Handle(resp)
+		if c := resp.Message; c != nil && c.Content != nil && c.Content.ContentType == "CONTENT_TYPE_PROGRESS_STATUS" && len(c.Content.Parts) > 0 {
+			task.SetText("Investigating: " + service.ExtractProgressDisplay(c.Content.Parts[0]))
+		}
+	})
+	err = client.ProcessPromptStream(cfg.ProjectID, sessionUUID, prompt, handler)
+	finishTranscript(err)
+	if err != nil {
+		progress.Stop()
+		return fmt.Errorf("stream error: %w", err)
+	}
+	if f := failures.Failure(); f != nil {
+		progress.Stop()
+		if quiet {
+			return &investigationFailedError{sessionUUID: sessionUUID, failure: *f}
+		}
+		return reportInvestigationFailure(sessionUUID, f)
+	}
+
+	task.SetText("Waiting for the summary and scores")
+	ready := func(r *api.GetSessionSummaryResponse) bool {
+		return service.HasSummary(r) && (noScores || service.HasScores(r))
+	}
+	summary, waitErr := service.WaitForSummary(func() (*api.GetSessionSummaryResponse, error) {
+		return client.GetSessionSummary(cfg.ProjectID, sessionUUID)
+	}, ready, timeout)
+	progress.Stop()
+
+	r := investigationSinkResult(cfg, sessionUUID, prompt, answers.Answer())
+	r.Sources = sources.Sources()
+	if summary != nil {
+		r.Summary = summary.SessionSummary
+		if summary.SessionInfo != nil && summary.SessionInfo.Name != "" {
+			r.SessionName = summary.SessionInfo.Name
+		}
+	}
+	if res := service.BuildInvestigationResult(sessionUUID, "", nil, summary, ""); res.Scores != nil {
+		r.Scores = res.Scores
+	}
+
+	var exportErr error
+	if export != nil {
+		exportErr = writeSinkFile(export.FilePath(r), r)
+	}
+
+	if quiet {
+		if waitErr != nil {
+			fmt.Fprintf(os.Stderr, "warning: %v; the report is incomplete\n", waitErr)
+		}
+		if exportErr != nil {
+			fmt.Fprintf(os.Stderr, "warning: writing the report: %v\n", exportErr)
+		}
+		deliverSinks(sinks, r, true)
+		return printJSON(r)
+	}
+
+	title := r.SessionName
+	if title == "" {
+		title = sessionUUID
+	}
+	display.Header(fmt.Sprintf("RCA: %s", title))
+	if waitErr != nil {
+		display.Warn(fmt.Sprintf("%v; the report is incomplete", waitErr))
+	}
+	fmt.Println()
+	// The title is in the header already.
+	_, body, _ := strings.Cut(r.Markdown(), "\n")
+	for _, line := range strings.Split(api.RenderMarkdown(strings.TrimSpace(body)), "\n") {
+		fmt.Printf("  %s\n", line)
+	}
+	fmt.Println()
+	switch {
+	case exportErr != nil:
+		display.Warn(fmt.Sprintf("Could not write the report: %v", exportErr))
+	case export != nil:
+		display.Success(fmt.Sprintf("Report written to %s", export.FilePath(r)))
+	}
+	deliverSinks(sinks, r, false)
+	fmt.Printf("\n  %sTip:%s Run %shawkeye inspect %s%s for the full investigation.\n\n",
+		display.Dim, display.Reset, display.Cyan, sessionUUID, display.Reset)
+	return nil
+}
+
+// ─── summary ────────────────────────────────────────────────────────────────
+
+func cmdSummary(args []string) error {
+	args, projectRef, err := splitProjectFlag(args)
+	if err != nil {
+		return err
+	}
+	cfg, err := config.Load(activeProfile)
+	if err != nil {
+		return err
+	}
+	if err := useProjectFlag(cfg, projectRef); err != nil {
+		return err
+	}
+	if err := cfg.ValidateProject(); err != nil {
+		return err
+	}
+
+	var positional, sinkSpecs []string
+	var wait bool
+	format := service.SummaryFull
+	timeout := summaryWaitTimeout
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--sink":
+			if i+1 < len(args) {
+				i++
+				sinkSpecs = append(sinkSpecs, args[i])
+			} else {
+				return fmt.Errorf("--sink requires a value")
+			}
+		case "--format":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--format requires a value (executive, engineer or timeline)")
+			}
+			i++
+			f, err := service.ParseSummaryFormat(args[i])
+			if err != nil {
+				return err
+			}
+			format = f
+		case "--wait":
+			wait = true
+		case "--timeout":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--timeout requires a value")
+			}
+			i++
+			d, err := parseWaitTimeout(args[i])
+			if err != nil {
+				return err
+			}
+			timeout, wait = d, true
+		default:
+			positional = append(positional, args[i])
+		}
+	}
+	sinks, err := parseSinks(sinkSpecs)
+	if err != nil {
+		return err
+	}
+
+	sessionUUID := ""
+	if len(positional) > 0 {
+		sessionUUID = cfg.ResolveSession(positional[0])
+	} else if cfg.LastSession != "" {
+		sessionUUID = cfg.LastSession
+	} else {
+		fmt.Println("Usage: hawkeye summary [session-uuid] [--format executive|engineer|timeline] [--sink <spec>] [--wait [--timeout 10m]]")
+		return nil
+	}
+
+	client := api.NewClient(cfg)
+	fetch := func() (*api.GetSessionSummaryResponse, error) {
+		return client.GetSessionSummary(cfg.ProjectID, sessionUUID)
+	}
+
+	var resp *api.GetSessionSummaryResponse
+	err = inSessionProject(cfg, client, projectRef != "", sessionUUID, func() (err error) {
+		if wait {
+			sp := display.Spin("Waiting for the session summary...")
+			resp, err = service.WaitForSummary(fetch, service.HasSummary, timeout)
+			sp.Stop()
+		} else {
+			resp, err = fetch()
+		}
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("getting summary: %w", err)
+	}
+	indexSession(func(idx *config.SearchIndex) { service.IndexSummary(idx, cfg.ProjectID, sessionUUID, resp) })
+
+	if len(sinks) > 0 && resp.SessionSummary != nil {
+		r := service.SinkResult{
+			SessionUUID: sessionUUID,
+			ProjectUUID: cfg.ProjectID,
+			Summary:     resp.SessionSummary,
+			ConsoleURL:  cfg.ConsoleSessionURL(sessionUUID),
+			Time:        time.Now(),
+		}
+		if resp.SessionInfo != nil {
+			r.SessionName = resp.SessionInfo.Name
+		}
+		if resp.SessionSummary.ShortSummary != nil {
+			r.Prompt = resp.SessionSummary.ShortSummary.Question
+		}
+		defer deliverSinks(sinks, r, jsonOutput)
+	}
+
+	if format != service.SummaryFull {
+		return printSummaryView(client, cfg.ProjectID, sessionUUID, resp, format)
+	}
+
+	if jsonOutput {
+		return printJSON(resp)
+	}
+
+	if resp.SessionInfo != nil {
+		name := resp.SessionInfo.Name
+		if name == "" {
+			name = sessionUUID
+		}
+		display.Header(fmt.Sprintf("Summary: %s", name))
+	} else {
+		display.Header("Session Summary")
+	}
+
+	if resp.SessionSummary == nil {
+		display.Warn("No summary available yet.")
+		return nil
+	}
+
+	summary := resp.SessionSummary
+
+	if summary.ShortSummary != nil {
+		if summary.ShortSummary.Question != "" {
+			fmt.Printf("\n  %sQuestion:%s\n", display.Dim, display.Reset)
+			for _, line := range strings.Split(api.RenderMarkdown(summary.ShortSummary.Question), "\n") {
+				fmt.Printf("    %s\n", line)
+			}
+		}
+		if summary.ShortSummary.Analysis != "" {
+			fmt.Printf("\n  %sQuick Analysis:%s\n", display.Dim, display.Reset)
+			for _, line := range strings.Split(api.RenderMarkdown(summary.ShortSummary.Analysis), "\n") {
+				fmt.Printf("    %s\n", line)
+			}
+		}
+	}
+
+	if summary.Analysis != "" {
+		fmt.Printf("\n  %s📋 Full Analysis:%s\n", display.Green, display.Reset)
+		for _, line := range strings.Split(api.RenderMarkdown(summary.Analysis), "\n") {
+			fmt.Printf("    %s\n", line)
+		}
+	}
+
+	if len(summary.ActionItems) > 0 {
+		fmt.Printf("\n  %s🎯 Action Items:%s\n", display.Yellow, display.Reset)
+		for i, item := range summary.ActionItems {
+			fmt.Printf("    %d. %s\n", i+1, item)
+		}
+	}
+
+	fmt.Println()
+	return nil
+}
+
+// summaryKeyQueries is how many queries the engineer view lists.
+const summaryKeyQueries = 5
+
+// printSummaryView prints a session summary in one of the --format views:
+// executive (issue, impact, resolution, action items), engineer (the full
+// analysis plus the key queries behind it) or timeline (the investigation's
+// steps in order, with timestamps).
+func printSummaryView(client *api.Client, projectUUID, sessionUUID string, resp *api.GetSessionSummaryResponse, format string) error {
+	name := sessionUUID
+	if resp.SessionInfo != nil && resp.SessionInfo.Name != "" {
+		name = resp.SessionInfo.Name
+	}
+
+	switch format {
+	case service.SummaryExecutive:
+		exec := service.BuildExecutiveSummary(resp.SessionSummary)
+		if jsonOutput {
+			return printJSON(exec)
+		}
+		display.Header(fmt.Sprintf("Executive Summary: %s", name))
+		if resp.SessionSummary == nil {
+			display.Warn("No summary available yet.")
+			return nil
+		}
+		printSummarySection("Issue", display.Dim, exec.Issue)
+		printSummarySection("Impact", display.Dim, exec.Impact)
+		printSummarySection("Resolution", display.Green, exec.Resolution)
+		if len(exec.ActionItems) > 0 {
+			fmt.Printf("\n  %s🎯 Action Items:%s\n", display.Yellow, display.Reset)
+			for i, item := range exec.ActionItems {
+				fmt.Printf("    %d. %s\n", i+1, item)
+			}
+		}
+
+	case service.SummaryEngineer:
+		qresp, err := client.GetInvestigationQueries(projectUUID, sessionUUID)
+		if err != nil {
+			return fmt.Errorf("getting queries: %w", err)
+		}
+		queries := service.KeyQueries(qresp.Queries, summaryKeyQueries)
+		if jsonOutput {
+			out := struct {
+				Summary    *api.SessionSummary  `json:"summary"`
+				KeyQueries []api.QueryExecution `json:"key_queries"`
+			}{resp.SessionSummary, queries}
+			return printJSON(out)
+		}
+		display.Header(fmt.Sprintf("Engineer Summary: %s", name))
+		if resp.SessionSummary == nil {
+			display.Warn("No summary available yet.")
+			return nil
+		}
+		s := resp.SessionSummary
+		if s.ShortSummary != nil {
+			printSummarySection("Question", display.Dim, s.ShortSummary.Question)
+		}
+		printSummarySection("📋 Full Analysis", display.Green, s.Analysis)
+		if len(queries) > 0 {
+			fmt.Printf("\n  %s🔎 Key Queries:%s\n", display.Cyan, display.Reset)
+			for i, q := range queries {
+				fmt.Printf("    %d. %s%s%s", i+1, display.Gray, truncate(q.Query, 100), display.Reset)
+				var meta []string
+				if q.Source != "" {
+					meta = append(meta, q.Source)
+				}
+				if q.ResultCount > 0 {
+					meta = append(meta, fmt.Sprintf("%d results", q.ResultCount))
+				}
+				if len(meta) > 0 {
+					fmt.Printf("  %s(%s)%s", display.Dim, strings.Join(meta, ", "), display.Reset)
+				}
+				fmt.Println()
+			}
+		}
+		if len(s.ActionItems) > 0 {
+			fmt.Printf("\n  %s🎯 Action Items:%s\n", display.Yellow, display.Reset)
+			for i, item := range s.ActionItems {
+				fmt.Printf("    %d. %s\n", i+1, item)
+			}
+		}
+
+	case service.SummaryTimeline:
+		inspect, err := client.SessionInspect(projectUUID, sessionUUID)
+		if err != nil {
+			return fmt.Errorf("getting session: %w", err)
+		}
+		events := service.BuildTimeline(inspect)
+		if jsonOutput {
+			return printJSON(events)
+		}
+		display.Header(fmt.Sprintf("Timeline: %s", name))
+		if len(events) == 0 {
+			display.Warn("No investigation steps recorded.")
+			return nil
+		}
+		fmt.Println()
+		for _, ev := range events {
+			ts := "        "
+			if !ev.Time.IsZero() {
+				ts = ev.Time.Local().Format("15:04:05")
+			}
+			switch ev.Kind {
+			case "prompt":
+				fmt.Printf("  %s%s%s  %s❯ %s%s\n", display.Dim, ts, display.Reset, display.Bold, truncate(ev.Text, 100), display.Reset)
+			case "answer":
+				fmt.Printf("  %s%s%s  %s✓ %s%s\n", display.Dim, ts, display.Reset, display.Green, ev.Text, display.Reset)
+			default:
+				line := truncate(ev.Text, 90)
+				if ev.Category != "" {
+					line = fmt.Sprintf("[%s] %s", ev.Category, line)
+				}
+				dur := ""
+				if ev.DurationMs > 0 {
+					dur = fmt.Sprintf("  %s(%s)%s", display.Dim, service.FormatDurationMs(ev.DurationMs), display.Reset)
+				}
+				fmt.Printf("  %s%s%s    %s%s\n", display.Dim, ts, display.Reset, line, dur)
+			}
+		}
+	}
+
+	fmt.Println()
+	return nil
+}
+
+// printSummarySection prints a titled markdown block; empty text prints
+// nothing.
+func printSummarySection(title, color, text string) {
+	if strings.TrimSpace(text) == "" {
+		return
+	}
+	fmt.Printf("\n  %s%s:%s\n", color, title, display.Reset)
+	for _, line := range strings.Split(api.RenderMarkdown(text), "\n") {
+		fmt.Printf("    %s\n", line)
+	}
+}
+
+// ─── actions ────────────────────────────────────────────────────────────────
+
+// defaultActionsLimit is how many recent sessions `actions list` reads.
+const defaultActionsLimit = 20
+
+func cmdActions(args []string) error {
+	cfg, err := config.Load(activeProfile)
+	if err != nil {
+		return err
+	}
+
+	sub := "list"
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		switch args[0] {
+		case "list", "ls", "done", "undo", "export":
+			sub, args = args[0], args[1:]
+		}
+	}
+
+	store, err := config.LoadActions(activeProfile)
+	if err != nil {
+		return err
+	}
+
+	switch sub {
+	case "done", "undo":
+		if len(args) == 0 {
+			fmt.Printf("Usage: hawkeye actions %s <n> [<n>...]  (numbers from the last actions list)\n", sub)
+			return nil
+		}
+		var refs []config.ActionRef
+		for _, a := range args {
+			n, err := strconv.Atoi(a)
+			if err != nil {
+				return fmt.Errorf("invalid action item number %q", a)
+			}
+			ref, err := store.Item(n)
+			if err != nil {
+				return err
+			}
+			refs = append(refs, ref)
+		}
+		now := time.Now()
+		for _, ref := range refs {
+			store.SetDone(ref.Key, sub == "done", now)
+		}
+		if err := store.Save(); err != nil {
+			return err
+		}
+		for _, ref := range refs {
+			if sub == "done" {
+				display.Success("Done: " + ref.Text)
+			} else {
+				display.Success("Reopened: " + ref.Text)
+			}
+		}
+		return nil
+	}
+
+	var positional []string
+	var openOnly bool
+	limit := defaultActionsLimit
+	format := "md"
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "-n", "--limit":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--limit requires a value")
+			}
+			i++
+			n, err := strconv.Atoi(args[i])
+			if err != nil || n < 1 {
+				return fmt.Errorf("--limit must be a positive number")
+			}
+			limit = n
+		case "--open":
+			openOnly = true
+		case "--format":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--format requires a value (md or json)")
+			}
+			i++
+			format = args[i]
+		default:
+			positional = append(positional, args[i])
+		}
+	}
+	if sub == "export" && format != "md" && format != "markdown" && format != "json" {
+		return fmt.Errorf("invalid --format %q (use md or json)", format)
+	}
+	if err := cfg.ValidateProject(); err != nil {
+		return err
+	}
+
+	items, failed, err := collectActionItems(cfg, positional, limit)
+	if err != nil {
+		return err
+	}
+	// The listing is saved before filtering so the numbers shown stay
+	// those `actions done` resolves.
+	store.Listing = nil
+	for i, it := range items {
+		store.Listing = append(store.Listing, config.ActionRef{
+			Key:         it.Key,
+			SessionUUID: it.SessionUUID,
+			SessionName: it.SessionName,
+			Text:        it.Text,
+		})
+		if at := store.DoneAt(it.Key); !at.IsZero() {
+			items[i].Done, items[i].DoneAt = true, at
+		}
+	}
+	if err := store.Save(); err != nil {
+		return err
+	}
+
+	if sub == "export" {
+		if openOnly {
+			items = service.OpenActionItems(items)
+		}
+		if jsonOutput || format == "json" {
+			return printJSON(items)
+		}
+		fmt.Print(service.FormatActionsMarkdown(items, cfg.ConsoleSessionURL))
+		return nil
+	}
+
+	if jsonOutput {
+		if openOnly {
+			items = service.OpenActionItems(items)
+		}
+		return printJSON(items)
+	}
+
+	open := len(service.OpenActionItems(items))
+	display.Header(fmt.Sprintf("Action Items (%d open of %d)", open, len(items)))
+	if failed > 0 {
+		display.Warn(fmt.Sprintf("%d session summary(ies) could not be fetched and were skipped.", failed))
+	}
+	if len(items) == 0 {
+		display.Warn("No action items found.")
+		return nil
+	}
+	current := ""
+	for i, it := range items {
+		if openOnly && it.Done {
+			continue
+		}
+		if it.SessionUUID != current {
+			current = it.SessionUUID
+			name := it.SessionName
+			if name == "" {
+				name = "(unnamed)"
+			}
+			fmt.Printf("\n  %s%s%s  %s%s%s\n", display.Bold, truncate(name, 60), display.Reset, display.Dim, it.SessionUUID, display.Reset)
+		}
+		if it.Done {
+			fmt.Printf("  %s%3d. ✓ %s%s\n", display.Dim, i+1, it.Text, display.Reset)
+		} else {
+			fmt.Printf("  %s%3d.%s ☐ %s\n", display.Yellow, i+1, display.Reset, it.Text)
+		}
+	}
+	fmt.Printf("\n  %sTip:%s Mark items done with %shawkeye actions done <n>%s\n\n",
+		display.Dim, display.Reset, display.Cyan, display.Reset)
+	return nil
+}
+
+// collectActionItems gathers the action items of one session, or of the
+// limit most recent sessions, newest first. It returns how many summaries
+// could not be fetched.
+func collectActionItems(cfg *config.Config, positional []string, limit int) ([]service.ActionItem, int, error) {
+	client := api.NewClient(cfg)
+
+	if len(positional) > 0 {
+		sessionUUID := cfg.ResolveSession(positional[0])
+		resp, err := client.GetSessionSummary(cfg.ProjectID, sessionUUID)
+		if err != nil {
+			return nil, 0, fmt.Errorf("getting summary: %w", err)
+		}
+		name := ""
+		if resp.SessionInfo != nil {
+			name = resp.SessionInfo.Name
+		}
+		var items []string
+		if resp.SessionSummary != nil {
+			items = resp.SessionSummary.ActionItems
+		}
+		return service.SessionActionItems(sessionUUID, name, items), 0, nil
+	}
+
+	resp, err := client.SessionList(cfg.ProjectID, 0, limit, nil)
+	if err != nil {
+		return nil, 0, fmt.Errorf("listing sessions: %w", err)
+	}
+	var all []service.ActionItem
+	var failed int
+	progress := display.NewProgress()
+	task := progress.Add("Reading session summaries")
+	for i, s := range resp.Sessions {
+		task.SetProgress(i+1, len(resp.Sessions))
+		summary, err := client.GetSessionSummary(cfg.ProjectID, s.SessionUUID)
+		if err != nil {
+			failed++
+			continue
+		}
+		if summary.SessionSummary == nil {
+			continue
+		}
+		all = append(all, service.SessionActionItems(s.SessionUUID, s.Name, summary.SessionSummary.ActionItems)...)
+	}
+	progress.Stop()
+	return all, failed, nil
+}
+
+// ─── feedback ───────────────────────────────────────────────────────────────
+
+func cmdFeedback(args []string) error {
+	if len(args) > 0 {
+		switch args[0] {
+		case "history":
+			return cmdFeedbackHistory(args[1:])
+		case "import":
+			return cmdFeedbackImport(args[1:])
+		}
+	}
+
+	args, projectRef, err := splitProjectFlag(args)
+	if err != nil {
+		return err
+	}
+
+	var reason string
+	var debugMode, up bool
+	var positional []string
+	cycle := 0
+
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "-r", "--reason":
+			if i+1 < len(args) {
+				i++
+				reason = args[i]
+			} else {
+				return fmt.Errorf("--reason requires a value")
+			}
+		case "--cycle":
+			if i+1 < len(args) {
+				i++
+				n, err := strconv.Atoi(args[i])
+				if err != nil || n < 1 {
+					return fmt.Errorf("--cycle must be a positive number")
+				}
+				cycle = n
+			} else {
+				return fmt.Errorf("--cycle requires a value")
+			}
+		case "--up":
+			up = true
+		case "--down":
+			up = false
+		case "--debug":
+			debugMode = true
+		default:
+			positional = append(positional, args[i])
+		}
+	}
+
+	cfg, err := config.Load(activeProfile)
+	if err != nil {
+		return err
+	}
+	if err := useProjectFlag(cfg, projectRef); err != nil {
+		return err
+	}
+	if err := cfg.ValidateProject(); err != nil {
+		return err
+	}
+
+	sessionUUID := ""
+	if len(positional) > 0 {
+		sessionUUID = cfg.ResolveSession(positional[0])
+	} else if cfg.LastSession != "" {
+		sessionUUID = cfg.LastSession
+	} else {
+		fmt.Println("Usage: hawkeye feedback|td [session-uuid] [--up|--down] [--cycle <n>] [-r reason]")
+		fmt.Println("       hawkeye feedback history [session-uuid]")
+		fmt.Println("       hawkeye feedback import <ratings.csv>")
+		return nil
+	}
+
+	client := api.NewClient(cfg)
+	client.SetDebug(debugMode)
+
+	var resp *api.SessionInspectResponse
+	err = inSessionProject(cfg, client, projectRef != "", sessionUUID, func() (err error) {
+		resp, err = client.SessionInspect(cfg.ProjectID, sessionUUID)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("inspecting session: %w", err)
+	}
+
+	if len(resp.PromptCycle) == 0 {
+		return fmt.Errorf("no prompt cycles found in session %s", sessionUUID)
+	}
+	pc, err := service.SelectCycle(resp.PromptCycle, cycle)
+	if err != nil {
+		return err
+	}
+	items := []api.RatingItemID{{ItemType: "ITEM_TYPE_PROMPT_CYCLE", ItemID: pc.ID}}
+
+	rating, label := service.RatingDown, "Thumbs down"
+	if up {
+		rating, label = service.RatingUp, "Thumbs up"
+	}
+	if reason == "" {
+		reason = label + " from CLI"
+	}
+
+	if err := client.PutRating(cfg.ProjectID, sessionUUID, items, rating, reason); err != nil {
+		return fmt.Errorf("submitting feedback: %w", err)
+	}
+
+	if cycle > 0 {
+		display.Success(fmt.Sprintf("%s submitted for cycle %d of session %s", label, cycle, sessionUUID))
+	} else {
+		display.Success(fmt.Sprintf("%s submitted for session %s", label, sessionUUID))
+	}
+	return nil
+}
+
+// feedbackImportResult is the outcome of one row of `feedback import`.
+type feedbackImportResult struct {
+	service.RatingRow
+	CycleID string `json:"cycle_id,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+// cmdFeedbackImport submits the ratings of a CSV file, e.g. the outcome of
+// a quality review. Each session is inspected once to find its cycles; a
+// row that fails is reported and the rest still go through.
+func cmdFeedbackImport(args []string) error {
+	args, projectRef, err := splitProjectFlag(args)
+	if err != nil {
+		return err
+	}
+	var path string
+	var dryRun bool
+	for _, a := range args {
+		switch a {
+		case "--dry-run":
+			dryRun = true
+		default:
+			if strings.HasPrefix(a, "-") && a != "-" {
+				return fmt.Errorf("unknown flag: %s", a)
+			}
+			path = a
+		}
+	}
+	if path == "" {
+		return fmt.Errorf("usage: hawkeye feedback import <ratings.csv|-> [--dry-run]")
+	}
+
+	data, err := readInputFile(path)
+	if err != nil {
+		return err
+	}
+	rows, err := service.ParseRatingsCSV(strings.NewReader(string(data)))
+	if err != nil {
+		return fmt.Errorf("reading %s:\n%w", path, err)
+	}
+
+	cfg, err := config.Load(activeProfile)
+	if err != nil {
+		return err
+	}
+	if err := useProjectFlag(cfg, projectRef); err != nil {
+		return err
+	}
+	if err := cfg.ValidateProject(); err != nil {
+		return err
+	}
+	client := api.NewClient(cfg)
+
+	cycles := map[string][]api.PromptCycle{}
+	inspectErrs := map[string]error{}
+	results := make([]feedbackImportResult, len(rows))
+	failed := 0
+
+	progress := display.NewProgress()
+	task := progress.Add("Submitting ratings")
+	if dryRun {
+		task.SetText("Checking ratings")
+	}
+	for i, row := range rows {
+		task.SetProgress(i+1, len(rows))
+		row.SessionUUID = cfg.ResolveSession(row.SessionUUID)
+		res := feedbackImportResult{RatingRow: row}
+		err := func() error {
+			if _, ok := cycles[row.SessionUUID]; !ok && inspectErrs[row.SessionUUID] == nil {
+				resp, err := client.SessionInspect(cfg.ProjectID, row.SessionUUID)
+				if err != nil {
+					inspectErrs[row.SessionUUID] = fmt.Errorf("inspecting session: %w", err)
+				} else {
+					cycles[row.SessionUUID] = resp.PromptCycle
+				}
+			}
+			if err := inspectErrs[row.SessionUUID]; err != nil {
+				return err
+			}
+			pc, err := service.SelectCycle(cycles[row.SessionUUID], row.Cycle)
+			if err != nil {
+				return err
+			}
+			res.CycleID = pc.ID
+			if dryRun {
+				return nil
+			}
+			reason := row.Reason
+			if reason == "" {
+				reason = "Thumbs " + service.RatingLabel(row.Rating) + " from CLI"
+			}
+			items := []api.RatingItemID{{ItemType: "ITEM_TYPE_PROMPT_CYCLE", ItemID: pc.ID}}
+			return client.PutRating(cfg.ProjectID, row.SessionUUID, items, row.Rating, reason)
+		}()
+		if err != nil {
+			res.Error = err.Error()
+			failed++
+		}
+		results[i] = res
+	}
+	progress.Stop()
+
+	if jsonOutput {
+		if err := printJSON(results); err != nil {
+			return err
+		}
+	} else {
+		verb := "Submitted"
+		if dryRun {
+			verb = "Would submit"
+		}
+		fmt.Println()
+		if ok := len(rows) - failed; ok > 0 {
+			display.Success(fmt.Sprintf("%s %d of %d rating(s) from %s", verb, ok, len(rows), path))
+		}
+		if failed > 0 {
+			display.Warn(fmt.Sprintf("%d rating(s) failed:", failed))
+			for _, r := range results {
+				if r.Error == "" {
+					continue
+				}
+				cycle := "last cycle"
+				if r.Cycle > 0 {
+					cycle = fmt.Sprintf("cycle %d", r.Cycle)
+				}
+				fmt.Printf("    %sline %d%s  %s %s: %s%s%s\n", display.Dim, r.Line, display.Reset, r.SessionUUID, cycle, display.Red, r.Error, display.Reset)
+			}
+		}
+		if dryRun {
+			fmt.Printf("\n  %sDry run: nothing was submitted.%s\n", display.Dim, display.Reset)
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d ratings failed", failed, len(rows))
+	}
+	return nil
+}
+
+// cmdFeedbackHistory shows the rating of each prompt cycle in a session.
+func cmdFeedbackHistory(args []string) error {
+	args, projectRef, err := splitProjectFlag(args)
+	if err != nil {
+		return err
+	}
+	cfg, err := config.Load(activeProfile)
+	if err != nil {
+		return err
+	}
+	if err := useProjectFlag(cfg, projectRef); err != nil {
+		return err
+	}
+	if err := cfg.ValidateProject(); err != nil {
+		return err
+	}
+
+	sessionUUID := cfg.LastSession
+	if len(args) > 0 {
+		sessionUUID = cfg.ResolveSession(args[0])
+	}
+	if sessionUUID == "" {
+		fmt.Println("Usage: hawkeye feedback history [session-uuid]")
+		return nil
+	}
+
+	client := api.NewClient(cfg)
+	var resp *api.SessionInspectResponse
+	err = inSessionProject(cfg, client, projectRef != "", sessionUUID, func() (err error) {
+		resp, err = client.SessionInspect(cfg.ProjectID, sessionUUID)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("inspecting session: %w", err)
+	}
+	history := service.FeedbackHistory(resp.PromptCycle)
+
+	if jsonOutput {
+		return printJSON(history)
+	}
+	if len(history) == 0 {
+		display.Warn("No prompt cycles found.")
+		return nil
+	}
+
+	display.Header(fmt.Sprintf("Feedback for session %s", sessionUUID))
+	fmt.Println()
+	for _, e := range history {
+		rating := display.Dim + "– not rated" + display.Reset
+		switch e.Rating {
+		case "up":
+			rating = display.Green + "👍 up" + display.Reset
+		case "down":
+			rating = display.Red + "👎 down" + display.Reset
+		}
+		when := ""
+		if e.Time != "" {
+			when = display.FormatTime(e.Time)
+		}
+		fmt.Printf("  %sCycle %d%s  %s  %s%s%s\n", display.Bold, e.Cycle, display.Reset, rating, display.Dim, when, display.Reset)
+		if e.Prompt != "" {
+			fmt.Printf("    %s❯%s %s\n", display.Cyan, display.Reset, truncate(e.Prompt, 90))
+		}
+		if e.Reason != "" {
+			fmt.Printf("    %sReason:%s %s\n", display.Dim, display.Reset, e.Reason)
+		}
+	}
+	fmt.Printf("\n  %sTip:%s Rate a cycle with %shawkeye feedback %s --cycle <n> --up%s\n\n",
+		display.Dim, display.Reset, display.Cyan, sessionUUID, display.Reset)
+	return nil
+}
+
+// ─── prompts ────────────────────────────────────────────────────────────────
+
+func cmdPrompts(args []string) error {
+	if len(args) > 0 {
+		switch args[0] {
+		case "push":
+			return cmdPromptsPush(args[1:])
+		case "delete":
+			return cmdPromptsDelete(args[1:])
+		case "list":
+		default:
+			return fmt.Errorf("unknown prompts subcommand: %s (valid: list, push, delete)", args[0])
+		}
+	}
+
+	cfg, err := config.Load(activeProfile)
+	if err != nil {
+		return err
+	}
+	if err := cfg.ValidateProject(); err != nil {
+		return err
+	}
+
+	client := api.NewClient(cfg)
+
+	resp, err := client.PromptLibrary(cfg.ProjectID)
+	if err != nil {
+		return fmt.Errorf("getting prompt library: %w", err)
+	}
+
+	if jsonOutput {
+		return printJSON(resp)
+	}
+
+	display.Header(fmt.Sprintf("Prompt Library (%d prompts)", len(resp.Items)))
+
+	if len(resp.Items) == 0 {
+		display.Warn("No prompts found in the library.")
+		return nil
+	}
+
+	for i, p := range resp.Items {
+		label := p.Oneliner
+		if label == "" {
+			label = truncate(p.Prompt, 80)
+		}
+		fmt.Printf("  %s%d.%s %s  %s%s%s\n", display.Cyan, i+1, display.Reset, label, display.Dim, p.UUID, display.Reset)
+		if p.Oneliner != "" && p.Prompt != "" && p.Prompt != p.Oneliner {
+			fmt.Printf("     %s%s%s\n", display.Gray, truncate(p.Prompt, 90), display.Reset)
+		}
+	}
+
+	fmt.Printf("\n  %sTip:%s Copy a prompt and run %shawkeye investigate \"<prompt>\"%s, or share one with %shawkeye prompts push%s\n\n",
+		display.Dim, display.Reset, display.Cyan, display.Reset, display.Cyan, display.Reset)
+
+	return nil
+}
+
+// cmdPromptsPush adds a prompt to the shared library, or replaces the one
+// with the same name, after showing what changes.
+func cmdPromptsPush(args []string) error {
+	var name, text, file string
+	dryRun := false
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--prompt", "-p":
+			if i+1 >= len(args) {
+				return fmt.Errorf("%s requires a value", args[i])
+			}
+			i++
+			text = args[i]
+		case "--file", "-f":
+			if i+1 >= len(args) {
+				return fmt.Errorf("%s requires a file or - for stdin", args[i])
+			}
+			i++
+			file = args[i]
+		case "--dry-run":
+			dryRun = true
+		default:
+			if strings.HasPrefix(args[i], "-") {
+				return fmt.Errorf("unknown flag for prompts push: %s", args[i])
+			}
+			if name != "" {
+				return fmt.Errorf("unexpected argument: %s (quote names with spaces)", args[i])
+			}
+			name = args[i]
+		}
+	}
+	if name == "" || (text == "") == (file == "") {
+		fmt.Println("Usage: hawkeye prompts push <name> --prompt <text>|--file <path|-> [--dry-run]")
+		return nil
+	}
+	if file != "" {
+		data, err := readInputFile(file)
+		if err != nil {
+			return fmt.Errorf("reading prompt: %w", err)
+		}
+		text = string(data)
+	}
+
+	cfg, err := config.Load(activeProfile)
+	if err != nil {
+		return err
+	}
+	if err := cfg.ValidateProject(); err != nil {
+		return err
+	}
+	client := api.NewClient(cfg)
+	lib, err := client.PromptLibrary(cfg.ProjectID)
+	if err != nil {
+		return fmt.Errorf("getting prompt library: %w", err)
+	}
+	change, err := service.PlanPromptPush(name, text, lib.Items)
+	if err != nil {
+		return err
+	}
+
+	if jsonOutput && (dryRun || change.Action == service.SyncUnchanged) {
+		return printJSON(change)
+	}
+	if !jsonOutput {
+		title := "Prompt Push"
+		if dryRun {
+			title += " (dry run)"
+		}
+		display.Header(title)
+		printPromptChange(change)
+	}
+	if dryRun {
+		fmt.Printf("\n  %sTip:%s Re-run without %s--dry-run%s to update the shared library.\n\n",
+			display.Dim, display.Reset, display.Cyan, display.Reset)
+		return nil
+	}
+	if change.Action == service.SyncUnchanged {
+		display.Success("The library already has this prompt; nothing to push")
+		return nil
+	}
+
+	resp, err := client.SavePrompt(cfg.ProjectID, api.InitialPrompt{UUID: change.UUID, Oneliner: change.Name, Prompt: change.Prompt})
+	if err != nil {
+		return fmt.Errorf("pushing prompt: %w", promptLibraryWriteError(err))
+	}
+	if resp.Item != nil && resp.Item.UUID != "" {
+		change.UUID = resp.Item.UUID
+	}
+	if jsonOutput {
+		return printJSON(change)
+	}
+	verb := "added to"
+	if change.Action == service.SyncUpdate {
+		verb = "updated in"
+	}
+	display.Success(fmt.Sprintf("Prompt %q %s the library (%s)", change.Name, verb, change.UUID))
+	return nil
+}
+
+// cmdPromptsDelete removes a prompt from the shared library.
+func cmdPromptsDelete(args []string) error {
+	var promptUUID string
+	confirmed, dryRun := false, false
+	for _, a := range args {
+		switch a {
+		case "--confirm", "-y":
+			confirmed = true
+		case "--dry-run":
+			dryRun = true
+		default:
+			if strings.HasPrefix(a, "-") {
+				return fmt.Errorf("unknown flag for prompts delete: %s", a)
+			}
+			promptUUID = a
+		}
+	}
+	if promptUUID == "" {
+		fmt.Println("Usage: hawkeye prompts delete <uuid> [--confirm] [--dry-run]")
+		return nil
+	}
+
+	cfg, err := config.Load(activeProfile)
+	if err != nil {
+		return err
+	}
+	if err := cfg.ValidateProject(); err != nil {
+		return err
+	}
+	client := api.NewClient(cfg)
+	lib, err := client.PromptLibrary(cfg.ProjectID)
+	if err != nil {
+		return fmt.Errorf("getting prompt library: %w", err)
+	}
+	p, ok := service.FindLibraryPrompt(lib.Items, promptUUID)
+	if !ok {
+		return fmt.Errorf("no prompt %s in the library of project %s", promptUUID, cfg.ProjectID)
+	}
+	change := service.PromptChange{Action: service.SyncDelete, UUID: p.UUID, Name: p.Oneliner, OldPrompt: p.Prompt}
+
+	if jsonOutput && dryRun {
+		return printJSON(change)
+	}
+	if !jsonOutput {
+		title := "Prompt Delete"
+		if dryRun {
+			title += " (dry run)"
+		}
+		display.Header(title)
+		printPromptChange(change)
+	}
+	if dryRun {
+		return nil
+	}
+	if !confirmed {
+		fmt.Printf("Delete %q from the shared library? Use --confirm to proceed.\n", p.Oneliner)
+		return nil
+	}
+
+	if err := client.DeletePrompt(cfg.ProjectID, p.UUID); err != nil {
+		return fmt.Errorf("deleting prompt: %w", promptLibraryWriteError(err))
+	}
+	if jsonOutput {
+		return printJSON(map[string]string{"deleted": p.UUID})
+	}
+	display.Success(fmt.Sprintf("Prompt %q deleted from the library", p.Oneliner))
+	return nil
+}
+
+// printPromptChange shows a library change with a line diff of the prompt.
+func printPromptChange(c service.PromptChange) {
+	switch c.Action {
+	case service.SyncCreate:
+		fmt.Printf("  %s+ create%s  %s\n", display.Green, display.Reset, c.Name)
+	case service.SyncUpdate:
+		fmt.Printf("  %s~ update%s  %s  %s%s%s\n", display.Yellow, display.Reset, c.Name, display.Dim, c.UUID, display.Reset)
+	case service.SyncDelete:
+		fmt.Printf("  %s- delete%s  %s  %s%s%s\n", display.Red, display.Reset, c.Name, display.Dim, c.UUID, display.Reset)
+	default:
+		fmt.Printf("  %s= same    %s  %s%s\n", display.Dim, c.Name, c.UUID, display.Reset)
+		fmt.Println()
+		return
+	}
+	fmt.Println()
+	printLineDiff(service.DiffLines(c.OldPrompt, c.Prompt))
+	fmt.Println()
+}
+
+// printLineDiff prints the changed hunks of a line diff, with +/- markers
+// in green and red and a few unchanged lines around each.
+func printLineDiff(lines []service.DiffLine) {
+	for _, h := range service.DiffHunks(lines, service.DiffContext) {
+		fmt.Printf("    %s%s%s\n", display.Cyan, h.Header(), display.Reset)
+		for _, l := range h.Lines {
+			switch l.Op {
+			case service.DiffAdd:
+				fmt.Printf("    %s+ %s%s\n", display.Green, l.Text, display.Reset)
+			case service.DiffRemove:
+				fmt.Printf("    %s- %s%s\n", display.Red, l.Text, display.Reset)
+			default:
+				fmt.Printf("    %s  %s%s\n", display.Dim, l.Text, display.Reset)
+			}
+		}
+	}
+}
+
+// promptLibraryWriteError explains the errors of servers whose prompt
+// library cannot be written from the API.
+func promptLibraryWriteError(err error) error {
+	msg := err.Error()
+	switch {
+	case strings.Contains(msg, "server returned 404"), strings.Contains(msg, "server returned 405"), strings.Contains(msg, "server returned 501"):
+		return fmt.Errorf("this server's prompt library is read-only: %w", err)
+	case strings.Contains(msg, "server returned 403"):
+		return fmt.Errorf("your role cannot change the prompt library: %w", err)
+	}
+	return err
+}
+
+// ─── template ───────────────────────────────────────────────────────────────
+
+func cmdTemplate(args []string) error {
+	if len(args) == 0 {
+		fmt.Println("Usage: hawkeye template <create|list|show|delete> ...")
+		return nil
+	}
+	switch args[0] {
+	case "create":
+		return cmdTemplateCreate(args[1:])
+	case "list":
+		return cmdTemplateList()
+	case "show":
+		return cmdTemplateShow(args[1:])
+	case "delete":
+		return cmdTemplateDelete(args[1:])
+	}
+	return fmt.Errorf("unknown template subcommand: %s (valid: create, list, show, delete)", args[0])
+}
+
+// cmdTemplateCreate saves a prompt with the session instructions and
+// telemetry scope to run it with.
+func cmdTemplateCreate(args []string) error {
+	var name, text, file, telemetry string
+	var instrSpecs []string
+	force := false
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--prompt", "-p":
+			if i+1 >= len(args) {
+				return fmt.Errorf("%s requires a value", args[i])
+			}
+			i++
+			text = args[i]
+		case "--prompt-file", "-f":
+			if i+1 >= len(args) {
+				return fmt.Errorf("%s requires a file or - for stdin", args[i])
+			}
+			i++
+			file = args[i]
+		case "--instruction", "-i":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--instruction requires type:content")
+			}
+			i++
+			instrSpecs = append(instrSpecs, args[i])
+		case "--telemetry":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--telemetry requires a value")
+			}
+			i++
+			telemetry = args[i]
+		case "--force":
+			force = true
+		default:
+			if strings.HasPrefix(args[i], "-") {
+				return fmt.Errorf("unknown flag for template create: %s", args[i])
+			}
+			if name != "" {
+				return fmt.Errorf("unexpected argument: %s", args[i])
+			}
+			name = args[i]
+		}
+	}
+	if name == "" || (text == "") == (file == "") {
+		fmt.Println("Usage: hawkeye template create <name> --prompt <text>|--prompt-file <path|->")
+		fmt.Println("         [--instruction <type>:<content>]... [--telemetry metric,log,trace] [--force]")
+		return nil
+	}
+	if err := service.ValidTemplateName(name); err != nil {
+		return err
+	}
+	if file != "" {
+		data, err := readInputFile(file)
+		if err != nil {
+			return fmt.Errorf("reading prompt: %w", err)
+		}
+		text = string(data)
+	}
+	tmpl := config.Template{Name: name, Prompt: strings.TrimSpace(text), CreatedAt: time.Now().UTC()}
+	if tmpl.Prompt == "" {
+		return fmt.Errorf("the template prompt is empty")
+	}
+	for _, spec := range instrSpecs {
+		in, err := service.ParseTemplateInstruction(spec)
+		if err != nil {
+			return err
+		}
+		tmpl.Instructions = append(tmpl.Instructions, in)
+	}
+	scope, err := service.ParseTelemetryScope(telemetry)
+	if err != nil {
+		return err
+	}
+	tmpl.Telemetry = scope
+
+	store, err := config.LoadTemplates()
+	if err != nil {
+		return err
+	}
+	if _, err := store.Get(name); err == nil && !force {
+		return fmt.Errorf("template %s already exists (use --force to replace it)", name)
+	}
+	store.Put(tmpl)
+	if err := store.Save(); err != nil {
+		return err
+	}
+
+	if jsonOutput {
+		return printJSON(tmpl)
+	}
+	display.Success(fmt.Sprintf("Template %s saved", name))
+	if vars := service.TemplateVariables(tmpl); len(vars) > 0 {
+		display.Info("Variables:", strings.Join(vars, ", "))
+	}
+	fmt.Printf("\n  %sTip:%s Run it with %shawkeye investigate --template %s%s\n\n",
+		display.Dim, display.Reset, display.Cyan, templateRunExample(tmpl), display.Reset)
+	return nil
+}
+
+func cmdTemplateList() error {
+	store, err := config.LoadTemplates()
+	if err != nil {
+		return err
+	}
+	list := store.List()
+	if jsonOutput {
+		return printJSON(list)
+	}
+
+	display.Header(fmt.Sprintf("Investigation Templates (%d)", len(list)))
+	if len(list) == 0 {
+		display.Warn("No templates yet. Create one with: hawkeye template create <name> --prompt-file <path>")
+		return nil
+	}
+	for _, t := range list {
+		var notes []string
+		if vars := service.TemplateVariables(t); len(vars) > 0 {
+			notes = append(notes, "vars: "+strings.Join(vars, ", "))
+		}
+		if n := len(t.Instructions); n > 0 {
+			notes = append(notes, fmt.Sprintf("%d instruction(s)", n))
+		}
+		if len(t.Telemetry) > 0 {
+			notes = append(notes, "telemetry: "+strings.Join(t.Telemetry, ","))
+		}
+		first, _, _ := strings.Cut(t.Prompt, "\n")
+		fmt.Printf("  %s%-20s%s %s\n", display.Cyan, t.Name, display.Reset, truncate(first, 60))
+		if len(notes) > 0 {
+			fmt.Printf("  %-20s %s%s%s\n", "", display.Dim, strings.Join(notes, " · "), display.Reset)
+		}
+	}
+	fmt.Println()
+	return nil
+}
+
+func cmdTemplateShow(args []string) error {
+	if len(args) == 0 {
+		fmt.Println("Usage: hawkeye template show <name>")
+		return nil
+	}
+	store, err := config.LoadTemplates()
+	if err != nil {
+		return err
+	}
+	t, err := store.Get(args[0])
+	if err != nil {
+		return err
+	}
+	if jsonOutput {
+		return printJSON(t)
+	}
+
+	display.Header("Template: " + t.Name)
+	if vars := service.TemplateVariables(t); len(vars) > 0 {
+		display.Info("Variables:", strings.Join(vars, ", "))
+	}
+	if len(t.Telemetry) > 0 {
+		display.Info("Telemetry:", strings.Join(t.Telemetry, ", "))
+	}
+	if !t.CreatedAt.IsZero() {
+		display.Info("Created:", t.CreatedAt.Local().Format("2006-01-02 15:04"))
+	}
+	fmt.Printf("\n  %sPrompt:%s\n", display.Bold, display.Reset)
+	for _, line := range strings.Split(t.Prompt, "\n") {
+		fmt.Printf("    %s\n", line)
+	}
+	if len(t.Instructions) > 0 {
+		fmt.Printf("\n  %sInstructions:%s\n", display.Bold, display.Reset)
+		for _, in := range t.Instructions {
+			fmt.Printf("    %s%-8s%s %s\n", display.Cyan, in.Type, display.Reset, in.Content)
+		}
+	}
+	fmt.Printf("\n  %sTip:%s Run it with %shawkeye investigate --template %s%s\n\n",
+		display.Dim, display.Reset, display.Cyan, templateRunExample(t), display.Reset)
+	return nil
+}
+
+func cmdTemplateDelete(args []string) error {
+	if len(args) == 0 {
+		fmt.Println("Usage: hawkeye template delete <name>")
+		return nil
+	}
+	store, err := config.LoadTemplates()
+	if err != nil {
+		return err
+	}
+	if !store.Delete(args[0]) {
+		return fmt.Errorf("no template named %q", args[0])
+	}
+	if err := store.Save(); err != nil {
+		return err
+	}
+	if jsonOutput {
+		return printJSON(map[string]string{"deleted": args[0]})
+	}
+	display.Success(fmt.Sprintf("Template %s deleted", args[0]))
+	return nil
+}
+
+// loadTemplateRun loads a saved template and fills in its --var values.
+func loadTemplateRun(name string, varSpecs []string) (service.RenderedTemplate, error) {
+	store, err := config.LoadTemplates()
+	if err != nil {
+		return service.RenderedTemplate{}, err
+	}
+	t, err := store.Get(name)
+	if err != nil {
+		return service.RenderedTemplate{}, err
+	}
+	vars, err := service.ParseTemplateVars(varSpecs)
+	if err != nil {
+		return service.RenderedTemplate{}, err
+	}
+	return service.RenderTemplate(t, vars)
+}
+
+// templateRunExample is the `investigate --template` arguments for a
+// template, with a placeholder --var for each variable.
+func templateRunExample(t config.Template) string {
+	s := t.Name
+	for _, v := range service.TemplateVariables(t) {
+		s += fmt.Sprintf(" --var %s=<value>", v)
+	}
+	return s
+}
+
+// ─── projects ───────────────────────────────────────────────────────────────
+
+func cmdProjects(args []string) error {
+	// Subcommand dispatch
+	if len(args) > 0 {
+		switch args[0] {
+		case "info":
+			return cmdProjectInfo(args[1:])
+		case "create":
+			return cmdProjectCreate(args[1:])
+		case "clone":
+			return cmdProjectClone(args[1:])
+		case "update":
+			return cmdProjectUpdate(args[1:])
+		case "delete":
+			return cmdProjectDelete(args[1:])
+		}
+	}
+
+	cfg, err := config.Load(activeProfile)
+	if err != nil {
+		return err
+	}
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+
+	client := api.NewClient(cfg)
+
+	resp, err := client.ListProjects()
+	if err != nil {
+		return fmt.Errorf("listing projects: %w", err)
+	}
+
+	projects := service.FilterSystemProjects(resp.Specs)
+
+	if jsonOutput {
+		return printJSON(projects)
+	}
+
+	display.Header(fmt.Sprintf("Projects (%d)", len(projects)))
+
+	if len(projects) == 0 {
+		display.Warn("No projects found.")
+		return nil
+	}
+
+	table := newTable(
+		display.Column{Title: "NAME", Max: 40},
+		display.Column{Title: "STATUS"},
+		display.Column{Title: "PROJECT", Keep: true},
+	)
+	for _, p := range projects {
+		ready := display.Green + "ready" + display.Reset
+		if !p.Ready {
+			ready = display.Yellow + "not ready" + display.Reset
+		}
+		name := p.Name
+		if p.UUID == cfg.ProjectID {
+			name += display.Dim + " (active)" + display.Reset
+		}
+		table.Row(name, ready, p.UUID)
+	}
+	table.Print()
+
+	fmt.Println()
+	fmt.Printf("  %sTip:%s Run %shawkeye set project <uuid>%s to select a project.\n\n",
+		display.Dim, display.Reset, display.Cyan, display.Reset)
+
+	return nil
+}
+
+func cmdProjectInfo(args []string) error {
+	if len(args) == 0 {
+		fmt.Println("Usage: hawkeye projects info <uuid>")
+		return nil
+	}
+
+	cfg, err := config.Load(activeProfile)
+	if err != nil {
+		return err
+	}
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+
+	client := api.NewClient(cfg)
+	resp, err := client.GetProject(args[0])
+	if err != nil {
+		return fmt.Errorf("getting project: %w", err)
+	}
+
+	if jsonOutput {
+		return printJSON(resp.Spec)
+	}
+
+	p := service.FormatProjectDetail(resp.Spec)
+	display.Header(fmt.Sprintf("Project: %s", p.Name))
+	display.Info("UUID:", p.UUID)
+	if p.Description != "" {
+		display.Info("Description:", p.Description)
+	}
+	ready := display.Green + "ready" + display.Reset
+	if !p.Ready {
+		ready = display.Yellow + "not ready" + display.Reset
+	}
+	display.Info("Status:", ready)
+	if p.CreateTime != "" {
+		display.Info("Created:", display.FormatTime(p.CreateTime))
+	}
+	if p.UpdateTime != "" {
+		display.Info("Updated:", display.FormatTime(p.UpdateTime))
+	}
+	fmt.Println()
+	return nil
+}
+
+func cmdProjectCreate(args []string) error {
+	if len(args) == 0 {
+		fmt.Println("Usage: hawkeye projects create <name> [--description <text>]")
+		return nil
+	}
+
+	var description string
+	var positional []string
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--description", "-d":
+			if i+1 < len(args) {
+				i++
+				description = args[i]
+			} else {
+				return fmt.Errorf("--description requires a value")
+			}
+		default:
+			positional = append(positional, args[i])
+		}
+	}
+
+	name := strings.Join(positional, " ")
+	if name == "" {
+		fmt.Println("Usage: hawkeye projects create <name> [--description <text>]")
+		return nil
+	}
+
+	cfg, err := config.Load(activeProfile)
+	if err != nil {
+		return err
+	}
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+
+	client := api.NewClient(cfg)
+	resp, err := client.CreateProject(name, description)
+	if err != nil {
+		return fmt.Errorf("creating project: %w", err)
+	}
+
+	if jsonOutput {
+		return printJSON(resp.Spec)
+	}
+
+	if resp.Spec != nil {
+		display.Success(fmt.Sprintf("Project created: %s (%s)", resp.Spec.Name, resp.Spec.UUID))
+	} else {
+		display.Success("Project created")
+	}
+	return nil
+}
+
+// cmdProjectClone creates a project like an existing one, optionally with
+// the same connections and instructions.
+func cmdProjectClone(args []string) error {
+	var source, name, description string
+	var withConns, withInstr, dryRun bool
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--name", "-n":
+			if i+1 < len(args) {
+				i++
+				name = args[i]
+			} else {
+				return fmt.Errorf("--name requires a value")
+			}
+		case "--description", "-d":
+			if i+1 < len(args) {
+				i++
+				description = args[i]
+			} else {
+				return fmt.Errorf("--description requires a value")
+			}
+		case "--with-connections":
+			withConns = true
+		case "--with-instructions":
+			withInstr = true
+		case "--dry-run":
+			dryRun = true
+		default:
+			if strings.HasPrefix(args[i], "-") {
+				return fmt.Errorf("unknown flag for projects clone: %s", args[i])
+			}
+			source = args[i]
+		}
+	}
+	if source == "" || name == "" {
+		fmt.Println("Usage: hawkeye projects clone <uuid|name> --name <new-name> [--with-connections] [--with-instructions] [--dry-run]")
+		return nil
+	}
+
+	cfg, err := config.Load(activeProfile)
+	if err != nil {
+		return err
+	}
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+
+	client := api.NewClient(cfg)
+	projResp, err := client.ListProjects()
+	if err != nil {
+		return fmt.Errorf("listing projects: %w", err)
+	}
+	projects := service.FilterSystemProjects(projResp.Specs)
+	src := service.FindProject(projects, source)
+	if src == nil {
+		return fmt.Errorf("project %q not found", source)
+	}
+	if p := service.FindProject(projects, name); p != nil {
+		return fmt.Errorf("a project named %q already exists (%s)", p.Name, p.UUID)
+	}
+	detail, err := client.GetProject(src.UUID)
+	if err != nil {
+		return fmt.Errorf("getting project: %w", err)
+	}
+	srcDetail := detail.Spec
+	if srcDetail == nil {
+		srcDetail = &api.ProjectDetail{UUID: src.UUID, Name: src.Name}
+	}
+
+	var conns []api.ConnectionSpec
+	if withConns {
+		connResp, err := client.ListProjectConnections(src.UUID)
+		if err != nil {
+			return fmt.Errorf("listing project connections: %w", err)
+		}
+		conns = connResp.Specs
+	}
+	var instructions []api.InstructionSpec
+	if withInstr {
+		instrResp, err := client.ListInstructions(src.UUID)
+		if err != nil {
+			return fmt.Errorf("listing instructions: %w", err)
+		}
+		instructions = instrResp.Instructions
+	}
+
+	plan := service.PlanProjectClone(srcDetail, name, conns, instructions)
+	if description != "" {
+		plan.Description = description
+	}
+
+	if jsonOutput && dryRun {
+		return printJSON(plan)
+	}
+	if !jsonOutput {
+		title := fmt.Sprintf("Clone: %s → %s", srcDetail.Name, plan.Name)
+		if dryRun {
+			title += " (dry run)"
+		}
+		display.Header(title)
+		printProjectPlan(plan)
+	}
+	if dryRun {
+		fmt.Printf("  %sTip:%s Re-run without %s--dry-run%s to create the project.\n\n",
+			display.Dim, display.Reset, display.Cyan, display.Reset)
+		return nil
+	}
+
+	resp, err := client.CreateProject(plan.Name, plan.Description)
+	if err != nil {
+		return fmt.Errorf("creating project: %w", err)
+	}
+	if resp.Spec == nil || resp.Spec.UUID == "" {
+		return fmt.Errorf("creating project: server returned no project UUID")
+	}
+	plan.ProjectUUID = resp.Spec.UUID
+
+	// The project exists from here on, so a failure reports what was
+	// already copied rather than leaving the user to guess.
+	copied := 0
+	for _, c := range plan.AttachConnections {
+		if err := client.AddConnectionToProject(plan.ProjectUUID, c.UUID); err != nil {
+			return fmt.Errorf("project %s created, but attaching connection %q failed after %d of %d: %w",
+				plan.ProjectUUID, c.Name, copied, len(plan.AttachConnections), err)
+		}
+		copied++
+	}
+	if err := applyInstructionChanges(client, plan.ProjectUUID, plan.Instructions); err != nil {
+		return fmt.Errorf("project %s created with its connections, but copying instructions failed: %w", plan.ProjectUUID, err)
+	}
+
+	if jsonOutput {
+		return printJSON(plan)
+	}
+	display.Success(fmt.Sprintf("Project %s cloned from %s (%s)", plan.Name, srcDetail.Name, plan.ProjectUUID))
+	display.Info("Connections:", fmt.Sprintf("%d attached", len(plan.AttachConnections)))
+	display.Info("Instructions:", fmt.Sprintf("%d copied", len(plan.Instructions)))
+	if !withConns || !withInstr {
+		var skipped []string
+		if !withConns {
+			skipped = append(skipped, "--with-connections")
+		}
+		if !withInstr {
+			skipped = append(skipped, "--with-instructions")
+		}
+		fmt.Printf("\n  %sNot copied:%s pass %s to include them.\n", display.Dim, display.Reset, strings.Join(skipped, " and "))
+	}
+	fmt.Printf("\n  %sTip:%s Run %shawkeye set project %s%s to make it the active project.\n\n",
+		display.Dim, display.Reset, display.Cyan, plan.ProjectUUID, display.Reset)
+	return nil
+}
+
+func cmdProjectUpdate(args []string) error {
+	if len(args) == 0 {
+		fmt.Println("Usage: hawkeye projects update <uuid> [--name <name>] [--description <text>]")
+		return nil
+	}
+
+	projectUUID := args[0]
+	var name, description string
+	for i := 1; i < len(args); i++ {
+		switch args[i] {
+		case "--name", "-n":
+			if i+1 < len(args) {
+				i++
+				name = args[i]
+			} else {
+				return fmt.Errorf("--name requires a value")
+			}
+		case "--description", "-d":
+			if i+1 < len(args) {
+				i++
+				description = args[i]
+			} else {
+				return fmt.Errorf("--description requires a value")
+			}
+		}
+	}
+
+	if name == "" && description == "" {
+		fmt.Println("Usage: hawkeye projects update <uuid> [--name <name>] [--description <text>]")
+		return nil
+	}
+
+	cfg, err := config.Load(activeProfile)
+	if err != nil {
+		return err
+	}
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+
+	client := api.NewClient(cfg)
+	resp, err := client.UpdateProject(projectUUID, name, description)
+	if err != nil {
+		return fmt.Errorf("updating project: %w", err)
+	}
+
+	if jsonOutput {
+		return printJSON(resp.Spec)
+	}
+
+	display.Success(fmt.Sprintf("Project %s updated", projectUUID))
+	return nil
+}
+
+func cmdProjectDelete(args []string) error {
+	if len(args) == 0 {
+		fmt.Println("Usage: hawkeye projects delete <uuid> [--confirm]")
+		return nil
+	}
+
+	projectUUID := args[0]
+	confirmed := false
+	for _, a := range args[1:] {
+		if a == "--confirm" || a == "-y" {
+			confirmed = true
+		}
+	}
+
+	cfg, err := config.Load(activeProfile)
+	if err != nil {
+		return err
+	}
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+	if err := requireAdmin(cfg, "projects delete"); err != nil {
+		return err
+	}
+
+	if !confirmed {
+		fmt.Printf("Delete project %s? This cannot be undone. Use --confirm to proceed.\n", projectUUID)
+		return nil
+	}
+
+	client := api.NewClient(cfg)
+	if err := client.DeleteProject(projectUUID); err != nil {
+		return fmt.Errorf("deleting project: %w", err)
+	}
+
+	if jsonOutput {
+		return printJSON(map[string]string{"deleted": projectUUID})
+	}
+
+	display.Success(fmt.Sprintf("Project %s deleted", projectUUID))
+	return nil
+}
+
+// ─── score ──────────────────────────────────────────────────────────────────
+
+func cmdScore(args []string) error {
+	args, projectRef, err := splitProjectFlag(args)
+	if err != nil {
+		return err
+	}
+	var thresholds service.ScoreThresholds
+	var wait bool
+	timeout := summaryWaitTimeout
+	var positional []string
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--min-accuracy", "--min-completeness":
+			flag := args[i]
+			if i+1 >= len(args) {
+				return fmt.Errorf("%s requires a value", flag)
+			}
+			i++
+			v, err := strconv.ParseFloat(args[i], 64)
+			if err != nil || v < 0 || v > 100 {
+				return fmt.Errorf("%s must be a score between 0 and 100", flag)
+			}
+			if flag == "--min-accuracy" {
+				thresholds.MinAccuracy = v
+			} else {
+				thresholds.MinCompleteness = v
+			}
+		case "--wait":
+			wait = true
+		case "--timeout":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--timeout requires a value")
+			}
+			i++
+			d, err := parseWaitTimeout(args[i])
+			if err != nil {
+				return err
+			}
+			timeout, wait = d, true
+		default:
+			positional = append(positional, args[i])
+		}
+	}
+
+	cfg, err := config.Load(activeProfile)
+	if err != nil {
+		return err
+	}
+	if err := useProjectFlag(cfg, projectRef); err != nil {
+		return err
+	}
+	if err := cfg.ValidateProject(); err != nil {
+		return err
+	}
+
+	sessionUUID := ""
+	if len(positional) > 0 {
+		sessionUUID = cfg.ResolveSession(positional[0])
+	} else if cfg.LastSession != "" {
+		sessionUUID = cfg.LastSession
+	} else {
+		fmt.Println("Usage: hawkeye score [session-uuid] [--min-accuracy <n>] [--min-completeness <n>] [--wait [--timeout 10m]]")
+		return nil
+	}
+
+	client := api.NewClient(cfg)
+	fetch := func() (*api.GetSessionSummaryResponse, error) {
+		return client.GetSessionSummary(cfg.ProjectID, sessionUUID)
+	}
+
+	var resp *api.GetSessionSummaryResponse
+	err = inSessionProject(cfg, client, projectRef != "", sessionUUID, func() (err error) {
+		if wait {
+			sp := display.Spin("Waiting for RCA scores...")
+			resp, err = service.WaitForSummary(fetch, service.HasScores, timeout)
+			sp.Stop()
+		} else {
+			resp, err = fetch()
+		}
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("getting summary: %w", err)
+	}
+
+	// The threshold gate is decided up front and returned after the scores
+	// have been rendered, so CI logs show both the numbers and the verdict.
+	var gateErr error
+	failures := thresholds.Check(service.ExtractScores(resp))
+	if len(failures) > 0 {
+		gateErr = fmt.Errorf("RCA score below threshold: %s", strings.Join(failures, ", "))
+	}
+
+	if jsonOutput {
+		if err := printJSON(resp); err != nil {
+			return err
+		}
+		return gateErr
+	}
+
+	scores := service.ExtractScores(resp)
+	if outputFormat == "gha" {
+		return scoreGHA(sessionUUID, scores, gateErr)
+	}
+	if !scores.HasScores {
+		display.Warn("No RCA scores available for this session.")
+		return gateErr
+	}
+
+	display.Header("RCA Quality Scores")
+
+	if scores.ScoredBy != "" {
+		display.Info("Scored by:", scores.ScoredBy)
+	}
+
+	fmt.Println()
+	fmt.Printf("  %s📊 Accuracy:%s     %.1f/100\n", display.Cyan, display.Reset, scores.Accuracy.Score)
+	if scores.Accuracy.Summary != "" {
+		fmt.Printf("     %s%s%s\n", display.Gray, scores.Accuracy.Summary, display.Reset)
+	}
+
+	fmt.Printf("  %s📊 Completeness:%s %.1f/100\n", display.Cyan, display.Reset, scores.Completeness.Score)
+	if scores.Completeness.Summary != "" {
+		fmt.Printf("     %s%s%s\n", display.Gray, scores.Completeness.Summary, display.Reset)
+	}
+
+	if len(scores.Qualitative.Strengths) > 0 {
+		fmt.Printf("\n  %s✅ Strengths:%s\n", display.Green, display.Reset)
+		for _, s := range scores.Qualitative.Strengths {
+			fmt.Printf("    • %s\n", s)
+		}
+	}
+
+	if len(scores.Qualitative.Improvements) > 0 {
+		fmt.Printf("\n  %s💡 Improvements:%s\n", display.Yellow, display.Reset)
+		for _, s := range scores.Qualitative.Improvements {
+			fmt.Printf("    • %s\n", s)
+		}
+	}
+
+	if scores.TimeSaved != nil {
+		fmt.Printf("\n  %s⏱  Time Saved:%s\n", display.Blue, display.Reset)
+		fmt.Printf("    Standard investigation: %.0f min\n", scores.TimeSaved.StandardInvestigationMin)
+		fmt.Printf("    Hawkeye investigation:  %.0f min\n", scores.TimeSaved.HawkeyeInvestigationMin)
+		fmt.Printf("    %sTime saved:%s            %.0f min\n",
+			display.Bold, display.Reset, scores.TimeSaved.TimeSavedMinutes)
+	}
+
+	fmt.Println()
+	if gateErr == nil && (thresholds.MinAccuracy > 0 || thresholds.MinCompleteness > 0) {
+		display.Success("Scores meet the thresholds")
+		fmt.Println()
+	}
+	return gateErr
+}
+
+// scoreGHA reports RCA scores as a GitHub Actions annotation and job
+// summary table. A failed threshold gate becomes an error annotation and
+// is returned.
+func scoreGHA(sessionUUID string, scores service.ScoreDisplay, gateErr error) error {
+	const title = "Hawkeye RCA score"
+	switch {
+	case gateErr != nil:
+		display.GHAError(title, fmt.Sprintf("%v (session %s)", gateErr, sessionUUID))
+	case !scores.HasScores:
+		display.GHAWarning(title, "No RCA scores available for session "+sessionUUID)
+	default:
+		display.GHANotice(title, fmt.Sprintf("Accuracy %.1f/100, completeness %.1f/100 (session %s)",
+			scores.Accuracy.Score, scores.Completeness.Score, sessionUUID))
+	}
+	if err := display.GHASummary(service.ScoresMarkdown(sessionUUID, scores)); err != nil {
+		display.GHAWarning(title, err.Error())
+	}
+	return gateErr
+}
+
+// summaryWaitTimeout is the default bound for summary and score --wait.
+const summaryWaitTimeout = 10 * time.Minute
+
+// parseWaitTimeout parses a --timeout value: a duration such as 90s or
+// 10m, or a bare number of seconds.
+func parseWaitTimeout(v string) (time.Duration, error) {
+	if n, err := strconv.Atoi(v); err == nil && n > 0 {
+		return time.Duration(n) * time.Second, nil
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("--timeout must be a duration like 10m or a number of seconds")
+	}
+	return d, nil
+}
+
+// ─── link ───────────────────────────────────────────────────────────────────
+
+func cmdLink(args []string) error {
+	args, projectRef, err := splitProjectFlag(args)
+	if err != nil {
+		return err
+	}
+	cfg, err := config.Load(activeProfile)
+	if err != nil {
+		return err
+	}
+	if err := useProjectFlag(cfg, projectRef); err != nil {
+		return err
+	}
+	if err := cfg.ValidateProject(); err != nil {
+		return err
+	}
+
+	var copyURL bool
+	var positional []string
+	for _, a := range args {
+		switch a {
+		case "--copy":
+			copyURL = true
+		default:
+			positional = append(positional, a)
+		}
+	}
+
+	sessionUUID := ""
+	if len(positional) > 0 {
+		sessionUUID = cfg.ResolveSession(positional[0])
+	} else if cfg.LastSession != "" {
+		sessionUUID = cfg.LastSession
+	} else {
+		fmt.Println("Usage: hawkeye link [session-uuid] [--copy]")
+		return nil
+	}
+
+	url := service.BuildSessionURL(cfg.Server, cfg.ProjectID, sessionUUID)
+
+	if copyURL {
+		if err := copyToClipboard(url, "session link"); err != nil {
+			return err
+		}
+	}
+
+	if jsonOutput {
+		return printJSON(map[string]string{"url": url})
+	}
+
+	fmt.Println(url)
+	return nil
+}
+
+// ─── share ──────────────────────────────────────────────────────────────────
+
+func cmdShare(args []string) error {
+	args, projectRef, err := splitProjectFlag(args)
+	if err != nil {
+		return err
+	}
+	cfg, err := config.Load(activeProfile)
+	if err != nil {
+		return err
+	}
+	if err := useProjectFlag(cfg, projectRef); err != nil {
+		return err
+	}
+	if err := cfg.ValidateProject(); err != nil {
+		return err
+	}
+
+	expiresIn := service.DefaultShareExpiry
+	copyURL := true
+	var positional []string
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--expires":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--expires requires a value (e.g. 12h, 7d or 2w)")
+			}
+			i++
+			d, err := service.ParseShareExpiry(args[i])
+			if err != nil {
+				return err
+			}
+			expiresIn = d
+		case "--no-copy":
+			copyURL = false
+		default:
+			positional = append(positional, args[i])
+		}
+	}
+
+	sessionUUID := ""
+	if len(positional) > 0 {
+		sessionUUID = cfg.ResolveSession(positional[0])
+	} else if cfg.LastSession != "" {
+		sessionUUID = cfg.LastSession
+	} else {
+		fmt.Println("Usage: hawkeye share [session-uuid] [--expires 7d] [--no-copy]")
+		return nil
+	}
+	noteSession(sessionUUID)
+
+	client := api.NewClient(cfg)
+	shareURL, expiresAt, accountNeeded := "", time.Time{}, false
+	resp, err := client.ShareSession(cfg.ProjectID, sessionUUID, expiresIn)
+	switch {
+	case err == nil:
+		shareURL, expiresAt = resp.URL, time.Now().Add(expiresIn)
+		if t, ok := display.ParseTime(resp.ExpireTime); ok {
+			expiresAt = t
+		}
+	case service.ShareUnsupported(err):
+		// The endpoint 404s on servers without share links, so make sure
+		// it was not the session that is missing before falling back.
+		err = inSessionProject(cfg, client, projectRef != "", sessionUUID, func() error {
+			_, err := client.SessionInspect(cfg.ProjectID, sessionUUID)
+			return err
+		})
+		if err != nil {
+			return fmt.Errorf("getting session: %w", err)
+		}
+		shareURL, accountNeeded = service.ReadOnlyDeepLink(cfg.ConsoleSessionURL(sessionUUID)), true
+	default:
+		return fmt.Errorf("creating share link: %w", err)
+	}
+
+	if copyURL {
+		// A headless machine has no clipboard; the link is printed anyway.
+		if err := copyToClipboard(shareURL, "share link"); err != nil {
+			fmt.Fprintf(os.Stderr, "%s%v%s\n", display.Dim, err, display.Reset)
+		}
+	}
+
+	if jsonOutput {
+		out := map[string]any{"url": shareURL, "session_uuid": sessionUUID, "account_required": accountNeeded}
+		if !expiresAt.IsZero() {
+			out["expires_at"] = expiresAt.UTC().Format(time.RFC3339)
+		}
+		return printJSON(out)
+	}
+
+	if accountNeeded {
+		// Notes go to stderr so stdout is just the link, as for link.
+		fmt.Fprintf(os.Stderr, "%s!%s This server does not issue share links. The link below opens the read-only\n  view, but viewers need a console login and it does not expire.\n",
+			display.Yellow, display.Reset)
+	} else {
+		fmt.Fprintf(os.Stderr, "%sRead-only link, no account needed; expires %s%s\n",
+			display.Dim, display.InZone(expiresAt).Format("Mon Jan 2 15:04"), display.Reset)
+	}
+	fmt.Println(shareURL)
+	return nil
+}
+
+// copyToClipboard puts plain text on the system clipboard. The confirmation
+// goes to stderr so stdout stays clean for pipes.
+func copyToClipboard(text, what string) error {
+	if err := clipboard.WriteAll(text); err != nil {
+		return fmt.Errorf("copying %s to clipboard: %w", what, err)
+	}
+	fmt.Fprintf(os.Stderr, "%s✓ Copied %s to clipboard%s\n", display.Dim, what, display.Reset)
+	return nil
+}
+
+// ─── open / parse ───────────────────────────────────────────────────────────
+
+func parseAndValidateSessionURL(rawURL string) (cfg *config.Config, projectUUID, sessionUUID string, err error) {
+	host, projectUUID, sessionUUID, err := service.ParseSessionURL(rawURL)
+	if err != nil {
+		return nil, "", "", fmt.Errorf("invalid session URL: %w", err)
+	}
+
+	cfg, err = config.Load(activeProfile)
+	if err != nil {
+		return nil, "", "", err
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, "", "", err
+	}
+
+	expectedBase := cfg.FrontendURL
+	if expectedBase == "" {
+		expectedBase = cfg.Server
+	}
+	expectedBase = strings.TrimRight(expectedBase, "/")
+	if idx := strings.Index(expectedBase, "/api"); idx > 0 {
+		expectedBase = expectedBase[:idx]
+	}
+
+	if !strings.EqualFold(host, expectedBase) {
+		profileHint := ""
+		if activeProfile != "" {
+			profileHint = " --profile " + activeProfile
+		}
+		return nil, "", "", fmt.Errorf(
+			"server mismatch: URL points to %s but you're logged into %s\n\n"+
+				"  Try: hawkeye --profile <name> open %s\n"+
+				"  Or:  hawkeye%s login %s -u <username> -p <password>",
+			host, expectedBase, rawURL, profileHint, host)
+	}
+
+	cfg.ProjectID = projectUUID
+	cfg.LastSession = sessionUUID
+	_ = cfg.Save()
+	return cfg, projectUUID, sessionUUID, nil
+}
+
+func cmdOpen(args []string) error {
+	if len(args) == 0 {
+		fmt.Println("Usage: hawkeye open <url>")
+		fmt.Println()
+		fmt.Println("Open a web console session URL in interactive mode.")
+		fmt.Println()
+		fmt.Println("Example:")
+		fmt.Println("  hawkeye open https://myenv.app.neubird.ai/console/project/abc-123/session/def-456?tab=rca")
+		fmt.Println("  hawkeye --profile staging open <url>")
+		return nil
+	}
+
+	_, _, sessionUUID, err := parseAndValidateSessionURL(args[0])
+	if err != nil {
+		return err
+	}
+
+	if jsonOutput {
+		return printJSON(map[string]string{"session_uuid": sessionUUID, "url": args[0]})
+	}
+
+	display.Success(fmt.Sprintf("Opening session: %s", sessionUUID))
+	return tui.Run(version, activeProfile, sessionUUID)
+}
+
+// cmdOpenURL sets the project and session from a console link, as parse
+// does, then inspects the session without starting interactive mode.
+func cmdOpenURL(args []string) error {
+	if len(args) == 0 {
+		fmt.Println("Usage: hawkeye open-url <url>")
+		fmt.Println()
+		fmt.Println("Set the project + session from a web console URL and inspect the session.")
+		fmt.Println("Links copied from Slack (<url|label>) and the console's other URL forms work too.")
+		return nil
+	}
+
+	// A pasted Slack link may arrive split at the spaces in its label.
+	_, _, sessionUUID, err := parseAndValidateSessionURL(strings.Join(args, " "))
+	if err != nil {
+		return err
+	}
+	return cmdInspect([]string{sessionUUID})
+}
+
+func cmdParse(args []string) error {
+	if len(args) == 0 {
+		fmt.Println("Usage: hawkeye parse <url>")
+		fmt.Println()
+		fmt.Println("Parse a web console URL and set the project + session in config.")
+		fmt.Println("Then use 'inspect', 'summary', 'score', etc. on that session.")
+		fmt.Println()
+		fmt.Println("Example:")
+		fmt.Println("  hawkeye parse https://myenv.app.neubird.ai/console/project/abc-123/session/def-456?tab=rca")
+		fmt.Println("  hawkeye inspect   # inspects the parsed session")
+		return nil
+	}
+
+	_, projectUUID, sessionUUID, err := parseAndValidateSessionURL(args[0])
+	if err != nil {
+		return err
+	}
+
+	if jsonOutput {
+		return printJSON(map[string]string{
+			"project_uuid": projectUUID,
+			"session_uuid": sessionUUID,
+			"url":          args[0],
+		})
+	}
+
+	display.Success(fmt.Sprintf("Project: %s", projectUUID))
+	display.Success(fmt.Sprintf("Session: %s", sessionUUID))
+	fmt.Println()
+	fmt.Printf("  %sNext:%s Run %shawkeye inspect%s to view session details.\n\n",
+		display.Dim, display.Reset, display.Cyan, display.Reset)
+	return nil
+}
+
+// ─── report ─────────────────────────────────────────────────────────────────
+
+func cmdReport(args []string) error {
+	if len(args) > 0 {
+		switch args[0] {
+		case "trend":
+			return cmdReportTrend(args[1:])
+		default:
+			return fmt.Errorf("unknown report subcommand: %s (valid: trend)", args[0])
+		}
+	}
+
+	cfg, err := config.Load(activeProfile)
+	if err != nil {
+		return err
+	}
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+
+	client := api.NewClient(cfg)
+
+	resp, err := client.GetIncidentReport()
+	if err != nil {
+		return fmt.Errorf("getting incident report: %w", err)
+	}
+
+	if jsonOutput {
+		return printJSON(resp)
+	}
+
+	report := service.FormatReport(resp)
+
+	display.Header("Incident Analytics Report")
+
+	if report.Period != "" {
+		display.Info("Period:", report.Period)
+	}
+
+	fmt.Println()
+	fmt.Printf("  %s📈 Overview%s\n", display.Cyan, display.Reset)
+	fmt.Printf("    Total incidents:      %d\n", report.TotalIncidents)
+	fmt.Printf("    Total investigations: %d\n", report.TotalInvestigations)
+	fmt.Printf("    Avg time saved:       %s\n", report.AvgTimeSavedMinutes)
+	fmt.Printf("    Avg MTTR:             %s\n", report.AvgMTTR)
+	fmt.Printf("    Noise reduction:      %s\n", report.NoiseReduction)
+	fmt.Printf("    Total time saved:     %s\n", report.TotalTimeSavedHours)
+
+	if len(report.IncidentTypes) > 0 {
+		fmt.Printf("\n  %s📋 By Incident Type%s\n", display.Cyan, display.Reset)
+		for _, it := range report.IncidentTypes {
+			fmt.Printf("    %s%s%s\n", display.Bold, it.Type, display.Reset)
+			for _, pr := range it.Priorities {
+				fmt.Printf("      [%s]  incidents: %-5d  investigated: %-3d  grouped: %-6s  saved: %s\n",
+					pr.Priority, pr.TotalIncidents, pr.Investigated, pr.PercentGrouped, pr.AvgTimeSaved)
+			}
+		}
+	}
+
+	fmt.Println()
+	return nil
+}
+
+// cmdReportTrend shows how a report metric moved over time, one report
+// call per period.
+func cmdReportTrend(args []string) error {
+	window, every, metricName := "12w", "", "mttr"
+	asCSV := false
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--window", "-w":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--window requires a value (e.g. 12w, 90d or 6mo)")
+			}
+			i++
+			window = args[i]
+		case "--every":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--every requires day, week or month")
+			}
+			i++
+			every = args[i]
+		case "--metric", "-m":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--metric requires a value (mttr, time-saved or noise)")
+			}
+			i++
+			metricName = args[i]
+		case "--csv":
+			asCSV = true
+		default:
+			return fmt.Errorf("unknown flag for report trend: %s", args[i])
+		}
+	}
+	metric, err := service.ParseTrendMetric(metricName)
+	if err != nil {
+		return err
+	}
+	periods, err := service.TrendPeriods(window, every, time.Now())
+	if err != nil {
+		return err
+	}
+
+	cfg, err := config.Load(activeProfile)
+	if err != nil {
+		return err
+	}
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+	client := api.NewClient(cfg)
+
+	reports := make([]*api.IncidentReportResponse, len(periods))
+	progress := display.NewProgress()
+	task := progress.Add("Fetching reports")
+	for i, p := range periods {
+		task.SetProgress(i+1, len(periods))
+		resp, err := client.GetIncidentReportRange(p.Start, p.End)
+		if err != nil {
+			progress.Stop()
+			return fmt.Errorf("getting incident report for %s: %w", p.Start.Format("2006-01-02"), err)
+		}
+		reports[i] = resp
+	}
+	progress.Stop()
+
+	points := service.BuildTrend(periods, reports, metric)
+	rangeIgnored := service.ReportRangeIgnored(reports)
+	summary := service.TrendSummary(points, metric)
+	if rangeIgnored {
+		summary = "the server does not report by time range; every period shows the same numbers"
+	}
+
+	if asCSV {
+		return service.WriteTrendCSV(os.Stdout, points, metric)
+	}
+	if jsonOutput {
+		return printJSON(map[string]any{
+			"metric":        metric.Name,
+			"window":        window,
+			"points":        points,
+			"summary":       summary,
+			"range_ignored": rangeIgnored,
+		})
+	}
+
+	display.Header(fmt.Sprintf("Report Trend: %s over %s", metric.Label, window))
+	if rangeIgnored {
+		display.Warn("The server ignored the time ranges and returned the same report for every period; it has no time-bucketed reports yet.")
+		fmt.Println()
+	}
+	fmt.Printf("  %s%s%s\n\n", display.Cyan, display.Sparkline(service.TrendValues(points)), display.Reset)
+
+	table := newTable(
+		display.Column{Title: "PERIOD"},
+		display.Column{Title: strings.ToUpper(metric.Label), Align: display.AlignRight},
+		display.Column{Title: "INCIDENTS", Align: display.AlignRight},
+		display.Column{Title: "INVESTIGATIONS", Align: display.AlignRight},
+	)
+	for _, p := range points {
+		value := display.Dim + "-" + display.Reset
+		if p.HasData {
+			value = service.FormatTrendValue(p.Value, metric)
+		}
+		period := p.Start.Local().Format("2006-01-02") + " → " + p.End.Local().Format("01-02")
+		table.Row(period, value, strconv.Itoa(p.Incidents), strconv.Itoa(p.Investigations))
+	}
+	table.Print()
+	fmt.Println()
+	display.Info("Trend:", summary)
+	fmt.Printf("\n  %sTip:%s Add %s--csv%s to chart it in a spreadsheet.\n\n",
+		display.Dim, display.Reset, display.Cyan, display.Reset)
+	return nil
+}
+
+// ─── digest ─────────────────────────────────────────────────────────────────
+
+// defaultDigestLimit is how many recent sessions a digest reads.
+const defaultDigestLimit = 100
+
+func cmdDigest(args []string) error {
+	sinceArg, format := "24h", ""
+	limit := defaultDigestLimit
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--since":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--since requires a value")
+			}
+			i++
+			sinceArg = args[i]
+		case "--format":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--format requires a value (md or json)")
+			}
+			i++
+			format = args[i]
+		case "-n", "--limit":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--limit requires a value")
+			}
+			i++
+			n, err := strconv.Atoi(args[i])
+			if err != nil || n < 1 {
+				return fmt.Errorf("--limit must be a positive number")
+			}
+			limit = n
+		default:
+			return fmt.Errorf("unknown flag for digest: %s", args[i])
+		}
+	}
+	switch format {
+	case "", "md", "markdown", "json":
+	default:
+		return fmt.Errorf("invalid --format %q (use md or json)", format)
+	}
+	now := time.Now()
+	since, err := service.ParseSince(sinceArg, now)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := config.Load(activeProfile)
+	if err != nil {
+		return err
+	}
+	if err := cfg.ValidateProject(); err != nil {
+		return err
+	}
+	client := api.NewClient(cfg)
+	store, err := config.LoadActions(activeProfile)
+	if err != nil {
+		return err
+	}
+
+	// Sessions are listed without a time filter: an investigation that
+	// finished in the window may belong to an older incident.
+	resp, err := client.SessionList(cfg.ProjectID, 0, limit, nil)
+	if err != nil {
+		return fmt.Errorf("listing sessions: %w", err)
+	}
+	sessions := service.DigestSessions(resp.Sessions, since)
+
+	summaries := map[string]*api.SessionSummary{}
+	var failed int
+	progress := display.NewProgress()
+	task := progress.Add("Reading session summaries")
+	for i, s := range sessions {
+		task.SetProgress(i+1, len(sessions))
+		if !service.InvestigationFinished(s) {
+			continue
+		}
+		summary, err := client.GetSessionSummary(cfg.ProjectID, s.SessionUUID)
+		if err != nil {
+			failed++
+			continue
+		}
+		summaries[s.SessionUUID] = summary.SessionSummary
+	}
+	progress.Stop()
+
+	done := func(key string) bool { return !store.DoneAt(key).IsZero() }
+	digest := service.BuildDigest(sessions, summaries, done, display.InZone(since), display.InZone(now))
+
+	if jsonOutput || format == "json" {
+		return printJSON(digest)
+	}
+	if format == "md" || format == "markdown" {
+		fmt.Print(service.FormatDigestMarkdown(digest, cfg.ConsoleSessionURL))
+		return nil
+	}
+
+	display.Header(fmt.Sprintf("Digest: %s → %s", digest.Since.Format("Jan 2 15:04"), digest.Until.Format("Jan 2 15:04")))
+	if failed > 0 {
+		display.Warn(fmt.Sprintf("%d session summary(ies) could not be fetched and were skipped.", failed))
+	}
+	if len(resp.Sessions) == limit && len(sessions) == limit {
+		display.Warn(fmt.Sprintf("Only the %d most recent sessions were read; raise --limit to cover the whole window.", limit))
+	}
+	display.Info("New incidents:", strconv.Itoa(len(digest.NewIncidents)))
+	display.Info("Completed:", strconv.Itoa(len(digest.Completed)))
+	display.Info("Time saved:", service.FormatTimeSaved(digest.TimeSavedMinutes))
+	display.Info("Open actions:", strconv.Itoa(len(digest.OpenActions)))
+
+	if len(digest.NewIncidents) > 0 {
+		fmt.Printf("\n  %s🚨 New Incidents%s\n", display.Cyan, display.Reset)
+		for _, e := range digest.NewIncidents {
+			fmt.Printf("    • %s  %s\n", truncate(digestName(e), 60), display.InvestigationStatusLabel(e.Status))
+		}
+	}
+	if len(digest.Completed) > 0 {
+		fmt.Printf("\n  %s✓ Investigations Completed%s\n", display.Cyan, display.Reset)
+		for _, e := range digest.Completed {
+			saved := ""
+			if e.TimeSavedMinutes > 0 {
+				saved = fmt.Sprintf("  %ssaved %s%s", display.Dim, service.FormatTimeSaved(e.TimeSavedMinutes), display.Reset)
+			}
+			fmt.Printf("    • %s%s\n", truncate(digestName(e), 60), saved)
+			if e.RootCause != "" {
+				fmt.Printf("      %s→ %s%s\n", display.Dim, truncate(e.RootCause, 100), display.Reset)
+			}
+		}
+	}
+	if len(digest.RootCauses) > 0 {
+		fmt.Printf("\n  %s🎯 Top Root Causes%s\n", display.Cyan, display.Reset)
+		for i, c := range digest.RootCauses {
+			count := ""
+			if len(c.Sessions) > 1 {
+				count = fmt.Sprintf("  %s×%d%s", display.Bold, len(c.Sessions), display.Reset)
+			}
+			fmt.Printf("    %d. %s%s\n", i+1, truncate(c.RootCause, 100), count)
+		}
+	}
+	if len(digest.OpenActions) > 0 {
+		fmt.Printf("\n  %s📋 Open Action Items%s\n", display.Cyan, display.Reset)
+		for _, it := range digest.OpenActions {
+			fmt.Printf("    ☐ %s %s(%s)%s\n", it.Text, display.Dim, truncate(it.SessionName, 40), display.Reset)
+		}
+	}
+	fmt.Printf("\n  %sTip:%s Add %s--format md%s for a version to paste into standup notes.\n\n",
+		display.Dim, display.Reset, display.Cyan, display.Reset)
+	return nil
+}
+
+// digestName names a session in digest output.
+func digestName(e service.DigestEntry) string {
+	if e.Name != "" {
+		return e.Name
+	}
+	return e.SessionUUID
+}
+
+// ─── connections ────────────────────────────────────────────────────────────
+
+func cmdConnections(args []string) error {
+	cfg, err := config.Load(activeProfile)
+	if err != nil {
+		return err
+	}
+
+	// Subcommand dispatch
+	if len(args) > 0 {
+		switch args[0] {
+		case "resources":
+			if err := cfg.ValidateProject(); err != nil {
+				return err
+			}
+			return cmdConnectionResources(cfg, args[1:])
+		case "types":
+			return cmdConnectionTypes()
+		case "info":
+			if err := cfg.Validate(); err != nil {
+				return err
+			}
+			if len(args) < 2 {
+				fmt.Println("Usage: hawkeye connections info <connection-uuid>")
+				return nil
+			}
+			return cmdConnectionInfo(cfg, args[1])
+		case "create":
+			if err := cfg.Validate(); err != nil {
+				return err
+			}
+			if err := requireAdmin(cfg, "connections create"); err != nil {
+				return err
+			}
+			return cmdConnectionCreate(cfg, args[1:])
+		case "sync":
+			if err := cfg.Validate(); err != nil {
+				return err
+			}
+			return cmdConnectionSync(cfg, args[1:])
+		case "rotate-secret":
+			if err := cfg.Validate(); err != nil {
+				return err
+			}
+			if err := requireAdmin(cfg, "connections rotate-secret"); err != nil {
+				return err
+			}
+			return cmdConnectionRotateSecret(cfg, args[1:])
+		case "train":
+			if err := cfg.Validate(); err != nil {
+				return err
+			}
+			return cmdConnectionTrain(cfg, args[1:])
+		case "status":
+			if err := cfg.ValidateProject(); err != nil {
+				return err
+			}
+			return cmdConnectionStatus(cfg, args[1:])
+		case "add":
+			if err := cfg.ValidateProject(); err != nil {
+				return err
+			}
+			return cmdConnectionAdd(cfg, args[1:])
+		case "remove":
+			if err := cfg.ValidateProject(); err != nil {
+				return err
+			}
+			return cmdConnectionRemove(cfg, args[1:])
+		case "project":
+			if err := cfg.ValidateProject(); err != nil {
+				return err
+			}
+			return cmdConnectionProject(cfg, args[1:])
+		case "import":
+			if err := cfg.Validate(); err != nil {
+				return err
+			}
+			return cmdConnectionImport(cfg, args[1:])
+		}
+	}
+
+	if err := cfg.ValidateProject(); err != nil {
+		return err
+	}
+
+	client := api.NewClient(cfg)
+
+	resp, err := client.ListConnections(cfg.ProjectID)
+	if err != nil {
+		return fmt.Errorf("listing connections: %w", err)
+	}
+
+	if jsonOutput {
+		return printJSON(resp.Specs)
+	}
+
+	display.Header(fmt.Sprintf("Connections (%d)", len(resp.Specs)))
+
+	if len(resp.Specs) == 0 {
+		display.Warn("No connections found.")
+		return nil
+	}
+
+	table := newTable(
+		display.Column{Title: "NAME", Max: 40},
+		display.Column{Title: "TYPE"},
+		display.Column{Title: "SYNC"},
+		display.Column{Title: "TRAINING"},
+		display.Column{Title: "CONNECTION", Keep: true},
+		display.Column{Title: "SYNC STATE", Wide: true},
+		display.Column{Title: "TRAINING STATE", Wide: true},
+	)
+	for _, spec := range resp.Specs {
+		c := service.FormatConnection(spec)
+		table.Row(c.Name, c.Type,
+			connectionProgressLabel(service.SyncProgress(c.SyncState), c.SyncState),
+			connectionProgressLabel(service.TrainingProgress(c.TrainingState), c.TrainingState),
+			c.UUID, c.SyncState, c.TrainingState)
+	}
+	fmt.Println()
+	table.Print()
+
+	fmt.Println()
+	fmt.Printf("  %sTip:%s Run %shawkeye connections resources <uuid>%s to list resources.\n\n",
+		display.Dim, display.Reset, display.Cyan, display.Reset)
+
+	return nil
+}
+
+func cmdConnectionTypes() error {
+	types := service.GetConnectionTypes()
+
+	if jsonOutput {
+		return printJSON(types)
+	}
+
+	display.Header(fmt.Sprintf("Supported Connection Types (%d)", len(types)))
+	for _, ct := range types {
+		fmt.Printf("  • %s%-15s%s %s%s%s\n", display.Bold, ct.Type, display.Reset,
+			display.Dim, ct.Description, display.Reset)
+	}
+	fmt.Println()
+	return nil
+}
+
+func cmdConnectionInfo(cfg *config.Config, connUUID string) error {
+	client := api.NewClient(cfg)
+	resp, err := client.GetConnectionInfo(connUUID)
+	if err != nil {
+		return fmt.Errorf("getting connection info: %w", err)
+	}
+
+	if jsonOutput {
+		return printJSON(resp.Spec)
+	}
+
+	c := service.FormatConnectionDetail(resp.Spec)
+	display.Header(fmt.Sprintf("Connection: %s", c.Name))
+	display.Info("UUID:", c.UUID)
+	display.Info("Type:", c.Type)
+	display.Info("Sync:", c.SyncState)
+	display.Info("Training:", c.TrainingState)
+	if c.CreateTime != "" {
+		display.Info("Created:", display.FormatTime(c.CreateTime))
+	}
+	fmt.Println()
+	return nil
+}
+
+func cmdConnectionCreate(cfg *config.Config, args []string) error {
+	if len(args) < 2 {
+		fmt.Println("Usage: hawkeye connections create <type> <name> [--key value ...]")
+		fmt.Println()
+		fmt.Println("Run 'hawkeye connections types' to see supported types.")
+		return nil
+	}
+
+	connType := args[0]
+	connName := args[1]
+	connConfig := make(map[string]string)
+
+	for i := 2; i < len(args); i++ {
+		if strings.HasPrefix(args[i], "--") && i+1 < len(args) {
+			key := strings.TrimPrefix(args[i], "--")
+			i++
+			connConfig[key] = args[i]
+		}
+	}
+
+	client := api.NewClient(cfg)
+	resp, err := client.CreateConnection(connName, connType, connConfig)
+	if err != nil {
+		return fmt.Errorf("creating connection: %w", err)
+	}
+
+	if jsonOutput {
+		return printJSON(resp.Spec)
+	}
+
+	if resp.Spec != nil {
+		display.Success(fmt.Sprintf("Connection created: %s (%s)", resp.Spec.Name, resp.Spec.UUID))
+	} else {
+		display.Success("Connection created")
+	}
+	return nil
+}
+
+// cmdConnectionImport proposes connections found in a Terraform state
+// file or the AWS CLI profiles and creates the ones the user accepts.
+func cmdConnectionImport(cfg *config.Config, args []string) error {
+	var from string
+	var yes, dryRun, addToProject bool
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--from":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--from requires a value (a .tfstate file or aws-profiles)")
+			}
+			i++
+			from = args[i]
+		case "--yes", "-y":
+			yes = true
+		case "--dry-run":
+			dryRun = true
+		case "--add":
+			addToProject = true
+		default:
+			return fmt.Errorf("unknown flag for connections import: %s", args[i])
+		}
+	}
+	if from == "" {
+		fmt.Println("Usage: hawkeye connections import --from <terraform.tfstate|aws-profiles> [--yes] [--dry-run] [--add]")
+		return nil
+	}
+	if addToProject {
+		if err := cfg.ValidateProject(); err != nil {
+			return err
+		}
+	}
+
+	candidates, err := importCandidates(from)
+	if err != nil {
+		return err
+	}
+	if len(candidates) == 0 {
+		if jsonOutput {
+			return printJSON([]service.ConnectionCandidate{})
+		}
+		display.Warn("No candidate connections found in " + from + ".")
+		return nil
+	}
+
+	interactive := term.IsTerminal(int(os.Stdin.Fd())) && !jsonOutput
+	if dryRun || (!interactive && !yes) {
+		if jsonOutput {
+			redacted := make([]service.ConnectionCandidate, len(candidates))
+			for i, c := range candidates {
+				redacted[i] = c.Redacted()
+			}
+			return printJSON(redacted)
+		}
+		display.Header(fmt.Sprintf("Candidate Connections (%d)", len(candidates)))
+		for _, c := range candidates {
+			printImportCandidate(c)
+		}
+		fmt.Println()
+		if !dryRun {
+			fmt.Printf("  %sTip:%s Run in a terminal to choose which to create, or pass %s--yes%s to create the complete ones.\n\n",
+				display.Dim, display.Reset, display.Cyan, display.Reset)
+		}
+		return nil
+	}
+
+	client := api.NewClient(cfg)
+	reader := bufio.NewReader(os.Stdin)
+	var created []api.ConnectionDetail
+	if !jsonOutput {
+		display.Header(fmt.Sprintf("Candidate Connections (%d)", len(candidates)))
+	}
+	for _, c := range candidates {
+		if !jsonOutput {
+			printImportCandidate(c)
+		}
+		if yes {
+			if len(c.Missing) > 0 {
+				if !jsonOutput {
+					display.Warn(fmt.Sprintf("Skipping %s: missing %s", c.Name, strings.Join(c.Missing, ", ")))
+				}
+				continue
+			}
+		} else {
+			answer, err := promptLine(reader, fmt.Sprintf("  Create %s? [y/N/q] ", c.Name))
+			if err != nil {
+				return err
+			}
+			switch strings.ToLower(answer) {
+			case "q", "quit":
+				return nil
+			case "y", "yes":
+			default:
+				continue
+			}
+			if err := fillCandidateFields(reader, &c); err != nil {
+				return err
+			}
+		}
+
+		ct, _ := service.FindConnectionType(c.Type)
+		connConfig, err := service.ValidateConnectionConfig(ct, c.Config)
+		if err != nil {
+			display.Warn(err.Error())
+			continue
+		}
+		resp, err := client.CreateConnection(c.Name, c.Type, connConfig)
+		if err != nil {
+			display.Warn(fmt.Sprintf("Creating %s failed: %v", c.Name, err))
+			continue
+		}
+		if resp.Spec == nil {
+			continue
+		}
+		created = append(created, *resp.Spec)
+		if !jsonOutput {
+			display.Success(fmt.Sprintf("Connection created: %s (%s)", resp.Spec.Name, resp.Spec.UUID))
+		}
+		if addToProject {
+			if err := client.AddConnectionToProject(cfg.ProjectID, resp.Spec.UUID); err != nil {
+				display.Warn(fmt.Sprintf("Adding %s to the project failed: %v", resp.Spec.Name, err))
+			} else if !jsonOutput {
+				display.Success(fmt.Sprintf("Added %s to project %s", resp.Spec.Name, cfg.ProjectID))
+			}
+		}
+	}
+
+	if jsonOutput {
+		return printJSON(created)
+	}
+	fmt.Println()
+	if len(created) > 0 {
+		fmt.Printf("  %sTip:%s Run %shawkeye connections status --watch%s to follow sync and training.\n\n",
+			display.Dim, display.Reset, display.Cyan, display.Reset)
+	}
+	return nil
+}
+
+// importCandidates reads the candidates from a Terraform state file, or
+// from the AWS CLI profiles when from is "aws-profiles".
+func importCandidates(from string) ([]service.ConnectionCandidate, error) {
+	if from != "aws-profiles" {
+		data, err := os.ReadFile(from)
+		if err != nil {
+			return nil, fmt.Errorf("reading Terraform state: %w", err)
+		}
+		return service.CandidatesFromTerraformState(data)
+	}
+
+	home, _ := os.UserHomeDir()
+	read := func(env, name string) string {
+		path := os.Getenv(env)
+		if path == "" {
+			path = filepath.Join(home, ".aws", name)
+		}
+		data, _ := os.ReadFile(path)
+		return string(data)
+	}
+	configFile := read("AWS_CONFIG_FILE", "config")
+	credentialsFile := read("AWS_SHARED_CREDENTIALS_FILE", "credentials")
+	if configFile == "" && credentialsFile == "" {
+		return nil, fmt.Errorf("no AWS CLI config or credentials found in ~/.aws")
+	}
+	return service.CandidatesFromAWSProfiles(configFile, credentialsFile), nil
+}
+
+// printImportCandidate shows a candidate connection with its secrets
+// masked.
+func printImportCandidate(c service.ConnectionCandidate) {
+	c = c.Redacted()
+	fmt.Printf("\n  %s%s%s  %s(%s, from %s)%s\n", display.Bold, c.Name, display.Reset, display.Dim, c.Type, c.Source, display.Reset)
+	for _, d := range c.Details {
+		fmt.Printf("    %s\n", d)
+	}
+	keys := make([]string, 0, len(c.Config))
+	for k := range c.Config {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	for _, k := range keys {
+		fmt.Printf("    %s%s:%s %s\n", display.Dim, k, display.Reset, c.Config[k])
+	}
+	if len(c.Missing) > 0 {
+		fmt.Printf("    %sNeeds:%s %s\n", display.Yellow, display.Reset, strings.Join(c.Missing, ", "))
+	}
+}
+
+// fillCandidateFields prompts for the candidate's missing required fields
+// and lets the name be changed.
+func fillCandidateFields(reader *bufio.Reader, c *service.ConnectionCandidate) error {
+	name, err := promptLine(reader, fmt.Sprintf("    Name [%s]: ", c.Name))
+	if err != nil {
+		return err
+	}
+	if name != "" {
+		c.Name = name
+	}
+	ct, _ := service.FindConnectionType(c.Type)
+	for _, f := range ct.Fields {
+		if f.Optional || strings.TrimSpace(c.Config[f.Key]) != "" {
+			continue
+		}
+		var value string
+		if f.Secret {
+			fmt.Printf("    %s: ", f.Label)
+			b, rerr := term.ReadPassword(int(os.Stdin.Fd()))
+			fmt.Println()
+			value, err = string(b), rerr
+		} else {
+			value, err = promptLine(reader, fmt.Sprintf("    %s: ", f.Label))
+		}
+		if err != nil {
+			return err
+		}
+		c.Config[f.Key] = value
+	}
+	return nil
+}
+
+// promptLine prints prompt and reads one trimmed line from reader.
+func promptLine(reader *bufio.Reader, prompt string) (string, error) {
+	fmt.Print(prompt)
+	line, err := reader.ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", err
+	}
+	return strings.TrimSpace(line), nil
+}
+
+func cmdConnectionSync(cfg *config.Config, args []string) error {
+	if len(args) == 0 {
+		fmt.Println("Usage: hawkeye connections sync <connection-uuid> [--timeout 300]")
+		return nil
+	}
+
+	connUUID := args[0]
+	timeout := 300
+
+	for i := 1; i < len(args); i++ {
+		if args[i] == "--timeout" && i+1 < len(args) {
+			i++
+			n, err := strconv.Atoi(args[i])
+			if err != nil {
+				return fmt.Errorf("invalid timeout: %s", args[i])
+			}
+			timeout = n
+		}
+	}
+
+	sp := display.Spin(fmt.Sprintf("Waiting for connection %s to sync (timeout: %ds)...", connUUID, timeout))
+
+	client := api.NewClient(cfg)
+	resp, err := client.WaitForConnectionSync(connUUID, timeout)
+	sp.Stop()
+
+	if err != nil {
+		return fmt.Errorf("sync: %w", err)
+	}
+
+	if jsonOutput {
+		return printJSON(resp.Spec)
+	}
+
+	display.Success(fmt.Sprintf("Connection %s synced", connUUID))
+	return nil
+}
+
+// cmdConnectionRotateSecret replaces the credentials of a connection in
+// place. Deleting and re-creating the connection would lose its resources
+// and training; updating only the credential keys keeps both.
+func cmdConnectionRotateSecret(cfg *config.Config, args []string) error {
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") {
+		fmt.Println("Usage: hawkeye connections rotate-secret <connection-uuid> --<credential> <value|-> ... [--no-wait] [--timeout 300]")
+		fmt.Println()
+		fmt.Println("Run 'hawkeye connections types' to see each type's fields; pass - to type a value without echo.")
+		return nil
+	}
+
+	connUUID := args[0]
+	wait := true
+	timeout := 300
+	var credArgs []string
+	for i := 1; i < len(args); i++ {
+		switch args[i] {
+		case "--no-wait":
+			wait = false
+		case "--timeout":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--timeout requires a value")
+			}
+			i++
+			n, err := strconv.Atoi(args[i])
+			if err != nil {
+				return fmt.Errorf("invalid timeout: %s", args[i])
+			}
+			timeout = n
+		default:
+			credArgs = append(credArgs, args[i])
+		}
+	}
+
+	client := api.NewClient(cfg)
+	info, err := client.GetConnectionInfo(connUUID)
+	if err != nil {
+		return fmt.Errorf("getting connection info: %w", err)
+	}
+	conn := service.FormatConnectionDetail(info.Spec)
+	ct, ok := service.FindConnectionType(conn.Type)
+	if !ok {
+		return fmt.Errorf("connection %s has type %q, whose credentials are not known", connUUID, conn.Type)
+	}
+	creds, err := service.ParseCredentialFlags(ct, credArgs)
+	if err != nil {
+		return err
+	}
+	if len(creds) == 0 {
+		var flags []string
+		for _, f := range ct.CredentialFields() {
+			flags = append(flags, service.CredentialFlag(f))
+		}
+		return fmt.Errorf("nothing to rotate: pass %s", strings.Join(flags, ", "))
+	}
+
+	var keys []string
+	reader := bufio.NewReader(os.Stdin)
+	for _, f := range ct.CredentialFields() {
+		value, ok := creds[f.Key]
+		if !ok {
+			continue
+		}
+		if value == "-" {
+			if value, err = readSecretValue(reader, f.Label); err != nil {
+				return err
+			}
+		}
+		if value = strings.TrimSpace(value); value == "" {
+			return fmt.Errorf("%s cannot be blank", service.CredentialFlag(f))
+		}
+		creds[f.Key] = value
+		keys = append(keys, f.Key)
+	}
+
+	if _, err := client.UpdateConnectionConfig(connUUID, creds); err != nil {
+		return fmt.Errorf("rotating credentials: %w", err)
+	}
+
+	syncState := ""
+	if wait {
+		sp := display.Spin(fmt.Sprintf("Validating the new credentials of %s (timeout: %ds)...", conn.Name, timeout))
+		resp, err := client.WaitForConnectionSync(connUUID, timeout)
+		sp.Stop()
+		if err != nil {
+			return fmt.Errorf("credentials of %s were replaced, but the connection did not sync: %w", conn.Name, err)
+		}
+		if resp.Spec != nil {
+			syncState = resp.Spec.SyncState
+		}
+	}
+
+	if jsonOutput {
+		return printJSON(map[string]any{"connection_uuid": connUUID, "rotated": keys, "sync_state": syncState})
+	}
+
+	display.Success(fmt.Sprintf("Rotated %s of connection %s", strings.Join(keys, ", "), conn.Name))
+	if wait {
+		display.Info("Sync:", service.ShortState(syncState))
+	} else {
+		fmt.Printf("  %sTip:%s Run %shawkeye connections sync %s%s to check the new credentials.\n\n",
+			display.Dim, display.Reset, display.Cyan, connUUID, display.Reset)
+	}
+	return nil
+}
+
+// readSecretValue reads one credential without echo from the terminal, or
+// as the next line of reader when stdin is piped.
+func readSecretValue(reader *bufio.Reader, label string) (string, error) {
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		line, err := reader.ReadString('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return "", fmt.Errorf("reading %s: %w", label, err)
+		}
+		return strings.TrimSpace(line), nil
+	}
+	fmt.Fprintf(os.Stderr, "  New %s: ", label)
+	b, err := term.ReadPassword(fd)
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return "", fmt.Errorf("reading %s: %w", label, err)
+	}
+	return string(b), nil
+}
+
+func cmdConnectionTrain(cfg *config.Config, args []string) error {
+	if len(args) == 0 {
+		fmt.Println("Usage: hawkeye connections train <connection-uuid> [--wait] [--timeout 600]")
+		return nil
+	}
+
+	connUUID := args[0]
+	wait := false
+	timeout := 600
+
+	for i := 1; i < len(args); i++ {
+		switch args[i] {
+		case "--wait":
+			wait = true
+		case "--timeout":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--timeout requires a value")
+			}
+			i++
+			n, err := strconv.Atoi(args[i])
+			if err != nil {
+				return fmt.Errorf("invalid timeout: %s", args[i])
+			}
+			timeout = n
+		default:
+			return fmt.Errorf("unknown flag: %s", args[i])
+		}
+	}
+
+	client := api.NewClient(cfg)
+	if err := client.TrainConnection(connUUID); err != nil {
+		return fmt.Errorf("triggering training: %w", err)
+	}
+
+	if !wait {
+		if jsonOutput {
+			return printJSON(map[string]any{"connection_uuid": connUUID, "training_triggered": true})
+		}
+		display.Success(fmt.Sprintf("Training triggered for connection %s", connUUID))
+		fmt.Printf("  %sTip:%s Run %shawkeye connections status --watch%s to follow progress.\n\n",
+			display.Dim, display.Reset, display.Cyan, display.Reset)
+		return nil
+	}
+
+	sp := display.Spin(fmt.Sprintf("Waiting for connection %s to train (timeout: %ds)...", connUUID, timeout))
+	resp, err := client.WaitForConnectionTraining(connUUID, timeout)
+	sp.Stop()
+
+	if err != nil {
+		return fmt.Errorf("training: %w", err)
+	}
+
+	if jsonOutput {
+		return printJSON(resp.Spec)
+	}
+
+	display.Success(fmt.Sprintf("Connection %s trained", connUUID))
+	return nil
+}
+
+// connectionStatusInterval is the default refresh period of
+// `connections status --watch`.
+const connectionStatusInterval = 5 * time.Second
+
+func cmdConnectionStatus(cfg *config.Config, args []string) error {
+	watch := false
+	interval := connectionStatusInterval
+
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--watch", "-w":
+			watch = true
+		case "--interval":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--interval requires a value")
+			}
+			i++
+			n, err := strconv.Atoi(args[i])
+			if err != nil || n < 1 {
+				return fmt.Errorf("invalid interval: %s", args[i])
+			}
+			interval = time.Duration(n) * time.Second
+		default:
+			return fmt.Errorf("unknown flag: %s", args[i])
+		}
+	}
+
+	client := api.NewClient(cfg)
+	fetch := func() ([]service.ConnectionDisplay, error) {
+		resp, err := client.ListConnections(cfg.ProjectID)
+		if err != nil {
+			return nil, fmt.Errorf("listing connections: %w", err)
+		}
+		conns := make([]service.ConnectionDisplay, 0, len(resp.Specs))
+		for _, spec := range resp.Specs {
+			conns = append(conns, service.FormatConnection(spec))
+		}
+		return conns, nil
+	}
+
+	conns, err := fetch()
+	if err != nil {
+		return err
+	}
+
+	if jsonOutput {
+		for watch && !service.ConnectionsSettled(conns) {
+			time.Sleep(interval)
+			if conns, err = fetch(); err != nil {
+				return err
+			}
+		}
+		return printJSON(conns)
+	}
+
+	if !watch {
+		fmt.Print(renderConnectionStatus(conns, ""))
+		return nil
+	}
+
+	// Redraw the dashboard in place until nothing is syncing or training.
+	// Accessible mode prints it again, below, only when something changed.
+	drawn, last := 0, ""
+	for {
+		settled := service.ConnectionsSettled(conns)
+		footer := fmt.Sprintf("Refreshing every %s — Ctrl+C to stop", interval)
+		if settled {
+			footer = "All connections settled."
+		}
+		out := renderConnectionStatus(conns, footer)
+		switch {
+		case !display.Accessible():
+			display.ClearLines(drawn)
+			fmt.Print(out)
+		case out != last:
+			fmt.Print(out)
+		}
+		drawn, last = strings.Count(out, "\n"), out
+		if settled {
+			return nil
+		}
+		time.Sleep(interval)
+		if conns, err = fetch(); err != nil {
+			return err
+		}
+	}
+}
+
+// renderConnectionStatus renders the sync and training state of each
+// connection, one line per connection, followed by an optional footer.
+func renderConnectionStatus(conns []service.ConnectionDisplay, footer string) string {
+	var b strings.Builder
+	title := fmt.Sprintf("Connection Status (%d)", len(conns))
+	fmt.Fprintf(&b, "\n%s%s%s\n", display.Bold+display.Cyan, title, display.Reset)
+	fmt.Fprintln(&b, strings.Repeat("─", display.FitWidth(min(len(title)+4, 80))))
+	if len(conns) == 0 {
+		fmt.Fprintf(&b, "%s!%s No connections found.\n", display.Yellow, display.Reset)
+	}
+
+	if len(conns) > 0 {
+		table := newTable(
+			display.Column{Title: "NAME", Max: 30},
+			display.Column{Title: "TYPE"},
+			display.Column{Title: "SYNC"},
+			display.Column{Title: "TRAINING"},
+		)
+		for _, c := range conns {
+			table.Row(c.Name, display.Dim+c.Type+display.Reset,
+				connectionProgressLabel(service.SyncProgress(c.SyncState), c.SyncState),
+				connectionProgressLabel(service.TrainingProgress(c.TrainingState), c.TrainingState))
+		}
+		b.WriteString(table.Render(display.TerminalWidth()))
+	}
+	if footer != "" {
+		fmt.Fprintf(&b, "\n  %s%s%s\n", display.Dim, footer, display.Reset)
+	}
+	return b.String()
+}
+
+func connectionProgressLabel(progress, state string) string {
+	label := service.ShortState(state)
+	switch progress {
+	case service.ProgressDone:
+		return display.Green + "✓ " + label + display.Reset
+	case service.ProgressFailed:
+		return display.Red + "✗ " + label + display.Reset
+	case service.ProgressPending:
+		return display.Yellow + "⟳ " + label + display.Reset
+	}
+	return display.Dim + "· " + label + display.Reset
+}
+
+func cmdConnectionAdd(cfg *config.Config, args []string) error {
+	if len(args) == 0 {
+		fmt.Println("Usage: hawkeye connections add <connection-uuid> [--project <uuid>]")
+		return nil
+	}
+
+	connUUID := args[0]
+	projectUUID := cfg.ProjectID
+
+	for i := 1; i < len(args); i++ {
+		if args[i] == "--project" && i+1 < len(args) {
+			i++
+			projectUUID = args[i]
+		}
+	}
+
+	client := api.NewClient(cfg)
+	if err := client.AddConnectionToProject(projectUUID, connUUID); err != nil {
+		return fmt.Errorf("adding connection to project: %w", err)
+	}
+
+	if jsonOutput {
+		return printJSON(map[string]string{"added": connUUID, "project": projectUUID})
+	}
+
+	display.Success(fmt.Sprintf("Connection %s added to project %s", connUUID, projectUUID))
+	return nil
+}
+
+func cmdConnectionRemove(cfg *config.Config, args []string) error {
+	if len(args) == 0 {
+		fmt.Println("Usage: hawkeye connections remove <connection-uuid> [--project <uuid>] [--confirm]")
+		return nil
+	}
+
+	connUUID := args[0]
+	projectUUID := cfg.ProjectID
+	confirmed := false
+
+	for i := 1; i < len(args); i++ {
+		switch args[i] {
+		case "--project":
+			if i+1 < len(args) {
+				i++
+				projectUUID = args[i]
+			}
+		case "--confirm", "-y":
+			confirmed = true
+		}
+	}
+
+	if !confirmed {
+		fmt.Printf("Remove connection %s from project %s? Use --confirm to proceed.\n", connUUID, projectUUID)
+		return nil
+	}
+
+	client := api.NewClient(cfg)
+	if err := client.RemoveConnectionFromProject(projectUUID, connUUID); err != nil {
+		return fmt.Errorf("removing connection from project: %w", err)
+	}
+
+	if jsonOutput {
+		return printJSON(map[string]string{"removed": connUUID, "project": projectUUID})
+	}
+
+	display.Success(fmt.Sprintf("Connection %s removed from project %s", connUUID, projectUUID))
+	return nil
+}
+
+func cmdConnectionProject(cfg *config.Config, args []string) error {
+	projectUUID := cfg.ProjectID
+
+	for i := 0; i < len(args); i++ {
+		if args[i] == "--project" && i+1 < len(args) {
+			i++
+			projectUUID = args[i]
+		}
+	}
+
+	client := api.NewClient(cfg)
+	resp, err := client.ListProjectConnections(projectUUID)
+	if err != nil {
+		return fmt.Errorf("listing project connections: %w", err)
+	}
+
+	if jsonOutput {
+		return printJSON(resp.Specs)
+	}
+
+	display.Header(fmt.Sprintf("Connections in project %s (%d)", projectUUID, len(resp.Specs)))
+
+	if len(resp.Specs) == 0 {
+		display.Warn("No connections found in this project.")
+		return nil
+	}
+
+	for _, spec := range resp.Specs {
+		c := service.FormatConnection(spec)
+		fmt.Printf("  • %s%-20s%s  %s(%s)%s  %s\n",
+			display.Bold, c.Name, display.Reset,
+			display.Dim, c.Type, display.Reset, c.UUID)
+	}
+	fmt.Println()
+	return nil
+}
+
+func cmdConnectionResources(cfg *config.Config, args []string) error {
+	var connUUID string
+	limit := 100
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--all":
+			limit = 0
+		case "-n", "--limit":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--limit requires a value")
+			}
+			i++
+			n, err := strconv.Atoi(args[i])
+			if err != nil || n < 1 {
+				return fmt.Errorf("invalid limit: %s", args[i])
+			}
+			limit = n
+		default:
+			if strings.HasPrefix(args[i], "-") {
+				return fmt.Errorf("unknown flag for connections resources: %s", args[i])
+			}
+			connUUID = args[i]
+		}
+	}
+	if connUUID == "" {
+		fmt.Println("Usage: hawkeye connections resources <connection-uuid> [--limit <n>|--all]")
+		return nil
+	}
+
+	client := api.NewClient(cfg)
+
+	progress := display.NewProgress()
+	task := progress.Add("Listing resources...")
+	specs, truncated, err := client.ListAllConnectionResources(connUUID, limit, func(read int) {
+		task.SetText(fmt.Sprintf("Listing resources... %d so far", read))
+	})
+	progress.Stop()
+	if err != nil {
+		return fmt.Errorf("listing resources: %w", err)
+	}
+
+	if jsonOutput {
+		return printJSON(specs)
+	}
+
+	resources := service.FormatResources(specs)
+
+	title := fmt.Sprintf("Resources for %s (%d)", connUUID, len(resources))
+	if truncated {
+		title = fmt.Sprintf("Resources for %s (first %d)", connUUID, len(resources))
+	}
+	display.Header(title)
+
+	if len(resources) == 0 {
+		display.Warn("No resources found.")
+		return nil
+	}
+
+	for _, r := range resources {
+		fmt.Printf("  • %s%-30s%s  %s%s%s\n",
+			display.Bold, r.Name, display.Reset,
+			display.Dim, r.TelemetryType, display.Reset)
+	}
+
+	fmt.Println()
+	if truncated {
+		fmt.Printf("  %sTip:%s The connection has more resources; pass %s--all%s or a larger %s--limit%s to list them.\n\n",
+			display.Dim, display.Reset, display.Cyan, display.Reset, display.Cyan, display.Reset)
+	}
+	return nil
+}
+
+// ─── discover ───────────────────────────────────────────────────────────────
+
+func cmdDiscover(args []string) error {
+	cfg, err := config.Load(activeProfile)
+	if err != nil {
+		return err
+	}
+	if err := cfg.ValidateProject(); err != nil {
+		return err
+	}
+
+	var telemetryType, connectionType string
+
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--telemetry-type":
+			if i+1 < len(args) {
+				i++
+				telemetryType = args[i]
+			}
+		case "--connection-type":
+			if i+1 < len(args) {
+				i++
+				connectionType = args[i]
+			}
+		case "--project":
+			if i+1 < len(args) {
+				i++
+				cfg.ProjectID = args[i]
+			}
+		}
+	}
+
+	client := api.NewClient(cfg)
+	sp := display.Spin("Discovering resources...")
+	resp, err := client.DiscoverProjectResources(cfg.ProjectID, telemetryType, connectionType)
+	sp.Stop()
+	if err != nil {
+		return fmt.Errorf("discovering resources: %w", err)
+	}
+
+	if jsonOutput {
+		return printJSON(resp.Resources)
+	}
+
+	resources := service.FormatDiscoveredResources(resp.Resources)
+	display.Header(fmt.Sprintf("Discovered Resources (%d)", len(resources)))
+
+	if len(resources) == 0 {
+		display.Warn("No resources found.")
+		return nil
+	}
+
+	for _, r := range resources {
+		fmt.Printf("  • %s%-30s%s  %s%s%s  %s(%s)%s\n",
+			display.Bold, r.Name, display.Reset,
+			display.Dim, r.TelemetryType, display.Reset,
+			display.Dim, r.ConnectionUUID, display.Reset)
+	}
+
+	fmt.Println()
+	return nil
+}
 
-		// Final Answer
-		if pc.FinalAnswer != "" {
-			fmt.Printf("\n  %s💬 Answer:%s\n", display.Green, display.Reset)
-			for _, line := range strings.Split(api.RenderMarkdown(pc.FinalAnswer), "\n") {
-				fmt.Printf("    %s\n", line)
-			}
+// ─── resource-types ─────────────────────────────────────────────────────────
+
+func cmdResourceTypes(args []string) error {
+	var connectionType, telemetryType string
+	var static bool
+
+	var positional []string
+	for _, a := range args {
+		if a == "--static" {
+			static = true
+			continue
 		}
+		positional = append(positional, a)
+	}
+	if len(positional) >= 1 {
+		connectionType = positional[0]
+	}
+	if len(positional) >= 2 {
+		telemetryType = positional[1]
+	}
 
-		// Follow-ups
-		if len(pc.FollowUpSuggestions) > 0 {
-			fmt.Printf("\n  %s💡 Follow-up suggestions:%s\n", display.Cyan, display.Reset)
-			for j, s := range pc.FollowUpSuggestions {
-				fmt.Printf("    %d. %s\n", j+1, s)
-			}
+	types, source := resourceTypes(connectionType, telemetryType, static)
+
+	if jsonOutput {
+		return printJSON(types)
+	}
+
+	display.Header(fmt.Sprintf("Resource Types (%d)", len(types)))
+	if source != "" {
+		fmt.Printf("  %s%s%s\n\n", display.Dim, source, display.Reset)
+	}
+
+	if len(types) == 0 {
+		display.Warn("No resource types found for the given parameters.")
+		return nil
+	}
+
+	for _, rt := range types {
+		fmt.Printf("  • %s%-25s%s %s%s%s", display.Bold, rt.Type, display.Reset, display.Dim, rt.Description, display.Reset)
+		if len(rt.Capabilities) > 0 {
+			fmt.Printf("  %s[%s]%s", display.Cyan, strings.Join(rt.Capabilities, ", "), display.Reset)
 		}
+		fmt.Println()
 	}
 
 	fmt.Println()
 	return nil
 }
 
-// ─── summary ────────────────────────────────────────────────────────────────
+// resourceTypes returns the resource types the server reports, falling
+// back to the built-in table when not logged in, when static is set or
+// when the server has no resource type endpoint. The second result notes
+// where the built-in table was used and why.
+func resourceTypes(connectionType, telemetryType string, static bool) ([]service.ResourceType, string) {
+	builtin := func(why string) ([]service.ResourceType, string) {
+		return service.GetResourceTypes(connectionType, telemetryType), "Built-in list (" + why + ")"
+	}
+	if static {
+		return builtin("--static")
+	}
+	cfg, err := config.Load(activeProfile)
+	if err != nil || cfg.Validate() != nil {
+		return builtin("not logged in")
+	}
+	resp, err := api.NewClient(cfg).ListResourceTypes(connectionType, telemetryType)
+	if err != nil {
+		return builtin("the server does not list resource types")
+	}
+	return service.ResourceTypesFromServer(resp.ResourceTypes, connectionType, telemetryType), ""
+}
 
-func cmdSummary(args []string) error {
+// ─── session-report ─────────────────────────────────────────────────────────
+
+func cmdSessionReport(args []string) error {
+	args, projectRef, err := splitProjectFlag(args)
+	if err != nil {
+		return err
+	}
 	cfg, err := config.Load(activeProfile)
 	if err != nil {
 		return err
 	}
+	if err := useProjectFlag(cfg, projectRef); err != nil {
+		return err
+	}
 	if err := cfg.ValidateProject(); err != nil {
 		return err
 	}
 
-	sessionUUID := ""
-	if len(args) > 0 {
-		sessionUUID = args[0]
-	} else if cfg.LastSession != "" {
-		sessionUUID = cfg.LastSession
-	} else {
-		fmt.Println("Usage: hawkeye summary [session-uuid]")
-		return nil
+	if len(args) == 0 {
+		if cfg.LastSession != "" {
+			args = []string{cfg.LastSession}
+		} else {
+			fmt.Println("Usage: hawkeye session-report <session-uuid> [<uuid>...]")
+			return nil
+		}
+	}
+	for i, a := range args {
+		args[i] = cfg.ResolveSession(a)
 	}
 
 	client := api.NewClient(cfg)
 
-	resp, err := client.GetSessionSummary(cfg.ProjectID, sessionUUID)
+	var items []api.SessionReportItem
+	err = inSessionProject(cfg, client, projectRef != "", args[0], func() (err error) {
+		items, err = client.GetSessionReport(cfg.ProjectID, args)
+		return err
+	})
 	if err != nil {
-		return fmt.Errorf("getting summary: %w", err)
+		return fmt.Errorf("getting session report: %w", err)
 	}
 
 	if jsonOutput {
-		return printJSON(resp)
-	}
-
-	if resp.SessionInfo != nil {
-		name := resp.SessionInfo.Name
-		if name == "" {
-			name = sessionUUID
-		}
-		display.Header(fmt.Sprintf("Summary: %s", name))
-	} else {
-		display.Header("Session Summary")
-	}
-
-	if resp.SessionSummary == nil {
-		display.Warn("No summary available yet.")
-		return nil
+		return printJSON(items)
 	}
 
-	summary := resp.SessionSummary
+	display.Header(fmt.Sprintf("Session Reports (%d)", len(items)))
 
-	if summary.ShortSummary != nil {
-		if summary.ShortSummary.Question != "" {
-			fmt.Printf("\n  %sQuestion:%s\n", display.Dim, display.Reset)
-			for _, line := range strings.Split(api.RenderMarkdown(summary.ShortSummary.Question), "\n") {
-				fmt.Printf("    %s\n", line)
-			}
-		}
-		if summary.ShortSummary.Analysis != "" {
-			fmt.Printf("\n  %sQuick Analysis:%s\n", display.Dim, display.Reset)
-			for _, line := range strings.Split(api.RenderMarkdown(summary.ShortSummary.Analysis), "\n") {
-				fmt.Printf("    %s\n", line)
-			}
+	for _, item := range items {
+		fmt.Printf("\n  %s%s%s\n", display.Bold, item.Prompt, display.Reset)
+		if item.Summary != "" {
+			fmt.Printf("  %sSummary:%s %s\n", display.Dim, display.Reset, truncate(item.Summary, 120))
 		}
-	}
-
-	if summary.Analysis != "" {
-		fmt.Printf("\n  %s📋 Full Analysis:%s\n", display.Green, display.Reset)
-		for _, line := range strings.Split(api.RenderMarkdown(summary.Analysis), "\n") {
-			fmt.Printf("    %s\n", line)
+		if item.TimeSaved > 0 {
+			fmt.Printf("  %sTime saved:%s %d min\n", display.Blue+display.Bold, display.Reset, item.TimeSaved/60)
 		}
-	}
-
-	if len(summary.ActionItems) > 0 {
-		fmt.Printf("\n  %s🎯 Action Items:%s\n", display.Yellow, display.Reset)
-		for i, item := range summary.ActionItems {
-			fmt.Printf("    %d. %s\n", i+1, item)
+		if item.SessionLink != "" {
+			fmt.Printf("  %sLink:%s %s\n", display.Dim, display.Reset, item.SessionLink)
 		}
 	}
 
@@ -835,29 +7787,86 @@ func cmdSummary(args []string) error {
 	return nil
 }
 
-// ─── feedback ───────────────────────────────────────────────────────────────
+// ─── investigate-alert ──────────────────────────────────────────────────────
 
-func cmdFeedback(args []string) error {
-	var reason string
-	var debugMode bool
+func cmdInvestigateAlert(args []string) error {
+	var projectUUID, fromFile, payloadPath, lang string
+	var allOpen bool
+	limit := 10
+	concurrency := 3
 	var positional []string
 
 	for i := 0; i < len(args); i++ {
 		switch args[i] {
-		case "-r", "--reason":
+		case "--project":
 			if i+1 < len(args) {
 				i++
-				reason = args[i]
+				projectUUID = args[i]
 			} else {
-				return fmt.Errorf("--reason requires a value")
+				return fmt.Errorf("--project requires a value")
+			}
+		case "--from-file":
+			if i+1 < len(args) {
+				i++
+				fromFile = args[i]
+			} else {
+				return fmt.Errorf("--from-file requires a value")
+			}
+		case "--alertmanager-payload":
+			if i+1 < len(args) {
+				i++
+				payloadPath = args[i]
+			} else {
+				return fmt.Errorf("--alertmanager-payload requires a value")
+			}
+		case "--all-open":
+			allOpen = true
+		case "-n", "--limit":
+			if i+1 < len(args) {
+				i++
+				n, err := strconv.Atoi(args[i])
+				if err != nil || n <= 0 {
+					return fmt.Errorf("invalid limit: %s", args[i])
+				}
+				limit = n
+			} else {
+				return fmt.Errorf("--limit requires a value")
+			}
+		case "--concurrency":
+			if i+1 < len(args) {
+				i++
+				n, err := strconv.Atoi(args[i])
+				if err != nil || n <= 0 {
+					return fmt.Errorf("invalid concurrency: %s", args[i])
+				}
+				concurrency = n
+			} else {
+				return fmt.Errorf("--concurrency requires a value")
+			}
+		case "--lang":
+			if i+1 < len(args) {
+				i++
+				lang = args[i]
+			} else {
+				return fmt.Errorf("--lang requires a value")
 			}
-		case "--debug":
-			debugMode = true
 		default:
 			positional = append(positional, args[i])
 		}
 	}
 
+	if len(positional) == 0 && fromFile == "" && !allOpen && payloadPath == "" {
+		fmt.Println("Usage: hawkeye investigate-alert <alert-id> [--project <uuid>]")
+		fmt.Println("       hawkeye investigate-alert --alertmanager-payload <file|->")
+		fmt.Println("       hawkeye investigate-alert --from-file <path|-> [--concurrency <n>]")
+		fmt.Println("       hawkeye investigate-alert --all-open [--limit <n>] [--concurrency <n>]")
+		return nil
+	}
+	lang, err := service.NormalizeLanguage(lang)
+	if err != nil {
+		return err
+	}
+
 	cfg, err := config.Load(activeProfile)
 	if err != nil {
 		return err
@@ -865,346 +7874,1065 @@ func cmdFeedback(args []string) error {
 	if err := cfg.ValidateProject(); err != nil {
 		return err
 	}
-
-	sessionUUID := ""
-	if len(positional) > 0 {
-		sessionUUID = positional[0]
-	} else if cfg.LastSession != "" {
-		sessionUUID = cfg.LastSession
-	} else {
-		fmt.Println("Usage: hawkeye feedback|td [session-uuid] [-r reason]")
-		return nil
+	if projectUUID == "" {
+		projectUUID = cfg.ProjectID
 	}
 
 	client := api.NewClient(cfg)
-	client.SetDebug(debugMode)
+	if lang != "" {
+		client.SetLanguage(lang)
+	}
 
-	resp, err := client.SessionInspect(cfg.ProjectID, sessionUUID)
+	if payloadPath != "" {
+		return investigateAlertmanagerPayload(cfg, client, projectUUID, payloadPath)
+	}
+	if fromFile != "" || allOpen {
+		return runBulkInvestigation(cfg, client, projectUUID, positional, fromFile, allOpen, limit, concurrency)
+	}
+
+	alertID := positional[0]
+
+	fmt.Println()
+	sp := display.Spin("Creating session from alert...")
+	sessResp, err := client.CreateSessionFromAlert(projectUUID, alertID)
+	sp.Stop()
 	if err != nil {
-		return fmt.Errorf("inspecting session: %w", err)
+		return fmt.Errorf("creating session from alert: %w", err)
 	}
 
-	if len(resp.PromptCycle) == 0 {
-		return fmt.Errorf("no prompt cycles found in session %s", sessionUUID)
+	sessionUUID := sessResp.SessionUUID
+	display.Success(fmt.Sprintf("Session created from alert: %s", sessionUUID))
+
+	cfg.LastSession = sessionUUID
+	_ = cfg.Save()
+
+	// Auto-send a prompt to start the investigation
+	prompt := fmt.Sprintf("Investigate alert %s", alertID)
+	streamDisplay := api.NewStreamDisplay(false)
+	var failures service.FailureCollector
+	err = client.ProcessPromptStream(projectUUID, sessionUUID, prompt, func(resp *api.ProcessPromptResponse) {
+		failures.Handle(resp)
+		streamDisplay.HandleEvent(resp)
+	})
+
+	fmt.Println()
+	if err != nil {
+		return fmt.Errorf("stream error: %w", err)
 	}
+	if f := failures.Failure(); f != nil {
+		return reportInvestigationFailure(sessionUUID, f)
+	}
+
+	display.Success("Investigation complete")
+	return nil
+}
 
-	last := resp.PromptCycle[len(resp.PromptCycle)-1]
-	items := []api.RatingItemID{{ItemType: "ITEM_TYPE_PROMPT_CYCLE", ItemID: last.ID}}
+// investigateAlertmanagerPayload investigates the firing alerts of an
+// Alertmanager webhook notification in one new session. Alertmanager alerts
+// have no backend alert ID, so the prompt carries their labels and
+// annotations instead.
+func investigateAlertmanagerPayload(cfg *config.Config, client *api.Client, projectUUID, path string) error {
+	data, err := readInputFile(path)
+	if err != nil {
+		return fmt.Errorf("reading Alertmanager payload: %w", err)
+	}
+	payload, err := service.ParseAlertmanagerPayload(data)
+	if err != nil {
+		return err
+	}
+	firing := payload.Firing()
+	if len(firing) == 0 {
+		display.Warn("No firing alerts in the payload.")
+		return nil
+	}
+	prompt := service.AlertmanagerPrompt(firing)
 
-	if reason == "" {
-		reason = "Thumbs down from CLI"
+	fmt.Println()
+	sp := display.Spin("Creating session...")
+	sessResp, err := client.NewSession(projectUUID)
+	sp.Stop()
+	if err != nil {
+		return fmt.Errorf("creating session: %w", err)
+	}
+	sessionUUID := sessResp.SessionUUID
+	if err := client.RenameSession(projectUUID, sessionUUID, service.SessionTitle(prompt)); err != nil {
+		display.Warn(fmt.Sprintf("Could not name session: %v", err))
 	}
+	display.Success(fmt.Sprintf("Session created for %d firing alert(s): %s", len(firing), sessionUUID))
 
-	if err := client.PutRating(cfg.ProjectID, sessionUUID, items, "RATING_THUMBS_DOWN", reason); err != nil {
-		return fmt.Errorf("submitting feedback: %w", err)
+	cfg.LastSession = sessionUUID
+	_ = cfg.Save()
+	recordHistory(prompt, sessionUUID)
+
+	fmt.Println()
+	for _, line := range strings.Split(prompt, "\n") {
+		fmt.Printf("  %s%s%s\n", display.Dim, line, display.Reset)
+	}
+	fmt.Println()
+
+	streamDisplay := api.NewStreamDisplay(false)
+	var failures service.FailureCollector
+	err = client.ProcessPromptStream(projectUUID, sessionUUID, prompt, func(resp *api.ProcessPromptResponse) {
+		failures.Handle(resp)
+		streamDisplay.HandleEvent(resp)
+	})
+
+	fmt.Println()
+	if err != nil {
+		return fmt.Errorf("stream error: %w", err)
+	}
+	if f := failures.Failure(); f != nil {
+		return reportInvestigationFailure(sessionUUID, f)
 	}
 
-	display.Success(fmt.Sprintf("Thumbs down submitted for session %s", sessionUUID))
+	display.Success("Investigation complete")
 	return nil
 }
 
-// ─── prompts ────────────────────────────────────────────────────────────────
+// bulkJob is one alert queued for a bulk investigation. When sessionUUID is
+// already known (an open incident session), no new session is created.
+type bulkJob struct {
+	alertID     string
+	sessionUUID string
+}
 
-func cmdPrompts() error {
-	cfg, err := config.Load(activeProfile)
-	if err != nil {
-		return err
+// runBulkInvestigation investigates several alerts with bounded concurrency
+// and prints a roll-up table once all of them have finished.
+func runBulkInvestigation(cfg *config.Config, client *api.Client, projectUUID string, ids []string, fromFile string, allOpen bool, limit, concurrency int) error {
+	var jobs []bulkJob
+	for _, id := range ids {
+		jobs = append(jobs, bulkJob{alertID: id})
 	}
-	if err := cfg.ValidateProject(); err != nil {
-		return err
+
+	if fromFile != "" {
+		data, err := readInputFile(fromFile)
+		if err != nil {
+			return fmt.Errorf("reading alert list: %w", err)
+		}
+		for _, id := range service.ParseAlertList(string(data)) {
+			jobs = append(jobs, bulkJob{alertID: id})
+		}
 	}
 
-	client := api.NewClient(cfg)
+	if allOpen {
+		filters := service.BuildSessionFilters("", "", "", "", true)
+		resp, err := client.SessionList(projectUUID, 0, limit, filters)
+		if err != nil {
+			return fmt.Errorf("listing open alerts: %w", err)
+		}
+		for _, s := range resp.Sessions {
+			if s.SessionType != "" && s.SessionType != "SESSION_TYPE_INCIDENT" {
+				continue
+			}
+			label := s.Name
+			if label == "" {
+				label = s.SessionUUID
+			}
+			jobs = append(jobs, bulkJob{alertID: label, sessionUUID: s.SessionUUID})
+		}
+	}
 
-	resp, err := client.PromptLibrary(cfg.ProjectID)
-	if err != nil {
-		return fmt.Errorf("getting prompt library: %w", err)
+	if len(jobs) == 0 {
+		display.Warn("No alerts to investigate.")
+		return nil
 	}
 
-	if jsonOutput {
-		return printJSON(resp)
+	if !jsonOutput {
+		fmt.Println()
+		display.Info("Alerts:", strconv.Itoa(len(jobs)))
+		display.Info("Concurrency:", strconv.Itoa(concurrency))
+		fmt.Println()
 	}
 
-	display.Header(fmt.Sprintf("Prompt Library (%d prompts)", len(resp.Items)))
+	results := make([]service.BulkResult, len(jobs))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	progress := display.NewProgress()
 
-	if len(resp.Items) == 0 {
-		display.Warn("No prompts found in the library.")
-		return nil
+	for i, job := range jobs {
+		wg.Add(1)
+		go func(i int, job bulkJob) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			task := progress.Add(fmt.Sprintf("Investigating %s...", job.alertID))
+			res := investigateBulkJob(client, projectUUID, job)
+			results[i] = res
+			task.Done()
+
+			if jsonOutput {
+				return
+			}
+			if res.Status == service.BulkStatusCompleted {
+				progress.Printf("  %s✓%s %s %s→ %s%s\n", display.Green, display.Reset, res.AlertID, display.Dim, res.SessionUUID, display.Reset)
+			} else {
+				progress.Printf("  %s✗%s %s %s→ %s%s\n", display.Red, display.Reset, res.AlertID, display.Dim, res.Error, display.Reset)
+			}
+		}(i, job)
 	}
+	wg.Wait()
+	progress.Stop()
 
-	for i, p := range resp.Items {
-		label := p.Oneliner
-		if label == "" {
-			label = truncate(p.Prompt, 80)
+	// Remember the last successfully created session, like a single run would.
+	for i := len(results) - 1; i >= 0; i-- {
+		if results[i].SessionUUID != "" {
+			cfg.LastSession = results[i].SessionUUID
+			_ = cfg.Save()
+			break
 		}
-		fmt.Printf("  %s%d.%s %s\n", display.Cyan, i+1, display.Reset, label)
-		if p.Oneliner != "" && p.Prompt != "" && p.Prompt != p.Oneliner {
-			fmt.Printf("     %s%s%s\n", display.Gray, truncate(p.Prompt, 90), display.Reset)
+	}
+
+	if jsonOutput {
+		return printJSON(results)
+	}
+
+	completed, failed := service.CountBulkResults(results)
+	display.Header(fmt.Sprintf("Bulk Investigation (%d completed, %d failed)", completed, failed))
+	fmt.Printf("  %s%-24s  %-36s  %-10s  %s%s\n", display.Bold, "ALERT", "SESSION", "STATUS", "SUMMARY", display.Reset)
+	for _, r := range results {
+		status := display.Green + fmt.Sprintf("%-10s", r.Status) + display.Reset
+		detail := r.Summary
+		if r.Status != service.BulkStatusCompleted {
+			status = display.Red + fmt.Sprintf("%-10s", r.Status) + display.Reset
+			detail = r.Error
 		}
+		sess := r.SessionUUID
+		if sess == "" {
+			sess = "-"
+		}
+		fmt.Printf("  %-24s  %-36s  %s  %s\n", truncate(r.AlertID, 24), sess, status, detail)
 	}
 
-	fmt.Printf("\n  %sTip:%s Copy a prompt and run %shawkeye investigate \"<prompt>\"%s\n\n",
+	fmt.Println()
+	fmt.Printf("  %sTip:%s Run %shawkeye inspect <session-uuid>%s to review a session.\n\n",
 		display.Dim, display.Reset, display.Cyan, display.Reset)
 
+	if failed > 0 {
+		return fmt.Errorf("%d of %d investigations failed", failed, len(results))
+	}
 	return nil
 }
 
-// ─── projects ───────────────────────────────────────────────────────────────
+// investigateBulkJob creates (if needed) and runs one investigation quietly,
+// returning its roll-up row.
+func investigateBulkJob(client *api.Client, projectUUID string, job bulkJob) service.BulkResult {
+	res := service.BulkResult{AlertID: job.alertID, SessionUUID: job.sessionUUID}
 
-func cmdProjects(args []string) error {
-	// Subcommand dispatch
-	if len(args) > 0 {
-		switch args[0] {
-		case "info":
-			return cmdProjectInfo(args[1:])
-		case "create":
-			return cmdProjectCreate(args[1:])
-		case "update":
-			return cmdProjectUpdate(args[1:])
-		case "delete":
-			return cmdProjectDelete(args[1:])
+	prompt := fmt.Sprintf("Investigate alert %s", job.alertID)
+	if res.SessionUUID == "" {
+		sessResp, err := client.CreateSessionFromAlert(projectUUID, job.alertID)
+		if err != nil {
+			res.Status = service.BulkStatusFailed
+			res.Error = fmt.Sprintf("creating session: %v", err)
+			return res
 		}
+		res.SessionUUID = sessResp.SessionUUID
 	}
 
-	cfg, err := config.Load(activeProfile)
+	var collector service.AnswerCollector
+	var failures service.FailureCollector
+	err := client.ProcessPromptStream(projectUUID, res.SessionUUID, prompt, func(resp *api.ProcessPromptResponse) {
+		collector.Handle(resp)
+		failures.Handle(resp)
+	})
 	if err != nil {
-		return err
+		res.Status = service.BulkStatusFailed
+		res.Error = fmt.Sprintf("stream error: %v", err)
+		return res
 	}
-	if err := cfg.Validate(); err != nil {
-		return err
-	}
-
-	client := api.NewClient(cfg)
-
-	resp, err := client.ListProjects()
-	if err != nil {
-		return fmt.Errorf("listing projects: %w", err)
+	if f := failures.Failure(); f != nil {
+		res.Status = service.BulkStatusFailed
+		res.Error = "investigation failed: " + f.Reason
+		return res
 	}
 
-	projects := service.FilterSystemProjects(resp.Specs)
+	res.Status = service.BulkStatusCompleted
+	res.Summary = service.ShortSummary(collector.Answer(), 60)
+	return res
+}
 
-	if jsonOutput {
-		return printJSON(projects)
-	}
+// ─── watch ──────────────────────────────────────────────────────────────────
 
-	display.Header(fmt.Sprintf("Projects (%d)", len(projects)))
+// watchSessionLimit is how many not-started sessions a poll lists.
+const watchSessionLimit = 100
 
-	if len(projects) == 0 {
-		display.Warn("No projects found.")
-		return nil
+func cmdWatch(args []string) error {
+	if len(args) > 0 && args[0] == "queue" {
+		return cmdWatchQueue(args[1:])
 	}
 
-	for _, p := range projects {
-		ready := display.Green + "ready" + display.Reset
-		if !p.Ready {
-			ready = display.Yellow + "not ready" + display.Reset
+	interval := time.Minute
+	maxConcurrent := 2
+	hours := service.AnyTime
+	var order []string
+	var auto bool
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--auto-investigate":
+			auto = true
+		case "--interval":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--interval requires a value")
+			}
+			i++
+			d, err := time.ParseDuration(args[i])
+			if err != nil || d < 10*time.Second {
+				return fmt.Errorf("--interval must be a duration of at least 10s, e.g. 1m")
+			}
+			interval = d
+		case "--max-concurrent":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--max-concurrent requires a value")
+			}
+			i++
+			n, err := strconv.Atoi(args[i])
+			if err != nil || n < 1 {
+				return fmt.Errorf("--max-concurrent must be a positive number")
+			}
+			maxConcurrent = n
+		case "--priority-order":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--priority-order requires a value")
+			}
+			i++
+			o, err := service.ParsePriorityOrder(args[i])
+			if err != nil {
+				return err
+			}
+			order = o
+		case "--business-hours":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--business-hours requires a value")
+			}
+			i++
+			h, err := service.ParseBusinessHours(args[i])
+			if err != nil {
+				return err
+			}
+			hours = h
+		default:
+			return fmt.Errorf("unknown flag %q (see hawkeye help watch)", args[i])
 		}
-		fmt.Printf("  ⏺ %s%-20s%s %s%s%s  %s[%s]%s\n", display.Bold, p.Name, display.Reset, display.Dim, p.UUID, display.Reset, display.Dim, ready, display.Reset)
 	}
-
-	fmt.Println()
-	fmt.Printf("  %sTip:%s Run %shawkeye set project <uuid>%s to select a project.\n\n",
-		display.Dim, display.Reset, display.Cyan, display.Reset)
-
-	return nil
-}
-
-func cmdProjectInfo(args []string) error {
-	if len(args) == 0 {
-		fmt.Println("Usage: hawkeye projects info <uuid>")
-		return nil
+	if jsonOutput {
+		return fmt.Errorf("--json is not supported by watch (try: hawkeye watch queue --json)")
 	}
 
 	cfg, err := config.Load(activeProfile)
 	if err != nil {
 		return err
 	}
-	if err := cfg.Validate(); err != nil {
+	if err := cfg.ValidateProject(); err != nil {
 		return err
 	}
-
 	client := api.NewClient(cfg)
-	resp, err := client.GetProject(args[0])
-	if err != nil {
-		return fmt.Errorf("getting project: %w", err)
-	}
+	projectUUID := cfg.ProjectID
 
-	if jsonOutput {
-		return printJSON(resp.Spec)
+	// Without --auto-investigate nothing is started, so the queue is only
+	// kept in memory and new incidents are just reported. With it, this
+	// watch owns the persisted queue until it stops: entries still running
+	// belonged to a watch that is gone.
+	queue := &config.WatchQueue{}
+	if auto {
+		lock, err := config.LockWatchQueue(activeProfile)
+		if err != nil {
+			return err
+		}
+		defer lock.Unlock()
+		if queue, err = config.LoadWatchQueue(activeProfile); err != nil {
+			return err
+		}
+		queue.Interrupt()
+		if err := queue.Save(); err != nil {
+			return err
+		}
 	}
 
-	p := service.FormatProjectDetail(resp.Spec)
-	display.Header(fmt.Sprintf("Project: %s", p.Name))
-	display.Info("UUID:", p.UUID)
-	if p.Description != "" {
-		display.Info("Description:", p.Description)
+	fmt.Println()
+	if auto {
+		display.Success("Watching for uninvestigated incidents, investigating them as they arrive")
+		display.Info("Max concurrent:", strconv.Itoa(maxConcurrent))
+		if len(order) > 0 {
+			display.Info("Priority order:", strings.Join(order, ", ")+", then the rest")
+		}
+		display.Info("Business hours:", hours.String())
+		if n := len(queue.Queued(projectUUID)); n > 0 {
+			display.Info("Queued:", fmt.Sprintf("%d from the last watch", n))
+		}
+	} else {
+		display.Success("Watching for uninvestigated incidents")
 	}
-	ready := display.Green + "ready" + display.Reset
-	if !p.Ready {
-		ready = display.Yellow + "not ready" + display.Reset
+	display.Info("Project:", projectUUID)
+	display.Info("Interval:", interval.String())
+	fmt.Printf("  %sCtrl+C to stop.%s\n\n", display.Dim, display.Reset)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	var mu sync.Mutex // guards queue and running
+	var wg sync.WaitGroup
+	running := 0
+	finished := make(chan struct{}, 1)
+	stamp := func() string {
+		return display.Dim + display.InZone(time.Now()).Format("15:04") + display.Reset
 	}
-	display.Info("Status:", ready)
-	if p.CreateTime != "" {
-		display.Info("Created:", p.CreateTime)
+	waitingSince := time.Time{}
+
+	for {
+		resp, err := client.SessionList(projectUUID, 0, watchSessionLimit, service.BuildSessionFilters("", "", "", "", true))
+		if err != nil {
+			fmt.Printf("  %s %s!%s listing incidents: %v\n", stamp(), display.Yellow, display.Reset, err)
+		} else {
+			incidents := service.UninvestigatedIncidents(resp.Sessions)
+			mu.Lock()
+			open := map[string]bool{}
+			for _, s := range incidents {
+				open[s.SessionUUID] = true
+				item := config.WatchItem{
+					SessionUUID: s.SessionUUID,
+					ProjectUUID: projectUUID,
+					Name:        s.Name,
+					Priority:    service.IncidentPriority(s.Name),
+					CreateTime:  s.CreateTime,
+					QueuedAt:    time.Now(),
+				}
+				if queue.Add(item) {
+					fmt.Printf("  %s %s●%s %s%s\n", stamp(), display.Cyan, display.Reset, watchLabel(&item), priorityTag(item.Priority))
+				}
+			}
+			// A queued incident no longer listed was started elsewhere.
+			// Only a complete listing can tell.
+			if len(resp.Sessions) < watchSessionLimit {
+				for _, it := range queue.Queued(projectUUID) {
+					if !open[it.SessionUUID] {
+						it.Status, it.FinishedAt = config.WatchSkipped, time.Now()
+						fmt.Printf("  %s %s⊘%s %s %s(investigated elsewhere)%s\n", stamp(), display.Dim, display.Reset, watchLabel(it), display.Dim, display.Reset)
+					}
+				}
+			}
+
+			if auto {
+				now := display.InZone(time.Now())
+				queued := queue.Queued(projectUUID)
+				switch {
+				case len(queued) == 0:
+					waitingSince = time.Time{}
+				case !hours.Open(now):
+					if waitingSince.IsZero() {
+						fmt.Printf("  %s %s%d queued; outside business hours until %s%s\n", stamp(), display.Dim, len(queued),
+							hours.NextOpen(now).Format("Mon 15:04"), display.Reset)
+						waitingSince = now
+					}
+				default:
+					waitingSince = time.Time{}
+					candidates := make([]service.WatchCandidate, len(queued))
+					byUUID := map[string]*config.WatchItem{}
+					for i, it := range queued {
+						candidates[i] = service.WatchCandidate{SessionUUID: it.SessionUUID, Priority: it.Priority, CreateTime: it.CreateTime}
+						byUUID[it.SessionUUID] = it
+					}
+					service.OrderWatchQueue(candidates, order)
+					for _, c := range candidates {
+						if running >= maxConcurrent {
+							break
+						}
+						it := byUUID[c.SessionUUID]
+						it.Status, it.StartedAt = config.WatchRunning, time.Now()
+						running++
+						fmt.Printf("  %s %s⟳%s Investigating %s%s\n", stamp(), display.Yellow, display.Reset, watchLabel(it), priorityTag(it.Priority))
+						wg.Add(1)
+						go func(it *config.WatchItem) {
+							defer wg.Done()
+							res := investigateBulkJob(client, projectUUID, bulkJob{alertID: watchLabel(it), sessionUUID: it.SessionUUID})
+							mu.Lock()
+							defer mu.Unlock()
+							finishWatchItem(cfg, it, res, stamp())
+							running--
+							if err := queue.Save(); err != nil {
+								display.Warn(fmt.Sprintf("Saving the watch queue: %v", err))
+							}
+							select {
+							case finished <- struct{}{}:
+							default: // a wake-up is already pending
+							}
+						}(it)
+					}
+				}
+				if err := queue.Save(); err != nil {
+					display.Warn(fmt.Sprintf("Saving the watch queue: %v", err))
+				}
+			}
+			mu.Unlock()
+		}
+
+		// Poll again after the interval, or as soon as a slot frees up.
+		select {
+		case <-ctx.Done():
+			stop()
+			mu.Lock()
+			n := running
+			mu.Unlock()
+			fmt.Println()
+			if n > 0 {
+				display.Info("Stopping:", fmt.Sprintf("waiting for %d running investigation(s) to finish (Ctrl+C again to quit now)", n))
+			}
+			wg.Wait()
+			return nil
+		case <-finished:
+		case <-time.After(interval):
+		}
 	}
-	if p.UpdateTime != "" {
-		display.Info("Updated:", p.UpdateTime)
+}
+
+// watchLabel names a queued incident in watch output.
+// finishWatchItem records the result of a watch investigation, prints it
+// and raises a desktop notification when notifications are on.
+func finishWatchItem(cfg *config.Config, it *config.WatchItem, res service.BulkResult, stamp string) {
+	it.FinishedAt = time.Now()
+	failure := ""
+	if res.Status == service.BulkStatusCompleted {
+		it.Status = config.WatchCompleted
+		fmt.Printf("  %s %s✓%s %s %s→ %s%s\n", stamp, display.Green, display.Reset, watchLabel(it), display.Dim, res.Summary, display.Reset)
+	} else {
+		it.Status, it.Error = config.WatchFailed, res.Error
+		fmt.Printf("  %s %s✗%s %s %s→ %s%s\n", stamp, display.Red, display.Reset, watchLabel(it), display.Dim, res.Error, display.Reset)
+		if failure = res.Error; failure == "" {
+			failure = res.Status
+		}
 	}
-	fmt.Println()
-	return nil
+	notifyInvestigation(cfg, it.SessionUUID, watchLabel(it), failure, it.StartedAt)
 }
 
-func cmdProjectCreate(args []string) error {
-	if len(args) == 0 {
-		fmt.Println("Usage: hawkeye projects create <name> [--description <text>]")
-		return nil
+func watchLabel(it *config.WatchItem) string {
+	if it.Name != "" {
+		return it.Name
 	}
+	return it.SessionUUID
+}
 
-	var description string
-	var positional []string
-	for i := 0; i < len(args); i++ {
-		switch args[i] {
-		case "--description", "-d":
-			if i+1 < len(args) {
-				i++
-				description = args[i]
-			} else {
-				return fmt.Errorf("--description requires a value")
-			}
-		default:
-			positional = append(positional, args[i])
-		}
+func priorityTag(priority string) string {
+	if priority == "" {
+		return ""
 	}
+	return " " + display.Dim + "[" + priority + "]" + display.Reset
+}
 
-	name := strings.Join(positional, " ")
-	if name == "" {
-		fmt.Println("Usage: hawkeye projects create <name> [--description <text>]")
+// cmdWatchQueue lists or clears the persisted watch queue. Listing never
+// writes it; clearing needs the queue's lock, so it waits for no live watch.
+func cmdWatchQueue(args []string) error {
+	if len(args) > 0 && args[0] == "clear" {
+		lock, err := config.LockWatchQueue(activeProfile)
+		if err != nil {
+			return err
+		}
+		defer lock.Unlock()
+		queue, err := config.LoadWatchQueue(activeProfile)
+		if err != nil {
+			return err
+		}
+		queue.Clear()
+		if err := queue.Save(); err != nil {
+			return err
+		}
+		display.Success("Watch queue cleared")
 		return nil
 	}
+	if len(args) > 0 {
+		return fmt.Errorf("unknown watch queue subcommand %q (use: watch queue [clear])", args[0])
+	}
 
-	cfg, err := config.Load(activeProfile)
+	queue, err := config.LoadWatchQueue(activeProfile)
 	if err != nil {
 		return err
 	}
-	if err := cfg.Validate(); err != nil {
-		return err
-	}
-
-	client := api.NewClient(cfg)
-	resp, err := client.CreateProject(name, description)
-	if err != nil {
-		return fmt.Errorf("creating project: %w", err)
+	// Running entries are only in flight while their watch is; shown
+	// without one they are interrupted. Nothing is saved.
+	owner := config.WatchOwner(activeProfile)
+	if owner == 0 {
+		queue.Interrupt()
 	}
 
 	if jsonOutput {
-		return printJSON(resp.Spec)
+		return printJSON(queue.Items)
 	}
-
-	if resp.Spec != nil {
-		display.Success(fmt.Sprintf("Project created: %s (%s)", resp.Spec.Name, resp.Spec.UUID))
-	} else {
-		display.Success("Project created")
+	display.Header(fmt.Sprintf("Watch Queue (%d)", len(queue.Items)))
+	if owner != 0 {
+		display.Info("Watch:", fmt.Sprintf("running (pid %d)", owner))
+		fmt.Println()
+	}
+	if len(queue.Items) == 0 {
+		fmt.Printf("  %sEmpty. Run hawkeye watch --auto-investigate to fill it.%s\n\n", display.Dim, display.Reset)
+		return nil
+	}
+	table := newTable(
+		display.Column{Title: "STATUS"},
+		display.Column{Title: "PRI"},
+		display.Column{Title: "SESSION", Keep: true},
+		display.Column{Title: "NAME", Max: 60},
+	)
+	for _, it := range queue.Items {
+		pri := it.Priority
+		if pri == "" {
+			pri = "-"
+		}
+		detail := it.Name
+		if it.Error != "" {
+			detail += display.Dim + " — " + it.Error + display.Reset
+		}
+		table.Row(it.Status, pri, it.SessionUUID, detail)
+	}
+	table.Print()
+	fmt.Println()
+	if slices.ContainsFunc(queue.Items, func(it *config.WatchItem) bool { return it.Status == config.WatchInterrupted }) {
+		fmt.Printf("  %sTip:%s Interrupted investigations are not restarted; run %shawkeye rerun <session-uuid>%s to retry one.\n\n",
+			display.Dim, display.Reset, display.Cyan, display.Reset)
 	}
 	return nil
 }
 
-func cmdProjectUpdate(args []string) error {
-	if len(args) == 0 {
-		fmt.Println("Usage: hawkeye projects update <uuid> [--name <name>] [--description <text>]")
-		return nil
+// ─── listen ─────────────────────────────────────────────────────────────────
+
+// listenSecretEnv supplies the webhook secret when --secret is not given, so
+// it need not appear in the process list.
+const listenSecretEnv = "HAWKEYE_WEBHOOK_SECRET"
+
+func cmdListen(args []string) error {
+	args, projectRef, err := splitProjectFlag(args)
+	if err != nil {
+		return err
 	}
+	port := 8787
+	concurrency := 2
+	queueSize := 32
+	secret := os.Getenv(listenSecretEnv)
+	var lang string
+	var sinkSpecs []string
 
-	projectUUID := args[0]
-	var name, description string
-	for i := 1; i < len(args); i++ {
+	for i := 0; i < len(args); i++ {
 		switch args[i] {
-		case "--name", "-n":
+		case "--port":
+			if i+1 < len(args) {
+				i++
+				n, err := strconv.Atoi(args[i])
+				if err != nil || n <= 0 || n > 65535 {
+					return fmt.Errorf("invalid port: %s", args[i])
+				}
+				port = n
+			} else {
+				return fmt.Errorf("--port requires a value")
+			}
+		case "--secret":
+			if i+1 < len(args) {
+				i++
+				secret = args[i]
+			} else {
+				return fmt.Errorf("--secret requires a value")
+			}
+		case "--concurrency":
+			if i+1 < len(args) {
+				i++
+				n, err := strconv.Atoi(args[i])
+				if err != nil || n <= 0 {
+					return fmt.Errorf("invalid concurrency: %s", args[i])
+				}
+				concurrency = n
+			} else {
+				return fmt.Errorf("--concurrency requires a value")
+			}
+		case "--queue":
+			if i+1 < len(args) {
+				i++
+				n, err := strconv.Atoi(args[i])
+				if err != nil || n <= 0 {
+					return fmt.Errorf("invalid queue size: %s", args[i])
+				}
+				queueSize = n
+			} else {
+				return fmt.Errorf("--queue requires a value")
+			}
+		case "--sink":
 			if i+1 < len(args) {
 				i++
-				name = args[i]
+				sinkSpecs = append(sinkSpecs, args[i])
 			} else {
-				return fmt.Errorf("--name requires a value")
+				return fmt.Errorf("--sink requires a value")
 			}
-		case "--description", "-d":
+		case "--lang":
 			if i+1 < len(args) {
 				i++
-				description = args[i]
+				lang = args[i]
 			} else {
-				return fmt.Errorf("--description requires a value")
+				return fmt.Errorf("--lang requires a value")
 			}
+		default:
+			return fmt.Errorf("unknown flag: %s", args[i])
 		}
 	}
 
-	if name == "" && description == "" {
-		fmt.Println("Usage: hawkeye projects update <uuid> [--name <name>] [--description <text>]")
-		return nil
+	if secret == "" {
+		return fmt.Errorf("--secret (or %s) is required: deliveries are only accepted with a valid HMAC signature or the secret as a bearer token or basic-auth password", listenSecretEnv)
+	}
+	sinks, err := parseSinks(sinkSpecs)
+	if err != nil {
+		return err
+	}
+	lang, err = service.NormalizeLanguage(lang)
+	if err != nil {
+		return err
 	}
 
 	cfg, err := config.Load(activeProfile)
 	if err != nil {
 		return err
 	}
-	if err := cfg.Validate(); err != nil {
+	if err := useProjectFlag(cfg, projectRef); err != nil {
 		return err
 	}
+	if err := cfg.ValidateProject(); err != nil {
+		return err
+	}
+	projectUUID := cfg.ProjectID
 
 	client := api.NewClient(cfg)
-	resp, err := client.UpdateProject(projectUUID, name, description)
-	if err != nil {
-		return fmt.Errorf("updating project: %w", err)
+	if lang != "" {
+		client.SetLanguage(lang)
+	}
+
+	// Investigations outlive the request that triggered them: the sender
+	// gets 202 Accepted right away and results go to the sinks. Alerts wait
+	// in a bounded queue for a fixed pool of workers; a delivery that does
+	// not fit is refused with 503 so the sender retries it later.
+	var mu sync.Mutex // guards output and cfg
+	var enqueue sync.Mutex
+	var wg sync.WaitGroup
+	queue := make(chan service.WebhookAlert, queueSize)
+
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for a := range queue {
+				mu.Lock()
+				fmt.Printf("  %s→%s %s %s(%s)%s\n", display.Cyan, display.Reset, a.Title, display.Dim, a.Source, display.Reset)
+				mu.Unlock()
+
+				result, err := investigateWebhookAlert(client, projectUUID, a)
+
+				mu.Lock()
+				if err != nil {
+					fmt.Printf("  %s✗%s %s %s→ %v%s\n", display.Red, display.Reset, a.Title, display.Dim, err, display.Reset)
+					mu.Unlock()
+					continue
+				}
+				fmt.Printf("  %s✓%s %s %s→ %s%s\n", display.Green, display.Reset, a.Title, display.Dim, result.SessionUUID, display.Reset)
+				cfg.LastSession = result.SessionUUID
+				_ = cfg.Save()
+				result.ConsoleURL = cfg.ConsoleSessionURL(result.SessionUUID)
+				mu.Unlock()
+
+				// Sinks make network calls; holding mu here would stall
+				// every other worker behind a slow webhook.
+				deliverSinks(sinks, result, false)
+			}
+		}()
 	}
 
-	if jsonOutput {
-		return printJSON(resp.Spec)
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /healthz", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, "ok")
+	})
+	mux.HandleFunc("POST /", func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(io.LimitReader(r.Body, 1<<20))
+		if err != nil {
+			http.Error(w, "reading body failed", http.StatusBadRequest)
+			return
+		}
+		if !service.AuthenticateWebhook(body, secret, r.Header) {
+			mu.Lock()
+			display.Warn(fmt.Sprintf("Rejected delivery from %s: invalid signature or secret", r.RemoteAddr))
+			mu.Unlock()
+			http.Error(w, "invalid signature or secret", http.StatusUnauthorized)
+			return
+		}
+		alerts, err := service.ParseAlertWebhook(body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		// Queue the whole delivery or none of it, so a retry does not
+		// investigate the alerts that did fit a second time. Only handlers
+		// send, under enqueue, so the sends below cannot block.
+		enqueue.Lock()
+		if len(queue)+len(alerts) > cap(queue) {
+			enqueue.Unlock()
+			mu.Lock()
+			display.Warn(fmt.Sprintf("Refused delivery from %s: %d alert(s) would overflow the queue", r.RemoteAddr, len(alerts)))
+			mu.Unlock()
+			w.Header().Set("Retry-After", "60")
+			http.Error(w, "investigation queue is full", http.StatusServiceUnavailable)
+			return
+		}
+		for _, a := range alerts {
+			queue <- a
+		}
+		enqueue.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusAccepted)
+		_ = json.NewEncoder(w).Encode(map[string]int{"accepted": len(alerts)})
+	})
+
+	srv := &http.Server{
+		Addr:              fmt.Sprintf(":%d", port),
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
 	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
-	display.Success(fmt.Sprintf("Project %s updated", projectUUID))
+	serveErr := make(chan error, 1)
+	go func() { serveErr <- srv.ListenAndServe() }()
+
+	fmt.Println()
+	display.Success(fmt.Sprintf("Listening for alert webhooks on :%d", port))
+	display.Info("Project:", projectUUID)
+	display.Info("Concurrency:", fmt.Sprintf("%d (queue %d)", concurrency, queueSize))
+	fmt.Printf("  %sPOST PagerDuty (v3) or Alertmanager payloads to /; Ctrl+C to stop.%s\n\n", display.Dim, display.Reset)
+
+	select {
+	case err := <-serveErr:
+		if !errors.Is(err, http.ErrServerClosed) {
+			return fmt.Errorf("listening on :%d: %w", port, err)
+		}
+	case <-ctx.Done():
+	}
+
+	fmt.Println()
+	display.Info("Stopping:", "waiting for running and queued investigations to finish")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	_ = srv.Shutdown(shutdownCtx)
+	close(queue)
+	wg.Wait()
 	return nil
 }
 
-func cmdProjectDelete(args []string) error {
-	if len(args) == 0 {
-		fmt.Println("Usage: hawkeye projects delete <uuid> [--confirm]")
-		return nil
+// investigateWebhookAlert runs one alert from a webhook delivery quietly.
+// Alerts with a sender ID get a session created from the alert; when there is
+// none, or the backend does not know it, a plain session is used instead.
+func investigateWebhookAlert(client *api.Client, projectUUID string, a service.WebhookAlert) (service.SinkResult, error) {
+	var sessionUUID string
+	if a.AlertID != "" {
+		if resp, err := client.CreateSessionFromAlert(projectUUID, a.AlertID); err == nil {
+			sessionUUID = resp.SessionUUID
+		}
 	}
-
-	projectUUID := args[0]
-	confirmed := false
-	for _, a := range args[1:] {
-		if a == "--confirm" || a == "-y" {
-			confirmed = true
+	if sessionUUID == "" {
+		resp, err := client.NewSession(projectUUID)
+		if err != nil {
+			return service.SinkResult{}, fmt.Errorf("creating session: %w", err)
+		}
+		sessionUUID = resp.SessionUUID
+		if err := client.RenameSession(projectUUID, sessionUUID, service.SessionTitle(a.Title)); err != nil {
+			fmt.Fprintf(os.Stderr, "warning: could not name session: %v\n", err)
 		}
 	}
 
-	if !confirmed {
-		fmt.Printf("Delete project %s? This cannot be undone. Use --confirm to proceed.\n", projectUUID)
-		return nil
+	var collector service.AnswerCollector
+	if err := client.ProcessPromptStream(projectUUID, sessionUUID, a.Prompt, collector.Handle); err != nil {
+		return service.SinkResult{SessionUUID: sessionUUID}, fmt.Errorf("stream error: %w", err)
 	}
+	return service.SinkResult{
+		SessionUUID: sessionUUID,
+		SessionName: service.SessionTitle(a.Title),
+		ProjectUUID: projectUUID,
+		Prompt:      a.Prompt,
+		Answer:      collector.Answer(),
+		Time:        time.Now(),
+	}, nil
+}
 
-	cfg, err := config.Load(activeProfile)
+// ─── multi-project investigations ───────────────────────────────────────────
+
+// runFanout asks the same question in several projects at once, each in a
+// new session, and prints the answers side by side once all have finished.
+func runFanout(cfg *config.Config, client *api.Client, prompt, projectList string, allProjects bool, concurrency int, autoName bool, kubeContext, namespace string, inputParts []string) error {
+	resp, err := client.ListProjects()
 	if err != nil {
-		return err
+		return fmt.Errorf("listing projects: %w", err)
 	}
-	if err := cfg.Validate(); err != nil {
+	projects, err := service.ResolveFanoutProjects(resp.Specs, projectList, allProjects)
+	if err != nil {
 		return err
 	}
 
-	client := api.NewClient(cfg)
-	if err := client.DeleteProject(projectUUID); err != nil {
-		return fmt.Errorf("deleting project: %w", err)
+	var contextParts []string
+	if kubeContext != "" || namespace != "" {
+		if contextParts, _, err = gatherKubeContext(kubeContext, namespace); err != nil {
+			fmt.Fprintf(os.Stderr, "%s!%s Kubernetes context unavailable: %v\n", display.Yellow, display.Reset, err)
+		}
+	}
+	contextParts = append(contextParts, inputParts...)
+	recordHistory(prompt, "")
+
+	if !jsonOutput {
+		fmt.Println()
+		display.Info("Prompt:", prompt)
+		display.Info("Projects:", strconv.Itoa(len(projects)))
+		display.Info("Concurrency:", strconv.Itoa(concurrency))
+		fmt.Println()
+	}
+
+	results := make([]service.FanoutResult, len(projects))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	progress := display.NewProgress()
+
+	for i, p := range projects {
+		wg.Add(1)
+		go func(i int, p api.ProjectSpec) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			label := fanoutLabel(p)
+			task := progress.Add(fmt.Sprintf("Investigating in %s...", label))
+			res := investigateInProject(cfg, client, p, prompt, contextParts, autoName)
+			results[i] = res
+			task.Done()
+
+			if jsonOutput {
+				return
+			}
+			if res.Status == service.BulkStatusCompleted {
+				progress.Printf("  %s✓%s %s %s→ %s%s\n", display.Green, display.Reset, label, display.Dim, res.SessionUUID, display.Reset)
+			} else {
+				progress.Printf("  %s✗%s %s %s→ %s%s\n", display.Red, display.Reset, label, display.Dim, res.Error, display.Reset)
+			}
+		}(i, p)
+	}
+	wg.Wait()
+	progress.Stop()
+
+	for _, r := range results {
+		noteSession(r.SessionUUID)
 	}
 
 	if jsonOutput {
-		return printJSON(map[string]string{"deleted": projectUUID})
+		return printJSON(results)
+	}
+
+	completed, failed := service.CountFanoutResults(results)
+	display.Header(fmt.Sprintf("Multi-project Investigation (%d completed, %d failed)", completed, failed))
+	fmt.Printf("  %s%-24s  %-36s  %-10s  %s%s\n", display.Bold, "PROJECT", "SESSION", "STATUS", "SUMMARY", display.Reset)
+	for _, r := range results {
+		status := display.Green + fmt.Sprintf("%-10s", r.Status) + display.Reset
+		detail := r.Summary
+		if r.Status != service.BulkStatusCompleted {
+			status = display.Red + fmt.Sprintf("%-10s", r.Status) + display.Reset
+			detail = r.Error
+		}
+		sess := r.SessionUUID
+		if sess == "" {
+			sess = "-"
+		}
+		fmt.Printf("  %-24s  %-36s  %s  %s\n", truncate(fanoutLabel(api.ProjectSpec{UUID: r.ProjectUUID, Name: r.ProjectName}), 24), sess, status, detail)
 	}
 
-	display.Success(fmt.Sprintf("Project %s deleted", projectUUID))
+	for _, r := range results {
+		if r.Answer == "" {
+			continue
+		}
+		display.Header(fanoutLabel(api.ProjectSpec{UUID: r.ProjectUUID, Name: r.ProjectName}))
+		fmt.Println(api.RenderMarkdown(r.Answer))
+		projectCfg := *cfg
+		projectCfg.ProjectID = r.ProjectUUID
+		if url := projectCfg.ConsoleSessionURL(r.SessionUUID); url != "" {
+			fmt.Printf("\n  %sConsole:%s %s\n", display.Dim, display.Reset, url)
+		}
+	}
+
+	fmt.Println()
+	fmt.Printf("  %sTip:%s Run %shawkeye inspect <session-uuid>%s to review a session.\n\n",
+		display.Dim, display.Reset, display.Cyan, display.Reset)
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d project investigations failed", failed, len(results))
+	}
 	return nil
 }
 
-// ─── score ──────────────────────────────────────────────────────────────────
+// investigateInProject runs one fan-out investigation quietly in a new
+// session of project p.
+func investigateInProject(cfg *config.Config, client *api.Client, p api.ProjectSpec, prompt string, contextParts []string, autoName bool) service.FanoutResult {
+	res := service.FanoutResult{ProjectUUID: p.UUID, ProjectName: p.Name}
+	sessResp, err := client.NewSession(p.UUID)
+	if err != nil {
+		res.Status = service.BulkStatusFailed
+		res.Error = fmt.Sprintf("creating session: %v", err)
+		return res
+	}
+	res.SessionUUID = sessResp.SessionUUID
+	if autoName {
+		_ = client.RenameSession(p.UUID, res.SessionUUID, service.SessionTitle(prompt))
+	}
+
+	var collector service.AnswerCollector
+	var failures service.FailureCollector
+	handler, finishTranscript := recordTranscript(cfg, p.UUID, p.Name, res.SessionUUID, prompt, func(resp *api.ProcessPromptResponse) {
+		collector.Handle(resp)
+		failures.Handle(resp)
+	})
+	err = client.ProcessPromptStreamWithContext(p.UUID, res.SessionUUID, prompt, contextParts, handler)
+	finishTranscript(err)
+	if err != nil {
+		res.Status = service.BulkStatusFailed
+		res.Error = fmt.Sprintf("stream error: %v", err)
+		return res
+	}
+	if f := failures.Failure(); f != nil {
+		res.Status = service.BulkStatusFailed
+		res.Error = "investigation failed: " + f.Reason
+		return res
+	}
+	res.Status = service.BulkStatusCompleted
+	res.Answer = collector.Answer()
+	res.Summary = service.ShortSummary(res.Answer, 60)
+	return res
+}
+
+// fanoutLabel names a project by name, or by UUID when the name is unknown.
+func fanoutLabel(p api.ProjectSpec) string {
+	if p.Name != "" {
+		return p.Name
+	}
+	return p.UUID
+}
+
+// ─── sources ────────────────────────────────────────────────────────────────
+
+func cmdSources(args []string) error {
+	var cycle int
+	var positional []string
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--cycle":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--cycle requires a value")
+			}
+			i++
+			n, err := strconv.Atoi(args[i])
+			if err != nil || n <= 0 {
+				return fmt.Errorf("invalid cycle: %s", args[i])
+			}
+			cycle = n
+		default:
+			positional = append(positional, args[i])
+		}
+	}
 
-func cmdScore(args []string) error {
 	cfg, err := config.Load(activeProfile)
 	if err != nil {
 		return err
@@ -1214,78 +8942,207 @@ func cmdScore(args []string) error {
 	}
 
 	sessionUUID := ""
-	if len(args) > 0 {
-		sessionUUID = args[0]
+	if len(positional) > 0 {
+		sessionUUID = cfg.ResolveSession(positional[0])
 	} else if cfg.LastSession != "" {
 		sessionUUID = cfg.LastSession
 	} else {
-		fmt.Println("Usage: hawkeye score [session-uuid]")
+		fmt.Println("Usage: hawkeye sources [session-uuid] [--cycle <n>]")
 		return nil
 	}
 
 	client := api.NewClient(cfg)
-
-	resp, err := client.GetSessionSummary(cfg.ProjectID, sessionUUID)
+	resp, err := client.SessionInspect(cfg.ProjectID, sessionUUID)
 	if err != nil {
-		return fmt.Errorf("getting summary: %w", err)
+		return fmt.Errorf("inspecting session: %w", err)
+	}
+	sources, err := service.CollectSources(resp.PromptCycle, cycle)
+	if err != nil {
+		return err
 	}
 
 	if jsonOutput {
-		return printJSON(resp)
+		return printJSON(sources)
 	}
 
-	scores := service.ExtractScores(resp)
-	if !scores.HasScores {
-		display.Warn("No RCA scores available for this session.")
+	display.Header(fmt.Sprintf("Sources (%d)", len(sources)))
+	if len(sources) == 0 {
+		display.Warn("No sources found.")
 		return nil
 	}
 
-	display.Header("RCA Quality Scores")
+	lastCycle := 0
+	for _, src := range sources {
+		if src.Cycle != lastCycle && cycle == 0 && len(resp.PromptCycle) > 1 {
+			fmt.Println()
+			display.SubHeader(fmt.Sprintf("── Prompt Cycle %d ──", src.Cycle))
+		}
+		lastCycle = src.Cycle
 
-	if scores.ScoredBy != "" {
-		display.Info("Scored by:", scores.ScoredBy)
+		cat := ""
+		if src.Category != "" {
+			cat = fmt.Sprintf(" %s(%s)%s", display.Dim, src.Category, display.Reset)
+		}
+		fmt.Printf("\n  📎 %s%s%s%s\n", display.Bold, src.Title, display.Reset, cat)
+		if src.ID != "" && src.ID != src.Title {
+			fmt.Printf("    %sID:%s %s\n", display.Dim, display.Reset, src.ID)
+		}
+		if src.Description != "" {
+			for _, line := range strings.Split(api.RenderMarkdown(src.Description), "\n") {
+				fmt.Printf("    %s\n", line)
+			}
+		}
+		if len(src.Queries) == 0 {
+			fmt.Printf("    %sNo queries reference this source.%s\n", display.Dim, display.Reset)
+			continue
+		}
+		fmt.Printf("    %sQueries (%d):%s\n", display.Dim, len(src.Queries), display.Reset)
+		for _, q := range src.Queries {
+			label := q.Description
+			if label == "" {
+				label = q.ID
+			}
+			status := ""
+			if q.Status != "" {
+				status = " " + display.CoTStatusLabel(q.Status)
+			}
+			fmt.Printf("      • %s%s\n", label, status)
+		}
 	}
 
 	fmt.Println()
-	fmt.Printf("  %s📊 Accuracy:%s     %.1f/100\n", display.Cyan, display.Reset, scores.Accuracy.Score)
-	if scores.Accuracy.Summary != "" {
-		fmt.Printf("     %s%s%s\n", display.Gray, scores.Accuracy.Summary, display.Reset)
+	return nil
+}
+
+// ─── queries ────────────────────────────────────────────────────────────────
+
+func cmdQueries(args []string) error {
+	args, projectRef, err := splitProjectFlag(args)
+	if err != nil {
+		return err
+	}
+	cfg, err := config.Load(activeProfile)
+	if err != nil {
+		return err
+	}
+	if err := useProjectFlag(cfg, projectRef); err != nil {
+		return err
+	}
+	if err := cfg.ValidateProject(); err != nil {
+		return err
 	}
 
-	fmt.Printf("  %s📊 Completeness:%s %.1f/100\n", display.Cyan, display.Reset, scores.Completeness.Score)
-	if scores.Completeness.Summary != "" {
-		fmt.Printf("     %s%s%s\n", display.Gray, scores.Completeness.Summary, display.Reset)
+	sessionUUID := ""
+	if len(args) > 0 {
+		sessionUUID = cfg.ResolveSession(args[0])
+	} else if cfg.LastSession != "" {
+		sessionUUID = cfg.LastSession
+	} else {
+		fmt.Println("Usage: hawkeye queries [session-uuid]")
+		return nil
 	}
 
-	if len(scores.Qualitative.Strengths) > 0 {
-		fmt.Printf("\n  %s✅ Strengths:%s\n", display.Green, display.Reset)
-		for _, s := range scores.Qualitative.Strengths {
-			fmt.Printf("    • %s\n", s)
-		}
+	client := api.NewClient(cfg)
+	var resp *api.GetInvestigationQueriesResponse
+	err = inSessionProject(cfg, client, projectRef != "", sessionUUID, func() (err error) {
+		resp, err = client.GetInvestigationQueries(cfg.ProjectID, sessionUUID)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("getting queries: %w", err)
 	}
 
-	if len(scores.Qualitative.Improvements) > 0 {
-		fmt.Printf("\n  %s💡 Improvements:%s\n", display.Yellow, display.Reset)
-		for _, s := range scores.Qualitative.Improvements {
-			fmt.Printf("    • %s\n", s)
-		}
+	if jsonOutput {
+		return printJSON(resp.Queries)
 	}
 
-	if scores.TimeSaved != nil {
-		fmt.Printf("\n  %s⏱  Time Saved:%s\n", display.Blue, display.Reset)
-		fmt.Printf("    Standard investigation: %.0f min\n", scores.TimeSaved.StandardInvestigationMin)
-		fmt.Printf("    Hawkeye investigation:  %.0f min\n", scores.TimeSaved.HawkeyeInvestigationMin)
-		fmt.Printf("    %sTime saved:%s            %.0f min\n",
-			display.Bold, display.Reset, scores.TimeSaved.TimeSavedMinutes)
+	queries := service.FormatQueries(resp.Queries)
+	display.Header(fmt.Sprintf("Investigation Queries (%d)", len(queries)))
+
+	if len(queries) == 0 {
+		display.Warn("No queries found.")
+		return nil
+	}
+
+	table := newTable(
+		display.Column{Title: "#", Align: display.AlignRight},
+		display.Column{Title: "", Max: 2},
+		display.Column{Title: "SOURCE", Max: 24},
+		display.Column{Title: "TIME", Align: display.AlignRight},
+		display.Column{Title: "RESULTS", Align: display.AlignRight},
+		display.Column{Title: "ID", Wide: true},
+		display.Column{Title: "QUERY", Max: 100},
+	)
+	var failed []string
+	for i, q := range queries {
+		statusIcon := "✅"
+		switch q.Status {
+		case "FAILED", "ERROR":
+			statusIcon = "❌"
+		case "RUNNING", "IN_PROGRESS":
+			statusIcon = "🔄"
+		}
+		results := ""
+		if q.ResultCount > 0 {
+			results = strconv.Itoa(q.ResultCount)
+		}
+		table.Row(strconv.Itoa(i+1), statusIcon, q.Source, q.ExecutionTime, results, q.ID,
+			display.Gray+strings.Join(strings.Fields(q.Query), " ")+display.Reset)
+		if q.ErrorMessage != "" {
+			failed = append(failed, fmt.Sprintf("  %sQuery %d:%s %s", display.Red, i+1, display.Reset, q.ErrorMessage))
+		}
+	}
+	fmt.Println()
+	table.Print()
+	if len(failed) > 0 {
+		fmt.Printf("\n  %sErrors:%s\n", display.Bold, display.Reset)
+		for _, line := range failed {
+			fmt.Println(line)
+		}
 	}
 
 	fmt.Println()
 	return nil
 }
 
-// ─── link ───────────────────────────────────────────────────────────────────
+// ─── stats ──────────────────────────────────────────────────────────────────
+
+func cmdStats(args []string) error {
+	var projectWide bool
+	limit := 20
+	top := 5
+	var positional []string
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--project":
+			projectWide = true
+		case "-n", "--limit":
+			if i+1 < len(args) {
+				i++
+				n, err := strconv.Atoi(args[i])
+				if err != nil || n < 1 {
+					return fmt.Errorf("invalid limit: %s", args[i])
+				}
+				limit = n
+			} else {
+				return fmt.Errorf("--limit requires a value")
+			}
+		case "--top":
+			if i+1 < len(args) {
+				i++
+				n, err := strconv.Atoi(args[i])
+				if err != nil || n < 1 {
+					return fmt.Errorf("invalid --top: %s", args[i])
+				}
+				top = n
+			} else {
+				return fmt.Errorf("--top requires a value")
+			}
+		default:
+			positional = append(positional, args[i])
+		}
+	}
 
-func cmdLink(args []string) error {
 	cfg, err := config.Load(activeProfile)
 	if err != nil {
 		return err
@@ -1293,176 +9150,208 @@ func cmdLink(args []string) error {
 	if err := cfg.ValidateProject(); err != nil {
 		return err
 	}
+	client := api.NewClient(cfg)
+
+	if projectWide {
+		return runProjectStats(cfg, client, limit, top)
+	}
 
 	sessionUUID := ""
-	if len(args) > 0 {
-		sessionUUID = args[0]
+	if len(positional) > 0 {
+		sessionUUID = cfg.ResolveSession(positional[0])
 	} else if cfg.LastSession != "" {
 		sessionUUID = cfg.LastSession
 	} else {
-		fmt.Println("Usage: hawkeye link [session-uuid]")
+		fmt.Println("Usage: hawkeye stats [session-uuid] | hawkeye stats --project [-n <count>]")
 		return nil
 	}
 
-	url := service.BuildSessionURL(cfg.Server, cfg.ProjectID, sessionUUID)
-
-	if jsonOutput {
-		return printJSON(map[string]string{"url": url})
-	}
-
-	fmt.Println(url)
-	return nil
-}
-
-// ─── open / parse ───────────────────────────────────────────────────────────
-
-func parseAndValidateSessionURL(rawURL string) (cfg *config.Config, projectUUID, sessionUUID string, err error) {
-	host, projectUUID, sessionUUID, err := service.ParseSessionURL(rawURL)
-	if err != nil {
-		return nil, "", "", fmt.Errorf("invalid session URL: %w", err)
-	}
-
-	cfg, err = config.Load(activeProfile)
+	resp, err := client.SessionInspect(cfg.ProjectID, sessionUUID)
 	if err != nil {
-		return nil, "", "", err
+		return fmt.Errorf("inspecting session: %w", err)
 	}
-	if err := cfg.Validate(); err != nil {
-		return nil, "", "", err
+	st := service.ComputeSessionStats(resp)
+	if st.SessionUUID == "" {
+		st.SessionUUID = sessionUUID
 	}
 
-	expectedBase := cfg.FrontendURL
-	if expectedBase == "" {
-		expectedBase = cfg.Server
-	}
-	expectedBase = strings.TrimRight(expectedBase, "/")
-	if idx := strings.Index(expectedBase, "/api"); idx > 0 {
-		expectedBase = expectedBase[:idx]
+	if jsonOutput {
+		return printJSON(st)
 	}
 
-	if !strings.EqualFold(host, expectedBase) {
-		profileHint := ""
-		if activeProfile != "" {
-			profileHint = " --profile " + activeProfile
+	name := st.Name
+	if name == "" {
+		name = "(unnamed)"
+	}
+	display.Header(fmt.Sprintf("Investigation Stats: %s", name))
+	display.Info("Session:", st.SessionUUID)
+	display.Info("Prompt cycles:", strconv.Itoa(st.PromptCycles))
+	display.Info("Wall time:", service.FormatDurationMs(st.WallTimeMs))
+	stepTime := service.FormatDurationMs(st.StepTimeMs)
+	if st.WallTimeMs > 0 {
+		stepTime += fmt.Sprintf(" %s(%d%% of wall time)%s", display.Dim, st.StepTimeMs*100/st.WallTimeMs, display.Reset)
+	}
+	display.Info("Step processing:", stepTime)
+	display.Info("Queries:", strconv.Itoa(st.Queries))
+	display.Info("Sources consulted:", strconv.Itoa(st.Sources))
+
+	if len(st.Steps) > 0 {
+		steps := st.Steps
+		if len(steps) > top {
+			steps = steps[:top]
+		}
+		fmt.Println()
+		display.SubHeader(fmt.Sprintf("Slowest steps (%d of %d)", len(steps), len(st.Steps)))
+		for _, step := range steps {
+			cat := ""
+			if step.Category != "" {
+				cat = fmt.Sprintf("%s[%s]%s ", display.Dim, step.Category, display.Reset)
+			}
+			fmt.Printf("  %s%9s%s  %s%s\n", display.Yellow, service.FormatDurationMs(step.DurationMs), display.Reset, cat, truncate(step.Description, 70))
 		}
-		return nil, "", "", fmt.Errorf(
-			"server mismatch: URL points to %s but you're logged into %s\n\n"+
-				"  Try: hawkeye --profile <name> open %s\n"+
-				"  Or:  hawkeye%s login %s -u <username> -p <password>",
-			host, expectedBase, rawURL, profileHint, host)
 	}
-
-	cfg.ProjectID = projectUUID
-	cfg.LastSession = sessionUUID
-	_ = cfg.Save()
-	return cfg, projectUUID, sessionUUID, nil
+	fmt.Println()
+	return nil
 }
 
-func cmdOpen(args []string) error {
-	if len(args) == 0 {
-		fmt.Println("Usage: hawkeye open <url>")
-		fmt.Println()
-		fmt.Println("Open a web console session URL in interactive mode.")
-		fmt.Println()
-		fmt.Println("Example:")
-		fmt.Println("  hawkeye open https://myenv.app.neubird.ai/console/project/abc-123/session/def-456?tab=rca")
-		fmt.Println("  hawkeye --profile staging open <url>")
-		return nil
+// runProjectStats aggregates stats over the most recent sessions.
+func runProjectStats(cfg *config.Config, client *api.Client, limit, top int) error {
+	resp, err := client.SessionList(cfg.ProjectID, 0, limit, nil)
+	if err != nil {
+		return fmt.Errorf("listing sessions: %w", err)
 	}
 
-	_, _, sessionUUID, err := parseAndValidateSessionURL(args[0])
-	if err != nil {
-		return err
+	var stats []service.SessionStats
+	var failed int
+	progress := display.NewProgress()
+	task := progress.Add("Analyzing sessions")
+	for i, s := range resp.Sessions {
+		task.SetProgress(i+1, len(resp.Sessions))
+		inspect, err := client.SessionInspect(cfg.ProjectID, s.SessionUUID)
+		if err != nil {
+			failed++
+			continue
+		}
+		st := service.ComputeSessionStats(inspect)
+		if st.SessionUUID == "" {
+			st.SessionUUID = s.SessionUUID
+			st.Name = s.Name
+		}
+		if st.PromptCycles > 0 {
+			stats = append(stats, st)
+		}
 	}
+	progress.Stop()
 
+	agg := service.AggregateStats(stats, top)
 	if jsonOutput {
-		return printJSON(map[string]string{"session_uuid": sessionUUID, "url": args[0]})
+		return printJSON(agg)
 	}
 
-	display.Success(fmt.Sprintf("Opening session: %s", sessionUUID))
-	return tui.Run(version, activeProfile, sessionUUID)
-}
-
-func cmdParse(args []string) error {
-	if len(args) == 0 {
-		fmt.Println("Usage: hawkeye parse <url>")
-		fmt.Println()
-		fmt.Println("Parse a web console URL and set the project + session in config.")
-		fmt.Println("Then use 'inspect', 'summary', 'score', etc. on that session.")
-		fmt.Println()
-		fmt.Println("Example:")
-		fmt.Println("  hawkeye parse https://myenv.app.neubird.ai/console/project/abc-123/session/def-456?tab=rca")
-		fmt.Println("  hawkeye inspect   # inspects the parsed session")
-		return nil
+	display.Header(fmt.Sprintf("Project Stats (%d sessions)", agg.Sessions))
+	if failed > 0 {
+		display.Warn(fmt.Sprintf("%d session(s) could not be inspected and were skipped.", failed))
 	}
-
-	_, projectUUID, sessionUUID, err := parseAndValidateSessionURL(args[0])
-	if err != nil {
-		return err
+	if agg.Sessions == 0 {
+		display.Warn("No investigated sessions found.")
+		return nil
 	}
+	display.Info("Avg wall time:", service.FormatDurationMs(agg.AvgWallMs))
+	display.Info("Median wall time:", service.FormatDurationMs(agg.MedianWallMs))
+	display.Info("Max wall time:", service.FormatDurationMs(agg.MaxWallMs))
+	display.Info("Avg step processing:", service.FormatDurationMs(agg.AvgStepMs))
+	display.Info("Avg queries:", fmt.Sprintf("%.1f", agg.AvgQueries))
+	display.Info("Avg sources:", fmt.Sprintf("%.1f", agg.AvgSources))
 
-	if jsonOutput {
-		return printJSON(map[string]string{
-			"project_uuid": projectUUID,
-			"session_uuid": sessionUUID,
-			"url":          args[0],
-		})
+	fmt.Println()
+	display.SubHeader("Slowest sessions")
+	for _, s := range agg.Slowest {
+		name := s.Name
+		if name == "" {
+			name = "(unnamed)"
+		}
+		fmt.Printf("  %s%9s%s  %s  %s%s%s\n", display.Yellow, service.FormatDurationMs(s.WallTimeMs), display.Reset,
+			truncate(name, 50), display.Dim, s.SessionUUID, display.Reset)
 	}
 
-	display.Success(fmt.Sprintf("Project: %s", projectUUID))
-	display.Success(fmt.Sprintf("Session: %s", sessionUUID))
 	fmt.Println()
-	fmt.Printf("  %sNext:%s Run %shawkeye inspect%s to view session details.\n\n",
+	fmt.Printf("  %sTip:%s Run %shawkeye stats <session-uuid>%s for a per-step breakdown.\n\n",
 		display.Dim, display.Reset, display.Cyan, display.Reset)
 	return nil
 }
 
-// ─── report ─────────────────────────────────────────────────────────────────
+// ─── instructions ───────────────────────────────────────────────────────────
 
-func cmdReport() error {
+func cmdInstructions(args []string) error {
 	cfg, err := config.Load(activeProfile)
 	if err != nil {
 		return err
 	}
-	if err := cfg.Validate(); err != nil {
+	if err := cfg.ValidateProject(); err != nil {
 		return err
 	}
 
-	client := api.NewClient(cfg)
+	// Subcommand dispatch
+	if len(args) > 0 {
+		switch args[0] {
+		case "create":
+			return cmdInstructionCreate(cfg, args[1:])
+		case "update":
+			return cmdInstructionUpdate(cfg, args[1:])
+		case "export":
+			return cmdInstructionExport(cfg, args[1:])
+		case "import":
+			return cmdInstructionImport(cfg, args[1:])
+		case "enable":
+			return cmdInstructionToggle(cfg, args[1:], true)
+		case "disable":
+			return cmdInstructionToggle(cfg, args[1:], false)
+		case "delete":
+			return cmdInstructionDelete(cfg, args[1:])
+		case "validate":
+			return cmdInstructionValidate(cfg, args[1:])
+		case "apply":
+			return cmdInstructionApply(cfg, args[1:])
+		case "info":
+			// info falls through to list with filter
+			if len(args) < 2 {
+				fmt.Println("Usage: hawkeye instructions info <uuid>")
+				return nil
+			}
+			return cmdInstructionInfo(cfg, args[1])
+		}
+	}
 
-	resp, err := client.GetIncidentReport()
+	// Default: list instructions
+	client := api.NewClient(cfg)
+	resp, err := client.ListInstructions(cfg.ProjectID)
 	if err != nil {
-		return fmt.Errorf("getting incident report: %w", err)
+		return fmt.Errorf("listing instructions: %w", err)
 	}
 
 	if jsonOutput {
-		return printJSON(resp)
+		return printJSON(resp.Instructions)
 	}
 
-	report := service.FormatReport(resp)
-
-	display.Header("Incident Analytics Report")
+	instructions := service.FormatInstructions(resp.Instructions)
+	display.Header(fmt.Sprintf("Instructions (%d)", len(instructions)))
 
-	if report.Period != "" {
-		display.Info("Period:", report.Period)
+	if len(instructions) == 0 {
+		display.Warn("No instructions found.")
+		return nil
 	}
 
-	fmt.Println()
-	fmt.Printf("  %s📈 Overview%s\n", display.Cyan, display.Reset)
-	fmt.Printf("    Total incidents:      %d\n", report.TotalIncidents)
-	fmt.Printf("    Total investigations: %d\n", report.TotalInvestigations)
-	fmt.Printf("    Avg time saved:       %s\n", report.AvgTimeSavedMinutes)
-	fmt.Printf("    Avg MTTR:             %s\n", report.AvgMTTR)
-	fmt.Printf("    Noise reduction:      %s\n", report.NoiseReduction)
-	fmt.Printf("    Total time saved:     %s\n", report.TotalTimeSavedHours)
-
-	if len(report.IncidentTypes) > 0 {
-		fmt.Printf("\n  %s📋 By Incident Type%s\n", display.Cyan, display.Reset)
-		for _, it := range report.IncidentTypes {
-			fmt.Printf("    %s%s%s\n", display.Bold, it.Type, display.Reset)
-			for _, pr := range it.Priorities {
-				fmt.Printf("      [%s]  incidents: %-5d  investigated: %-3d  grouped: %-6s  saved: %s\n",
-					pr.Priority, pr.TotalIncidents, pr.Investigated, pr.PercentGrouped, pr.AvgTimeSaved)
-			}
+	for _, instr := range instructions {
+		status := display.Green + "enabled" + display.Reset
+		if !instr.Enabled {
+			status = display.Dim + "disabled" + display.Reset
+		}
+		fmt.Printf("\n  %s%s%s  %s[%s]%s  %s\n",
+			display.Bold, instr.Name, display.Reset,
+			display.Dim, instr.Type, display.Reset, status)
+		fmt.Printf("    %sUUID:%s %s\n", display.Dim, display.Reset, instr.UUID)
+		if instr.Content != "" {
+			fmt.Printf("    %s%s%s\n", display.Gray, truncate(instr.Content, 80), display.Reset)
 		}
 	}
 
@@ -1470,461 +9359,764 @@ func cmdReport() error {
 	return nil
 }
 
-// ─── connections ────────────────────────────────────────────────────────────
-
-func cmdConnections(args []string) error {
-	cfg, err := config.Load(activeProfile)
+func cmdInstructionInfo(cfg *config.Config, instrUUID string) error {
+	client := api.NewClient(cfg)
+	resp, err := client.ListInstructions(cfg.ProjectID)
 	if err != nil {
-		return err
+		return fmt.Errorf("listing instructions: %w", err)
 	}
 
-	// Subcommand dispatch
-	if len(args) > 0 {
-		switch args[0] {
-		case "resources":
-			if err := cfg.ValidateProject(); err != nil {
-				return err
-			}
-			if len(args) < 2 {
-				fmt.Println("Usage: hawkeye connections resources <connection-uuid>")
-				return nil
-			}
-			return cmdConnectionResources(cfg, args[1])
-		case "types":
-			return cmdConnectionTypes()
-		case "info":
-			if err := cfg.Validate(); err != nil {
-				return err
-			}
-			if len(args) < 2 {
-				fmt.Println("Usage: hawkeye connections info <connection-uuid>")
-				return nil
+	for _, s := range resp.Instructions {
+		if s.UUID == instrUUID {
+			if jsonOutput {
+				return printJSON(s)
 			}
-			return cmdConnectionInfo(cfg, args[1])
-		case "create":
-			if err := cfg.Validate(); err != nil {
-				return err
+			instr := service.FormatInstruction(s)
+			display.Header(fmt.Sprintf("Instruction: %s", instr.Name))
+			display.Info("UUID:", instr.UUID)
+			display.Info("Type:", instr.Type)
+			status := "enabled"
+			if !instr.Enabled {
+				status = "disabled"
 			}
-			return cmdConnectionCreate(cfg, args[1:])
-		case "sync":
-			if err := cfg.Validate(); err != nil {
-				return err
+			display.Info("Status:", status)
+			if instr.Content != "" {
+				fmt.Printf("\n  %sContent:%s\n", display.Dim, display.Reset)
+				fmt.Printf("  %s\n", instr.Content)
 			}
-			return cmdConnectionSync(cfg, args[1:])
-		case "add":
-			if err := cfg.ValidateProject(); err != nil {
-				return err
+			fmt.Println()
+			return nil
+		}
+	}
+
+	return fmt.Errorf("instruction %s not found", instrUUID)
+}
+
+func cmdInstructionCreate(cfg *config.Config, args []string) error {
+	if len(args) == 0 {
+		fmt.Println("Usage: hawkeye instructions create <name> --type <filter|system|grouping|rca> --content <text>|--content-file <path>")
+		return nil
+	}
+
+	var instrType, content, contentFile string
+	var positional []string
+
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--type", "-t":
+			if i+1 < len(args) {
+				i++
+				instrType = args[i]
+			} else {
+				return fmt.Errorf("--type requires a value")
 			}
-			return cmdConnectionAdd(cfg, args[1:])
-		case "remove":
-			if err := cfg.ValidateProject(); err != nil {
-				return err
+		case "--content", "-c":
+			if i+1 < len(args) {
+				i++
+				content = args[i]
+			} else {
+				return fmt.Errorf("--content requires a value")
 			}
-			return cmdConnectionRemove(cfg, args[1:])
-		case "project":
-			if err := cfg.ValidateProject(); err != nil {
-				return err
+		case "--content-file":
+			if i+1 < len(args) {
+				i++
+				contentFile = args[i]
+			} else {
+				return fmt.Errorf("--content-file requires a value")
 			}
-			return cmdConnectionProject(cfg, args[1:])
+		default:
+			positional = append(positional, args[i])
 		}
 	}
 
-	if err := cfg.ValidateProject(); err != nil {
+	content, err := resolveContent(content, contentFile)
+	if err != nil {
 		return err
 	}
 
-	client := api.NewClient(cfg)
+	name := strings.Join(positional, " ")
+	if name == "" || instrType == "" || content == "" {
+		fmt.Println("Usage: hawkeye instructions create <name> --type <filter|system|grouping|rca> --content <text>|--content-file <path>")
+		return nil
+	}
 
-	resp, err := client.ListConnections(cfg.ProjectID)
+	if !service.ValidInstructionType(instrType) {
+		return fmt.Errorf("invalid instruction type: %s (valid: filter, system, grouping, rca)", instrType)
+	}
+
+	client := api.NewClient(cfg)
+	resp, err := client.CreateInstruction(cfg.ProjectID, name, instrType, content)
 	if err != nil {
-		return fmt.Errorf("listing connections: %w", err)
+		return fmt.Errorf("creating instruction: %w", err)
 	}
 
 	if jsonOutput {
-		return printJSON(resp.Specs)
+		return printJSON(resp.Instruction)
 	}
 
-	display.Header(fmt.Sprintf("Connections (%d)", len(resp.Specs)))
+	if resp.Instruction != nil {
+		display.Success(fmt.Sprintf("Instruction created: %s (%s)", resp.Instruction.Name, resp.Instruction.UUID))
+	} else {
+		display.Success("Instruction created")
+	}
+	return nil
+}
 
-	if len(resp.Specs) == 0 {
-		display.Warn("No connections found.")
+func cmdInstructionUpdate(cfg *config.Config, args []string) error {
+	if len(args) == 0 {
+		fmt.Println("Usage: hawkeye instructions update <uuid> [--name <name>] [--content <text>|--content-file <path>] [--yes]")
 		return nil
 	}
 
-	for _, spec := range resp.Specs {
-		c := service.FormatConnection(spec)
-		syncIcon := "🔄"
-		if c.SyncState == "SYNCED" || c.SyncState == "SYNC_STATE_SYNCED" {
-			syncIcon = "✅"
+	instrUUID := args[0]
+	var name, content, contentFile string
+	var yes bool
+
+	for i := 1; i < len(args); i++ {
+		switch args[i] {
+		case "--name":
+			if i+1 < len(args) {
+				i++
+				name = args[i]
+			} else {
+				return fmt.Errorf("--name requires a value")
+			}
+		case "--content", "-c":
+			if i+1 < len(args) {
+				i++
+				content = args[i]
+			} else {
+				return fmt.Errorf("--content requires a value")
+			}
+		case "--content-file":
+			if i+1 < len(args) {
+				i++
+				contentFile = args[i]
+			} else {
+				return fmt.Errorf("--content-file requires a value")
+			}
+		case "--yes", "-y":
+			yes = true
 		}
-		fmt.Printf("\n  %s %s%s%s  %s(%s)%s\n", syncIcon, display.Bold, c.Name, display.Reset,
-			display.Dim, c.Type, display.Reset)
-		fmt.Printf("    %sUUID:%s  %s\n", display.Dim, display.Reset, c.UUID)
-		fmt.Printf("    %sSync:%s  %s   %sTraining:%s %s\n",
-			display.Dim, display.Reset, c.SyncState,
-			display.Dim, display.Reset, c.TrainingState)
 	}
 
-	fmt.Println()
-	fmt.Printf("  %sTip:%s Run %shawkeye connections resources <uuid>%s to list resources.\n\n",
-		display.Dim, display.Reset, display.Cyan, display.Reset)
-
-	return nil
-}
-
-func cmdConnectionTypes() error {
-	types := service.GetConnectionTypes()
-
-	if jsonOutput {
-		return printJSON(types)
+	content, err := resolveContent(content, contentFile)
+	if err != nil {
+		return err
 	}
 
-	display.Header(fmt.Sprintf("Supported Connection Types (%d)", len(types)))
-	for _, ct := range types {
-		fmt.Printf("  • %s%-15s%s %s%s%s\n", display.Bold, ct.Type, display.Reset,
-			display.Dim, ct.Description, display.Reset)
+	if name == "" && content == "" {
+		return fmt.Errorf("nothing to update: specify --name, --content, or --content-file")
 	}
-	fmt.Println()
-	return nil
-}
 
-func cmdConnectionInfo(cfg *config.Config, connUUID string) error {
 	client := api.NewClient(cfg)
-	resp, err := client.GetConnectionInfo(connUUID)
+	if content != "" && !yes {
+		// A content change is shown as a diff first and applied with --yes.
+		list, err := client.ListInstructions(cfg.ProjectID)
+		if err != nil {
+			return fmt.Errorf("listing instructions: %w", err)
+		}
+		var current *api.InstructionSpec
+		for i := range list.Instructions {
+			if list.Instructions[i].UUID == instrUUID {
+				current = &list.Instructions[i]
+			}
+		}
+		var diff []service.DiffLine
+		if current != nil {
+			diff = service.DiffLines(current.Content, content)
+		}
+		switch {
+		case current == nil:
+			// Not in the active project, so there is nothing to diff
+			// against; the UUID alone still identifies it.
+			fmt.Fprintf(os.Stderr, "note: instruction %s is not in the active project; updating it without a preview\n", instrUUID)
+		case service.DiffChanged(diff):
+			if jsonOutput {
+				return printJSON(map[string]any{"uuid": instrUUID, "name": name, "old_content": current.Content, "content": content, "applied": false})
+			}
+			display.Header(fmt.Sprintf("Instruction Update: %s", current.Name))
+			if name != "" && name != current.Name {
+				display.Info("Name:", fmt.Sprintf("%s → %s", current.Name, name))
+			}
+			printInstructionDiff(current.Content, content)
+			fmt.Printf("  %sTip:%s Re-run with %s--yes%s to apply this change.\n\n",
+				display.Dim, display.Reset, display.Cyan, display.Reset)
+			return nil
+		case name == "" || name == current.Name:
+			if jsonOutput {
+				return printJSON(current)
+			}
+			display.Success(fmt.Sprintf("Instruction %s is unchanged", instrUUID))
+			return nil
+		default:
+			// Only the name changes; no review needed.
+			content = ""
+		}
+	}
+
+	resp, err := client.UpdateInstruction(instrUUID, name, content)
 	if err != nil {
-		return fmt.Errorf("getting connection info: %w", err)
+		return fmt.Errorf("updating instruction: %w", err)
 	}
 
 	if jsonOutput {
-		return printJSON(resp.Spec)
+		return printJSON(resp.Instruction)
 	}
 
-	c := service.FormatConnectionDetail(resp.Spec)
-	display.Header(fmt.Sprintf("Connection: %s", c.Name))
-	display.Info("UUID:", c.UUID)
-	display.Info("Type:", c.Type)
-	display.Info("Sync:", c.SyncState)
-	display.Info("Training:", c.TrainingState)
-	if c.CreateTime != "" {
-		display.Info("Created:", c.CreateTime)
+	display.Success(fmt.Sprintf("Instruction %s updated", instrUUID))
+	if name != "" {
+		display.Info("Name:", name)
+	}
+	if content != "" {
+		display.Info("Content:", fmt.Sprintf("%d lines", len(strings.Split(content, "\n"))))
 	}
-	fmt.Println()
 	return nil
 }
 
-func cmdConnectionCreate(cfg *config.Config, args []string) error {
-	if len(args) < 2 {
-		fmt.Println("Usage: hawkeye connections create <type> <name> [--key value ...]")
-		fmt.Println()
-		fmt.Println("Run 'hawkeye connections types' to see supported types.")
-		return nil
+func cmdInstructionExport(cfg *config.Config, args []string) error {
+	var outPath string
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--out", "-o":
+			if i+1 < len(args) {
+				i++
+				outPath = args[i]
+			} else {
+				return fmt.Errorf("--out requires a value")
+			}
+		}
 	}
 
-	connType := args[0]
-	connName := args[1]
-	connConfig := make(map[string]string)
+	client := api.NewClient(cfg)
+	resp, err := client.ListInstructions(cfg.ProjectID)
+	if err != nil {
+		return fmt.Errorf("listing instructions: %w", err)
+	}
 
-	for i := 2; i < len(args); i++ {
-		if strings.HasPrefix(args[i], "--") && i+1 < len(args) {
-			key := strings.TrimPrefix(args[i], "--")
-			i++
-			connConfig[key] = args[i]
-		}
+	manifest := service.ExportInstructions(resp.Instructions)
+	if jsonOutput && outPath == "" {
+		return printJSON(manifest)
 	}
 
-	client := api.NewClient(cfg)
-	resp, err := client.CreateConnection(connName, connType, connConfig)
+	data, err := service.MarshalInstructionManifest(manifest)
 	if err != nil {
-		return fmt.Errorf("creating connection: %w", err)
+		return fmt.Errorf("encoding instructions: %w", err)
 	}
 
-	if jsonOutput {
-		return printJSON(resp.Spec)
+	if outPath == "" {
+		fmt.Print(string(data))
+		return nil
 	}
 
-	if resp.Spec != nil {
-		display.Success(fmt.Sprintf("Connection created: %s (%s)", resp.Spec.Name, resp.Spec.UUID))
-	} else {
-		display.Success("Connection created")
+	if err := os.WriteFile(outPath, data, 0644); err != nil {
+		return fmt.Errorf("writing %s: %w", outPath, err)
 	}
+	display.Success(fmt.Sprintf("Exported %d instructions to %s", len(manifest.Instructions), outPath))
 	return nil
 }
 
-func cmdConnectionSync(cfg *config.Config, args []string) error {
-	if len(args) == 0 {
-		fmt.Println("Usage: hawkeye connections sync <connection-uuid> [--timeout 300]")
+func cmdInstructionImport(cfg *config.Config, args []string) error {
+	var prune, dryRun, yes bool
+	var positional []string
+	for _, a := range args {
+		switch a {
+		case "--prune":
+			prune = true
+		case "--dry-run":
+			dryRun = true
+		case "--yes", "-y":
+			yes = true
+		default:
+			positional = append(positional, a)
+		}
+	}
+
+	if len(positional) == 0 {
+		fmt.Println("Usage: hawkeye instructions import <file.yaml> [--prune] [--dry-run] [--yes]")
 		return nil
 	}
 
-	connUUID := args[0]
-	timeout := 300
+	data, err := os.ReadFile(positional[0])
+	if err != nil {
+		return fmt.Errorf("reading %s: %w", positional[0], err)
+	}
+	manifest, err := service.ParseInstructionManifest(data)
+	if err != nil {
+		return err
+	}
 
-	for i := 1; i < len(args); i++ {
-		if args[i] == "--timeout" && i+1 < len(args) {
-			i++
-			n, err := strconv.Atoi(args[i])
-			if err != nil {
-				return fmt.Errorf("invalid timeout: %s", args[i])
-			}
-			timeout = n
-		}
+	client := api.NewClient(cfg)
+	resp, err := client.ListInstructions(cfg.ProjectID)
+	if err != nil {
+		return fmt.Errorf("listing instructions: %w", err)
 	}
 
-	display.Spinner(fmt.Sprintf("Waiting for connection %s to sync (timeout: %ds)...", connUUID, timeout))
+	changes := service.PlanInstructionSync(manifest.Instructions, resp.Instructions, prune)
+	pending := service.HasInstructionChanges(changes)
 
-	client := api.NewClient(cfg)
-	resp, err := client.WaitForConnectionSync(connUUID, timeout)
-	display.ClearLine()
+	if jsonOutput && (dryRun || !yes || !pending) {
+		return printJSON(changes)
+	}
 
-	if err != nil {
-		return fmt.Errorf("sync: %w", err)
+	if !jsonOutput {
+		title := "Instruction Import"
+		if dryRun {
+			title += " (dry run)"
+		}
+		display.Header(title)
+		printInstructionPlan(changes)
+	}
+
+	if dryRun {
+		fmt.Printf("\n  %sTip:%s Re-run without %s--dry-run%s to apply these changes.\n\n",
+			display.Dim, display.Reset, display.Cyan, display.Reset)
+		return nil
+	}
+	if !pending {
+		display.Success("Instructions are up to date")
+		return nil
+	}
+	if !yes {
+		// Instructions steer every investigation, so changes are applied
+		// only once their diff has been reviewed.
+		fmt.Printf("  %sTip:%s Re-run with %s--yes%s to apply these changes.\n\n",
+			display.Dim, display.Reset, display.Cyan, display.Reset)
+		return nil
+	}
+
+	if err := applyInstructionChanges(client, cfg.ProjectID, changes); err != nil {
+		return err
 	}
 
 	if jsonOutput {
-		return printJSON(resp.Spec)
+		return printJSON(changes)
 	}
 
-	display.Success(fmt.Sprintf("Connection %s synced", connUUID))
+	counts := service.CountInstructionChanges(changes)
+	display.Success(fmt.Sprintf("Applied: %d created, %d updated, %d deleted, %d unchanged",
+		counts[service.SyncCreate], counts[service.SyncUpdate], counts[service.SyncDelete], counts[service.SyncUnchanged]))
 	return nil
 }
 
-func cmdConnectionAdd(cfg *config.Config, args []string) error {
-	if len(args) == 0 {
-		fmt.Println("Usage: hawkeye connections add <connection-uuid> [--project <uuid>]")
-		return nil
+// printInstructionPlan renders a sync plan as a +/~/- list, with a line
+// diff under each change to an instruction's content.
+func printInstructionPlan(changes []service.InstructionChange) {
+	if len(changes) == 0 {
+		display.Warn("No instructions in manifest.")
+		return
+	}
+	diffed := false
+	for _, c := range changes {
+		switch c.Action {
+		case service.SyncCreate:
+			fmt.Printf("  %s+ create%s  %s[%s]%s %s\n", display.Green, display.Reset, display.Dim, c.Type, display.Reset, c.Name)
+		case service.SyncUpdate:
+			var what []string
+			if c.ContentChanged {
+				what = append(what, "content")
+			}
+			if c.EnabledChanged {
+				if c.Enabled {
+					what = append(what, "enable")
+				} else {
+					what = append(what, "disable")
+				}
+			}
+			fmt.Printf("  %s~ update%s  %s[%s]%s %s %s(%s)%s\n", display.Yellow, display.Reset, display.Dim, c.Type, display.Reset,
+				c.Name, display.Dim, strings.Join(what, ", "), display.Reset)
+		case service.SyncDelete:
+			fmt.Printf("  %s- delete%s  %s[%s]%s %s\n", display.Red, display.Reset, display.Dim, c.Type, display.Reset, c.Name)
+		default:
+			fmt.Printf("  %s= same    [%s] %s%s\n", display.Dim, c.Type, c.Name, display.Reset)
+		}
+		diffed = c.Action == service.SyncCreate || c.Action == service.SyncDelete || c.ContentChanged
+		if diffed {
+			printInstructionDiff(c.OldContent, c.Content)
+		}
+	}
+	if !diffed {
+		fmt.Println()
 	}
+}
 
-	connUUID := args[0]
-	projectUUID := cfg.ProjectID
+// printInstructionDiff shows how an instruction's content changes, set off
+// by blank lines from the plan around it.
+func printInstructionDiff(before, after string) {
+	fmt.Println()
+	printLineDiff(service.DiffLines(before, after))
+	fmt.Println()
+}
+
+// applyInstructionChanges executes a sync plan against the API. It stops at
+// the first failure so a partial apply can be re-run safely.
+func applyInstructionChanges(client *api.Client, projectID string, changes []service.InstructionChange) error {
+	for _, c := range changes {
+		switch c.Action {
+		case service.SyncCreate:
+			resp, err := client.CreateInstruction(projectID, c.Name, c.Type, c.Content)
+			if err != nil {
+				return fmt.Errorf("creating instruction %q: %w", c.Name, err)
+			}
+			if !c.Enabled && resp.Instruction != nil {
+				if err := client.UpdateInstructionStatus(resp.Instruction.UUID, false); err != nil {
+					return fmt.Errorf("disabling instruction %q: %w", c.Name, err)
+				}
+			}
+		case service.SyncUpdate:
+			if c.ContentChanged {
+				if _, err := client.UpdateInstruction(c.UUID, "", c.Content); err != nil {
+					return fmt.Errorf("updating instruction %q: %w", c.Name, err)
+				}
+			}
+			if c.EnabledChanged {
+				if err := client.UpdateInstructionStatus(c.UUID, c.Enabled); err != nil {
+					return fmt.Errorf("updating instruction %q: %w", c.Name, err)
+				}
+			}
+		case service.SyncDelete:
+			if err := client.DeleteInstruction(c.UUID); err != nil {
+				return fmt.Errorf("deleting instruction %q: %w", c.Name, err)
+			}
+		}
+	}
+	return nil
+}
 
-	for i := 1; i < len(args); i++ {
-		if args[i] == "--project" && i+1 < len(args) {
-			i++
-			projectUUID = args[i]
+func cmdInstructionToggle(cfg *config.Config, args []string, enable bool) error {
+	if len(args) == 0 {
+		action := "enable"
+		if !enable {
+			action = "disable"
 		}
+		fmt.Printf("Usage: hawkeye instructions %s <uuid>\n", action)
+		return nil
 	}
 
 	client := api.NewClient(cfg)
-	if err := client.AddConnectionToProject(projectUUID, connUUID); err != nil {
-		return fmt.Errorf("adding connection to project: %w", err)
+	if err := client.UpdateInstructionStatus(args[0], enable); err != nil {
+		return fmt.Errorf("updating instruction: %w", err)
 	}
 
 	if jsonOutput {
-		return printJSON(map[string]string{"added": connUUID, "project": projectUUID})
+		return printJSON(map[string]any{"uuid": args[0], "enabled": enable})
 	}
 
-	display.Success(fmt.Sprintf("Connection %s added to project %s", connUUID, projectUUID))
+	action := "enabled"
+	if !enable {
+		action = "disabled"
+	}
+	display.Success(fmt.Sprintf("Instruction %s %s", args[0], action))
 	return nil
 }
 
-func cmdConnectionRemove(cfg *config.Config, args []string) error {
+func cmdInstructionDelete(cfg *config.Config, args []string) error {
 	if len(args) == 0 {
-		fmt.Println("Usage: hawkeye connections remove <connection-uuid> [--project <uuid>] [--confirm]")
+		fmt.Println("Usage: hawkeye instructions delete <uuid> [--confirm]")
 		return nil
 	}
 
-	connUUID := args[0]
-	projectUUID := cfg.ProjectID
+	instrUUID := args[0]
 	confirmed := false
-
-	for i := 1; i < len(args); i++ {
-		switch args[i] {
-		case "--project":
-			if i+1 < len(args) {
-				i++
-				projectUUID = args[i]
-			}
-		case "--confirm", "-y":
+	for _, a := range args[1:] {
+		if a == "--confirm" || a == "-y" {
 			confirmed = true
 		}
 	}
 
 	if !confirmed {
-		fmt.Printf("Remove connection %s from project %s? Use --confirm to proceed.\n", connUUID, projectUUID)
+		fmt.Printf("Delete instruction %s? Use --confirm to proceed.\n", instrUUID)
 		return nil
 	}
 
 	client := api.NewClient(cfg)
-	if err := client.RemoveConnectionFromProject(projectUUID, connUUID); err != nil {
-		return fmt.Errorf("removing connection from project: %w", err)
+	if err := client.DeleteInstruction(instrUUID); err != nil {
+		return fmt.Errorf("deleting instruction: %w", err)
 	}
 
 	if jsonOutput {
-		return printJSON(map[string]string{"removed": connUUID, "project": projectUUID})
+		return printJSON(map[string]string{"deleted": instrUUID})
 	}
 
-	display.Success(fmt.Sprintf("Connection %s removed from project %s", connUUID, projectUUID))
+	display.Success(fmt.Sprintf("Instruction %s deleted", instrUUID))
 	return nil
 }
 
-func cmdConnectionProject(cfg *config.Config, args []string) error {
-	projectUUID := cfg.ProjectID
+func cmdInstructionValidate(cfg *config.Config, args []string) error {
+	var instrType, content, contentFile string
 
 	for i := 0; i < len(args); i++ {
-		if args[i] == "--project" && i+1 < len(args) {
-			i++
-			projectUUID = args[i]
+		switch args[i] {
+		case "--type", "-t":
+			if i+1 < len(args) {
+				i++
+				instrType = args[i]
+			}
+		case "--content", "-c":
+			if i+1 < len(args) {
+				i++
+				content = args[i]
+			}
+		case "--content-file":
+			if i+1 < len(args) {
+				i++
+				contentFile = args[i]
+			} else {
+				return fmt.Errorf("--content-file requires a value")
+			}
 		}
 	}
 
-	client := api.NewClient(cfg)
-	resp, err := client.ListProjectConnections(projectUUID)
+	content, err := resolveContent(content, contentFile)
 	if err != nil {
-		return fmt.Errorf("listing project connections: %w", err)
-	}
-
-	if jsonOutput {
-		return printJSON(resp.Specs)
+		return err
 	}
 
-	display.Header(fmt.Sprintf("Connections in project %s (%d)", projectUUID, len(resp.Specs)))
-
-	if len(resp.Specs) == 0 {
-		display.Warn("No connections found in this project.")
+	if instrType == "" || content == "" {
+		fmt.Println("Usage: hawkeye instructions validate --type <type> --content <text>|--content-file <path>")
 		return nil
 	}
 
-	for _, spec := range resp.Specs {
-		c := service.FormatConnection(spec)
-		fmt.Printf("  • %s%-20s%s  %s(%s)%s  %s\n",
-			display.Bold, c.Name, display.Reset,
-			display.Dim, c.Type, display.Reset, c.UUID)
-	}
-	fmt.Println()
-	return nil
-}
-
-func cmdConnectionResources(cfg *config.Config, connUUID string) error {
 	client := api.NewClient(cfg)
-
-	resp, err := client.ListConnectionResources(connUUID, 100)
+	resp, err := client.ValidateInstruction(instrType, content)
 	if err != nil {
-		return fmt.Errorf("listing resources: %w", err)
+		return fmt.Errorf("validating instruction: %w", err)
 	}
 
 	if jsonOutput {
-		return printJSON(resp.Specs)
-	}
-
-	resources := service.FormatResources(resp.Specs)
-
-	display.Header(fmt.Sprintf("Resources for %s (%d)", connUUID, len(resources)))
-
-	if len(resources) == 0 {
-		display.Warn("No resources found.")
-		return nil
+		return printJSON(resp)
 	}
 
-	for _, r := range resources {
-		fmt.Printf("  • %s%-30s%s  %s%s%s\n",
-			display.Bold, r.Name, display.Reset,
-			display.Dim, r.TelemetryType, display.Reset)
+	if resp.Instruction != nil {
+		display.Success("Instruction content is valid")
+		display.Info("Name:", resp.Instruction.Name)
+		display.Info("Type:", resp.Instruction.Type)
+		display.Info("Content:", resp.Instruction.Content)
+	} else {
+		display.Error("Instruction validation failed")
 	}
-
-	fmt.Println()
 	return nil
 }
 
-// ─── discover ───────────────────────────────────────────────────────────────
-
-func cmdDiscover(args []string) error {
-	cfg, err := config.Load(activeProfile)
-	if err != nil {
-		return err
-	}
-	if err := cfg.ValidateProject(); err != nil {
-		return err
+func cmdInstructionApply(cfg *config.Config, args []string) error {
+	if len(args) == 0 {
+		fmt.Println("Usage: hawkeye instructions apply <session-uuid> --type <type> --content <text>")
+		return nil
 	}
 
-	var telemetryType, connectionType string
+	sessionUUID := cfg.ResolveSession(args[0])
+	var instrType, content string
 
-	for i := 0; i < len(args); i++ {
+	for i := 1; i < len(args); i++ {
 		switch args[i] {
-		case "--telemetry-type":
+		case "--type", "-t":
 			if i+1 < len(args) {
 				i++
-				telemetryType = args[i]
+				instrType = args[i]
 			}
-		case "--connection-type":
+		case "--content", "-c":
 			if i+1 < len(args) {
 				i++
-				connectionType = args[i]
+				content = args[i]
 			}
-		case "--project":
+		}
+	}
+
+	if instrType == "" || content == "" {
+		fmt.Println("Usage: hawkeye instructions apply <session-uuid> --type <type> --content <text>")
+		return nil
+	}
+
+	client := api.NewClient(cfg)
+	if err := client.ApplySessionInstruction(sessionUUID, instrType, content); err != nil {
+		return fmt.Errorf("applying instruction: %w", err)
+	}
+
+	if jsonOutput {
+		return printJSON(map[string]string{"applied": sessionUUID, "type": instrType})
+	}
+
+	display.Success(fmt.Sprintf("Instruction applied to session %s", sessionUUID))
+	return nil
+}
+
+// ─── apply ──────────────────────────────────────────────────────────────────
+
+func cmdApply(args []string) error {
+	var file string
+	var dryRun, yes bool
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "-f", "--file":
 			if i+1 < len(args) {
 				i++
-				cfg.ProjectID = args[i]
+				file = args[i]
+			} else {
+				return fmt.Errorf("--file requires a value")
 			}
+		case "--dry-run":
+			dryRun = true
+		case "--yes", "-y":
+			yes = true
 		}
 	}
 
-	client := api.NewClient(cfg)
-	resp, err := client.DiscoverProjectResources(cfg.ProjectID, telemetryType, connectionType)
+	if file == "" {
+		fmt.Println("Usage: hawkeye apply -f <project.yaml> [--dry-run] [--yes]")
+		return nil
+	}
+
+	data, err := os.ReadFile(file)
 	if err != nil {
-		return fmt.Errorf("discovering resources: %w", err)
+		return fmt.Errorf("reading %s: %w", file, err)
+	}
+	manifest, err := service.ParseProjectManifest(data)
+	if err != nil {
+		return err
 	}
 
-	if jsonOutput {
-		return printJSON(resp.Resources)
+	cfg, err := config.Load(activeProfile)
+	if err != nil {
+		return err
+	}
+	if err := cfg.Validate(); err != nil {
+		return err
 	}
 
-	resources := service.FormatDiscoveredResources(resp.Resources)
-	display.Header(fmt.Sprintf("Discovered Resources (%d)", len(resources)))
+	client := api.NewClient(cfg)
 
-	if len(resources) == 0 {
-		display.Warn("No resources found.")
-		return nil
+	projResp, err := client.ListProjects()
+	if err != nil {
+		return fmt.Errorf("listing projects: %w", err)
+	}
+	var existing *api.ProjectDetail
+	var attached []api.ConnectionSpec
+	var instructions []api.InstructionSpec
+	if p := service.FindProject(service.FilterSystemProjects(projResp.Specs), manifest.Project.Name); p != nil {
+		detail, err := client.GetProject(p.UUID)
+		if err != nil {
+			return fmt.Errorf("getting project: %w", err)
+		}
+		existing = detail.Spec
+		if existing == nil {
+			existing = &api.ProjectDetail{UUID: p.UUID, Name: p.Name}
+		}
+		connResp, err := client.ListProjectConnections(p.UUID)
+		if err != nil {
+			return fmt.Errorf("listing project connections: %w", err)
+		}
+		attached = connResp.Specs
+		instrResp, err := client.ListInstructions(p.UUID)
+		if err != nil {
+			return fmt.Errorf("listing instructions: %w", err)
+		}
+		instructions = instrResp.Instructions
 	}
 
-	for _, r := range resources {
-		fmt.Printf("  • %s%-30s%s  %s%s%s  %s(%s)%s\n",
-			display.Bold, r.Name, display.Reset,
-			display.Dim, r.TelemetryType, display.Reset,
-			display.Dim, r.ConnectionUUID, display.Reset)
+	var desiredConns []api.ConnectionSpec
+	if len(manifest.Connections) > 0 {
+		allConns, err := client.ListConnections("")
+		if err != nil {
+			return fmt.Errorf("listing connections: %w", err)
+		}
+		desiredConns, err = service.ResolveConnections(manifest.Connections, allConns.Specs)
+		if err != nil {
+			return err
+		}
 	}
 
-	fmt.Println()
-	return nil
-}
+	plan := service.PlanProjectApply(manifest, existing, desiredConns, attached, instructions)
 
-// ─── resource-types ─────────────────────────────────────────────────────────
+	if jsonOutput && dryRun {
+		return printJSON(plan)
+	}
 
-func cmdResourceTypes(args []string) error {
-	var connectionType, telemetryType string
+	if !jsonOutput {
+		title := fmt.Sprintf("Apply: %s", plan.Name)
+		if dryRun {
+			title += " (dry run)"
+		}
+		display.Header(title)
+		printProjectPlan(plan)
+	}
 
-	if len(args) >= 1 {
-		connectionType = args[0]
+	if dryRun || !plan.HasChanges() {
+		if !jsonOutput && !plan.HasChanges() {
+			display.Success("Project is up to date")
+		}
+		if jsonOutput {
+			return printJSON(plan)
+		}
+		return nil
 	}
-	if len(args) >= 2 {
-		telemetryType = args[1]
+	if !yes && service.HasInstructionChanges(plan.Instructions) {
+		if jsonOutput {
+			return printJSON(plan)
+		}
+		fmt.Printf("  %sTip:%s The plan changes instructions; re-run with %s--yes%s to apply it.\n\n",
+			display.Dim, display.Reset, display.Cyan, display.Reset)
+		return nil
 	}
 
-	types := service.GetResourceTypes(connectionType, telemetryType)
+	switch plan.ProjectAction {
+	case service.SyncCreate:
+		resp, err := client.CreateProject(plan.Name, plan.Description)
+		if err != nil {
+			return fmt.Errorf("creating project: %w", err)
+		}
+		if resp.Spec == nil || resp.Spec.UUID == "" {
+			return fmt.Errorf("creating project: server returned no project UUID")
+		}
+		plan.ProjectUUID = resp.Spec.UUID
+	case service.SyncUpdate:
+		if _, err := client.UpdateProject(plan.ProjectUUID, "", plan.Description); err != nil {
+			return fmt.Errorf("updating project: %w", err)
+		}
+	}
 
-	if jsonOutput {
-		return printJSON(types)
+	for _, c := range plan.AttachConnections {
+		if err := client.AddConnectionToProject(plan.ProjectUUID, c.UUID); err != nil {
+			return fmt.Errorf("attaching connection %q: %w", c.Name, err)
+		}
 	}
 
-	display.Header(fmt.Sprintf("Resource Types (%d)", len(types)))
+	if err := applyInstructionChanges(client, plan.ProjectUUID, plan.Instructions); err != nil {
+		return err
+	}
 
-	if len(types) == 0 {
-		display.Warn("No resource types found for the given parameters.")
-		return nil
+	if jsonOutput {
+		return printJSON(plan)
 	}
 
-	for _, rt := range types {
-		fmt.Printf("  • %s%-25s%s %s%s%s\n",
-			display.Bold, rt.Type, display.Reset,
-			display.Dim, rt.Description, display.Reset)
+	display.Success(fmt.Sprintf("Project %s applied (%s)", plan.Name, plan.ProjectUUID))
+	fmt.Printf("\n  %sTip:%s Run %shawkeye set project %s%s to make it the active project.\n\n",
+		display.Dim, display.Reset, display.Cyan, plan.ProjectUUID, display.Reset)
+	return nil
+}
+
+// printProjectPlan renders the project, connection and instruction changes
+// of an apply plan.
+func printProjectPlan(plan service.ProjectPlan) {
+	switch plan.ProjectAction {
+	case service.SyncCreate:
+		fmt.Printf("  %s+ create%s  project %s\n", display.Green, display.Reset, plan.Name)
+	case service.SyncUpdate:
+		fmt.Printf("  %s~ update%s  project %s %s(description)%s\n", display.Yellow, display.Reset, plan.Name, display.Dim, display.Reset)
+	default:
+		fmt.Printf("  %s= same    project %s%s\n", display.Dim, plan.Name, display.Reset)
+	}
+	for _, c := range plan.AttachConnections {
+		fmt.Printf("  %s+ attach%s  connection %s %s(%s)%s\n", display.Green, display.Reset, c.Name, display.Dim, c.UUID, display.Reset)
 	}
-
 	fmt.Println()
-	return nil
+	if len(plan.Instructions) > 0 {
+		printInstructionPlan(plan.Instructions)
+	}
 }
 
-// ─── session-report ─────────────────────────────────────────────────────────
+// ─── rerun ──────────────────────────────────────────────────────────────────
 
-func cmdSessionReport(args []string) error {
+func cmdRerun(args []string) error {
 	cfg, err := config.Load(activeProfile)
 	if err != nil {
 		return err
@@ -1933,104 +10125,208 @@ func cmdSessionReport(args []string) error {
 		return err
 	}
 
-	if len(args) == 0 {
-		if cfg.LastSession != "" {
-			args = []string{cfg.LastSession}
-		} else {
-			fmt.Println("Usage: hawkeye session-report <session-uuid> [<uuid>...]")
-			return nil
+	var positional []string
+	var status string
+	var since time.Time
+	var bulk, dryRun bool
+	limit := 20
+	concurrency := 4
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--status":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--status requires a value")
+			}
+			i++
+			status, bulk = args[i], true
+		case "--since":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--since requires a value")
+			}
+			i++
+			t, err := service.ParseSince(args[i], time.Now())
+			if err != nil {
+				return err
+			}
+			since, bulk = t, true
+		case "-n", "--limit":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--limit requires a value")
+			}
+			i++
+			n, err := strconv.Atoi(args[i])
+			if err != nil || n < 1 {
+				return fmt.Errorf("--limit must be a positive number")
+			}
+			limit = n
+		case "--concurrency":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--concurrency requires a value")
+			}
+			i++
+			n, err := strconv.Atoi(args[i])
+			if err != nil || n < 1 {
+				return fmt.Errorf("invalid concurrency: %s", args[i])
+			}
+			concurrency = n
+		case "--dry-run":
+			dryRun = true
+		default:
+			positional = append(positional, args[i])
 		}
 	}
 
 	client := api.NewClient(cfg)
+	if bulk {
+		if len(positional) > 0 {
+			return fmt.Errorf("give either a session or --status/--since filters, not both")
+		}
+		return runBulkRerun(cfg, client, status, since, limit, concurrency, dryRun)
+	}
+
+	sessionUUID := ""
+	if len(positional) > 0 {
+		sessionUUID = cfg.ResolveSession(positional[0])
+	} else if cfg.LastSession != "" {
+		sessionUUID = cfg.LastSession
+	} else {
+		fmt.Println("Usage: hawkeye rerun <session-uuid>")
+		fmt.Println("       hawkeye rerun --status <status> [--since 24h] [--limit 20] [--concurrency 4] [--dry-run]")
+		return nil
+	}
 
-	items, err := client.GetSessionReport(cfg.ProjectID, args)
+	resp, err := client.RerunSession(sessionUUID)
 	if err != nil {
-		return fmt.Errorf("getting session report: %w", err)
+		return fmt.Errorf("rerunning session: %w", err)
 	}
 
 	if jsonOutput {
-		return printJSON(items)
+		return printJSON(resp)
 	}
 
-	display.Header(fmt.Sprintf("Session Reports (%d)", len(items)))
-
-	for _, item := range items {
-		fmt.Printf("\n  %s%s%s\n", display.Bold, item.Prompt, display.Reset)
-		if item.Summary != "" {
-			fmt.Printf("  %sSummary:%s %s\n", display.Dim, display.Reset, truncate(item.Summary, 120))
-		}
-		if item.TimeSaved > 0 {
-			fmt.Printf("  %sTime saved:%s %d min\n", display.Blue+display.Bold, display.Reset, item.TimeSaved/60)
-		}
-		if item.SessionLink != "" {
-			fmt.Printf("  %sLink:%s %s\n", display.Dim, display.Reset, item.SessionLink)
-		}
+	display.Success(fmt.Sprintf("Rerun started for session %s", sessionUUID))
+	if resp.SessionUUID != "" && resp.SessionUUID != sessionUUID {
+		display.Info("New session:", resp.SessionUUID)
 	}
-
-	fmt.Println()
 	return nil
 }
 
-// ─── investigate-alert ──────────────────────────────────────────────────────
-
-func cmdInvestigateAlert(args []string) error {
-	if len(args) == 0 {
-		fmt.Println("Usage: hawkeye investigate-alert <alert-id> [--project <uuid>]")
-		return nil
+// runBulkRerun reruns the sessions matching the status and since filters,
+// with bounded concurrency, and prints the outcome for each.
+func runBulkRerun(cfg *config.Config, client *api.Client, status string, since time.Time, limit, concurrency int, dryRun bool) error {
+	from := ""
+	if !since.IsZero() {
+		from = since.UTC().Format(time.RFC3339)
 	}
-
-	alertID := args[0]
-	cfg, err := config.Load(activeProfile)
+	filters := service.BuildSessionFilters(status, from, "", "", false)
+	resp, err := client.SessionList(cfg.ProjectID, 0, limit, filters)
 	if err != nil {
-		return err
+		return fmt.Errorf("listing sessions: %w", err)
 	}
-	if err := cfg.ValidateProject(); err != nil {
-		return err
+	sessions := service.SessionsSince(resp.Sessions, since)
+	if len(sessions) > limit {
+		sessions = sessions[:limit]
 	}
 
-	projectUUID := cfg.ProjectID
-	for i := 1; i < len(args); i++ {
-		if args[i] == "--project" && i+1 < len(args) {
-			i++
-			projectUUID = args[i]
+	if len(sessions) == 0 {
+		if jsonOutput {
+			return printJSON([]service.RerunResult{})
 		}
+		display.Warn("No sessions match the filters.")
+		return nil
 	}
 
-	client := api.NewClient(cfg)
+	if dryRun {
+		if jsonOutput {
+			return printJSON(sessions)
+		}
+		display.Header(fmt.Sprintf("Would rerun %d session(s)", len(sessions)))
+		for _, s := range sessions {
+			fmt.Printf("  %-36s  %s  %s\n", s.SessionUUID, display.InvestigationStatusLabel(s.InvestigationStatus), truncate(s.Name, 50))
+		}
+		fmt.Println()
+		return nil
+	}
 
-	fmt.Println()
-	display.Spinner("Creating session from alert...")
-	sessResp, err := client.CreateSessionFromAlert(projectUUID, alertID)
-	if err != nil {
-		display.ClearLine()
-		return fmt.Errorf("creating session from alert: %w", err)
+	if !jsonOutput {
+		fmt.Println()
+		display.Info("Sessions:", strconv.Itoa(len(sessions)))
+		display.Info("Concurrency:", strconv.Itoa(concurrency))
+		fmt.Println()
 	}
-	display.ClearLine()
 
-	sessionUUID := sessResp.SessionUUID
-	display.Success(fmt.Sprintf("Session created from alert: %s", sessionUUID))
+	results := make([]service.RerunResult, len(sessions))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	progress := display.NewProgress()
 
-	cfg.LastSession = sessionUUID
-	_ = cfg.Save()
+	for i, s := range sessions {
+		wg.Add(1)
+		go func(i int, s api.SessionInfo) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
 
-	// Auto-send a prompt to start the investigation
-	prompt := fmt.Sprintf("Investigate alert %s", alertID)
-	streamDisplay := api.NewStreamDisplay(false)
-	err = client.ProcessPromptStream(projectUUID, sessionUUID, prompt, streamDisplay.HandleEvent)
+			task := progress.Add(fmt.Sprintf("Rerunning %s...", s.SessionUUID))
+			res := service.RerunResult{SessionUUID: s.SessionUUID, Name: s.Name, Status: service.BulkStatusCompleted}
+			rr, err := client.RerunSession(s.SessionUUID)
+			if err != nil {
+				res.Status, res.Error = service.BulkStatusFailed, err.Error()
+			} else if rr.SessionUUID != "" && rr.SessionUUID != s.SessionUUID {
+				res.NewSessionUUID = rr.SessionUUID
+			}
+			results[i] = res
+			task.Done()
 
-	fmt.Println()
-	if err != nil {
-		return fmt.Errorf("stream error: %w", err)
+			if jsonOutput {
+				return
+			}
+			if res.Status == service.BulkStatusCompleted {
+				progress.Printf("  %s✓%s %s %s%s%s\n", display.Green, display.Reset, s.SessionUUID, display.Dim, truncate(s.Name, 50), display.Reset)
+			} else {
+				progress.Printf("  %s✗%s %s %s→ %s%s\n", display.Red, display.Reset, s.SessionUUID, display.Dim, res.Error, display.Reset)
+			}
+		}(i, s)
 	}
+	wg.Wait()
+	progress.Stop()
 
-	display.Success("Investigation complete")
+	if jsonOutput {
+		return printJSON(results)
+	}
+
+	started, failed := service.CountRerunResults(results)
+	display.Header(fmt.Sprintf("Bulk Rerun (%d started, %d failed)", started, failed))
+	fmt.Printf("  %s%-36s  %-10s  %s%s\n", display.Bold, "SESSION", "STATUS", "DETAIL", display.Reset)
+	for _, r := range results {
+		status := display.Green + fmt.Sprintf("%-10s", "started") + display.Reset
+		detail := truncate(r.Name, 50)
+		if r.NewSessionUUID != "" {
+			detail = "→ " + r.NewSessionUUID
+		}
+		if r.Status != service.BulkStatusCompleted {
+			status = display.Red + fmt.Sprintf("%-10s", r.Status) + display.Reset
+			detail = r.Error
+		}
+		fmt.Printf("  %-36s  %s  %s\n", r.SessionUUID, status, detail)
+	}
+	fmt.Println()
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d reruns failed", failed, len(results))
+	}
 	return nil
 }
 
-// ─── queries ────────────────────────────────────────────────────────────────
+// ─── groups ─────────────────────────────────────────────────────────────────
+
+func cmdGroups(args []string) error {
+	sub := "list"
+	if len(args) > 0 {
+		sub = args[0]
+		args = args[1:]
+	}
 
-func cmdQueries(args []string) error {
 	cfg, err := config.Load(activeProfile)
 	if err != nil {
 		return err
@@ -2038,894 +10334,1865 @@ func cmdQueries(args []string) error {
 	if err := cfg.ValidateProject(); err != nil {
 		return err
 	}
+	client := api.NewClient(cfg)
 
-	sessionUUID := ""
-	if len(args) > 0 {
-		sessionUUID = args[0]
-	} else if cfg.LastSession != "" {
-		sessionUUID = cfg.LastSession
-	} else {
-		fmt.Println("Usage: hawkeye queries [session-uuid]")
-		return nil
+	switch sub {
+	case "list", "ls":
+		return cmdGroupsList(cfg, client, args)
+	case "show":
+		if len(args) == 0 {
+			fmt.Println("Usage: hawkeye groups show <group-id>")
+			return nil
+		}
+		return cmdGroupsShow(cfg, client, args[0])
+	case "investigate":
+		if len(args) == 0 {
+			fmt.Println("Usage: hawkeye groups investigate <group-id>")
+			return nil
+		}
+		return cmdGroupsInvestigate(cfg, client, args[0])
+	default:
+		return fmt.Errorf("unknown groups subcommand: %s (valid: list, show, investigate)", sub)
 	}
+}
 
-	client := api.NewClient(cfg)
-	resp, err := client.GetInvestigationQueries(cfg.ProjectID, sessionUUID)
+func cmdGroupsList(cfg *config.Config, client *api.Client, args []string) error {
+	limit := 0
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "-n", "--limit":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--limit requires a value")
+			}
+			i++
+			n, err := strconv.Atoi(args[i])
+			if err != nil || n <= 0 {
+				return fmt.Errorf("invalid limit: %s", args[i])
+			}
+			limit = n
+		default:
+			return fmt.Errorf("unknown flag: %s", args[i])
+		}
+	}
+
+	resp, err := client.ListIncidentGroups(cfg.ProjectID)
 	if err != nil {
-		return fmt.Errorf("getting queries: %w", err)
+		return fmt.Errorf("listing incident groups: %w", err)
+	}
+	groups := resp.Groups
+	if limit > 0 && len(groups) > limit {
+		groups = groups[:limit]
 	}
 
 	if jsonOutput {
-		return printJSON(resp.Queries)
+		if groups == nil {
+			groups = []api.IncidentGroup{}
+		}
+		return printJSON(groups)
 	}
 
-	queries := service.FormatQueries(resp.Queries)
-	display.Header(fmt.Sprintf("Investigation Queries (%d)", len(queries)))
-
-	if len(queries) == 0 {
-		display.Warn("No queries found.")
+	display.Header(fmt.Sprintf("Incident groups (%d)", len(groups)))
+	if len(groups) == 0 {
+		display.Warn("No incident groups found.")
 		return nil
 	}
+	for _, g := range groups {
+		investigated := display.Dim + "not investigated" + display.Reset
+		if g.SessionUUID != "" {
+			investigated = display.Green + "session " + g.SessionUUID + display.Reset
+		}
+		priority := ""
+		if g.Priority != "" {
+			priority = fmt.Sprintf("  %s[%s]%s", display.Dim, g.Priority, display.Reset)
+		}
+		fmt.Printf("\n  %s%s%s%s\n", display.Bold, service.GroupLabel(g), display.Reset, priority)
+		fmt.Printf("    %sID:%s      %s\n", display.Dim, display.Reset, g.GroupID)
+		fmt.Printf("    %sAlerts:%s  %d  %s·%s  %s\n", display.Dim, display.Reset, service.GroupAlertCount(g), display.Dim, display.Reset, investigated)
+		ts := g.LastUpdate
+		if ts == "" {
+			ts = g.CreateTime
+		}
+		if ts != "" {
+			fmt.Printf("    %sUpdated:%s %s\n", display.Dim, display.Reset, display.FormatTime(ts))
+		}
+	}
+	fmt.Printf("\n  %sTip:%s Run %shawkeye groups show <group-id>%s to see the member alerts.\n\n",
+		display.Dim, display.Reset, display.Cyan, display.Reset)
+	return nil
+}
+
+func cmdGroupsShow(cfg *config.Config, client *api.Client, groupID string) error {
+	g, err := client.GetIncidentGroup(cfg.ProjectID, groupID)
+	if err != nil {
+		return fmt.Errorf("getting incident group: %w", err)
+	}
+	if jsonOutput {
+		return printJSON(g)
+	}
 
-	for i, q := range queries {
-		statusIcon := "✅"
-		switch q.Status {
-		case "FAILED", "ERROR":
-			statusIcon = "❌"
-		case "RUNNING", "IN_PROGRESS":
-			statusIcon = "🔄"
+	display.Header("Incident group: " + service.GroupLabel(*g))
+	display.Info("ID:", g.GroupID)
+	if g.Priority != "" {
+		display.Info("Priority:", g.Priority)
+	}
+	if g.Status != "" {
+		display.Info("Status:", g.Status)
+	}
+	if g.CreateTime != "" {
+		display.Info("Created:", display.FormatTime(g.CreateTime))
+	}
+	if g.SessionUUID != "" {
+		display.Info("Session:", g.SessionUUID)
+		if link := cfg.ConsoleSessionURL(g.SessionUUID); link != "" {
+			display.Info("Console:", link)
 		}
+	} else {
+		display.Info("Session:", display.Dim+"(not investigated)"+display.Reset)
+	}
 
-		fmt.Printf("\n  %s %sQuery %d%s  %s(%s)%s\n", statusIcon, display.Bold, i+1, display.Reset,
-			display.Dim, q.Source, display.Reset)
-		if q.Query != "" {
-			fmt.Printf("    %s%s%s\n", display.Gray, truncate(q.Query, 100), display.Reset)
+	fmt.Printf("\n  %sAlerts (%d)%s\n", display.Bold, service.GroupAlertCount(*g), display.Reset)
+	if len(g.Alerts) == 0 {
+		fmt.Printf("    %s(member alerts not reported)%s\n", display.Dim, display.Reset)
+	}
+	for _, a := range g.Alerts {
+		meta := []string{}
+		if a.Source != "" {
+			meta = append(meta, a.Source)
 		}
-		if q.ExecutionTime != "" {
-			fmt.Printf("    %sTime:%s %s", display.Dim, display.Reset, q.ExecutionTime)
+		if a.Priority != "" {
+			meta = append(meta, a.Priority)
 		}
-		if q.ResultCount > 0 {
-			fmt.Printf("  %sResults:%s %d", display.Dim, display.Reset, q.ResultCount)
+		if a.CreateTime != "" {
+			meta = append(meta, display.FormatTime(a.CreateTime))
 		}
-		if q.ExecutionTime != "" || q.ResultCount > 0 {
-			fmt.Println()
+		title := a.Title
+		if title == "" {
+			title = "(untitled)"
 		}
-		if q.ErrorMessage != "" {
-			fmt.Printf("    %sError:%s %s\n", display.Red, display.Reset, q.ErrorMessage)
+		fmt.Printf("    • %s  %s", a.AlertID, title)
+		if len(meta) > 0 {
+			fmt.Printf("  %s%s%s", display.Dim, strings.Join(meta, " · "), display.Reset)
 		}
+		fmt.Println()
 	}
 
 	fmt.Println()
+	if g.SessionUUID != "" {
+		fmt.Printf("  %sTip:%s Run %shawkeye inspect %s%s to review the investigation.\n\n",
+			display.Dim, display.Reset, display.Cyan, g.SessionUUID, display.Reset)
+	} else {
+		fmt.Printf("  %sTip:%s Run %shawkeye groups investigate %s%s to investigate it.\n\n",
+			display.Dim, display.Reset, display.Cyan, g.GroupID, display.Reset)
+	}
 	return nil
 }
 
-// ─── instructions ───────────────────────────────────────────────────────────
-
-func cmdInstructions(args []string) error {
-	cfg, err := config.Load(activeProfile)
+// cmdGroupsInvestigate investigates a group as one incident: in its
+// representative session when it has one, otherwise in a new session
+// created from its first alert.
+func cmdGroupsInvestigate(cfg *config.Config, client *api.Client, groupID string) error {
+	g, err := client.GetIncidentGroup(cfg.ProjectID, groupID)
 	if err != nil {
-		return err
-	}
-	if err := cfg.ValidateProject(); err != nil {
-		return err
+		return fmt.Errorf("getting incident group: %w", err)
 	}
 
-	// Subcommand dispatch
-	if len(args) > 0 {
-		switch args[0] {
-		case "create":
-			return cmdInstructionCreate(cfg, args[1:])
-		case "enable":
-			return cmdInstructionToggle(cfg, args[1:], true)
-		case "disable":
-			return cmdInstructionToggle(cfg, args[1:], false)
-		case "delete":
-			return cmdInstructionDelete(cfg, args[1:])
-		case "validate":
-			return cmdInstructionValidate(cfg, args[1:])
-		case "apply":
-			return cmdInstructionApply(cfg, args[1:])
-		case "info":
-			// info falls through to list with filter
-			if len(args) < 2 {
-				fmt.Println("Usage: hawkeye instructions info <uuid>")
-				return nil
-			}
-			return cmdInstructionInfo(cfg, args[1])
+	fmt.Println()
+	sessionUUID := g.SessionUUID
+	if sessionUUID != "" {
+		display.Info("Session:", sessionUUID+" (representative)")
+	} else {
+		if len(g.Alerts) == 0 {
+			return fmt.Errorf("incident group %s has no session and no member alerts to start one from", groupID)
+		}
+		sp := display.Spin("Creating session from alert...")
+		sessResp, err := client.CreateSessionFromAlert(cfg.ProjectID, g.Alerts[0].AlertID)
+		sp.Stop()
+		if err != nil {
+			return fmt.Errorf("creating session from alert: %w", err)
 		}
+		sessionUUID = sessResp.SessionUUID
+		display.Success(fmt.Sprintf("Session created from alert %s: %s", g.Alerts[0].AlertID, sessionUUID))
 	}
 
-	// Default: list instructions
-	client := api.NewClient(cfg)
-	resp, err := client.ListInstructions(cfg.ProjectID)
+	cfg.LastSession = sessionUUID
+	_ = cfg.Save()
+
+	streamDisplay := api.NewStreamDisplay(false)
+	var failures service.FailureCollector
+	err = client.ProcessPromptStream(cfg.ProjectID, sessionUUID, service.GroupPrompt(*g), func(resp *api.ProcessPromptResponse) {
+		failures.Handle(resp)
+		streamDisplay.HandleEvent(resp)
+	})
+
+	fmt.Println()
+	if err != nil {
+		return fmt.Errorf("stream error: %w", err)
+	}
+	if f := failures.Failure(); f != nil {
+		return reportInvestigationFailure(sessionUUID, f)
+	}
+
+	display.Success("Investigation complete")
+	return nil
+}
+
+// ─── incidents ───────────────────────────────────────────────────────────────
+
+func cmdIncidents(args []string) error {
+	cfg, err := config.Load(activeProfile)
 	if err != nil {
-		return fmt.Errorf("listing instructions: %w", err)
+		return err
 	}
-
-	if jsonOutput {
-		return printJSON(resp.Instructions)
+	if err := cfg.ValidateProject(); err != nil {
+		return err
 	}
 
-	instructions := service.FormatInstructions(resp.Instructions)
-	display.Header(fmt.Sprintf("Instructions (%d)", len(instructions)))
-
-	if len(instructions) == 0 {
-		display.Warn("No instructions found.")
+	if len(args) == 0 {
+		fmt.Println("Usage: hawkeye incidents add <type> [flags]")
+		fmt.Println("       hawkeye incidents test <type> [flags]")
+		fmt.Println("Types: pagerduty, firehydrant, incidentio")
 		return nil
 	}
 
-	for _, instr := range instructions {
-		status := display.Green + "enabled" + display.Reset
-		if !instr.Enabled {
-			status = display.Dim + "disabled" + display.Reset
+	if args[0] == "add" {
+		if len(args) < 2 {
+			fmt.Println("Usage: hawkeye incidents add <type> [flags]")
+			fmt.Println("Types: pagerduty, firehydrant, incidentio")
+			return nil
 		}
-		fmt.Printf("\n  %s%s%s  %s[%s]%s  %s\n",
-			display.Bold, instr.Name, display.Reset,
-			display.Dim, instr.Type, display.Reset, status)
-		fmt.Printf("    %sUUID:%s %s\n", display.Dim, display.Reset, instr.UUID)
-		if instr.Content != "" {
-			fmt.Printf("    %s%s%s\n", display.Gray, truncate(instr.Content, 80), display.Reset)
+		switch args[1] {
+		case "pagerduty":
+			return cmdConnectionAddPagerDuty(cfg, args[2:])
+		case "firehydrant":
+			return cmdConnectionAddFirehydrant(cfg, args[2:])
+		case "incidentio":
+			return cmdConnectionAddIncidentio(cfg, args[2:])
+		default:
+			fmt.Printf("Unknown type %q. Types: pagerduty, firehydrant, incidentio\n", args[1])
+			return nil
 		}
 	}
 
-	fmt.Println()
-	return nil
-}
-
-func cmdInstructionInfo(cfg *config.Config, instrUUID string) error {
-	client := api.NewClient(cfg)
-	resp, err := client.ListInstructions(cfg.ProjectID)
-	if err != nil {
-		return fmt.Errorf("listing instructions: %w", err)
-	}
-
-	for _, s := range resp.Instructions {
-		if s.UUID == instrUUID {
-			if jsonOutput {
-				return printJSON(s)
-			}
-			instr := service.FormatInstruction(s)
-			display.Header(fmt.Sprintf("Instruction: %s", instr.Name))
-			display.Info("UUID:", instr.UUID)
-			display.Info("Type:", instr.Type)
-			status := "enabled"
-			if !instr.Enabled {
-				status = "disabled"
-			}
-			display.Info("Status:", status)
-			if instr.Content != "" {
-				fmt.Printf("\n  %sContent:%s\n", display.Dim, display.Reset)
-				fmt.Printf("  %s\n", instr.Content)
-			}
-			fmt.Println()
+	if args[0] == "test" {
+		if len(args) < 2 {
+			fmt.Println("Usage: hawkeye incidents test <type> [--api-key <key>] [--routing-key <key>] [--file <path>] [--run-level <n>]")
+			fmt.Println("Types: pagerduty, firehydrant, incidentio")
 			return nil
 		}
+		return cmdIncidentsTest(args[1:])
 	}
 
-	return fmt.Errorf("instruction %s not found", instrUUID)
+	fmt.Printf("Unknown subcommand %q. Try: hawkeye incidents add | test\n", args[0])
+	return nil
 }
 
-func cmdInstructionCreate(cfg *config.Config, args []string) error {
+func cmdIncidentsTest(args []string) error {
 	if len(args) == 0 {
-		fmt.Println("Usage: hawkeye instructions create <name> --type <filter|system|grouping|rca> --content <text>")
+		fmt.Println("Usage: hawkeye incidents test <type> [--api-key <key>] [--routing-key <key>] [--file <path>] [--run-level <n>]")
+		fmt.Println("Types: pagerduty, firehydrant, incidentio")
 		return nil
 	}
+	providerType := args[0]
+	args = args[1:]
 
-	var instrType, content string
-	var positional []string
+	var apiKey, routingKey, filename string
+	runLevel := 0
 
 	for i := 0; i < len(args); i++ {
 		switch args[i] {
-		case "--type", "-t":
+		case "--api-key":
 			if i+1 < len(args) {
 				i++
-				instrType = args[i]
+				apiKey = args[i]
 			} else {
-				return fmt.Errorf("--type requires a value")
+				return fmt.Errorf("--api-key requires a value")
 			}
-		case "--content", "-c":
+		case "--routing-key":
 			if i+1 < len(args) {
 				i++
-				content = args[i]
+				routingKey = args[i]
 			} else {
-				return fmt.Errorf("--content requires a value")
+				return fmt.Errorf("--routing-key requires a value")
+			}
+		case "--file":
+			if i+1 < len(args) {
+				i++
+				filename = args[i]
+			} else {
+				return fmt.Errorf("--file requires a value")
+			}
+		case "--run-level":
+			if i+1 < len(args) {
+				i++
+				n, err := strconv.Atoi(args[i])
+				if err != nil {
+					return fmt.Errorf("--run-level must be an integer")
+				}
+				runLevel = n
+			} else {
+				return fmt.Errorf("--run-level requires a value")
 			}
-		default:
-			positional = append(positional, args[i])
 		}
 	}
 
-	name := strings.Join(positional, " ")
-	if name == "" || instrType == "" || content == "" {
-		fmt.Println("Usage: hawkeye instructions create <name> --type <filter|system|grouping|rca> --content <text>")
-		return nil
+	if apiKey == "" && routingKey == "" {
+		return fmt.Errorf("--api-key is required (use --routing-key for PagerDuty Events API)")
 	}
-
-	if !service.ValidInstructionType(instrType) {
-		return fmt.Errorf("invalid instruction type: %s (valid: filter, system, grouping, rca)", instrType)
+	if filename == "" {
+		dir, _ := config.Dir()
+		candidate := filepath.Join(dir, "test_config")
+		if _, err := os.Stat(candidate); err == nil {
+			filename = candidate
+		}
+	}
+	if runLevel == 0 {
+		runLevel = 1
 	}
 
-	client := api.NewClient(cfg)
-	resp, err := client.CreateInstruction(cfg.ProjectID, name, instrType, content)
-	if err != nil {
-		return fmt.Errorf("creating instruction: %w", err)
+	creds := incidents.Creds{
+		ApiKey:     apiKey,
+		RoutingKey: routingKey,
 	}
 
-	if jsonOutput {
-		return printJSON(resp.Instruction)
+	created, err := incidents.RunTest(providerType, creds, filename, incidents.IncidentInput{Count: runLevel})
+	if err != nil {
+		return fmt.Errorf("incidents test: %w", err)
 	}
 
-	if resp.Instruction != nil {
-		display.Success(fmt.Sprintf("Instruction created: %s (%s)", resp.Instruction.Name, resp.Instruction.UUID))
-	} else {
-		display.Success("Instruction created")
+	fmt.Printf("\nCreated %d incident(s) via %s:\n\n", len(created), providerType)
+	for _, inc := range created {
+		fmt.Printf("  %-10s %s\n", "source:", inc.SourceID)
+		fmt.Printf("  %-10s %s\n", "remote:", inc.RemoteID)
+		fmt.Printf("  %-10s %s\n", "title:", inc.Title)
+		if inc.URL != "" {
+			fmt.Printf("  %-10s %s\n", "url:", inc.URL)
+		}
+		fmt.Println()
 	}
 	return nil
 }
 
-func cmdInstructionToggle(cfg *config.Config, args []string, enable bool) error {
-	if len(args) == 0 {
-		action := "enable"
-		if !enable {
-			action = "disable"
+func cmdConnectionAddPagerDuty(cfg *config.Config, args []string) error {
+	var name, apiKey string
+
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--name":
+			if i+1 < len(args) {
+				i++
+				name = args[i]
+			} else {
+				return fmt.Errorf("--name requires a value")
+			}
+		case "--api-key":
+			if i+1 < len(args) {
+				i++
+				apiKey = args[i]
+			} else {
+				return fmt.Errorf("--api-key requires a value")
+			}
 		}
-		fmt.Printf("Usage: hawkeye instructions %s <uuid>\n", action)
-		return nil
 	}
 
-	client := api.NewClient(cfg)
-	if err := client.UpdateInstructionStatus(args[0], enable); err != nil {
-		return fmt.Errorf("updating instruction: %w", err)
+	if name == "" {
+		fmt.Print("Connection name: ")
+		fmt.Scanln(&name)
 	}
-
-	if jsonOutput {
-		return printJSON(map[string]any{"uuid": args[0], "enabled": enable})
+	if apiKey == "" {
+		fmt.Print("PagerDuty API access key: ")
+		fmt.Scanln(&apiKey)
+	}
+	if name == "" || apiKey == "" {
+		return fmt.Errorf("name and api-key are required")
 	}
 
-	action := "enabled"
-	if !enable {
-		action = "disabled"
+	client := api.NewClient(cfg)
+	resp, err := client.AddConnection(&api.AddConnectionRequest{
+		Connection: api.AddConnectionInput{
+			Name:           name,
+			ConnectionType: "CONNECTION_TYPE_PAGERDUTY",
+			PagerdutyConnectionInfo: &api.PagerdutyConnectionInfo{
+				ApiAccessKey: apiKey,
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("adding connection: %w", err)
 	}
-	display.Success(fmt.Sprintf("Instruction %s %s", args[0], action))
+	if resp.Response.ErrorMessage != "" {
+		return fmt.Errorf("server error: %s", resp.Response.ErrorMessage)
+	}
+
+	display.Header("PagerDuty Connection Added")
+	fmt.Printf("\n  %s%s%s\n", display.Bold, name, display.Reset)
+	fmt.Printf("    %sUUID:%s  %s\n\n", display.Dim, display.Reset, resp.Response.UUID)
 	return nil
 }
 
-func cmdInstructionDelete(cfg *config.Config, args []string) error {
-	if len(args) == 0 {
-		fmt.Println("Usage: hawkeye instructions delete <uuid> [--confirm]")
-		return nil
-	}
+func cmdConnectionAddFirehydrant(cfg *config.Config, args []string) error {
+	var name, apiKey string
 
-	instrUUID := args[0]
-	confirmed := false
-	for _, a := range args[1:] {
-		if a == "--confirm" || a == "-y" {
-			confirmed = true
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--name":
+			if i+1 < len(args) {
+				i++
+				name = args[i]
+			} else {
+				return fmt.Errorf("--name requires a value")
+			}
+		case "--api-key":
+			if i+1 < len(args) {
+				i++
+				apiKey = args[i]
+			} else {
+				return fmt.Errorf("--api-key requires a value")
+			}
 		}
 	}
 
-	if !confirmed {
-		fmt.Printf("Delete instruction %s? Use --confirm to proceed.\n", instrUUID)
-		return nil
+	if name == "" {
+		fmt.Print("Connection name: ")
+		fmt.Scanln(&name)
+	}
+	if apiKey == "" {
+		fmt.Print("FireHydrant API key: ")
+		fmt.Scanln(&apiKey)
+	}
+	if name == "" || apiKey == "" {
+		return fmt.Errorf("name and api-key are required")
 	}
 
 	client := api.NewClient(cfg)
-	if err := client.DeleteInstruction(instrUUID); err != nil {
-		return fmt.Errorf("deleting instruction: %w", err)
+	resp, err := client.AddConnection(&api.AddConnectionRequest{
+		Connection: api.AddConnectionInput{
+			Name:           name,
+			ConnectionType: "CONNECTION_TYPE_FIREHYDRANT",
+			FirehydrantConnectionInfo: &api.FirehydrantConnectionInfo{
+				ApiKey: apiKey,
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("adding connection: %w", err)
 	}
-
-	if jsonOutput {
-		return printJSON(map[string]string{"deleted": instrUUID})
+	if resp.Response.ErrorMessage != "" {
+		return fmt.Errorf("server error: %s", resp.Response.ErrorMessage)
 	}
 
-	display.Success(fmt.Sprintf("Instruction %s deleted", instrUUID))
+	display.Header("FireHydrant Connection Added")
+	fmt.Printf("\n  %s%s%s\n", display.Bold, name, display.Reset)
+	fmt.Printf("    %sUUID:%s  %s\n\n", display.Dim, display.Reset, resp.Response.UUID)
 	return nil
 }
 
-func cmdInstructionValidate(cfg *config.Config, args []string) error {
-	var instrType, content string
+func cmdConnectionAddIncidentio(cfg *config.Config, args []string) error {
+	var name, apiKey string
 
 	for i := 0; i < len(args); i++ {
 		switch args[i] {
-		case "--type", "-t":
+		case "--name":
 			if i+1 < len(args) {
 				i++
-				instrType = args[i]
+				name = args[i]
+			} else {
+				return fmt.Errorf("--name requires a value")
 			}
-		case "--content", "-c":
+		case "--api-key":
 			if i+1 < len(args) {
 				i++
-				content = args[i]
+				apiKey = args[i]
+			} else {
+				return fmt.Errorf("--api-key requires a value")
 			}
 		}
 	}
 
-	if instrType == "" || content == "" {
-		fmt.Println("Usage: hawkeye instructions validate --type <type> --content <text>")
-		return nil
+	if name == "" {
+		fmt.Print("Connection name: ")
+		fmt.Scanln(&name)
+	}
+	if apiKey == "" {
+		fmt.Print("incident.io API key: ")
+		fmt.Scanln(&apiKey)
+	}
+	if name == "" || apiKey == "" {
+		return fmt.Errorf("name and api-key are required")
 	}
 
 	client := api.NewClient(cfg)
-	resp, err := client.ValidateInstruction(instrType, content)
+	resp, err := client.AddConnection(&api.AddConnectionRequest{
+		Connection: api.AddConnectionInput{
+			Name:           name,
+			ConnectionType: "CONNECTION_TYPE_INCIDENTIO",
+			IncidentioConnectionInfo: &api.IncidentioConnectionInfo{
+				ApiKey: apiKey,
+			},
+		},
+	})
 	if err != nil {
-		return fmt.Errorf("validating instruction: %w", err)
+		return fmt.Errorf("adding connection: %w", err)
 	}
-
-	if jsonOutput {
-		return printJSON(resp)
+	if resp.Response.ErrorMessage != "" {
+		return fmt.Errorf("server error: %s", resp.Response.ErrorMessage)
 	}
 
-	if resp.Instruction != nil {
-		display.Success("Instruction content is valid")
-		display.Info("Name:", resp.Instruction.Name)
-		display.Info("Type:", resp.Instruction.Type)
-		display.Info("Content:", resp.Instruction.Content)
-	} else {
-		display.Error("Instruction validation failed")
-	}
+	display.Header("incident.io Connection Added")
+	fmt.Printf("\n  %s%s%s\n", display.Bold, name, display.Reset)
+	fmt.Printf("    %sUUID:%s  %s\n\n", display.Dim, display.Reset, resp.Response.UUID)
 	return nil
 }
 
-func cmdInstructionApply(cfg *config.Config, args []string) error {
-	if len(args) == 0 {
-		fmt.Println("Usage: hawkeye instructions apply <session-uuid> --type <type> --content <text>")
-		return nil
-	}
-
-	sessionUUID := args[0]
-	var instrType, content string
+// ─── profiles ───────────────────────────────────────────────────────────────
 
-	for i := 1; i < len(args); i++ {
-		switch args[i] {
-		case "--type", "-t":
-			if i+1 < len(args) {
-				i++
-				instrType = args[i]
-			}
-		case "--content", "-c":
-			if i+1 < len(args) {
-				i++
-				content = args[i]
-			}
-		}
+func cmdProfiles() error {
+	profiles, err := config.ListProfiles()
+	if err != nil {
+		return err
 	}
 
-	if instrType == "" || content == "" {
-		fmt.Println("Usage: hawkeye instructions apply <session-uuid> --type <type> --content <text>")
-		return nil
+	if jsonOutput {
+		return printJSON(map[string]any{
+			"profiles": profiles,
+			"active":   config.ProfileName(activeProfile),
+		})
 	}
 
-	client := api.NewClient(cfg)
-	if err := client.ApplySessionInstruction(sessionUUID, instrType, content); err != nil {
-		return fmt.Errorf("applying instruction: %w", err)
+	display.Header(fmt.Sprintf("Profiles (%d)", len(profiles)))
+
+	if len(profiles) == 0 {
+		display.Warn("No profiles found.")
+		return nil
 	}
 
-	if jsonOutput {
-		return printJSON(map[string]string{"applied": sessionUUID, "type": instrType})
+	for _, p := range profiles {
+		marker := " "
+		if p == config.ProfileName(activeProfile) {
+			marker = display.Green + "●" + display.Reset
+		}
+		fmt.Printf("  %s %s\n", marker, p)
 	}
+	fmt.Println()
 
-	display.Success(fmt.Sprintf("Instruction applied to session %s", sessionUUID))
 	return nil
 }
 
-// ─── rerun ──────────────────────────────────────────────────────────────────
+// ─── helpers ────────────────────────────────────────────────────────────────
 
-func cmdRerun(args []string) error {
-	cfg, err := config.Load(activeProfile)
+func printJSON(v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
 	if err != nil {
-		return err
+		return fmt.Errorf("JSON marshal: %w", err)
 	}
-	if err := cfg.ValidateProject(); err != nil {
+	if data, err = selectJSONFields(data, "  "); err != nil {
 		return err
 	}
+	fmt.Println(string(data))
+	return nil
+}
 
-	sessionUUID := ""
-	if len(args) > 0 {
-		sessionUUID = args[0]
-	} else if cfg.LastSession != "" {
-		sessionUUID = cfg.LastSession
-	} else {
-		fmt.Println("Usage: hawkeye rerun <session-uuid>")
-		return nil
+// printJSONLine prints v as one line of JSON, for output that streams one
+// object per line.
+func printJSONLine(v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("JSON marshal: %w", err)
 	}
+	if data, err = selectJSONFields(data, ""); err != nil {
+		return err
+	}
+	fmt.Println(string(data))
+	return nil
+}
 
-	client := api.NewClient(cfg)
-	resp, err := client.RerunSession(sessionUUID)
+// fieldsWarned keeps a stream from repeating the warning about --fields
+// that match nothing.
+var fieldsWarned bool
+
+// selectJSONFields applies --fields to encoded JSON output.
+func selectJSONFields(data []byte, indent string) ([]byte, error) {
+	if len(jsonFields) == 0 {
+		return data, nil
+	}
+	data, missing, err := service.SelectJSON(data, jsonFields, indent)
 	if err != nil {
-		return fmt.Errorf("rerunning session: %w", err)
+		return nil, fmt.Errorf("selecting --fields: %w", err)
 	}
+	if len(missing) > 0 && !fieldsWarned {
+		fieldsWarned = true
+		fmt.Fprintf(os.Stderr, "%s!%s --fields: no %s in this output\n", display.Yellow, display.Reset, strings.Join(missing, ", "))
+	}
+	return data, nil
+}
 
-	if jsonOutput {
-		return printJSON(resp)
+func wrapText(text string, width int) []string {
+	var lines []string
+	for _, paragraph := range strings.Split(text, "\n") {
+		if paragraph == "" {
+			lines = append(lines, "")
+			continue
+		}
+		words := strings.Fields(paragraph)
+		current := ""
+		for _, word := range words {
+			if current == "" {
+				current = word
+			} else if len(current)+1+len(word) <= width {
+				current += " " + word
+			} else {
+				lines = append(lines, current)
+				current = word
+			}
+		}
+		if current != "" {
+			lines = append(lines, current)
+		}
 	}
+	return lines
+}
 
-	display.Success(fmt.Sprintf("Rerun started for session %s", sessionUUID))
-	if resp.SessionUUID != "" && resp.SessionUUID != sessionUUID {
-		display.Info("New session:", resp.SessionUUID)
+func parseGlobalFlags(args []string) []string {
+	var remaining []string
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--profile":
+			if i+1 < len(args) {
+				i++
+				activeProfile = args[i]
+			}
+		case "-j", "--json":
+			jsonOutput = true
+		case "-c", "--continue":
+			continueLastSession = true
+		case "--relative":
+			relativeTimes = true
+		case "--no-emoji", "--ascii":
+			noEmoji = true
+		case "--output":
+			outputFormat = "invalid" // rejected in main unless a value follows
+			if i+1 < len(args) {
+				i++
+				outputFormat = strings.ToLower(args[i])
+			}
+		case "--proxy":
+			value := ""
+			if i+1 < len(args) {
+				i++
+				value = args[i]
+			}
+			proxyFlag = &value
+		case "--org":
+			value := ""
+			if i+1 < len(args) {
+				i++
+				value = args[i]
+			}
+			orgFlag = &value
+		case "--fields":
+			value := ""
+			if i+1 < len(args) {
+				i++
+				value = args[i]
+			}
+			fieldsFlag = &value
+		case "--insecure-skip-verify":
+			insecureTLS = true
+		case "--no-defaults":
+			noDefaults = true
+		case "--no-cache":
+			noCache = true
+		case "--no-banner":
+			noBanner = true
+		case "--accessible":
+			accessible = true
+		case "--wide":
+			wideOutput = true
+		case "--width":
+			outputWidth = -1 // rejected in main unless a valid value follows
+			if i+1 < len(args) {
+				i++
+				if n, err := strconv.Atoi(args[i]); err == nil && n > 0 {
+					outputWidth = n
+				}
+			}
+		default:
+			remaining = append(remaining, args[i])
+		}
 	}
-	return nil
+	return remaining
 }
 
-// ─── incidents ───────────────────────────────────────────────────────────────
+// resolveContent returns the inline content or, when path is set, the
+// contents of that file. Supplying both is an error.
+func resolveContent(content, path string) (string, error) {
+	if path == "" {
+		return content, nil
+	}
+	if content != "" {
+		return "", fmt.Errorf("use either --content or --content-file, not both")
+	}
+	data, err := readInputFile(path)
+	if err != nil {
+		return "", fmt.Errorf("reading content file: %w", err)
+	}
+	return strings.TrimRight(string(data), "\n"), nil
+}
 
-func cmdIncidents(args []string) error {
+// newTable returns a table for a listing, showing Wide columns when --wide
+// is set.
+func newTable(cols ...display.Column) *display.Table {
+	t := display.NewTable(cols...)
+	t.Wide = wideOutput
+	return t
+}
+
+func truncate(s string, max int) string {
+	if len(s) <= max {
+		return s
+	}
+	return s[:max-3] + "..."
+}
+
+// ─── alias ──────────────────────────────────────────────────────────────────
+
+func cmdAlias(args []string) error {
 	cfg, err := config.Load(activeProfile)
 	if err != nil {
 		return err
 	}
-	if err := cfg.ValidateProject(); err != nil {
-		return err
-	}
 
-	if len(args) == 0 {
-		fmt.Println("Usage: hawkeye incidents add <type> [flags]")
-		fmt.Println("       hawkeye incidents test <type> [flags]")
-		fmt.Println("Types: pagerduty, firehydrant, incidentio")
-		return nil
+	sub := "list"
+	if len(args) > 0 {
+		sub = args[0]
+		args = args[1:]
 	}
 
-	if args[0] == "add" {
-		if len(args) < 2 {
-			fmt.Println("Usage: hawkeye incidents add <type> [flags]")
-			fmt.Println("Types: pagerduty, firehydrant, incidentio")
+	switch sub {
+	case "list", "ls":
+		if jsonOutput {
+			aliases := cfg.Aliases
+			if aliases == nil {
+				aliases = map[string]string{}
+			}
+			return printJSON(aliases)
+		}
+		names := cfg.AliasNames()
+		if len(names) == 0 {
+			fmt.Println("No aliases. Add one with: hawkeye alias add <name> <session-uuid>")
 			return nil
 		}
-		switch args[1] {
-		case "pagerduty":
-			return cmdConnectionAddPagerDuty(cfg, args[2:])
-		case "firehydrant":
-			return cmdConnectionAddFirehydrant(cfg, args[2:])
-		case "incidentio":
-			return cmdConnectionAddIncidentio(cfg, args[2:])
-		default:
-			fmt.Printf("Unknown type %q. Types: pagerduty, firehydrant, incidentio\n", args[1])
+		display.Header(fmt.Sprintf("Session Aliases (%d)", len(names)))
+		for _, name := range names {
+			fmt.Printf("  %s%-24s%s %s\n", display.Bold, name, display.Reset, cfg.Aliases[name])
+		}
+		fmt.Printf("\n  %sTip:%s Use an alias anywhere a session UUID is accepted, e.g. %shawkeye inspect %s%s\n\n",
+			display.Dim, display.Reset, display.Cyan, names[0], display.Reset)
+		return nil
+
+	case "add", "set":
+		if len(args) == 0 {
+			fmt.Println("Usage: hawkeye alias add <name> [session-uuid]  (defaults to the last session)")
 			return nil
 		}
-	}
+		name := args[0]
+		sessionUUID := cfg.LastSession
+		if len(args) > 1 {
+			sessionUUID = cfg.ResolveSession(args[1])
+		}
+		if sessionUUID == "" {
+			return fmt.Errorf("no session given and no last session to alias")
+		}
+		previous, existed := cfg.Aliases[strings.ToLower(name)]
+		if err := cfg.SetAlias(name, sessionUUID); err != nil {
+			return err
+		}
+		if err := cfg.Save(); err != nil {
+			return err
+		}
+		if existed && previous != sessionUUID {
+			display.Success(fmt.Sprintf("Alias %s moved from %s to %s", strings.ToLower(name), previous, sessionUUID))
+		} else {
+			display.Success(fmt.Sprintf("Alias %s → %s", strings.ToLower(name), sessionUUID))
+		}
+		return nil
 
-	if args[0] == "test" {
-		if len(args) < 2 {
-			fmt.Println("Usage: hawkeye incidents test <type> [--api-key <key>] [--routing-key <key>] [--file <path>] [--run-level <n>]")
-			fmt.Println("Types: pagerduty, firehydrant, incidentio")
+	case "rm", "remove", "delete":
+		if len(args) == 0 {
+			fmt.Println("Usage: hawkeye alias rm <name>")
 			return nil
 		}
-		return cmdIncidentsTest(args[1:])
-	}
+		if !cfg.RemoveAlias(args[0]) {
+			return fmt.Errorf("no alias named %q", args[0])
+		}
+		if err := cfg.Save(); err != nil {
+			return err
+		}
+		display.Success(fmt.Sprintf("Alias %s removed", strings.ToLower(args[0])))
+		return nil
 
-	fmt.Printf("Unknown subcommand %q. Try: hawkeye incidents add | test\n", args[0])
-	return nil
+	default:
+		return fmt.Errorf("unknown alias subcommand: %s (valid: list, add, rm)", sub)
+	}
 }
 
-func cmdIncidentsTest(args []string) error {
-	if len(args) == 0 {
-		fmt.Println("Usage: hawkeye incidents test <type> [--api-key <key>] [--routing-key <key>] [--file <path>] [--run-level <n>]")
-		fmt.Println("Types: pagerduty, firehydrant, incidentio")
-		return nil
-	}
-	providerType := args[0]
-	args = args[1:]
+// ─── history ────────────────────────────────────────────────────────────────
 
-	var apiKey, routingKey, filename string
-	runLevel := 0
+// recordHistory adds an investigation prompt to the profile's history, the
+// same file the interactive mode recalls with Up and Ctrl+R.
+func recordHistory(prompt, sessionUUID string) {
+	noteSession(sessionUUID)
+	_ = config.AppendHistory(activeProfile, config.HistoryEntry{
+		Prompt:      prompt,
+		SessionUUID: sessionUUID,
+		Time:        time.Now(),
+	})
+}
 
+func cmdHistory(args []string) error {
+	limit := 20
+	var search string
+	var clear bool
 	for i := 0; i < len(args); i++ {
 		switch args[i] {
-		case "--api-key":
-			if i+1 < len(args) {
-				i++
-				apiKey = args[i]
-			} else {
-				return fmt.Errorf("--api-key requires a value")
-			}
-		case "--routing-key":
-			if i+1 < len(args) {
-				i++
-				routingKey = args[i]
-			} else {
-				return fmt.Errorf("--routing-key requires a value")
-			}
-		case "--file":
+		case "-n", "--limit":
 			if i+1 < len(args) {
 				i++
-				filename = args[i]
+				n, err := strconv.Atoi(args[i])
+				if err != nil || n < 1 {
+					return fmt.Errorf("--limit must be a positive number")
+				}
+				limit = n
 			} else {
-				return fmt.Errorf("--file requires a value")
+				return fmt.Errorf("--limit requires a value")
 			}
-		case "--run-level":
+		case "--search":
 			if i+1 < len(args) {
 				i++
-				n, err := strconv.Atoi(args[i])
-				if err != nil {
-					return fmt.Errorf("--run-level must be an integer")
-				}
-				runLevel = n
+				search = args[i]
 			} else {
-				return fmt.Errorf("--run-level requires a value")
+				return fmt.Errorf("--search requires a value")
 			}
+		case "--clear":
+			clear = true
+		default:
+			return fmt.Errorf("unknown flag: %s", args[i])
 		}
 	}
 
-	if apiKey == "" && routingKey == "" {
-		return fmt.Errorf("--api-key is required (use --routing-key for PagerDuty Events API)")
-	}
-	if filename == "" {
-		home, _ := os.UserHomeDir()
-		candidate := home + "/.hawkeye/test_config"
-		if _, err := os.Stat(candidate); err == nil {
-			filename = candidate
+	if clear {
+		if err := config.ClearHistory(activeProfile); err != nil {
+			return fmt.Errorf("clearing history: %w", err)
 		}
-	}
-	if runLevel == 0 {
-		runLevel = 1
+		display.Success("History cleared")
+		return nil
 	}
 
-	creds := incidents.Creds{
-		ApiKey:     apiKey,
-		RoutingKey: routingKey,
+	entries := config.RecentHistory(config.LoadHistoryEntries(activeProfile), search, limit)
+
+	if jsonOutput {
+		if entries == nil {
+			entries = []config.HistoryEntry{}
+		}
+		return printJSON(entries)
 	}
 
-	created, err := incidents.RunTest(providerType, creds, filename, incidents.IncidentInput{Count: runLevel})
-	if err != nil {
-		return fmt.Errorf("incidents test: %w", err)
+	if len(entries) == 0 {
+		fmt.Println("No history yet.")
+		return nil
 	}
 
-	fmt.Printf("\nCreated %d incident(s) via %s:\n\n", len(created), providerType)
-	for _, inc := range created {
-		fmt.Printf("  %-10s %s\n", "source:", inc.SourceID)
-		fmt.Printf("  %-10s %s\n", "remote:", inc.RemoteID)
-		fmt.Printf("  %-10s %s\n", "title:", inc.Title)
-		if inc.URL != "" {
-			fmt.Printf("  %-10s %s\n", "url:", inc.URL)
+	display.Header(fmt.Sprintf("History (%d)", len(entries)))
+	for _, e := range entries {
+		when := display.Dim + "—" + display.Reset
+		if !e.Time.IsZero() {
+			when = display.FormatTime(e.Time.Format(time.RFC3339))
 		}
-		fmt.Println()
+		session := display.Dim + "(no session)" + display.Reset
+		if e.SessionUUID != "" {
+			session = e.SessionUUID
+		}
+		fmt.Printf("\n  %s%s%s\n", display.Bold, truncate(strings.ReplaceAll(e.Prompt, "\n", " "), 100), display.Reset)
+		fmt.Printf("    %sSession:%s %s\n", display.Dim, display.Reset, session)
+		fmt.Printf("    %sWhen:%s    %s\n", display.Dim, display.Reset, when)
 	}
+	fmt.Printf("\n  %sTip:%s Run %shawkeye inspect <session-uuid>%s to review a session.\n\n",
+		display.Dim, display.Reset, display.Cyan, display.Reset)
 	return nil
 }
 
-func cmdConnectionAddPagerDuty(cfg *config.Config, args []string) error {
-	var name, apiKey string
+// ─── audit ──────────────────────────────────────────────────────────────────
 
-	for i := 0; i < len(args); i++ {
-		switch args[i] {
-		case "--name":
-			if i+1 < len(args) {
-				i++
-				name = args[i]
-			} else {
-				return fmt.Errorf("--name requires a value")
-			}
-		case "--api-key":
-			if i+1 < len(args) {
-				i++
-				apiKey = args[i]
-			} else {
-				return fmt.Errorf("--api-key requires a value")
+// auditSessions collects the sessions a command created or ran prompts in,
+// for its audit entry.
+var auditSessions []string
+
+func noteSession(sessionUUID string) {
+	if sessionUUID != "" && !slices.Contains(auditSessions, sessionUUID) {
+		auditSessions = append(auditSessions, sessionUUID)
+	}
+}
+
+// sessionCommands take session UUIDs as positional arguments.
+var sessionCommands = map[string]bool{
+	"investigate": true, "ask": true, "replay": true, "inspect": true,
+	"summary": true, "feedback": true, "td": true, "score": true,
+	"chat": true, "link": true, "share": true, "open": true, "open-url": true, "queries": true, "sources": true,
+	"stats": true, "rerun": true, "session-report": true,
+}
+
+// recordAudit appends the run to the audit log. A log that cannot be
+// written never fails the command.
+func recordAudit(args []string, started time.Time, err error) {
+	command, rest := "interactive", []string(nil)
+	if len(args) > 0 && args[0] != "-i" && args[0] != "--interactive" {
+		command, rest = args[0], args[1:]
+	}
+	if command == "audit" {
+		return
+	}
+
+	sessions := append([]string(nil), auditSessions...)
+	if sessionCommands[command] {
+		for i, a := range rest {
+			if config.IsUUID(a) && (i == 0 || rest[i-1] != "--project") && !slices.Contains(sessions, a) {
+				sessions = append(sessions, a)
 			}
 		}
 	}
+	e := config.AuditEntry{
+		Time:       started,
+		Profile:    config.ProfileName(activeProfile),
+		Command:    command,
+		Args:       config.RedactArgs(command, rest, service.SecretFlags()...),
+		Status:     config.AuditOK,
+		DurationMS: time.Since(started).Milliseconds(),
+		Sessions:   sessions,
+	}
+	if err != nil {
+		e.Status, e.Error = config.AuditError, err.Error()
+	}
+	_ = config.AppendAudit(e)
+}
 
-	if name == "" {
-		fmt.Print("Connection name: ")
-		fmt.Scanln(&name)
+// telemetryUploadWait bounds how long a command waits at exit for a
+// telemetry upload.
+const telemetryUploadWait = 3 * time.Second
+
+// recordTelemetry counts the run when telemetry is on and uploads the
+// pending batch once it is due. Telemetry never fails or visibly slows a
+// command: errors are ignored and a slow upload is abandoned, leaving the
+// batch for the next run.
+func recordTelemetry(args []string, err error) {
+	t, lerr := config.LoadTelemetry()
+	if lerr != nil || !t.Enabled {
+		return
 	}
-	if apiKey == "" {
-		fmt.Print("PagerDuty API access key: ")
-		fmt.Scanln(&apiKey)
+	command := "interactive"
+	if len(args) > 0 && args[0] != "-i" && args[0] != "--interactive" {
+		command = config.CanonicalCommand(args[0])
 	}
-	if name == "" || apiKey == "" {
-		return fmt.Errorf("name and api-key are required")
+	if command == "telemetry" {
+		return
+	}
+	now := time.Now()
+	t.Record(command, service.ErrorCategory(err), now)
+	if t.Due(now) {
+		if cfg, cerr := config.Load(activeProfile); cerr == nil && cfg.Validate() == nil {
+			done := make(chan error, 1)
+			go func() { done <- api.NewClient(cfg).UploadTelemetry(service.NewTelemetryBatch(t, version, now)) }()
+			select {
+			case uerr := <-done:
+				if uerr == nil {
+					t.Reset(now)
+					t.LastUpload = now
+				}
+			case <-time.After(telemetryUploadWait):
+			}
+		}
 	}
+	_ = t.Save()
+}
 
-	client := api.NewClient(cfg)
-	resp, err := client.AddConnection(&api.AddConnectionRequest{
-		Connection: api.AddConnectionInput{
-			Name:           name,
-			ConnectionType: "CONNECTION_TYPE_PAGERDUTY",
-			PagerdutyConnectionInfo: &api.PagerdutyConnectionInfo{
-				ApiAccessKey: apiKey,
-			},
-		},
-	})
+func cmdTelemetry(args []string) error {
+	sub := "show"
+	if len(args) > 0 {
+		sub = args[0]
+	}
+	t, err := config.LoadTelemetry()
 	if err != nil {
-		return fmt.Errorf("adding connection: %w", err)
+		return fmt.Errorf("reading telemetry state: %w", err)
 	}
-	if resp.Response.ErrorMessage != "" {
-		return fmt.Errorf("server error: %s", resp.Response.ErrorMessage)
+
+	switch sub {
+	case "on":
+		t.Enable(time.Now())
+		if err := t.Save(); err != nil {
+			return err
+		}
+		display.Success("Telemetry on: command counts and error categories will be uploaded daily")
+		fmt.Printf("  %sRun%s hawkeye telemetry show %sto see exactly what is sent.%s\n", display.Dim, display.Reset, display.Dim, display.Reset)
+		return nil
+	case "off":
+		t.Disable()
+		if err := t.Save(); err != nil {
+			return err
+		}
+		display.Success("Telemetry off: the pending batch was discarded and nothing will be sent")
+		return nil
+	case "show":
+	default:
+		return fmt.Errorf("unknown telemetry command: %s (use show, on or off)", sub)
 	}
 
-	display.Header("PagerDuty Connection Added")
-	fmt.Printf("\n  %s%s%s\n", display.Bold, name, display.Reset)
-	fmt.Printf("    %sUUID:%s  %s\n\n", display.Dim, display.Reset, resp.Response.UUID)
+	now := time.Now()
+	batch := service.NewTelemetryBatch(t, version, now)
+	url := api.TelemetryPath
+	if cfg, err := config.Load(activeProfile); err == nil && cfg.Server != "" {
+		url = strings.TrimRight(cfg.Server, "/") + api.TelemetryPath
+	}
+	if jsonOutput {
+		return printJSON(map[string]any{
+			"enabled": t.Enabled,
+			"request": map[string]any{"method": "POST", "url": url, "headers": api.TelemetryHeaders},
+			"pending": batch,
+		})
+	}
+	if !t.Enabled {
+		fmt.Println("Telemetry is off. Nothing is recorded or sent (hawkeye telemetry on to opt in).")
+		return nil
+	}
+
+	display.Header("Telemetry")
+	display.Info("Status:", "on")
+	if !t.LastUpload.IsZero() {
+		display.Info("Last upload:", display.FormatTime(t.LastUpload.Format(time.RFC3339)))
+	}
+	if len(t.Commands) > 0 {
+		display.Info("Next upload:", "after "+display.FormatTime(t.Since.Add(config.TelemetryInterval).Format(time.RFC3339)))
+	}
+	if path, err := config.TelemetryPath(); err == nil {
+		display.Info("State:", path)
+	}
+	fmt.Println()
+	fmt.Printf("  %sPending upload, exactly as it would be sent (no token or organization):%s\n\n", display.Dim, display.Reset)
+	fmt.Printf("  POST %s\n", url)
+	headers := slices.Sorted(maps.Keys(api.TelemetryHeaders))
+	for _, k := range headers {
+		fmt.Printf("  %s: %s\n", k, api.TelemetryHeaders[k])
+	}
+	data, err := json.MarshalIndent(batch, "  ", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Printf("\n  %s\n\n", data)
 	return nil
 }
 
-func cmdConnectionAddFirehydrant(cfg *config.Config, args []string) error {
-	var name, apiKey string
+func cmdAudit(args []string) error {
+	if len(args) > 0 && (args[0] == "list" || args[0] == "ls") {
+		args = args[1:]
+	} else if len(args) > 0 && args[0] == "path" {
+		path, err := config.AuditPath()
+		if err != nil {
+			return err
+		}
+		fmt.Println(path)
+		return nil
+	}
 
+	var since time.Time
+	var profile, command string
+	var failedOnly bool
+	limit := 0
 	for i := 0; i < len(args); i++ {
 		switch args[i] {
-		case "--name":
-			if i+1 < len(args) {
-				i++
-				name = args[i]
-			} else {
-				return fmt.Errorf("--name requires a value")
+		case "--since":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--since requires a value")
 			}
-		case "--api-key":
-			if i+1 < len(args) {
-				i++
-				apiKey = args[i]
-			} else {
-				return fmt.Errorf("--api-key requires a value")
+			i++
+			t, err := service.ParseSince(args[i], time.Now())
+			if err != nil {
+				return err
+			}
+			since = t
+		case "--for-profile":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--for-profile requires a value")
+			}
+			i++
+			profile = args[i]
+		case "--command":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--command requires a value")
+			}
+			i++
+			command = args[i]
+		case "--failed":
+			failedOnly = true
+		case "-n", "--limit":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--limit requires a value")
+			}
+			i++
+			n, err := strconv.Atoi(args[i])
+			if err != nil || n < 1 {
+				return fmt.Errorf("--limit must be a positive number")
 			}
+			limit = n
+		default:
+			return fmt.Errorf("unknown flag: %s", args[i])
+		}
+	}
+
+	all, err := config.LoadAudit(since)
+	if err != nil {
+		return fmt.Errorf("reading audit log: %w", err)
+	}
+	entries := []config.AuditEntry{}
+	for _, e := range all {
+		if (profile != "" && e.Profile != profile) || (command != "" && e.Command != command) ||
+			(failedOnly && e.Status != config.AuditError) {
+			continue
 		}
+		entries = append(entries, e)
+	}
+	if limit > 0 && len(entries) > limit {
+		entries = entries[len(entries)-limit:]
+	}
+
+	if jsonOutput {
+		return printJSON(entries)
 	}
-
-	if name == "" {
-		fmt.Print("Connection name: ")
-		fmt.Scanln(&name)
+	if len(entries) == 0 {
+		fmt.Println("No audit entries.")
+		return nil
 	}
-	if apiKey == "" {
-		fmt.Print("FireHydrant API key: ")
-		fmt.Scanln(&apiKey)
+
+	display.Header(fmt.Sprintf("Audit log (%d)", len(entries)))
+	fmt.Println()
+	for _, e := range entries {
+		status := display.Green + "✓" + display.Reset
+		if e.Status == config.AuditError {
+			status = display.Red + "✗" + display.Reset
+		}
+		line := e.Command
+		if len(e.Args) > 0 {
+			line += " " + quoteArgs(e.Args)
+		}
+		fmt.Printf("  %s %s  %s%-10s%s %s\n", status, display.FormatTime(e.Time.Format(time.RFC3339)),
+			display.Dim, e.Profile, display.Reset, truncate(line, 100))
+		for _, s := range e.Sessions {
+			fmt.Printf("      %sSession:%s %s\n", display.Dim, display.Reset, s)
+		}
+		if e.Error != "" {
+			fmt.Printf("      %sError:%s   %s\n", display.Dim, display.Reset, truncate(e.Error, 100))
+		}
 	}
-	if name == "" || apiKey == "" {
-		return fmt.Errorf("name and api-key are required")
+	fmt.Println()
+	return nil
+}
+
+// quoteArgs joins arguments for display, quoting those with spaces.
+func quoteArgs(args []string) string {
+	quoted := make([]string, len(args))
+	for i, a := range args {
+		if a == "" || strings.ContainsAny(a, " \t\n\"'") {
+			a = strconv.Quote(a)
+		}
+		quoted[i] = a
 	}
+	return strings.Join(quoted, " ")
+}
 
-	client := api.NewClient(cfg)
-	resp, err := client.AddConnection(&api.AddConnectionRequest{
-		Connection: api.AddConnectionInput{
-			Name:           name,
-			ConnectionType: "CONNECTION_TYPE_FIREHYDRANT",
-			FirehydrantConnectionInfo: &api.FirehydrantConnectionInfo{
-				ApiKey: apiKey,
-			},
-		},
-	})
+// writeCheckReport writes r to path as JUnit XML or SARIF, chosen by the
+// file extension.
+func writeCheckReport(path string, r service.CheckReport) error {
+	write, err := service.CheckReportWriter(path)
 	if err != nil {
-		return fmt.Errorf("adding connection: %w", err)
+		return err
 	}
-	if resp.Response.ErrorMessage != "" {
-		return fmt.Errorf("server error: %s", resp.Response.ErrorMessage)
+	f, err := os.Create(path)
+	if err != nil {
+		return err
 	}
-
-	display.Header("FireHydrant Connection Added")
-	fmt.Printf("\n  %s%s%s\n", display.Bold, name, display.Reset)
-	fmt.Printf("    %sUUID:%s  %s\n\n", display.Dim, display.Reset, resp.Response.UUID)
-	return nil
+	err = write(f, r)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	return err
 }
 
-func cmdConnectionAddIncidentio(cfg *config.Config, args []string) error {
-	var name, apiKey string
+// ─── eval ───────────────────────────────────────────────────────────────────
 
-	for i := 0; i < len(args); i++ {
+func cmdEval(args []string) error {
+	if len(args) == 0 || args[0] != "run" || len(args) < 2 {
+		fmt.Println("Usage: hawkeye eval run <suite.yaml|-> [--report <file.xml|file.sarif>] [--score-timeout <duration>]")
+		return nil
+	}
+
+	suitePath := args[1]
+	var reportPaths []string
+	scoreTimeout := summaryWaitTimeout
+	for i := 2; i < len(args); i++ {
 		switch args[i] {
-		case "--name":
+		case "--report", "--junit":
 			if i+1 < len(args) {
 				i++
-				name = args[i]
+				if _, err := service.CheckReportFormat(args[i]); err != nil {
+					return err
+				}
+				reportPaths = append(reportPaths, args[i])
 			} else {
-				return fmt.Errorf("--name requires a value")
+				return fmt.Errorf("%s requires a value", args[i])
 			}
-		case "--api-key":
+		case "--score-timeout":
 			if i+1 < len(args) {
 				i++
-				apiKey = args[i]
+				d, err := time.ParseDuration(args[i])
+				if err != nil || d <= 0 {
+					return fmt.Errorf("--score-timeout must be a duration like 5m")
+				}
+				scoreTimeout = d
 			} else {
-				return fmt.Errorf("--api-key requires a value")
+				return fmt.Errorf("--score-timeout requires a value")
 			}
+		default:
+			return fmt.Errorf("unknown flag: %s", args[i])
 		}
 	}
 
-	if name == "" {
-		fmt.Print("Connection name: ")
-		fmt.Scanln(&name)
+	data, err := readInputFile(suitePath)
+	if err != nil {
+		return fmt.Errorf("reading suite: %w", err)
 	}
-	if apiKey == "" {
-		fmt.Print("incident.io API key: ")
-		fmt.Scanln(&apiKey)
+	suite, err := service.ParseEvalSuite(data)
+	if err != nil {
+		return err
 	}
-	if name == "" || apiKey == "" {
-		return fmt.Errorf("name and api-key are required")
+	if suite.Name == "" && suitePath == "-" {
+		suite.Name = "stdin"
+	} else if suite.Name == "" {
+		suite.Name = strings.TrimSuffix(filepath.Base(suitePath), filepath.Ext(suitePath))
 	}
 
-	client := api.NewClient(cfg)
-	resp, err := client.AddConnection(&api.AddConnectionRequest{
-		Connection: api.AddConnectionInput{
-			Name:           name,
-			ConnectionType: "CONNECTION_TYPE_INCIDENTIO",
-			IncidentioConnectionInfo: &api.IncidentioConnectionInfo{
-				ApiKey: apiKey,
-			},
-		},
-	})
+	cfg, err := config.Load(activeProfile)
 	if err != nil {
-		return fmt.Errorf("adding connection: %w", err)
+		return err
 	}
-	if resp.Response.ErrorMessage != "" {
-		return fmt.Errorf("server error: %s", resp.Response.ErrorMessage)
+	if suite.Project != "" {
+		cfg.ProjectID = suite.Project
 	}
-
-	display.Header("incident.io Connection Added")
-	fmt.Printf("\n  %s%s%s\n", display.Bold, name, display.Reset)
-	fmt.Printf("    %sUUID:%s  %s\n\n", display.Dim, display.Reset, resp.Response.UUID)
-	return nil
-}
-
-// ─── profiles ───────────────────────────────────────────────────────────────
-
-func cmdProfiles() error {
-	profiles, err := config.ListProfiles()
-	if err != nil {
+	if err := cfg.ValidateProject(); err != nil {
 		return err
 	}
 
-	if jsonOutput {
-		return printJSON(map[string]any{
-			"profiles": profiles,
-			"active":   config.ProfileName(activeProfile),
-		})
+	runner := &evalRunner{cfg: cfg, client: api.NewClient(cfg), scoreTimeout: scoreTimeout}
+	interactive := !jsonOutput
+	if interactive {
+		display.Header(fmt.Sprintf("Eval: %s (%d cases)", suite.Name, len(suite.Cases)))
 	}
+	report := service.RunEval(suite, runner, func(r service.EvalCaseResult) {
+		if interactive {
+			printEvalCase(r)
+		}
+	})
 
-	display.Header(fmt.Sprintf("Profiles (%d)", len(profiles)))
-
-	if len(profiles) == 0 {
-		display.Warn("No profiles found.")
-		return nil
+	for _, path := range reportPaths {
+		if err := writeCheckReport(path, report.CheckReport(suitePath)); err != nil {
+			return fmt.Errorf("writing report: %w", err)
+		}
 	}
 
-	for _, p := range profiles {
-		marker := " "
-		if p == config.ProfileName(activeProfile) {
-			marker = display.Green + "●" + display.Reset
+	var failErr error
+	if report.Failed > 0 {
+		failErr = fmt.Errorf("%d of %d eval cases failed", report.Failed, len(report.Results))
+	}
+	if jsonOutput {
+		if err := printJSON(report); err != nil {
+			return err
 		}
-		fmt.Printf("  %s %s\n", marker, p)
+		return failErr
+	}
+
+	printEvalMatrix(report)
+	for _, path := range reportPaths {
+		display.Success(fmt.Sprintf("Report written to %s", path))
+	}
+	if failErr == nil {
+		display.Success(fmt.Sprintf("All %d cases passed", report.Passed))
 	}
 	fmt.Println()
+	return failErr
+}
 
-	return nil
+// evalRunner runs eval cases against the API, each in a fresh session.
+// Eval sessions are not recorded as the last session or in history.
+type evalRunner struct {
+	cfg          *config.Config
+	client       *api.Client
+	scoreTimeout time.Duration
 }
 
-// ─── helpers ────────────────────────────────────────────────────────────────
+func (r *evalRunner) Investigate(prompt string) (string, string, error) {
+	sess, err := r.client.NewSession(r.cfg.ProjectID)
+	if err != nil {
+		return "", "", fmt.Errorf("creating session: %w", err)
+	}
+	sessionUUID := sess.SessionUUID
+	if !r.cfg.NoAutoName {
+		_ = r.client.RenameSession(r.cfg.ProjectID, sessionUUID, service.SessionTitle(prompt))
+	}
+	var collector service.AnswerCollector
+	if err := r.client.ProcessPromptStream(r.cfg.ProjectID, sessionUUID, prompt, collector.Handle); err != nil {
+		return sessionUUID, collector.Answer(), fmt.Errorf("stream error: %w", err)
+	}
+	if collector.Answer() == "" {
+		return sessionUUID, "", fmt.Errorf("investigation finished without an answer")
+	}
+	return sessionUUID, collector.Answer(), nil
+}
 
-func printJSON(v any) error {
-	data, err := json.MarshalIndent(v, "", "  ")
+func (r *evalRunner) Scores(sessionUUID string) (service.ScoreDisplay, error) {
+	fetch := func() (*api.GetSessionSummaryResponse, error) {
+		return r.client.GetSessionSummary(r.cfg.ProjectID, sessionUUID)
+	}
+	resp, err := service.WaitForSummary(fetch, service.HasScores, r.scoreTimeout)
 	if err != nil {
-		return fmt.Errorf("JSON marshal: %w", err)
+		return service.ScoreDisplay{}, err
 	}
-	fmt.Println(string(data))
-	return nil
+	return service.ExtractScores(resp), nil
 }
 
-func wrapText(text string, width int) []string {
-	var lines []string
-	for _, paragraph := range strings.Split(text, "\n") {
-		if paragraph == "" {
-			lines = append(lines, "")
-			continue
-		}
-		words := strings.Fields(paragraph)
-		current := ""
-		for _, word := range words {
-			if current == "" {
-				current = word
-			} else if len(current)+1+len(word) <= width {
-				current += " " + word
-			} else {
-				lines = append(lines, current)
-				current = word
-			}
+func printEvalCase(r service.EvalCaseResult) {
+	icon := display.Green + "✓" + display.Reset
+	if !r.Passed {
+		icon = display.Red + "✗" + display.Reset
+	}
+	fmt.Printf("  %s %s %s(%s)%s\n", icon, r.Name, display.Dim, r.Duration.Round(time.Second), display.Reset)
+	if r.Error != "" {
+		fmt.Printf("      %serror:%s %s\n", display.Red, display.Reset, r.Error)
+	}
+	for _, f := range r.Failures {
+		fmt.Printf("      %s•%s %s\n", display.Yellow, display.Reset, f)
+	}
+}
+
+// printEvalMatrix prints the pass/fail table for a finished run.
+func printEvalMatrix(report service.EvalReport) {
+	score := func(v *float64) string {
+		if v == nil {
+			return "-"
 		}
-		if current != "" {
-			lines = append(lines, current)
+		return fmt.Sprintf("%.0f", *v)
+	}
+	fmt.Println()
+	fmt.Printf("  %s%-28s %-6s %-8s %-5s %-5s %s%s\n", display.Bold, "CASE", "RESULT", "TIME", "ACC", "COMP", "SESSION", display.Reset)
+	for _, r := range report.Results {
+		result := display.Green + "PASS  " + display.Reset
+		if r.Error != "" {
+			result = display.Red + "ERROR " + display.Reset
+		} else if !r.Passed {
+			result = display.Red + "FAIL  " + display.Reset
 		}
+		fmt.Printf("  %-28s %s %-8s %-5s %-5s %s\n", truncate(r.Name, 28), result,
+			r.Duration.Round(time.Second), score(r.Accuracy), score(r.Completeness), r.SessionUUID)
 	}
-	return lines
+	fmt.Println()
+	fmt.Printf("  %d passed, %d failed in %s\n\n", report.Passed, report.Failed, report.Duration.Round(time.Second))
 }
 
-func parseGlobalFlags(args []string) []string {
-	var remaining []string
+// smokeTimeout bounds how long `smoke` waits for the streamed answer.
+const smokeTimeout = 2 * time.Minute
+
+// cmdSmoke checks an environment end to end without touching anything but
+// a throwaway session of its own: token, projects, connections, a session,
+// a streamed answer to a trivial prompt, and deletion of the session. It
+// exits non-zero when a step fails, for synthetic monitoring.
+func cmdSmoke(args []string) error {
+	var projectRef string
+	var reportPaths []string
+	timeout := smokeTimeout
 	for i := 0; i < len(args); i++ {
 		switch args[i] {
-		case "--profile":
-			if i+1 < len(args) {
-				i++
-				activeProfile = args[i]
+		case "--project":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--project requires a value")
 			}
-		case "-j", "--json":
-			jsonOutput = true
-		case "-c", "--continue":
-			continueLastSession = true
+			i++
+			projectRef = args[i]
+		case "--timeout":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--timeout requires a value")
+			}
+			i++
+			d, err := time.ParseDuration(args[i])
+			if err != nil || d <= 0 {
+				return fmt.Errorf("--timeout must be a duration like 2m")
+			}
+			timeout = d
+		case "--report":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--report requires a value")
+			}
+			i++
+			if _, err := service.CheckReportFormat(args[i]); err != nil {
+				return err
+			}
+			reportPaths = append(reportPaths, args[i])
 		default:
-			remaining = append(remaining, args[i])
+			return fmt.Errorf("unknown flag: %s", args[i])
 		}
 	}
-	return remaining
+
+	cfg, err := config.Load(activeProfile)
+	if err != nil {
+		return err
+	}
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+	if projectRef == "" {
+		projectRef = cfg.ProjectID
+	}
+	client := api.NewClient(cfg)
+
+	interactive := !jsonOutput
+	if interactive {
+		display.Header(fmt.Sprintf("Smoke test: %s", cfg.Server))
+	}
+	smoke := service.NewSmoke()
+	printed := 0
+	report := func() {
+		for ; interactive && printed < len(smoke.Steps); printed++ {
+			printSmokeStep(smoke.Steps[printed])
+		}
+	}
+
+	var project *api.ProjectSpec
+	var sessionUUID string
+	smoke.Run("token", func() (string, error) {
+		user, err := client.FetchUserInfo()
+		if err != nil {
+			return "", err
+		}
+		return "signed in as " + user.Email, nil
+	})
+	report()
+	smoke.Run("projects", func() (string, error) {
+		resp, err := client.ListProjects()
+		if err != nil {
+			return "", err
+		}
+		projects := service.FilterSystemProjects(resp.Specs)
+		if projectRef == "" {
+			return "", fmt.Errorf("no project to test (pass --project or run: hawkeye set project <uuid>)")
+		}
+		if project = service.FindProject(projects, projectRef); project == nil {
+			return "", fmt.Errorf("project %q not found among %d", projectRef, len(projects))
+		}
+		return fmt.Sprintf("%d listed, using %s", len(projects), project.Name), nil
+	})
+	report()
+	smoke.Run("connections", func() (string, error) {
+		resp, err := client.ListProjectConnections(project.UUID)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%d configured", len(resp.Specs)), nil
+	})
+	report()
+	smoke.Run("session", func() (string, error) {
+		sess, err := client.NewSession(project.UUID)
+		if err != nil {
+			return "", err
+		}
+		sessionUUID = sess.SessionUUID
+		// Named so that anyone finding it, should cleanup fail, knows
+		// what it is.
+		_ = client.RenameSession(project.UUID, sessionUUID, service.SmokeSessionName)
+		return sessionUUID, nil
+	})
+	report()
+	smoke.Run("prompt", func() (string, error) {
+		type result struct {
+			answer string
+			err    error
+		}
+		done := make(chan result, 1)
+		go func() {
+			var collector service.AnswerCollector
+			err := client.ProcessPromptStream(project.UUID, sessionUUID, service.SmokePrompt, collector.Handle)
+			done <- result{collector.Answer(), err}
+		}()
+		select {
+		case r := <-done:
+			if r.err != nil {
+				return "", fmt.Errorf("stream error: %w", r.err)
+			}
+			answer := strings.Join(strings.Fields(r.answer), " ")
+			if answer == "" {
+				return "", fmt.Errorf("stream finished without an answer")
+			}
+			return "answered " + strconv.Quote(truncate(answer, 40)), nil
+		case <-time.After(timeout):
+			return "", fmt.Errorf("no answer within %s", timeout)
+		}
+	})
+	report()
+	if sessionUUID != "" {
+		smoke.Cleanup("cleanup", func() (string, error) {
+			err := client.DeleteSession(project.UUID, sessionUUID)
+			if service.DeleteUnsupported(err) {
+				return fmt.Sprintf("server cannot delete sessions; left as %q", service.SmokeSessionName), nil
+			}
+			if err != nil {
+				return "", err
+			}
+			return "session deleted", nil
+		})
+		report()
+	}
+
+	for _, path := range reportPaths {
+		if err := writeCheckReport(path, smoke.CheckReport(cfg.Server)); err != nil {
+			return fmt.Errorf("writing report: %w", err)
+		}
+	}
+
+	var failErr error
+	if f := smoke.FailedStep(); f != nil {
+		failErr = fmt.Errorf("smoke test failed at %s: %s", f.Name, f.Message)
+	}
+	if jsonOutput {
+		out := map[string]any{"ok": smoke.OK(), "server": cfg.Server, "duration_ms": smoke.Duration().Milliseconds(), "steps": smoke.Steps}
+		if err := printJSON(out); err != nil {
+			return err
+		}
+		return failErr
+	}
+
+	fmt.Println()
+	for _, path := range reportPaths {
+		display.Success(fmt.Sprintf("Report written to %s", path))
+	}
+	if failErr == nil {
+		display.Success(fmt.Sprintf("All %d steps passed in %s", len(smoke.Steps), service.FormatStepDuration(smoke.Duration())))
+		fmt.Println()
+	}
+	return failErr
 }
 
-func truncate(s string, max int) string {
-	if len(s) <= max {
-		return s
+func printSmokeStep(st service.SmokeStep) {
+	icon := display.Green + "✓" + display.Reset
+	switch st.Status {
+	case service.CheckFailed:
+		icon = display.Red + "✗" + display.Reset
+	case service.SmokeSkipped:
+		icon = display.Dim + "-" + display.Reset
 	}
-	return s[:max-3] + "..."
+	took := ""
+	if st.Status != service.SmokeSkipped {
+		took = service.FormatStepDuration(st.Duration)
+	}
+	fmt.Printf("  %s %-12s %s%7s%s  %s\n", icon, st.Name, display.Dim, took, display.Reset, st.Message)
 }
 
 // ─── usage ──────────────────────────────────────────────────────────────────
 
 func printUsage() {
-	fmt.Printf(`%sHawkeye CLI%s — Neubird AI SRE Platform (v%s)
+	fmt.Print(usageText())
+	if plugins := config.ListPlugins(os.Getenv("PATH"), runtime.GOOS, os.Getenv("PATHEXT")); len(plugins) > 0 {
+		fmt.Printf("%sPlugins:%s\n", display.Cyan, display.Reset)
+		for _, p := range plugins {
+			fmt.Printf("  %-30s %s%s%s\n", p.Name, display.Dim, p.Path, display.Reset)
+		}
+		fmt.Println()
+	}
+}
+
+// usageText is the full command reference. `help <command>` prints the
+// lines of it about one command.
+func usageText() string {
+	return fmt.Sprintf(`%sHawkeye CLI%s — Neubird AI SRE Platform (v%s)
 
 %sUsage:%s
   hawkeye                                            Launch interactive mode (default)
   hawkeye [--profile <name>] [-j] <command> [args]   Run a specific command
+  hawkeye help <command>                             Show one command's usage and flags
+  hawkeye <name> [args]                              Run a hawkeye-<name> plugin from PATH, like git
+                                                     (gets HAWKEYE_BIN, HAWKEYE_PROFILE, HAWKEYE_SERVER, HAWKEYE_PROJECT_ID)
 
 %sGlobal Options:%s
   --profile <name>            Use a named config profile (default: unnamed)
+  --org <uuid|name>           Act in another organization you belong to for this run only
   -j, --json                  Output results as JSON (for scripting/piping)
+  --fields <a,b.c,...>        Only these JSON fields, dotted for nested ones; implies --json
   -c, --continue              Resume the last used session in interactive mode
+  --relative                  Show times relative to now ("2h ago")
+  --no-emoji, --ascii         Replace icons with ASCII markers (or HAWKEYE_ASCII=1; =0 keeps icons)
+  --accessible                Screen-reader output: no color or spinners, icons as words (or HAWKEYE_ACCESSIBLE=1)
+  HAWKEYE_COLOR=<level>       Force none, 16, 256 or truecolor instead of detecting the terminal
+  --width <n>                 Wrap output at n columns (tables are fitted to it, else to the terminal)
+  --wide                      Show extra columns in sessions, projects, connections and queries tables
+  --proxy <url>               Send API requests through this proxy (overrides set proxy and HTTPS_PROXY)
+  --insecure-skip-verify      Do not verify the server's TLS certificate (testing only)
+  --no-defaults               Ignore command defaults from config set-default for this run
+  --no-cache                  Fetch project, connection and prompt lists without the ETag response cache
+  --no-banner                 Start interactive mode without the banner (for scripted sessions)
+  --output <text|json|gha>    gha: GitHub Actions annotations and job summary (investigate, score)
+  HAWKEYE_FIXTURES=<dir>      Answer every request from canned files instead of a server (see docs/fixtures)
 
 %sGetting Started:%s
   login <url> -u <user> -p <pass>  Authenticate (URL = frontend address)
   set project <uuid>               Set the active project UUID
   config                           Show current configuration
+  config encrypt                   Encrypt the profile with a passphrase (or HAWKEYE_CONFIG_PASSPHRASE)
+  config decrypt                   Store the profile as plaintext again
+  config validate                  Check server URL, UUIDs, timezone, theme and language
+  config set-default <cmd.flag> <v>  Default a command's flag, e.g. sessions.limit 50 (true/false for switches)
+  config unset-default <cmd.flag>  Remove a command default
+  config defaults                  List command defaults
+  config export                    Print the profile's shareable settings as YAML
+    --include-secrets              Also export the token and proxy password (left out by default)
+    --out <file>                   Write to a file instead of stdout
+  config import <file.yaml>        Apply shared settings to the profile (--profile picks which)
+    --dry-run                      Only list what would change
+  config show-term                 Show detected unicode and color support (and why)
 
 %sProjects:%s
   projects                         List available projects
   projects info <uuid>             Get project details
   projects create <name>           Create a new project
     --description <text>           Project description
+  projects clone <uuid|name>       Create a look-alike project (--name <new-name> required)
+    --with-connections             Attach the same connections
+    --with-instructions            Copy the instructions, keeping disabled ones disabled
+    --dry-run                      Show what would be copied
   projects update <uuid>           Update a project
     --name <name>                  New project name
     --description <text>           New description
-  projects delete <uuid>           Delete a project
+  projects delete <uuid>           Delete a project (admins)
     --confirm                      Skip confirmation prompt
+  apply -f <project.yaml>          Create/update a project from a manifest
+    --dry-run                      Show the changes without applying them
+    --yes                          Apply instruction changes (without it they are only shown)
 
 %sSettings:%s
   set server <url>          Override the server URL
   set project <uuid>        Set the active project UUID
   set token <jwt>           Manually set the auth token
-  set org <uuid>            Set the organization UUID
+  set org <uuid|name>       Set the organization (checks the active project belongs to it)
+  set org --interactive     Pick an organization from a list
+  set timezone <tz>         Display times in a zone, e.g. Europe/Berlin (local to reset)
+  set theme <name|file>     Colors: auto, dark, light, mono or a .json palette (NO_COLOR=1 disables color)
+  set banner <name>         Interactive-mode banner: hawk (default), toucan, minimal or none
+  set auto-name <on|off>    Name new sessions after their first prompt (default: on)
+  set notifications <on|off>  Desktop notification when a background or 30s+ investigation finishes
+  set language <code>       Answer and summarize in a language, e.g. ja or pt-BR (auto to reset)
+  set proxy <url>           Send API requests through an HTTP(S) or socks5 proxy (none to reset)
+  set ca-cert <path>        Also trust the CA certificates in a PEM file (none to reset)
+  set client-cert <path>    Client certificate for mTLS; pair with set client-key <path>
+  set transcript-dir <dir>  Write a markdown transcript of every investigation to <dir> (none to reset)
+  set timeout <duration>    Give up on API calls after this long, e.g. 60s (default 2m; none for no limit)
+  set stream-idle-timeout <duration>  Abort a stream with no events for this long, e.g. 10m (default 10m)
+  orgs                      List organizations you belong to
 
 %sInvestigation:%s
   investigate|ask "<question>"         Run an AI-powered investigation (streams output)
     -s, --session <uuid>               Continue in an existing session
+    --k8s-context <name>               Attach live kubectl context (pods, events, deployments)
+    --namespace <ns>                   Kubernetes namespace for --k8s-context
+    -f, --file <path|->                Read the question from a file, or stdin with -, sent as written (markdown, code blocks)
+    --context <file|->                 Attach a file, or piped stdin with -, as context (repeatable; large input is truncated)
+    --answer-only                      Print only the final answer (for piping)
+    -q, --quiet                        No streaming output; with -j/--output json print one result document:
+                                       {session_uuid, final_answer, action_items, sources, scores?, console_url}
+    --verbosity <level>                quiet (progress dots and the answer), normal or verbose (every delta and raw sources)
+    --json-stream                      Write stream events to stdout as NDJSON
+    --record <file>                    Record the raw event stream to an NDJSON file
+    --no-auto-name                     Leave a new session unnamed (default: named after the prompt)
+    --lang <code>                      Response language for this run (overrides set language)
+    --sink <spec>                      Also send the result to file://<path>, an http(s) webhook or jira://<key> (repeatable)
+    --projects <uuid|name,...>         Ask in each listed project at once and compare the answers
+    --all-projects                     Ask in every project at once
+    --concurrency <n>                  Parallel projects for --projects/--all-projects (default: 3)
+    --template <name>                  Use a saved template's prompt, instructions and telemetry scope
+    --var <name>=<value>               Fill a {{name}} placeholder of the template (repeatable)
+  rca "<question>"                     Investigate, wait for the summary and scores, and print one RCA report
+    --export <md|json>                 Also write the report to rca-<date>-<name>.<ext>
+    -o, --out <path>                   Report path ({{session}}, {{name}}, {{date}} are filled in)
+    --ticket jira[:<key>]              Open a Jira issue with the report (JIRA_URL, JIRA_USER, JIRA_API_TOKEN;
+                                       key defaults to JIRA_PROJECT)
+    --sink <spec>                      Also send the report to a sink (repeatable)
+    --timeout <dur>                    How long to wait for the summary and scores (default: 10m)
+    --no-scores                        Do not wait for RCA scores
+  interactive                          Launch interactive mode, as with no command (also -i, --interactive)
+  resume [session-uuid]                Reopen the last session interactively: show its answer, then ask follow-ups
+  replay <file>                        Re-render a recorded stream offline
+    --speed <2x|0.5x|max>              Playback speed (default: 1x)
   investigate-alert <alert-id>         Investigate from an alert
     --project <uuid>                   Override project UUID
+    --alertmanager-payload <file|->    Investigate the firing alerts of an Alertmanager webhook JSON (no alert ID needed)
+    --from-file <path|->               Investigate every alert ID in a file or stdin (one per line)
+    --all-open                         Investigate all open (not started) incident sessions
+    --limit <n>                        Max open alerts for --all-open (default: 10)
+    --concurrency <n>                  Parallel investigations for bulk runs (default: 3)
+    --lang <code>                      Response language for this run (overrides set language)
+  listen                               Receive alert webhooks and investigate each firing alert
+    --port <n>                         Port to listen on (default: 8787)
+    --secret <key>                     Shared secret (or HAWKEYE_WEBHOOK_SECRET): an HMAC signature, or the
+                                       secret as a bearer token or basic-auth password (Alertmanager)
+    --project <uuid|name>              Project to investigate in (default: the active one)
+    --concurrency <n>                  Parallel investigations (default: 2)
+    --queue <n>                        Alerts waiting for a worker before deliveries get 503 (default: 32)
+    --sink <spec>                      Post each result to file://<path> or an http(s) webhook (repeatable)
+    --lang <code>                      Response language (overrides set language)
+  watch                                Report uninvestigated incidents as they arrive
+    --auto-investigate                 Queue and investigate them (the queue survives restarts; one per profile)
+    --max-concurrent <n>               Investigations in flight at once (default: 2)
+    --priority-order <P1,P2,...>       Start these priorities first, from the incident title (others after)
+    --business-hours <9-18 Mon-Fri>    Only start investigations in this window (set timezone applies)
+    --interval <duration>              Poll interval (default: 1m)
+  watch queue [clear]                  Show the queue and what became of each incident, or clear it (no watch running)
+  groups [list]                        List incident groups: related alerts investigated as one
+    -n, --limit <count>                Number of groups to list
+  groups show <group-id>               Show a group's member alerts and representative session
+  groups investigate <group-id>        Investigate a group in its representative session (or one from its first alert)
   queries [session-uuid]               Show investigation queries
+    --project <uuid|name>              Project the session is in (default: the active one, then a search)
+  sources [session-uuid]               List cited sources in full with the queries that touched them
+    --cycle <n>                        Only prompt cycle n (default: all)
+  stats [session-uuid]                 Timing breakdown: wall time, slowest steps, queries, sources
+    --project                          Aggregate over recent sessions instead
+    -n, --limit <count>                Sessions to analyze with --project (default: 20)
+    --top <n>                          Slowest steps/sessions to show (default: 5)
   link [session-uuid]                  Get web UI URL for a session
+    --copy                             Also copy the URL to the clipboard
+    --project <uuid|name>              Project the session is in (default: the active one)
+  share [session-uuid]                 Create a read-only link for people without an account and copy it
+    --expires <span>                   How long the link works, e.g. 12h, 7d or 2w (default: 7d, max: 90d)
+    --no-copy                          Only print the link
+    --project <uuid|name>              Project the session is in (default: the active one, then a search)
   open <url>                           Open a web console URL in interactive mode
   parse <url>                          Parse a web console URL, set project + session
+  open-url <url>                       Set project + session from a console URL and inspect it
 
 %sSessions:%s
   sessions                  List recent investigation sessions
     -n, --limit <count>     Number of sessions to list (default: 20)
-    --status <status>       Filter by status (not_started, in_progress, investigated)
+    --status <status>       Filter by status (not_started, in_progress, investigated, paused, failed)
     --from <date>           Filter sessions created after date
     --to <date>             Filter sessions created before date
     --search <text>         Search sessions by title
+    --search-mode <mode>    auto (default), server, or client-side matching
     --uninvestigated        Shorthand for --status not_started
+    --tag <tag>             Only sessions with this local tag (repeatable)
+    --filter <expr>         Filter on fields, e.g. 'status=investigated,type=incident,created>7d,name~timeout'
+                              Fields: status, type (= !=), created, updated (> >= < <=), name (~)
+  sessions tag <uuid> <tag...>    Tag a session locally (e.g. sev1 payments)
+  sessions untag <uuid> [tag...]  Remove tags (all when none given)
+  sessions tags             List tags in use
+  sessions autoname         Name unnamed sessions after their first prompt
+    -n, --limit <count>     Recent sessions to check (default: 20)
+    --uninvestigated        Only check not-started sessions
+    --dry-run               Show the names without renaming
+  sessions export --out <file>  Archive all sessions as NDJSON or a tar of per-session JSON files
+    --format <ndjson|tar>   Archive format (default: from the file extension, else ndjson)
+    --inspect               Include each session's full inspect payload
+    --status, --from, --to, --uninvestigated   Filter as for sessions
+    --rate <n>              API requests per second (default: 5, 0 for no limit)
+    --page-size <n>         Sessions per page (default: 100)
+    --resume                Continue an interrupted export from its checkpoint
+    --force                 Overwrite an existing file
+  search "<text>"           Search prompts, answers and summaries of locally indexed sessions
+    -n, --limit <n>         Maximum results (default: 10)
+    --all-projects          Search sessions of every project, not just the active one
+    --sync                  Index new and changed sessions from the server first
+    --sync-limit <n>        Most recent sessions to check when syncing (default: 200)
   inspect [session-uuid]    View session details (defaults to last session)
+    --answer-only           Print only the latest final answer
+    --copy-answer           Copy the latest final answer to the clipboard
+    --cot <none|summary|full>  Chain-of-thought detail (default: full)
+    --category <name>       Only steps in this category (repeatable)
+    --cycle <n>             Only prompt cycle n
+    --failed-only           Only steps that errored or failed
+    --verify <file>         Compare with a saved inspect --json and flag what changed since
+    --project <uuid|name>   Project the session is in; without it a 404 searches your other projects
+  chat [session-uuid]       Read the session as a conversation: prompts and answers only
+    -f, --follow            Keep printing new turns while someone else drives the session
+    --interval <duration>   How often --follow checks (default: 5s)
+    --project <uuid|name>   Project the session is in (as for inspect)
   summary [session-uuid]    Get executive summary (defaults to last session)
+    --format <view>         executive (issue, impact, resolution, actions), engineer
+                            (full analysis + key queries) or timeline (steps in order)
+    --sink <spec>           Also write it to file://<path> ({{session}}, {{name}}, {{date}}) or a webhook
+    --wait                  Poll until the summary is generated
+    --timeout <duration>    Give up waiting after this long (default: 10m; implies --wait)
+    --project <uuid|name>   Project the session is in (as for inspect)
   feedback|td [session-uuid]  Thumbs down feedback (defaults to last session)
-    -r, --reason <text>     Reason for negative feedback
+    -r, --reason <text>     Reason for the feedback
+    --up                    Thumbs up instead of down
+    --cycle <n>             Rate prompt cycle n instead of the last one
+    --project <uuid|name>   Project the session is in (as for inspect; also for history and import)
+  feedback history [uuid]   Show the rating of each prompt cycle
+  feedback import <file|->  Submit ratings in bulk from CSV: session_uuid, cycle, rating, reason
+    --dry-run               Check every row against its session without submitting
 
 %sAnalysis:%s
   score [session-uuid]      Show RCA quality scores
+    --min-accuracy <n>      Exit non-zero if accuracy is below n (0-100)
+    --min-completeness <n>  Exit non-zero if completeness is below n (0-100)
+    --wait                  Poll until scoring completes
+    --timeout <duration>    Give up waiting after this long (default: 10m; implies --wait)
+    --project <uuid|name>   Project the session is in (as for inspect)
   report                    Show org-wide incident analytics
+  report trend              Chart a report metric per period with a sparkline
+    --metric <name>         mttr, time-saved or noise (default: mttr)
+    --window <span>         How far back, e.g. 30d, 12w or 6mo (default: 12w)
+    --every <period>        day, week or month (default: the window's unit)
+    --csv                   Print the periods as CSV
+  digest                    Roll up recent incidents, investigations, root causes and action items
+    --since <span>          Window to cover, e.g. 24h, 3d or 2026-10-01 (default: 24h)
+    --format <md|json>      Print markdown to paste into standup notes, or JSON
+    -n, --limit <n>         Number of recent sessions to read (default: 100)
+  actions [list] [session-uuid]  Action items from recent session summaries, numbered
+    -n, --limit <n>         Number of recent sessions to read (default: 20)
+    --open                  Hide items marked done
+  actions done <n>...       Mark action items done (undo <n>... reopens them)
+  actions export            Print the action items as a markdown checklist
+    --format <md|json>      Output format (default: md)
 
 %sConnections:%s
   connections                              List data source connections
   connections resources <conn-uuid>        List resources for a connection
+    -n, --limit <n>                        Maximum resources (default: 100)
+    --all                                  List every resource, however many pages
   connections types                        List supported connection types
   connections info <conn-uuid>             Get connection details
-  connections create <type> <name>         Create a connection
+  connections create <type> <name>         Create a connection (admins)
   connections sync <conn-uuid>             Wait for connection sync
     --timeout <seconds>                    Timeout in seconds (default: 300)
+  connections rotate-secret <conn-uuid>    Replace a connection's credentials, keeping its training (admins)
+    --<credential> <value|->               New value, e.g. --api-key; - reads it without echo
+    --no-wait                              Don't wait for the connection to re-sync
+    --timeout <seconds>                    Timeout in seconds (default: 300)
+  connections train <conn-uuid>            (Re)trigger connection training
+    --wait                                 Wait until training finishes
+    --timeout <seconds>                    Timeout in seconds (default: 600)
+  connections status                       Sync and training state of project connections
+    --watch, -w                            Refresh in place until all have settled
+    --interval <seconds>                   Refresh interval (default: 5)
   connections add <conn-uuid>              Add connection to current project
   connections remove <conn-uuid>           Remove connection from project
     --confirm                              Skip confirmation prompt
   connections project                      List project connections
+  connections import --from <source>       Propose connections from a .tfstate file or aws-profiles
+    --yes                                  Create every complete candidate without asking
+    --dry-run                              Only list the candidates
+    --add                                  Also add created connections to the current project
+  incidents add <type>                     Connect pagerduty, firehydrant or incidentio (--name, --api-key)
+  incidents test <type>                    Create a test incident (--api-key, --routing-key, --file, --run-level)
 
 %sInstructions:%s
   instructions                     List project instructions
@@ -2933,6 +12200,18 @@ func printUsage() {
   instructions create <name>       Create an instruction
     --type <filter|system|grouping|rca>  Instruction type
     --content <text>               Instruction content
+    --content-file <path>          Read instruction content from a file
+  instructions update <uuid>       Edit an instruction
+    --name <name>                  New instruction name
+    --content <text>               New instruction content
+    --content-file <path>          Read new content from a file
+    --yes                          Apply a content change (without it the diff is only shown)
+  instructions export              Export instructions as YAML
+    --out <path>                   Write to a file instead of stdout
+  instructions import <file.yaml>  Apply instructions from a YAML file
+    --prune                        Delete instructions missing from the file
+    --dry-run                      Show the changes without applying them
+    --yes                          Apply the changes (without it they are only shown)
   instructions enable <uuid>       Enable an instruction
   instructions disable <uuid>      Disable an instruction
   instructions delete <uuid>       Delete an instruction
@@ -2940,32 +12219,100 @@ func printUsage() {
   instructions validate            Validate instruction content
     --type <type>                  Instruction type
     --content <text>               Content to validate
+    --content-file <path>          Read content to validate from a file
   instructions apply <session-uuid>  Apply instruction to session
     --type <type>                  Instruction type
     --content <text>               Instruction content
   rerun <session-uuid>             Rerun an investigation
+  rerun --status <status>          Rerun every matching session (e.g. not_started, failed)
+    --since <when>                 Only sessions created since (e.g. 24h, 7d, 2025-01-01)
+    -n, --limit <n>                At most n sessions (default: 20)
+    --concurrency <n>              Reruns in flight at once (default: 4)
+    --dry-run                      List the sessions without rerunning them
 
 %sDiscovery & Reports:%s
   discover                         Discover project resources
     --telemetry-type <type>        Filter by telemetry type (metric, log, trace)
     --connection-type <type>       Filter by connection type (aws, datadog, etc.)
-  resource-types <conn> <telemetry>  List resource types (static)
+  resource-types <conn> <telemetry>  List resource types supported by the server
+    --static                       Use the built-in list instead of asking the server
   session-report <uuid> [<uuid>...]  Per-session report with time-saved metrics
+    --project <uuid|name>          Project the sessions are in (as for inspect)
 
 %sLibrary:%s
   prompts                   Browse available investigation prompts
+  prompts push <name>       Add or replace a shared library prompt (--prompt <text> or --file <path|->)
+    --dry-run                 Show the diff without changing the library
+  prompts delete <uuid>     Remove a library prompt (--confirm to proceed, --dry-run to preview)
+  template create <name>    Save an investigation template (--prompt <text> or --prompt-file <path|->)
+    --instruction <type>:<text>  Session instruction to apply with it (repeatable; rca, system, filter, grouping)
+    --telemetry <types>          Limit it to metric, log and/or trace telemetry
+    --force                      Replace an existing template
+  template list             List saved templates
+  template show <name>      Show a template's prompt, variables and instructions
+  template delete <name>    Delete a template
 
 %sProfiles:%s
   profiles                    List all config profiles
 
+%sAliases:%s
+  alias [list]                List session aliases for this profile
+  alias add <name> [uuid]     Name a session (defaults to the last session)
+  alias rm <name>             Remove an alias
+                              Aliases work anywhere a session UUID is accepted
+
+%sHistory:%s
+  history                     List recent prompts with their session UUIDs
+    -n, --limit <n>           Number of entries (default: 20)
+    --search <text>           Only prompts containing text
+    --clear                   Delete the saved history
+
+%sAudit:%s
+  audit [list]                Show the local log of commands run on this machine
+    --since <when>            Only entries since a lookback or date (e.g. 7d, 12h, 2025-01-01)
+    --for-profile <name>      Only entries for a profile
+    --command <name>          Only entries for a command
+    --failed                  Only commands that failed
+    -n, --limit <n>           Only the newest n entries
+  audit path                  Print the audit log location
+
+%sTelemetry:%s
+  telemetry [show]            Show whether usage telemetry is on and the batch that would be sent
+  telemetry on|off            Opt in to or out of anonymous usage counts (off by default)
+                              Only command names and error categories are counted, uploaded daily
+
+%sEvaluation:%s
+  eval run <suite.yaml|->     Run golden questions and check the answers
+    --report <file>           Also write JUnit XML (.xml) or SARIF (.sarif, .json); repeatable
+    --score-timeout <dur>     Wait this long for scores when a case sets minima (default: 10m)
+                              Cases set expect: contains, not_contains, matches,
+                              min_accuracy, min_completeness; exits non-zero on failure
+  smoke                       Check login, projects, connections and a streamed answer end to end,
+                              in a throwaway session that is deleted after; exits non-zero on failure
+    --project <uuid|name>     Project to test (default: the current one)
+    --timeout <dur>           Wait this long for the answer (default: 2m)
+    --report <file>           Also write JUnit XML (.xml) or SARIF (.sarif, .json); repeatable
+
+%sExit codes:%s
+  0                           Success
+  1                           CLI, network or API error
+  3                           The backend reported the investigation failed (see queries/rerun)
+
+%sEnvironment:%s
+  HAWKEYE_OTEL_EXPORTER       Send OpenTelemetry traces of API calls and streams to a collector,
+                              e.g. otlp://localhost:4318 (otlps:// for TLS); OTEL_EXPORTER_OTLP_HEADERS,
+                              OTEL_SERVICE_NAME and TRACEPARENT are honoured
+
 %sExamples:%s
   hawkeye                                            # Start interactive mode
   hawkeye login https://myenv.app.neubird.ai/ -u admin@company.com -p secret
   hawkeye set project 66520f61-6a43-48ac-8286-a7e7cf9755c5
   hawkeye investigate "Why is the API returning 500 errors?"
   hawkeye investigate "Check DB connections" -s <session-uuid>
+  hawkeye investigate -f - <<'EOF'                   # Multi-paragraph question from a heredoc
   hawkeye sessions --uninvestigated
   hawkeye sessions --status investigated --from 2025-01-01
+  hawkeye sessions --filter 'type=incident,created>7d,name~timeout'
   hawkeye score <session-uuid>
   hawkeye link <session-uuid>
   hawkeye report
@@ -2988,5 +12335,12 @@ func printUsage() {
 		display.Cyan, display.Reset, // Discovery & Reports
 		display.Cyan, display.Reset, // Library
 		display.Cyan, display.Reset, // Profiles
+		display.Cyan, display.Reset, // Aliases
+		display.Cyan, display.Reset, // History
+		display.Cyan, display.Reset, // Audit
+		display.Cyan, display.Reset, // Telemetry
+		display.Cyan, display.Reset, // Evaluation
+		display.Cyan, display.Reset, // Exit codes
+		display.Cyan, display.Reset, // Environment
 		display.Cyan, display.Reset) // Examples
 }
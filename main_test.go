@@ -2,8 +2,18 @@ package main
 
 import (
 	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
+	"time"
+
+	"hawkeye-cli/internal/config"
+	"hawkeye-cli/internal/service"
 )
 
 func TestWrapText(t *testing.T) {
@@ -346,3 +356,376 @@ func TestParseGlobalFlagsJSON(t *testing.T) {
 		})
 	}
 }
+
+func TestResolveContent(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rules.md")
+	if err := os.WriteFile(path, []byte("line one\nline two\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		content string
+		path    string
+		want    string
+		wantErr bool
+	}{
+		{name: "inline", content: "inline text", want: "inline text"},
+		{name: "file", path: path, want: "line one\nline two"},
+		{name: "both", content: "x", path: path, wantErr: true},
+		{name: "missing file", path: filepath.Join(t.TempDir(), "nope"), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := resolveContent(tt.content, tt.path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("resolveContent() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("resolveContent() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadPromptFile(t *testing.T) {
+	dir := t.TempDir()
+	prompt := "Checkout is slow since 09:00.\n\n```\nERROR pool exhausted\n```\n\n- 09:00 deploy\n"
+	path := filepath.Join(dir, "incident.md")
+	if err := os.WriteFile(path, []byte("\n"+strings.ReplaceAll(prompt, "\n", "\r\n")), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	got, err := readPromptFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := strings.TrimRight(prompt, "\n"); got != want {
+		t.Errorf("readPromptFile() = %q, want %q", got, want)
+	}
+
+	blank := filepath.Join(dir, "blank.md")
+	if err := os.WriteFile(blank, []byte("\n  \n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := readPromptFile(blank); err == nil {
+		t.Error("an empty prompt file should be refused")
+	}
+	if _, err := readPromptFile(filepath.Join(dir, "nope.md")); err == nil {
+		t.Error("a missing prompt file should fail")
+	}
+}
+
+func TestParseGlobalFlagsRelative(t *testing.T) {
+	relativeTimes = false
+	defer func() { relativeTimes = false }()
+	got := parseGlobalFlags([]string{"sessions", "--relative", "-n", "5"})
+	if !relativeTimes {
+		t.Error("relativeTimes = false, want true")
+	}
+	if len(got) != 3 || got[0] != "sessions" {
+		t.Errorf("remaining args = %v", got)
+	}
+}
+
+func TestParseGlobalFlagsOutput(t *testing.T) {
+	defer func() { noEmoji, outputWidth, outputFormat = false, 0, "" }()
+	tests := []struct {
+		args       []string
+		wantASCII  bool
+		wantWidth  int
+		wantFormat string
+		wantRest   int
+	}{
+		{[]string{"sessions", "--no-emoji"}, true, 0, "", 1},
+		{[]string{"--ascii", "--width", "80", "inspect", "x"}, true, 80, "", 2},
+		{[]string{"sessions", "--width", "0"}, false, -1, "", 1},
+		{[]string{"sessions", "--width"}, false, -1, "", 1},
+		{[]string{"--output", "GHA", "score", "x"}, false, 0, "gha", 2},
+		{[]string{"score", "--output"}, false, 0, "invalid", 1},
+		{[]string{"sessions", "export", "--out", "all.jsonl"}, false, 0, "", 4},
+	}
+	for _, tt := range tests {
+		noEmoji, outputWidth, outputFormat = false, 0, ""
+		got := parseGlobalFlags(tt.args)
+		if noEmoji != tt.wantASCII || outputWidth != tt.wantWidth || outputFormat != tt.wantFormat || len(got) != tt.wantRest {
+			t.Errorf("parseGlobalFlags(%v): ascii=%v width=%d output=%q rest=%v", tt.args, noEmoji, outputWidth, outputFormat, got)
+		}
+	}
+}
+
+func TestParseGlobalFlagsFields(t *testing.T) {
+	defer func() { fieldsFlag = nil }()
+	got := parseGlobalFlags([]string{"sessions", "--fields", "session_uuid,name", "-n", "5"})
+	if fieldsFlag == nil || *fieldsFlag != "session_uuid,name" || len(got) != 3 {
+		t.Errorf("fields = %v, rest = %v", fieldsFlag, got)
+	}
+	fieldsFlag = nil
+	parseGlobalFlags([]string{"sessions", "--fields"})
+	if fieldsFlag == nil || *fieldsFlag != "" {
+		t.Errorf("a bare --fields should be kept for main to reject, got %v", fieldsFlag)
+	}
+}
+
+func TestParseWaitTimeout(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    time.Duration
+		wantErr bool
+	}{
+		{"10m", 10 * time.Minute, false},
+		{"90s", 90 * time.Second, false},
+		{"120", 120 * time.Second, false},
+		{"0", 0, true},
+		{"-5m", 0, true},
+		{"soon", 0, true},
+	}
+	for _, tt := range tests {
+		got, err := parseWaitTimeout(tt.in)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("parseWaitTimeout(%q) = %v, %v", tt.in, got, err)
+		}
+	}
+}
+
+func TestCLICommands(t *testing.T) {
+	commands := cliCommands()
+	usage := usageText()
+	for _, c := range commands.Commands() {
+		for _, alias := range c.Aliases {
+			if strings.HasPrefix(alias, "-") {
+				continue
+			}
+			// Defaults, audit and telemetry record a command under its
+			// canonical name, so config must know every alias.
+			if got := config.CanonicalCommand(alias); got != c.Name {
+				t.Errorf("config.CanonicalCommand(%q) = %q, want %q", alias, got, c.Name)
+			}
+		}
+		if c.Name != "help" && c.Name != "version" && service.UsageBlock(usage, c.Name) == "" {
+			t.Errorf("%s is missing from the usage text", c.Name)
+		}
+	}
+	for _, name := range []string{"ask", "resume", "interactive", "-i", "--interactive"} {
+		if _, ok := commands.Lookup(name); !ok {
+			t.Errorf("%s is not registered", name)
+		}
+	}
+}
+
+func TestRecordAuditRedactsRotateSecret(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("SNAP_USER_COMMON", "")
+	secrets := []string{"AWS-S3CR3T", "DD-APP-KEY", "AZ-CLIENT-SECRET", "GCP-SA-JSON", "xoxb-bot-token"}
+	recordAudit([]string{"connections", "rotate-secret", "c1",
+		"--secret-access-key", secrets[0],
+		"--app-key=" + secrets[1],
+		"--client-secret", secrets[2],
+		"--service-account-json", secrets[3],
+		"--bot-token", secrets[4],
+	}, time.Now(), nil)
+
+	entries, err := config.LoadAudit(time.Time{})
+	if err != nil || len(entries) != 1 {
+		t.Fatalf("LoadAudit() = %v, %v", entries, err)
+	}
+	data, _ := json.Marshal(entries[0])
+	for _, s := range secrets {
+		if strings.Contains(string(data), s) {
+			t.Errorf("audit entry contains secret %q: %s", s, data)
+		}
+	}
+}
+
+func TestDefaultsSkipSubcommands(t *testing.T) {
+	sessions, _ := cliCommands().Lookup("sessions")
+	c := config.Config{Defaults: map[string]string{"sessions.limit": "50"}}
+	for _, args := range [][]string{{"sessions", "tag", "abc", "prod"}, {"sessions", "export"}} {
+		if got := c.ApplyDefaults(args, sessions.Subcommands); len(got) != len(args) {
+			t.Errorf("ApplyDefaults(%v) = %v, want no defaults on a subcommand", args, got)
+		}
+	}
+	if got := strings.Join(c.ApplyDefaults([]string{"sessions"}, sessions.Subcommands), " "); got != "sessions --limit 50" {
+		t.Errorf("ApplyDefaults(sessions) = %q", got)
+	}
+}
+
+func TestFinishWatchItemNotifies(t *testing.T) {
+	var got []service.Notification
+	orig := desktopNotify
+	desktopNotify = func(n service.Notification) error { got = append(got, n); return nil }
+	defer func() { desktopNotify = orig }()
+
+	cfg := &config.Config{Server: "https://x.neubird.ai", ProjectID: "p1", Notifications: true}
+	started := time.Now().Add(-time.Minute)
+	ok := &config.WatchItem{SessionUUID: "s1", Name: "checkout latency", StartedAt: started}
+	finishWatchItem(cfg, ok, service.BulkResult{Status: service.BulkStatusCompleted, Summary: "done"}, "")
+	failed := &config.WatchItem{SessionUUID: "s2", StartedAt: started}
+	finishWatchItem(cfg, failed, service.BulkResult{Status: service.BulkStatusFailed, Error: "timed out"}, "")
+
+	if ok.Status != config.WatchCompleted || failed.Status != config.WatchFailed {
+		t.Errorf("statuses = %s, %s", ok.Status, failed.Status)
+	}
+	if len(got) != 2 {
+		t.Fatalf("notifications = %+v, want 2", got)
+	}
+	if got[0].Body != "checkout latency" || !strings.Contains(got[0].Title, "complete") {
+		t.Errorf("completed notification = %+v", got[0])
+	}
+	if !strings.Contains(got[1].Title, "failed") || !strings.Contains(got[1].Body, "timed out") {
+		t.Errorf("failed notification = %+v", got[1])
+	}
+
+	cfg.Notifications = false
+	finishWatchItem(cfg, &config.WatchItem{SessionUUID: "s3", StartedAt: started}, service.BulkResult{Status: service.BulkStatusCompleted}, "")
+	if len(got) != 2 {
+		t.Error("notified with notifications off")
+	}
+}
+
+func TestCreateTranscriptFile(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "rca")
+	f, err := createTranscriptFile(dir, "a.md")
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+	if _, err := createTranscriptFile(dir, "a.md"); err == nil {
+		t.Error("an existing transcript should not be overwritten")
+	}
+}
+
+func TestWriteCheckReport(t *testing.T) {
+	dir := t.TempDir()
+	report := service.CheckReport{Name: "smoke", Checks: []service.CheckResult{{ID: "auth", Name: "auth", Status: service.CheckPassed}}}
+	for _, name := range []string{"junit.xml", "scan.sarif"} {
+		path := filepath.Join(dir, name)
+		if err := writeCheckReport(path, report); err != nil {
+			t.Fatalf("writeCheckReport(%s) error = %v", name, err)
+		}
+		if data, err := os.ReadFile(path); err != nil || len(data) == 0 {
+			t.Errorf("%s: %d bytes, %v", name, len(data), err)
+		}
+	}
+	if err := writeCheckReport(filepath.Join(dir, "x.txt"), report); err == nil {
+		t.Error("writeCheckReport(.txt) error = nil, want unknown format")
+	}
+	if _, err := os.Stat(filepath.Join(dir, "x.txt")); err == nil {
+		t.Error("an unknown format should not create the file")
+	}
+}
+
+func TestWriteSink(t *testing.T) {
+	dir := t.TempDir()
+	r := service.SinkResult{SessionUUID: "sess-1", Answer: "Pool exhausted."}
+
+	dest, err := writeSink(service.Sink{Kind: service.SinkFile, Target: filepath.Join(dir, "pm") + "/"}, r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := filepath.Join(dir, "pm", "sess-1.md"); dest != want {
+		t.Errorf("dest = %q, want %q", dest, want)
+	}
+	if data, err := os.ReadFile(dest); err != nil || !strings.Contains(string(data), "Pool exhausted.") {
+		t.Errorf("file = %q, %v", data, err)
+	}
+
+	var received service.SinkResult
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, _ := io.ReadAll(req.Body)
+		_ = json.Unmarshal(body, &received)
+	}))
+	defer srv.Close()
+	if _, err := writeSink(service.Sink{Kind: service.SinkWebhook, Target: srv.URL}, r); err != nil {
+		t.Fatal(err)
+	}
+	if received.SessionUUID != "sess-1" || received.Answer != "Pool exhausted." {
+		t.Errorf("received = %+v", received)
+	}
+}
+
+func TestParseSinksNeedsJiraSite(t *testing.T) {
+	t.Setenv("JIRA_URL", "")
+	if _, err := parseSinks([]string{"jira://OPS"}); err == nil || !strings.Contains(err.Error(), "JIRA_URL") {
+		t.Errorf("parseSinks() error = %v, want one naming JIRA_URL", err)
+	}
+	if _, err := parseSinks([]string{"file://out/"}); err != nil {
+		t.Errorf("parseSinks(file) error = %v", err)
+	}
+}
+
+func TestWatchQueueRespectsLiveWatch(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("SNAP_USER_COMMON", "")
+	activeProfile = ""
+
+	q, _ := config.LoadWatchQueue("")
+	q.Add(config.WatchItem{SessionUUID: "s1", ProjectUUID: "p"})
+	q.Find("s1").Status = config.WatchRunning
+	if err := q.Save(); err != nil {
+		t.Fatal(err)
+	}
+	lock, err := config.LockWatchQueue("")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := cmdWatchQueue(nil); err != nil {
+		t.Fatal(err)
+	}
+	if q, _ := config.LoadWatchQueue(""); q.Find("s1").Status != config.WatchRunning {
+		t.Error("listing the queue changed a live watch's entry")
+	}
+	if err := cmdWatchQueue([]string{"clear"}); err == nil {
+		t.Error("clear should refuse while a watch holds the queue")
+	}
+
+	lock.Unlock()
+	if err := cmdWatchQueue([]string{"clear"}); err != nil {
+		t.Fatal(err)
+	}
+	if q, _ := config.LoadWatchQueue(""); len(q.Items) != 0 {
+		t.Errorf("queue after clear = %+v", q.Items)
+	}
+}
+
+func TestInstructionUpdateNeedsYes(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("SNAP_USER_COMMON", "")
+	var updates []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		switch {
+		case req.Method == "GET" && req.URL.Path == "/v1/instruction":
+			fmt.Fprint(w, `{"instructions":[{"uuid":"in-1","name":"Runbook","content":"old line\n"}]}`)
+		case req.Method == "PUT":
+			updates = append(updates, strings.TrimPrefix(req.URL.Path, "/v1/instruction/"))
+			fmt.Fprint(w, `{"instruction":{"uuid":"in-1"}}`)
+		default:
+			http.NotFound(w, req)
+		}
+	}))
+	defer srv.Close()
+	cfg := &config.Config{Server: srv.URL, Token: "tok", ProjectID: "proj-1"}
+
+	tests := []struct {
+		name        string
+		args        []string
+		wantUpdated bool
+	}{
+		{"content change previews", []string{"in-1", "--content", "new line"}, false},
+		{"--yes applies", []string{"in-1", "--content", "new line", "--yes"}, true},
+		{"unchanged content", []string{"in-1", "--content", "old line"}, false},
+		{"rename only", []string{"in-1", "--name", "Ops runbook"}, true},
+		{"outside the active project", []string{"in-9", "--content", "new line"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			updates = nil
+			if err := cmdInstructionUpdate(cfg, tt.args); err != nil {
+				t.Fatal(err)
+			}
+			if got := len(updates) == 1 && updates[0] == tt.args[0]; got != tt.wantUpdated {
+				t.Errorf("updated = %v (%v), want %v", got, updates, tt.wantUpdated)
+			}
+		})
+	}
+}